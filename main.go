@@ -3,53 +3,107 @@ package main
 import (
 	"fmt"
 	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/export"
+	"github.com/df-mc/dragonfly/server/logging"
 	"github.com/df-mc/dragonfly/server/player/chat"
-	"github.com/pelletier/go-toml"
-	"github.com/sirupsen/logrus"
+	"github.com/df-mc/dragonfly/server/plugin"
+	"github.com/df-mc/dragonfly/server/script"
 	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	log := logrus.New()
-	log.Formatter = &logrus.TextFormatter{ForceColors: true}
-	log.Level = logrus.DebugLevel
-
 	chat.Global.Subscribe(chat.StdoutSubscriber{})
 
-	conf, err := readConfig(log)
+	log, uc, conf, err := readConfig()
+	if err != nil {
+		logging.New(logging.TextSink(os.Stderr), logging.LevelInfo).Fatalf("%v", err)
+	}
+	exporter, err := newExporter(uc, log)
 	if err != nil {
-		log.Fatalln(err)
+		log.Fatalf("%v", err)
 	}
 
 	srv := conf.New()
 	srv.CloseOnProgramEnd()
+	go srv.HandleConsole(os.Stdin, os.Stdout)
+	go reloadOnSignal(srv, log)
+	if exporter != nil {
+		exporter.Attach(srv, conf.Moderation)
+	}
+
+	plugins := plugin.NewManager(srv, log)
+	if err := plugins.LoadRegistered(); err != nil {
+		log.Errorf("%v", err)
+	}
+	if err := plugins.LoadDir("plugins"); err != nil {
+		log.Errorf("%v", err)
+	}
+	if err := plugins.Load(&script.Plugin{Dir: "scripts"}); err != nil {
+		log.Errorf("%v", err)
+	}
 
 	srv.Listen()
 	for srv.Accept(nil) {
 	}
+	if err := plugins.Close(); err != nil {
+		log.Errorf("%v", err)
+	}
+	if exporter != nil {
+		_ = exporter.Close()
+	}
 }
 
 // readConfig reads the configuration from the config.toml file, or creates the
-// file if it does not yet exist.
-func readConfig(log server.Logger) (server.Config, error) {
-	c := server.DefaultConfig()
-	var zero server.Config
-	if _, err := os.Stat("config.toml"); os.IsNotExist(err) {
-		data, err := toml.Marshal(c)
-		if err != nil {
-			return zero, fmt.Errorf("encode default config: %v", err)
-		}
-		if err := os.WriteFile("config.toml", data, 0644); err != nil {
-			return zero, fmt.Errorf("create default config: %v", err)
-		}
-		return c.Config(log)
+// file if it does not yet exist. The logger returned writes to stderr using the
+// log settings of the configuration.
+func readConfig() (*logging.Logger, server.UserConfig, server.Config, error) {
+	uc, err := server.ReadUserConfig("config.toml")
+	if err != nil {
+		return nil, uc, server.Config{}, err
 	}
-	data, err := os.ReadFile("config.toml")
+	log, err := uc.Logger(os.Stderr)
 	if err != nil {
-		return zero, fmt.Errorf("read config: %v", err)
+		return nil, uc, server.Config{}, err
+	}
+	conf, err := uc.Config(log)
+	return log, uc, conf, err
+}
+
+// newExporter creates an export.Exporter posting events to the webhooks set in
+// the configuration. If no webhooks are set, nil is returned.
+func newExporter(uc server.UserConfig, log *logging.Logger) (*export.Exporter, error) {
+	if len(uc.Export.Webhooks) == 0 {
+		return nil, nil
 	}
-	if err := toml.Unmarshal(data, &c); err != nil {
-		return zero, fmt.Errorf("decode config: %v", err)
+	types, err := export.ParseTypes(uc.Export.Events)
+	if err != nil {
+		return nil, fmt.Errorf("parse export events: %w", err)
+	}
+	conf := export.Config{Log: log.Subsystem("export"), Types: types}
+	for _, u := range uc.Export.Webhooks {
+		if uc.Export.Format == "discord" {
+			conf.Targets = append(conf.Targets, export.DiscordWebhook{URL: u})
+			continue
+		}
+		conf.Targets = append(conf.Targets, export.Webhook{URL: u, Format: export.JSON})
+	}
+	return conf.New(), nil
+}
+
+// reloadOnSignal reloads the configuration of the server from the config.toml
+// file every time the process receives a SIGHUP signal.
+func reloadOnSignal(srv *server.Server, log server.Logger) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	for range c {
+		uc, err := server.ReadUserConfig("config.toml")
+		if err == nil {
+			err = srv.Reload(uc)
+		}
+		if err != nil {
+			log.Errorf("reload config: %v", err)
+		}
 	}
-	return c.Config(log)
 }
@@ -0,0 +1,287 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/moderation"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// API configures the HTTP admin API of a Server, which allows web panels and other tools to manage the Server
+// using JSON requests. Every request must carry the Token in an 'Authorization: Bearer <token>' header.
+//
+// The following endpoints are served:
+//
+//	GET  /api/status                  Name, player counts, TPS and MSPT of the server.
+//	GET  /api/players                 Players currently online.
+//	POST /api/players/<name>/kick     Kicks a player. Body: {"reason": "..."}.
+//	POST /api/players/<name>/ban      Bans a player. Body: {"reason": "...", "duration": "1d"}.
+//	POST /api/broadcast               Broadcasts a chat message. Body: {"message": "..."}.
+//	GET  /api/worlds                  Information on the worlds of the server.
+//	POST /api/save                    Saves all player data and worlds.
+type API struct {
+	// Address is the TCP address on which the API is served, such as ':8080'. If empty, the API is disabled.
+	Address string
+	// Token is the token that requests must be authorised with. The API is not served if no Token is set.
+	Token string
+}
+
+// serveAPI serves the HTTP admin API on the address of the API config of the Server, until the Server is closed.
+func (srv *Server) serveAPI() {
+	conf := srv.conf.API
+	if conf.Token == "" {
+		srv.conf.Log.Errorf("api: no token set, not listening on %v", conf.Address)
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/status", srv.apiStatus)
+	mux.HandleFunc("/api/players", srv.apiPlayers)
+	mux.HandleFunc("/api/players/", srv.apiPlayerAction)
+	mux.HandleFunc("/api/broadcast", srv.apiBroadcast)
+	mux.HandleFunc("/api/worlds", srv.apiWorlds)
+	mux.HandleFunc("/api/save", srv.apiSave)
+	s := &http.Server{Addr: conf.Address, Handler: apiAuth(conf.Token, mux), ReadHeaderTimeout: time.Second * 5}
+
+	go func() {
+		<-srv.closing
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		_ = s.Shutdown(ctx)
+	}()
+	srv.conf.Log.Infof("Serving admin API on %v/api.", conf.Address)
+	if err := s.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		srv.conf.Log.Errorf("serve api: %v", err)
+	}
+}
+
+// apiMaxBody is the maximum size in bytes of the body of a request to the API.
+const apiMaxBody = 1 << 20
+
+// apiAuth wraps around the http.Handler passed, rejecting requests that are not authorised with the token
+// passed.
+func apiAuth(token string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if !strings.HasPrefix(header, "Bearer ") {
+			apiError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+		got := strings.TrimPrefix(header, "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			apiError(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// apiStatus handles requests to /api/status.
+func (srv *Server) apiStatus(w http.ResponseWriter, r *http.Request) {
+	if !apiMethod(w, r, http.MethodGet) {
+		return
+	}
+	apiJSON(w, map[string]any{
+		"name":        srv.reloadable().Name,
+		"players":     len(srv.Players()),
+		"max_players": srv.MaxPlayerCount(),
+		"tps":         srv.TPS(),
+		"mspt":        srv.MSPT(),
+	})
+}
+
+// apiPlayer is the JSON representation of a player returned by /api/players.
+type apiPlayer struct {
+	Name      string     `json:"name"`
+	UUID      string     `json:"uuid"`
+	XUID      string     `json:"xuid,omitempty"`
+	Address   string     `json:"address,omitempty"`
+	LatencyMS int64      `json:"latency_ms"`
+	World     string     `json:"world"`
+	Position  [3]float64 `json:"position"`
+	Health    float64    `json:"health"`
+}
+
+// apiPlayers handles requests to /api/players.
+func (srv *Server) apiPlayers(w http.ResponseWriter, r *http.Request) {
+	if !apiMethod(w, r, http.MethodGet) {
+		return
+	}
+	players := srv.Players()
+	list := make([]apiPlayer, 0, len(players))
+	for _, p := range players {
+		e := apiPlayer{
+			Name:      p.Name(),
+			UUID:      p.UUID().String(),
+			XUID:      p.XUID(),
+			LatencyMS: p.Latency().Milliseconds(),
+			Position:  p.Position(),
+			Health:    p.Health(),
+		}
+		if addr := p.Addr(); addr != nil {
+			e.Address = addr.String()
+		}
+		if pw := p.World(); pw != nil {
+			e.World = pw.Name()
+		}
+		list = append(list, e)
+	}
+	apiJSON(w, list)
+}
+
+// apiPlayerAction handles requests to /api/players/<name>/<action>.
+func (srv *Server) apiPlayerAction(w http.ResponseWriter, r *http.Request) {
+	if !apiMethod(w, r, http.MethodPost) {
+		return
+	}
+	name, action, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/players/"), "/")
+	if !ok {
+		apiError(w, http.StatusNotFound, "unknown endpoint")
+		return
+	}
+	p, ok := srv.PlayerByName(name)
+	if !ok {
+		apiError(w, http.StatusNotFound, "player not online")
+		return
+	}
+	var body struct {
+		Reason   string `json:"reason"`
+		Duration string `json:"duration"`
+	}
+	if !apiDecode(w, r, &body) {
+		return
+	}
+	switch action {
+	case "kick":
+		p.Disconnect(body.Reason)
+	case "ban":
+		if srv.conf.Moderation == nil {
+			apiError(w, http.StatusNotImplemented, "moderation is disabled")
+			return
+		}
+		e := moderation.Entry{Reason: body.Reason, Source: "API", Created: time.Now()}
+		if body.Duration != "" {
+			d, err := moderation.ParseDuration(body.Duration)
+			if err != nil {
+				apiError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			e.Expires = e.Created.Add(d)
+		}
+		if err := srv.conf.Moderation.Ban(p, e); err != nil {
+			apiError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	default:
+		apiError(w, http.StatusNotFound, "unknown endpoint")
+		return
+	}
+	srv.conf.Log.Infof("API: %v %v.", action, p.Name())
+	apiJSON(w, map[string]any{"ok": true})
+}
+
+// apiBroadcast handles requests to /api/broadcast.
+func (srv *Server) apiBroadcast(w http.ResponseWriter, r *http.Request) {
+	if !apiMethod(w, r, http.MethodPost) {
+		return
+	}
+	var body struct {
+		Message string `json:"message"`
+	}
+	if !apiDecode(w, r, &body) {
+		return
+	}
+	if body.Message == "" {
+		apiError(w, http.StatusBadRequest, "message is empty")
+		return
+	}
+	_, _ = fmt.Fprintln(chat.Global, body.Message)
+	apiJSON(w, map[string]any{"ok": true})
+}
+
+// apiWorld is the JSON representation of a world returned by /api/worlds.
+type apiWorld struct {
+	Name      string  `json:"name"`
+	Dimension string  `json:"dimension"`
+	Time      int     `json:"time"`
+	Spawn     [3]int  `json:"spawn"`
+	TPS       float64 `json:"tps"`
+	MSPT      float64 `json:"mspt"`
+	Chunks    int     `json:"chunks"`
+	Entities  int     `json:"entities"`
+}
+
+// apiWorlds handles requests to /api/worlds.
+func (srv *Server) apiWorlds(w http.ResponseWriter, r *http.Request) {
+	if !apiMethod(w, r, http.MethodGet) {
+		return
+	}
+	var list []apiWorld
+	for _, wo := range []*world.World{srv.world, srv.nether, srv.end} {
+		list = append(list, apiWorld{
+			Name:      wo.Name(),
+			Dimension: fmt.Sprint(wo.Dimension()),
+			Time:      wo.Time(),
+			Spawn:     wo.Spawn(),
+			TPS:       wo.TPS(),
+			MSPT:      wo.MSPT(),
+			Chunks:    wo.ChunkCount(),
+			Entities:  wo.EntityCount(),
+		})
+	}
+	apiJSON(w, list)
+}
+
+// apiSave handles requests to /api/save.
+func (srv *Server) apiSave(w http.ResponseWriter, r *http.Request) {
+	if !apiMethod(w, r, http.MethodPost) {
+		return
+	}
+	srv.Save()
+	apiJSON(w, map[string]any{"ok": true})
+}
+
+// apiMethod checks if the request passed uses the method passed, writing an error if not.
+func apiMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		apiError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return false
+	}
+	return true
+}
+
+// apiDecode decodes the JSON body of the request passed into v, writing an error if it could not be decoded.
+// An empty body is accepted.
+func apiDecode(w http.ResponseWriter, r *http.Request, v any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, apiMaxBody)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			apiError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return false
+		}
+		apiError(w, http.StatusBadRequest, "decode body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// apiJSON writes v as JSON to the http.ResponseWriter passed.
+func apiJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// apiError writes an error with the status code and message passed to the http.ResponseWriter passed.
+func apiError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,179 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// Bamboo is a fast-growing plant found in jungles. Bamboo is placed as a BambooSapling, which grows into a stalk
+// of bamboo of up to 16 blocks tall.
+type Bamboo struct {
+	transparent
+
+	// Thick specifies if the stalk of the bamboo is thick. Bamboo grows thicker as its stalk grows taller.
+	Thick bool
+	// LeafSize is the size of the leaves on the bamboo. The top blocks of a stalk carry leaves.
+	LeafSize BambooLeafSize
+	// Ready is true if the stalk of bamboo has stopped growing.
+	Ready bool
+}
+
+// UseOnBlock ...
+func (b Bamboo) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, b)
+	if !used {
+		return false
+	}
+	switch below := w.Block(pos.Side(cube.FaceDown)).(type) {
+	case Bamboo:
+		place(w, pos, Bamboo{Thick: below.Thick}, user, ctx)
+	case BambooSapling:
+		place(w, pos, Bamboo{}, user, ctx)
+	default:
+		// Bamboo placed on the ground is placed as a sapling first.
+		if !supportsVegetation(b, below) {
+			return false
+		}
+		place(w, pos, BambooSapling{}, user, ctx)
+	}
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (b Bamboo) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !bambooSupported(pos, w) {
+		w.SetBlock(pos, nil, nil)
+		w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
+		dropItem(w, item.NewStack(Bamboo{}, 1), pos.Vec3Centre())
+	}
+}
+
+// RandomTick ...
+func (b Bamboo) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
+	if b.Ready || w.Light(pos.Side(cube.FaceUp)) < 9 || r.Intn(3) != 0 {
+		return
+	}
+	b.grow(pos, w, r)
+}
+
+// BoneMeal ...
+func (b Bamboo) BoneMeal(pos cube.Pos, w *world.World) (success bool) {
+	for above, ok := w.Block(pos.Side(cube.FaceUp)).(Bamboo); ok; above, ok = w.Block(pos.Side(cube.FaceUp)).(Bamboo) {
+		pos, b = pos.Side(cube.FaceUp), above
+	}
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for i, n := 0, 1+r.Intn(2); i < n && !b.Ready && b.grow(pos, w, r); i++ {
+		success = true
+		above, ok := w.Block(pos.Side(cube.FaceUp)).(Bamboo)
+		if !ok {
+			break
+		}
+		pos, b = pos.Side(cube.FaceUp), above
+	}
+	return success
+}
+
+// grow grows the bamboo at the position passed by one block, if it is the top of the stalk and the stalk has not
+// yet reached its maximum height. The leaves of the stalk are moved up along with its top.
+func (b Bamboo) grow(pos cube.Pos, w *world.World, r *rand.Rand) bool {
+	above := pos.Side(cube.FaceUp)
+	if _, ok := w.Block(above).(Air); !ok {
+		return false
+	}
+	height := b.height(pos, w)
+	if height >= 16 {
+		return false
+	}
+	top := Bamboo{Thick: b.Thick || height >= 3, LeafSize: SmallBambooLeaves()}
+	if height >= 2 {
+		top.LeafSize = LargeBambooLeaves()
+	}
+	top.Ready = height+1 == 16 || (height >= 10 && r.Float64() < 0.25)
+	if !grow(w, above, top) {
+		return false
+	}
+
+	if height >= 2 && b.LeafSize != SmallBambooLeaves() {
+		b.LeafSize = SmallBambooLeaves()
+		w.SetBlock(pos, b, nil)
+	}
+	if below, ok := w.Block(pos.Side(cube.FaceDown)).(Bamboo); ok && below.LeafSize != NoBambooLeaves() {
+		below.LeafSize = NoBambooLeaves()
+		w.SetBlock(pos.Side(cube.FaceDown), below, nil)
+	}
+	return true
+}
+
+// height returns the height of the stalk of bamboo from its bottom up to the position passed.
+func (Bamboo) height(pos cube.Pos, w *world.World) (height int) {
+	for _, ok := w.Block(pos).(Bamboo); ok; _, ok = w.Block(pos).(Bamboo) {
+		height++
+		pos = pos.Side(cube.FaceDown)
+	}
+	return height
+}
+
+// bambooSupported checks if bamboo or a bamboo sapling at the position passed is supported by the block below it.
+func bambooSupported(pos cube.Pos, w *world.World) bool {
+	switch below := w.Block(pos.Side(cube.FaceDown)).(type) {
+	case Bamboo, BambooSapling:
+		return true
+	default:
+		return supportsVegetation(Bamboo{}, below)
+	}
+}
+
+// SideClosed ...
+func (Bamboo) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// BreakInfo ...
+func (b Bamboo) BreakInfo() BreakInfo {
+	return newBreakInfo(1, alwaysHarvestable, axeEffective, oneOf(Bamboo{}))
+}
+
+// FuelInfo ...
+func (Bamboo) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 5 / 2)
+}
+
+// Model ...
+func (Bamboo) Model() world.BlockModel {
+	return model.Bamboo{}
+}
+
+// CompostChance ...
+func (Bamboo) CompostChance() float64 {
+	return 0.3
+}
+
+// EncodeItem ...
+func (Bamboo) EncodeItem() (name string, meta int16) {
+	return "minecraft:bamboo", 0
+}
+
+// EncodeBlock ...
+func (b Bamboo) EncodeBlock() (name string, properties map[string]any) {
+	thickness := "thin"
+	if b.Thick {
+		thickness = "thick"
+	}
+	return "minecraft:bamboo", map[string]any{"bamboo_leaf_size": b.LeafSize.String(), "bamboo_stalk_thickness": thickness, "age_bit": b.Ready}
+}
+
+// allBamboo returns all possible states of a bamboo block.
+func allBamboo() (bamboo []world.Block) {
+	for _, size := range BambooLeafSizes() {
+		for _, thick := range []bool{false, true} {
+			bamboo = append(bamboo, Bamboo{Thick: thick, LeafSize: size}, Bamboo{Thick: thick, LeafSize: size, Ready: true})
+		}
+	}
+	return
+}
@@ -0,0 +1,46 @@
+package block
+
+// BambooLeafSize represents the size of the leaves growing on a Bamboo stalk.
+type BambooLeafSize struct {
+	bambooLeafSize
+}
+
+// NoBambooLeaves is the leaf size of bamboo without any leaves.
+func NoBambooLeaves() BambooLeafSize {
+	return BambooLeafSize{0}
+}
+
+// SmallBambooLeaves is the leaf size of bamboo with small leaves.
+func SmallBambooLeaves() BambooLeafSize {
+	return BambooLeafSize{1}
+}
+
+// LargeBambooLeaves is the leaf size of bamboo with large leaves.
+func LargeBambooLeaves() BambooLeafSize {
+	return BambooLeafSize{2}
+}
+
+// BambooLeafSizes returns all possible BambooLeafSizes.
+func BambooLeafSizes() []BambooLeafSize {
+	return []BambooLeafSize{NoBambooLeaves(), SmallBambooLeaves(), LargeBambooLeaves()}
+}
+
+type bambooLeafSize uint8
+
+// Uint8 returns the BambooLeafSize as a uint8.
+func (b bambooLeafSize) Uint8() uint8 {
+	return uint8(b)
+}
+
+// String returns the BambooLeafSize as a string.
+func (b bambooLeafSize) String() string {
+	switch b {
+	case 0:
+		return "no_leaves"
+	case 1:
+		return "small_leaves"
+	case 2:
+		return "large_leaves"
+	}
+	panic("should never happen")
+}
@@ -0,0 +1,83 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"math/rand"
+)
+
+// BambooSapling is the first stage of growth of Bamboo. It is placed when bamboo is placed on the ground and grows
+// into a stalk of bamboo.
+type BambooSapling struct {
+	empty
+	transparent
+
+	// Ready is unused by the server, but is part of the state of the block.
+	Ready bool
+}
+
+// NeighbourUpdateTick ...
+func (b BambooSapling) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !bambooSupported(pos, w) {
+		w.SetBlock(pos, nil, nil)
+		w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
+		dropItem(w, item.NewStack(Bamboo{}, 1), pos.Vec3Centre())
+	}
+}
+
+// RandomTick ...
+func (b BambooSapling) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
+	if w.Light(pos.Side(cube.FaceUp)) >= 9 && r.Intn(3) == 0 {
+		b.grow(pos, w)
+	}
+}
+
+// BoneMeal ...
+func (b BambooSapling) BoneMeal(pos cube.Pos, w *world.World) bool {
+	return b.grow(pos, w)
+}
+
+// grow grows the sapling into a stalk of bamboo of two blocks tall.
+func (b BambooSapling) grow(pos cube.Pos, w *world.World) bool {
+	above := pos.Side(cube.FaceUp)
+	if _, ok := w.Block(above).(Air); !ok {
+		return false
+	}
+	if !grow(w, above, Bamboo{LeafSize: SmallBambooLeaves()}) {
+		return false
+	}
+	w.SetBlock(pos, Bamboo{}, nil)
+	return true
+}
+
+// Pick ...
+func (BambooSapling) Pick() item.Stack {
+	return item.NewStack(Bamboo{}, 1)
+}
+
+// HasLiquidDrops ...
+func (BambooSapling) HasLiquidDrops() bool {
+	return true
+}
+
+// SideClosed ...
+func (BambooSapling) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// BreakInfo ...
+func (BambooSapling) BreakInfo() BreakInfo {
+	return newBreakInfo(1, alwaysHarvestable, axeEffective, oneOf(Bamboo{}))
+}
+
+// EncodeBlock ...
+func (b BambooSapling) EncodeBlock() (name string, properties map[string]any) {
+	return "minecraft:bamboo_sapling", map[string]any{"sapling_type": "oak", "age_bit": b.Ready}
+}
+
+// allBambooSaplings returns all possible states of a bamboo sapling block.
+func allBambooSaplings() []world.Block {
+	return []world.Block{BambooSapling{}, BambooSapling{Ready: true}}
+}
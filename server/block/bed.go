@@ -0,0 +1,215 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Bed is a block that allows players to sleep through the night and to set their spawn point. Beds consist of
+// two parts: The foot, which is the part placed at the position clicked, and the head.
+type Bed struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Colour is the colour of the bed.
+	Colour item.Colour
+	// Facing is the direction that the bed is facing, which is the direction from the foot to the head.
+	Facing cube.Direction
+	// Head is true if the block is the head part of the bed.
+	Head bool
+	// Occupied is true if a player is currently sleeping in the bed.
+	Occupied bool
+}
+
+// sleeper is an item.User that is able to sleep in a Bed and set its spawn point at it.
+type sleeper interface {
+	item.User
+	world.Sleeper
+	Message(a ...any)
+	SpawnPoint() cube.Pos
+	SetSpawnPoint(pos cube.Pos)
+	Sleep(pos cube.Pos)
+}
+
+// MaxCount always returns 1.
+func (Bed) MaxCount() int {
+	return 1
+}
+
+// Model ...
+func (Bed) Model() world.BlockModel {
+	return model.Bed{}
+}
+
+// SideClosed ...
+func (Bed) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// BreakInfo ...
+func (b Bed) BreakInfo() BreakInfo {
+	return newBreakInfo(0.2, alwaysHarvestable, nothingEffective, oneOf(Bed{Colour: b.Colour}))
+}
+
+// UseOnBlock places the Bed as two blocks: The foot at the position clicked and the head in the direction
+// that the user is facing.
+func (b Bed) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	if pos, _, used = firstReplaceable(w, pos, face, b); !used {
+		return false
+	}
+	b.Facing = user.Rotation().Direction()
+	headPos := pos.Side(b.Facing.Face())
+	if !replaceableWith(w, headPos, b) || !bedSupported(pos, w) || !bedSupported(headPos, w) {
+		return false
+	}
+
+	ctx.IgnoreBBox = true
+	place(w, pos, b, user, ctx)
+	place(w, headPos, Bed{Colour: b.Colour, Facing: b.Facing, Head: true}, user, ctx)
+	ctx.SubtractFromCount(1)
+	return placed(ctx)
+}
+
+// bedSupported checks if the block below the position passed has a solid top face, so that a part of a Bed may
+// be placed at the position.
+func bedSupported(pos cube.Pos, w *world.World) bool {
+	below := pos.Side(cube.FaceDown)
+	return w.Block(below).Model().FaceSolid(below, cube.FaceUp, w)
+}
+
+// NeighbourUpdateTick removes the Bed if its other part was removed.
+func (b Bed) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if _, ok := w.Block(b.otherPart(pos)).(Bed); !ok {
+		w.SetBlock(pos, nil, nil)
+	}
+}
+
+// otherPart returns the position of the other part of the Bed at the position passed.
+func (b Bed) otherPart(pos cube.Pos) cube.Pos {
+	if b.Head {
+		return pos.Side(b.Facing.Opposite().Face())
+	}
+	return pos.Side(b.Facing.Face())
+}
+
+// head returns the head part of the Bed at the position passed, together with its position. False is returned
+// if the head could not be found.
+func (b Bed) head(pos cube.Pos, w *world.World) (Bed, cube.Pos, bool) {
+	if b.Head {
+		return b, pos, true
+	}
+	headPos := b.otherPart(pos)
+	head, ok := w.Block(headPos).(Bed)
+	return head, headPos, ok && head.Head
+}
+
+// Activate makes the user set its spawn point at the Bed and attempt to sleep in it. Beds explode when used in
+// any dimension other than the overworld.
+func (b Bed) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User, _ *item.UseContext) bool {
+	s, ok := u.(sleeper)
+	if !ok {
+		return false
+	}
+	head, headPos, ok := b.head(pos, w)
+	if !ok {
+		return false
+	}
+	if w.Dimension() != world.Overworld {
+		w.SetBlock(headPos, nil, nil)
+		w.SetBlock(head.otherPart(headPos), nil, nil)
+		ExplosionConfig{Size: 5, SpawnFire: true}.Explode(w, headPos.Vec3Centre())
+		return true
+	}
+	if _, sleeping := s.Sleeping(); sleeping {
+		return true
+	}
+	if s.SpawnPoint() != headPos {
+		s.SetSpawnPoint(headPos)
+		s.Message("Respawn point set")
+	}
+
+	if t := w.Time() % 24000; (t < 12542 || t > 23459) && !w.ThunderingAt(headPos) {
+		s.Message("You can only sleep at night and during thunderstorms")
+		return true
+	}
+	if head.Occupied {
+		s.Message("This bed is occupied")
+		return true
+	}
+	s.Sleep(headPos)
+	return true
+}
+
+// CanRespawnOn returns true if the Bed is in the overworld.
+func (Bed) CanRespawnOn(_ cube.Pos, w *world.World) bool {
+	return w.Dimension() == world.Overworld
+}
+
+// SafeSpawn returns a free position around either part of the Bed with a solid block below it.
+func (b Bed) SafeSpawn(pos cube.Pos, w *world.World) (mgl64.Vec3, bool) {
+	for _, part := range []cube.Pos{pos, b.otherPart(pos)} {
+		for x := -1; x <= 1; x++ {
+			for z := -1; z <= 1; z++ {
+				candidate := part.Add(cube.Pos{x, 0, z})
+				if _, ok := w.Block(candidate).(Bed); ok {
+					continue
+				}
+				if bedSupported(candidate, w) && bedFree(candidate, w) && bedFree(candidate.Side(cube.FaceUp), w) {
+					return candidate.Vec3Middle(), true
+				}
+			}
+		}
+	}
+	return mgl64.Vec3{}, false
+}
+
+// bedFree checks if the block at the position passed has no collision box.
+func bedFree(pos cube.Pos, w *world.World) bool {
+	return len(w.Block(pos).Model().BBox(pos, w)) == 0
+}
+
+// RespawnOn ...
+func (Bed) RespawnOn(cube.Pos, item.User, *world.World) {}
+
+// EncodeItem ...
+func (b Bed) EncodeItem() (name string, meta int16) {
+	return "minecraft:bed", int16(b.Colour.Uint8())
+}
+
+// EncodeBlock ...
+func (b Bed) EncodeBlock() (name string, properties map[string]any) {
+	return "minecraft:bed", map[string]any{
+		"direction":      int32(horizontalDirection(b.Facing)),
+		"head_piece_bit": b.Head,
+		"occupied_bit":   b.Occupied,
+	}
+}
+
+// EncodeNBT ...
+func (b Bed) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":    "Bed",
+		"color": b.Colour.Uint8(),
+	}
+}
+
+// DecodeNBT ...
+func (b Bed) DecodeNBT(data map[string]any) any {
+	b.Colour = item.Colours()[nbtconv.Uint8(data, "color")%16]
+	return b
+}
+
+// allBeds returns all possible bed states.
+func allBeds() (beds []world.Block) {
+	for _, d := range cube.Directions() {
+		beds = append(beds, Bed{Facing: d})
+		beds = append(beds, Bed{Facing: d, Head: true})
+		beds = append(beds, Bed{Facing: d, Occupied: true})
+		beds = append(beds, Bed{Facing: d, Head: true, Occupied: true})
+	}
+	return
+}
@@ -27,7 +27,7 @@ func (b BeetrootSeeds) BoneMeal(pos cube.Pos, w *world.World) bool {
 	}
 	if rand.Float64() < 0.75 {
 		b.Growth++
-		w.SetBlock(pos, b, nil)
+		grow(w, pos, b)
 		return true
 	}
 	return false
@@ -50,11 +50,11 @@ func (b BeetrootSeeds) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w
 
 // BreakInfo ...
 func (b BeetrootSeeds) BreakInfo() BreakInfo {
-	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(item.Tool, []item.Enchantment) []item.Stack {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(_ item.Tool, enchantments []item.Enchantment) []item.Stack {
 		if b.Growth < 7 {
 			return []item.Stack{item.NewStack(b, 1)}
 		}
-		return []item.Stack{item.NewStack(item.Beetroot{}, 1), item.NewStack(b, rand.Intn(4)+1)}
+		return []item.Stack{item.NewStack(item.Beetroot{}, 1), item.NewStack(b, b.harvestCount(0, enchantments))}
 	})
 }
 
@@ -75,7 +75,7 @@ func (b BeetrootSeeds) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 		w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
 	} else if b.Growth < 7 && r.Intn(3) > 0 && r.Float64() <= b.CalculateGrowthChance(pos, w) {
 		b.Growth++
-		w.SetBlock(pos, b, nil)
+		grow(w, pos, b)
 	}
 }
 
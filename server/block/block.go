@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
@@ -73,6 +74,18 @@ type EntityInsider interface {
 	EntityInside(pos cube.Pos, w *world.World, e world.Entity)
 }
 
+// RespawnBlock represents a block that a player may set its spawn point at, such as a bed or a respawn anchor.
+// Players respawn at a RespawnBlock as long as it remains valid.
+type RespawnBlock interface {
+	// CanRespawnOn checks if a player may respawn at the block in the world.World passed.
+	CanRespawnOn(pos cube.Pos, w *world.World) bool
+	// SafeSpawn returns a position near the block at which a player may safely respawn. If no such position
+	// exists, for example because the block is obstructed, false is returned.
+	SafeSpawn(pos cube.Pos, w *world.World) (mgl64.Vec3, bool)
+	// RespawnOn is called when a player respawns at the block.
+	RespawnOn(pos cube.Pos, u item.User, w *world.World)
+}
+
 // Frictional represents a block that may have a custom friction value, friction is used for entity drag when the
 // entity is on ground. If a block does not implement this interface, it should be assumed that its friction is 0.6.
 type Frictional interface {
@@ -80,6 +93,13 @@ type Frictional interface {
 	Friction() float64
 }
 
+// RedstoneSource represents a block that is able to emit a redstone signal, such as a sculk sensor.
+type RedstoneSource interface {
+	// RedstonePower returns the strength of the redstone signal, ranging from 0 to 15, that the block at the
+	// position passed emits towards the face passed.
+	RedstonePower(pos cube.Pos, face cube.Face, w *world.World) int
+}
+
 func calculateFace(user item.User, placePos cube.Pos) cube.Face {
 	userPos := user.Position()
 	pos := cube.PosFromVec3(userPos)
@@ -165,6 +185,17 @@ func placed(ctx *item.UseContext) bool {
 	return ctx.CountSub > 0
 }
 
+// grow replaces the block at the position passed with newBlock as the result of a plant growing. The world.Handler
+// of the world is called first, and false is returned if it cancelled the growth.
+func grow(w *world.World, pos cube.Pos, newBlock world.Block) bool {
+	ctx := event.C()
+	if w.Handler().HandleBlockGrow(ctx, pos, newBlock); ctx.Cancelled() {
+		return false
+	}
+	w.SetBlock(pos, newBlock, nil)
+	return true
+}
+
 // boolByte returns 1 if the bool passed is true, or 0 if it is false.
 func boolByte(b bool) uint8 {
 	if b {
@@ -246,6 +277,12 @@ type livingEntity interface {
 	Hurt(damage float64, src world.DamageSource) (n float64, vulnerable bool)
 }
 
+// freezingEntity represents an entity that freezes while inside powder snow.
+type freezingEntity interface {
+	// Freeze freezes the entity for one tick.
+	Freeze()
+}
+
 // flammableEntity ...
 type flammableEntity interface {
 	// OnFireDuration returns duration of fire in ticks.
@@ -258,6 +295,10 @@ type flammableEntity interface {
 
 // dropItem ...
 func dropItem(w *world.World, it item.Stack, pos mgl64.Vec3) {
+	if it.Empty() {
+		// Some drops, such as the seeds of a crop, may have a count of 0.
+		return
+	}
 	create := w.EntityRegistry().Config().Item
 	w.AddEntity(create(it, pos, mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}))
 }
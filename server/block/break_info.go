@@ -205,6 +205,17 @@ func hasSilkTouch(enchantments []item.Enchantment) bool {
 	return false
 }
 
+// fortuneLevel returns the level of the fortune enchantment in the enchantments passed, or 0 if the enchantments do
+// not contain fortune.
+func fortuneLevel(enchantments []item.Enchantment) int {
+	for _, enchant := range enchantments {
+		if _, ok := enchant.Type().(enchantment.Fortune); ok {
+			return enchant.Level()
+		}
+	}
+	return 0
+}
+
 // silkTouchOneOf returns a drop function that returns 1x of the silk touch drop when silk touch exists, or 1x of the
 // normal drop when it does not.
 func silkTouchOneOf(normal, silkTouch world.Item) func(item.Tool, []item.Enchantment) []item.Stack {
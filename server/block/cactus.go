@@ -47,10 +47,10 @@ func (c Cactus) RandomTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
 		c.Age++
 	} else if c.Age == 15 {
 		c.Age = 0
-		if c.canGrowHere(pos.Side(cube.FaceDown), w, false) {
+		if c.canGrowHere(pos, w, true) {
 			for y := 1; y < 3; y++ {
 				if _, ok := w.Block(pos.Add(cube.Pos{0, y})).(Air); ok {
-					w.SetBlock(pos.Add(cube.Pos{0, y}), Cactus{Age: 0}, nil)
+					grow(w, pos.Add(cube.Pos{0, y}), Cactus{})
 					break
 				} else if _, ok := w.Block(pos.Add(cube.Pos{0, y})).(Cactus); !ok {
 					break
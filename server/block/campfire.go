@@ -0,0 +1,260 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// campfireCookTime is the time it takes for an item on a campfire to be cooked.
+const campfireCookTime = time.Second * 30
+
+// CampfireItem is an item that is cooking on a Campfire.
+type CampfireItem struct {
+	// Item is the item that is being cooked.
+	Item item.Stack
+	// Time is the remaining time until the item is cooked.
+	Time time.Duration
+}
+
+// Campfire is a block that may be used to cook up to four food items at once, without needing any fuel. Lit
+// campfires damage entities standing on them. A campfire placed on top of a hay bale emits signal smoke that
+// rises much higher than normal smoke.
+type Campfire struct {
+	transparent
+	bass
+	sourceWaterDisplacer
+
+	// Items holds the items cooking on the campfire. Each of the four slots cooks its item separately.
+	Items [4]CampfireItem
+	// Facing is the direction that the campfire is facing.
+	Facing cube.Direction
+	// Extinguished is true if the campfire was extinguished. Extinguished campfires do not cook items, nor do they
+	// damage entities standing on them. They may be lit again using flint and steel or a fire charge.
+	Extinguished bool
+	// Type is the type of fire of the campfire, which is either normal fire or soul fire.
+	Type FireType
+}
+
+// Model ...
+func (Campfire) Model() world.BlockModel {
+	return model.Campfire{}
+}
+
+// SideClosed ...
+func (Campfire) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// LightEmissionLevel ...
+func (c Campfire) LightEmissionLevel() uint8 {
+	if c.Extinguished {
+		return 0
+	}
+	return c.Type.LightLevel()
+}
+
+// BreakInfo ...
+func (c Campfire) BreakInfo() BreakInfo {
+	return newBreakInfo(2, alwaysHarvestable, axeEffective, func(_ item.Tool, enchantments []item.Enchantment) []item.Stack {
+		var drops []item.Stack
+		if hasSilkTouch(enchantments) {
+			drops = append(drops, item.NewStack(Campfire{Type: c.Type}, 1))
+		} else if c.Type == SoulFire() {
+			drops = append(drops, item.NewStack(SoulSoil{}, 1))
+		} else {
+			drops = append(drops, item.NewStack(item.Charcoal{}, 2))
+		}
+		for _, it := range c.Items {
+			if !it.Item.Empty() {
+				drops = append(drops, it.Item)
+			}
+		}
+		return drops
+	})
+}
+
+// UseOnBlock ...
+func (c Campfire) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, c)
+	if !used {
+		return
+	}
+	c.Facing = user.Rotation().Direction().Opposite()
+	c.Items = [4]CampfireItem{}
+	if liquid, ok := w.Liquid(pos); ok {
+		if _, water := liquid.(Water); water {
+			c.Extinguished = true
+		}
+	}
+
+	place(w, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// Activate ...
+func (c Campfire) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User, ctx *item.UseContext) bool {
+	held, _ := u.HeldItems()
+	s, ok := held.Item().(item.Smeltable)
+	if !ok || !s.SmeltInfo().Food {
+		return false
+	}
+	for i, it := range c.Items {
+		if it.Item.Empty() {
+			c.Items[i] = CampfireItem{Item: held.Grow(1 - held.Count()), Time: campfireCookTime}
+			ctx.SubtractFromCount(1)
+			w.SetBlock(pos, c, nil)
+			return true
+		}
+	}
+	return false
+}
+
+// Ignite lights the campfire if it was extinguished and is not waterlogged.
+func (c Campfire) Ignite(pos cube.Pos, w *world.World) bool {
+	if !c.Extinguished {
+		return false
+	}
+	if liquid, ok := w.Liquid(pos); ok {
+		if _, water := liquid.(Water); water {
+			return false
+		}
+	}
+	c.Extinguished = false
+	w.SetBlock(pos, c, nil)
+	return true
+}
+
+// Shovel extinguishes the campfire if it is lit.
+func (c Campfire) Shovel() (world.Block, bool) {
+	if c.Extinguished {
+		return nil, false
+	}
+	c.Extinguished = true
+	return c, true
+}
+
+// extinguish extinguishes the campfire at the position passed and plays the corresponding sound.
+func (c Campfire) extinguish(pos cube.Pos, w *world.World) {
+	c.Extinguished = true
+	w.SetBlock(pos, c, nil)
+	w.PlaySound(pos.Vec3Centre(), sound.FireExtinguish{})
+}
+
+// NeighbourUpdateTick ...
+func (c Campfire) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if c.Extinguished {
+		return
+	}
+	if liquid, ok := w.Liquid(pos); ok {
+		if _, water := liquid.(Water); water {
+			c.extinguish(pos, w)
+		}
+	}
+}
+
+// EntityInside ...
+func (c Campfire) EntityInside(_ cube.Pos, _ *world.World, e world.Entity) {
+	if c.Extinguished {
+		return
+	}
+	if l, ok := e.(livingEntity); ok && !l.AttackImmune() {
+		l.Hurt(c.Type.Damage(), FireDamageSource{})
+	}
+}
+
+// Tick cooks the items on the campfire. The remaining cook time of the items is updated once per second, so that
+// the campfire is not updated every tick.
+func (c Campfire) Tick(currentTick int64, pos cube.Pos, w *world.World) {
+	if c.Extinguished {
+		return
+	}
+	if rand.Float64() <= 0.016 { // Every three or so seconds.
+		w.PlaySound(pos.Vec3Centre(), sound.CampfireCrackle{})
+	}
+	if currentTick%20 != 0 {
+		return
+	}
+	var updated bool
+	for i, it := range c.Items {
+		if it.Item.Empty() {
+			continue
+		}
+		updated = true
+		if it.Time -= time.Second; it.Time > 0 {
+			c.Items[i] = it
+			continue
+		}
+		c.Items[i] = CampfireItem{}
+		if s, ok := it.Item.Item().(item.Smeltable); ok {
+			dropItem(w, s.SmeltInfo().Product, pos.Vec3Middle().Add(mgl64.Vec3{0, 0.5}))
+		}
+	}
+	if updated {
+		w.SetBlock(pos, c, nil)
+	}
+}
+
+// EncodeItem ...
+func (c Campfire) EncodeItem() (name string, meta int16) {
+	if c.Type == SoulFire() {
+		return "minecraft:soul_campfire", 0
+	}
+	return "minecraft:campfire", 0
+}
+
+// EncodeBlock ...
+func (c Campfire) EncodeBlock() (name string, properties map[string]any) {
+	name = "minecraft:campfire"
+	if c.Type == SoulFire() {
+		name = "minecraft:soul_campfire"
+	}
+	return name, map[string]any{
+		"extinguished":                 c.Extinguished,
+		"minecraft:cardinal_direction": c.Facing.String(),
+	}
+}
+
+// EncodeNBT ...
+func (c Campfire) EncodeNBT() map[string]any {
+	m := map[string]any{"id": "Campfire"}
+	for i, it := range c.Items {
+		if it.Item.Empty() {
+			continue
+		}
+		n := strconv.Itoa(i + 1)
+		m["Item"+n] = nbtconv.WriteItem(it.Item, true)
+		m["ItemTime"+n] = int32((campfireCookTime - it.Time).Milliseconds() / 50)
+	}
+	return m
+}
+
+// DecodeNBT ...
+func (c Campfire) DecodeNBT(data map[string]any) any {
+	for i := range c.Items {
+		n := strconv.Itoa(i + 1)
+		c.Items[i] = CampfireItem{Item: nbtconv.MapItem(data, "Item"+n)}
+		if !c.Items[i].Item.Empty() {
+			c.Items[i].Time = campfireCookTime - time.Duration(nbtconv.Int32(data, "ItemTime"+n))*time.Millisecond*50
+		}
+	}
+	return c
+}
+
+// allCampfires ...
+func allCampfires() (campfires []world.Block) {
+	for _, d := range cube.Directions() {
+		for _, t := range FireTypes() {
+			campfires = append(campfires, Campfire{Facing: d, Type: t})
+			campfires = append(campfires, Campfire{Facing: d, Type: t, Extinguished: true})
+		}
+	}
+	return
+}
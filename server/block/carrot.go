@@ -43,8 +43,7 @@ func (c Carrot) BoneMeal(pos cube.Pos, w *world.World) bool {
 		return false
 	}
 	c.Growth = min(c.Growth+rand.Intn(4)+2, 7)
-	w.SetBlock(pos, c, nil)
-	return true
+	return grow(w, pos, c)
 }
 
 // UseOnBlock ...
@@ -64,11 +63,11 @@ func (c Carrot) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.
 
 // BreakInfo ...
 func (c Carrot) BreakInfo() BreakInfo {
-	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(item.Tool, []item.Enchantment) []item.Stack {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(_ item.Tool, enchantments []item.Enchantment) []item.Stack {
 		if c.Growth < 7 {
 			return []item.Stack{item.NewStack(c, 1)}
 		}
-		return []item.Stack{item.NewStack(c, rand.Intn(4)+2)}
+		return []item.Stack{item.NewStack(c, c.harvestCount(2, enchantments))}
 	})
 }
 
@@ -89,7 +88,7 @@ func (c Carrot) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 		w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: c})
 	} else if c.Growth < 7 && r.Float64() <= c.CalculateGrowthChance(pos, w) {
 		c.Growth++
-		w.SetBlock(pos, c, nil)
+		grow(w, pos, c)
 	}
 }
 
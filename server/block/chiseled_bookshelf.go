@@ -0,0 +1,213 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// BookshelfBooks holds the books stored in the six slots of a ChiseledBookshelf. The first three slots form the
+// top row and the last three the bottom row, both ordered from left to right when looking at the front of the
+// bookshelf.
+type BookshelfBooks [6]item.Stack
+
+// Uint8 returns a bitmask of the slots that hold a book, with the first slot in the lowest bit.
+func (b BookshelfBooks) Uint8() uint8 {
+	var m uint8
+	for i, book := range b {
+		if !book.Empty() {
+			m |= 1 << i
+		}
+	}
+	return m
+}
+
+// ChiseledBookshelf is a variant of the bookshelf that can store up to six books, enchanted books, books and
+// quills or written books. Books are put into and taken out of the bookshelf by using its front side.
+type ChiseledBookshelf struct {
+	solid
+	bass
+
+	// Facing is the direction that the front of the bookshelf is facing.
+	Facing cube.Direction
+	// Books holds the books stored in the bookshelf.
+	Books BookshelfBooks
+	// LastInteractedSlot is the slot that a book was last put into or taken out of. It determines the signal that
+	// a comparator reading the bookshelf outputs.
+	LastInteractedSlot int
+}
+
+// BreakInfo ...
+func (c ChiseledBookshelf) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, axeEffective, func(_ item.Tool, enchantments []item.Enchantment) []item.Stack {
+		var drops []item.Stack
+		if hasSilkTouch(enchantments) {
+			drops = append(drops, item.NewStack(ChiseledBookshelf{}, 1))
+		}
+		for _, book := range c.Books {
+			if !book.Empty() {
+				drops = append(drops, book)
+			}
+		}
+		return drops
+	})
+}
+
+// FlammabilityInfo ...
+func (ChiseledBookshelf) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(30, 20, true)
+}
+
+// FuelInfo ...
+func (ChiseledBookshelf) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// UseOnBlock ...
+func (c ChiseledBookshelf) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, c)
+	if !used {
+		return
+	}
+	c.Facing = user.Rotation().Direction().Opposite()
+	c.Books, c.LastInteractedSlot = BookshelfBooks{}, 0
+
+	place(w, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// Activate ...
+func (c ChiseledBookshelf) Activate(pos cube.Pos, clickedFace cube.Face, w *world.World, u item.User, ctx *item.UseContext) bool {
+	if clickedFace != c.Facing.Face() {
+		return false
+	}
+	slot := c.slot(ctx.ClickPos)
+	if book := c.Books[slot]; !book.Empty() {
+		c.Books[slot], c.LastInteractedSlot = item.Stack{}, slot
+		ctx.NewItem = book
+		w.SetBlock(pos, c, nil)
+
+		_, enchanted := book.Item().(item.EnchantedBook)
+		w.PlaySound(pos.Vec3Centre(), sound.ChiseledBookshelfRemove{Enchanted: enchanted})
+		return true
+	}
+	held, _ := u.HeldItems()
+	if !bookshelfBook(held.Item()) {
+		return false
+	}
+	c.Books[slot], c.LastInteractedSlot = held.Grow(1-held.Count()), slot
+	ctx.SubtractFromCount(1)
+	w.SetBlock(pos, c, nil)
+
+	_, enchanted := held.Item().(item.EnchantedBook)
+	w.PlaySound(pos.Vec3Centre(), sound.ChiseledBookshelfInsert{Enchanted: enchanted})
+	return true
+}
+
+// slot returns the slot of the bookshelf at the position clicked on its front side.
+func (c ChiseledBookshelf) slot(clickPos mgl64.Vec3) int {
+	// The horizontal position is converted so that it goes from left to right when looking at the front of
+	// the bookshelf.
+	var x float64
+	switch c.Facing {
+	case cube.North:
+		x = 1 - clickPos[0]
+	case cube.South:
+		x = clickPos[0]
+	case cube.East:
+		x = 1 - clickPos[2]
+	case cube.West:
+		x = clickPos[2]
+	}
+	column := int(x * 3)
+	if column > 2 {
+		column = 2
+	} else if column < 0 {
+		column = 0
+	}
+	if clickPos[1] < 0.5 {
+		return column + 3
+	}
+	return column
+}
+
+// ComparatorSignal returns the strength of the signal that a comparator reading the bookshelf outputs. The signal
+// is the last slot interacted with plus one.
+func (c ChiseledBookshelf) ComparatorSignal() int {
+	return c.LastInteractedSlot + 1
+}
+
+// bookshelfBook checks if the item passed may be stored in a chiseled bookshelf.
+func bookshelfBook(i world.Item) bool {
+	switch i.(type) {
+	case item.Book, item.EnchantedBook, item.BookAndQuill, item.WrittenBook:
+		return true
+	}
+	return false
+}
+
+// EncodeItem ...
+func (ChiseledBookshelf) EncodeItem() (name string, meta int16) {
+	return "minecraft:chiseled_bookshelf", 0
+}
+
+// EncodeBlock ...
+func (c ChiseledBookshelf) EncodeBlock() (string, map[string]any) {
+	return "minecraft:chiseled_bookshelf", map[string]any{
+		"books_stored": int32(c.Books.Uint8()),
+		"direction":    int32(horizontalDirection(c.Facing)),
+	}
+}
+
+// EncodeNBT ...
+func (c ChiseledBookshelf) EncodeNBT() map[string]any {
+	books := make([]any, 0, len(c.Books))
+	for _, book := range c.Books {
+		if book.Empty() {
+			books = append(books, map[string]any{"Name": "", "Count": uint8(0)})
+			continue
+		}
+		books = append(books, nbtconv.WriteItem(book, true))
+	}
+	return map[string]any{
+		"id":                 "ChiseledBookshelf",
+		"Items":              books,
+		"LastInteractedSlot": int32(c.LastInteractedSlot),
+	}
+}
+
+// DecodeNBT ...
+func (c ChiseledBookshelf) DecodeNBT(data map[string]any) any {
+	c.LastInteractedSlot = int(nbtconv.Int32(data, "LastInteractedSlot"))
+	if items := nbtconv.Slice(data, "Items"); items != nil {
+		c.Books = BookshelfBooks{}
+		for i, v := range items {
+			if m, ok := v.(map[string]any); ok && i < len(c.Books) {
+				if name, _ := m["Name"].(string); name != "" {
+					c.Books[i] = nbtconv.Item(m, nil)
+				}
+			}
+		}
+	}
+	return c
+}
+
+// allChiseledBookshelves ...
+func allChiseledBookshelves() (bookshelves []world.Block) {
+	for _, d := range cube.Directions() {
+		for books := 0; books < 64; books++ {
+			b := ChiseledBookshelf{Facing: d}
+			for i := range b.Books {
+				if books&(1<<i) != 0 {
+					b.Books[i] = item.NewStack(item.Book{}, 1)
+				}
+			}
+			bookshelves = append(bookshelves, b)
+		}
+	}
+	return
+}
@@ -1,7 +1,9 @@
 package block
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 )
 
@@ -15,6 +17,11 @@ func (c Clay) Instrument() sound.Instrument {
 	return sound.Flute()
 }
 
+// BoneMeal grows seagrass on and around the clay if it is underwater.
+func (c Clay) BoneMeal(pos cube.Pos, w *world.World) bool {
+	return growSeagrass(pos, w)
+}
+
 // BreakInfo ...
 func (c Clay) BreakInfo() BreakInfo {
 	return newBreakInfo(0.6, alwaysHarvestable, shovelEffective, silkTouchDrop(item.NewStack(item.ClayBall{}, 4), item.NewStack(c, 1)))
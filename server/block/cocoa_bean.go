@@ -25,7 +25,7 @@ func (c CocoaBean) BoneMeal(pos cube.Pos, w *world.World) bool {
 		return false
 	}
 	c.Age++
-	w.SetBlock(pos, c, nil)
+	grow(w, pos, c)
 	return true
 }
 
@@ -81,7 +81,7 @@ func (c CocoaBean) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *wor
 func (c CocoaBean) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 	if c.Age < 2 && r.Intn(5) == 0 {
 		c.Age++
-		w.SetBlock(pos, c, nil)
+		grow(w, pos, c)
 	}
 }
 
@@ -63,13 +63,16 @@ func (c Composter) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.Us
 		return false
 	}
 	it, _ := u.HeldItems()
-	compostable, ok := it.Item().(item.Compostable)
+	if it.Empty() {
+		return false
+	}
+	chance, ok := item.CompostChance(it.Item())
 	if !ok {
 		return false
 	}
 	ctx.SubtractFromCount(1)
 	w.AddParticle(pos.Vec3(), particle.BoneMeal{})
-	if rand.Float64() > compostable.CompostChance() {
+	if rand.Float64() > chance {
 		w.PlaySound(pos.Vec3(), sound.ComposterFill{})
 		return true
 	}
@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"math/rand"
 )
 
 // Crop is an interface for all crops that are grown on farmland. A crop has a random chance to grow during random ticks.
@@ -36,6 +37,19 @@ func (c crop) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
 	}
 }
 
+// harvestCount returns the amount of items dropped when harvesting a fully grown crop. The amount is base plus a
+// binomially distributed bonus of up to 3 items, which is increased by the level of fortune in the enchantments passed.
+// If base is 0, the amount returned may be 0 as well.
+func (c crop) harvestCount(base int, enchantments []item.Enchantment) int {
+	n := base
+	for i := 0; i < 3+fortuneLevel(enchantments); i++ {
+		if rand.Float64() < 4.0/7.0 {
+			n++
+		}
+	}
+	return n
+}
+
 // HasLiquidDrops ...
 func (c crop) HasLiquidDrops() bool {
 	return true
@@ -70,3 +70,27 @@ func BlockIntercept(pos cube.Pos, w *world.World, b world.Block, start, end mgl6
 
 	return BlockResult{bb: hit.BBox(), pos: hit.Position(), face: hit.Face(), blockPos: pos}, true
 }
+
+// tallBlockIntercept checks if the ray between start and end collides with the part of the block below pos
+// that extends into pos, such as the upper half of a fence or wall. The BlockResult returned holds the
+// position of the block below.
+func tallBlockIntercept(pos cube.Pos, w *world.World, start, end mgl64.Vec3) (result BlockResult, ok bool) {
+	below := pos.Side(cube.FaceDown)
+	dist := math.MaxFloat64
+	for _, bb := range w.Block(below).Model().BBox(below, w) {
+		min, max := bb.Min(), bb.Max()
+		if max[1] <= 1 {
+			continue
+		}
+		top := cube.Box(min[0], math.Max(min[1], 1), min[2], max[0], max[1], max[2]).Translate(below.Vec3())
+		next, hit := BBoxIntercept(top, start, end)
+		if !hit {
+			continue
+		}
+		if nextDist := next.Position().Sub(start).LenSqr(); nextDist < dist {
+			result = BlockResult{bb: bb.Translate(below.Vec3()), pos: next.Position(), face: next.Face(), blockPos: below}
+			dist, ok = nextDist, true
+		}
+	}
+	return result, ok
+}
@@ -25,8 +25,15 @@ func Perform(start, end mgl64.Vec3, w *world.World, box cube.BBox, ignored func(
 	TraverseBlocks(start, end, func(pos cube.Pos) (cont bool) {
 		b := w.Block(pos)
 
-		// Check if we collide with the block's model.
-		if result, ok := BlockIntercept(pos, w, b, start, end); ok {
+		// Check if we collide with the block's model, or with the part of the block below that extends into
+		// this position.
+		result, ok := BlockIntercept(pos, w, b, start, end)
+		if below, belowOk := tallBlockIntercept(pos, w, start, end); belowOk {
+			if !ok || below.Position().Sub(start).LenSqr() < result.Position().Sub(start).LenSqr() {
+				result, ok = below, true
+			}
+		}
+		if ok {
 			hit = result
 			end = hit.Position()
 			return false
@@ -1,6 +1,7 @@
 package block
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
 )
 
@@ -19,12 +20,17 @@ func (d Dirt) SoilFor(block world.Block) bool {
 	switch block.(type) {
 	case TallGrass, DoubleTallGrass, DeadBush:
 		return !d.Coarse
-	case Flower, DoubleFlower, NetherSprouts, SugarCane:
+	case Flower, DoubleFlower, NetherSprouts, SugarCane, Sapling, Bamboo, BambooSapling:
 		return true
 	}
 	return false
 }
 
+// BoneMeal grows seagrass on and around the dirt if it is underwater.
+func (d Dirt) BoneMeal(pos cube.Pos, w *world.World) bool {
+	return growSeagrass(pos, w)
+}
+
 // BreakInfo ...
 func (d Dirt) BreakInfo() BreakInfo {
 	return newBreakInfo(0.5, alwaysHarvestable, shovelEffective, oneOf(d))
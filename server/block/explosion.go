@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube/trace"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
@@ -153,6 +154,7 @@ func (c ExplosionConfig) Explode(w *world.World, explosionPos mgl64.Vec3) {
 
 	w.AddParticle(explosionPos, c.Particle)
 	w.PlaySound(explosionPos, c.Sound)
+	w.EmitGameEvent(explosionPos, gameevent.Explode{}, nil)
 }
 
 // exposure returns the exposure of an explosion to an entity, used to calculate the impact of an explosion.
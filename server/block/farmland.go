@@ -2,6 +2,7 @@ package block
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/world"
 	"math/rand"
 )
@@ -20,7 +21,7 @@ type Farmland struct {
 // SoilFor ...
 func (f Farmland) SoilFor(block world.Block) bool {
 	switch block.(type) {
-	case TallGrass, DoubleTallGrass, Flower, DoubleFlower, NetherSprouts:
+	case TallGrass, DoubleTallGrass, Flower, DoubleFlower, NetherSprouts, Sapling:
 		return true
 	}
 	return false
@@ -45,7 +46,7 @@ func (f Farmland) RandomTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
 				w.SetBlock(pos, Dirt{}, nil)
 			}
 		}
-	} else {
+	} else if f.Hydration != 7 {
 		f.Hydration = 7
 		w.SetBlock(pos, f, nil)
 	}
@@ -72,6 +73,10 @@ func (f Farmland) hydrated(pos cube.Pos, w *world.World) bool {
 func (f Farmland) EntityLand(pos cube.Pos, w *world.World, e world.Entity, distance *float64) {
 	if living, ok := e.(livingEntity); ok {
 		if fall, ok := living.(fallDistanceEntity); ok && rand.Float64() < fall.FallDistance()-0.5 {
+			ctx := event.C()
+			if w.Handler().HandleCropTrample(ctx, pos, e); ctx.Cancelled() {
+				return
+			}
 			w.SetBlock(pos, Dirt{}, nil)
 		}
 	}
@@ -0,0 +1,64 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"math/rand"
+	"time"
+)
+
+// FrostedIce is a variant of ice that is formed when a player wearing boots enchanted with frost walker walks
+// over water. It melts back into water over time.
+type FrostedIce struct {
+	solid
+	transparent
+
+	// Age is the age of the frosted ice, ranging from 0 to 3. Frosted ice melts when it ages beyond 3.
+	Age int
+}
+
+// Instrument ...
+func (FrostedIce) Instrument() sound.Instrument {
+	return sound.Chimes()
+}
+
+// LightDiffusionLevel ...
+func (FrostedIce) LightDiffusionLevel() uint8 {
+	return 2
+}
+
+// BreakInfo ...
+func (f FrostedIce) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, pickaxeEffective, simpleDrops())
+}
+
+// Friction ...
+func (FrostedIce) Friction() float64 {
+	return 0.98
+}
+
+// ScheduledTick ages the frosted ice, eventually melting it into water.
+func (f FrostedIce) ScheduledTick(pos cube.Pos, w *world.World, r *rand.Rand) {
+	if r.Intn(3) == 0 {
+		if f.Age++; f.Age > 3 {
+			w.SetBlock(pos, Water{Depth: 8, Still: true}, nil)
+			return
+		}
+		w.SetBlock(pos, f, nil)
+	}
+	w.ScheduleBlockUpdate(pos, time.Second+time.Duration(r.Intn(20))*time.Second/20)
+}
+
+// EncodeBlock ...
+func (f FrostedIce) EncodeBlock() (string, map[string]any) {
+	return "minecraft:frosted_ice", map[string]any{"age": int32(f.Age)}
+}
+
+// allFrostedIce ...
+func allFrostedIce() (ice []world.Block) {
+	for i := 0; i < 4; i++ {
+		ice = append(ice, FrostedIce{Age: i})
+	}
+	return
+}
@@ -19,6 +19,11 @@ func (g Gravel) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
 	g.fall(g, pos, w)
 }
 
+// BoneMeal grows seagrass on and around the gravel if it is underwater.
+func (g Gravel) BoneMeal(pos cube.Pos, w *world.World) bool {
+	return growSeagrass(pos, w)
+}
+
 // BreakInfo ...
 func (g Gravel) BreakInfo() BreakInfo {
 	return newBreakInfo(0.6, alwaysHarvestable, shovelEffective, func(t item.Tool, enchantments []item.Enchantment) []item.Stack {
@@ -8,11 +8,14 @@ const (
 	hashAncientDebris
 	hashAndesite
 	hashAnvil
+	hashBamboo
+	hashBambooSapling
 	hashBanner
 	hashBarrel
 	hashBarrier
 	hashBasalt
 	hashBeacon
+	hashBed
 	hashBedrock
 	hashBeetrootSeeds
 	hashBlackstone
@@ -24,10 +27,12 @@ const (
 	hashCactus
 	hashCake
 	hashCalcite
+	hashCampfire
 	hashCarpet
 	hashCarrot
 	hashChain
 	hashChest
+	hashChiseledBookshelf
 	hashChiseledQuartz
 	hashClay
 	hashCoal
@@ -67,6 +72,7 @@ const (
 	hashFletchingTable
 	hashFlower
 	hashFroglight
+	hashFrostedIce
 	hashFurnace
 	hashGlass
 	hashGlassPane
@@ -80,10 +86,13 @@ const (
 	hashGrindstone
 	hashHayBale
 	hashHoneycomb
+	hashIce
 	hashInvisibleBedrock
 	hashIron
 	hashIronBars
+	hashIronDoor
 	hashIronOre
+	hashIronTrapdoor
 	hashItemFrame
 	hashJukebox
 	hashKelp
@@ -100,6 +109,7 @@ const (
 	hashLoom
 	hashMelon
 	hashMelonSeeds
+	hashMobSpawner
 	hashMossCarpet
 	hashMud
 	hashMudBricks
@@ -121,6 +131,7 @@ const (
 	hashPodzol
 	hashPolishedBlackstoneBrick
 	hashPotato
+	hashPowderSnow
 	hashPrismarine
 	hashPumpkin
 	hashPumpkinSeeds
@@ -135,8 +146,11 @@ const (
 	hashReinforcedDeepslate
 	hashSand
 	hashSandstone
+	hashSapling
+	hashSculkSensor
 	hashSeaLantern
 	hashSeaPickle
+	hashSeagrass
 	hashShroomlight
 	hashSign
 	hashSkull
@@ -144,6 +158,7 @@ const (
 	hashSmithingTable
 	hashSmoker
 	hashSnow
+	hashSnowLayer
 	hashSoulSand
 	hashSoulSoil
 	hashSponge
@@ -161,6 +176,7 @@ const (
 	hashTerracotta
 	hashTorch
 	hashTuff
+	hashVines
 	hashWall
 	hashWater
 	hashWheatSeeds
@@ -192,6 +208,14 @@ func (a Anvil) Hash() uint64 {
 	return hashAnvil | uint64(a.Type.Uint8())<<8 | uint64(a.Facing)<<10
 }
 
+func (b Bamboo) Hash() uint64 {
+	return hashBamboo | uint64(boolByte(b.Thick))<<8 | uint64(b.LeafSize.Uint8())<<9 | uint64(boolByte(b.Ready))<<11
+}
+
+func (b BambooSapling) Hash() uint64 {
+	return hashBambooSapling | uint64(boolByte(b.Ready))<<8
+}
+
 func (b Banner) Hash() uint64 {
 	return hashBanner | uint64(b.Attach.Uint8())<<8
 }
@@ -212,6 +236,10 @@ func (Beacon) Hash() uint64 {
 	return hashBeacon
 }
 
+func (b Bed) Hash() uint64 {
+	return hashBed | uint64(b.Facing)<<8 | uint64(boolByte(b.Head))<<10 | uint64(boolByte(b.Occupied))<<11
+}
+
 func (b Bedrock) Hash() uint64 {
 	return hashBedrock | uint64(boolByte(b.InfiniteBurning))<<8
 }
@@ -256,6 +284,10 @@ func (Calcite) Hash() uint64 {
 	return hashCalcite
 }
 
+func (c Campfire) Hash() uint64 {
+	return hashCampfire | uint64(c.Facing)<<8 | uint64(boolByte(c.Extinguished))<<10 | uint64(c.Type.Uint8())<<11
+}
+
 func (c Carpet) Hash() uint64 {
 	return hashCarpet | uint64(c.Colour.Uint8())<<8
 }
@@ -272,6 +304,10 @@ func (c Chest) Hash() uint64 {
 	return hashChest | uint64(c.Facing)<<8
 }
 
+func (c ChiseledBookshelf) Hash() uint64 {
+	return hashChiseledBookshelf | uint64(c.Facing)<<8 | uint64(c.Books.Uint8())<<10
+}
+
 func (ChiseledQuartz) Hash() uint64 {
 	return hashChiseledQuartz
 }
@@ -428,6 +464,10 @@ func (f Froglight) Hash() uint64 {
 	return hashFroglight | uint64(f.Type.Uint8())<<8 | uint64(f.Axis)<<10
 }
 
+func (f FrostedIce) Hash() uint64 {
+	return hashFrostedIce | uint64(f.Age)<<8
+}
+
 func (f Furnace) Hash() uint64 {
 	return hashFurnace | uint64(f.Facing)<<8 | uint64(boolByte(f.Lit))<<11
 }
@@ -480,6 +520,10 @@ func (Honeycomb) Hash() uint64 {
 	return hashHoneycomb
 }
 
+func (Ice) Hash() uint64 {
+	return hashIce
+}
+
 func (InvisibleBedrock) Hash() uint64 {
 	return hashInvisibleBedrock
 }
@@ -492,12 +536,20 @@ func (IronBars) Hash() uint64 {
 	return hashIronBars
 }
 
+func (d IronDoor) Hash() uint64 {
+	return hashIronDoor | uint64(d.Facing)<<8 | uint64(boolByte(d.Open))<<10 | uint64(boolByte(d.Top))<<11 | uint64(boolByte(d.Right))<<12
+}
+
 func (i IronOre) Hash() uint64 {
 	return hashIronOre | uint64(i.Type.Uint8())<<8
 }
 
+func (t IronTrapdoor) Hash() uint64 {
+	return hashIronTrapdoor | uint64(t.Facing)<<8 | uint64(boolByte(t.Open))<<10 | uint64(boolByte(t.Top))<<11
+}
+
 func (i ItemFrame) Hash() uint64 {
-	return hashItemFrame | uint64(i.Facing)<<8 | uint64(boolByte(i.Glowing))<<11
+	return hashItemFrame | uint64(i.Facing)<<8 | uint64(boolByte(i.Glowing))<<11 | uint64(boolByte(i.Map))<<12
 }
 
 func (Jukebox) Hash() uint64 {
@@ -560,6 +612,10 @@ func (m MelonSeeds) Hash() uint64 {
 	return hashMelonSeeds | uint64(m.Growth)<<8 | uint64(m.Direction)<<16
 }
 
+func (MobSpawner) Hash() uint64 {
+	return hashMobSpawner
+}
+
 func (MossCarpet) Hash() uint64 {
 	return hashMossCarpet
 }
@@ -644,6 +700,10 @@ func (p Potato) Hash() uint64 {
 	return hashPotato | uint64(p.Growth)<<8
 }
 
+func (PowderSnow) Hash() uint64 {
+	return hashPowderSnow
+}
+
 func (p Prismarine) Hash() uint64 {
 	return hashPrismarine | uint64(p.Type.Uint8())<<8
 }
@@ -700,6 +760,14 @@ func (s Sandstone) Hash() uint64 {
 	return hashSandstone | uint64(s.Type.Uint8())<<8 | uint64(boolByte(s.Red))<<10
 }
 
+func (s Sapling) Hash() uint64 {
+	return hashSapling | uint64(s.Wood.Uint8())<<8 | uint64(boolByte(s.Ready))<<12
+}
+
+func (SculkSensor) Hash() uint64 {
+	return hashSculkSensor
+}
+
 func (SeaLantern) Hash() uint64 {
 	return hashSeaLantern
 }
@@ -708,6 +776,10 @@ func (s SeaPickle) Hash() uint64 {
 	return hashSeaPickle | uint64(s.AdditionalCount)<<8 | uint64(boolByte(s.Dead))<<16
 }
 
+func (s Seagrass) Hash() uint64 {
+	return hashSeagrass | uint64(boolByte(s.Tall))<<8 | uint64(boolByte(s.UpperPart))<<9
+}
+
 func (Shroomlight) Hash() uint64 {
 	return hashShroomlight
 }
@@ -736,6 +808,10 @@ func (Snow) Hash() uint64 {
 	return hashSnow
 }
 
+func (s SnowLayer) Hash() uint64 {
+	return hashSnowLayer | uint64(s.Height)<<8
+}
+
 func (SoulSand) Hash() uint64 {
 	return hashSoulSand
 }
@@ -804,6 +880,10 @@ func (Tuff) Hash() uint64 {
 	return hashTuff
 }
 
+func (v Vines) Hash() uint64 {
+	return hashVines | uint64(boolByte(v.NorthDirection))<<8 | uint64(boolByte(v.EastDirection))<<9 | uint64(boolByte(v.SouthDirection))<<10 | uint64(boolByte(v.WestDirection))<<11
+}
+
 func (w Wall) Hash() uint64 {
 	return hashWall | w.Block.Hash()<<8 | uint64(w.NorthConnection.Uint8())<<24 | uint64(w.EastConnection.Uint8())<<26 | uint64(w.SouthConnection.Uint8())<<28 | uint64(w.WestConnection.Uint8())<<30 | uint64(boolByte(w.Post))<<32
 }
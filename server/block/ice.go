@@ -0,0 +1,64 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"math/rand"
+)
+
+// Ice is a transparent solid block that forms when water freezes in cold biomes. It melts into water when lit by
+// bright block light sources.
+type Ice struct {
+	solid
+	transparent
+}
+
+// Instrument ...
+func (Ice) Instrument() sound.Instrument {
+	return sound.Chimes()
+}
+
+// LightDiffusionLevel ...
+func (Ice) LightDiffusionLevel() uint8 {
+	return 2
+}
+
+// BreakInfo ...
+func (i Ice) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, pickaxeEffective, silkTouchOnlyDrop(i)).withBreakHandler(func(pos cube.Pos, w *world.World, u item.User) {
+		if u != nil {
+			if held, _ := u.HeldItems(); hasSilkTouch(held.Enchantments()) {
+				return
+			}
+		}
+		below := pos.Side(cube.FaceDown)
+		_, liquid := w.Liquid(below)
+		if liquid || w.Block(below).Model().FaceSolid(below, cube.FaceUp, w) {
+			w.SetBlock(pos, Water{Depth: 8, Still: true}, nil)
+		}
+	})
+}
+
+// RandomTick melts the ice if it is lit by a block light source brighter than 11.
+func (i Ice) RandomTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	if blockLight(pos, w) > 11 {
+		w.SetBlock(pos, Water{Depth: 8, Still: true}, nil)
+	}
+}
+
+// Friction ...
+func (Ice) Friction() float64 {
+	return 0.98
+}
+
+// EncodeItem ...
+func (Ice) EncodeItem() (name string, meta int16) {
+	return "minecraft:ice", 0
+}
+
+// EncodeBlock ...
+func (Ice) EncodeBlock() (string, map[string]any) {
+	return "minecraft:ice", nil
+}
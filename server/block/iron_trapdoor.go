@@ -0,0 +1,74 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// IronTrapdoor is a variant of the trapdoor made of iron. Unlike wooden trapdoors, it cannot be opened by hand.
+// TODO: Redstone functionality.
+type IronTrapdoor struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Facing is the direction the trapdoor is facing.
+	Facing cube.Direction
+	// Open is whether the trapdoor is open.
+	Open bool
+	// Top is whether the trapdoor occupies the top or bottom part of a block.
+	Top bool
+}
+
+// Model ...
+func (t IronTrapdoor) Model() world.BlockModel {
+	return model.Trapdoor{Facing: t.Facing, Top: t.Top, Open: t.Open}
+}
+
+// UseOnBlock handles the directional placing of trapdoors and makes sure they are properly placed upside down
+// when needed.
+func (t IronTrapdoor) UseOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(w, pos, face, t)
+	if !used {
+		return false
+	}
+	t.Facing = user.Rotation().Direction().Opposite()
+	t.Top = (clickPos.Y() > 0.5 && face != cube.FaceUp) || face == cube.FaceDown
+
+	place(w, pos, t, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (t IronTrapdoor) BreakInfo() BreakInfo {
+	return newBreakInfo(5, pickaxeHarvestable, pickaxeEffective, oneOf(t))
+}
+
+// SideClosed ...
+func (t IronTrapdoor) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// EncodeItem ...
+func (t IronTrapdoor) EncodeItem() (name string, meta int16) {
+	return "minecraft:iron_trapdoor", 0
+}
+
+// EncodeBlock ...
+func (t IronTrapdoor) EncodeBlock() (name string, properties map[string]any) {
+	return "minecraft:iron_trapdoor", map[string]any{"direction": int32(math.Abs(float64(t.Facing) - 3)), "open_bit": t.Open, "upside_down_bit": t.Top}
+}
+
+// allIronTrapdoors returns a list of all iron trapdoor types.
+func allIronTrapdoors() (trapdoors []world.Block) {
+	for i := cube.Direction(0); i <= 3; i++ {
+		trapdoors = append(trapdoors, IronTrapdoor{Facing: i, Open: false, Top: false})
+		trapdoors = append(trapdoors, IronTrapdoor{Facing: i, Open: false, Top: true})
+		trapdoors = append(trapdoors, IronTrapdoor{Facing: i, Open: true, Top: true})
+		trapdoors = append(trapdoors, IronTrapdoor{Facing: i, Open: true, Top: false})
+	}
+	return
+}
@@ -29,17 +29,19 @@ type ItemFrame struct {
 	DropChance float64
 	// Glowing makes the frame the glowing variant.
 	Glowing bool
+	// Map is true if the frame displays a filled map, in which case the frame is shown in its larger map variant.
+	// Map is updated automatically when an item is put into or taken out of the frame.
+	Map bool
 }
 
 // Activate ...
 func (i ItemFrame) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User, ctx *item.UseContext) bool {
 	if !i.Item.Empty() {
-		// TODO: Item frames with maps can only be rotated four times.
-		i.Rotations = (i.Rotations + 1) % 8
+		i.Rotations = (i.Rotations + 1) % i.maxRotations()
 		w.PlaySound(pos.Vec3Centre(), sound.ItemFrameRotate{})
 	} else if held, _ := u.HeldItems(); !held.Empty() {
 		i.Item = held.Grow(-held.Count() + 1)
-		// TODO: When maps are implemented, check the item is a map, and if so, display the large version of the frame.
+		i.Map = filledMap(i.Item)
 		ctx.SubtractFromCount(1)
 		w.PlaySound(pos.Vec3Centre(), sound.ItemFrameAdd{})
 	} else {
@@ -63,7 +65,7 @@ func (i ItemFrame) Punch(pos cube.Pos, _ cube.Face, w *world.World, u item.User)
 			dropItem(w, i.Item, pos.Vec3Centre())
 		}
 	}
-	i.Item, i.Rotations = item.Stack{}, 0
+	i.Item, i.Rotations, i.Map = item.Stack{}, 0, false
 	w.PlaySound(pos.Vec3Centre(), sound.ItemFrameRemove{})
 	w.SetBlock(pos, i, nil)
 }
@@ -87,7 +89,37 @@ func (i ItemFrame) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *wor
 
 // BreakInfo ...
 func (i ItemFrame) BreakInfo() BreakInfo {
-	return newBreakInfo(0.25, alwaysHarvestable, nothingEffective, oneOf(i))
+	return newBreakInfo(0.25, alwaysHarvestable, nothingEffective, func(item.Tool, []item.Enchantment) []item.Stack {
+		return i.drops()
+	})
+}
+
+// ComparatorSignal returns the strength of the signal that a comparator reading the frame outputs. The signal is
+// 0 if the frame is empty and otherwise increases with every rotation of the item in the frame, starting at 1.
+func (i ItemFrame) ComparatorSignal() int {
+	if i.Item.Empty() {
+		return 0
+	}
+	return i.Rotations%i.maxRotations() + 1
+}
+
+// maxRotations returns the number of different rotations that the item in the frame may have. Maps may only be
+// rotated by 90 degrees, giving them four rotations, while other items have eight.
+func (i ItemFrame) maxRotations() int {
+	if i.Map {
+		return 4
+	}
+	return 8
+}
+
+// drops returns the items dropped when the frame is broken: The frame itself and, depending on the drop chance
+// of the frame, the item inside it.
+func (i ItemFrame) drops() []item.Stack {
+	drops := []item.Stack{item.NewStack(ItemFrame{Glowing: i.Glowing}, 1)}
+	if !i.Item.Empty() && rand.Float64() <= i.DropChance {
+		drops = append(drops, i.Item)
+	}
+	return drops
 }
 
 // EncodeItem ...
@@ -106,7 +138,7 @@ func (i ItemFrame) EncodeBlock() (name string, properties map[string]any) {
 	}
 	return name, map[string]any{
 		"facing_direction":     int32(i.Facing.Opposite()),
-		"item_frame_map_bit":   uint8(0), // TODO: When maps are added, set this to true if the item is a map.
+		"item_frame_map_bit":   boolByte(i.Map),
 		"item_frame_photo_bit": uint8(0), // Only implemented in Education Edition.
 	}
 }
@@ -116,6 +148,7 @@ func (i ItemFrame) DecodeNBT(data map[string]any) any {
 	i.DropChance = float64(nbtconv.Float32(data, "ItemDropChance"))
 	i.Rotations = int(nbtconv.Uint8(data, "ItemRotation"))
 	i.Item = nbtconv.MapItem(data, "Item")
+	i.Map = filledMap(i.Item)
 	return i
 }
 
@@ -154,18 +187,28 @@ func (i ItemFrame) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
 		// TODO: Allow exceptions for pressure plates.
 		w.SetBlock(pos, nil, nil)
 		w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: i})
-		dropItem(w, item.NewStack(i, 1), pos.Vec3Centre())
-		if !i.Item.Empty() {
-			dropItem(w, i.Item, pos.Vec3Centre())
+		for _, drop := range i.drops() {
+			dropItem(w, drop, pos.Vec3Centre())
 		}
 	}
 }
 
+// filledMap checks if the item passed is a filled map, which is displayed over the full size of an item frame.
+func filledMap(s item.Stack) bool {
+	if s.Empty() {
+		return false
+	}
+	name, _ := s.Item().EncodeItem()
+	return name == "minecraft:filled_map"
+}
+
 // allItemFrames ...
 func allItemFrames() (frames []world.Block) {
 	for _, f := range cube.Faces() {
-		frames = append(frames, ItemFrame{Facing: f, Glowing: true})
-		frames = append(frames, ItemFrame{Facing: f, Glowing: false})
+		for _, m := range []bool{false, true} {
+			frames = append(frames, ItemFrame{Facing: f, Glowing: true, Map: m})
+			frames = append(frames, ItemFrame{Facing: f, Glowing: false, Map: m})
+		}
 	}
 	return
 }
@@ -35,8 +35,7 @@ func (k Kelp) BoneMeal(pos cube.Pos, w *world.World) bool {
 			continue
 		}
 		if water, ok := block.(Water); ok && water.Depth == 8 {
-			w.SetBlock(currentPos, Kelp{Age: k.Age + 1}, nil)
-			return true
+			return grow(w, currentPos, Kelp{Age: k.Age + 1})
 		}
 		break
 	}
@@ -135,7 +134,9 @@ func (k Kelp) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 		} else if _, ok := liquid.(Water); ok {
 			switch w.Block(abovePos).(type) {
 			case Air, Water:
-				w.SetBlock(abovePos, Kelp{Age: k.Age + 1}, nil)
+				if !grow(w, abovePos, Kelp{Age: k.Age + 1}) {
+					return
+				}
 				if liquid.LiquidDepth() < 8 {
 					// When kelp grows into a water block, the water block becomes a source block.
 					w.SetLiquid(abovePos, Water{Still: true, Depth: 8, Falling: false})
@@ -96,11 +96,32 @@ func (l Leaves) BreakInfo() BreakInfo {
 		if (l.Wood == OakWood() || l.Wood == DarkOakWood()) && rand.Float64() < 0.005 {
 			drops = append(drops, item.NewStack(item.Apple{}, 1))
 		}
-		// TODO: Saplings and sticks can drop along with apples
+		if l.hasSapling() {
+			chance := 0.05
+			if l.Wood == JungleWood() {
+				chance = 0.025
+			}
+			if rand.Float64() < chance {
+				drops = append(drops, item.NewStack(Sapling{Wood: l.Wood}, 1))
+			}
+		}
+		if rand.Float64() < 0.02 {
+			drops = append(drops, item.NewStack(item.Stick{}, rand.Intn(2)+1))
+		}
 		return drops
 	})
 }
 
+// hasSapling checks if a sapling exists for the wood type of the leaves.
+func (l Leaves) hasSapling() bool {
+	for _, w := range saplingWoodTypes() {
+		if w == l.Wood {
+			return true
+		}
+	}
+	return false
+}
+
 // CompostChance ...
 func (Leaves) CompostChance() float64 {
 	return 0.3
@@ -120,9 +120,23 @@ func (l Lectern) TurnPage(pos cube.Pos, w *world.World, page int) error {
 	}
 	l.Page = page
 	w.SetBlock(pos, l, nil)
+	w.PlaySound(pos.Vec3Centre(), sound.BookPageTurn{})
 	return nil
 }
 
+// ComparatorSignal returns the strength of the signal that a comparator reading the lectern outputs. The signal
+// is 0 if the lectern holds no book and otherwise ranges from 1 on the first page to 15 on the last page.
+func (l Lectern) ComparatorSignal() int {
+	if l.Book.Empty() {
+		return 0
+	}
+	r, ok := l.Book.Item().(readableBook)
+	if !ok || r.TotalPages() <= 1 {
+		return 15
+	}
+	return int(float64(l.Page)/float64(r.TotalPages()-1)*14) + 1
+}
+
 // EncodeNBT ...
 func (l Lectern) EncodeNBT() map[string]any {
 	m := map[string]any{
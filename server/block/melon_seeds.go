@@ -41,7 +41,7 @@ func (m MelonSeeds) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 	if r.Float64() <= m.CalculateGrowthChance(pos, w) && w.Light(pos) >= 8 {
 		if m.Growth < 7 {
 			m.Growth++
-			w.SetBlock(pos, m, nil)
+			grow(w, pos, m)
 		} else {
 			directions := cube.Directions()
 			for _, i := range directions {
@@ -54,9 +54,10 @@ func (m MelonSeeds) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 			if _, ok := w.Block(stemPos).(Air); ok {
 				switch w.Block(stemPos.Side(cube.FaceDown)).(type) {
 				case Farmland, Dirt, Grass:
-					m.Direction = direction
-					w.SetBlock(pos, m, nil)
-					w.SetBlock(stemPos, Melon{}, nil)
+					if grow(w, stemPos, Melon{}) {
+						m.Direction = direction
+						w.SetBlock(pos, m, nil)
+					}
 				}
 			}
 		}
@@ -69,8 +70,7 @@ func (m MelonSeeds) BoneMeal(pos cube.Pos, w *world.World) bool {
 		return false
 	}
 	m.Growth = min(m.Growth+rand.Intn(4)+2, 7)
-	w.SetBlock(pos, m, nil)
-	return true
+	return grow(w, pos, m)
 }
 
 // UseOnBlock ...
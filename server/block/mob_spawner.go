@@ -0,0 +1,258 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// MobSpawner is a cage-like block that periodically spawns mobs around it while a player is nearby.
+// The empty value of MobSpawner is valid, but it is replaced with a MobSpawner created using NewMobSpawner when it
+// is first ticked.
+type MobSpawner struct {
+	solid
+	transparent
+	sourceWaterDisplacer
+	*spawner
+
+	// Entity is the identifier of the entity spawned by the MobSpawner, such as 'minecraft:villager_v2'. The
+	// entity type must be registered in the world.EntityRegistry of the world and must be a
+	// world.SaveableEntityType, which is decoded with only the position of the entity set to create new
+	// entities. If empty, the MobSpawner does not spawn anything.
+	Entity string
+	// MinSpawnDelay and MaxSpawnDelay are the minimum and maximum delay between two spawns. After every spawn,
+	// a random delay in this range is chosen. If 0, delays of 10 and 40 seconds are used respectively.
+	MinSpawnDelay, MaxSpawnDelay time.Duration
+	// SpawnCount is the amount of entities the MobSpawner attempts to spawn at once. If 0, 4 entities are
+	// spawned.
+	SpawnCount int
+	// SpawnRange is the maximum horizontal distance from the MobSpawner at which entities are spawned. If 0, a
+	// range of 4 blocks is used.
+	SpawnRange int
+	// MaxNearbyEntities is the maximum amount of entities of the Entity type that may be near the MobSpawner.
+	// No entities are spawned while this amount is reached. If 0, a maximum of 6 entities is used.
+	MaxNearbyEntities int
+	// RequiredPlayerRange is the distance within which a player must be for the MobSpawner to be active. If 0, a
+	// range of 16 blocks is used.
+	RequiredPlayerRange int
+	// SilkTouchDrop specifies if the MobSpawner drops itself, keeping its Entity, when broken using a tool with
+	// silk touch. By default, a MobSpawner never drops itself.
+	SilkTouchDrop bool
+}
+
+// NewMobSpawner creates a new initialised MobSpawner that spawns entities with the identifier passed.
+func NewMobSpawner(entity string) MobSpawner {
+	return MobSpawner{Entity: entity, spawner: &spawner{delay: time.Second}}
+}
+
+// spawner holds the state of a MobSpawner that changes every tick.
+type spawner struct {
+	mu    sync.Mutex
+	delay time.Duration
+}
+
+// Tick spawns entities around the MobSpawner every time its delay runs out, provided that a player is within
+// the RequiredPlayerRange.
+func (s MobSpawner) Tick(_ int64, pos cube.Pos, w *world.World) {
+	if s.spawner == nil {
+		s.spawner = &spawner{delay: time.Second}
+		w.SetBlock(pos, s, nil)
+		return
+	}
+	t, ok := w.EntityRegistry().Lookup(s.Entity)
+	if !ok || !s.playerNearby(pos, w) {
+		return
+	}
+	saveable, ok := t.(world.SaveableEntityType)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	s.delay -= time.Second / 20
+	spawn := s.delay <= 0
+	if spawn {
+		s.delay = s.randomDelay()
+	}
+	s.mu.Unlock()
+	if !spawn || s.nearbyEntities(pos, w) >= s.maxNearbyEntities() {
+		return
+	}
+
+	r := s.spawnRange()
+	for i := 0; i < s.spawnCount(); i++ {
+		spawnPos := pos.Add(cube.Pos{rand.Intn(r*2+1) - r, rand.Intn(3) - 1, rand.Intn(r*2+1) - r})
+		if !s.canSpawnAt(spawnPos, w) {
+			continue
+		}
+		vec := spawnPos.Vec3Middle()
+		e := saveable.DecodeNBT(map[string]any{"Pos": nbtconv.Vec3ToFloat32Slice(vec)})
+		if e == nil {
+			return
+		}
+		w.AddEntity(e)
+		w.AddParticle(vec, particle.MobSpawn{})
+	}
+}
+
+// playerNearby checks if a player is within the RequiredPlayerRange of the MobSpawner.
+func (s MobSpawner) playerNearby(pos cube.Pos, w *world.World) bool {
+	r := float64(s.requiredPlayerRange())
+	centre := pos.Vec3Centre()
+	for _, e := range w.EntitiesWithin(cube.Box(-r, -r, -r, r, r, r).Translate(centre), nil) {
+		if e.Type().EncodeEntity() == "minecraft:player" && e.Position().Sub(centre).Len() <= r {
+			return true
+		}
+	}
+	return false
+}
+
+// nearbyEntities returns the amount of entities of the Entity type of the MobSpawner close to it.
+func (s MobSpawner) nearbyEntities(pos cube.Pos, w *world.World) (n int) {
+	r := float64(s.spawnRange() * 2)
+	for _, e := range w.EntitiesWithin(cube.Box(-r, -4, -r, r+1, 5, r+1).Translate(pos.Vec3()), nil) {
+		if e.Type().EncodeEntity() == s.Entity {
+			n++
+		}
+	}
+	return n
+}
+
+// canSpawnAt checks if an entity can be spawned at the position passed: The position and the one above it must
+// not be solid, and the block below must be.
+func (s MobSpawner) canSpawnAt(pos cube.Pos, w *world.World) bool {
+	if pos.OutOfBounds(w.Range()) || pos.Side(cube.FaceUp).OutOfBounds(w.Range()) {
+		return false
+	}
+	above, below := pos.Side(cube.FaceUp), pos.Side(cube.FaceDown)
+	if len(w.Block(pos).Model().BBox(pos, w)) != 0 || len(w.Block(above).Model().BBox(above, w)) != 0 {
+		return false
+	}
+	return w.Block(below).Model().FaceSolid(below, cube.FaceUp, w)
+}
+
+// randomDelay returns a random delay between the MinSpawnDelay and MaxSpawnDelay of the MobSpawner.
+func (s MobSpawner) randomDelay() time.Duration {
+	minDelay, maxDelay := s.MinSpawnDelay, s.MaxSpawnDelay
+	if minDelay <= 0 {
+		minDelay = time.Second * 10
+	}
+	if maxDelay <= 0 {
+		maxDelay = time.Second * 40
+	}
+	if maxDelay <= minDelay {
+		return minDelay
+	}
+	return minDelay + time.Duration(rand.Int63n(int64(maxDelay-minDelay)))
+}
+
+// spawnCount returns the SpawnCount of the MobSpawner, or 4 if not set.
+func (s MobSpawner) spawnCount() int {
+	if s.SpawnCount <= 0 {
+		return 4
+	}
+	return s.SpawnCount
+}
+
+// spawnRange returns the SpawnRange of the MobSpawner, or 4 if not set.
+func (s MobSpawner) spawnRange() int {
+	if s.SpawnRange <= 0 {
+		return 4
+	}
+	return s.SpawnRange
+}
+
+// maxNearbyEntities returns the MaxNearbyEntities of the MobSpawner, or 6 if not set.
+func (s MobSpawner) maxNearbyEntities() int {
+	if s.MaxNearbyEntities <= 0 {
+		return 6
+	}
+	return s.MaxNearbyEntities
+}
+
+// requiredPlayerRange returns the RequiredPlayerRange of the MobSpawner, or 16 if not set.
+func (s MobSpawner) requiredPlayerRange() int {
+	if s.RequiredPlayerRange <= 0 {
+		return 16
+	}
+	return s.RequiredPlayerRange
+}
+
+// UseOnBlock ...
+func (s MobSpawner) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, s)
+	if !used {
+		return false
+	}
+	// The spawner placed must not share its state with the item it was placed with.
+	s.spawner = &spawner{delay: time.Second}
+	place(w, pos, s, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (s MobSpawner) BreakInfo() BreakInfo {
+	drops := func(item.Tool, []item.Enchantment) []item.Stack { return nil }
+	if s.SilkTouchDrop {
+		s.spawner = nil
+		drops = silkTouchOnlyDrop(s)
+	}
+	return newBreakInfo(5, pickaxeHarvestable, pickaxeEffective, drops).withXPDropRange(15, 43)
+}
+
+// SideClosed ...
+func (MobSpawner) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// EncodeItem ...
+func (MobSpawner) EncodeItem() (name string, meta int16) {
+	return "minecraft:mob_spawner", 0
+}
+
+// EncodeBlock ...
+func (MobSpawner) EncodeBlock() (string, map[string]any) {
+	return "minecraft:mob_spawner", nil
+}
+
+// DecodeNBT ...
+func (s MobSpawner) DecodeNBT(data map[string]any) any {
+	s.spawner = &spawner{delay: nbtconv.TickDuration[int16](data, "Delay")}
+	s.Entity = nbtconv.String(data, "EntityIdentifier")
+	s.MinSpawnDelay = nbtconv.TickDuration[int16](data, "MinSpawnDelay")
+	s.MaxSpawnDelay = nbtconv.TickDuration[int16](data, "MaxSpawnDelay")
+	s.SpawnCount = int(nbtconv.Int16(data, "SpawnCount"))
+	s.SpawnRange = int(nbtconv.Int16(data, "SpawnRange"))
+	s.MaxNearbyEntities = int(nbtconv.Int16(data, "MaxNearbyEntities"))
+	s.RequiredPlayerRange = int(nbtconv.Int16(data, "RequiredPlayerRange"))
+	s.SilkTouchDrop = nbtconv.Bool(data, "SilkTouchDrop")
+	return s
+}
+
+// EncodeNBT ...
+func (s MobSpawner) EncodeNBT() map[string]any {
+	var delay time.Duration
+	if s.spawner != nil {
+		s.mu.Lock()
+		delay = s.delay
+		s.mu.Unlock()
+	}
+	return map[string]any{
+		"id":                  "MobSpawner",
+		"Delay":               int16(delay.Milliseconds() / 50),
+		"MinSpawnDelay":       int16(s.MinSpawnDelay.Milliseconds() / 50),
+		"MaxSpawnDelay":       int16(s.MaxSpawnDelay.Milliseconds() / 50),
+		"SpawnCount":          int16(s.SpawnCount),
+		"SpawnRange":          int16(s.SpawnRange),
+		"MaxNearbyEntities":   int16(s.MaxNearbyEntities),
+		"RequiredPlayerRange": int16(s.RequiredPlayerRange),
+		"SilkTouchDrop":       boolByte(s.SilkTouchDrop),
+		"EntityIdentifier":    s.Entity,
+		"DisplayEntityScale":  float32(1),
+	}
+}
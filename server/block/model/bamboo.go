@@ -0,0 +1,19 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Bamboo is the model for a Bamboo stalk. It is a thin pillar in the centre of the block.
+type Bamboo struct{}
+
+// BBox returns a physics.BBox of a thin pillar in the centre of the block.
+func (Bamboo) BBox(cube.Pos, *world.World) []cube.BBox {
+	return []cube.BBox{cube.Box(0.40625, 0, 0.40625, 0.59375, 1, 0.59375)}
+}
+
+// FaceSolid always returns false.
+func (Bamboo) FaceSolid(cube.Pos, cube.Face, *world.World) bool {
+	return false
+}
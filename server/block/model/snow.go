@@ -0,0 +1,23 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Snow is the model of a snow layer. It has a height depending on the amount of layers.
+type Snow struct {
+	// Height is the height of the snow layer, ranging from 0 to 7. A height of 0 means the snow layer consists of
+	// a single layer, which has no collision.
+	Height int
+}
+
+// BBox returns a BBox with a height of 1/8th of a block for every layer, excluding the top layer.
+func (s Snow) BBox(cube.Pos, *world.World) []cube.BBox {
+	return []cube.BBox{cube.Box(0, 0, 0, 1, float64(s.Height)/8, 1)}
+}
+
+// FaceSolid only returns true for the bottom face, or for all faces if the snow layer is a full block high.
+func (s Snow) FaceSolid(_ cube.Pos, face cube.Face, _ *world.World) bool {
+	return s.Height == 7 || face == cube.FaceDown
+}
@@ -26,7 +26,7 @@ func (n NetherWart) HasLiquidDrops() bool {
 func (n NetherWart) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 	if n.Age < 3 && r.Float64() < 0.1 {
 		n.Age++
-		w.SetBlock(pos, n, nil)
+		grow(w, pos, n)
 	}
 }
 
@@ -48,8 +48,7 @@ func (p Potato) BoneMeal(pos cube.Pos, w *world.World) bool {
 		return false
 	}
 	p.Growth = min(p.Growth+rand.Intn(4)+2, 7)
-	w.SetBlock(pos, p, nil)
-	return true
+	return grow(w, pos, p)
 }
 
 // UseOnBlock ...
@@ -69,11 +68,14 @@ func (p Potato) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.
 
 // BreakInfo ...
 func (p Potato) BreakInfo() BreakInfo {
-	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(item.Tool, []item.Enchantment) []item.Stack {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(_ item.Tool, enchantments []item.Enchantment) []item.Stack {
+		if p.Growth < 7 {
+			return []item.Stack{item.NewStack(p, 1)}
+		}
 		if rand.Float64() < 0.02 {
-			return []item.Stack{item.NewStack(p, rand.Intn(5)+1), item.NewStack(item.PoisonousPotato{}, 1)}
+			return []item.Stack{item.NewStack(p, p.harvestCount(2, enchantments)), item.NewStack(item.PoisonousPotato{}, 1)}
 		}
-		return []item.Stack{item.NewStack(p, rand.Intn(5)+1)}
+		return []item.Stack{item.NewStack(p, p.harvestCount(2, enchantments))}
 	})
 }
 
@@ -94,7 +96,7 @@ func (p Potato) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 		w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: p})
 	} else if p.Growth < 7 && r.Float64() <= p.CalculateGrowthChance(pos, w) {
 		p.Growth++
-		w.SetBlock(pos, p, nil)
+		grow(w, pos, p)
 	}
 }
 
@@ -0,0 +1,43 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// PowderSnow is a block that entities sink into. Entities inside of powder snow slowly freeze and take damage once
+// they are fully frozen, unless they are wearing leather armour. Burning entities are extinguished by it.
+type PowderSnow struct {
+	empty
+	transparent
+}
+
+// BreakInfo ...
+func (p PowderSnow) BreakInfo() BreakInfo {
+	return newBreakInfo(0.25, alwaysHarvestable, shovelEffective, simpleDrops())
+}
+
+// SideClosed ...
+func (PowderSnow) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// EntityInside ...
+func (PowderSnow) EntityInside(_ cube.Pos, _ *world.World, e world.Entity) {
+	if f, ok := e.(flammableEntity); ok && f.OnFireDuration() > 0 {
+		f.Extinguish()
+	}
+	if f, ok := e.(freezingEntity); ok {
+		f.Freeze()
+	}
+}
+
+// EncodeItem ...
+func (PowderSnow) EncodeItem() (name string, meta int16) {
+	return "minecraft:powder_snow", 0
+}
+
+// EncodeBlock ...
+func (PowderSnow) EncodeBlock() (string, map[string]any) {
+	return "minecraft:powder_snow", nil
+}
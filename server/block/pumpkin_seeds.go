@@ -41,7 +41,7 @@ func (p PumpkinSeeds) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 	if r.Float64() <= p.CalculateGrowthChance(pos, w) && w.Light(pos) >= 8 {
 		if p.Growth < 7 {
 			p.Growth++
-			w.SetBlock(pos, p, nil)
+			grow(w, pos, p)
 		} else {
 			directions := []cube.Direction{cube.North, cube.South, cube.West, cube.East}
 			for _, i := range directions {
@@ -54,9 +54,10 @@ func (p PumpkinSeeds) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 			if _, ok := w.Block(stemPos).(Air); ok {
 				switch w.Block(stemPos.Side(cube.FaceDown)).(type) {
 				case Farmland, Dirt, Grass:
-					p.Direction = direction
-					w.SetBlock(pos, p, nil)
-					w.SetBlock(stemPos, Pumpkin{}, nil)
+					if grow(w, stemPos, Pumpkin{}) {
+						p.Direction = direction
+						w.SetBlock(pos, p, nil)
+					}
 				}
 			}
 		}
@@ -69,8 +70,7 @@ func (p PumpkinSeeds) BoneMeal(pos cube.Pos, w *world.World) bool {
 		return false
 	}
 	p.Growth = min(p.Growth+rand.Intn(4)+2, 7)
-	w.SetBlock(pos, p, nil)
-	return true
+	return grow(w, pos, p)
 }
 
 // UseOnBlock ...
@@ -61,6 +61,7 @@ func init() {
 	world.RegisterBlock(Jukebox{})
 	world.RegisterBlock(Lapis{})
 	world.RegisterBlock(Melon{})
+	world.RegisterBlock(MobSpawner{})
 	world.RegisterBlock(MossCarpet{})
 	world.RegisterBlock(MudBricks{})
 	world.RegisterBlock(Mud{})
@@ -76,6 +77,7 @@ func init() {
 	world.RegisterBlock(Obsidian{Crying: true})
 	world.RegisterBlock(Obsidian{})
 	world.RegisterBlock(PackedIce{})
+	world.RegisterBlock(PowderSnow{})
 	world.RegisterBlock(PackedMud{})
 	world.RegisterBlock(Podzol{})
 	world.RegisterBlock(PolishedBlackstoneBrick{Cracked: true})
@@ -91,6 +93,7 @@ func init() {
 	world.RegisterBlock(Shroomlight{})
 	world.RegisterBlock(SmithingTable{})
 	world.RegisterBlock(Snow{})
+	world.RegisterBlock(Ice{})
 	world.RegisterBlock(SoulSand{})
 	world.RegisterBlock(SoulSoil{})
 	world.RegisterBlock(Sponge{Wet: true})
@@ -113,7 +116,10 @@ func init() {
 	}
 
 	registerAll(allAnvils())
+	registerAll(allBamboo())
+	registerAll(allBambooSaplings())
 	registerAll(allBanners())
+	registerAll(allBeds())
 	registerAll(allBarrels())
 	registerAll(allBasalt())
 	registerAll(allBeetroot())
@@ -148,6 +154,8 @@ func init() {
 	registerAll(allGrindstones())
 	registerAll(allHayBales())
 	registerAll(allItemFrames())
+	registerAll(allIronDoors())
+	registerAll(allIronTrapdoors())
 	registerAll(allKelp())
 	registerAll(allLadders())
 	registerAll(allLanterns())
@@ -170,7 +178,9 @@ func init() {
 	registerAll(allPurpurs())
 	registerAll(allQuartz())
 	registerAll(allSandstones())
+	registerAll(allSaplings())
 	registerAll(allSeaPickles())
+	registerAll(allSeagrass())
 	registerAll(allSigns())
 	registerAll(allSkulls())
 	registerAll(allSlabs())
@@ -185,12 +195,18 @@ func init() {
 	registerAll(allTallGrass())
 	registerAll(allTorches())
 	registerAll(allTrapdoors())
+	registerAll(allVines())
 	registerAll(allWalls())
 	registerAll(allWater())
 	registerAll(allWheat())
 	registerAll(allWood())
 	registerAll(allWool())
 	registerAll(allDecoratedPots())
+	registerAll(allChiseledBookshelves())
+	registerAll(allCampfires())
+	registerAll(allSnowLayers())
+	registerAll(allFrostedIce())
+	registerAll(allSculkSensors())
 }
 
 func init() {
@@ -199,6 +215,7 @@ func init() {
 	world.RegisterItem(AncientDebris{})
 	world.RegisterItem(Andesite{Polished: true})
 	world.RegisterItem(Andesite{})
+	world.RegisterItem(Bamboo{})
 	world.RegisterItem(Barrel{})
 	world.RegisterItem(Barrier{})
 	world.RegisterItem(Basalt{Polished: true})
@@ -259,6 +276,8 @@ func init() {
 	world.RegisterItem(Honeycomb{})
 	world.RegisterItem(InvisibleBedrock{})
 	world.RegisterItem(IronBars{})
+	world.RegisterItem(IronDoor{})
+	world.RegisterItem(IronTrapdoor{})
 	world.RegisterItem(Iron{})
 	world.RegisterItem(ItemFrame{Glowing: true})
 	world.RegisterItem(ItemFrame{})
@@ -271,6 +290,7 @@ func init() {
 	world.RegisterItem(Loom{})
 	world.RegisterItem(MelonSeeds{})
 	world.RegisterItem(Melon{})
+	world.RegisterItem(MobSpawner{})
 	world.RegisterItem(MossCarpet{})
 	world.RegisterItem(MudBricks{})
 	world.RegisterItem(MuddyMangroveRoots{})
@@ -288,6 +308,7 @@ func init() {
 	world.RegisterItem(Obsidian{Crying: true})
 	world.RegisterItem(Obsidian{})
 	world.RegisterItem(PackedIce{})
+	world.RegisterItem(PowderSnow{})
 	world.RegisterItem(PackedMud{})
 	world.RegisterItem(Podzol{})
 	world.RegisterItem(PolishedBlackstoneBrick{Cracked: true})
@@ -310,10 +331,14 @@ func init() {
 	world.RegisterItem(Sand{})
 	world.RegisterItem(SeaLantern{})
 	world.RegisterItem(SeaPickle{})
+	world.RegisterItem(Seagrass{})
 	world.RegisterItem(Shroomlight{})
 	world.RegisterItem(SmithingTable{})
 	world.RegisterItem(Smoker{})
 	world.RegisterItem(Snow{})
+	world.RegisterItem(SnowLayer{})
+	world.RegisterItem(Ice{})
+	world.RegisterItem(SculkSensor{})
 	world.RegisterItem(SoulSand{})
 	world.RegisterItem(SoulSoil{})
 	world.RegisterItem(Sponge{Wet: true})
@@ -326,8 +351,13 @@ func init() {
 	world.RegisterItem(TNT{})
 	world.RegisterItem(Terracotta{})
 	world.RegisterItem(Tuff{})
+	world.RegisterItem(Vines{})
 	world.RegisterItem(WheatSeeds{})
 	world.RegisterItem(DecoratedPot{})
+	world.RegisterItem(ChiseledBookshelf{})
+	for _, t := range FireTypes() {
+		world.RegisterItem(Campfire{Type: t})
+	}
 	world.RegisterItem(item.Bucket{Content: item.LiquidBucketContent(Lava{})})
 	world.RegisterItem(item.Bucket{Content: item.LiquidBucketContent(Water{})})
 	world.RegisterItem(item.Bucket{Content: item.MilkBucketContent()})
@@ -353,6 +383,7 @@ func init() {
 	}
 	for _, c := range item.Colours() {
 		world.RegisterItem(Banner{Colour: c})
+		world.RegisterItem(Bed{Colour: c})
 		world.RegisterItem(Carpet{Colour: c})
 		world.RegisterItem(ConcretePowder{Colour: c})
 		world.RegisterItem(Concrete{Colour: c})
@@ -377,6 +408,9 @@ func init() {
 		world.RegisterItem(Wood{Wood: w, Stripped: true})
 		world.RegisterItem(Wood{Wood: w})
 	}
+	for _, w := range saplingWoodTypes() {
+		world.RegisterItem(Sapling{Wood: w})
+	}
 	for _, ore := range OreTypes() {
 		world.RegisterItem(CoalOre{Type: ore})
 		world.RegisterItem(CopperOre{Type: ore})
@@ -19,7 +19,7 @@ type Sand struct {
 // SoilFor ...
 func (s Sand) SoilFor(block world.Block) bool {
 	switch block.(type) {
-	case Cactus, DeadBush, SugarCane:
+	case Cactus, DeadBush, SugarCane, Bamboo, BambooSapling:
 		return true
 	}
 	return false
@@ -30,6 +30,11 @@ func (s Sand) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
 	s.fall(s, pos, w)
 }
 
+// BoneMeal grows seagrass on and around the sand if it is underwater.
+func (s Sand) BoneMeal(pos cube.Pos, w *world.World) bool {
+	return growSeagrass(pos, w)
+}
+
 // BreakInfo ...
 func (s Sand) BreakInfo() BreakInfo {
 	return newBreakInfo(0.5, alwaysHarvestable, shovelEffective, oneOf(s))
@@ -0,0 +1,177 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// Sapling is a non-solid plant that grows into a tree over time. Saplings drop from leaves and may be placed on
+// dirt and grass. Dark oak saplings only grow when four of them are placed in a two by two square.
+type Sapling struct {
+	empty
+	transparent
+
+	// Wood is the type of wood of the tree that the sapling grows into. Only oak, spruce, birch, jungle, acacia
+	// and dark oak saplings exist.
+	Wood WoodType
+	// Ready is true if the sapling has passed its first stage of growth. A ready sapling grows into a tree the next
+	// time it grows.
+	Ready bool
+}
+
+// UseOnBlock ...
+func (s Sapling) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, s)
+	if !used {
+		return false
+	}
+	if !supportsVegetation(s, w.Block(pos.Side(cube.FaceDown))) {
+		return false
+	}
+
+	place(w, pos, s, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (s Sapling) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !supportsVegetation(s, w.Block(pos.Side(cube.FaceDown))) {
+		w.SetBlock(pos, nil, nil)
+		w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: s})
+		dropItem(w, item.NewStack(Sapling{Wood: s.Wood}, 1), pos.Vec3Centre())
+	}
+}
+
+// RandomTick ...
+func (s Sapling) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
+	if w.Light(pos.Side(cube.FaceUp)) >= 9 && r.Intn(7) == 0 {
+		s.advance(pos, w, r)
+	}
+}
+
+// BoneMeal ...
+func (s Sapling) BoneMeal(pos cube.Pos, w *world.World) bool {
+	if rand.Float64() < 0.45 {
+		s.advance(pos, w, rand.New(rand.NewSource(rand.Int63())))
+	}
+	// Bone meal is always used up on saplings, even if the sapling did not grow.
+	return true
+}
+
+// advance advances the growth of the sapling. A sapling that is not yet ready becomes ready, while a sapling that is
+// ready attempts to grow into a tree.
+func (s Sapling) advance(pos cube.Pos, w *world.World, r *rand.Rand) {
+	if !s.Ready {
+		s.Ready = true
+		w.SetBlock(pos, s, nil)
+		return
+	}
+	s.growTree(pos, w, r)
+}
+
+// growTree attempts to grow the sapling at the position passed into a tree. False is returned if there was not
+// enough space for the tree, if the sapling is a dark oak sapling without three neighbouring saplings or if the
+// world.Handler cancelled the growth.
+func (s Sapling) growTree(pos cube.Pos, w *world.World, r *rand.Rand) bool {
+	base := pos
+	if s.Wood == DarkOakWood() {
+		corner, ok := s.square(pos, w)
+		if !ok {
+			return false
+		}
+		base = corner
+	}
+	t := newTree(s.Wood, r)
+	if !t.canGrow(base, w) {
+		return false
+	}
+	structurePos := base.Add(t.min)
+
+	ctx := event.C()
+	if w.Handler().HandleTreeGrow(ctx, pos, structurePos, t); ctx.Cancelled() {
+		return false
+	}
+	for _, p := range t.trunk() {
+		// Trees turn the grass below their trunk into dirt.
+		if _, ok := w.Block(base.Add(p).Side(cube.FaceDown)).(Grass); ok {
+			w.SetBlock(base.Add(p).Side(cube.FaceDown), Dirt{}, nil)
+		}
+	}
+	w.BuildStructure(structurePos, t)
+	return true
+}
+
+// square looks for a two by two square of saplings of the same wood type that the sapling at the position passed is
+// part of. If found, the north-west corner of the square is returned.
+func (s Sapling) square(pos cube.Pos, w *world.World) (cube.Pos, bool) {
+	for x := -1; x <= 0; x++ {
+		for z := -1; z <= 0; z++ {
+			corner := pos.Add(cube.Pos{x, 0, z})
+			if s.sameSapling(corner, w) && s.sameSapling(corner.Add(cube.Pos{1, 0, 0}), w) &&
+				s.sameSapling(corner.Add(cube.Pos{0, 0, 1}), w) && s.sameSapling(corner.Add(cube.Pos{1, 0, 1}), w) {
+				return corner, true
+			}
+		}
+	}
+	return pos, false
+}
+
+// sameSapling checks if the block at the position passed is a sapling of the same wood type as s.
+func (s Sapling) sameSapling(pos cube.Pos, w *world.World) bool {
+	sapling, ok := w.Block(pos).(Sapling)
+	return ok && sapling.Wood == s.Wood
+}
+
+// HasLiquidDrops ...
+func (Sapling) HasLiquidDrops() bool {
+	return true
+}
+
+// SideClosed ...
+func (Sapling) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// BreakInfo ...
+func (s Sapling) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(Sapling{Wood: s.Wood}))
+}
+
+// FuelInfo ...
+func (Sapling) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 5)
+}
+
+// CompostChance ...
+func (Sapling) CompostChance() float64 {
+	return 0.3
+}
+
+// EncodeItem ...
+func (s Sapling) EncodeItem() (name string, meta int16) {
+	return "minecraft:sapling", int16(s.Wood.Uint8())
+}
+
+// EncodeBlock ...
+func (s Sapling) EncodeBlock() (name string, properties map[string]any) {
+	return "minecraft:sapling", map[string]any{"sapling_type": s.Wood.String(), "age_bit": s.Ready}
+}
+
+// saplingWoodTypes returns all wood types that have a sapling.
+func saplingWoodTypes() []WoodType {
+	return []WoodType{OakWood(), SpruceWood(), BirchWood(), JungleWood(), AcaciaWood(), DarkOakWood()}
+}
+
+// allSaplings returns all possible states of a sapling block.
+func allSaplings() (saplings []world.Block) {
+	for _, w := range saplingWoodTypes() {
+		saplings = append(saplings, Sapling{Wood: w}, Sapling{Wood: w, Ready: true})
+	}
+	return
+}
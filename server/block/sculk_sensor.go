@@ -0,0 +1,146 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// sculkSensorActiveDuration is the duration that a sculk sensor stays active after detecting a vibration.
+	sculkSensorActiveDuration = time.Second * 3 / 2
+	// sculkSensorCooldownDuration is the duration of the cooldown phase that follows the active phase.
+	sculkSensorCooldownDuration = time.Second / 2
+)
+
+// SculkSensor is a block that detects vibrations caused by game events nearby, such as entities walking or
+// blocks being placed, and emits a redstone signal when it does. Vibrations do not pass through wool.
+type SculkSensor struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Phase is the current phase of the sculk sensor. Sculk sensors only detect vibrations while inactive.
+	Phase SculkSensorPhase
+	// Power is the strength of the redstone signal emitted by the sculk sensor while it is active. The closer
+	// the vibration detected, the stronger the signal.
+	Power int
+	// Frequency is the frequency of the last vibration detected by the sculk sensor. It is the strength of the
+	// signal that a comparator reading the sculk sensor outputs.
+	Frequency int
+}
+
+// Model ...
+func (SculkSensor) Model() world.BlockModel {
+	return model.Slab{}
+}
+
+// LightEmissionLevel ...
+func (SculkSensor) LightEmissionLevel() uint8 {
+	return 1
+}
+
+// BreakInfo ...
+func (SculkSensor) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, hoeEffective, silkTouchOnlyDrop(SculkSensor{})).withXPDropRange(5, 5)
+}
+
+// VibrationRange ...
+func (SculkSensor) VibrationRange() float64 {
+	return 8
+}
+
+// ListenVibration activates the sculk sensor if it is inactive and the vibration is not occluded by wool. The
+// redstone signal emitted depends on the distance of the vibration.
+func (s SculkSensor) ListenVibration(pos cube.Pos, src mgl64.Vec3, ev world.GameEvent, _ world.Entity, w *world.World) {
+	if s.Phase != InactiveSculkSensorPhase() || cube.PosFromVec3(src) == pos {
+		return
+	}
+	if vibrationOccluded(src, pos, w) {
+		return
+	}
+	dist := src.Sub(pos.Vec3Centre()).Len()
+	s.Phase, s.Frequency = ActiveSculkSensorPhase(), ev.Frequency()
+	s.Power = int(math.Max(1, 15-math.Floor(15*dist/s.VibrationRange())))
+	w.SetBlock(pos, s, nil)
+	w.ScheduleBlockUpdate(pos, sculkSensorActiveDuration)
+	w.PlaySound(pos.Vec3Centre(), sound.SculkSensorPowerOn{})
+}
+
+// vibrationOccluded checks if a vibration travelling from src to the block position passed is blocked by wool
+// on its way.
+func vibrationOccluded(src mgl64.Vec3, pos cube.Pos, w *world.World) (occluded bool) {
+	start := cube.PosFromVec3(src)
+	trace.TraverseBlocks(src, pos.Vec3Centre(), func(p cube.Pos) bool {
+		if p == start || p == pos {
+			return true
+		}
+		_, occluded = w.Block(p).(Wool)
+		return !occluded
+	})
+	return occluded
+}
+
+// ScheduledTick moves the sculk sensor to its next phase once it has been active or in cooldown for long enough.
+func (s SculkSensor) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	switch s.Phase {
+	case ActiveSculkSensorPhase():
+		s.Phase, s.Power = CooldownSculkSensorPhase(), 0
+		w.SetBlock(pos, s, nil)
+		w.ScheduleBlockUpdate(pos, sculkSensorCooldownDuration)
+		w.PlaySound(pos.Vec3Centre(), sound.SculkSensorPowerOff{})
+	case CooldownSculkSensorPhase():
+		s.Phase = InactiveSculkSensorPhase()
+		w.SetBlock(pos, s, nil)
+	}
+}
+
+// RedstonePower returns the power of the sculk sensor while it is active, or 0 otherwise.
+func (s SculkSensor) RedstonePower(cube.Pos, cube.Face, *world.World) int {
+	if s.Phase != ActiveSculkSensorPhase() {
+		return 0
+	}
+	return s.Power
+}
+
+// ComparatorSignal returns the strength of the signal that a comparator reading the sculk sensor outputs. The
+// signal is equal to the frequency of the last vibration detected.
+func (s SculkSensor) ComparatorSignal() int {
+	return s.Frequency
+}
+
+// DecodeNBT ...
+func (s SculkSensor) DecodeNBT(data map[string]any) any {
+	s.Power = int(nbtconv.Int32(data, "power"))
+	s.Frequency = int(nbtconv.Int32(data, "frequency"))
+	return s
+}
+
+// EncodeNBT ...
+func (s SculkSensor) EncodeNBT() map[string]any {
+	return map[string]any{"id": "SculkSensor", "power": int32(s.Power), "frequency": int32(s.Frequency)}
+}
+
+// EncodeItem ...
+func (SculkSensor) EncodeItem() (name string, meta int16) {
+	return "minecraft:sculk_sensor", 0
+}
+
+// EncodeBlock ...
+func (s SculkSensor) EncodeBlock() (string, map[string]any) {
+	return "minecraft:sculk_sensor", map[string]any{"sculk_sensor_phase": int32(s.Phase.Uint8())}
+}
+
+// allSculkSensors ...
+func allSculkSensors() (sensors []world.Block) {
+	for _, p := range SculkSensorPhases() {
+		sensors = append(sensors, SculkSensor{Phase: p})
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package block
+
+// SculkSensorPhase represents the phase that a sculk sensor is in. A sculk sensor is inactive by default, active
+// for a short time after detecting a vibration and then goes through a cooldown phase before it becomes
+// inactive again.
+type SculkSensorPhase struct {
+	sculkSensorPhase
+}
+
+type sculkSensorPhase uint8
+
+// InactiveSculkSensorPhase is the phase of a sculk sensor that is listening for vibrations.
+func InactiveSculkSensorPhase() SculkSensorPhase {
+	return SculkSensorPhase{0}
+}
+
+// ActiveSculkSensorPhase is the phase of a sculk sensor that detected a vibration and emits a redstone signal.
+func ActiveSculkSensorPhase() SculkSensorPhase {
+	return SculkSensorPhase{1}
+}
+
+// CooldownSculkSensorPhase is the phase of a sculk sensor that was recently active. Sculk sensors do not detect
+// vibrations while in this phase.
+func CooldownSculkSensorPhase() SculkSensorPhase {
+	return SculkSensorPhase{2}
+}
+
+// Uint8 returns the sculk sensor phase as a uint8.
+func (s sculkSensorPhase) Uint8() uint8 {
+	return uint8(s)
+}
+
+// String ...
+func (s sculkSensorPhase) String() string {
+	switch s {
+	case 0:
+		return "inactive"
+	case 1:
+		return "active"
+	case 2:
+		return "cooldown"
+	}
+	panic("unknown sculk sensor phase")
+}
+
+// SculkSensorPhases ...
+func SculkSensorPhases() []SculkSensorPhase {
+	return []SculkSensorPhase{InactiveSculkSensorPhase(), ActiveSculkSensorPhase(), CooldownSculkSensorPhase()}
+}
@@ -0,0 +1,156 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// Seagrass is a non-solid plant that grows on the bottom of bodies of water. It may be grown into tall
+// seagrass using bone meal.
+type Seagrass struct {
+	transparent
+	empty
+	sourceWaterDisplacer
+
+	// Tall specifies if the seagrass is two blocks high.
+	Tall bool
+	// UpperPart is set if the seagrass is the upper part of tall seagrass.
+	UpperPart bool
+}
+
+// BoneMeal ...
+func (s Seagrass) BoneMeal(pos cube.Pos, w *world.World) bool {
+	if s.Tall {
+		return false
+	}
+	above := pos.Side(cube.FaceUp)
+	if !underwater(w, above) {
+		return false
+	}
+	if !grow(w, pos, Seagrass{Tall: true}) {
+		return false
+	}
+	w.SetBlock(above, Seagrass{Tall: true, UpperPart: true}, nil)
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (s Seagrass) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !underwater(w, pos) {
+		s.breakNaturally(pos, w)
+		return
+	}
+	if s.Tall && s.UpperPart {
+		if bottom, ok := w.Block(pos.Side(cube.FaceDown)).(Seagrass); !ok || !bottom.Tall || bottom.UpperPart {
+			s.breakNaturally(pos, w)
+		}
+		return
+	}
+	if s.Tall {
+		if upper, ok := w.Block(pos.Side(cube.FaceUp)).(Seagrass); !ok || !upper.Tall || !upper.UpperPart {
+			s.breakNaturally(pos, w)
+			return
+		}
+	}
+	if below := pos.Side(cube.FaceDown); !w.Block(below).Model().FaceSolid(below, cube.FaceUp, w) {
+		s.breakNaturally(pos, w)
+	}
+}
+
+// breakNaturally removes the seagrass, leaving only the water it was in.
+func (s Seagrass) breakNaturally(pos cube.Pos, w *world.World) {
+	w.SetBlock(pos, nil, nil)
+	w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: s})
+}
+
+// UseOnBlock ...
+func (s Seagrass) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, s)
+	if !used {
+		return false
+	}
+	if below := pos.Side(cube.FaceDown); !w.Block(below).Model().FaceSolid(below, cube.FaceUp, w) {
+		return false
+	}
+	if !underwater(w, pos) {
+		return false
+	}
+	place(w, pos, Seagrass{}, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (s Seagrass) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(t item.Tool, enchantments []item.Enchantment) []item.Stack {
+		if t.ToolType() == item.TypeShears || hasSilkTouch(enchantments) {
+			return []item.Stack{item.NewStack(Seagrass{}, 1)}
+		}
+		return nil
+	})
+}
+
+// CompostChance ...
+func (Seagrass) CompostChance() float64 {
+	return 0.3
+}
+
+// SideClosed ...
+func (Seagrass) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// EncodeItem ...
+func (Seagrass) EncodeItem() (name string, meta int16) {
+	return "minecraft:seagrass", 0
+}
+
+// EncodeBlock ...
+func (s Seagrass) EncodeBlock() (string, map[string]any) {
+	typ := "default"
+	if s.Tall {
+		typ = "double_bot"
+		if s.UpperPart {
+			typ = "double_top"
+		}
+	}
+	return "minecraft:seagrass", map[string]any{"sea_grass_type": typ}
+}
+
+// allSeagrass ...
+func allSeagrass() []world.Block {
+	return []world.Block{Seagrass{}, Seagrass{Tall: true}, Seagrass{Tall: true, UpperPart: true}}
+}
+
+// underwater checks if the position passed is filled with still water.
+func underwater(w *world.World, pos cube.Pos) bool {
+	l, ok := w.Liquid(pos)
+	if !ok {
+		return false
+	}
+	water, ok := l.(Water)
+	return ok && water.Depth == 8 && !water.Falling
+}
+
+// growSeagrass grows seagrass on and around the underwater block at the position passed, as happens when bone
+// meal is used on it. False is returned if the block is not underwater.
+func growSeagrass(pos cube.Pos, w *world.World) bool {
+	if _, ok := w.Block(pos.Side(cube.FaceUp)).(Water); !ok || !underwater(w, pos.Side(cube.FaceUp)) {
+		return false
+	}
+	for i := 0; i < 64; i++ {
+		target := pos.Add(cube.Pos{rand.Intn(7) - 3, rand.Intn(3) - 1, rand.Intn(7) - 3})
+		above := target.Side(cube.FaceUp)
+		if _, ok := w.Block(above).(Water); !ok || !underwater(w, above) {
+			continue
+		}
+		if !w.Block(target).Model().FaceSolid(target, cube.FaceUp, w) {
+			continue
+		}
+		grow(w, above, Seagrass{})
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// SnowLayer is a thin layer of snow that forms on the ground while it is snowing. Up to eight layers may be
+// stacked on top of each other.
+type SnowLayer struct {
+	transparent
+
+	// Height is the amount of layers of the snow layer minus one, ranging from 0 to 7.
+	Height int
+}
+
+// Model ...
+func (s SnowLayer) Model() world.BlockModel {
+	return model.Snow{Height: s.Height}
+}
+
+// ReplaceableBy ...
+func (s SnowLayer) ReplaceableBy(b world.Block) bool {
+	if _, ok := b.(SnowLayer); ok {
+		return false
+	}
+	return s.Height == 0
+}
+
+// SideClosed ...
+func (s SnowLayer) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return s.Height == 7
+}
+
+// BreakInfo ...
+func (s SnowLayer) BreakInfo() BreakInfo {
+	return newBreakInfo(0.1, shovelEffective, shovelEffective, silkTouchDrop(item.NewStack(item.Snowball{}, s.Height+1), item.NewStack(SnowLayer{}, s.Height+1)))
+}
+
+// UseOnBlock adds a layer to an existing snow layer if one was clicked, or places a new snow layer on top of a
+// block with a solid top face.
+func (s SnowLayer) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	if existing, ok := w.Block(pos).(SnowLayer); ok && existing.Height < 7 {
+		existing.Height++
+		place(w, pos, existing, user, ctx)
+		return placed(ctx)
+	}
+	pos, _, used := firstReplaceable(w, pos, face, s)
+	if !used {
+		return false
+	}
+	s.Height = 0
+	below := pos.Side(cube.FaceDown)
+	if !w.Block(below).Model().FaceSolid(below, cube.FaceUp, w) {
+		return false
+	}
+	place(w, pos, s, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (s SnowLayer) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	below := pos.Side(cube.FaceDown)
+	if !w.Block(below).Model().FaceSolid(below, cube.FaceUp, w) {
+		w.SetBlock(pos, nil, nil)
+	}
+}
+
+// RandomTick melts the snow layer if it is lit by a block light source brighter than 11.
+func (s SnowLayer) RandomTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	if blockLight(pos, w) > 11 {
+		w.SetBlock(pos, nil, nil)
+	}
+}
+
+// AccumulateSnow adds a layer to the snow layer while it is snowing, until the snow layer is seven layers high.
+func (s SnowLayer) AccumulateSnow(pos cube.Pos, w *world.World) {
+	if s.Height < 6 {
+		s.Height++
+		w.SetBlock(pos, s, nil)
+	}
+}
+
+// EncodeItem ...
+func (SnowLayer) EncodeItem() (name string, meta int16) {
+	return "minecraft:snow_layer", 0
+}
+
+// EncodeBlock ...
+func (s SnowLayer) EncodeBlock() (string, map[string]any) {
+	return "minecraft:snow_layer", map[string]any{"height": int32(s.Height), "covered_bit": false}
+}
+
+// allSnowLayers ...
+func allSnowLayers() (layers []world.Block) {
+	for i := 0; i < 8; i++ {
+		layers = append(layers, SnowLayer{Height: i})
+	}
+	return
+}
+
+// blockLight returns the light level at the position passed that is emitted by blocks. Because sky light is
+// stored separately, any light brighter than the sky light at the position is assumed to be emitted by blocks.
+func blockLight(pos cube.Pos, w *world.World) uint8 {
+	if l := w.Light(pos); l > w.SkyLight(pos) {
+		return l
+	}
+	return 0
+}
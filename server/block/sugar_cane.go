@@ -47,10 +47,10 @@ func (c SugarCane) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 		c.Age++
 	} else if c.Age == 15 {
 		c.Age = 0
-		if c.canGrowHere(pos.Side(cube.FaceDown), w, false) {
+		if c.canGrowHere(pos, w, true) {
 			for y := 1; y < 3; y++ {
 				if _, ok := w.Block(pos.Add(cube.Pos{0, y})).(Air); ok {
-					w.SetBlock(pos.Add(cube.Pos{0, y}), SugarCane{}, nil)
+					grow(w, pos.Add(cube.Pos{0, y}), SugarCane{})
 					break
 				} else if _, ok := w.Block(pos.Add(cube.Pos{0, y})).(SugarCane); !ok {
 					break
@@ -62,19 +62,28 @@ func (c SugarCane) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 }
 
 // BoneMeal ...
-func (c SugarCane) BoneMeal(pos cube.Pos, w *world.World) bool {
+func (c SugarCane) BoneMeal(pos cube.Pos, w *world.World) (success bool) {
 	for _, ok := w.Block(pos.Side(cube.FaceDown)).(SugarCane); ok; _, ok = w.Block(pos.Side(cube.FaceDown)).(SugarCane) {
 		pos = pos.Side(cube.FaceDown)
 	}
-	if c.canGrowHere(pos.Side(cube.FaceDown), w, false) {
-		for y := 1; y < 3; y++ {
-			if _, ok := w.Block(pos.Add(cube.Pos{0, y})).(Air); ok {
-				w.SetBlock(pos.Add(cube.Pos{0, y}), SugarCane{}, nil)
+	if !c.canGrowHere(pos, w, false) {
+		return false
+	}
+	// Bone meal grows the sugar cane up to its maximum height of three blocks at once.
+	for y := 1; y < 3; y++ {
+		switch w.Block(pos.Add(cube.Pos{0, y})).(type) {
+		case SugarCane:
+			continue
+		case Air:
+			if !grow(w, pos.Add(cube.Pos{0, y}), SugarCane{}) {
+				return success
 			}
+			success = true
+		default:
+			return success
 		}
-		return true
 	}
-	return false
+	return success
 }
 
 // canGrowHere implements logic to check if sugar cane can live/grow here.
@@ -105,6 +114,11 @@ func (c SugarCane) BreakInfo() BreakInfo {
 	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(c))
 }
 
+// CompostChance ...
+func (SugarCane) CompostChance() float64 {
+	return 0.5
+}
+
 // EncodeItem ...
 func (c SugarCane) EncodeItem() (name string, meta int16) {
 	return "minecraft:sugar_cane", 0
@@ -0,0 +1,204 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand"
+)
+
+// tree is a world.Structure of a tree grown from a Sapling. The blocks of the tree are generated when it is
+// created and are stored relative to the position of the sapling it grows from.
+type tree struct {
+	min, max cube.Pos
+	blocks   map[cube.Pos]world.Block
+}
+
+// newTree generates a tree of the wood type passed, using the random source passed to vary its shape.
+func newTree(wood WoodType, r *rand.Rand) *tree {
+	t := &tree{blocks: make(map[cube.Pos]world.Block)}
+	switch wood {
+	case SpruceWood():
+		t.spruce(6+r.Intn(4), r)
+	case BirchWood():
+		t.blob(wood, 5+r.Intn(3), r)
+	case JungleWood():
+		t.blob(wood, 4+r.Intn(7), r)
+	case AcaciaWood():
+		t.acacia(5+r.Intn(3), r)
+	case DarkOakWood():
+		t.darkOak(6+r.Intn(3), r)
+	default:
+		t.blob(wood, 4+r.Intn(3), r)
+	}
+	return t
+}
+
+// Dimensions ...
+func (t *tree) Dimensions() [3]int {
+	return [3]int{t.max[0] - t.min[0] + 1, t.max[1] - t.min[1] + 1, t.max[2] - t.min[2] + 1}
+}
+
+// At ...
+func (t *tree) At(x, y, z int, blockAt func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	b, ok := t.blocks[t.min.Add(cube.Pos{x, y, z})]
+	if !ok || !treeReplaceable(blockAt(x, y, z), b) {
+		return nil, nil
+	}
+	return b, nil
+}
+
+// canGrow checks if the tree has space to grow at the position of the sapling passed. Only the logs of the tree
+// are checked: Leaves will not replace any blocks in the way when the tree is built.
+func (t *tree) canGrow(pos cube.Pos, w *world.World) bool {
+	if pos[1]+t.max[1] > w.Range()[1] {
+		return false
+	}
+	for p, b := range t.blocks {
+		if _, ok := b.(Log); ok && !treeReplaceable(w.Block(pos.Add(p)), b) {
+			return false
+		}
+	}
+	return true
+}
+
+// trunk returns the positions of the lowest logs of the tree, relative to the sapling it grows from.
+func (t *tree) trunk() (trunk []cube.Pos) {
+	for p, b := range t.blocks {
+		if _, ok := b.(Log); ok && p[1] == 0 {
+			trunk = append(trunk, p)
+		}
+	}
+	return
+}
+
+// log sets a log of the wood type passed at a position relative to the sapling.
+func (t *tree) log(wood WoodType, pos cube.Pos) {
+	t.set(pos, Log{Wood: wood, Axis: cube.Y})
+}
+
+// leaves sets leaves of the wood type passed at a position relative to the sapling, unless a log was already
+// set at that position.
+func (t *tree) leaves(wood WoodType, pos cube.Pos) {
+	if _, ok := t.blocks[pos].(Log); !ok {
+		t.set(pos, Leaves{Wood: wood})
+	}
+}
+
+// leafLayer sets a square layer of leaves with the radius passed around centre. Corners of the layer are left
+// out if skipCorner returns true for them.
+func (t *tree) leafLayer(wood WoodType, centre cube.Pos, radius int, skipCorner func() bool) {
+	for x := -radius; x <= radius; x++ {
+		for z := -radius; z <= radius; z++ {
+			if radius > 0 && abs(x) == radius && abs(z) == radius && skipCorner() {
+				continue
+			}
+			t.leaves(wood, centre.Add(cube.Pos{x, 0, z}))
+		}
+	}
+}
+
+// set sets a block at a position relative to the sapling and updates the bounds of the tree.
+func (t *tree) set(pos cube.Pos, b world.Block) {
+	if len(t.blocks) == 0 {
+		t.min, t.max = pos, pos
+	}
+	for i := 0; i < 3; i++ {
+		if pos[i] < t.min[i] {
+			t.min[i] = pos[i]
+		}
+		if pos[i] > t.max[i] {
+			t.max[i] = pos[i]
+		}
+	}
+	t.blocks[pos] = b
+}
+
+// blob generates a tree with a straight trunk and a rounded crown of leaves, such as an oak or birch tree.
+func (t *tree) blob(wood WoodType, height int, r *rand.Rand) {
+	for y := 0; y < height; y++ {
+		t.log(wood, cube.Pos{0, y, 0})
+	}
+	for y := height - 3; y <= height; y++ {
+		// The two lower layers of leaves have a radius of 2, the upper two layers a radius of 1.
+		offset := y - height
+		t.leafLayer(wood, cube.Pos{0, y, 0}, 1-offset/2, func() bool {
+			return offset == 0 || r.Intn(2) == 0
+		})
+	}
+}
+
+// spruce generates a spruce tree with a straight trunk and a cone of leaves.
+func (t *tree) spruce(height int, r *rand.Rand) {
+	wood := SpruceWood()
+	for y := 0; y < height; y++ {
+		t.log(wood, cube.Pos{0, y, 0})
+	}
+	t.leaves(wood, cube.Pos{0, height, 0})
+
+	maxRadius, bottom := 2+r.Intn(2), 1+r.Intn(2)
+	radius := 0
+	for y := height - 1; y > bottom; y-- {
+		t.leafLayer(wood, cube.Pos{0, y, 0}, radius, func() bool { return true })
+		if radius >= maxRadius {
+			radius = 1
+		} else {
+			radius++
+		}
+	}
+}
+
+// acacia generates an acacia tree with a trunk that bends into a random horizontal direction near its top and a
+// flat crown of leaves.
+func (t *tree) acacia(height int, r *rand.Rand) {
+	wood := AcaciaWood()
+	bend := height - 1 - r.Intn(3)
+	dir := cube.Directions()[r.Intn(4)].Face()
+
+	pos := cube.Pos{}
+	for y := 0; y < height; y++ {
+		if y >= bend {
+			pos = pos.Side(dir)
+		}
+		pos[1] = y
+		t.log(wood, pos)
+	}
+	t.leafLayer(wood, pos, 3, func() bool { return true })
+	t.leafLayer(wood, pos.Side(cube.FaceUp), 1, func() bool { return false })
+}
+
+// darkOak generates a dark oak tree with a trunk of two by two logs and a wide crown of leaves. The sapling is
+// at the north-west corner of the trunk.
+func (t *tree) darkOak(height int, r *rand.Rand) {
+	wood := DarkOakWood()
+	for y := 0; y < height; y++ {
+		for _, p := range []cube.Pos{{0, y, 0}, {1, y, 0}, {0, y, 1}, {1, y, 1}} {
+			t.log(wood, p)
+		}
+	}
+	skip := func() bool { return r.Intn(2) == 0 }
+	for y := height - 2; y < height; y++ {
+		for x := -2; x <= 3; x++ {
+			for z := -2; z <= 3; z++ {
+				if (x == -2 || x == 3) && (z == -2 || z == 3) && skip() {
+					continue
+				}
+				t.leaves(wood, cube.Pos{x, y, z})
+			}
+		}
+	}
+	for x := -1; x <= 2; x++ {
+		for z := -1; z <= 2; z++ {
+			t.leaves(wood, cube.Pos{x, height, z})
+		}
+	}
+}
+
+// treeReplaceable checks if a block of a tree may replace the existing block passed when the tree is built.
+func treeReplaceable(existing, with world.Block) bool {
+	switch existing.(type) {
+	case Leaves, Sapling:
+		return true
+	}
+	r, ok := existing.(Replaceable)
+	return ok && r.ReplaceableBy(with)
+}
@@ -0,0 +1,233 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// Vines are climbable plants that attach to the sides of blocks. Vines slowly spread downwards and upwards over
+// time.
+type Vines struct {
+	empty
+	replaceable
+	transparent
+
+	// NorthDirection is true if the vines are attached to the block on the north side.
+	NorthDirection bool
+	// EastDirection is true if the vines are attached to the block on the east side.
+	EastDirection bool
+	// SouthDirection is true if the vines are attached to the block on the south side.
+	SouthDirection bool
+	// WestDirection is true if the vines are attached to the block on the west side.
+	WestDirection bool
+}
+
+// Attachment returns whether the vines are attached to the block in the direction passed.
+func (v Vines) Attachment(d cube.Direction) bool {
+	switch d {
+	case cube.North:
+		return v.NorthDirection
+	case cube.East:
+		return v.EastDirection
+	case cube.South:
+		return v.SouthDirection
+	case cube.West:
+		return v.WestDirection
+	}
+	panic("should never happen")
+}
+
+// WithAttachment returns the vines with the attachment in the direction passed set to the value passed.
+func (v Vines) WithAttachment(d cube.Direction, attached bool) Vines {
+	switch d {
+	case cube.North:
+		v.NorthDirection = attached
+	case cube.East:
+		v.EastDirection = attached
+	case cube.South:
+		v.SouthDirection = attached
+	case cube.West:
+		v.WestDirection = attached
+	}
+	return v
+}
+
+// Attachments returns all directions that the vines are attached to.
+func (v Vines) Attachments() (attachments []cube.Direction) {
+	for _, d := range cube.Directions() {
+		if v.Attachment(d) {
+			attachments = append(attachments, d)
+		}
+	}
+	return
+}
+
+// UseOnBlock ...
+func (v Vines) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(w, pos, face, v)
+	if !used {
+		return false
+	}
+	if face == cube.FaceUp || face == cube.FaceDown {
+		return false
+	}
+	d := face.Opposite().Direction()
+	if !v.canAttach(pos, d, w) {
+		return false
+	}
+	if existing, ok := w.Block(pos).(Vines); ok {
+		// Placing vines inside existing vines adds an attachment to them.
+		if existing.Attachment(d) {
+			return false
+		}
+		v = existing
+	}
+
+	place(w, pos, v.WithAttachment(d, true), user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (v Vines) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	above, _ := w.Block(pos.Side(cube.FaceUp)).(Vines)
+	updated := v
+	for _, d := range v.Attachments() {
+		// Vines hanging from vines above remain in place without a block to support them.
+		if !v.canAttach(pos, d, w) && !above.Attachment(d) {
+			updated = updated.WithAttachment(d, false)
+		}
+	}
+	if updated == v {
+		return
+	}
+	if len(updated.Attachments()) == 0 {
+		w.SetBlock(pos, nil, nil)
+		return
+	}
+	w.SetBlock(pos, updated, nil)
+}
+
+// RandomTick ...
+func (v Vines) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
+	if r.Intn(4) != 0 {
+		return
+	}
+	if r.Intn(2) == 0 {
+		v.spreadDown(pos, w, r)
+		return
+	}
+	v.spreadUp(pos, w, r)
+}
+
+// spreadDown spreads a random selection of the attachments of the vines to the block below.
+func (v Vines) spreadDown(pos cube.Pos, w *world.World, r *rand.Rand) {
+	below := pos.Side(cube.FaceDown)
+	if below.OutOfBounds(w.Range()) {
+		return
+	}
+	var existing Vines
+	switch b := w.Block(below).(type) {
+	case Air:
+	case Vines:
+		existing = b
+	default:
+		return
+	}
+	spread := existing
+	for _, d := range v.Attachments() {
+		if r.Intn(2) == 0 {
+			spread = spread.WithAttachment(d, true)
+		}
+	}
+	if spread != existing {
+		grow(w, below, spread)
+	}
+}
+
+// spreadUp spreads the attachments of the vines to the block above, as long as the blocks on those sides can
+// support them.
+func (v Vines) spreadUp(pos cube.Pos, w *world.World, r *rand.Rand) {
+	above := pos.Side(cube.FaceUp)
+	if above.OutOfBounds(w.Range()) {
+		return
+	}
+	if _, ok := w.Block(above).(Air); !ok {
+		return
+	}
+	var spread Vines
+	for _, d := range v.Attachments() {
+		if r.Intn(2) == 0 && v.canAttach(above, d, w) {
+			spread = spread.WithAttachment(d, true)
+		}
+	}
+	if len(spread.Attachments()) != 0 {
+		grow(w, above, spread)
+	}
+}
+
+// canAttach checks if vines at the position passed can attach to the block in the direction passed.
+func (Vines) canAttach(pos cube.Pos, d cube.Direction, w *world.World) bool {
+	side := pos.Side(d.Face())
+	return w.Block(side).Model().FaceSolid(side, d.Opposite().Face(), w)
+}
+
+// EntityInside ...
+func (Vines) EntityInside(_ cube.Pos, _ *world.World, e world.Entity) {
+	if fallEntity, ok := e.(fallDistanceEntity); ok {
+		fallEntity.ResetFallDistance()
+	}
+}
+
+// SideClosed ...
+func (Vines) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// HasLiquidDrops ...
+func (Vines) HasLiquidDrops() bool {
+	return true
+}
+
+// FlammabilityInfo ...
+func (Vines) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(15, 100, true)
+}
+
+// BreakInfo ...
+func (v Vines) BreakInfo() BreakInfo {
+	return newBreakInfo(0.2, func(t item.Tool) bool {
+		return t.ToolType() == item.TypeShears
+	}, axeEffective, oneOf(Vines{}))
+}
+
+// CompostChance ...
+func (Vines) CompostChance() float64 {
+	return 0.5
+}
+
+// EncodeItem ...
+func (Vines) EncodeItem() (name string, meta int16) {
+	return "minecraft:vine", 0
+}
+
+// EncodeBlock ...
+func (v Vines) EncodeBlock() (string, map[string]any) {
+	return "minecraft:vine", map[string]any{"vine_direction_bits": int32(boolByte(v.SouthDirection) | boolByte(v.WestDirection)<<1 | boolByte(v.NorthDirection)<<2 | boolByte(v.EastDirection)<<3)}
+}
+
+// allVines returns all possible states of a vines block.
+func allVines() (b []world.Block) {
+	for _, north := range []bool{false, true} {
+		for _, east := range []bool{false, true} {
+			for _, south := range []bool{false, true} {
+				for _, west := range []bool{false, true} {
+					b = append(b, Vines{NorthDirection: north, EastDirection: east, SouthDirection: south, WestDirection: west})
+				}
+			}
+		}
+	}
+	return
+}
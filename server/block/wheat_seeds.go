@@ -26,8 +26,7 @@ func (s WheatSeeds) BoneMeal(pos cube.Pos, w *world.World) bool {
 		return false
 	}
 	s.Growth = min(s.Growth+rand.Intn(4)+2, 7)
-	w.SetBlock(pos, s, nil)
-	return true
+	return grow(w, pos, s)
 }
 
 // UseOnBlock ...
@@ -47,11 +46,11 @@ func (s WheatSeeds) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *wo
 
 // BreakInfo ...
 func (s WheatSeeds) BreakInfo() BreakInfo {
-	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(item.Tool, []item.Enchantment) []item.Stack {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(_ item.Tool, enchantments []item.Enchantment) []item.Stack {
 		if s.Growth < 7 {
 			return []item.Stack{item.NewStack(s, 1)}
 		}
-		return []item.Stack{item.NewStack(item.Wheat{}, 1), item.NewStack(s, rand.Intn(4)+1)}
+		return []item.Stack{item.NewStack(item.Wheat{}, 1), item.NewStack(s, s.harvestCount(0, enchantments))}
 	})
 }
 
@@ -72,7 +71,7 @@ func (s WheatSeeds) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 		w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: s})
 	} else if s.Growth < 7 && r.Float64() <= s.CalculateGrowthChance(pos, w) {
 		s.Growth++
-		w.SetBlock(pos, s, nil)
+		grow(w, pos, s)
 	}
 }
 
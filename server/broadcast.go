@@ -0,0 +1,47 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/player/title"
+)
+
+// BroadcastPopup sends a popup to all players currently connected to the server. The popup is shown above the
+// hotbar of the players. The popup is formatted following the rules of fmt.Sprintln without a newline at the
+// end.
+func (srv *Server) BroadcastPopup(a ...any) {
+	for _, p := range srv.Players() {
+		p.SendPopup(a...)
+	}
+}
+
+// BroadcastTip sends a tip to all players currently connected to the server. The tip is shown in the middle
+// of the screen of the players. The tip is formatted following the rules of fmt.Sprintln without a newline at
+// the end.
+func (srv *Server) BroadcastTip(a ...any) {
+	for _, p := range srv.Players() {
+		p.SendTip(a...)
+	}
+}
+
+// BroadcastToast sends a toast to all players currently connected to the server. The toast is shown at the
+// top of the screen of the players.
+func (srv *Server) BroadcastToast(title, message string) {
+	for _, p := range srv.Players() {
+		p.SendToast(title, message)
+	}
+}
+
+// BroadcastTitle sends a title.Title to all players currently connected to the server, replacing any title
+// currently shown to them.
+func (srv *Server) BroadcastTitle(t title.Title) {
+	for _, p := range srv.Players() {
+		p.SendTitle(t)
+	}
+}
+
+// BroadcastActionBar sends an action bar message to all players currently connected to the server. The
+// message is formatted following the rules of fmt.Sprintln without a newline at the end.
+func (srv *Server) BroadcastActionBar(a ...any) {
+	for _, p := range srv.Players() {
+		p.SendActionBar(a...)
+	}
+}
@@ -0,0 +1,307 @@
+package server
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/permission"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"strings"
+)
+
+// maxBuildVolume is the maximum amount of blocks that may be changed using a single /fill or /clone command.
+const maxBuildVolume = 32768
+
+// registerBuildCommands registers the /setblock, /fill and /clone commands used to edit the blocks of a world.
+func registerBuildCommands() {
+	cmd.Register(cmd.New("setblock", "Changes a block to another block.", nil, setBlock{}))
+	cmd.Register(cmd.New("fill", "Fills all or parts of a region with a specific block.", nil, fill{}))
+	cmd.Register(cmd.New("clone", "Copies blocks from one place to another.", nil, clone{}))
+}
+
+// setBlockMode is the mode of the /setblock command.
+type setBlockMode string
+
+// Type ...
+func (setBlockMode) Type() string { return "SetBlockMode" }
+
+// Options ...
+func (setBlockMode) Options(cmd.Source) []string { return []string{"destroy", "keep", "replace"} }
+
+// setBlock implements the /setblock command.
+type setBlock struct {
+	Position cube.Pos                   `cmd:"position"`
+	Block    string                     `cmd:"block"`
+	Mode     cmd.Optional[setBlockMode] `cmd:"mode"`
+}
+
+// Permission ...
+func (setBlock) Permission() string { return "dragonfly.command.setblock" }
+
+// Level ...
+func (setBlock) Level() permission.Level { return permission.LevelGameMaster }
+
+// Run ...
+func (s setBlock) Run(src cmd.Source, out *cmd.Output) {
+	b, err := parseBlock(s.Block)
+	if err != nil {
+		out.Error(err)
+		return
+	}
+	w := src.World()
+	if s.Position.OutOfBounds(w.Range()) {
+		out.Errorf("Cannot place block outside of the world.")
+		return
+	}
+	a := world.NewBuildArea(w)
+	switch s.Mode.LoadOr("replace") {
+	case "keep":
+		if _, ok := w.Block(s.Position).(block.Air); !ok {
+			out.Errorf("Could not place the block: The position is not empty.")
+			return
+		}
+	case "destroy":
+		destroyBlock(w, s.Position)
+	}
+	a.SetBlock(s.Position, b)
+	if a.Apply(nil) == 0 {
+		out.Errorf("Could not place the block: The block is already there.")
+		return
+	}
+	out.Printf("Block placed.")
+}
+
+// fillMode is the mode of the /fill command.
+type fillMode string
+
+// Type ...
+func (fillMode) Type() string { return "FillMode" }
+
+// Options ...
+func (fillMode) Options(cmd.Source) []string {
+	return []string{"destroy", "hollow", "keep", "outline", "replace"}
+}
+
+// fill implements the /fill command.
+type fill struct {
+	From  cube.Pos               `cmd:"from"`
+	To    cube.Pos               `cmd:"to"`
+	Block string                 `cmd:"block"`
+	Mode  cmd.Optional[fillMode] `cmd:"mode"`
+}
+
+// Permission ...
+func (fill) Permission() string { return "dragonfly.command.fill" }
+
+// Level ...
+func (fill) Level() permission.Level { return permission.LevelGameMaster }
+
+// Run ...
+func (f fill) Run(src cmd.Source, out *cmd.Output) {
+	b, err := parseBlock(f.Block)
+	if err != nil {
+		out.Error(err)
+		return
+	}
+	w := src.World()
+	lo, hi, ok := buildRegion(w, f.From, f.To, out)
+	if !ok {
+		return
+	}
+	mode := f.Mode.LoadOr("replace")
+
+	a := world.NewBuildArea(w)
+	for x := lo[0]; x <= hi[0]; x++ {
+		for y := lo[1]; y <= hi[1]; y++ {
+			for z := lo[2]; z <= hi[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				edge := x == lo[0] || x == hi[0] || y == lo[1] || y == hi[1] || z == lo[2] || z == hi[2]
+				switch mode {
+				case "keep":
+					if _, ok := w.Block(pos).(block.Air); !ok {
+						continue
+					}
+				case "outline":
+					if !edge {
+						continue
+					}
+				case "hollow":
+					if !edge {
+						a.SetBlock(pos, nil)
+						continue
+					}
+				case "destroy":
+					destroyBlock(w, pos)
+				}
+				a.SetBlock(pos, b)
+			}
+		}
+	}
+	n := a.Apply(nil)
+	if n == 0 {
+		out.Errorf("No blocks were filled.")
+		return
+	}
+	out.Printf("%v blocks filled.", n)
+}
+
+// cloneMask is the mask mode of the /clone command.
+type cloneMask string
+
+// Type ...
+func (cloneMask) Type() string { return "MaskMode" }
+
+// Options ...
+func (cloneMask) Options(cmd.Source) []string { return []string{"masked", "replace"} }
+
+// cloneMode is the clone mode of the /clone command.
+type cloneMode string
+
+// Type ...
+func (cloneMode) Type() string { return "CloneMode" }
+
+// Options ...
+func (cloneMode) Options(cmd.Source) []string { return []string{"force", "move", "normal"} }
+
+// clone implements the /clone command.
+type clone struct {
+	Begin       cube.Pos                `cmd:"begin"`
+	End         cube.Pos                `cmd:"end"`
+	Destination cube.Pos                `cmd:"destination"`
+	Mask        cmd.Optional[cloneMask] `cmd:"maskMode"`
+	Mode        cmd.Optional[cloneMode] `cmd:"cloneMode"`
+}
+
+// Permission ...
+func (clone) Permission() string { return "dragonfly.command.clone" }
+
+// Level ...
+func (clone) Level() permission.Level { return permission.LevelGameMaster }
+
+// Run ...
+func (c clone) Run(src cmd.Source, out *cmd.Output) {
+	w := src.World()
+	lo, hi, ok := buildRegion(w, c.Begin, c.End, out)
+	if !ok {
+		return
+	}
+	size := hi.Sub(lo)
+	dst := c.Destination
+	if dst.OutOfBounds(w.Range()) || dst.Add(size).OutOfBounds(w.Range()) {
+		out.Errorf("Cannot place blocks outside of the world.")
+		return
+	}
+	mode := c.Mode.LoadOr("normal")
+	overlaps := dst[0] <= hi[0] && dst[0]+size[0] >= lo[0] && dst[1] <= hi[1] && dst[1]+size[1] >= lo[1] && dst[2] <= hi[2] && dst[2]+size[2] >= lo[2]
+	if overlaps && mode != "force" {
+		out.Errorf("The source and destination regions cannot overlap.")
+		return
+	}
+	masked := c.Mask.LoadOr("replace") == "masked"
+
+	// All blocks of the source region are read before any are changed, so that overlapping regions are copied
+	// correctly when the force mode is used.
+	a, moved := world.NewBuildArea(w), world.NewBuildArea(w)
+	for x := 0; x <= size[0]; x++ {
+		for y := 0; y <= size[1]; y++ {
+			for z := 0; z <= size[2]; z++ {
+				offset := cube.Pos{x, y, z}
+				b := w.Block(lo.Add(offset))
+				if _, air := b.(block.Air); air && masked {
+					continue
+				}
+				a.SetBlock(dst.Add(offset), b)
+				if mode == "move" {
+					moved.SetBlock(lo.Add(offset), nil)
+				}
+			}
+		}
+	}
+	moved.Apply(nil)
+	n := a.Apply(nil)
+	if n == 0 {
+		out.Errorf("No blocks were cloned.")
+		return
+	}
+	out.Printf("%v blocks cloned.", n)
+}
+
+// buildRegion returns the lowest and highest corner of the region between the positions passed. If the region
+// is not within the world or is too large, an error is written to the output and false is returned.
+func buildRegion(w *world.World, a, b cube.Pos, out *cmd.Output) (lo, hi cube.Pos, ok bool) {
+	for i := 0; i < 3; i++ {
+		lo[i], hi[i] = a[i], b[i]
+		if lo[i] > hi[i] {
+			lo[i], hi[i] = hi[i], lo[i]
+		}
+	}
+	if lo.OutOfBounds(w.Range()) || hi.OutOfBounds(w.Range()) {
+		out.Errorf("Cannot access blocks outside of the world.")
+		return lo, hi, false
+	}
+	if volume := (hi[0] - lo[0] + 1) * (hi[1] - lo[1] + 1) * (hi[2] - lo[2] + 1); volume > maxBuildVolume {
+		out.Errorf("Too many blocks in the specified area (%v > %v).", volume, maxBuildVolume)
+		return lo, hi, false
+	}
+	return lo, hi, true
+}
+
+// destroyBlock drops the block at the position passed as if it were mined by hand and shows its break particles.
+// The block itself is not removed.
+func destroyBlock(w *world.World, pos cube.Pos) {
+	b := w.Block(pos)
+	if _, ok := b.(block.Air); ok {
+		return
+	}
+	w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
+	breakable, ok := b.(block.Breakable)
+	if !ok {
+		return
+	}
+	info := breakable.BreakInfo()
+	if info.Drops == nil || !info.Harvestable(item.ToolNone{}) {
+		return
+	}
+	create := w.EntityRegistry().Config().Item
+	for _, drop := range info.Drops(item.ToolNone{}, nil) {
+		if !drop.Empty() {
+			w.AddEntity(create(drop, pos.Vec3Centre(), mgl64.Vec3{}))
+		}
+	}
+}
+
+// parseBlock parses a block from a string such as "stone", "minecraft:oak_log" or
+// "oak_log[pillar_axis=x]". The name may be prefixed with "minecraft:" and may be followed by state properties
+// between square brackets, separated by commas. Properties not specified keep their default value.
+func parseBlock(s string) (world.Block, error) {
+	name, states := s, ""
+	if i := strings.IndexByte(s, '['); i != -1 {
+		if !strings.HasSuffix(s, "]") {
+			return nil, fmt.Errorf("invalid block states in %v", s)
+		}
+		name, states = s[:i], s[i+1:len(s)-1]
+	}
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	b, ok := world.DefaultBlock(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown block %v", name)
+	}
+	if states == "" {
+		return b, nil
+	}
+	properties := map[string]any{}
+	for _, state := range strings.Split(states, ",") {
+		k, v, ok := strings.Cut(state, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid block state %v", state)
+		}
+		properties[strings.Trim(strings.TrimSpace(k), `"`)] = strings.Trim(strings.TrimSpace(v), `"`)
+	}
+	return world.BlockWithProperties(b, properties)
+}
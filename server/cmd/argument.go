@@ -3,11 +3,9 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/go-gl/mathgl/mgl64"
-	"math/rand"
 	"reflect"
-	"sort"
 	"strconv"
 	"strings"
 )
@@ -88,7 +86,9 @@ func (p parser) parseArgument(line *Line, v reflect.Value, optional bool, name s
 	case bool:
 		err = p.bool(line, v)
 	case mgl64.Vec3:
-		err = p.vec3(line, v)
+		err = p.vec3(line, v, source)
+	case cube.Pos:
+		err = p.pos(line, v, source)
 	case Varargs:
 		err = p.varargs(line, v)
 	case []Target:
@@ -220,16 +220,51 @@ func (p parser) sub(line *Line, name string) error {
 }
 
 // vec3 ...
-func (p parser) vec3(line *Line, v reflect.Value) error {
-	if err := p.float(line, v.Index(0)); err != nil {
-		return err
+func (p parser) vec3(line *Line, v reflect.Value, source Source) error {
+	origin := source.Position()
+	for i := 0; i < 3; i++ {
+		if i != 0 {
+			line.RemoveNext()
+		}
+		arg, ok := line.Next()
+		if !ok {
+			return ErrInsufficientArgs
+		}
+		value, err := p.coordinate(arg, origin[i])
+		if err != nil {
+			return err
+		}
+		v.Index(i).SetFloat(value)
 	}
-	line.RemoveNext()
-	if err := p.float(line, v.Index(1)); err != nil {
+	return nil
+}
+
+// pos ...
+func (p parser) pos(line *Line, v reflect.Value, source Source) error {
+	var vec mgl64.Vec3
+	if err := p.vec3(line, reflect.ValueOf(&vec).Elem(), source); err != nil {
 		return err
 	}
-	line.RemoveNext()
-	return p.float(line, v.Index(2))
+	v.Set(reflect.ValueOf(cube.PosFromVec3(vec)))
+	return nil
+}
+
+// coordinate parses a single coordinate of a position. Coordinates prefixed with '~' are relative to the
+// origin passed, such that '~' is the origin itself and '~2' is the origin plus 2.
+func (p parser) coordinate(arg string, origin float64) (float64, error) {
+	relative := strings.HasPrefix(arg, "~")
+	num := strings.TrimPrefix(arg, "~")
+	if relative && num == "" {
+		return origin, nil
+	}
+	value, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf(`cannot parse argument "%v" as coordinate for argument "%v"`, arg, p.currentField)
+	}
+	if relative {
+		value += origin
+	}
+	return value, nil
 }
 
 // varargs ...
@@ -251,42 +286,23 @@ func (p parser) targets(line *Line, v reflect.Value) error {
 	return nil
 }
 
-// parseTargets parses one or more Targets from the Line passed.
+// parseTargets parses one or more Targets from the Line passed. Arguments starting with '@' are parsed as a
+// Selector, while other arguments are parsed as the name of a player.
 func (p parser) parseTargets(line *Line) ([]Target, error) {
-	entities, players := targets(line.src)
 	first, ok := line.Next()
 	if !ok {
 		return nil, ErrInsufficientArgs
 	}
-	switch first {
-	case "@p":
-		pos := line.src.Position()
-		playerDistances := make([]float64, len(players))
-		for i, p := range players {
-			playerDistances[i] = p.Position().Sub(pos).Len()
-		}
-		sort.Slice(players, func(i, j int) bool {
-			return playerDistances[i] < playerDistances[j]
-		})
-		if len(players) == 0 {
-			return nil, nil
+	if strings.HasPrefix(first, "@") {
+		sel, err := ParseSelector(first)
+		if err != nil {
+			return nil, err
 		}
-		return sliceutil.Convert[Target](players[0:1]), nil
-	case "@e":
-		return entities, nil
-	case "@a":
-		return sliceutil.Convert[Target](players), nil
-	case "@s":
-		return []Target{line.src}, nil
-	case "@r":
-		if len(players) == 0 {
-			return nil, nil
-		}
-		return []Target{players[rand.Intn(len(players))]}, nil
-	default:
-		target, err := p.parsePlayer(players, first)
-		return []Target{target}, err
+		return sel.Resolve(line.src), nil
 	}
+	_, players := targets(line.src)
+	target, err := p.parsePlayer(players, first)
+	return []Target{target}, err
 }
 
 // parsePlayer parses one Player from the Line, reading more arguments if necessary to find a valid player
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/csv"
 	"fmt"
+	"github.com/df-mc/dragonfly/server/permission"
 	"go/ast"
 	"reflect"
 	"strings"
@@ -14,7 +15,7 @@ import (
 // below) have their values copied but retained.
 // A Runnable may have exported fields only of the following types:
 // int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint,
-// float32, float64, string, bool, mgl64.Vec3, Varargs, []Target, cmd.SubCommand, Optional[T] (to make a parameter
+// float32, float64, string, bool, mgl64.Vec3, cube.Pos, Varargs, []Target, cmd.SubCommand, Optional[T] (to make a parameter
 // optional), or a type that implements the cmd.Parameter or cmd.Enum interface. cmd.Enum implementations must be of the
 // type string.
 // Fields in the Runnable struct may have `cmd:` struct tag to specify the name and suffix of a parameter as such:
@@ -40,6 +41,49 @@ type Allower interface {
 	Allow(src Source) bool
 }
 
+// Permissioned may be implemented by a type also implementing Runnable to require a permission node, such as
+// 'dragonfly.command.ban', to run the command. Sources implementing permission.Holder, such as players, may only
+// run the command if they have the permission node. Other sources may always run it.
+type Permissioned interface {
+	// Permission returns the permission node required to run the command.
+	Permission() string
+}
+
+// Leveled may be implemented by a type also implementing Runnable to require an operator level to run the
+// command. Sources implementing permission.Leveled, such as players and the console, may only run the command if
+// their level is at least the level returned. If the Runnable also implements Permissioned, sources with the
+// permission node may run the command regardless of their level.
+// A Runnable implementing only Permissioned may be run by sources with permission.LevelOwner without the node.
+type Leveled interface {
+	// Level returns the operator level required to run the command.
+	Level() permission.Level
+}
+
+// allowed checks if the Source passed may run the Runnable passed, considering the Allower, Permissioned and
+// Leveled interfaces.
+func allowed(r any, src Source) bool {
+	required := permission.LevelNone
+	if l, ok := r.(Leveled); ok {
+		required = l.Level()
+	} else if _, ok := r.(Permissioned); ok {
+		required = permission.LevelOwner
+	}
+	level, leveled := src.(permission.Leveled)
+	operator := leveled && required > permission.LevelNone && level.OperatorLevel() >= required
+
+	if p, ok := r.(Permissioned); ok {
+		if holder, ok := src.(permission.Holder); ok && !holder.HasPermission(p.Permission()) && !operator {
+			return false
+		}
+	} else if leveled && !operator && required > permission.LevelNone {
+		return false
+	}
+	if allower, ok := r.(Allower); ok {
+		return allower.Allow(src)
+	}
+	return true
+}
+
 // Command is a wrapper around a Runnable. It provides additional identity and utility methods for the actual
 // runnable command so that it may be identified more easily.
 type Command struct {
@@ -178,7 +222,7 @@ func (cmd Command) Params(src Source) [][]ParamInfo {
 		elem := reflect.New(runnable.Type()).Elem()
 		elem.Set(runnable)
 
-		if allower, ok := runnable.Interface().(Allower); ok && !allower.Allow(src) {
+		if !allowed(runnable.Interface(), src) {
 			// This source cannot execute this runnable.
 			continue
 		}
@@ -203,7 +247,7 @@ func (cmd Command) Runnables(src Source) map[int]Runnable {
 	m := make(map[int]Runnable, len(cmd.v))
 	for i, runnable := range cmd.v {
 		v := runnable.Interface().(Runnable)
-		if allower, ok := v.(Allower); !ok || allower.Allow(src) {
+		if allowed(v, src) {
 			m[i] = v
 		}
 	}
@@ -220,7 +264,7 @@ func (cmd Command) String() string {
 // parsing was not successful or the Runnable could not be run by this source, an error is returned, and the
 // leftover command line.
 func (cmd Command) executeRunnable(v reflect.Value, args string, source Source, output *Output) (*Line, error) {
-	if a, ok := v.Interface().(Allower); ok && !a.Allow(source) {
+	if !allowed(v.Interface(), source) {
 		//lint:ignore ST1005 Error string is capitalised because it is shown to the player.
 		//goland:noinspection GoErrorStringFormat
 		return nil, fmt.Errorf("You cannot execute this command.")
@@ -235,7 +279,7 @@ func (cmd Command) executeRunnable(v reflect.Value, args string, source Source,
 		if err != nil {
 			return nil, fmt.Errorf("error parsing command string: %w", err)
 		}
-		argFrags = record
+		argFrags = joinSelectors(record)
 	}
 	parser := parser{}
 	arguments := &Line{args: argFrags, src: source}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/go-gl/mathgl/mgl64"
 	"reflect"
 	"strings"
@@ -97,7 +98,7 @@ func typeNameOf(i any, name string) string {
 		return "text"
 	case bool:
 		return "bool"
-	case mgl64.Vec3:
+	case mgl64.Vec3, cube.Pos:
 		return "x y z"
 	case []Target:
 		return "target"
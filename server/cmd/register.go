@@ -1,6 +1,9 @@
 package cmd
 
-import "sync"
+import (
+	"strings"
+	"sync"
+)
 
 // commands holds a list of registered commands indexed by their name.
 var commands sync.Map
@@ -14,6 +17,16 @@ func Register(command Command) {
 	}
 }
 
+// Unregister unregisters a command registered using Register, together with all of its aliases. Names and
+// aliases that were overwritten by another command since are left untouched.
+func Unregister(command Command) {
+	for _, alias := range append([]string{command.name}, command.aliases...) {
+		if c, ok := ByAlias(alias); ok && c.name == command.name {
+			commands.Delete(alias)
+		}
+	}
+}
+
 // ByAlias looks up a command by an alias. If found, the command and true are returned. If not, the returned
 // command is nil and the bool is false.
 func ByAlias(alias string) (Command, bool) {
@@ -33,3 +46,35 @@ func Commands() map[string]Command {
 	})
 	return cmd
 }
+
+// Lookup looks up the command called by the command line passed, such as '/ban Steve'. The leading slash is
+// optional. If found, the command is returned together with the arguments passed to it. If not, the bool
+// returned is false.
+func Lookup(commandLine string) (Command, []string, bool) {
+	args := strings.Split(strings.TrimPrefix(commandLine, "/"), " ")
+	command, ok := ByAlias(args[0])
+	return command, args[1:], ok
+}
+
+// Dispatch executes the command line passed, such as '/ban Steve', as the Source passed. The leading slash is
+// optional. If the command could not be found, an error is sent to the Source.
+func Dispatch(src Source, commandLine string) {
+	command, args, ok := Lookup(commandLine)
+	if !ok {
+		src.SendCommandOutput(UnknownCommand(commandLine))
+		return
+	}
+	command.Execute(strings.Join(args, " "), src)
+}
+
+// UnknownCommand returns an Output holding the error sent to a Source that tries to execute the command line
+// passed while no command with its name exists.
+func UnknownCommand(commandLine string) *Output {
+	name := strings.Split(commandLine, " ")[0]
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name
+	}
+	o := &Output{}
+	o.Errorf("Unknown command: %v. Please check that the command exists and that you have permission to use it.", name)
+	return o
+}
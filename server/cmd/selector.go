@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TaggedTarget is a Target that may have tags added to it. Targets implementing TaggedTarget may be selected
+// using the tag argument of a Selector.
+type TaggedTarget interface {
+	Target
+	// HasTag checks if the Target has the tag passed.
+	HasTag(tag string) bool
+}
+
+// Selector is a target selector as used in vanilla commands, such as '@a' or '@e[type=cow,r=10]'. A Selector
+// may be parsed using ParseSelector and resolved to the Targets it selects using Selector.Resolve.
+// Selector arguments supported are r and rm (maximum and minimum distance), type, name, tag, gamemode (m) and
+// c (maximum count). The type, name, tag and gamemode arguments may be negated by prefixing the value with '!'.
+type Selector struct {
+	// Variable is the variable of the Selector: One of 'p' (nearest player), 'a' (all players), 'r' (random
+	// player), 'e' (all entities) or 's' (the source itself).
+	Variable byte
+
+	// MinRadius and MaxRadius limit the distance of targets to the source. A negative MaxRadius means there is
+	// no maximum distance.
+	MinRadius, MaxRadius float64
+	// Count is the maximum amount of targets selected. If 0, the default for the Variable is used.
+	Count int
+
+	types, names, tags, gameModes filter
+}
+
+// filter holds values that a property of a target must match, and values that it must not match.
+type filter struct {
+	include, exclude []string
+}
+
+// add adds a selector argument value to the filter, excluding the value if it is prefixed with '!'.
+func (f *filter) add(v string) {
+	if strings.HasPrefix(v, "!") {
+		f.exclude = append(f.exclude, strings.TrimPrefix(v, "!"))
+		return
+	}
+	f.include = append(f.include, v)
+}
+
+// matches checks if the filter matches a target using the function passed, which returns true if the target
+// has a specific value. ok is false if the target does not have the property filtered on at all.
+func (f filter) matches(has func(v string) bool, ok bool) bool {
+	if len(f.include) == 0 && len(f.exclude) == 0 {
+		return true
+	}
+	if !ok {
+		return false
+	}
+	for _, v := range f.include {
+		if !has(v) {
+			return false
+		}
+	}
+	for _, v := range f.exclude {
+		if has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseSelector parses a target selector such as '@a[r=10,name=!Steve]'. An error is returned if the selector
+// has an unknown variable or has invalid arguments.
+func ParseSelector(s string) (Selector, error) {
+	if len(s) < 2 || s[0] != '@' {
+		return Selector{}, fmt.Errorf("invalid selector %v", s)
+	}
+	sel := Selector{Variable: s[1], MaxRadius: -1}
+	if !strings.ContainsRune("parse", rune(sel.Variable)) {
+		return Selector{}, fmt.Errorf("unknown selector variable @%c", sel.Variable)
+	}
+	args := s[2:]
+	if args == "" {
+		return sel, nil
+	}
+	if !strings.HasPrefix(args, "[") || !strings.HasSuffix(args, "]") {
+		return Selector{}, fmt.Errorf("invalid selector arguments %v", args)
+	}
+	for _, arg := range strings.Split(args[1:len(args)-1], ",") {
+		if arg = strings.TrimSpace(arg); arg == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return Selector{}, fmt.Errorf("selector argument %v has no value", arg)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		var err error
+		switch key {
+		case "r":
+			sel.MaxRadius, err = strconv.ParseFloat(value, 64)
+		case "rm":
+			sel.MinRadius, err = strconv.ParseFloat(value, 64)
+		case "c":
+			sel.Count, err = strconv.Atoi(value)
+		case "type":
+			sel.types.add(value)
+		case "name":
+			sel.names.add(value)
+		case "tag":
+			sel.tags.add(value)
+		case "m", "gamemode":
+			sel.gameModes.add(value)
+		default:
+			return Selector{}, fmt.Errorf("unknown selector argument %v", key)
+		}
+		if err != nil {
+			return Selector{}, fmt.Errorf("invalid value %v for selector argument %v", value, key)
+		}
+	}
+	return sel, nil
+}
+
+// joinSelectors joins arguments that were split on spaces inside the arguments of a selector, such as
+// '@e[type=cow,' and 'r=10]', back into a single argument.
+func joinSelectors(args []string) []string {
+	joined := make([]string, 0, len(args))
+	depth := 0
+	for _, arg := range args {
+		if depth > 0 {
+			joined[len(joined)-1] += " " + arg
+		} else {
+			joined = append(joined, arg)
+			if !strings.HasPrefix(arg, "@") {
+				continue
+			}
+		}
+		if depth += strings.Count(arg, "[") - strings.Count(arg, "]"); depth < 0 {
+			depth = 0
+		}
+	}
+	return joined
+}
+
+// Resolve resolves the Selector to the Targets it selects for the Source passed. Targets are found using the
+// functions added using AddTargetFunc. Distances are measured from the position of the Source, and targets in
+// another world than the Source are never selected if the Selector has a distance limit.
+func (sel Selector) Resolve(src Source) []Target {
+	entities, players := targets(src)
+
+	var candidates []Target
+	switch sel.Variable {
+	case 's':
+		candidates = []Target{src}
+	case 'e':
+		candidates = entities
+	default:
+		candidates = make([]Target, 0, len(players))
+		for _, p := range players {
+			candidates = append(candidates, p)
+		}
+	}
+
+	pos := src.Position()
+	selected := make([]Target, 0, len(candidates))
+	for _, t := range candidates {
+		if sel.matches(t, src, pos) {
+			selected = append(selected, t)
+		}
+	}
+
+	count := sel.Count
+	switch sel.Variable {
+	case 'p':
+		sort.SliceStable(selected, func(i, j int) bool {
+			return selected[i].Position().Sub(pos).Len() < selected[j].Position().Sub(pos).Len()
+		})
+		if count == 0 {
+			count = 1
+		}
+	case 'r':
+		rand.Shuffle(len(selected), func(i, j int) {
+			selected[i], selected[j] = selected[j], selected[i]
+		})
+		if count == 0 {
+			count = 1
+		}
+	}
+	if count > 0 && len(selected) > count {
+		selected = selected[:count]
+	}
+	return selected
+}
+
+// matches checks if a Target matches all arguments of the Selector.
+func (sel Selector) matches(t Target, src Source, pos mgl64.Vec3) bool {
+	if sel.MinRadius > 0 || sel.MaxRadius >= 0 {
+		if e, ok := t.(world.Entity); ok && e.World() != src.World() {
+			return false
+		}
+		dist := t.Position().Sub(pos).Len()
+		if dist < sel.MinRadius || (sel.MaxRadius >= 0 && dist > sel.MaxRadius) {
+			return false
+		}
+	}
+
+	e, isEntity := t.(world.Entity)
+	if !sel.types.matches(func(v string) bool {
+		return e.Type().EncodeEntity() == entityTypeName(v)
+	}, isEntity) {
+		return false
+	}
+	named, isNamed := t.(NamedTarget)
+	if !sel.names.matches(func(v string) bool {
+		return named.Name() == v
+	}, isNamed) {
+		return false
+	}
+	tagged, isTagged := t.(TaggedTarget)
+	if !sel.tags.matches(func(v string) bool {
+		return tagged.HasTag(v)
+	}, isTagged) {
+		return false
+	}
+	g, hasGameMode := t.(interface{ GameMode() world.GameMode })
+	return sel.gameModes.matches(func(v string) bool {
+		mode, ok := gameModeByName(v)
+		return ok && g.GameMode() == mode
+	}, hasGameMode)
+}
+
+// entityTypeName returns the full name of an entity type, adding the 'minecraft:' namespace if the name passed
+// has no namespace.
+func entityTypeName(name string) string {
+	if !strings.Contains(name, ":") {
+		return "minecraft:" + name
+	}
+	return name
+}
+
+// gameModeByName looks up a world.GameMode by its name, abbreviation or ID, as used in the gamemode argument of
+// a selector.
+func gameModeByName(name string) (world.GameMode, bool) {
+	switch strings.ToLower(name) {
+	case "survival", "s", "0":
+		return world.GameModeSurvival, true
+	case "creative", "c", "1":
+		return world.GameModeCreative, true
+	case "adventure", "a", "2":
+		return world.GameModeAdventure, true
+	case "spectator", "sp", "6":
+		return world.GameModeSpectator, true
+	}
+	return nil, false
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/permission"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/timings"
+)
+
+// registerOperatorCommands registers the /op and /deop commands used to manage the permission.OperatorList
+// passed.
+func registerOperatorCommands(ops *permission.OperatorList) {
+	cmd.Register(cmd.New("op", "Grants operator status to players.", nil, op{ops: ops}))
+	cmd.Register(cmd.New("deop", "Revokes operator status from players.", nil, deop{ops: ops}))
+}
+
+// op implements the /op command.
+type op struct {
+	ops     *permission.OperatorList
+	Targets []cmd.Target      `cmd:"player"`
+	OpLevel cmd.Optional[int] `cmd:"level"`
+}
+
+// Permission ...
+func (op) Permission() string { return "dragonfly.command.op" }
+
+// Level ...
+func (op) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (o op) Run(src cmd.Source, out *cmd.Output) {
+	lvl := permission.LevelAdmin
+	if l, ok := o.OpLevel.Load(); ok {
+		lvl = permission.Level(l)
+	}
+	if lvl <= permission.LevelNone || lvl > permission.LevelOwner {
+		out.Errorf("Operator level must be between %v and %v.", permission.LevelModerator, permission.LevelOwner)
+		return
+	}
+	if l, ok := src.(permission.Leveled); ok && l.OperatorLevel() < lvl {
+		out.Errorf("You cannot grant an operator level higher than your own.")
+		return
+	}
+	setOperatorLevel(o.ops, o.Targets, lvl, out)
+}
+
+// deop implements the /deop command.
+type deop struct {
+	ops     *permission.OperatorList
+	Targets []cmd.Target `cmd:"player"`
+}
+
+// Permission ...
+func (deop) Permission() string { return "dragonfly.command.deop" }
+
+// Level ...
+func (deop) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (d deop) Run(_ cmd.Source, out *cmd.Output) {
+	setOperatorLevel(d.ops, d.Targets, permission.LevelNone, out)
+}
+
+// setOperatorLevel sets the operator level of all players among the targets passed, both in the
+// permission.OperatorList and on the players themselves.
+func setOperatorLevel(ops *permission.OperatorList, targets []cmd.Target, lvl permission.Level, out *cmd.Output) {
+	for _, t := range targets {
+		p, ok := t.(*player.Player)
+		if !ok {
+			continue
+		}
+		if err := ops.SetLevel(p.UUID(), p.Name(), lvl); err != nil {
+			out.Error(err)
+			continue
+		}
+		p.SetOperatorLevel(lvl)
+		if lvl == permission.LevelNone {
+			out.Printf("Made %v no longer a server operator.", p.Name())
+			continue
+		}
+		out.Printf("Made %v a server operator with level %v.", p.Name(), int(lvl))
+	}
+}
+
+// registerTimingsCommand registers the /timings command used to view and reset the timings recorded by
+// timings.Global.
+func registerTimingsCommand() {
+	cmd.Register(cmd.New("timings", "Shows how long the systems of the server take to run.", nil, timingsReport{}, timingsReset{}))
+}
+
+// timingsReport implements the /timings command.
+type timingsReport struct{}
+
+// Permission ...
+func (timingsReport) Permission() string { return "dragonfly.command.timings" }
+
+// Level ...
+func (timingsReport) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (timingsReport) Run(_ cmd.Source, out *cmd.Output) {
+	out.Print(timings.Global.Report().String())
+}
+
+// timingsReset implements the /timings reset command.
+type timingsReset struct {
+	Reset cmd.SubCommand `cmd:"reset"`
+}
+
+// Permission ...
+func (timingsReset) Permission() string { return "dragonfly.command.timings" }
+
+// Level ...
+func (timingsReset) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (timingsReset) Run(_ cmd.Source, out *cmd.Output) {
+	timings.Global.Reset()
+	out.Print("Reset all timings.")
+}
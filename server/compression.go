@@ -0,0 +1,107 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/flate"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"io"
+	"strings"
+	"sync"
+)
+
+// flateCompression is a packet.Compression that compresses packets using flate at a specific compression
+// level. It is compatible with packet.FlateCompression, which uses a fixed level.
+type flateCompression struct {
+	pool *sync.Pool
+}
+
+// newFlateCompression creates a flateCompression that compresses at the level passed. An error is returned
+// if the level is not a valid flate compression level.
+func newFlateCompression(level int) (flateCompression, error) {
+	if _, err := flate.NewWriter(nil, level); err != nil {
+		return flateCompression{}, err
+	}
+	return flateCompression{pool: &sync.Pool{New: func() any {
+		w, _ := flate.NewWriter(nil, level)
+		return w
+	}}}, nil
+}
+
+// EncodeCompression ...
+func (c flateCompression) EncodeCompression() uint16 {
+	return packet.FlateCompression.EncodeCompression()
+}
+
+// Compress ...
+func (c flateCompression) Compress(decompressed []byte) ([]byte, error) {
+	w := c.pool.Get().(*flate.Writer)
+	defer c.pool.Put(w)
+
+	compressed := bytes.NewBuffer(make([]byte, 0, len(decompressed)/2))
+	w.Reset(compressed)
+	if _, err := w.Write(decompressed); err != nil {
+		return nil, fmt.Errorf("compress flate: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("close flate writer: %w", err)
+	}
+	return compressed.Bytes(), nil
+}
+
+// Decompress ...
+func (c flateCompression) Decompress(compressed []byte) ([]byte, error) {
+	return packet.FlateCompression.Decompress(compressed)
+}
+
+// limitedCompression wraps around a packet.Compression to limit the size of data after decompression, so
+// that clients cannot exhaust the memory of the server by sending small batches that decompress to a large
+// size.
+type limitedCompression struct {
+	packet.Compression
+	max int
+}
+
+// Decompress ...
+func (c limitedCompression) Decompress(compressed []byte) ([]byte, error) {
+	switch c.EncodeCompression() {
+	case packet.FlateCompression.EncodeCompression():
+		r := flate.NewReader(bytes.NewReader(compressed))
+		defer r.Close()
+		decompressed, err := io.ReadAll(io.LimitReader(r, int64(c.max)+1))
+		if err != nil {
+			return nil, fmt.Errorf("decompress flate: %w", err)
+		}
+		if len(decompressed) > c.max {
+			return nil, fmt.Errorf("decompressed size exceeds maximum of %v bytes", c.max)
+		}
+		return decompressed, nil
+	case packet.SnappyCompression.EncodeCompression():
+		if n, err := snappy.DecodedLen(compressed); err != nil {
+			return nil, fmt.Errorf("decompress snappy: %w", err)
+		} else if n > c.max {
+			return nil, fmt.Errorf("decompressed size %v exceeds maximum of %v bytes", n, c.max)
+		}
+	}
+	decompressed, err := c.Compression.Decompress(compressed)
+	if err == nil && len(decompressed) > c.max {
+		return nil, fmt.Errorf("decompressed size %v exceeds maximum of %v bytes", len(decompressed), c.max)
+	}
+	return decompressed, err
+}
+
+// parseCompression parses a packet.Compression from its name, either "flate" or "snappy". The level passed
+// is used for flate compression if non-zero.
+func parseCompression(name string, level int) (packet.Compression, error) {
+	switch strings.ToLower(name) {
+	case "", "flate":
+		if level == 0 {
+			return packet.FlateCompression, nil
+		}
+		return newFlateCompression(level)
+	case "snappy":
+		return packet.SnappyCompression, nil
+	}
+	return nil, fmt.Errorf("unknown compression %q", name)
+}
@@ -1,10 +1,16 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/economy"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/internal/packbuilder"
+	"github.com/df-mc/dragonfly/server/logging"
+	"github.com/df-mc/dragonfly/server/moderation"
+	"github.com/df-mc/dragonfly/server/permission"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/player/playerdb"
 	"github.com/df-mc/dragonfly/server/session"
@@ -13,11 +19,15 @@ import (
 	"github.com/df-mc/dragonfly/server/world/generator"
 	"github.com/df-mc/dragonfly/server/world/mcdb"
 	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 	"github.com/sandertv/gophertunnel/minecraft/resource"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/exp/slices"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 // Config contains options for starting a Minecraft server.
@@ -34,14 +44,60 @@ type Config struct {
 	// Name is the name of the server. By default, it is shown to users in the
 	// server list before joining the server and when opening the in-game menu.
 	Name string
+	// StatusProvider may be set to provide the status of the Server shown in
+	// the server list, such as its name and player counts. It is called
+	// periodically and every time a player joins or leaves. If nil, the Name
+	// of the Server is shown with the amount of players online.
+	StatusProvider minecraft.ServerStatusProvider
+	// DisplayedMaxPlayers is the maximum amount of players shown in the server
+	// list and in query responses. If 0, MaxPlayers is shown. It does not
+	// change the amount of players allowed to join.
+	DisplayedMaxPlayers int
+	// Query specifies if the default listener should answer queries following
+	// the GameSpy 4 (UT3) query protocol on the port it listens on.
+	Query bool
+	// Compression is the packet.Compression used by the default listener to
+	// compress packets sent to clients. If nil, packet.DefaultCompression is
+	// used.
+	Compression packet.Compression
+	// FlushRate is the interval at which the default listener flushes the
+	// packets buffered for a client, batching them together. Lower rates lower
+	// latency, but cost more CPU and bandwidth. If 0, packets are flushed
+	// every 50ms.
+	FlushRate time.Duration
+	// MaxBatchSize is the maximum amount of packets that are buffered for a
+	// client of the default listener before they are flushed, regardless of
+	// FlushRate. If 0, the amount of packets in a batch is not limited.
+	MaxBatchSize int
+	// MaxDecompressedSize is the maximum size in bytes that a batch of packets
+	// sent by a client to the default listener may have after decompression.
+	// Clients sending larger batches are disconnected. If 0, the size is
+	// limited to 16 MiB. If negative, the size is not limited.
+	MaxDecompressedSize int
+	// DisableSubChunkRequests specifies if chunks should be sent to players in
+	// full rather than having players request the sub-chunks they need. Sub-
+	// chunk requests save bandwidth and should generally be left enabled.
+	DisableSubChunkRequests bool
+	// PacketRateLimits holds the session.RateLimit applied to packets sent by
+	// players, indexed by packet ID. If nil, session.DefaultRateLimits() is
+	// used. An empty map may be passed to disable rate limiting.
+	PacketRateLimits map[uint32]session.RateLimit
 	// Resources is a slice of resource packs to use on the server. When joining
 	// the server, the player will then first be requested to download these
-	// resource packs.
+	// resource packs. Packs obtained using resource.ReadURL are advertised by
+	// their URL, so that clients download them directly from there instead of
+	// through the connection. Clients that fail to do so fall back to
+	// downloading them through the connection.
 	Resources []*resource.Pack
 	// ResourcesRequires specifies if the downloading of resource packs is
 	// required to join the server. If set to true, players will not be able to
 	// join without first downloading and applying the Resources above.
 	ResourcesRequired bool
+	// ResourceSendRate is the maximum rate in bytes per second at which data
+	// is sent to a player while it is joining, which limits the bandwidth
+	// used for sending resource packs to players. If 0, the rate is not
+	// limited.
+	ResourceSendRate int
 	// DisableResourceBuilding specifies if automatic resource pack building for
 	// custom items should be disabled. Dragonfly, by default, automatically
 	// produces a resource pack for custom items. If this is not desired (for
@@ -96,6 +152,78 @@ type Config struct {
 	// may be added to the Server's worlds. If no entity types are registered,
 	// Entities will be set to entity.DefaultRegistry.
 	Entities world.EntityRegistry
+	// SpawnProtection is the radius in blocks around the spawn of the
+	// overworld in which players may not break, place or interact with
+	// blocks. If left as 0, spawn protection is disabled. Additional
+	// protection may be added using World.SetProtector.
+	SpawnProtection int
+	// MobSpawns is the spawn list used to naturally spawn mobs in the default
+	// worlds. If left empty, no mobs will spawn naturally.
+	MobSpawns []world.MobSpawnEntry
+	// SaveInterval is the interval at which the default worlds and the data
+	// of online players are saved while the Server is running. If left as 0,
+	// data is only saved when chunks are unloaded, when players leave and
+	// when the Server is closed.
+	SaveInterval time.Duration
+	// TickRegionSize is the size in chunks of the regions that the default
+	// worlds are divided in for parallel ticking. If left as 0, the worlds are
+	// ticked on a single goroutine. See world.Config for more information.
+	TickRegionSize int
+	// ActivationRanges maps encoded entity types to the ActivationRange used
+	// for entities of that type in the default worlds. Entities far away from
+	// players are ticked less often or not at all. If left empty, all entities
+	// near players are ticked every tick. See world.Config for more information.
+	ActivationRanges map[string]world.ActivationRange
+	// LowTPSThreshold is the TPS below which world.Handler.HandleLowTPS is
+	// called for the default worlds. If left as 0, HandleLowTPS is never
+	// called.
+	LowTPSThreshold float64
+	// MetricsAddress is the address on which metrics of the Server, such as
+	// the player count, TPS and loaded chunks, are served in the Prometheus
+	// text format on the /metrics path. If left empty, no metrics are served.
+	MetricsAddress string
+	// PermissionProvider is the permission.Provider used to check the
+	// permissions of players. If left as nil, players have no permissions.
+	PermissionProvider permission.Provider
+	// Moderation is the moderation.Manager holding the ban lists, whitelist
+	// and mute list of the Server. If set, players that are banned or not
+	// whitelisted are refused before the Allower is consulted, mutes are
+	// applied to players joining and the commands returned by
+	// moderation.Commands are registered. If left as nil, none of these
+	// lists are used.
+	Moderation *moderation.Manager
+	// Economy is the economy.Economy holding the balances of players, shared
+	// by all plugins through Server.Economy. If set, the commands returned
+	// by economy.Commands are registered. If left as nil, an in-memory
+	// economy.Store is used and no commands are registered.
+	Economy economy.Economy
+	// Operators is the permission.OperatorList holding the operators of the
+	// Server and their levels. If set, the operator levels of players are
+	// set when they join and the /op and /deop commands are registered. If
+	// left as nil, no player is an operator.
+	Operators *permission.OperatorList
+	// MovementValidation is the player.MovementValidation used to validate
+	// the movement sent by the clients of players. If left as the zero
+	// value, movement of clients is not validated.
+	MovementValidation player.MovementValidation
+	// RCON configures the RCON listener of the Server, through which commands
+	// may be executed remotely. By default, RCON is disabled.
+	RCON RCON
+	// API configures the HTTP admin API of the Server. By default, the API is
+	// disabled.
+	API API
+	// Forwarding configures the Server to run behind a proxy, accepting the
+	// real addresses and identities of players forwarded by it. By default,
+	// nothing is forwarded.
+	Forwarding Forwarding
+	// Carrier is the player.Carrier used to carry the data of players over
+	// to other servers when they are transferred, and to receive the data of
+	// players transferred from other servers. If left as nil, the data of
+	// players is only loaded from the PlayerProvider.
+	Carrier player.Carrier
+	// Combat is the player.Combat used when players attack other entities.
+	// If left as the zero value, player.DefaultCombat is used.
+	Combat player.Combat
 }
 
 // Logger is used to report information and errors from a dragonfly Server. Any
@@ -108,6 +236,15 @@ type Logger interface {
 	Warnf(format string, v ...any)
 }
 
+// subsystemLog returns a Logger that logs messages as part of the subsystem
+// passed if log is a *logging.Logger. Otherwise, log itself is returned.
+func subsystemLog(log Logger, subsystem string) Logger {
+	if l, ok := log.(*logging.Logger); ok {
+		return l.Subsystem(subsystem)
+	}
+	return log
+}
+
 // New creates a Server using fields of conf. The Server's worlds are created
 // and connections from the Server's listeners may be accepted by calling
 // Server.Listen() and Server.Accept() afterwards.
@@ -115,12 +252,16 @@ func (conf Config) New() *Server {
 	if conf.Log == nil {
 		conf.Log = logrus.New()
 	}
+	conf.Log = subsystemLog(conf.Log, logging.SubsystemServer)
 	if len(conf.Listeners) == 0 {
 		conf.Log.Warnf("config: no listeners set, no connections will be accepted")
 	}
 	if conf.Name == "" {
 		conf.Name = "Dragonfly Server"
 	}
+	if conf.PermissionProvider == nil {
+		conf.PermissionProvider = permission.NopProvider{}
+	}
 	if conf.PlayerProvider == nil {
 		conf.PlayerProvider = player.NopProvider{}
 	}
@@ -133,6 +274,9 @@ func (conf Config) New() *Server {
 	if conf.Generator == nil {
 		conf.Generator = loadGenerator
 	}
+	if conf.Combat == (player.Combat{}) {
+		conf.Combat = player.DefaultCombat()
+	}
 	if conf.MaxChunkRadius == 0 {
 		conf.MaxChunkRadius = 12
 	}
@@ -144,20 +288,46 @@ func (conf Config) New() *Server {
 			conf.Resources = append(conf.Resources, pack)
 		}
 	}
+	if conf.MaxDecompressedSize == 0 {
+		conf.MaxDecompressedSize = 16 << 20
+	}
+	if conf.PacketRateLimits == nil {
+		conf.PacketRateLimits = session.DefaultRateLimits()
+	}
 	// Copy resources so that the slice can't be edited afterwards.
 	conf.Resources = slices.Clone(conf.Resources)
 
 	srv := &Server{
 		conf:     conf,
 		incoming: make(chan *session.Session),
+		closing:  make(chan struct{}),
 		p:        make(map[uuid.UUID]*player.Player),
+		sessions: make(map[uuid.UUID]*session.Session),
 		world:    &world.World{}, nether: &world.World{}, end: &world.World{},
 	}
+	srv.conf.StatusProvider = statusProvider{srv: srv, custom: conf.StatusProvider}
 	srv.world = srv.createWorld(world.Overworld, &srv.nether, &srv.end)
 	srv.nether = srv.createWorld(world.Nether, &srv.world, &srv.end)
 	srv.end = srv.createWorld(world.End, &srv.nether, &srv.world)
 
 	srv.registerTargetFunc()
+	if conf.Economy != nil {
+		for _, c := range economy.Commands(conf.Economy) {
+			cmd.Register(c)
+		}
+	} else {
+		srv.conf.Economy, _ = economy.NewStore("", economy.DefaultCurrency)
+	}
+	if conf.Moderation != nil {
+		for _, c := range moderation.Commands(conf.Moderation) {
+			cmd.Register(c)
+		}
+	}
+	if conf.Operators != nil {
+		registerOperatorCommands(conf.Operators)
+	}
+	registerTimingsCommand()
+	registerBuildCommands()
 	srv.checkNetIsolation()
 
 	return srv
@@ -167,12 +337,115 @@ func (conf Config) New() *Server {
 // settings that affect different aspects of the server, such as its name and
 // maximum players. UserConfig may be serialised and can be converted to a
 // Config by calling UserConfig.Config().
+// The sections of a UserConfig are kept as they were before versioning was
+// introduced, so that existing configuration files remain valid. Grouping
+// them differently requires a new Version and a migration.
 type UserConfig struct {
+	// Version is the version of the configuration format. It is used to
+	// upgrade configurations written by older versions of the server and
+	// should not be changed manually.
+	Version int
 	// Network holds settings related to network aspects of the server.
 	Network struct {
 		// Address is the address on which the server should listen. Players may
 		// connect to this address in order to join.
 		Address string
+		// MetricsAddress is the address on which metrics are served in the
+		// Prometheus format on the /metrics path. Leave this empty to disable
+		// the metrics endpoint.
+		MetricsAddress string
+		// ProxyProtocol controls whether PROXY protocol headers are accepted,
+		// so that the real addresses of players joining through a proxy are
+		// known.
+		ProxyProtocol bool
+		// TrustedProxies is a list of IP addresses of proxies that PROXY
+		// protocol headers are accepted from. It must not be empty if
+		// ProxyProtocol is enabled.
+		TrustedProxies []string
+		// ForwardingSecret is a secret shared with a proxy that forwards the
+		// identities of players. If set, Xbox Live authentication is left to
+		// the proxy. Leave this empty unless the server runs behind a proxy.
+		ForwardingSecret string
+		// Query controls whether the server answers queries following the
+		// GameSpy 4 (UT3) query protocol on its address, which is used by
+		// server lists and monitoring services.
+		Query bool
+		// Compression is the algorithm used to compress packets. It is either
+		// "flate" or "snappy". Snappy uses less CPU but compresses less.
+		Compression string
+		// CompressionLevel is the level of flate compression, between 1 (fast)
+		// and 9 (small). Set it to 0 to use the default level.
+		CompressionLevel int
+		// FlushIntervalMillis is the interval in milliseconds at which packets
+		// buffered for a player are sent together in one batch.
+		FlushIntervalMillis int
+		// MaxBatchSize is the maximum amount of packets sent together in one
+		// batch. Set it to 0 to leave the batch size unlimited.
+		MaxBatchSize int
+		// PacketRateScale scales the limits on the rate at which players may
+		// send packets that are expensive to handle. Set it to 0 to disable
+		// rate limiting altogether.
+		PacketRateScale float64
+	}
+	RCON struct {
+		// Address is the TCP address on which RCON connections are accepted.
+		// Leave this empty to disable RCON.
+		Address string
+		// Password is the password that RCON clients authenticate with. RCON
+		// is not enabled unless a password is set.
+		Password string
+		// Commands is a list of commands that may be executed over RCON. If
+		// empty, all commands may be executed.
+		Commands []string
+		// RateLimit is the maximum amount of commands a single RCON client may
+		// execute per second. Set to 0 to disable the limit.
+		RateLimit int
+	}
+	Economy struct {
+		// File is the JSON file that the balances of players are stored in.
+		// Leave this empty to disable the economy commands and keep balances
+		// in memory only.
+		File string
+		// CurrencyName is the name of the currency, such as "Coins".
+		CurrencyName string
+		// CurrencySymbol is the symbol shown in front of amounts, such as "$".
+		CurrencySymbol string
+		// CurrencyDecimals is the amount of decimals of the currency. Set it
+		// to 0 for a currency without fractional amounts.
+		CurrencyDecimals int
+	}
+	Log struct {
+		// Level is the minimum level of messages logged. It is one of
+		// "debug", "info", "warn", "error" or "fatal".
+		Level string
+		// Format is the format in which messages are written. It is either
+		// "text" or "json". JSON is suited for shipping logs to aggregators.
+		Format string
+		// Subsystems holds levels for specific subsystems that override
+		// Level, in the form subsystem=level, such as "network=warn". The
+		// subsystems are server, network, world, entity and plugin.
+		Subsystems []string
+	}
+	Export struct {
+		// Webhooks is a list of URLs of HTTP webhooks that events such as
+		// players joining, chatting and being banned are posted to. Leave
+		// this empty to disable exporting events.
+		Webhooks []string
+		// Format is the format in which events are posted. It is either
+		// "json" or "discord". Discord posts events as webhook messages.
+		Format string
+		// Events is a list of the types of events that are exported. The
+		// types are join, quit, chat, death and ban. If empty, all events
+		// are exported.
+		Events []string
+	}
+	API struct {
+		// Address is the TCP address on which the HTTP admin API is served.
+		// Leave this empty to disable the API.
+		Address string
+		// Token is the token that requests to the API must be authorised
+		// with. The API is not served unless a token is set.
+		Token string
 	}
 	Server struct {
 		// Name is the name of the server as it shows up in the server list.
@@ -202,12 +475,23 @@ type UserConfig struct {
 		SaveData bool
 		// Folder is the folder that the data of the world resides in.
 		Folder string
+		// SpawnProtection is the radius in blocks around the spawn of the
+		// world in which players may not break, place or interact with
+		// blocks. Set this to 0 to disable spawn protection.
+		SpawnProtection int
+		// SaveIntervalMinutes is the interval in minutes at which the world
+		// and the data of online players are saved. Set this to 0 to only
+		// save data when the server is stopped.
+		SaveIntervalMinutes int
 	}
 	Players struct {
 		// MaxCount is the maximum amount of players allowed to join the server
 		// at the same time. If set to 0, the amount of maximum players will
 		// grow every time a player joins.
 		MaxCount int
+		// DisplayedMaxCount is the maximum amount of players shown in the
+		// server list. If set to 0, MaxCount is shown instead.
+		DisplayedMaxCount int
 		// MaximumChunkRadius is the maximum chunk radius that players may set
 		// in their settings. If they try to set it above this number, it will
 		// be capped and set to the max.
@@ -221,17 +505,52 @@ type UserConfig struct {
 		// Folder controls where the player data will be stored by the default
 		// LevelDB player provider if it is enabled.
 		Folder string
+		// Format is the format that player data is stored in if SaveData is
+		// true. It may be either "leveldb", to store the data of all players
+		// in a LevelDB database, or "nbt", to store the data of every player
+		// in a separate NBT file.
+		Format string
+		// PermissionsFile is the JSON file that permission groups and the
+		// permissions of players are stored in. If the file does not exist,
+		// it is created with a "default" group that players are in and that
+		// grants no permissions, and an "admin" group granting all of them.
+		PermissionsFile string
+		// OperatorsFile is the JSON file that the operators of the server and
+		// their levels are stored in. Leave this empty to disable operators.
+		OperatorsFile string
+		// ModerationFolder is the folder that the ban lists, whitelist and
+		// mute list are stored in. Leave this empty to disable them.
+		ModerationFolder string
+		// Whitelist controls whether only players on the whitelist may join
+		// the server.
+		Whitelist bool
+		// ValidateMovement controls whether the movement of players is
+		// validated server-side, moving players back if they move faster
+		// than allowed, fly without being allowed to or move into blocks.
+		ValidateMovement bool
 	}
 	Resources struct {
 		// AutoBuildPack is if the server should automatically generate a
 		// resource pack for custom features.
 		AutoBuildPack bool
 		// Folder controls the location where resource packs will be loaded
-		// from.
+		// from. Encrypted packs require a file holding their content key
+		// next to them, named after the pack with a .key extension added.
 		Folder string
+		// URLs is a list of URLs of resource packs that clients download
+		// directly, for example from a CDN, rather than through the game
+		// connection. Each pack is downloaded once by the server when it
+		// starts. URLs that cannot be reached are skipped, so that only the
+		// packs in the Folder are sent. Encrypted packs are not supported here.
+		URLs []string
 		// Required is a boolean to force the client to load the resource pack
 		// on join. If they do not accept, they'll have to leave the server.
 		Required bool
+		// SendRateKB is the maximum rate in kilobytes per second at which
+		// resource packs are sent to a joining player. Set it to 0 to leave
+		// the rate unlimited. The size of the chunks that packs are sent in is
+		// fixed by the client protocol library.
+		SendRateKB int
 	}
 }
 
@@ -239,21 +558,52 @@ type UserConfig struct {
 // a Server. An error is returned if creating data providers or loading
 // resources failed.
 func (uc UserConfig) Config(log Logger) (Config, error) {
+	if err := uc.Validate(); err != nil {
+		return Config{}, err
+	}
 	var err error
 	conf := Config{
 		Log:                     log,
 		Name:                    uc.Server.Name,
 		ResourcesRequired:       uc.Resources.Required,
+		ResourceSendRate:        uc.Resources.SendRateKB * 1024,
 		AuthDisabled:            !uc.Server.AuthEnabled,
 		MaxPlayers:              uc.Players.MaxCount,
+		DisplayedMaxPlayers:     uc.Players.DisplayedMaxCount,
+		Query:                   uc.Network.Query,
+		FlushRate:               time.Duration(uc.Network.FlushIntervalMillis) * time.Millisecond,
+		MaxBatchSize:            uc.Network.MaxBatchSize,
+		PacketRateLimits:        uc.rateLimits(),
 		MaxChunkRadius:          uc.Players.MaximumChunkRadius,
 		JoinMessage:             uc.Server.JoinMessage,
 		QuitMessage:             uc.Server.QuitMessage,
 		ShutdownMessage:         uc.Server.ShutdownMessage,
 		DisableResourceBuilding: !uc.Resources.AutoBuildPack,
+		SpawnProtection:         uc.World.SpawnProtection,
+		SaveInterval:            time.Duration(uc.World.SaveIntervalMinutes) * time.Minute,
+		MetricsAddress:          uc.Network.MetricsAddress,
+		RCON: RCON{
+			Address:   uc.RCON.Address,
+			Password:  uc.RCON.Password,
+			Commands:  uc.RCON.Commands,
+			RateLimit: uc.RCON.RateLimit,
+		},
+		API: API{Address: uc.API.Address, Token: uc.API.Token},
+		Forwarding: Forwarding{
+			ProxyProtocol:  uc.Network.ProxyProtocol,
+			TrustedProxies: uc.Network.TrustedProxies,
+			Secret:         uc.Network.ForwardingSecret,
+		},
+	}
+	conf.Compression, err = parseCompression(uc.Network.Compression, uc.Network.CompressionLevel)
+	if err != nil {
+		return conf, fmt.Errorf("parse compression: %w", err)
 	}
 	if uc.World.SaveData {
-		conf.WorldProvider, err = mcdb.Config{Log: log}.Open(uc.World.Folder)
+		conf.WorldProvider, err = mcdb.Config{
+			Log:       subsystemLog(log, logging.SubsystemWorld),
+			EntityLog: subsystemLog(log, logging.SubsystemEntity),
+		}.Open(uc.World.Folder)
 		if err != nil {
 			return conf, fmt.Errorf("create world provider: %w", err)
 		}
@@ -262,17 +612,67 @@ func (uc UserConfig) Config(log Logger) (Config, error) {
 	if err != nil {
 		return conf, fmt.Errorf("load resources: %w", err)
 	}
+	for _, url := range uc.Resources.URLs {
+		pack, err := resource.ReadURL(url)
+		if err != nil {
+			// An unreachable URL should not prevent the server from starting: Clients are still sent the other
+			// packs through the connection.
+			if log != nil {
+				log.Errorf("load resource from url %v: %v", url, err)
+			}
+			continue
+		}
+		conf.Resources = append(conf.Resources, pack)
+	}
 	if uc.Players.SaveData {
-		conf.PlayerProvider, err = playerdb.NewProvider(uc.Players.Folder)
+		if uc.Players.Format == "nbt" {
+			conf.PlayerProvider, err = playerdb.NewFileProvider(uc.Players.Folder)
+		} else {
+			conf.PlayerProvider, err = playerdb.NewProvider(uc.Players.Folder)
+		}
 		if err != nil {
 			return conf, fmt.Errorf("create player provider: %w", err)
 		}
 	}
+	if uc.Players.PermissionsFile != "" {
+		conf.PermissionProvider, err = permission.NewFileProvider(uc.Players.PermissionsFile)
+		if err != nil {
+			return conf, fmt.Errorf("create permission provider: %w", err)
+		}
+	}
+	if uc.Players.OperatorsFile != "" {
+		conf.Operators, err = permission.NewOperatorList(uc.Players.OperatorsFile)
+		if err != nil {
+			return conf, fmt.Errorf("create operator list: %w", err)
+		}
+	}
+	if uc.Players.ModerationFolder != "" {
+		conf.Moderation, err = moderation.NewManager(uc.Players.ModerationFolder)
+		if err != nil {
+			return conf, fmt.Errorf("create moderation manager: %w", err)
+		}
+		conf.Moderation.SetWhitelistEnabled(uc.Players.Whitelist)
+	}
+	if uc.Economy.File != "" {
+		conf.Economy, err = economy.NewStore(uc.Economy.File, economy.Currency{
+			Name:     uc.Economy.CurrencyName,
+			Symbol:   uc.Economy.CurrencySymbol,
+			Decimals: uc.Economy.CurrencyDecimals,
+		})
+		if err != nil {
+			return conf, fmt.Errorf("create economy: %w", err)
+		}
+	}
+	if uc.Players.ValidateMovement {
+		conf.MovementValidation = player.DefaultMovementValidation()
+	}
 	conf.Listeners = append(conf.Listeners, uc.listenerFunc)
 	return conf, nil
 }
 
-// loadResources loads all resource packs found in a directory passed.
+// loadResources loads all resource packs found in a directory passed. Packs may be .mcpack or .zip files or
+// directories. Encrypted packs are loaded with the content key found in a file next to the pack with the same
+// name and an additional .key extension, such as 'pack.mcpack.key'.
 func loadResources(dir string) ([]*resource.Pack, error) {
 	_ = os.MkdirAll(dir, 0777)
 
@@ -280,16 +680,30 @@ func loadResources(dir string) ([]*resource.Pack, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read dir: %w", err)
 	}
-	packs := make([]*resource.Pack, len(resources))
-	for i, entry := range resources {
-		packs[i], err = resource.ReadPath(filepath.Join(dir, entry.Name()))
+	packs := make([]*resource.Pack, 0, len(resources))
+	for _, entry := range resources {
+		if filepath.Ext(entry.Name()) == contentKeyExt {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		pack, err := resource.ReadPath(path)
 		if err != nil {
 			return nil, fmt.Errorf("compile resource (%v): %w", entry.Name(), err)
 		}
+		key, err := os.ReadFile(path + contentKeyExt)
+		if err == nil {
+			pack = pack.WithContentKey(strings.TrimSpace(string(key)))
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("read content key (%v): %w", entry.Name(), err)
+		}
+		packs = append(packs, pack)
 	}
 	return packs, nil
 }
 
+// contentKeyExt is the extension of files holding the content key of an encrypted resource pack.
+const contentKeyExt = ".key"
+
 // loadGenerator loads a standard world.Generator for a world.Dimension.
 func loadGenerator(dim world.Dimension) world.Generator {
 	switch dim {
@@ -305,8 +719,11 @@ func loadGenerator(dim world.Dimension) world.Generator {
 
 // DefaultConfig returns a configuration with the default values filled out.
 func DefaultConfig() UserConfig {
-	c := UserConfig{}
+	c := UserConfig{Version: currentConfigVersion}
 	c.Network.Address = ":19132"
+	c.Network.Compression = "flate"
+	c.Network.FlushIntervalMillis = 50
+	c.Network.PacketRateScale = 1
 	c.Server.Name = "Dragonfly Server"
 	c.Server.ShutdownMessage = "Server closed."
 	c.Server.AuthEnabled = true
@@ -314,9 +731,22 @@ func DefaultConfig() UserConfig {
 	c.Server.QuitMessage = "%v has left the game"
 	c.World.SaveData = true
 	c.World.Folder = "world"
+	c.World.SaveIntervalMinutes = 5
 	c.Players.MaximumChunkRadius = 32
 	c.Players.SaveData = true
 	c.Players.Folder = "players"
+	c.Players.Format = "leveldb"
+	c.Players.PermissionsFile = "permissions.json"
+	c.Players.OperatorsFile = "ops.json"
+	c.Players.ModerationFolder = "moderation"
+	c.RCON.RateLimit = 5
+	c.Log.Level = "debug"
+	c.Log.Format = "text"
+	c.Export.Format = "json"
+	c.Economy.File = "economy.json"
+	c.Economy.CurrencyName = economy.DefaultCurrency.Name
+	c.Economy.CurrencySymbol = economy.DefaultCurrency.Symbol
+	c.Economy.CurrencyDecimals = economy.DefaultCurrency.Decimals
 	c.Resources.AutoBuildPack = true
 	c.Resources.Folder = "resources"
 	c.Resources.Required = false
@@ -0,0 +1,282 @@
+package server
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/logging"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/pelletier/go-toml"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// currentConfigVersion is the version of the UserConfig format written by this
+// version of the server.
+const currentConfigVersion = 1
+
+// EnvPrefix is the prefix of environment variables that override values of a
+// UserConfig. A value is overridden by a variable named after the prefix, the
+// section and the field, such as DRAGONFLY_SERVER_NAME or
+// DRAGONFLY_NETWORK_ADDRESS.
+const EnvPrefix = "DRAGONFLY_"
+
+// ReadUserConfig reads a UserConfig from the TOML file at the path passed. If
+// the file does not exist, it is created with the values of DefaultConfig.
+// Configurations of older versions are upgraded in memory only: An existing
+// file is never written, so that its comments and formatting are kept.
+// Afterwards, values are overridden by environment variables as described in
+// UserConfig.ApplyEnv and the resulting UserConfig is validated.
+func ReadUserConfig(path string) (UserConfig, error) {
+	c := DefaultConfig()
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		if err := c.write(path); err != nil {
+			return c, err
+		}
+	case err != nil:
+		return c, fmt.Errorf("read config: %w", err)
+	default:
+		// Configurations without a version predate versioning, so the version
+		// is reset before decoding rather than defaulting to the latest.
+		c.Version = 0
+		if err := toml.Unmarshal(data, &c); err != nil {
+			return c, fmt.Errorf("decode config: %w", err)
+		}
+		if c.Version > currentConfigVersion {
+			return c, fmt.Errorf("config version %v is newer than the latest supported version %v", c.Version, currentConfigVersion)
+		}
+		if c.Version < currentConfigVersion {
+			c.migrate()
+		}
+	}
+	if err := c.ApplyEnv(); err != nil {
+		return c, err
+	}
+	return c, c.Validate()
+}
+
+// write encodes the UserConfig and writes it to the file at the path passed.
+func (uc UserConfig) write(path string) error {
+	data, err := toml.Marshal(uc)
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+// migrate upgrades a UserConfig of an older version to the current version.
+// Fields missing from an older configuration keep their default values, so
+// only fields whose meaning changed between versions need to be converted.
+func (uc *UserConfig) migrate() {
+	// Version 1 introduced versioning itself and only added new fields.
+	uc.Version = currentConfigVersion
+}
+
+// ApplyEnv overrides values of the UserConfig with those of environment
+// variables named after EnvPrefix, the section and the field in upper case,
+// such as DRAGONFLY_PLAYERS_MAXCOUNT. Lists are separated by commas. An error
+// is returned if the value of a variable could not be parsed.
+func (uc *UserConfig) ApplyEnv() error {
+	v := reflect.ValueOf(uc).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		section := v.Field(i)
+		if section.Kind() != reflect.Struct {
+			continue
+		}
+		sectionName := v.Type().Field(i).Name
+		for j := 0; j < section.NumField(); j++ {
+			name := EnvPrefix + strings.ToUpper(sectionName+"_"+section.Type().Field(j).Name)
+			val, ok := os.LookupEnv(name)
+			if !ok {
+				continue
+			}
+			if err := setEnvField(section.Field(j), val); err != nil {
+				return fmt.Errorf("environment variable %v: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// setEnvField parses the value of an environment variable and sets it to the
+// field passed.
+func setEnvField(field reflect.Value, val string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("expected true or false, got %q", val)
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("expected an integer, got %q", val)
+		}
+		field.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return fmt.Errorf("expected a number, got %q", val)
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		var list []string
+		if val != "" {
+			list = strings.Split(val, ",")
+		}
+		field.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("fields of type %v cannot be set", field.Type())
+	}
+	return nil
+}
+
+// ConfigError is returned by UserConfig.Validate if one or more values of the
+// UserConfig are invalid. It holds a description of every invalid value.
+type ConfigError struct {
+	// Problems holds a description of each invalid value, prefixed by the
+	// section and field it was found in.
+	Problems []string
+}
+
+// Error ...
+func (err ConfigError) Error() string {
+	return "invalid config:\n\t" + strings.Join(err.Problems, "\n\t")
+}
+
+// Validate checks if the values of the UserConfig are valid. If not, a
+// ConfigError describing each invalid value is returned.
+func (uc UserConfig) Validate() error {
+	var problems []string
+	check := func(invalid bool, field, format string, a ...any) {
+		if invalid {
+			problems = append(problems, field+": "+fmt.Sprintf(format, a...))
+		}
+	}
+	_, _, err := net.SplitHostPort(uc.Network.Address)
+	check(err != nil, "Network.Address", "%q is not an address of the form host:port, such as :19132", uc.Network.Address)
+	if uc.Network.MetricsAddress != "" {
+		_, _, err := net.SplitHostPort(uc.Network.MetricsAddress)
+		check(err != nil, "Network.MetricsAddress", "%q is not an address of the form host:port", uc.Network.MetricsAddress)
+	}
+	check(uc.Network.ProxyProtocol && len(uc.Network.TrustedProxies) == 0, "Network.TrustedProxies", "must not be empty if Network.ProxyProtocol is true")
+	for _, ip := range uc.Network.TrustedProxies {
+		check(net.ParseIP(ip) == nil, "Network.TrustedProxies", "%q is not an IP address", ip)
+	}
+	_, err = parseCompression(uc.Network.Compression, uc.Network.CompressionLevel)
+	check(err != nil, "Network.Compression", "%v", err)
+	check(uc.Network.FlushIntervalMillis < 0, "Network.FlushIntervalMillis", "must not be negative, got %v", uc.Network.FlushIntervalMillis)
+	check(uc.Network.MaxBatchSize < 0, "Network.MaxBatchSize", "must not be negative, got %v", uc.Network.MaxBatchSize)
+	check(uc.Network.PacketRateScale < 0, "Network.PacketRateScale", "must not be negative, got %v", uc.Network.PacketRateScale)
+	check(uc.Server.Name == "", "Server.Name", "must not be empty")
+	check(uc.World.SaveData && uc.World.Folder == "", "World.Folder", "must not be empty if World.SaveData is true")
+	check(uc.World.SpawnProtection < 0, "World.SpawnProtection", "must not be negative, got %v", uc.World.SpawnProtection)
+	check(uc.World.SaveIntervalMinutes < 0, "World.SaveIntervalMinutes", "must not be negative, got %v", uc.World.SaveIntervalMinutes)
+	check(uc.Players.MaxCount < 0, "Players.MaxCount", "must not be negative, got %v", uc.Players.MaxCount)
+	check(uc.Players.DisplayedMaxCount < 0, "Players.DisplayedMaxCount", "must not be negative, got %v", uc.Players.DisplayedMaxCount)
+	check(uc.Players.MaximumChunkRadius < 1, "Players.MaximumChunkRadius", "must be at least 1, got %v", uc.Players.MaximumChunkRadius)
+	check(uc.Players.SaveData && uc.Players.Folder == "", "Players.Folder", "must not be empty if Players.SaveData is true")
+	check(uc.Players.Format != "" && uc.Players.Format != "leveldb" && uc.Players.Format != "nbt", "Players.Format", "must be either \"leveldb\" or \"nbt\", got %q", uc.Players.Format)
+	_, _, err = uc.logLevels()
+	check(err != nil, "Log.Subsystems", "%v", err)
+	check(uc.Log.Format != "" && uc.Log.Format != "text" && uc.Log.Format != "json", "Log.Format", "must be either \"text\" or \"json\", got %q", uc.Log.Format)
+	for _, u := range uc.Export.Webhooks {
+		parsed, err := url.Parse(u)
+		check(err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https"), "Export.Webhooks", "%q is not an HTTP URL", u)
+	}
+	check(uc.Export.Format != "" && uc.Export.Format != "json" && uc.Export.Format != "discord", "Export.Format", "must be either \"json\" or \"discord\", got %q", uc.Export.Format)
+	check(uc.Economy.CurrencyDecimals < 0 || uc.Economy.CurrencyDecimals > 8, "Economy.CurrencyDecimals", "must be between 0 and 8, got %v", uc.Economy.CurrencyDecimals)
+	check(uc.RCON.RateLimit < 0, "RCON.RateLimit", "must not be negative, got %v", uc.RCON.RateLimit)
+	check(uc.Resources.SendRateKB < 0, "Resources.SendRateKB", "must not be negative, got %v", uc.Resources.SendRateKB)
+
+	if len(problems) > 0 {
+		return ConfigError{Problems: problems}
+	}
+	return nil
+}
+
+// Logger creates a logging.Logger that writes messages to the io.Writer passed
+// using the format and levels set in the Log section of the UserConfig. An
+// error is returned if the levels could not be parsed.
+func (uc UserConfig) Logger(w io.Writer) (*logging.Logger, error) {
+	var sink logging.Sink
+	if uc.Log.Format == "json" {
+		sink = logging.JSONSink(w)
+	} else {
+		sink = logging.TextSink(w)
+	}
+	log := logging.New(sink, logging.LevelInfo)
+	if err := uc.applyLogLevels(log); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// applyLogLevels sets the levels of the Log section of the UserConfig to the
+// logging.Logger passed, replacing any levels set previously.
+func (uc UserConfig) applyLogLevels(log *logging.Logger) error {
+	def, subsystems, err := uc.logLevels()
+	if err != nil {
+		return err
+	}
+	log.ResetLevels(def)
+	for subsystem, level := range subsystems {
+		log.SetLevel(subsystem, level)
+	}
+	return nil
+}
+
+// logLevels parses the default level and the levels per subsystem of the Log
+// section of the UserConfig.
+func (uc UserConfig) logLevels() (logging.Level, map[string]logging.Level, error) {
+	def := logging.LevelInfo
+	if uc.Log.Level != "" {
+		level, err := logging.ParseLevel(uc.Log.Level)
+		if err != nil {
+			return def, nil, err
+		}
+		def = level
+	}
+	subsystems := make(map[string]logging.Level, len(uc.Log.Subsystems))
+	for _, s := range uc.Log.Subsystems {
+		subsystem, name, ok := strings.Cut(s, "=")
+		if !ok {
+			return def, nil, fmt.Errorf("%q is not of the form subsystem=level", s)
+		}
+		level, err := logging.ParseLevel(strings.TrimSpace(name))
+		if err != nil {
+			return def, nil, fmt.Errorf("subsystem %v: %w", subsystem, err)
+		}
+		subsystems[strings.TrimSpace(subsystem)] = level
+	}
+	return def, subsystems, nil
+}
+
+// rateLimits returns the session.RateLimit applied to packets, scaled by the
+// PacketRateScale of the UserConfig.
+func (uc UserConfig) rateLimits() map[uint32]session.RateLimit {
+	limits := session.DefaultRateLimits()
+	if uc.Network.PacketRateScale == 0 {
+		return map[uint32]session.RateLimit{}
+	}
+	for id, limit := range limits {
+		limit.Rate *= uc.Network.PacketRateScale
+		limit.Burst = int(float64(limit.Burst) * uc.Network.PacketRateScale)
+		if limit.Burst < 1 {
+			limit.Burst = 1
+		}
+		limits[id] = limit
+	}
+	return limits
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/permission"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"io"
+	"strings"
+)
+
+// ConsoleSender is a cmd.Source that executes commands on behalf of the console of a Server, such as commands
+// typed in the terminal or received over a remote connection. A ConsoleSender has permission.LevelOwner, so it
+// may run every registered command. The output of commands is written to an io.Writer.
+type ConsoleSender struct {
+	name  string
+	w     *world.World
+	out   io.Writer
+	plain bool
+}
+
+// Compile time checks to make sure ConsoleSender implements cmd.Source and permission.Leveled.
+var (
+	_ cmd.Source         = (*ConsoleSender)(nil)
+	_ permission.Leveled = (*ConsoleSender)(nil)
+)
+
+// Console returns a ConsoleSender that executes commands in the overworld of the Server and writes their output
+// to the io.Writer passed.
+func (srv *Server) Console(out io.Writer) *ConsoleSender {
+	return &ConsoleSender{name: "Console", w: srv.World(), out: out}
+}
+
+// HandleConsole reads command lines from the io.Reader passed, such as os.Stdin, and executes each of them as a
+// ConsoleSender writing to the io.Writer passed. HandleConsole blocks until the io.Reader returns an error,
+// such as io.EOF.
+func (srv *Server) HandleConsole(r io.Reader, out io.Writer) {
+	c := srv.Console(out)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			c.ExecuteCommand(line)
+		}
+	}
+}
+
+// ExecuteCommand executes the command line passed, such as 'ban Steve', as the ConsoleSender. The leading slash
+// is optional.
+func (c *ConsoleSender) ExecuteCommand(commandLine string) {
+	cmd.Dispatch(c, commandLine)
+}
+
+// Name returns the name of the ConsoleSender, which is 'Console' for a ConsoleSender returned by
+// Server.Console.
+func (c *ConsoleSender) Name() string {
+	return c.name
+}
+
+// Position returns the spawn position of the world.World of the ConsoleSender.
+func (c *ConsoleSender) Position() mgl64.Vec3 {
+	return c.w.Spawn().Vec3Middle()
+}
+
+// World returns the world.World that commands executed by the ConsoleSender are executed in.
+func (c *ConsoleSender) World() *world.World {
+	return c.w
+}
+
+// OperatorLevel always returns permission.LevelOwner.
+func (c *ConsoleSender) OperatorLevel() permission.Level {
+	return permission.LevelOwner
+}
+
+// SendCommandOutput writes the messages and errors of the cmd.Output passed to the io.Writer of the
+// ConsoleSender, one per line. Colour codes are converted to ANSI escape codes.
+func (c *ConsoleSender) SendCommandOutput(o *cmd.Output) {
+	for _, m := range o.Messages() {
+		_, _ = fmt.Fprintln(c.out, c.format(m))
+	}
+	for _, err := range o.Errors() {
+		_, _ = fmt.Fprintln(c.out, c.format(text.Colourf("<red>%v</red>", err)))
+	}
+}
+
+// format converts the colour codes in the message passed to ANSI escape codes, or strips them if the
+// ConsoleSender writes plain text.
+func (c *ConsoleSender) format(m string) string {
+	if c.plain {
+		return text.Clean(m)
+	}
+	return text.ANSI(m)
+}
@@ -0,0 +1,178 @@
+package economy
+
+import (
+	"errors"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/permission"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/google/uuid"
+)
+
+// Commands returns the commands used to view and change balances of the Economy passed in-game: /balance,
+// /pay and /economy. /balance and /pay may be used by any player, while /economy requires the permission node
+// 'dragonfly.command.economy' or permission.LevelAdmin. The commands returned may be registered using
+// cmd.Register.
+func Commands(e Economy) []cmd.Command {
+	return []cmd.Command{
+		cmd.New("balance", "Shows the balance of a player.", []string{"bal", "money"}, balance{e: e}),
+		cmd.New("pay", "Pays another player.", nil, pay{e: e}),
+		cmd.New("economy", "Manages the balances of players.", []string{"eco"}, give{e: e}, take{e: e}),
+	}
+}
+
+// balance implements the /balance command.
+type balance struct {
+	e       Economy
+	Targets cmd.Optional[[]cmd.Target] `cmd:"player"`
+}
+
+// Run ...
+func (b balance) Run(src cmd.Source, o *cmd.Output) {
+	targets, ok := b.Targets.Load()
+	if !ok {
+		p, ok := src.(*player.Player)
+		if !ok {
+			o.Errorf("Usage: /balance <player>")
+			return
+		}
+		targets = []cmd.Target{p}
+	}
+	for _, p := range players(targets) {
+		amount, err := b.e.Balance(p.UUID())
+		if err != nil {
+			o.Error(err)
+			continue
+		}
+		o.Printf("Balance of %v: %v", p.Name(), b.e.Currency().Format(amount))
+	}
+}
+
+// pay implements the /pay command.
+type pay struct {
+	e      Economy
+	Target []cmd.Target `cmd:"player"`
+	Amount string       `cmd:"amount"`
+}
+
+// Allow ...
+func (pay) Allow(src cmd.Source) bool {
+	_, ok := src.(*player.Player)
+	return ok
+}
+
+// Run ...
+func (p pay) Run(src cmd.Source, o *cmd.Output) {
+	from := src.(*player.Player)
+	targets := players(p.Target)
+	if len(targets) != 1 {
+		o.Errorf("You can only pay one player at a time.")
+		return
+	}
+	amount, ok := parseAmount(p.e, p.Amount, o)
+	if !ok {
+		return
+	}
+	to := targets[0]
+	if to == from {
+		o.Errorf("You cannot pay yourself.")
+		return
+	}
+	err := Transfer(p.e, uuid.NewString(), from.UUID(), to.UUID(), amount, "payment")
+	if errors.Is(err, ErrInsufficientFunds) {
+		o.Errorf("You do not have %v.", p.e.Currency().Format(amount))
+		return
+	} else if err != nil {
+		o.Error(err)
+		return
+	}
+	o.Printf("Paid %v to %v.", p.e.Currency().Format(amount), to.Name())
+	to.Messagef("%v paid you %v.", from.Name(), p.e.Currency().Format(amount))
+}
+
+// give implements the /economy give command.
+type give struct {
+	e       Economy
+	Sub     cmd.SubCommand `cmd:"give"`
+	Targets []cmd.Target   `cmd:"player"`
+	Amount  string         `cmd:"amount"`
+}
+
+// Permission ...
+func (give) Permission() string { return "dragonfly.command.economy" }
+
+// Level ...
+func (give) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (g give) Run(_ cmd.Source, o *cmd.Output) {
+	amount, ok := parseAmount(g.e, g.Amount, o)
+	if !ok {
+		return
+	}
+	for _, p := range players(g.Targets) {
+		if err := Deposit(g.e, uuid.NewString(), p.UUID(), amount, "given by command"); err != nil {
+			o.Error(err)
+			continue
+		}
+		o.Printf("Gave %v to %v.", g.e.Currency().Format(amount), p.Name())
+	}
+}
+
+// take implements the /economy take command.
+type take struct {
+	e       Economy
+	Sub     cmd.SubCommand `cmd:"take"`
+	Targets []cmd.Target   `cmd:"player"`
+	Amount  string         `cmd:"amount"`
+}
+
+// Permission ...
+func (take) Permission() string { return "dragonfly.command.economy" }
+
+// Level ...
+func (take) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (t take) Run(_ cmd.Source, o *cmd.Output) {
+	amount, ok := parseAmount(t.e, t.Amount, o)
+	if !ok {
+		return
+	}
+	for _, p := range players(t.Targets) {
+		err := Withdraw(t.e, uuid.NewString(), p.UUID(), amount, "taken by command")
+		if errors.Is(err, ErrInsufficientFunds) {
+			o.Errorf("%v does not have %v.", p.Name(), t.e.Currency().Format(amount))
+			continue
+		} else if err != nil {
+			o.Error(err)
+			continue
+		}
+		o.Printf("Took %v from %v.", t.e.Currency().Format(amount), p.Name())
+	}
+}
+
+// parseAmount parses an amount passed to a command, writing an error to the output if it is not a positive
+// amount of the Currency of the Economy.
+func parseAmount(e Economy, s string, o *cmd.Output) (int64, bool) {
+	amount, err := e.Currency().Parse(s)
+	if err != nil {
+		o.Error(err)
+		return 0, false
+	}
+	if amount <= 0 {
+		o.Errorf("The amount must be positive.")
+		return 0, false
+	}
+	return amount, true
+}
+
+// players returns all players among the targets passed.
+func players(targets []cmd.Target) []*player.Player {
+	var players []*player.Player
+	for _, t := range targets {
+		if p, ok := t.(*player.Player); ok {
+			players = append(players, p)
+		}
+	}
+	return players
+}
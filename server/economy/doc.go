@@ -0,0 +1,8 @@
+// Package economy implements a single currency shared by all parts of a server, so that plugins such as shops,
+// auctions and jobs operate on the same balances.
+//
+// An Economy holds the balance of every account and changes them through Transactions. Each Transaction has an
+// ID, so that retrying a Transaction that may or may not have been applied never applies it twice. Store is the
+// default Economy and is stored in a JSON file. Commands returned by Commands may be registered to let players
+// view and transfer their balance in-game.
+package economy
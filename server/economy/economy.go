@@ -0,0 +1,131 @@
+package economy
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInsufficientFunds is returned when applying a Transaction that takes more from an account than its
+	// balance holds.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrInvalidTransaction is returned when applying a Transaction without an ID, with an amount that is not
+	// positive or that moves money from an account to itself.
+	ErrInvalidTransaction = errors.New("invalid transaction")
+)
+
+// Economy holds the balances of accounts in a single Currency. Accounts are identified by a UUID, which is the
+// UUID of a player for player accounts. Plugins may use other UUIDs, such as those created using
+// uuid.NewSHA1, for accounts that do not belong to players. Balances are amounts of the smallest unit of the
+// Currency and are never negative.
+// Implementations of Economy must be safe for concurrent use.
+type Economy interface {
+	// Currency returns the Currency that balances are held in.
+	Currency() Currency
+	// Balance returns the balance of an account. Accounts that never received anything have a balance of 0.
+	Balance(account uuid.UUID) (int64, error)
+	// Apply applies a Transaction, moving its amount from one account to another. Applying a Transaction with
+	// an ID that was applied before has no effect and does not return an error, so that Transactions may be
+	// retried safely. ErrInsufficientFunds is returned if the account the amount is taken from does not hold
+	// enough, in which case no balance is changed.
+	Apply(tx Transaction) error
+	// Transaction looks up a Transaction applied before by its ID. False is returned if no Transaction with
+	// the ID was applied, or if it was applied too long ago to be remembered.
+	Transaction(id string) (Transaction, bool)
+	// Close closes the Economy, saving any balances that were not yet saved.
+	Close() error
+}
+
+// Transaction moves an amount of money from one account to another. Money is created when moving it from
+// uuid.Nil and removed when moving it to uuid.Nil, so that deposits and withdrawals are Transactions too.
+type Transaction struct {
+	// ID uniquely identifies the Transaction. Applying a Transaction with the same ID twice only applies it
+	// once. A random ID may be obtained using uuid.NewString.
+	ID string `json:"id"`
+	// From is the account that the Amount is taken from. If uuid.Nil, the Amount is created.
+	From uuid.UUID `json:"from"`
+	// To is the account that the Amount is given to. If uuid.Nil, the Amount is removed.
+	To uuid.UUID `json:"to"`
+	// Amount is the amount moved, in the smallest unit of the Currency. It must be positive.
+	Amount int64 `json:"amount"`
+	// Reason is a description of the Transaction, such as 'shop purchase'. It is only used for display
+	// purposes.
+	Reason string `json:"reason,omitempty"`
+	// Time is the time at which the Transaction was applied. It is set when the Transaction is applied.
+	Time time.Time `json:"time"`
+}
+
+// Deposit adds an amount to the balance of an account of the Economy passed using a Transaction with the ID
+// passed.
+func Deposit(e Economy, id string, account uuid.UUID, amount int64, reason string) error {
+	return e.Apply(Transaction{ID: id, To: account, Amount: amount, Reason: reason})
+}
+
+// Withdraw takes an amount from the balance of an account of the Economy passed using a Transaction with the ID
+// passed. ErrInsufficientFunds is returned if the account does not hold enough.
+func Withdraw(e Economy, id string, account uuid.UUID, amount int64, reason string) error {
+	return e.Apply(Transaction{ID: id, From: account, Amount: amount, Reason: reason})
+}
+
+// Transfer moves an amount from one account of the Economy passed to another using a Transaction with the ID
+// passed. ErrInsufficientFunds is returned if the account the amount is taken from does not hold enough.
+func Transfer(e Economy, id string, from, to uuid.UUID, amount int64, reason string) error {
+	return e.Apply(Transaction{ID: id, From: from, To: to, Amount: amount, Reason: reason})
+}
+
+// Currency describes the currency of an Economy. Amounts are stored as integers in the smallest unit of the
+// Currency to avoid rounding errors, and are formatted using the amount of Decimals of the Currency.
+type Currency struct {
+	// Name is the name of the Currency, such as 'Coins'.
+	Name string
+	// Symbol is the symbol written in front of formatted amounts, such as '$'.
+	Symbol string
+	// Decimals is the amount of decimals of the Currency. An amount of 150 is formatted as 1.50 if Decimals
+	// is 2.
+	Decimals int
+}
+
+// DefaultCurrency is the Currency used by an Economy if none is set.
+var DefaultCurrency = Currency{Name: "Coins", Symbol: "$", Decimals: 2}
+
+// Format formats an amount in the smallest unit of the Currency, such as $1.50.
+func (c Currency) Format(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	if c.Decimals <= 0 {
+		return fmt.Sprintf("%v%v%d", sign, c.Symbol, amount)
+	}
+	unit := c.unit()
+	return fmt.Sprintf("%v%v%d.%0*d", sign, c.Symbol, amount/unit, c.Decimals, amount%unit)
+}
+
+// Parse parses an amount such as '1.50' or '$1.50' into an amount in the smallest unit of the Currency. An
+// error is returned if the amount is not a number, has more decimals than the Currency or is too large.
+func (c Currency) Parse(s string) (int64, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), c.Symbol)
+	whole, frac, _ := strings.Cut(s, ".")
+	if len(frac) > c.Decimals || (frac != "" && c.Decimals <= 0) {
+		return 0, fmt.Errorf("amount %q has more than %v decimals", s, c.Decimals)
+	}
+	if strings.HasPrefix(frac, "-") || strings.HasPrefix(frac, "+") {
+		return 0, fmt.Errorf("amount %q is not a number", s)
+	}
+	frac += strings.Repeat("0", c.Decimals-len(frac))
+	n, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount %q is not a number", s)
+	}
+	return n, nil
+}
+
+// unit returns the amount of the smallest unit of the Currency that makes up one whole unit.
+func (c Currency) unit() int64 {
+	return int64(math.Pow10(c.Decimals))
+}
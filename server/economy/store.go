@@ -0,0 +1,223 @@
+package economy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// saveDelay is the delay after a Transaction is applied before a Store writes its file. Transactions applied
+// within the delay are written at once.
+const saveDelay = time.Second
+
+// maxTransactions is the amount of most recent Transactions that a Store remembers. Transactions applied
+// before those are forgotten, so retrying them applies them again.
+const maxTransactions = 10000
+
+// Store is the default Economy. It keeps balances in memory and writes them to a JSON file in the background
+// shortly after Transactions are applied, together with the most recent Transactions so that their IDs are
+// remembered across restarts. Writes that fail are retried, and Close writes the file a final time.
+// Methods on Store are safe for simultaneous use from multiple goroutines.
+type Store struct {
+	path     string
+	currency Currency
+
+	mu           sync.RWMutex
+	balances     map[uuid.UUID]int64
+	transactions []Transaction
+	ids          map[string]int
+
+	// saveMu ensures that only one goroutine writes the file at a time.
+	saveMu sync.Mutex
+	dirty  chan struct{}
+	once   sync.Once
+	closed chan struct{}
+	saved  chan struct{}
+}
+
+// storeData is the data of a Store as written to its JSON file.
+type storeData struct {
+	Balances     map[uuid.UUID]int64 `json:"balances"`
+	Transactions []Transaction       `json:"transactions"`
+}
+
+// NewStore creates a Store with balances in the Currency passed that reads from and writes to the JSON file at
+// the path passed. If the file does not exist, it is created when the first Transaction is applied. If the path
+// is empty, the Store is kept in memory only.
+func NewStore(path string, c Currency) (*Store, error) {
+	s := &Store{path: path, currency: c, balances: map[uuid.UUID]int64{}, ids: map[string]int{}, dirty: make(chan struct{}, 1), closed: make(chan struct{}), saved: make(chan struct{})}
+	if path == "" {
+		close(s.saved)
+		return s, nil
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		go s.saveLoop()
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read economy: %w", err)
+	}
+	var data storeData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, fmt.Errorf("decode economy: %w", err)
+	}
+	if data.Balances != nil {
+		s.balances = data.Balances
+	}
+	s.transactions = data.Transactions
+	for i, tx := range s.transactions {
+		s.ids[tx.ID] = i
+	}
+	go s.saveLoop()
+	return s, nil
+}
+
+// Currency ...
+func (s *Store) Currency() Currency {
+	return s.currency
+}
+
+// Balance ...
+func (s *Store) Balance(account uuid.UUID) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.balances[account], nil
+}
+
+// Balances returns the balances of all accounts in the Store that hold more than 0.
+func (s *Store) Balances() map[uuid.UUID]int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m := make(map[uuid.UUID]int64, len(s.balances))
+	for account, balance := range s.balances {
+		m[account] = balance
+	}
+	return m
+}
+
+// Apply applies the Transaction to the balances held in memory. The file of the Store is written shortly
+// after in the background, so errors writing it are not returned.
+func (s *Store) Apply(tx Transaction) error {
+	if tx.ID == "" || tx.Amount <= 0 || tx.From == tx.To {
+		return ErrInvalidTransaction
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.ids[tx.ID]; ok {
+		return nil
+	}
+	if tx.From != uuid.Nil && s.balances[tx.From] < tx.Amount {
+		return ErrInsufficientFunds
+	}
+	if tx.To != uuid.Nil && s.balances[tx.To] > math.MaxInt64-tx.Amount {
+		return fmt.Errorf("apply transaction: balance of %v would overflow", tx.To)
+	}
+	if tx.From != uuid.Nil {
+		if s.balances[tx.From] -= tx.Amount; s.balances[tx.From] == 0 {
+			delete(s.balances, tx.From)
+		}
+	}
+	if tx.To != uuid.Nil {
+		s.balances[tx.To] += tx.Amount
+	}
+	tx.Time = time.Now()
+	s.remember(tx)
+	s.markDirty()
+	return nil
+}
+
+// Transaction ...
+func (s *Store) Transaction(id string) (Transaction, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i, ok := s.ids[id]
+	if !ok {
+		return Transaction{}, false
+	}
+	return s.transactions[i], true
+}
+
+// Close stops writing the file of the Store in the background and writes it a final time. The error returned
+// is that of the final write.
+func (s *Store) Close() error {
+	s.once.Do(func() { close(s.closed) })
+	<-s.saved
+	return s.save()
+}
+
+// markDirty schedules the file of the Store to be written.
+func (s *Store) markDirty() {
+	select {
+	case s.dirty <- struct{}{}:
+	default:
+		// A write is already scheduled.
+	}
+}
+
+// saveLoop writes the file of the Store saveDelay after it is marked dirty, until the Store is closed. Failed
+// writes are retried after the same delay.
+func (s *Store) saveLoop() {
+	defer close(s.saved)
+	for {
+		select {
+		case <-s.closed:
+			return
+		case <-s.dirty:
+		}
+		select {
+		case <-s.closed:
+			return
+		case <-time.After(saveDelay):
+		}
+		if err := s.save(); err != nil {
+			s.markDirty()
+		}
+	}
+}
+
+// remember adds a Transaction to the most recent Transactions of the Store, forgetting the oldest ones if more
+// than maxTransactions are remembered.
+func (s *Store) remember(tx Transaction) {
+	s.transactions = append(s.transactions, tx)
+	if len(s.transactions) <= maxTransactions {
+		s.ids[tx.ID] = len(s.transactions) - 1
+		return
+	}
+	// Forget the oldest tenth of the transactions at once, so that the IDs need not be reindexed after every
+	// transaction.
+	s.transactions = append([]Transaction(nil), s.transactions[maxTransactions/10:]...)
+	s.ids = make(map[string]int, len(s.transactions))
+	for i, tx := range s.transactions {
+		s.ids[tx.ID] = i
+	}
+}
+
+// save writes the balances and Transactions of the Store to its file. The Store is only locked while its data
+// is encoded, so that Transactions may be applied while the file is written.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	s.mu.RLock()
+	b, err := json.Marshal(storeData{Balances: s.balances, Transactions: s.transactions})
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("encode economy: %w", err)
+	}
+	// Write to a temporary file first, so that the file is never left half-written.
+	if err := os.WriteFile(s.path+".tmp", b, 0644); err != nil {
+		return fmt.Errorf("write economy: %w", err)
+	}
+	if err := os.Rename(s.path+".tmp", s.path); err != nil {
+		return fmt.Errorf("write economy: %w", err)
+	}
+	return nil
+}
@@ -41,6 +41,17 @@ type PickedUpAction struct {
 	action
 }
 
+// WakeUpAction is a world.EntityAction that makes a sleeping entity wake up, leaving the bed it was sleeping in.
+type WakeUpAction struct{ action }
+
+// TotemUseAction is a world.EntityAction that makes an entity display the animation of a totem of undying being
+// used, showing the totem on the screen of the player that used it and particles around the entity.
+type TotemUseAction struct{ action }
+
+// LoveAction is a world.EntityAction that makes an entity display heart particles, such as when an animal is
+// fed and enters love mode.
+type LoveAction struct{ action }
+
 // FireworkExplosionAction is a world.EntityAction that makes a Firework rocket display an explosion particle.
 type FireworkExplosionAction struct{ action }
 
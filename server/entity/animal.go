@@ -0,0 +1,335 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// AnimalBehaviourConfig holds optional parameters for an AnimalBehaviour.
+type AnimalBehaviourConfig struct {
+	// BreedingItems are the items that may be fed to the animal to make it
+	// enter love mode. Feeding one of these items to a baby animal makes it
+	// grow up faster.
+	BreedingItems []world.Item
+	// MaxHealth is the maximum health of the animal. If 0, a maximum health of
+	// 10 is used.
+	MaxHealth float64
+	// Speed is the movement speed of the animal in blocks per tick. If 0, a
+	// speed of 0.1 is used.
+	Speed float64
+	// LoveDuration is the duration that an animal stays in love mode after
+	// being fed. If 0, animals stay in love for 30 seconds.
+	LoveDuration time.Duration
+	// BreedingCooldown is the duration after breeding during which an animal
+	// cannot enter love mode again. If 0, a cooldown of 5 minutes is used.
+	BreedingCooldown time.Duration
+	// GrowthDuration is the duration it takes for a baby animal to grow up. If
+	// 0, babies grow up after 20 minutes.
+	GrowthDuration time.Duration
+	// Baby specifies if the animal is created as a baby.
+	Baby bool
+}
+
+// New creates an animal with the world.EntityType passed at a position using
+// the parameters in conf. The children of the animal are created using the
+// same parameters and world.EntityType.
+func (conf AnimalBehaviourConfig) New(t world.EntityType, pos mgl64.Vec3) *Mob {
+	if conf.MaxHealth <= 0 {
+		conf.MaxHealth = 10
+	}
+	if conf.LoveDuration <= 0 {
+		conf.LoveDuration = time.Second * 30
+	}
+	if conf.BreedingCooldown <= 0 {
+		conf.BreedingCooldown = time.Minute * 5
+	}
+	if conf.GrowthDuration <= 0 {
+		conf.GrowthDuration = time.Minute * 20
+	}
+	b := &AnimalBehaviour{conf: conf}
+	if conf.Baby {
+		b.growth = conf.GrowthDuration
+	}
+	return MobConfig{Behaviour: b, MaxHealth: conf.MaxHealth, Speed: conf.Speed}.New(t, pos)
+}
+
+// AnimalBehaviour implements the behaviour of passive animals that may be
+// bred. Animals wander around and enter love mode when fed one of their
+// breeding items. Two animals of the same type in love seek each other out
+// and create a baby, which grows up over time.
+type AnimalBehaviour struct {
+	conf   AnimalBehaviourConfig
+	wander Wander
+
+	mu       sync.Mutex
+	love     time.Duration
+	cooldown time.Duration
+	growth   time.Duration
+}
+
+// BreedingItem checks if the item passed is one of the breeding items of the
+// animal.
+func (a *AnimalBehaviour) BreedingItem(it world.Item) bool {
+	name, _ := it.EncodeItem()
+	for _, b := range a.conf.BreedingItems {
+		if n, _ := b.EncodeItem(); n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Baby checks if the animal is a baby.
+func (a *AnimalBehaviour) Baby() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.growth > 0
+}
+
+// Growth returns the remaining duration until a baby animal grows up. Growth
+// returns 0 if the animal is not a baby.
+func (a *AnimalBehaviour) Growth() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.growth
+}
+
+// SetGrowth sets the remaining duration until the animal grows up. Passing a
+// duration above 0 turns the animal into a baby, while passing 0 makes it
+// grow up when it is next ticked.
+func (a *AnimalBehaviour) SetGrowth(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.growth = d
+}
+
+// Scale returns the scale of the animal, which is 0.5 for babies and 1 for
+// adults.
+func (a *AnimalBehaviour) Scale() float64 {
+	if a.Baby() {
+		return 0.5
+	}
+	return 1
+}
+
+// InLove checks if the animal is currently in love mode.
+func (a *AnimalBehaviour) InLove() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.love > 0
+}
+
+// SetInLove puts the animal in love mode for the LoveDuration of the animal,
+// regardless of its breeding cooldown. Babies cannot enter love mode.
+func (a *AnimalBehaviour) SetInLove() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.growth <= 0 {
+		a.love = a.conf.LoveDuration
+	}
+}
+
+// BreedingCooldown returns the remaining duration until the animal can enter
+// love mode again.
+func (a *AnimalBehaviour) BreedingCooldown() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.cooldown
+}
+
+// Tick makes the animal wander around or, if it is in love, walk towards a
+// partner in love nearby to breed with it. Babies grow up over time.
+func (a *AnimalBehaviour) Tick(m *Mob) {
+	a.mu.Lock()
+	a.love, a.cooldown = a.love-time.Second/20, a.cooldown-time.Second/20
+	grown := a.growth > 0 && a.growth <= time.Second/20
+	a.growth -= time.Second / 20
+	love := a.love
+	if a.love < 0 {
+		a.love = 0
+	}
+	if a.cooldown < 0 {
+		a.cooldown = 0
+	}
+	if a.growth < 0 {
+		a.growth = 0
+	}
+	a.mu.Unlock()
+
+	if grown {
+		m.updateState()
+	}
+	if love <= 0 {
+		a.wander.Tick(m)
+		return
+	}
+	if love%(time.Second/2) == 0 {
+		for _, v := range m.World().Viewers(m.Position()) {
+			v.ViewEntityAction(m, LoveAction{})
+		}
+	}
+	partner, ok := a.partner(m)
+	if !ok {
+		a.wander.Tick(m)
+		return
+	}
+	m.LookAt(partner.Position())
+	if partner.Position().Sub(m.Position()).Len() > 1.5 {
+		m.WalkTowards(partner.Position())
+		return
+	}
+	m.Stop()
+	a.breed(m, partner)
+}
+
+// partner looks for the closest animal of the same type as m that is in love
+// and is not a baby.
+func (a *AnimalBehaviour) partner(m *Mob) (*Mob, bool) {
+	pos := m.Position()
+	var (
+		partner *Mob
+		dist    = 8.0
+	)
+	for _, e := range m.World().EntitiesWithin(cube.Box(-8, -4, -8, 8, 4, 8).Translate(pos), nil) {
+		other, ok := e.(*Mob)
+		if !ok || other == m || other.Dead() || other.Type().EncodeEntity() != m.Type().EncodeEntity() {
+			continue
+		}
+		b, ok := other.Behaviour().(*AnimalBehaviour)
+		if !ok || b.Baby() || !b.InLove() {
+			continue
+		}
+		if d := other.Position().Sub(pos).Len(); d < dist {
+			partner, dist = other, d
+		}
+	}
+	return partner, partner != nil
+}
+
+// breed makes m breed with the partner passed, spawning a baby between the two
+// if the breeding is not cancelled by the world.Handler.
+func (a *AnimalBehaviour) breed(m, partner *Mob) {
+	b := partner.Behaviour().(*AnimalBehaviour)
+	w, pos := m.World(), m.Position().Add(partner.Position()).Mul(0.5)
+
+	conf := a.conf
+	conf.Baby = true
+	child := conf.New(m.Type(), pos)
+
+	ctx := event.C()
+	w.Handler().HandleEntityBreed(ctx, m, partner, child)
+	a.stopLove(!ctx.Cancelled())
+	b.stopLove(!ctx.Cancelled())
+	if ctx.Cancelled() {
+		return
+	}
+	w.AddEntity(child)
+	for _, orb := range NewExperienceOrbs(pos, rand.Intn(7)+1) {
+		w.AddEntity(orb)
+	}
+}
+
+// stopLove makes the animal leave love mode. If bred is true, the animal
+// receives its breeding cooldown.
+func (a *AnimalBehaviour) stopLove(bred bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.love = 0
+	if bred {
+		a.cooldown = a.conf.BreedingCooldown
+	}
+}
+
+// Interact feeds the item held by the user to the animal if it is one of its
+// breeding items. Adults enter love mode, while babies grow up 10% faster.
+func (a *AnimalBehaviour) Interact(m *Mob, user item.User, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	if held.Empty() || !a.BreedingItem(held.Item()) {
+		return false
+	}
+	a.mu.Lock()
+	baby := a.growth > 0
+	if baby {
+		a.growth -= a.growth / 10
+	} else if a.love > 0 || a.cooldown > 0 {
+		a.mu.Unlock()
+		return false
+	} else {
+		a.love = a.conf.LoveDuration
+	}
+	a.mu.Unlock()
+
+	ctx.SubtractFromCount(1)
+	for _, v := range m.World().Viewers(m.Position()) {
+		if baby {
+			v.ViewEntityAction(m, EatAction{})
+			continue
+		}
+		v.ViewEntityAction(m, LoveAction{})
+	}
+	return true
+}
+
+// animalType is a world.EntityType of an animal with an AnimalBehaviour.
+type animalType interface {
+	world.EntityType
+	// config returns the AnimalBehaviourConfig used for animals of the type.
+	config() AnimalBehaviourConfig
+}
+
+// animalBBox returns the bounding box of an animal with the width and height
+// passed. The bounding box of babies is scaled down.
+func animalBBox(e world.Entity, width, height float64) cube.BBox {
+	if m, ok := e.(*Mob); ok {
+		if b, ok := m.Behaviour().(*AnimalBehaviour); ok {
+			scale := b.Scale()
+			width, height = width*scale, height*scale
+		}
+	}
+	return cube.Box(-width/2, 0, -width/2, width/2, height, width/2)
+}
+
+// decodeAnimal decodes an animal of the animalType passed from NBT data.
+func decodeAnimal(t animalType, m map[string]any) *Mob {
+	a := t.config().New(t, nbtconv.Vec3(m, "Pos"))
+	a.vel, a.rot = nbtconv.Vec3(m, "Motion"), nbtconv.Rotation(m)
+	if health := float64(nbtconv.Float32(m, "Health")); health > 0 {
+		a.health.AddHealth(health - a.MaxHealth())
+	}
+	b := a.Behaviour().(*AnimalBehaviour)
+	if age := nbtconv.Int32(m, "Age"); age < 0 && nbtconv.Bool(m, "IsBaby") {
+		b.growth = time.Duration(-age) * time.Second / 20
+	}
+	b.love = nbtconv.TickDuration[int32](m, "InLove")
+	b.cooldown = nbtconv.TickDuration[int32](m, "BreedCooldown")
+	return a
+}
+
+// encodeAnimal encodes an animal to NBT data.
+func encodeAnimal(e world.Entity) map[string]any {
+	a := e.(*Mob)
+	b := a.Behaviour().(*AnimalBehaviour)
+	b.mu.Lock()
+	growth, love, cooldown := b.growth, b.love, b.cooldown
+	b.mu.Unlock()
+
+	yaw, pitch := a.Rotation().Elem()
+	return map[string]any{
+		"Pos":           nbtconv.Vec3ToFloat32Slice(a.Position()),
+		"Motion":        nbtconv.Vec3ToFloat32Slice(a.Velocity()),
+		"Yaw":           float32(yaw),
+		"Pitch":         float32(pitch),
+		"Health":        float32(a.Health()),
+		"IsBaby":        boolByte(growth > 0),
+		"Age":           int32(-growth.Milliseconds() / 50),
+		"InLove":        int32(love.Milliseconds() / 50),
+		"BreedCooldown": int32(cooldown.Milliseconds() / 50),
+	}
+}
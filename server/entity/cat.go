@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// NewCat creates a stray cat with a random variant at the position passed.
+// Cats are tamed using raw cod or salmon.
+func NewCat(pos mgl64.Vec3) *Mob {
+	conf := CatType{}.config()
+	conf.Variant = rand.Int31n(11)
+	return conf.New(CatType{}, pos)
+}
+
+// CatType is a world.EntityType implementation for cats.
+type CatType struct{}
+
+func (CatType) EncodeEntity() string { return "minecraft:cat" }
+func (CatType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 0.7, 0.3)
+}
+
+func (t CatType) DecodeNBT(m map[string]any) world.Entity { return decodePet(t, m) }
+func (CatType) EncodeNBT(e world.Entity) map[string]any   { return encodePet(e) }
+
+// config returns the PetBehaviourConfig of cats.
+func (CatType) config() PetBehaviourConfig {
+	return PetBehaviourConfig{
+		TamingItems: []world.Item{item.Cod{}, item.Salmon{}},
+		MaxHealth:   10,
+		Speed:       0.15,
+		Collar:      true,
+	}
+}
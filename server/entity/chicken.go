@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewChicken creates a chicken at the position passed.
+func NewChicken(pos mgl64.Vec3) *Mob {
+	return ChickenType{}.config().New(ChickenType{}, pos)
+}
+
+// ChickenType is a world.EntityType implementation for chickens.
+type ChickenType struct{}
+
+func (ChickenType) EncodeEntity() string { return "minecraft:chicken" }
+func (ChickenType) BBox(e world.Entity) cube.BBox {
+	return animalBBox(e, 0.4, 0.7)
+}
+
+func (t ChickenType) DecodeNBT(m map[string]any) world.Entity { return decodeAnimal(t, m) }
+func (ChickenType) EncodeNBT(e world.Entity) map[string]any   { return encodeAnimal(e) }
+
+// config returns the AnimalBehaviourConfig of chickens.
+func (ChickenType) config() AnimalBehaviourConfig {
+	return AnimalBehaviourConfig{
+		BreedingItems: []world.Item{block.WheatSeeds{}, block.BeetrootSeeds{}, block.MelonSeeds{}, block.PumpkinSeeds{}},
+		MaxHealth:     4,
+	}
+}
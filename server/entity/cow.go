@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewCow creates a cow at the position passed.
+func NewCow(pos mgl64.Vec3) *Mob {
+	return CowType{}.config().New(CowType{}, pos)
+}
+
+// CowType is a world.EntityType implementation for cows.
+type CowType struct{}
+
+func (CowType) EncodeEntity() string { return "minecraft:cow" }
+func (CowType) BBox(e world.Entity) cube.BBox {
+	return animalBBox(e, 0.9, 1.3)
+}
+
+func (t CowType) DecodeNBT(m map[string]any) world.Entity { return decodeAnimal(t, m) }
+func (CowType) EncodeNBT(e world.Entity) map[string]any   { return encodeAnimal(e) }
+
+// config returns the AnimalBehaviourConfig of cows.
+func (CowType) config() AnimalBehaviourConfig {
+	return AnimalBehaviourConfig{
+		BreedingItems: []world.Item{item.Wheat{}},
+		MaxHealth:     10,
+	}
+}
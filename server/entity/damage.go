@@ -46,6 +46,10 @@ type (
 
 	// ExplosionDamageSource is used for damage caused by an explosion.
 	ExplosionDamageSource struct{}
+
+	// FreezeDamageSource is used for damage caused by being frozen in powder
+	// snow.
+	FreezeDamageSource struct{}
 )
 
 func (FallDamageSource) ReducedByArmour() bool     { return false }
@@ -80,6 +84,9 @@ func (ProjectileDamageSource) AffectedByEnchantment(e item.EnchantmentType) bool
 	_, prot := e.(enchantment.ProjectileProtection)
 	return prot
 }
+func (FreezeDamageSource) ReducedByResistance() bool    { return true }
+func (FreezeDamageSource) ReducedByArmour() bool        { return false }
+func (FreezeDamageSource) Fire() bool                   { return false }
 func (ExplosionDamageSource) ReducedByResistance() bool { return true }
 func (ExplosionDamageSource) ReducedByArmour() bool     { return true }
 func (ExplosionDamageSource) Fire() bool                { return false }
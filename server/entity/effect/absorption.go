@@ -3,6 +3,7 @@ package effect
 import (
 	"github.com/df-mc/dragonfly/server/world"
 	"image/color"
+	"math"
 )
 
 // Absorption is a lasting effect that increases the health of an entity over the maximum. Once this extra
@@ -15,8 +16,11 @@ type Absorption struct {
 func (Absorption) Start(e world.Entity, lvl int) {
 	if i, ok := e.(interface {
 		SetAbsorption(health float64)
+		Absorption() float64
 	}); ok {
-		i.SetAbsorption(4 * float64(lvl))
+		// Absorption never lowers the extra health that an entity already has, for example when eating a golden
+		// apple after an enchanted golden apple.
+		i.SetAbsorption(math.Max(i.Absorption(), 4*float64(lvl)))
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
 )
 
 // NewEnderPearl creates an EnderPearl entity. EnderPearl is a smooth, greenish-
@@ -24,6 +25,13 @@ var enderPearlConf = ProjectileBehaviourConfig{
 	Hit:      teleport,
 }
 
+// EndermiteSpawner is called with a 5% chance when an ender pearl teleports
+// its owner, to spawn an endermite at the position the pearl landed. Because
+// endermites are not implemented by default, no endermite is spawned if
+// EndermiteSpawner is nil. EndermiteSpawner should be set before any worlds
+// are loaded.
+var EndermiteSpawner func(pos mgl64.Vec3) world.Entity
+
 // teleporter represents a living entity that can teleport.
 type teleporter interface {
 	// Teleport teleports the entity to the position given.
@@ -31,12 +39,28 @@ type teleporter interface {
 	Living
 }
 
-// teleport teleports the owner of an Ent to a trace.Result's position.
+// teleport teleports the owner of an Ent to a trace.Result's position. The
+// owner takes 5 fall damage on arrival, unless the teleport was cancelled.
 func teleport(e *Ent, target trace.Result) {
-	if user, ok := e.Behaviour().(*ProjectileBehaviour).Owner().(teleporter); ok {
-		e.World().PlaySound(user.Position(), sound.Teleport{})
-		user.Teleport(target.Position())
-		user.Hurt(5, FallDamageSource{})
+	user, ok := e.Behaviour().(*ProjectileBehaviour).Owner().(teleporter)
+	if !ok || user.Dead() {
+		return
+	}
+	w, pos := e.World(), target.Position()
+	if uw, _ := world.OfEntity(user); uw != w {
+		// Ender pearls do not teleport their owner across worlds.
+		return
+	}
+	from := user.Position()
+	if user.Teleport(pos); user.Position() != pos {
+		// The teleport was cancelled by a handler of the owner.
+		return
+	}
+	w.PlaySound(from, sound.Teleport{})
+	user.Hurt(5, FallDamageSource{})
+
+	if EndermiteSpawner != nil && rand.Float64() < 0.05 {
+		w.AddEntity(EndermiteSpawner(pos))
 	}
 }
 
@@ -39,7 +39,7 @@ type Ent struct {
 	vel mgl64.Vec3
 	rot cube.Rotation
 
-	name string
+	name, scoreTag string
 
 	fireDuration time.Duration
 	age          time.Duration
@@ -158,6 +158,26 @@ func (e *Ent) SetNameTag(s string) {
 	}
 }
 
+// ScoreTag returns the score tag of the entity, which is displayed under its
+// name tag. An empty string is returned if no score tag was set.
+func (e *Ent) ScoreTag() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.scoreTag
+}
+
+// SetScoreTag changes the score tag of an entity. The score tag is removed if
+// an empty string is passed.
+func (e *Ent) SetScoreTag(s string) {
+	e.mu.Lock()
+	e.scoreTag = s
+	e.mu.Unlock()
+
+	for _, v := range e.World().Viewers(e.Position()) {
+		v.ViewEntityState(e)
+	}
+}
+
 // Tick ticks Ent, progressing its lifetime and closing the entity if it is
 // in the void.
 func (e *Ent) Tick(w *world.World, current int64) {
@@ -0,0 +1,368 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// NewHorse creates a wild horse with a random variant, speed, jump strength
+// and health at the position passed.
+func NewHorse(pos mgl64.Vec3) *Mob {
+	return HorseBehaviourConfig{Variant: rand.Int31n(7), MarkVariant: rand.Int31n(5)}.New(pos)
+}
+
+// HorseBehaviourConfig holds optional parameters for a HorseBehaviour.
+type HorseBehaviourConfig struct {
+	// Variant is the colour of the horse, from 0 to 6.
+	Variant int32
+	// MarkVariant is the markings of the horse, from 0 to 4.
+	MarkVariant int32
+	// MaxHealth is the maximum health of the horse. If 0, a random maximum
+	// health between 15 and 30 is chosen.
+	MaxHealth float64
+	// Speed is the movement speed of the horse in blocks per tick. If 0, a
+	// random speed between 0.1125 and 0.3375 is chosen.
+	Speed float64
+	// JumpStrength is the vertical velocity of the horse when jumping with
+	// full strength. If 0, a random jump strength between 0.4 and 1 is
+	// chosen.
+	JumpStrength float64
+	// Owner is the UUID of the owner of the horse. If not uuid.Nil, the horse
+	// is created tamed.
+	Owner uuid.UUID
+	// Saddled specifies if the horse is created with a saddle.
+	Saddled bool
+}
+
+// New creates a horse at the position passed using the parameters in conf.
+func (conf HorseBehaviourConfig) New(pos mgl64.Vec3) *Mob {
+	if conf.MaxHealth <= 0 {
+		conf.MaxHealth = 15 + float64(rand.Intn(8)+rand.Intn(9))
+	}
+	if conf.Speed <= 0 {
+		conf.Speed = (0.45 + rand.Float64()*0.3 + rand.Float64()*0.3 + rand.Float64()*0.3) * 0.25
+	}
+	if conf.JumpStrength <= 0 {
+		conf.JumpStrength = 0.4 + rand.Float64()*0.2 + rand.Float64()*0.2 + rand.Float64()*0.2
+	}
+	b := &HorseBehaviour{conf: conf, owner: conf.Owner}
+	m := MobConfig{Behaviour: b, MaxHealth: conf.MaxHealth, Speed: conf.Speed}.New(HorseType{}, pos)
+	b.inv = inventory.NewHorse(func(slot int, before, after item.Stack) {
+		m.updateState()
+	})
+	if conf.Saddled {
+		_ = b.inv.SetItem(inventory.HorseSaddleSlot, item.NewStack(item.Saddle{}, 1))
+	}
+	return m
+}
+
+// HorseBehaviour implements the behaviour of horses. Horses wander around
+// until a player mounts them. Untamed horses eventually either accept their
+// rider and become tamed, or throw their rider off. Tamed horses with a
+// saddle are controlled by their rider.
+type HorseBehaviour struct {
+	conf   HorseBehaviourConfig
+	wander Wander
+	inv    *inventory.Inventory
+
+	mu     sync.Mutex
+	owner  uuid.UUID
+	temper int
+	taming int
+}
+
+// Variant returns the colour of the horse.
+func (h *HorseBehaviour) Variant() int32 {
+	return h.conf.Variant
+}
+
+// MarkVariant returns the markings of the horse.
+func (h *HorseBehaviour) MarkVariant() int32 {
+	return h.conf.MarkVariant
+}
+
+// JumpStrength returns the vertical velocity of the horse when jumping with
+// full strength.
+func (h *HorseBehaviour) JumpStrength() float64 {
+	return h.conf.JumpStrength
+}
+
+// Inventory returns the inventory of the horse, which holds its saddle and
+// armour.
+func (h *HorseBehaviour) Inventory() *inventory.Inventory {
+	return h.inv
+}
+
+// Saddled checks if the horse is wearing a saddle.
+func (h *HorseBehaviour) Saddled() bool {
+	it, _ := h.inv.Item(inventory.HorseSaddleSlot)
+	return !it.Empty()
+}
+
+// DefencePoints returns the defence points of the armour worn by the horse.
+func (h *HorseBehaviour) DefencePoints() float64 {
+	it, _ := h.inv.Item(inventory.HorseArmourSlot)
+	if a, ok := it.Item().(item.HorseArmour); ok {
+		return a.DefencePoints()
+	}
+	return 0
+}
+
+// Tamed checks if the horse is tamed.
+func (h *HorseBehaviour) Tamed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.owner != uuid.Nil
+}
+
+// OwnerUUID returns the UUID of the owner of the horse, or uuid.Nil if the
+// horse is not tamed.
+func (h *HorseBehaviour) OwnerUUID() uuid.UUID {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.owner
+}
+
+// Tame tames the horse for the owner passed, which must have a UUID, such as a
+// player.
+func (h *HorseBehaviour) Tame(m *Mob, owner world.Entity) {
+	u, ok := owner.(interface{ UUID() uuid.UUID })
+	if !ok {
+		return
+	}
+	h.mu.Lock()
+	h.owner, h.temper = u.UUID(), 100
+	h.mu.Unlock()
+
+	for _, v := range m.World().Viewers(m.Position()) {
+		v.ViewEntityAction(m, LoveAction{})
+	}
+	m.updateState()
+}
+
+// CanMount allows a Rider to mount the horse.
+func (h *HorseBehaviour) CanMount(*Mob, Rider) bool {
+	return true
+}
+
+// SeatOffset returns the offset of the seat of a rider on the horse.
+func (h *HorseBehaviour) SeatOffset() mgl64.Vec3 {
+	return mgl64.Vec3{0, 2.3, -0.2}
+}
+
+// Jump makes the horse jump if it is on the ground and controlled by its
+// rider. The height of the jump depends on the strength passed and the
+// JumpStrength of the horse.
+func (h *HorseBehaviour) Jump(m *Mob, strength float64) {
+	if !h.controlled(m) || !m.OnGround() {
+		return
+	}
+	strength = math.Max(0, math.Min(strength, 1))
+	vel := m.Velocity()
+	vel[1] = h.conf.JumpStrength * strength
+	m.SetVelocity(vel)
+}
+
+// controlled checks if the horse is currently controlled by a rider.
+func (h *HorseBehaviour) controlled(m *Mob) bool {
+	_, ok := m.Rider()
+	return ok && h.Tamed() && h.Saddled()
+}
+
+// Tick moves the horse according to the input of its rider if it is
+// controlled. An untamed horse that is ridden tries to throw off its rider
+// until it is tamed. Horses without a rider wander around.
+func (h *HorseBehaviour) Tick(m *Mob) {
+	r, ridden := m.Rider()
+	if !ridden {
+		h.wander.Tick(m)
+		return
+	}
+	if !h.Tamed() {
+		m.Stop()
+		h.tick(m, r)
+		return
+	}
+	if !h.Saddled() {
+		m.Stop()
+		return
+	}
+	input := r.MovementInput()
+	yaw := r.Rotation().Yaw()
+	m.SetRotation(cube.Rotation{yaw, 0})
+	if input.Len() == 0 {
+		m.Stop()
+		return
+	}
+	if input[1] < 0 {
+		// Horses walk backwards a lot slower than forwards.
+		input[1] *= 0.25
+	}
+	rad := mgl64.DegToRad(yaw)
+	sin, cos := math.Sin(rad), math.Cos(rad)
+	speed := m.Speed()
+	vel := m.Velocity()
+	vel[0] = (-sin*input[1] + cos*input[0]) * speed
+	vel[2] = (cos*input[1] + sin*input[0]) * speed
+	m.SetVelocity(vel)
+}
+
+// tick ticks the taming of an untamed horse ridden by the Rider passed. Every
+// few seconds, the horse either accepts the rider, depending on its temper, or
+// throws it off, increasing its temper.
+func (h *HorseBehaviour) tick(m *Mob, r Rider) {
+	h.mu.Lock()
+	if h.taming++; h.taming < 40+rand.Intn(60) {
+		h.mu.Unlock()
+		return
+	}
+	h.taming = 0
+	accept := rand.Intn(100) < h.temper
+	if !accept {
+		h.temper = int(math.Min(float64(h.temper+5), 100))
+	}
+	h.mu.Unlock()
+
+	if accept {
+		h.Tame(m, r)
+		return
+	}
+	m.Dismount()
+	m.SetVelocity(mgl64.Vec3{0, 0.3, 0})
+}
+
+// Interact handles a user interacting with the horse. Feeding the horse heals
+// it and makes it easier to tame. A tamed horse is saddled when interacted
+// with using a saddle, and its owner may open its inventory by sneaking. In
+// other cases, the user mounts the horse if it is a Rider.
+func (h *HorseBehaviour) Interact(m *Mob, user item.User, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	if heal, temper, ok := horseFood(held.Item()); ok && !held.Empty() {
+		if m.Health() >= m.MaxHealth() && h.Tamed() {
+			return false
+		}
+		m.Heal(heal, nil)
+		h.mu.Lock()
+		h.temper = int(math.Min(float64(h.temper+temper), 100))
+		h.mu.Unlock()
+		ctx.SubtractFromCount(1)
+		for _, v := range m.World().Viewers(m.Position()) {
+			v.ViewEntityAction(m, EatAction{})
+		}
+		return true
+	}
+	if u, ok := user.(interface{ UUID() uuid.UUID }); ok && h.Tamed() && u.UUID() == h.OwnerUUID() {
+		if _, ok := held.Item().(item.Saddle); ok && !h.Saddled() {
+			_ = h.inv.SetItem(inventory.HorseSaddleSlot, held.Grow(-held.Count()+1))
+			ctx.SubtractFromCount(1)
+			return true
+		}
+		if s, ok := user.(interface{ Sneaking() bool }); ok && s.Sneaking() {
+			if o, ok := user.(horseInventoryOpener); ok {
+				o.OpenHorseInventory(m, h.inv)
+				return true
+			}
+		}
+	}
+	if r, ok := user.(Rider); ok {
+		return r.Ride(m)
+	}
+	return false
+}
+
+// horseInventoryOpener represents an entity that is able to open the
+// inventory of a horse, such as a player.
+type horseInventoryOpener interface {
+	OpenHorseInventory(e world.Entity, inv *inventory.Inventory)
+}
+
+// horseFood returns the health restored and the temper added by feeding the
+// item passed to a horse. False is returned if horses do not eat the item.
+func horseFood(it world.Item) (heal float64, temper int, ok bool) {
+	switch it.(type) {
+	case item.Sugar:
+		return 1, 3, true
+	case item.Wheat:
+		return 2, 3, true
+	case item.Apple:
+		return 3, 3, true
+	case item.GoldenCarrot:
+		return 4, 5, true
+	case item.GoldenApple:
+		return 10, 10, true
+	case block.HayBale:
+		return 20, 0, true
+	}
+	return 0, 0, false
+}
+
+// HorseType is a world.EntityType implementation for horses.
+type HorseType struct{}
+
+func (HorseType) EncodeEntity() string { return "minecraft:horse" }
+func (HorseType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.7, 0, -0.7, 0.7, 1.6, 0.7)
+}
+
+func (HorseType) DecodeNBT(m map[string]any) world.Entity {
+	conf := HorseBehaviourConfig{
+		Variant:      nbtconv.Int32(m, "Variant"),
+		MarkVariant:  nbtconv.Int32(m, "MarkVariant"),
+		MaxHealth:    float64(nbtconv.Float32(m, "MaxHealth")),
+		Speed:        float64(nbtconv.Float32(m, "Speed")),
+		JumpStrength: float64(nbtconv.Float32(m, "JumpStrength")),
+	}
+	conf.Owner, _ = uuid.Parse(nbtconv.String(m, "Owner"))
+	h := conf.New(nbtconv.Vec3(m, "Pos"))
+	h.vel, h.rot = nbtconv.Vec3(m, "Motion"), nbtconv.Rotation(m)
+	if health := float64(nbtconv.Float32(m, "Health")); health > 0 {
+		h.health.AddHealth(health - h.MaxHealth())
+	}
+	b := h.Behaviour().(*HorseBehaviour)
+	b.temper = int(nbtconv.Int32(m, "Temper"))
+	_ = b.inv.SetItem(inventory.HorseSaddleSlot, nbtconv.MapItem(m, "SaddleItem"))
+	_ = b.inv.SetItem(inventory.HorseArmourSlot, nbtconv.MapItem(m, "ArmorItem"))
+	return h
+}
+
+func (HorseType) EncodeNBT(e world.Entity) map[string]any {
+	h := e.(*Mob)
+	b := h.Behaviour().(*HorseBehaviour)
+	b.mu.Lock()
+	owner, temper := b.owner, b.temper
+	b.mu.Unlock()
+
+	yaw, pitch := h.Rotation().Elem()
+	data := map[string]any{
+		"Pos":          nbtconv.Vec3ToFloat32Slice(h.Position()),
+		"Motion":       nbtconv.Vec3ToFloat32Slice(h.Velocity()),
+		"Yaw":          float32(yaw),
+		"Pitch":        float32(pitch),
+		"Health":       float32(h.Health()),
+		"MaxHealth":    float32(h.MaxHealth()),
+		"Speed":        float32(h.Speed()),
+		"JumpStrength": float32(b.conf.JumpStrength),
+		"Variant":      b.Variant(),
+		"MarkVariant":  b.MarkVariant(),
+		"Temper":       int32(temper),
+	}
+	if owner != uuid.Nil {
+		data["Owner"] = owner.String()
+	}
+	if saddle, _ := b.inv.Item(inventory.HorseSaddleSlot); !saddle.Empty() {
+		data["SaddleItem"] = nbtconv.WriteItem(saddle, true)
+	}
+	if armour, _ := b.inv.Item(inventory.HorseArmourSlot); !armour.Empty() {
+		data["ArmorItem"] = nbtconv.WriteItem(armour, true)
+	}
+	return data
+}
@@ -29,7 +29,7 @@ func (conf ItemBehaviourConfig) New(i item.Stack) *ItemBehaviour {
 	if i.Count() > i.MaxCount() {
 		i = i.Grow(i.MaxCount() - i.Count())
 	}
-	i = nbtconv.Item(nbtconv.WriteItem(i, true), nil)
+	i = copyStack(i)
 
 	if conf.PickupDelay == 0 {
 		conf.PickupDelay = time.Second / 2
@@ -48,6 +48,17 @@ func (conf ItemBehaviourConfig) New(i item.Stack) *ItemBehaviour {
 	return b
 }
 
+// copyStack returns a copy of the item.Stack passed that shares no data with the original. Stacks that hold
+// no values and whose item has no NBT are only given a new ID, as encoding them to NBT and decoding them again
+// would result in an equal stack while allocating a lot of memory, which adds up when many items are dropped
+// at once. Other stacks are copied using nbtconv.CopyItem, which pools the memory used for the copy.
+func copyStack(i item.Stack) item.Stack {
+	if _, ok := i.Item().(world.NBTer); !ok && !i.HasValues() {
+		return i.Grow(0)
+	}
+	return nbtconv.CopyItem(i)
+}
+
 // ItemBehaviour implements the behaviour of item entities.
 type ItemBehaviour struct {
 	conf    ItemBehaviourConfig
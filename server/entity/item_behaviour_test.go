@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"testing"
+)
+
+// BenchmarkCopyStack benchmarks copying an item stack without values, which is the most common case when
+// items are dropped.
+func BenchmarkCopyStack(b *testing.B) {
+	s := item.NewStack(item.Stick{}, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = copyStack(s)
+	}
+}
+
+// BenchmarkCopyStackValues benchmarks copying an item stack with values, which requires an NBT round trip.
+func BenchmarkCopyStackValues(b *testing.B) {
+	s := item.NewStack(item.Stick{}, 64).WithValue("owner", "Steve").WithCustomName("Stick")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = copyStack(s)
+	}
+}
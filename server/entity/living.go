@@ -36,6 +36,9 @@ type Living interface {
 	// source of the velocity, typically the position of an attacking entity. The source is used to calculate
 	// the direction which the entity should be knocked back in.
 	KnockBack(src mgl64.Vec3, force, height float64)
+	// KnockBackDirection knocks the entity back in the direction passed with a given force and height. Only
+	// the horizontal components of the direction are used. Unlike KnockBack, no source position is required.
+	KnockBackDirection(dir mgl64.Vec3, force, height float64)
 	// Velocity returns the players current velocity.
 	Velocity() mgl64.Vec3
 	// SetVelocity updates the entity's velocity.
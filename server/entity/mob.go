@@ -0,0 +1,536 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// MobBehaviour implements the behaviour of a Mob.
+type MobBehaviour interface {
+	// Tick ticks the Mob using the MobBehaviour. The MobBehaviour may change
+	// the velocity and rotation of the Mob, after which the Mob moves
+	// according to its velocity.
+	Tick(m *Mob)
+}
+
+// Interactable represents an entity that may be interacted with by a user,
+// for example a villager that opens its trading window when a player
+// interacts with it.
+type Interactable interface {
+	// Interact is called when the user passed interacts with the entity.
+	// True is returned if the interaction was handled, in which case the
+	// item held by the user is not used on the entity.
+	Interact(user item.User, ctx *item.UseContext) bool
+}
+
+// MobConfig allows specifying options that influence the way a Mob behaves.
+type MobConfig struct {
+	// Behaviour is the MobBehaviour of the Mob. If nil, the Mob does not do
+	// anything on its own.
+	Behaviour MobBehaviour
+	// MaxHealth is the maximum health of the Mob. The Mob spawns with this
+	// health. If 0, a maximum health of 20 is used.
+	MaxHealth float64
+	// Speed is the movement speed of the Mob in blocks per tick. If 0, a
+	// speed of 0.1 is used.
+	Speed float64
+}
+
+// New creates a new Mob using conf. The Mob has a type and a position.
+func (conf MobConfig) New(t world.EntityType, pos mgl64.Vec3) *Mob {
+	if conf.MaxHealth <= 0 {
+		conf.MaxHealth = 20
+	}
+	if conf.Speed <= 0 {
+		conf.Speed = 0.1
+	}
+	return &Mob{
+		conf:    conf,
+		t:       t,
+		pos:     pos,
+		speed:   conf.Speed,
+		health:  NewHealthManager(conf.MaxHealth, conf.MaxHealth),
+		effects: NewEffectManager(),
+		mc:      &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+	}
+}
+
+// Mob is a world.Entity implementation for living entities other than
+// players, such as animals and villagers. It implements Living and shares the
+// code for health, effects and movement between mobs. The behaviour specific
+// to a mob is implemented by its MobBehaviour.
+type Mob struct {
+	conf MobConfig
+	t    world.EntityType
+
+	mu  sync.Mutex
+	pos mgl64.Vec3
+	vel mgl64.Vec3
+	rot cube.Rotation
+
+	name, scoreTag string
+	rider          Rider
+
+	fireDuration time.Duration
+	age          time.Duration
+	speed        float64
+
+	health        *HealthManager
+	effects       *EffectManager
+	immunityTicks int
+	deathTicks    int
+	fallDistance  float64
+
+	mc *MovementComputer
+}
+
+// Type returns the world.EntityType passed to MobConfig.New.
+func (m *Mob) Type() world.EntityType {
+	return m.t
+}
+
+// Behaviour returns the MobBehaviour of the Mob.
+func (m *Mob) Behaviour() MobBehaviour {
+	return m.conf.Behaviour
+}
+
+// Position returns the current position of the Mob.
+func (m *Mob) Position() mgl64.Vec3 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pos
+}
+
+// Velocity returns the current velocity of the Mob. The values in the Vec3
+// returned represent the speed on that axis in blocks/tick.
+func (m *Mob) Velocity() mgl64.Vec3 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.vel
+}
+
+// SetVelocity sets the velocity of the Mob. The values in the Vec3 passed
+// represent the speed on that axis in blocks/tick.
+func (m *Mob) SetVelocity(v mgl64.Vec3) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.vel = v
+}
+
+// Teleport teleports the Mob to a position in its world.
+func (m *Mob) Teleport(pos mgl64.Vec3) {
+	m.mu.Lock()
+	m.pos, m.vel = pos, mgl64.Vec3{}
+	m.fallDistance = 0
+	m.mu.Unlock()
+	for _, v := range m.World().Viewers(pos) {
+		v.ViewEntityTeleport(m, pos)
+	}
+}
+
+// Rotation returns the rotation of the Mob.
+func (m *Mob) Rotation() cube.Rotation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rot
+}
+
+// SetRotation changes the rotation of the Mob. The new rotation is sent to
+// viewers when the Mob is next ticked.
+func (m *Mob) SetRotation(rot cube.Rotation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rot = rot
+}
+
+// OnGround checks if the Mob is currently on the ground.
+func (m *Mob) OnGround() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.mc.OnGround()
+}
+
+// World returns the world of the Mob.
+func (m *Mob) World() *world.World {
+	w, _ := world.OfEntity(m)
+	return w
+}
+
+// Age returns the total time lived of the Mob. It increases by time.Second/20
+// for every time Tick is called.
+func (m *Mob) Age() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.age
+}
+
+// OnFireDuration ...
+func (m *Mob) OnFireDuration() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.fireDuration
+}
+
+// SetOnFire ...
+func (m *Mob) SetOnFire(duration time.Duration) {
+	if duration < 0 {
+		duration = 0
+	}
+	m.mu.Lock()
+	before, after := m.fireDuration > 0, duration > 0
+	m.fireDuration = duration
+	m.mu.Unlock()
+
+	if before != after {
+		m.updateState()
+	}
+}
+
+// Extinguish ...
+func (m *Mob) Extinguish() {
+	m.SetOnFire(0)
+}
+
+// NameTag returns the name tag of the Mob. An empty string is returned if no
+// name tag was set.
+func (m *Mob) NameTag() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.name
+}
+
+// SetNameTag changes the name tag of the Mob. The name tag is removed if an
+// empty string is passed.
+func (m *Mob) SetNameTag(s string) {
+	m.mu.Lock()
+	m.name = s
+	m.mu.Unlock()
+	m.updateState()
+}
+
+// ScoreTag returns the score tag of the Mob, which is displayed under its name
+// tag. An empty string is returned if no score tag was set.
+func (m *Mob) ScoreTag() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.scoreTag
+}
+
+// SetScoreTag changes the score tag of the Mob. The score tag is removed if an
+// empty string is passed.
+func (m *Mob) SetScoreTag(s string) {
+	m.mu.Lock()
+	m.scoreTag = s
+	m.mu.Unlock()
+	m.updateState()
+}
+
+// Health returns the current health of the Mob.
+func (m *Mob) Health() float64 {
+	return m.health.Health()
+}
+
+// MaxHealth returns the maximum health of the Mob.
+func (m *Mob) MaxHealth() float64 {
+	return m.health.MaxHealth()
+}
+
+// SetMaxHealth changes the maximum health of the Mob.
+func (m *Mob) SetMaxHealth(v float64) {
+	m.health.SetMaxHealth(v)
+}
+
+// Dead checks if the Mob is dead, which is the case if its health is 0.
+func (m *Mob) Dead() bool {
+	return m.Health() <= mgl64.Epsilon
+}
+
+// AttackImmune checks if the Mob is currently immune to entity attacks,
+// meaning it was recently attacked.
+func (m *Mob) AttackImmune() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.immunityTicks > 0
+}
+
+// Hurt hurts the Mob for a given amount of damage. Damage from attacks is
+// ignored while the Mob is AttackImmune. If the damage is fatal, the Mob dies
+// and is removed from the world shortly after.
+func (m *Mob) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
+	if m.Dead() || dmg < 0 {
+		return 0, false
+	}
+	if _, ok := src.(AttackDamageSource); ok && m.AttackImmune() {
+		return 0, false
+	}
+	if _, ok := m.effects.Effect(effect.FireResistance{}); ok && src.Fire() {
+		return 0, false
+	}
+	if res, ok := m.effects.Effect(effect.Resistance{}); ok {
+		dmg *= effect.Resistance{}.Multiplier(src, res.Level())
+	}
+	if a, ok := m.conf.Behaviour.(interface{ DefencePoints() float64 }); ok && src.ReducedByArmour() {
+		dmg *= 1 - math.Min(a.DefencePoints(), 20)/25
+	}
+	m.health.AddHealth(-dmg)
+
+	m.mu.Lock()
+	m.immunityTicks = 10
+	m.mu.Unlock()
+
+	for _, v := range m.World().Viewers(m.Position()) {
+		v.ViewEntityAction(m, HurtAction{})
+	}
+	if h, ok := m.conf.Behaviour.(interface {
+		Hurt(m *Mob, dmg float64, src world.DamageSource)
+	}); ok {
+		h.Hurt(m, dmg, src)
+	}
+	if m.Dead() {
+		m.kill(src)
+	}
+	return dmg, true
+}
+
+// kill makes the Mob die, dismounting its rider and calling the Death method
+// of its MobBehaviour if it implements one.
+func (m *Mob) kill(src world.DamageSource) {
+	m.Dismount()
+	for _, v := range m.World().Viewers(m.Position()) {
+		v.ViewEntityAction(m, DeathAction{})
+	}
+	if d, ok := m.conf.Behaviour.(interface {
+		Death(m *Mob, src world.DamageSource)
+	}); ok {
+		d.Death(m, src)
+	}
+}
+
+// Heal heals the Mob for a given amount of health.
+func (m *Mob) Heal(health float64, _ world.HealingSource) {
+	if m.Dead() || health < 0 {
+		return
+	}
+	m.health.AddHealth(health)
+}
+
+// KnockBack knocks the Mob back with a given force and height, away from the
+// source passed.
+func (m *Mob) KnockBack(src mgl64.Vec3, force, height float64) {
+	m.KnockBackDirection(m.Position().Sub(src), force, height)
+}
+
+// KnockBackDirection knocks the Mob back in the direction passed with a given
+// force and height. Only the horizontal components of the direction are used.
+func (m *Mob) KnockBackDirection(dir mgl64.Vec3, force, height float64) {
+	if m.Dead() {
+		return
+	}
+	velocity := dir
+	velocity[1] = 0
+	if velocity.Len() != 0 {
+		velocity = velocity.Normalize().Mul(force)
+	}
+	velocity[1] = height
+	m.SetVelocity(velocity)
+}
+
+// AddEffect adds an effect.Effect to the Mob.
+func (m *Mob) AddEffect(e effect.Effect) {
+	m.effects.Add(e, m)
+	m.updateState()
+}
+
+// RemoveEffect removes any effect of the type passed from the Mob.
+func (m *Mob) RemoveEffect(e effect.Type) {
+	m.effects.Remove(e, m)
+	m.updateState()
+}
+
+// Effects returns the effects currently active on the Mob.
+func (m *Mob) Effects() []effect.Effect {
+	return m.effects.Effects()
+}
+
+// Speed returns the movement speed of the Mob in blocks per tick.
+func (m *Mob) Speed() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.speed
+}
+
+// SetSpeed changes the movement speed of the Mob.
+func (m *Mob) SetSpeed(speed float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.speed = speed
+}
+
+// Interact calls the Interact method of the MobBehaviour of the Mob if it
+// implements Interactable.
+func (m *Mob) Interact(user item.User, ctx *item.UseContext) bool {
+	if m.Dead() {
+		return false
+	}
+	if i, ok := m.conf.Behaviour.(interface {
+		Interact(m *Mob, user item.User, ctx *item.UseContext) bool
+	}); ok {
+		return i.Interact(m, user, ctx)
+	}
+	return false
+}
+
+// Explode adds velocity to the Mob to blast it away from the explosion's
+// source and hurts it.
+func (m *Mob) Explode(src mgl64.Vec3, impact float64, conf block.ExplosionConfig) {
+	m.SetVelocity(m.Velocity().Add(m.Position().Sub(src).Normalize().Mul(impact)))
+	m.Hurt(math.Floor((impact*impact+impact)*3.5*conf.Size+1), ExplosionDamageSource{})
+}
+
+// Walk makes the Mob walk in the direction of the yaw passed at its speed,
+// rotating it to face that direction. If the Mob walks into a block while on
+// the ground, it jumps.
+func (m *Mob) Walk(yaw float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rad := mgl64.DegToRad(yaw)
+	m.vel[0], m.vel[2] = -math.Sin(rad)*m.speed, math.Cos(rad)*m.speed
+	m.rot = cube.Rotation{yaw, 0}
+}
+
+// WalkTowards makes the Mob walk in the direction of the position passed.
+func (m *Mob) WalkTowards(pos mgl64.Vec3) {
+	d := pos.Sub(m.Position())
+	m.Walk(mgl64.RadToDeg(math.Atan2(-d[0], d[2])))
+}
+
+// Stop stops the Mob from walking.
+func (m *Mob) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.vel[0], m.vel[2] = 0, 0
+}
+
+// LookAt rotates the Mob so that it faces the position passed.
+func (m *Mob) LookAt(pos mgl64.Vec3) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := pos.Sub(m.pos)
+	yaw := mgl64.RadToDeg(math.Atan2(-d[0], d[2]))
+	pitch := -mgl64.RadToDeg(math.Atan2(d[1], math.Hypot(d[0], d[2])))
+	m.rot = cube.Rotation{yaw, pitch}
+}
+
+// Tick ticks the Mob, moving it, applying its effects and ticking its
+// MobBehaviour.
+func (m *Mob) Tick(w *world.World, current int64) {
+	m.mu.Lock()
+	y := m.pos[1]
+	m.mu.Unlock()
+	if y < float64(w.Range()[0]) && current%10 == 0 {
+		_ = m.Close()
+		return
+	}
+	if m.Dead() {
+		m.mu.Lock()
+		m.deathTicks++
+		done := m.deathTicks >= 20
+		m.mu.Unlock()
+		if done {
+			_ = m.Close()
+		}
+		return
+	}
+	m.effects.Tick(m)
+	if m.OnFireDuration() > 0 && current%20 == 0 {
+		m.Hurt(1, block.FireDamageSource{})
+	}
+	m.SetOnFire(m.OnFireDuration() - time.Second/20)
+
+	if m.conf.Behaviour != nil {
+		m.conf.Behaviour.Tick(m)
+	}
+
+	m.mu.Lock()
+	if m.immunityTicks > 0 {
+		m.immunityTicks--
+	}
+	horizontal := mgl64.Vec2{m.vel[0], m.vel[2]}
+	mv := m.mc.TickMovement(m, m.pos, m.vel, m.rot)
+	blocked := mv.dpos[0] == 0 && horizontal[0] != 0 || mv.dpos[2] == 0 && horizontal[1] != 0
+	m.pos, m.vel = mv.pos, mv.vel
+	if m.mc.OnGround() {
+		// Keep walking at the same speed: The horizontal velocity is only reduced by the behaviour of the mob.
+		m.vel[0], m.vel[2] = horizontal[0], horizontal[1]
+		if blocked {
+			m.vel[1] = 0.42
+		}
+	}
+	fall := m.fallDistance
+	m.fallDistance = math.Max(m.fallDistance-mv.dvel[1], 0)
+	if m.mc.OnGround() {
+		m.fallDistance = 0
+	}
+	m.age += time.Second / 20
+	m.mu.Unlock()
+
+	mv.Send()
+	if m.mc.OnGround() && fall > 3 {
+		m.Hurt(math.Ceil(fall-3), FallDamageSource{})
+	}
+}
+
+// Close closes the Mob and removes it from the world.
+func (m *Mob) Close() error {
+	m.Dismount()
+	m.World().RemoveEntity(m)
+	return nil
+}
+
+// updateState sends the state of the Mob to all of its viewers.
+func (m *Mob) updateState() {
+	w := m.World()
+	if w == nil {
+		return
+	}
+	for _, v := range w.Viewers(m.Position()) {
+		v.ViewEntityState(m)
+	}
+}
+
+// Wander is a MobBehaviour that makes a Mob walk around randomly. Other
+// behaviours may embed it to share its wandering.
+type Wander struct {
+	ticks int
+	yaw   float64
+}
+
+// Tick makes the Mob passed walk in a random direction for a few seconds
+// before standing still for a while and choosing a new direction.
+func (w *Wander) Tick(m *Mob) {
+	if w.ticks--; w.ticks > 0 {
+		if w.walking() {
+			m.Walk(w.yaw)
+		}
+		return
+	}
+	if rand.Intn(3) == 0 {
+		w.yaw, w.ticks = rand.Float64()*360-180, 40+rand.Intn(60)
+		return
+	}
+	m.Stop()
+	w.yaw, w.ticks = math.NaN(), 60+rand.Intn(120)
+}
+
+// walking checks if the Wander is currently walking in a direction.
+func (w *Wander) walking() bool {
+	return !math.IsNaN(w.yaw)
+}
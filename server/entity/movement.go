@@ -177,6 +177,9 @@ func blockBBoxsAround(e world.Entity, box cube.BBox) []cube.BBox {
 	min, max := grown.Min(), grown.Max()
 	minX, minY, minZ := int(math.Floor(min[0])), int(math.Floor(min[1])), int(math.Floor(min[2]))
 	maxX, maxY, maxZ := int(math.Ceil(max[0])), int(math.Ceil(max[1])), int(math.Ceil(max[2]))
+	// Blocks such as fences and walls have a collision box that extends into the block above them, so the
+	// blocks below the BBox are included too.
+	minY--
 
 	// A prediction of one BBox per block, plus an additional 2, in case
 	blockBBoxs := make([]cube.BBox, 0, (maxX-minX)*(maxY-minY)*(maxZ-minZ)+2)
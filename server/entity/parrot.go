@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// NewParrot creates a wild parrot with a random colour at the position passed.
+// Parrots are tamed using seeds.
+func NewParrot(pos mgl64.Vec3) *Mob {
+	conf := ParrotType{}.config()
+	conf.Variant = rand.Int31n(5)
+	return conf.New(ParrotType{}, pos)
+}
+
+// ParrotType is a world.EntityType implementation for parrots.
+type ParrotType struct{}
+
+func (ParrotType) EncodeEntity() string { return "minecraft:parrot" }
+func (ParrotType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.25, 0, -0.25, 0.25, 0.9, 0.25)
+}
+
+func (t ParrotType) DecodeNBT(m map[string]any) world.Entity { return decodePet(t, m) }
+func (ParrotType) EncodeNBT(e world.Entity) map[string]any   { return encodePet(e) }
+
+// config returns the PetBehaviourConfig of parrots.
+func (ParrotType) config() PetBehaviourConfig {
+	return PetBehaviourConfig{
+		TamingItems: []world.Item{block.WheatSeeds{}, block.BeetrootSeeds{}, block.MelonSeeds{}, block.PumpkinSeeds{}},
+		MaxHealth:   6,
+	}
+}
@@ -0,0 +1,423 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math/rand"
+	"sync"
+)
+
+// PetBehaviourConfig holds optional parameters for a PetBehaviour.
+type PetBehaviourConfig struct {
+	// TamingItems are the items that may be fed to the pet to tame it.
+	TamingItems []world.Item
+	// TameChance is the chance, between 0 and 1, that feeding a taming item to
+	// the pet tames it. If 0, a chance of 1/3 is used.
+	TameChance float64
+	// MaxHealth is the maximum health of the pet. If 0, a maximum health of 8
+	// is used.
+	MaxHealth float64
+	// TamedMaxHealth is the maximum health of the pet once it is tamed. If 0,
+	// the MaxHealth of the pet is used.
+	TamedMaxHealth float64
+	// Speed is the movement speed of the pet in blocks per tick. If 0, a speed
+	// of 0.1 is used.
+	Speed float64
+	// Protective specifies if the pet attacks entities that attack it or, if
+	// tamed, entities that attack its owner or that are attacked by its owner.
+	Protective bool
+	// AttackDamage is the damage dealt by a Protective pet. If 0, an attack
+	// damage of 4 is used.
+	AttackDamage float64
+	// Collar specifies if the pet wears a collar when tamed. The colour of the
+	// collar may be changed by its owner using a dye.
+	Collar bool
+	// Variant is the variant of the pet, such as the colour of a parrot.
+	Variant int32
+	// Owner is the UUID of the owner of the pet. If not uuid.Nil, the pet is
+	// created tamed and follows the entity with this UUID once it is nearby.
+	Owner uuid.UUID
+}
+
+// New creates a pet with the world.EntityType passed at a position using the
+// parameters in conf.
+func (conf PetBehaviourConfig) New(t world.EntityType, pos mgl64.Vec3) *Mob {
+	if conf.TameChance <= 0 {
+		conf.TameChance = 1.0 / 3
+	}
+	if conf.MaxHealth <= 0 {
+		conf.MaxHealth = 8
+	}
+	if conf.TamedMaxHealth <= 0 {
+		conf.TamedMaxHealth = conf.MaxHealth
+	}
+	if conf.AttackDamage <= 0 {
+		conf.AttackDamage = 4
+	}
+	b := &PetBehaviour{conf: conf, owner: conf.Owner, collar: item.ColourRed()}
+	maxHealth := conf.MaxHealth
+	if b.owner != uuid.Nil {
+		maxHealth = conf.TamedMaxHealth
+	}
+	return MobConfig{Behaviour: b, MaxHealth: maxHealth, Speed: conf.Speed}.New(t, pos)
+}
+
+// PetBehaviour implements the behaviour of pets that may be tamed, such as
+// wolves, cats and parrots. Tamed pets follow their owner, teleporting to it
+// when they fall too far behind, and may be told to sit by their owner.
+// Protective pets defend themselves and their owner.
+type PetBehaviour struct {
+	conf   PetBehaviourConfig
+	wander Wander
+
+	mu             sync.Mutex
+	owner          uuid.UUID
+	ownerEntity    world.Entity
+	sitting        bool
+	collar         item.Colour
+	target         world.Entity
+	attackCooldown int
+	ticks          int
+}
+
+// Tamed checks if the pet is tamed.
+func (p *PetBehaviour) Tamed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.owner != uuid.Nil
+}
+
+// OwnerUUID returns the UUID of the owner of the pet. uuid.Nil is returned if
+// the pet is not tamed.
+func (p *PetBehaviour) OwnerUUID() uuid.UUID {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.owner
+}
+
+// Owner returns the owner of the pet if it is in the same world as the pet.
+// Owner returns nil if the pet is not tamed or if its owner could not be
+// found.
+func (p *PetBehaviour) Owner() world.Entity {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ownerEntity
+}
+
+// Tame tames the pet for the owner passed, which must have a UUID, such as a
+// player. The pet sits down after being tamed.
+func (p *PetBehaviour) Tame(m *Mob, owner world.Entity) {
+	u, ok := owner.(interface{ UUID() uuid.UUID })
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+	p.owner, p.ownerEntity, p.sitting, p.target = u.UUID(), owner, true, nil
+	p.mu.Unlock()
+
+	m.SetMaxHealth(p.conf.TamedMaxHealth)
+	m.Heal(p.conf.TamedMaxHealth, nil)
+	m.Stop()
+	m.updateState()
+}
+
+// Sitting checks if the pet is currently sitting.
+func (p *PetBehaviour) Sitting() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sitting
+}
+
+// SetSitting makes the pet sit down or stand up. Sitting pets do not move
+// and do not follow their owner.
+func (p *PetBehaviour) SetSitting(m *Mob, sitting bool) {
+	p.mu.Lock()
+	p.sitting = sitting
+	p.mu.Unlock()
+	m.Stop()
+	m.updateState()
+}
+
+// Collar returns the colour of the collar of the pet. False is returned if the
+// pet does not wear a collar.
+func (p *PetBehaviour) Collar() (item.Colour, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.collar, p.conf.Collar && p.owner != uuid.Nil
+}
+
+// SetCollar changes the colour of the collar of the pet.
+func (p *PetBehaviour) SetCollar(m *Mob, c item.Colour) {
+	p.mu.Lock()
+	p.collar = c
+	p.mu.Unlock()
+	m.updateState()
+}
+
+// Variant returns the variant of the pet.
+func (p *PetBehaviour) Variant() int32 {
+	return p.conf.Variant
+}
+
+// Target returns the entity that the pet is currently attacking, or nil if it
+// is not attacking any entity.
+func (p *PetBehaviour) Target() world.Entity {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.target
+}
+
+// SetTarget makes a Protective pet attack the entity passed, making it stand
+// up if it was sitting. Passing nil makes the pet stop attacking. The owner of
+// the pet is never attacked.
+func (p *PetBehaviour) SetTarget(m *Mob, e world.Entity) {
+	if !p.conf.Protective || e == world.Entity(m) {
+		return
+	}
+	p.mu.Lock()
+	if e != nil && e == p.ownerEntity {
+		p.mu.Unlock()
+		return
+	}
+	stood := e != nil && p.sitting
+	p.target = e
+	if stood {
+		p.sitting = false
+	}
+	p.mu.Unlock()
+	if stood {
+		m.updateState()
+	}
+}
+
+// Tick makes the pet attack its target, follow its owner or wander around,
+// unless it is sitting.
+func (p *PetBehaviour) Tick(m *Mob) {
+	p.mu.Lock()
+	p.ticks++
+	findOwner := p.ticks%20 == 1
+	p.mu.Unlock()
+	if findOwner {
+		p.findOwner(m)
+	}
+
+	p.mu.Lock()
+	sitting, owner, target := p.sitting, p.ownerEntity, p.target
+	if p.attackCooldown > 0 {
+		p.attackCooldown--
+	}
+	p.mu.Unlock()
+
+	if sitting {
+		return
+	}
+	if target != nil && p.attack(m, target) {
+		return
+	}
+	if owner != nil {
+		d := owner.Position().Sub(m.Position()).Len()
+		switch {
+		case d > 12:
+			m.Teleport(owner.Position())
+			return
+		case d > 6:
+			m.WalkTowards(owner.Position())
+			return
+		case d < 3:
+			m.Stop()
+			return
+		}
+	}
+	p.wander.Tick(m)
+}
+
+// findOwner looks up the owner of the pet in the world of the pet. The owner
+// is cleared if it is no longer in the world.
+func (p *PetBehaviour) findOwner(m *Mob) {
+	u := p.OwnerUUID()
+	if u == uuid.Nil {
+		return
+	}
+	w := m.World()
+	var owner world.Entity
+	for _, e := range w.Entities() {
+		if o, ok := e.(interface{ UUID() uuid.UUID }); ok && o.UUID() == u {
+			owner = e
+			break
+		}
+	}
+	p.mu.Lock()
+	changed := p.ownerEntity != owner
+	p.ownerEntity = owner
+	p.mu.Unlock()
+	if changed {
+		m.updateState()
+	}
+}
+
+// attack makes the pet walk towards its target and attack it when close. False
+// is returned if the target is no longer valid, after which the target is
+// cleared.
+func (p *PetBehaviour) attack(m *Mob, target world.Entity) bool {
+	l, ok := target.(Living)
+	w, _ := world.OfEntity(target)
+	if !ok || l.Dead() || w != m.World() || target.Position().Sub(m.Position()).Len() > 16 {
+		p.mu.Lock()
+		p.target = nil
+		p.mu.Unlock()
+		return false
+	}
+	m.LookAt(target.Position())
+	if target.Position().Sub(m.Position()).Len() > 1.5 {
+		m.WalkTowards(target.Position())
+		return true
+	}
+	m.Stop()
+
+	p.mu.Lock()
+	ready := p.attackCooldown == 0
+	if ready {
+		p.attackCooldown = 20
+	}
+	p.mu.Unlock()
+	if ready {
+		if _, vulnerable := l.Hurt(p.conf.AttackDamage, AttackDamageSource{Attacker: m}); vulnerable {
+			l.KnockBack(m.Position(), 0.4, 0.4)
+		}
+	}
+	return true
+}
+
+// Hurt makes a Protective pet attack the entity that hurt it, unless the
+// entity is its owner.
+func (p *PetBehaviour) Hurt(m *Mob, _ float64, src world.DamageSource) {
+	var attacker world.Entity
+	if s, ok := src.(AttackDamageSource); ok {
+		attacker = s.Attacker
+	} else if s, ok := src.(ProjectileDamageSource); ok {
+		attacker = s.Owner
+	}
+	if attacker != nil {
+		p.SetTarget(m, attacker)
+	}
+}
+
+// Interact tames the pet if the user feeds it a taming item. If the user is
+// the owner of the pet, it instead dyes the collar of the pet if the user
+// holds a dye, or makes the pet sit down or stand up.
+func (p *PetBehaviour) Interact(m *Mob, user item.User, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	if !p.Tamed() {
+		if held.Empty() || !p.tamingItem(held.Item()) {
+			return false
+		}
+		ctx.SubtractFromCount(1)
+		if rand.Float64() < p.conf.TameChance {
+			p.Tame(m, user)
+			for _, v := range m.World().Viewers(m.Position()) {
+				v.ViewEntityAction(m, LoveAction{})
+			}
+		}
+		return true
+	}
+	u, ok := user.(interface{ UUID() uuid.UUID })
+	if !ok || u.UUID() != p.OwnerUUID() {
+		return false
+	}
+	if dye, ok := held.Item().(item.Dye); ok && p.conf.Collar {
+		if c, _ := p.Collar(); c != dye.Colour {
+			p.SetCollar(m, dye.Colour)
+			ctx.SubtractFromCount(1)
+		}
+		return true
+	}
+	p.SetSitting(m, !p.Sitting())
+	return true
+}
+
+// tamingItem checks if the item passed is one of the taming items of the pet.
+func (p *PetBehaviour) tamingItem(it world.Item) bool {
+	name, _ := it.EncodeItem()
+	for _, t := range p.conf.TamingItems {
+		if n, _ := t.EncodeItem(); n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AlertPets makes the Protective pets of the owner passed that are near it
+// attack the target passed. It should be called when the owner attacks an
+// entity or is attacked by one.
+func AlertPets(owner, target world.Entity) {
+	w, _ := world.OfEntity(owner)
+	if w == nil || target == nil || owner == target {
+		return
+	}
+	for _, e := range w.EntitiesWithin(cube.Box(-16, -16, -16, 16, 16, 16).Translate(owner.Position()), nil) {
+		m, ok := e.(*Mob)
+		if !ok || e == target {
+			continue
+		}
+		if p, ok := m.Behaviour().(*PetBehaviour); ok && p.Owner() == owner {
+			p.SetTarget(m, target)
+		}
+	}
+}
+
+// petType is a world.EntityType of a pet with a PetBehaviour.
+type petType interface {
+	world.EntityType
+	// config returns the PetBehaviourConfig used for pets of the type.
+	config() PetBehaviourConfig
+}
+
+// decodePet decodes a pet of the petType passed from NBT data.
+func decodePet(t petType, m map[string]any) *Mob {
+	conf := t.config()
+	conf.Variant = nbtconv.Int32(m, "Variant")
+	conf.Owner, _ = uuid.Parse(nbtconv.String(m, "Owner"))
+
+	pet := conf.New(t, nbtconv.Vec3(m, "Pos"))
+	pet.vel, pet.rot = nbtconv.Vec3(m, "Motion"), nbtconv.Rotation(m)
+	if health := float64(nbtconv.Float32(m, "Health")); health > 0 {
+		pet.health.AddHealth(health - pet.MaxHealth())
+	}
+	b := pet.Behaviour().(*PetBehaviour)
+	b.sitting = nbtconv.Bool(m, "Sitting")
+	if c, ok := m["Color"]; ok {
+		if c, ok := c.(uint8); ok && int(c) < len(item.Colours()) {
+			b.collar = item.Colours()[c]
+		}
+	}
+	return pet
+}
+
+// encodePet encodes a pet to NBT data.
+func encodePet(e world.Entity) map[string]any {
+	pet := e.(*Mob)
+	b := pet.Behaviour().(*PetBehaviour)
+	b.mu.Lock()
+	owner, sitting, collar := b.owner, b.sitting, b.collar
+	b.mu.Unlock()
+
+	yaw, pitch := pet.Rotation().Elem()
+	data := map[string]any{
+		"Pos":     nbtconv.Vec3ToFloat32Slice(pet.Position()),
+		"Motion":  nbtconv.Vec3ToFloat32Slice(pet.Velocity()),
+		"Yaw":     float32(yaw),
+		"Pitch":   float32(pitch),
+		"Health":  float32(pet.Health()),
+		"Variant": b.Variant(),
+		"Sitting": boolByte(sitting),
+		"Color":   collar.Uint8(),
+	}
+	if owner != uuid.Nil {
+		data["Owner"] = owner.String()
+	}
+	return data
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewPig creates a pig at the position passed.
+func NewPig(pos mgl64.Vec3) *Mob {
+	return PigType{}.config().New(PigType{}, pos)
+}
+
+// PigType is a world.EntityType implementation for pigs.
+type PigType struct{}
+
+func (PigType) EncodeEntity() string { return "minecraft:pig" }
+func (PigType) BBox(e world.Entity) cube.BBox {
+	return animalBBox(e, 0.9, 0.9)
+}
+
+func (t PigType) DecodeNBT(m map[string]any) world.Entity { return decodeAnimal(t, m) }
+func (PigType) EncodeNBT(e world.Entity) map[string]any   { return encodeAnimal(e) }
+
+// config returns the AnimalBehaviourConfig of pigs.
+func (PigType) config() AnimalBehaviourConfig {
+	return AnimalBehaviourConfig{
+		BreedingItems: []world.Item{block.Carrot{}, block.Potato{}, item.Beetroot{}},
+		MaxHealth:     10,
+	}
+}
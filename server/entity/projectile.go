@@ -7,6 +7,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
 	"github.com/go-gl/mathgl/mgl64"
 	"math"
 	"math/rand"
@@ -27,6 +28,10 @@ type ProjectileBehaviourConfig struct {
 	// back. The base damage is multiplied with the velocity of the projectile
 	// to calculate the final damage of the projectile.
 	Damage float64
+	// WetDamageAddend is additional damage dealt to entities that are in
+	// water or rain when hit by the projectile, such as by a trident enchanted
+	// with impaling.
+	WetDamageAddend float64
 	// Potion is the potion effect that is applied to an entity when the
 	// projectile hits it.
 	Potion potion.Potion
@@ -60,6 +65,11 @@ type ProjectileBehaviourConfig struct {
 	// false, the projectile will break when hitting a block (like a snowball).
 	// If set to true, the projectile will survive like an arrow does.
 	SurviveBlockCollision bool
+	// SurviveEntityCollision specifies if a projectile with this
+	// ProjectileBehaviour should survive collision with an entity. If set to
+	// true, the projectile bounces off the entity it hit (like a trident) and
+	// no longer hits entities afterwards.
+	SurviveEntityCollision bool
 	// BlockCollisionVelocityMultiplier is the multiplier used to modify the
 	// velocity of a projectile that has SurviveBlockCollision set to true. The
 	// default, 0, will cause the projectile to lose its velocity completely. A
@@ -100,6 +110,7 @@ type ProjectileBehaviour struct {
 
 	collisionPos cube.Pos
 	collided     bool
+	dealtDamage  bool
 }
 
 // Owner returns the owner of the projectile.
@@ -172,15 +183,24 @@ func (lt *ProjectileBehaviour) Tick(e *Ent) *Movement {
 		if t, ok := w.Block(bpos).(block.TNT); ok && e.OnFireDuration() > 0 {
 			t.Ignite(bpos, w)
 		}
-		if lt.conf.SurviveBlockCollision {
-			lt.hitBlockSurviving(e, r, m)
-			return m
-		}
+		w.EmitGameEvent(r.Position(), gameevent.ProjectileLand{}, e)
 	}
 	if lt.conf.Hit != nil {
 		lt.conf.Hit(e, result)
 	}
 
+	switch r := result.(type) {
+	case trace.EntityResult:
+		if lt.conf.SurviveEntityCollision {
+			lt.hitEntitySurviving(e, vel)
+			return m
+		}
+	case trace.BlockResult:
+		if lt.conf.SurviveBlockCollision {
+			lt.hitBlockSurviving(e, r, m)
+			return m
+		}
+	}
 	lt.close = true
 	return m
 }
@@ -258,6 +278,17 @@ func (lt *ProjectileBehaviour) hitBlockSurviving(e *Ent, r trace.BlockResult, m
 	e.mu.Unlock()
 }
 
+// hitEntitySurviving is called if
+// ProjectileBehaviourConfig.SurviveEntityCollision is set to true and the
+// projectile collides with an entity. The projectile bounces off the entity
+// and will not hit any other entities.
+func (lt *ProjectileBehaviour) hitEntitySurviving(e *Ent, vel mgl64.Vec3) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.vel = mgl64.Vec3{vel[0] * -0.01, vel[1] * -0.1, vel[2] * -0.01}
+	lt.dealtDamage = true
+}
+
 // hitEntity is called when a projectile hits a Living. It deals damage to the
 // entity and knocks it back. Additionally, it applies any potion effects and
 // fire if applicable.
@@ -267,7 +298,11 @@ func (lt *ProjectileBehaviour) hitEntity(l Living, e *Ent, origin, vel mgl64.Vec
 	if lt.conf.Critical {
 		dmg += rand.Float64() * dmg / 2
 	}
-	if _, vulnerable := l.Hurt(lt.conf.Damage, src); vulnerable {
+	damage := lt.conf.Damage
+	if lt.conf.WetDamageAddend > 0 && Wet(l) {
+		damage += lt.conf.WetDamageAddend
+	}
+	if _, vulnerable := l.Hurt(damage, src); vulnerable {
 		l.KnockBack(origin, 0.45+lt.conf.KnockBackForceAddend, 0.3608+lt.conf.KnockBackHeightAddend)
 
 		for _, eff := range lt.conf.Potion.Effects() {
@@ -327,6 +362,6 @@ func (lt *ProjectileBehaviour) ignores(e *Ent) func(other world.Entity) bool {
 	return func(other world.Entity) (ignored bool) {
 		g, ok := other.(interface{ GameMode() world.GameMode })
 		_, living := other.(Living)
-		return (ok && !g.GameMode().HasCollision()) || e == other || !living || (e.age < time.Second/4 && lt.owner == other)
+		return (ok && !g.GameMode().HasCollision()) || e == other || !living || lt.dealtDamage || (e.age < time.Second/4 && lt.owner == other)
 	}
 }
@@ -16,18 +16,28 @@ var DefaultRegistry = conf.New([]world.EntityType{
 	AreaEffectCloudType{},
 	ArrowType{},
 	BottleOfEnchantingType{},
+	CatType{},
+	ChickenType{},
+	CowType{},
 	EggType{},
 	EnderPearlType{},
 	ExperienceOrbType{},
 	FallingBlockType{},
 	FireworkType{},
+	HorseType{},
 	ItemType{},
 	LightningType{},
 	LingeringPotionType{},
+	ParrotType{},
+	PigType{},
+	SheepType{},
 	SnowballType{},
 	SplashPotionType{},
 	TNTType{},
 	TextType{},
+	TridentType{},
+	VillagerType{},
+	WolfType{},
 })
 
 var conf = world.EntityRegistryConfig{
@@ -90,4 +100,12 @@ var conf = world.EntityRegistryConfig{
 	Lightning: func(pos mgl64.Vec3) world.Entity {
 		return NewLightning(pos)
 	},
+	Trident: func(pos, vel mgl64.Vec3, rot cube.Rotation, owner world.Entity, trident any, obtainOnPickup bool) world.Entity {
+		t := NewTrident(pos, rot, owner, trident.(item.Stack))
+		if !obtainOnPickup {
+			t.conf.Behaviour.(*TridentBehaviour).projectile.conf.PickupItem = item.Stack{}
+		}
+		t.vel = vel
+		return t
+	},
 }
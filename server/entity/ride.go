@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Rideable represents an entity that may be ridden by a Rider, such as a
+// horse.
+type Rideable interface {
+	world.Entity
+	// Rider returns the Rider currently riding the entity. False is returned
+	// if the entity has no rider.
+	Rider() (Rider, bool)
+	// Mount makes the Rider passed start riding the entity. False is returned
+	// if the entity cannot be ridden by the Rider, for example because it
+	// already has a rider.
+	Mount(r Rider) bool
+	// Dismount removes the current Rider from the entity.
+	Dismount()
+	// SeatOffset returns the offset of the seat of the Rider relative to the
+	// position of the entity.
+	SeatOffset() mgl64.Vec3
+	// Jump makes the entity jump with a strength between 0 and 1, which
+	// depends on how long the Rider held the jump button.
+	Jump(strength float64)
+}
+
+// Rider represents an entity that is able to ride a Rideable entity, such as
+// a player.
+type Rider interface {
+	world.Entity
+	// Ride makes the Rider start riding the Rideable passed. False is returned
+	// if the Rideable could not be mounted.
+	Ride(e Rideable) bool
+	// Riding returns the Rideable that the Rider is currently riding. False is
+	// returned if the Rider is not riding an entity.
+	Riding() (Rideable, bool)
+	// Dismount makes the Rider stop riding the Rideable it is riding.
+	Dismount()
+	// MovementInput returns the movement input of the Rider. The first value
+	// is the strafing input, positive to the left, and the second value is the
+	// forward input. Both values are between -1 and 1.
+	MovementInput() mgl64.Vec2
+}
+
+// Rider returns the Rider currently riding the Mob.
+func (m *Mob) Rider() (Rider, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rider, m.rider != nil
+}
+
+// Mount makes the Rider passed start riding the Mob. Mobs can only be ridden
+// if their MobBehaviour allows it by implementing a CanMount method, such as
+// horses.
+func (m *Mob) Mount(r Rider) bool {
+	b, ok := m.conf.Behaviour.(interface {
+		CanMount(m *Mob, r Rider) bool
+	})
+	if !ok || m.Dead() || !b.CanMount(m, r) {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.rider != nil {
+		return false
+	}
+	m.rider = r
+	return true
+}
+
+// Dismount removes the Rider of the Mob, making it stop riding the Mob.
+func (m *Mob) Dismount() {
+	m.mu.Lock()
+	r := m.rider
+	m.rider = nil
+	m.mu.Unlock()
+	if r == nil {
+		return
+	}
+	if riding, ok := r.Riding(); ok && riding == Rideable(m) {
+		r.Dismount()
+	}
+}
+
+// SeatOffset returns the offset of the seat of the Mob relative to its
+// position. Unless its MobBehaviour implements a SeatOffset method, the seat
+// is on top of the bounding box of the Mob.
+func (m *Mob) SeatOffset() mgl64.Vec3 {
+	if s, ok := m.conf.Behaviour.(interface{ SeatOffset() mgl64.Vec3 }); ok {
+		return s.SeatOffset()
+	}
+	return mgl64.Vec3{0, m.t.BBox(m).Height()}
+}
+
+// Jump makes the Mob jump if it is on the ground. If the MobBehaviour of the
+// Mob implements a Jump method, it is called instead with the strength passed.
+func (m *Mob) Jump(strength float64) {
+	if j, ok := m.conf.Behaviour.(interface {
+		Jump(m *Mob, strength float64)
+	}); ok {
+		j.Jump(m, strength)
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.mc.OnGround() {
+		m.vel[1] = 0.42
+	}
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewSheep creates a sheep at the position passed.
+func NewSheep(pos mgl64.Vec3) *Mob {
+	return SheepType{}.config().New(SheepType{}, pos)
+}
+
+// SheepType is a world.EntityType implementation for sheep.
+type SheepType struct{}
+
+func (SheepType) EncodeEntity() string { return "minecraft:sheep" }
+func (SheepType) BBox(e world.Entity) cube.BBox {
+	return animalBBox(e, 0.9, 1.3)
+}
+
+func (t SheepType) DecodeNBT(m map[string]any) world.Entity { return decodeAnimal(t, m) }
+func (SheepType) EncodeNBT(e world.Entity) map[string]any   { return encodeAnimal(e) }
+
+// config returns the AnimalBehaviourConfig of sheep.
+func (SheepType) config() AnimalBehaviourConfig {
+	return AnimalBehaviourConfig{
+		BreedingItems: []world.Item{item.Wheat{}},
+		MaxHealth:     8,
+	}
+}
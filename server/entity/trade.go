@@ -0,0 +1,227 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"math"
+	"sync"
+)
+
+// TradeOffer is a single trade offered by a trader, such as a villager. The
+// player trading gives BuyA and, optionally, BuyB in exchange for Sell.
+type TradeOffer struct {
+	// BuyA is the first item the player must give. Its count is the base
+	// price of the offer, which changes with the Demand of the offer.
+	BuyA item.Stack
+	// BuyB is the second item the player must give. It may be empty if only
+	// one item is needed.
+	BuyB item.Stack
+	// Sell is the item the player receives.
+	Sell item.Stack
+	// Uses is the amount of times the offer was used since the trader last
+	// restocked.
+	Uses int
+	// MaxUses is the amount of times the offer may be used before the trader
+	// must restock. If 0, the offer may be used an unlimited amount of times.
+	MaxUses int
+	// PriceMultiplier is the factor by which the Demand of the offer changes
+	// the price of BuyA. If 0, the price never changes.
+	PriceMultiplier float64
+	// Demand is the demand of the offer. It increases when the offer is used
+	// a lot before the trader restocks, making BuyA more expensive, and
+	// decreases when it is used little.
+	Demand int
+	// Tier is the tier of the trader from which the offer is available,
+	// ranging from 0 (novice) to 4 (master).
+	Tier int
+	// TraderExperience is the experience the trader gains every time the
+	// offer is used, which unlocks offers of higher tiers.
+	TraderExperience int
+	// RewardExperience specifies if the player receives experience when using
+	// the offer.
+	RewardExperience bool
+}
+
+// Price returns the stack of BuyA that the player must give for the offer,
+// taking into account the Demand of the offer.
+func (o TradeOffer) Price() item.Stack {
+	if o.BuyA.Empty() {
+		return o.BuyA
+	}
+	count := o.BuyA.Count()
+	if o.Demand > 0 {
+		count += int(math.Floor(float64(count) * float64(o.Demand) * o.PriceMultiplier))
+	}
+	count = int(math.Max(math.Min(float64(count), float64(o.BuyA.MaxCount())), 1))
+	return o.BuyA.Grow(count - o.BuyA.Count())
+}
+
+// Disabled checks if the offer was used its maximum amount of times and may
+// not be used until the trader restocks.
+func (o TradeOffer) Disabled() bool {
+	return o.MaxUses > 0 && o.Uses >= o.MaxUses
+}
+
+// TradeTierExperience holds the experience a trader needs to reach each tier,
+// ranging from novice to master.
+var TradeTierExperience = [...]int{0, 10, 70, 150, 250}
+
+// TradeList is a list of TradeOffers of a trader, together with the
+// experience and tier of the trader. A TradeList may be opened for a player
+// using player.Player.OpenTrading, so that any entity, such as an NPC, may be
+// given a custom list of trades.
+// Methods on TradeList are safe for simultaneous use from multiple
+// goroutines.
+type TradeList struct {
+	mu         sync.Mutex
+	name       string
+	offers     []TradeOffer
+	experience int
+	tier       int
+	maxTier    int
+}
+
+// NewTradeList creates a TradeList with the TradeOffers passed. The name is
+// shown at the top of the trading window. Only offers with a Tier of 0 are
+// available until the trader gains experience.
+func NewTradeList(name string, offers ...TradeOffer) *TradeList {
+	return &TradeList{name: name, offers: offers, maxTier: len(TradeTierExperience) - 1}
+}
+
+// Name returns the name shown at the top of the trading window.
+func (l *TradeList) Name() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.name
+}
+
+// SetName changes the name shown at the top of the trading window.
+func (l *TradeList) SetName(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.name = name
+}
+
+// Offers returns all TradeOffers in the TradeList, including those not yet
+// available at the current tier.
+func (l *TradeList) Offers() []TradeOffer {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]TradeOffer(nil), l.offers...)
+}
+
+// SetOffers replaces all TradeOffers in the TradeList.
+func (l *TradeList) SetOffers(offers ...TradeOffer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.offers = append([]TradeOffer(nil), offers...)
+}
+
+// AddOffers adds TradeOffers to the TradeList.
+func (l *TradeList) AddOffers(offers ...TradeOffer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.offers = append(l.offers, offers...)
+}
+
+// Tier returns the current tier of the trader, ranging from 0 (novice) to 4
+// (master).
+func (l *TradeList) Tier() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.tier
+}
+
+// SetTier changes the tier of the trader, unlocking all offers of this tier
+// and lower. The experience of the trader is set to the experience needed for
+// the tier.
+func (l *TradeList) SetTier(tier int) {
+	tier = clampTier(tier)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tier, l.experience = tier, TradeTierExperience[tier]
+}
+
+// SetMaxTier limits the tier that the trader can reach by gaining experience.
+// Custom traders may set a max tier of 0 to always offer all of their offers
+// that have a Tier of 0.
+func (l *TradeList) SetMaxTier(tier int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxTier = clampTier(tier)
+}
+
+// Experience returns the experience of the trader.
+func (l *TradeList) Experience() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.experience
+}
+
+// Trade uses the TradeOffer at index i, increasing its uses and the experience
+// of the trader. The offer as it was before the trade is returned, so that its
+// Price may be taken from the player. False is returned if no offer exists at
+// that index, if it is not available at the current tier or if it is
+// Disabled.
+func (l *TradeList) Trade(i int) (TradeOffer, bool) {
+	return l.TradeIf(i, func(TradeOffer) bool { return true })
+}
+
+// TradeIf works like Trade, but only uses the TradeOffer if the function
+// passed returns true for it. The function is called with the TradeList
+// locked, so that the offer cannot be changed between checking and using it.
+// It must not call methods on the TradeList.
+func (l *TradeList) TradeIf(i int, f func(o TradeOffer) bool) (TradeOffer, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if i < 0 || i >= len(l.offers) {
+		return TradeOffer{}, false
+	}
+	o := l.offers[i]
+	if o.Tier > l.tier || o.Disabled() || !f(o) {
+		return TradeOffer{}, false
+	}
+	l.offers[i].Uses++
+	l.experience += o.TraderExperience
+	for l.tier < l.maxTier && l.experience >= TradeTierExperience[l.tier+1] {
+		l.tier++
+	}
+	return o, true
+}
+
+// Restock resets the uses of all offers, so that Disabled offers may be used
+// again. The demand of every offer is updated according to how often it was
+// used since the last restock.
+func (l *TradeList) Restock() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, o := range l.offers {
+		l.offers[i].Demand = o.Demand + o.Uses - (o.MaxUses - o.Uses)
+		if l.offers[i].Demand < 0 {
+			l.offers[i].Demand = 0
+		}
+		l.offers[i].Uses = 0
+	}
+}
+
+// Restockable checks if any offer in the TradeList was used since the last
+// restock.
+func (l *TradeList) Restockable() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, o := range l.offers {
+		if o.Uses > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// clampTier clamps a tier to the range of tiers in TradeTierExperience.
+func clampTier(tier int) int {
+	if tier < 0 {
+		return 0
+	} else if tier >= len(TradeTierExperience) {
+		return len(TradeTierExperience) - 1
+	}
+	return tier
+}
@@ -0,0 +1,225 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// NewTrident creates a thrown trident entity from the trident item.Stack
+// passed. The trident is returned to the thrower after hitting a target if it
+// is enchanted with loyalty, and summons lightning on the entity hit during a
+// thunderstorm if it is enchanted with channeling.
+func NewTrident(pos mgl64.Vec3, rot cube.Rotation, owner world.Entity, trident item.Stack) *Ent {
+	conf := tridentConf
+	conf.PickupItem = trident
+	if i, ok := trident.Enchantment(enchantment.Impaling{}); ok {
+		conf.WetDamageAddend = (enchantment.Impaling{}).Damage(i.Level())
+	}
+	b := &TridentBehaviour{trident: trident}
+	if l, ok := trident.Enchantment(enchantment.Loyalty{}); ok {
+		b.loyalty = l.Level()
+	}
+	_, b.channeling = trident.Enchantment(enchantment.Channeling{})
+	conf.Hit = b.hit
+	b.projectile = conf.New(owner)
+
+	t := Config{Behaviour: b}.New(TridentType{}, pos)
+	t.rot = rot
+	return t
+}
+
+var tridentConf = ProjectileBehaviourConfig{
+	Gravity:                0.05,
+	Drag:                   0.01,
+	Damage:                 8,
+	SurviveBlockCollision:  true,
+	SurviveEntityCollision: true,
+}
+
+// TridentBehaviour implements the behaviour of thrown tridents. It extends
+// ProjectileBehaviour with the loyalty and channeling enchantments.
+type TridentBehaviour struct {
+	projectile *ProjectileBehaviour
+	trident    item.Stack
+
+	loyalty    int
+	channeling bool
+	returning  bool
+}
+
+// Owner returns the entity that threw the trident.
+func (t *TridentBehaviour) Owner() world.Entity {
+	return t.projectile.Owner()
+}
+
+// Trident returns the trident item.Stack that was thrown.
+func (t *TridentBehaviour) Trident() item.Stack {
+	return t.trident
+}
+
+// Returning checks if the trident is currently returning to its owner as a
+// result of the loyalty enchantment.
+func (t *TridentBehaviour) Returning() bool {
+	return t.returning
+}
+
+// Tick moves the trident. If the trident is enchanted with loyalty, it starts
+// returning to its owner once it hit an entity or a block.
+func (t *TridentBehaviour) Tick(e *Ent) *Movement {
+	if t.returning {
+		return t.tickReturning(e)
+	}
+	m := t.projectile.Tick(e)
+	if t.loyalty == 0 || t.projectile.close {
+		return m
+	}
+	w := e.World()
+	if t.projectile.dealtDamage || t.projectile.collided || e.Position()[1] < float64(w.Range()[0]) {
+		if _, ok := t.owner(w); ok {
+			t.returning = true
+			w.PlaySound(e.Position(), sound.TridentReturn{})
+			for _, v := range w.Viewers(e.Position()) {
+				v.ViewEntityState(e)
+			}
+		}
+	}
+	return m
+}
+
+// tickReturning moves the trident towards its owner. Once it reaches the
+// owner, it is picked up. If the owner is no longer valid, the trident is
+// dropped as an item.
+func (t *TridentBehaviour) tickReturning(e *Ent) *Movement {
+	w := e.World()
+	owner, ok := t.owner(w)
+	if !ok {
+		if !t.projectile.conf.PickupItem.Empty() {
+			w.AddEntity(NewItem(t.projectile.conf.PickupItem, e.Position()))
+		}
+		_ = e.Close()
+		return nil
+	}
+
+	e.mu.Lock()
+	pos, vel := e.pos, e.vel
+	diff := EyePosition(owner).Sub(pos)
+	pos[1] += diff[1] * 0.015 * float64(t.loyalty)
+	newVel := vel.Mul(0.95).Add(diff.Normalize().Mul((enchantment.Loyalty{}).ReturnSpeed(t.loyalty)))
+	end := pos.Add(newVel)
+	rot := cube.Rotation{
+		mgl64.RadToDeg(math.Atan2(newVel[0], newVel[2])),
+		mgl64.RadToDeg(math.Atan2(newVel[1], math.Hypot(newVel[0], newVel[2]))),
+	}
+	before := e.pos
+	e.pos, e.vel = end, newVel
+	e.mu.Unlock()
+
+	if owner.Type().BBox(owner).Translate(owner.Position()).Grow(1).Vec3Within(end) {
+		t.pickup(e, owner)
+	}
+	return &Movement{v: w.Viewers(end), e: e, pos: end, vel: newVel, dpos: end.Sub(before), dvel: newVel.Sub(vel), rot: rot}
+}
+
+// pickup makes the owner of the trident pick it up, closing the entity.
+func (t *TridentBehaviour) pickup(e *Ent, owner Living) {
+	_ = e.Close()
+	collector, ok := owner.(Collector)
+	if !ok {
+		return
+	}
+	for _, viewer := range e.World().Viewers(e.Position()) {
+		viewer.ViewEntityAction(e, PickedUpAction{Collector: collector})
+	}
+	if !t.projectile.conf.PickupItem.Empty() {
+		_ = collector.Collect(t.projectile.conf.PickupItem)
+	}
+}
+
+// owner returns the owner of the trident if it is still alive and in the
+// world passed.
+func (t *TridentBehaviour) owner(w *world.World) (Living, bool) {
+	l, ok := t.projectile.Owner().(Living)
+	if !ok || l.Dead() {
+		return nil, false
+	}
+	if ow, _ := world.OfEntity(l); ow != w {
+		return nil, false
+	}
+	return l, true
+}
+
+// hit plays the hit sounds of the trident and summons lightning on the entity
+// hit if the trident is enchanted with channeling and it is thundering.
+func (t *TridentBehaviour) hit(e *Ent, target trace.Result) {
+	w := e.World()
+	r, ok := target.(trace.EntityResult)
+	if !ok {
+		w.PlaySound(target.Position(), sound.TridentHitGround{})
+		return
+	}
+	w.PlaySound(target.Position(), sound.TridentHit{})
+	if pos := r.Entity().Position(); t.channeling && w.ThunderingAt(cube.PosFromVec3(pos)) {
+		w.AddEntity(NewLightning(pos))
+		w.PlaySound(pos, sound.TridentThunder{})
+	}
+}
+
+// Wet checks if the entity passed is in water or exposed to rain.
+func Wet(e world.Entity) bool {
+	w, ok := world.OfEntity(e)
+	if !ok {
+		return false
+	}
+	pos := cube.PosFromVec3(e.Position())
+	if l, ok := w.Liquid(pos); ok && l.LiquidType() == "water" {
+		return true
+	}
+	return w.RainingAt(pos)
+}
+
+// TridentType is a world.EntityType implementation for thrown tridents.
+type TridentType struct{}
+
+func (TridentType) EncodeEntity() string { return "minecraft:thrown_trident" }
+func (TridentType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.125, 0, -0.125, 0.125, 0.35, 0.125)
+}
+
+func (TridentType) DecodeNBT(m map[string]any) world.Entity {
+	t := NewTrident(nbtconv.Vec3(m, "Pos"), nbtconv.Rotation(m), nil, nbtconv.MapItem(m, "Trident"))
+	b := t.conf.Behaviour.(*TridentBehaviour)
+	t.vel = nbtconv.Vec3(m, "Motion")
+	if !nbtconv.Bool(m, "player") {
+		b.projectile.conf.PickupItem = item.Stack{}
+	}
+	if _, ok := m["StuckToBlockPos"]; ok {
+		b.projectile.collisionPos = nbtconv.Pos(m, "StuckToBlockPos")
+		b.projectile.collided = true
+	}
+	return t
+}
+
+func (TridentType) EncodeNBT(e world.Entity) map[string]any {
+	t := e.(*Ent)
+	b := t.conf.Behaviour.(*TridentBehaviour)
+	yaw, pitch := t.Rotation().Elem()
+	data := map[string]any{
+		"Pos":     nbtconv.Vec3ToFloat32Slice(t.Position()),
+		"Yaw":     float32(yaw),
+		"Pitch":   float32(pitch),
+		"Motion":  nbtconv.Vec3ToFloat32Slice(t.Velocity()),
+		"Trident": nbtconv.WriteItem(b.trident, true),
+		"player":  boolByte(!b.projectile.conf.PickupItem.Empty()),
+	}
+	if b.projectile.collided {
+		data["StuckToBlockPos"] = nbtconv.PosToInt32Slice(b.projectile.collisionPos)
+	}
+	return data
+}
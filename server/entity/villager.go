@@ -0,0 +1,202 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// NewVillager creates a villager with the Profession passed. The villager
+// offers the DefaultTrades of its Profession.
+func NewVillager(pos mgl64.Vec3, p Profession) *Mob {
+	return VillagerBehaviourConfig{Profession: p}.New(pos)
+}
+
+// VillagerBehaviourConfig holds optional parameters for a VillagerBehaviour.
+type VillagerBehaviourConfig struct {
+	// Profession is the Profession of the villager. It changes the look of
+	// the villager and, if Trades is nil, the offers of the villager.
+	Profession Profession
+	// Trades is the TradeList of the villager. If nil, a TradeList with the
+	// DefaultTrades of the Profession is used.
+	Trades *TradeList
+	// RestockInterval is the interval at which the villager restocks its
+	// offers. If 0, the villager restocks every 10 minutes.
+	RestockInterval time.Duration
+	// Stationary specifies if the villager should stand still instead of
+	// wandering around, which is useful for villagers used as shops.
+	Stationary bool
+}
+
+// New creates a villager at the position passed using the parameters in
+// conf.
+func (conf VillagerBehaviourConfig) New(pos mgl64.Vec3) *Mob {
+	if conf.Trades == nil {
+		conf.Trades = NewTradeList(conf.Profession.Name(), DefaultTrades(conf.Profession)...)
+	}
+	if conf.RestockInterval <= 0 {
+		conf.RestockInterval = time.Minute * 10
+	}
+	b := &VillagerBehaviour{conf: conf, tier: conf.Trades.Tier()}
+	return MobConfig{Behaviour: b, MaxHealth: 20, Speed: 0.05}.New(VillagerType{}, pos)
+}
+
+// VillagerBehaviour implements the behaviour of a villager. Villagers wander
+// around and open their trading window when a player interacts with them.
+type VillagerBehaviour struct {
+	conf   VillagerBehaviourConfig
+	wander Wander
+
+	restock time.Duration
+	tier    int
+}
+
+// Profession returns the Profession of the villager.
+func (v *VillagerBehaviour) Profession() Profession {
+	return v.conf.Profession
+}
+
+// Trades returns the TradeList of the villager. Changes made to the TradeList
+// are shown to players when they next open the trading window.
+func (v *VillagerBehaviour) Trades() *TradeList {
+	return v.conf.Trades
+}
+
+// Variant returns the variant of the villager, which is its profession.
+func (v *VillagerBehaviour) Variant() int32 {
+	return int32(v.conf.Profession.Uint8())
+}
+
+// Tick makes the villager wander around and restock its offers every
+// RestockInterval. When the villager levels up, it briefly regenerates.
+func (v *VillagerBehaviour) Tick(m *Mob) {
+	if !v.conf.Stationary {
+		v.wander.Tick(m)
+	}
+	if v.restock += time.Second / 20; v.restock >= v.conf.RestockInterval {
+		v.restock = 0
+		if v.conf.Trades.Restockable() {
+			v.conf.Trades.Restock()
+		}
+	}
+	if tier := v.conf.Trades.Tier(); tier != v.tier {
+		v.tier = tier
+		m.AddEffect(effect.New(effect.Regeneration{}, 1, time.Second*10))
+	}
+}
+
+// Interact opens the trading window of the villager for the user passed if
+// the villager trades and the user is able to trade.
+func (v *VillagerBehaviour) Interact(m *Mob, user item.User, _ *item.UseContext) bool {
+	t, ok := user.(trader)
+	if !ok || !v.conf.Profession.Trades() {
+		return false
+	}
+	m.Stop()
+	m.LookAt(user.Position())
+	t.OpenTrading(m, v.conf.Trades)
+	return true
+}
+
+// trader represents an entity that is able to trade with another entity, such
+// as a player.
+type trader interface {
+	OpenTrading(e world.Entity, l *TradeList)
+}
+
+// VillagerType is a world.EntityType implementation for villagers.
+type VillagerType struct{}
+
+func (VillagerType) EncodeEntity() string { return "minecraft:villager_v2" }
+func (VillagerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.9, 0.3)
+}
+
+func (VillagerType) DecodeNBT(m map[string]any) world.Entity {
+	l := NewTradeList(nbtconv.String(m, "TraderName"))
+	offers, _ := m["Offers"].(map[string]any)
+	for _, o := range nbtconv.Slice(offers, "Recipes") {
+		if o, ok := o.(map[string]any); ok {
+			l.AddOffers(decodeTradeOffer(o))
+		}
+	}
+	l.SetTier(int(nbtconv.Int32(m, "TradeTier")))
+	l.experience = int(nbtconv.Int32(m, "TradeExperience"))
+
+	p := ProfessionNone()
+	if variant := int(nbtconv.Int32(m, "Variant")); variant > 0 && variant < len(Professions()) {
+		p = Professions()[variant]
+	}
+	if l.Name() == "" {
+		l.SetName(p.Name())
+	}
+	v := VillagerBehaviourConfig{Profession: p, Trades: l, Stationary: nbtconv.Bool(m, "Stationary")}.New(nbtconv.Vec3(m, "Pos"))
+	v.vel, v.rot = nbtconv.Vec3(m, "Motion"), nbtconv.Rotation(m)
+	if health := float64(nbtconv.Float32(m, "Health")); health > 0 {
+		v.health.AddHealth(health - v.MaxHealth())
+	}
+	return v
+}
+
+func (VillagerType) EncodeNBT(e world.Entity) map[string]any {
+	v := e.(*Mob)
+	b := v.Behaviour().(*VillagerBehaviour)
+	l := b.Trades()
+	recipes := make([]any, 0, len(l.Offers()))
+	for _, o := range l.Offers() {
+		recipes = append(recipes, encodeTradeOffer(o))
+	}
+	yaw, pitch := v.Rotation().Elem()
+	return map[string]any{
+		"Pos":             nbtconv.Vec3ToFloat32Slice(v.Position()),
+		"Motion":          nbtconv.Vec3ToFloat32Slice(v.Velocity()),
+		"Yaw":             float32(yaw),
+		"Pitch":           float32(pitch),
+		"Health":          float32(v.Health()),
+		"Variant":         b.Variant(),
+		"TraderName":      l.Name(),
+		"Stationary":      boolByte(b.conf.Stationary),
+		"TradeTier":       int32(l.Tier()),
+		"TradeExperience": int32(l.Experience()),
+		"Offers":          map[string]any{"Recipes": recipes},
+	}
+}
+
+// encodeTradeOffer encodes a TradeOffer to a map that can be encoded to NBT.
+func encodeTradeOffer(o TradeOffer) map[string]any {
+	m := map[string]any{
+		"buyA":             nbtconv.WriteItem(o.BuyA, true),
+		"sell":             nbtconv.WriteItem(o.Sell, true),
+		"uses":             int32(o.Uses),
+		"maxUses":          int32(o.MaxUses),
+		"demand":           int32(o.Demand),
+		"priceMultiplierA": float32(o.PriceMultiplier),
+		"tier":             int32(o.Tier),
+		"traderExp":        int32(o.TraderExperience),
+		"rewardExp":        boolByte(o.RewardExperience),
+	}
+	if !o.BuyB.Empty() {
+		m["buyB"] = nbtconv.WriteItem(o.BuyB, true)
+	}
+	return m
+}
+
+// decodeTradeOffer decodes a TradeOffer from a map decoded from NBT.
+func decodeTradeOffer(m map[string]any) TradeOffer {
+	return TradeOffer{
+		BuyA:             nbtconv.MapItem(m, "buyA"),
+		BuyB:             nbtconv.MapItem(m, "buyB"),
+		Sell:             nbtconv.MapItem(m, "sell"),
+		Uses:             int(nbtconv.Int32(m, "uses")),
+		MaxUses:          int(nbtconv.Int32(m, "maxUses")),
+		Demand:           int(nbtconv.Int32(m, "demand")),
+		PriceMultiplier:  float64(nbtconv.Float32(m, "priceMultiplierA")),
+		Tier:             int(nbtconv.Int32(m, "tier")),
+		TraderExperience: int(nbtconv.Int32(m, "traderExp")),
+		RewardExperience: nbtconv.Bool(m, "rewardExp"),
+	}
+}
@@ -0,0 +1,175 @@
+package entity
+
+// Profession represents the profession of a villager. The profession
+// determines the look of the villager and the trades that it offers.
+type Profession struct {
+	profession
+}
+
+// ProfessionNone returns the profession of villagers without a job. These
+// villagers do not trade.
+func ProfessionNone() Profession {
+	return Profession{0}
+}
+
+// ProfessionFarmer returns the farmer profession.
+func ProfessionFarmer() Profession {
+	return Profession{1}
+}
+
+// ProfessionFisherman returns the fisherman profession.
+func ProfessionFisherman() Profession {
+	return Profession{2}
+}
+
+// ProfessionShepherd returns the shepherd profession.
+func ProfessionShepherd() Profession {
+	return Profession{3}
+}
+
+// ProfessionFletcher returns the fletcher profession.
+func ProfessionFletcher() Profession {
+	return Profession{4}
+}
+
+// ProfessionLibrarian returns the librarian profession.
+func ProfessionLibrarian() Profession {
+	return Profession{5}
+}
+
+// ProfessionCartographer returns the cartographer profession.
+func ProfessionCartographer() Profession {
+	return Profession{6}
+}
+
+// ProfessionCleric returns the cleric profession.
+func ProfessionCleric() Profession {
+	return Profession{7}
+}
+
+// ProfessionArmourer returns the armourer profession.
+func ProfessionArmourer() Profession {
+	return Profession{8}
+}
+
+// ProfessionWeaponsmith returns the weaponsmith profession.
+func ProfessionWeaponsmith() Profession {
+	return Profession{9}
+}
+
+// ProfessionToolsmith returns the toolsmith profession.
+func ProfessionToolsmith() Profession {
+	return Profession{10}
+}
+
+// ProfessionButcher returns the butcher profession.
+func ProfessionButcher() Profession {
+	return Profession{11}
+}
+
+// ProfessionLeatherworker returns the leatherworker profession.
+func ProfessionLeatherworker() Profession {
+	return Profession{12}
+}
+
+// ProfessionMason returns the mason profession.
+func ProfessionMason() Profession {
+	return Profession{13}
+}
+
+// ProfessionNitwit returns the profession of nitwits, villagers that can never
+// have a job and do not trade.
+func ProfessionNitwit() Profession {
+	return Profession{14}
+}
+
+// Professions returns all villager professions.
+func Professions() []Profession {
+	return []Profession{ProfessionNone(), ProfessionFarmer(), ProfessionFisherman(), ProfessionShepherd(), ProfessionFletcher(), ProfessionLibrarian(), ProfessionCartographer(), ProfessionCleric(), ProfessionArmourer(), ProfessionWeaponsmith(), ProfessionToolsmith(), ProfessionButcher(), ProfessionLeatherworker(), ProfessionMason(), ProfessionNitwit()}
+}
+
+type profession uint8
+
+// Uint8 returns the profession as a uint8.
+func (p profession) Uint8() uint8 {
+	return uint8(p)
+}
+
+// Trades checks if villagers with the profession trade with players.
+func (p profession) Trades() bool {
+	return p != 0 && p != 14
+}
+
+// Name ...
+func (p profession) Name() string {
+	switch p {
+	case 0:
+		return "Villager"
+	case 1:
+		return "Farmer"
+	case 2:
+		return "Fisherman"
+	case 3:
+		return "Shepherd"
+	case 4:
+		return "Fletcher"
+	case 5:
+		return "Librarian"
+	case 6:
+		return "Cartographer"
+	case 7:
+		return "Cleric"
+	case 8:
+		return "Armourer"
+	case 9:
+		return "Weaponsmith"
+	case 10:
+		return "Toolsmith"
+	case 11:
+		return "Butcher"
+	case 12:
+		return "Leatherworker"
+	case 13:
+		return "Mason"
+	case 14:
+		return "Nitwit"
+	}
+	panic("unknown profession")
+}
+
+// String ...
+func (p profession) String() string {
+	switch p {
+	case 0:
+		return "none"
+	case 1:
+		return "farmer"
+	case 2:
+		return "fisherman"
+	case 3:
+		return "shepherd"
+	case 4:
+		return "fletcher"
+	case 5:
+		return "librarian"
+	case 6:
+		return "cartographer"
+	case 7:
+		return "cleric"
+	case 8:
+		return "armorer"
+	case 9:
+		return "weaponsmith"
+	case 10:
+		return "toolsmith"
+	case 11:
+		return "butcher"
+	case 12:
+		return "leatherworker"
+	case 13:
+		return "mason"
+	case 14:
+		return "nitwit"
+	}
+	panic("unknown profession")
+}
@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// DefaultTrades returns the TradeOffers that villagers with the Profession
+// passed offer by default. Villagers that do not trade, such as nitwits, have
+// no offers.
+func DefaultTrades(p Profession) []TradeOffer {
+	switch p {
+	case ProfessionFarmer():
+		return []TradeOffer{
+			buy(item.Wheat{}, 20, 16, 2, 0), buy(block.Potato{}, 26, 16, 2, 0), buy(block.Carrot{}, 22, 16, 2, 0),
+			sell(item.Bread{}, 1, 6, 16, 1, 0),
+			buy(block.Pumpkin{}, 6, 12, 10, 1), sell(item.PumpkinPie{}, 1, 4, 12, 5, 1), sell(item.Apple{}, 1, 4, 16, 5, 1),
+			sell(item.Cookie{}, 3, 18, 12, 10, 2), buy(block.Melon{}, 4, 12, 20, 2),
+			sell(item.GoldenCarrot{}, 3, 3, 12, 30, 4), sell(item.GlisteringMelonSlice{}, 4, 3, 12, 30, 4),
+		}
+	case ProfessionFisherman():
+		return []TradeOffer{
+			buy(item.Coal{}, 10, 16, 2, 0), sell(item.Cod{Cooked: true}, 1, 6, 16, 1, 0),
+			buy(item.Cod{}, 15, 16, 10, 1), sell(item.Salmon{Cooked: true}, 1, 6, 16, 5, 1),
+			buy(item.Salmon{}, 13, 16, 20, 2),
+		}
+	case ProfessionShepherd():
+		return []TradeOffer{
+			buy(block.Wool{Colour: item.ColourWhite()}, 18, 16, 2, 0), sell(item.Shears{}, 2, 1, 12, 1, 0),
+			sell(block.Wool{Colour: item.ColourRed()}, 1, 1, 16, 5, 1),
+		}
+	case ProfessionFletcher():
+		return []TradeOffer{
+			buy(item.Stick{}, 32, 16, 2, 0), sell(item.Arrow{}, 1, 16, 12, 1, 0),
+			buy(item.Flint{}, 26, 12, 10, 1), sell(item.Bow{}, 2, 1, 12, 5, 1),
+			buy(item.Feather{}, 24, 16, 20, 2),
+		}
+	case ProfessionLibrarian():
+		return []TradeOffer{
+			buy(item.Paper{}, 24, 16, 2, 0), sell(block.Bookshelf{}, 9, 1, 12, 1, 0),
+			buy(item.Book{}, 4, 12, 10, 1), sell(item.Compass{}, 5, 1, 12, 10, 2),
+			sell(item.Clock{}, 5, 1, 12, 15, 3), sell(block.Glass{}, 1, 4, 12, 10, 2),
+		}
+	case ProfessionCartographer():
+		return []TradeOffer{
+			buy(item.Paper{}, 24, 16, 2, 0), buy(block.GlassPane{}, 11, 16, 10, 1), buy(item.Compass{}, 1, 12, 20, 2),
+		}
+	case ProfessionCleric():
+		return []TradeOffer{
+			buy(item.RottenFlesh{}, 32, 16, 2, 0), sell(item.Dye{Colour: item.ColourRed()}, 1, 2, 12, 1, 0),
+			buy(item.GoldIngot{}, 3, 12, 10, 1), sell(item.LapisLazuli{}, 1, 1, 12, 5, 1),
+			sell(item.GlowstoneDust{}, 4, 1, 12, 10, 2), sell(item.EnderPearl{}, 5, 1, 12, 15, 3),
+		}
+	case ProfessionArmourer():
+		return []TradeOffer{
+			buy(item.Coal{}, 15, 16, 2, 0),
+			tool(item.Helmet{Tier: item.ArmourTierIron{}}, 5, 0), tool(item.Chestplate{Tier: item.ArmourTierIron{}}, 9, 0),
+			tool(item.Leggings{Tier: item.ArmourTierIron{}}, 7, 0), tool(item.Boots{Tier: item.ArmourTierIron{}}, 4, 0),
+			buy(item.IronIngot{}, 4, 12, 10, 1), buy(item.Diamond{}, 1, 12, 30, 3),
+		}
+	case ProfessionWeaponsmith():
+		return []TradeOffer{
+			buy(item.Coal{}, 15, 16, 2, 0), tool(item.Axe{Tier: item.ToolTierIron}, 3, 0),
+			buy(item.IronIngot{}, 4, 12, 10, 1), tool(item.Sword{Tier: item.ToolTierIron}, 4, 1),
+			buy(item.Diamond{}, 1, 12, 30, 3),
+		}
+	case ProfessionToolsmith():
+		return []TradeOffer{
+			buy(item.Coal{}, 15, 16, 2, 0), tool(item.Axe{Tier: item.ToolTierStone}, 1, 0),
+			tool(item.Shovel{Tier: item.ToolTierStone}, 1, 0), tool(item.Pickaxe{Tier: item.ToolTierStone}, 1, 0),
+			tool(item.Hoe{Tier: item.ToolTierStone}, 1, 0), buy(item.IronIngot{}, 4, 12, 10, 1),
+			buy(item.Diamond{}, 1, 12, 30, 3),
+		}
+	case ProfessionButcher():
+		return []TradeOffer{
+			buy(item.Chicken{}, 14, 16, 2, 0), buy(item.Porkchop{}, 7, 16, 2, 0), buy(item.Rabbit{}, 4, 16, 2, 0),
+			sell(item.Rabbit{Cooked: true}, 1, 5, 16, 1, 0),
+			buy(item.Coal{}, 15, 16, 2, 1), sell(item.Porkchop{Cooked: true}, 1, 5, 16, 5, 1),
+			sell(item.Chicken{Cooked: true}, 1, 8, 16, 5, 1),
+			buy(item.Mutton{}, 7, 16, 20, 2), buy(item.Beef{}, 10, 16, 20, 2),
+		}
+	case ProfessionLeatherworker():
+		return []TradeOffer{
+			buy(item.Leather{}, 6, 16, 2, 0),
+			tool(item.Leggings{Tier: item.ArmourTierLeather{}}, 3, 0), tool(item.Chestplate{Tier: item.ArmourTierLeather{}}, 7, 0),
+			tool(item.Helmet{Tier: item.ArmourTierLeather{}}, 5, 1), tool(item.Boots{Tier: item.ArmourTierLeather{}}, 4, 1),
+		}
+	case ProfessionMason():
+		return []TradeOffer{
+			buy(item.ClayBall{}, 10, 16, 2, 0), sell(item.Brick{}, 1, 10, 16, 1, 0),
+		}
+	}
+	return nil
+}
+
+// buy returns a TradeOffer in which the villager buys an amount of an item
+// for one emerald.
+func buy(it world.Item, count, maxUses, experience, tier int) TradeOffer {
+	return TradeOffer{
+		BuyA:             item.NewStack(it, count),
+		Sell:             item.NewStack(item.Emerald{}, 1),
+		MaxUses:          maxUses,
+		PriceMultiplier:  0.05,
+		Tier:             tier,
+		TraderExperience: experience,
+		RewardExperience: true,
+	}
+}
+
+// sell returns a TradeOffer in which the villager sells an amount of an item
+// for an amount of emeralds.
+func sell(it world.Item, emeralds, count, maxUses, experience, tier int) TradeOffer {
+	return TradeOffer{
+		BuyA:             item.NewStack(item.Emerald{}, emeralds),
+		Sell:             item.NewStack(it, count),
+		MaxUses:          maxUses,
+		PriceMultiplier:  0.05,
+		Tier:             tier,
+		TraderExperience: experience,
+		RewardExperience: true,
+	}
+}
+
+// tool returns a TradeOffer in which the villager sells a tool or a piece of
+// armour for an amount of emeralds. These offers have a higher price
+// multiplier than other offers.
+func tool(it world.Item, emeralds, tier int) TradeOffer {
+	o := sell(it, emeralds, 1, 12, 1+tier*4, tier)
+	o.PriceMultiplier = 0.2
+	return o
+}
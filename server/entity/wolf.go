@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewWolf creates a wild wolf at the position passed. Wolves are tamed using
+// bones and defend their owner.
+func NewWolf(pos mgl64.Vec3) *Mob {
+	return WolfType{}.config().New(WolfType{}, pos)
+}
+
+// WolfType is a world.EntityType implementation for wolves.
+type WolfType struct{}
+
+func (WolfType) EncodeEntity() string { return "minecraft:wolf" }
+func (WolfType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 0.85, 0.3)
+}
+
+func (t WolfType) DecodeNBT(m map[string]any) world.Entity { return decodePet(t, m) }
+func (WolfType) EncodeNBT(e world.Entity) map[string]any   { return encodePet(e) }
+
+// config returns the PetBehaviourConfig of wolves.
+func (WolfType) config() PetBehaviourConfig {
+	return PetBehaviourConfig{
+		TamingItems:    []world.Item{item.Bone{}},
+		MaxHealth:      8,
+		TamedMaxHealth: 20,
+		Speed:          0.15,
+		Protective:     true,
+		Collar:         true,
+	}
+}
@@ -0,0 +1,104 @@
+package event
+
+import (
+	"sort"
+	"sync"
+)
+
+// Priority is the priority of a handler subscribed to a Bus. Handlers with a lower priority are called first,
+// so that handlers with a higher priority have the final say over the outcome of an event.
+type Priority int
+
+const (
+	// PriorityLowest is the priority of handlers called first.
+	PriorityLowest Priority = iota
+	// PriorityLow is the priority of handlers called after those with PriorityLowest.
+	PriorityLow
+	// PriorityNormal is the default priority of handlers.
+	PriorityNormal
+	// PriorityHigh is the priority of handlers called after those with PriorityNormal.
+	PriorityHigh
+	// PriorityHighest is the priority of handlers called last that may still change the outcome of an event.
+	PriorityHighest
+	// PriorityMonitor is the priority of handlers that only observe the outcome of an event. They are called
+	// after all other handlers, and calls to Context.Cancel made by them have no effect.
+	PriorityMonitor
+)
+
+// Bus dispatches events of type E to any number of handlers subscribed to it, ordered by their Priority. It
+// allows multiple independent components to react to the same event. The zero value of a Bus is ready to
+// use. A Bus is safe for concurrent use.
+type Bus[E any] struct {
+	mu     sync.RWMutex
+	nextID uint64
+	subs   []subscription[E]
+}
+
+// subscription is a handler subscribed to a Bus.
+type subscription[E any] struct {
+	id uint64
+	p  Priority
+	f  func(ctx *Context, e E)
+}
+
+// Subscribe subscribes a handler to the Bus with the Priority passed. Handlers with the same Priority are
+// called in the order they were subscribed. The function returned unsubscribes the handler when called.
+func (b *Bus[E]) Subscribe(p Priority, f func(ctx *Context, e E)) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	// The slice is copied so that events currently being called keep using the old handlers.
+	subs := append(make([]subscription[E], 0, len(b.subs)+1), b.subs...)
+	subs = append(subs, subscription[E]{id: id, p: p, f: f})
+	sort.SliceStable(subs, func(i, j int) bool {
+		return subs[i].p < subs[j].p
+	})
+	b.subs = subs
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { b.unsubscribe(id) })
+	}
+}
+
+// unsubscribe removes the handler with the ID passed from the Bus.
+func (b *Bus[E]) unsubscribe(id uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs := make([]subscription[E], 0, len(b.subs))
+	for _, sub := range b.subs {
+		if sub.id != id {
+			subs = append(subs, sub)
+		}
+	}
+	b.subs = subs
+}
+
+// Call calls all handlers subscribed to the Bus with the event passed, ordered by their Priority. Handlers
+// may cancel the event by calling ctx.Cancel(), or undo the cancellation of a handler called before them by
+// calling ctx.Uncancel(). Handlers with PriorityMonitor are passed a copy of ctx, so that they cannot change
+// the outcome. The caller should check ctx.Cancelled() after Call returns.
+func (b *Bus[E]) Call(ctx *Context, e E) {
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+
+	for _, sub := range subs {
+		if sub.p == PriorityMonitor {
+			c := *ctx
+			sub.f(&c, e)
+			continue
+		}
+		sub.f(ctx, e)
+	}
+}
+
+// Len returns the amount of handlers subscribed to the Bus.
+func (b *Bus[E]) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
@@ -20,3 +20,8 @@ func (ctx *Context) Cancelled() bool {
 func (ctx *Context) Cancel() {
 	ctx.cancel = true
 }
+
+// Uncancel undoes the cancellation of the context, for example by a handler called earlier.
+func (ctx *Context) Uncancel() {
+	ctx.cancel = false
+}
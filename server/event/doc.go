@@ -3,4 +3,8 @@
 // Generally, the caller of `event.C()` calls `Context.Cancelled()` to check if the `Context` was cancelled (using
 // `Context.Cancel()`) by whatever code it was passed to.
 // who is then able to cancel it by calling `Context.Cancel()`.
+// A `Bus` may be used to dispatch an event to multiple handlers, ordered by `Priority`, rather than a single one.
+// Slot changes of an `inventory.Inventory` are dispatched through a `Bus`. Events of players and worlds are still
+// passed to the single `Handler` of the player or world, which may forward them to a `Bus` of its own if multiple
+// components need to handle them.
 package event
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/moderation"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"strings"
+	"sync"
+)
+
+// Attach exports the Events of the server.Server passed to the Exporter: players joining, leaving and dying
+// and messages sent in the global chat. If the moderation.Manager passed is not nil, players banned through it
+// are exported too. The function returned stops exporting the Events of the server.Server.
+func (ex *Exporter) Attach(srv *server.Server, m *moderation.Manager) (detach func()) {
+	a := &attachment{ex: ex, deaths: map[*player.Player]func(){}}
+	for _, p := range srv.Players() {
+		a.watchDeaths(p)
+	}
+	unsubscribers := []func(){
+		srv.Joins().Subscribe(event.PriorityMonitor, a.join),
+		srv.Quits().Subscribe(event.PriorityMonitor, a.quit),
+	}
+	if m != nil {
+		unsubscribers = append(unsubscribers, m.Banned().Subscribe(event.PriorityMonitor, a.ban))
+	}
+	chat.Global.Subscribe(a)
+
+	return func() {
+		chat.Global.Unsubscribe(a)
+		for _, f := range unsubscribers {
+			f()
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		for _, f := range a.deaths {
+			f()
+		}
+		a.deaths = map[*player.Player]func(){}
+	}
+}
+
+// attachment exports the Events of a server.Server to an Exporter. It implements chat.Subscriber to export
+// messages sent in the global chat.
+type attachment struct {
+	ex *Exporter
+
+	mu     sync.Mutex
+	deaths map[*player.Player]func()
+}
+
+// join exports a TypeJoin Event for the player passed and starts exporting its deaths.
+func (a *attachment) join(_ *event.Context, p *player.Player) {
+	a.ex.Export(Event{Type: TypeJoin, Player: p.Name(), XUID: p.XUID(), Message: p.Name() + " joined the server"})
+	a.watchDeaths(p)
+}
+
+// quit exports a TypeQuit Event for the player passed and stops exporting its deaths.
+func (a *attachment) quit(_ *event.Context, p *player.Player) {
+	a.ex.Export(Event{Type: TypeQuit, Player: p.Name(), XUID: p.XUID(), Message: p.Name() + " left the server"})
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if f, ok := a.deaths[p]; ok {
+		f()
+		delete(a.deaths, p)
+	}
+}
+
+// watchDeaths starts exporting a TypeDeath Event every time the player passed dies.
+func (a *attachment) watchDeaths(p *player.Player) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.deaths[p]; ok {
+		return
+	}
+	a.deaths[p] = p.Deaths().Subscribe(event.PriorityMonitor, func(_ *event.Context, src world.DamageSource) {
+		a.death(p, src)
+	})
+}
+
+// death exports a TypeDeath Event for the player passed, killed by the world.DamageSource passed.
+func (a *attachment) death(p *player.Player, src world.DamageSource) {
+	e := Event{Type: TypeDeath, Player: p.Name(), XUID: p.XUID(), Message: p.Name() + " died", Fields: map[string]string{
+		"cause": damageCause(src),
+	}}
+	if attack, ok := src.(entity.AttackDamageSource); ok {
+		if killer, ok := attack.Attacker.(interface{ Name() string }); ok {
+			e.Fields["killer"] = killer.Name()
+			e.Message = fmt.Sprintf("%v was killed by %v", p.Name(), killer.Name())
+		}
+	}
+	a.ex.Export(e)
+}
+
+// ban exports a TypeBan Event for the moderation.Entry passed.
+func (a *attachment) ban(_ *event.Context, e moderation.Entry) {
+	msg := e.Name + " was banned"
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	fields := map[string]string{"source": e.Source}
+	if !e.Permanent() {
+		fields["expires"] = e.Expires.String()
+	}
+	a.ex.Export(Event{Type: TypeBan, Player: e.Name, Message: msg, Fields: fields})
+}
+
+// Message exports a TypeChat Event for a message sent in the global chat.
+func (a *attachment) Message(msg ...any) {
+	s := strings.TrimSpace(text.Clean(strings.TrimSuffix(fmt.Sprintln(msg...), "\n")))
+	if s == "" {
+		return
+	}
+	a.ex.Export(Event{Type: TypeChat, Message: s})
+}
+
+// damageCause returns a name for the world.DamageSource passed, such as "Attack" for an
+// entity.AttackDamageSource.
+func damageCause(src world.DamageSource) string {
+	name := fmt.Sprintf("%T", src)
+	if i := strings.LastIndexByte(name, '.'); i != -1 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, "DamageSource")
+}
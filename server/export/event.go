@@ -0,0 +1,66 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Type is the type of an Event, such as TypeJoin.
+type Type string
+
+const (
+	// TypeJoin is the Type of Events exported when a player joins the server.
+	TypeJoin Type = "join"
+	// TypeQuit is the Type of Events exported when a player leaves the server.
+	TypeQuit Type = "quit"
+	// TypeChat is the Type of Events exported when a message is sent in the
+	// global chat.
+	TypeChat Type = "chat"
+	// TypeDeath is the Type of Events exported when a player dies.
+	TypeDeath Type = "death"
+	// TypeBan is the Type of Events exported when a player is banned.
+	TypeBan Type = "ban"
+)
+
+// Types returns all Types of Events that are exported by an Exporter.
+func Types() []Type {
+	return []Type{TypeJoin, TypeQuit, TypeChat, TypeDeath, TypeBan}
+}
+
+// ParseTypes parses a list of Types from their names, such as "join". An
+// error is returned if one of the names is not that of a Type.
+func ParseTypes(names []string) ([]Type, error) {
+	types := make([]Type, 0, len(names))
+	for _, name := range names {
+		t := Type(strings.ToLower(strings.TrimSpace(name)))
+		found := false
+		for _, other := range Types() {
+			found = found || t == other
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown event type %q, expected one of %v", name, Types())
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// Event is an event that happened on the server and is exported to the
+// Targets of an Exporter.
+type Event struct {
+	// Type is the Type of the Event.
+	Type Type `json:"type"`
+	// Time is the time at which the Event happened.
+	Time time.Time `json:"time"`
+	// Player is the name of the player that the Event concerns, if any.
+	Player string `json:"player,omitempty"`
+	// XUID is the XUID of the player that the Event concerns, if known.
+	XUID string `json:"xuid,omitempty"`
+	// Message is a human-readable description of the Event, such as the
+	// message sent in the chat or the reason of a ban.
+	Message string `json:"message,omitempty"`
+	// Fields holds additional data about the Event, such as the cause of a
+	// death or the source of a ban.
+	Fields map[string]string `json:"fields,omitempty"`
+}
@@ -0,0 +1,189 @@
+// Package export implements the exporting of events that happen on the server, such as players joining or
+// being banned, to HTTP webhooks or other destinations such as message queues. Events are sent in batches
+// and retried if sending them fails, so that they may be used for chat bridges and moderation tooling.
+package export
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// Logger is a logger implementation that may be passed to the Log field of Config. It is used to log
+// Events that could not be exported.
+type Logger interface {
+	Errorf(format string, a ...any)
+	Debugf(format string, a ...any)
+}
+
+// Config holds the settings of an Exporter.
+type Config struct {
+	// Log is the Logger used to log Events that could not be exported. If nil, a Logrus logger is used.
+	Log Logger
+	// Targets are the Targets that Events are exported to. Each Target is sent Events independently, so that a
+	// slow Target does not hold up the others.
+	Targets []Target
+	// Types are the Types of Events that are exported. If empty, Events of all Types are exported.
+	Types []Type
+	// BatchSize is the maximum amount of Events sent to a Target at once. If 0, batches hold up to 20 Events.
+	BatchSize int
+	// FlushInterval is the interval at which batches that are not yet full are sent. If 0, batches are sent
+	// every two seconds.
+	FlushInterval time.Duration
+	// MaxRetries is the amount of times sending a batch is retried before its Events are dropped. If 0, a
+	// batch is retried 3 times. If negative, batches are not retried.
+	MaxRetries int
+	// RetryDelay is the delay before the first retry of a batch. The delay doubles with every retry. If 0, the
+	// first retry happens after one second.
+	RetryDelay time.Duration
+	// BufferSize is the maximum amount of Events queued for a Target. Events exported while the queue is
+	// full are dropped. If 0, up to 1024 Events are queued.
+	BufferSize int
+}
+
+// New creates an Exporter using the fields of conf and starts sending Events exported to it to the Targets
+// of conf. Exporter.Close must be called to send the remaining Events and stop the Exporter.
+func (conf Config) New() *Exporter {
+	if conf.Log == nil {
+		conf.Log = logrus.New()
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = 20
+	}
+	if conf.FlushInterval <= 0 {
+		conf.FlushInterval = time.Second * 2
+	}
+	if conf.MaxRetries == 0 {
+		conf.MaxRetries = 3
+	}
+	if conf.RetryDelay <= 0 {
+		conf.RetryDelay = time.Second
+	}
+	if conf.BufferSize <= 0 {
+		conf.BufferSize = 1024
+	}
+	ex := &Exporter{conf: conf, types: make(map[Type]struct{}, len(conf.Types)), closing: make(chan struct{})}
+	for _, t := range conf.Types {
+		ex.types[t] = struct{}{}
+	}
+	for _, t := range conf.Targets {
+		w := &worker{ex: ex, t: t, queue: make(chan Event, conf.BufferSize)}
+		ex.workers = append(ex.workers, w)
+		ex.wg.Add(1)
+		go w.run()
+	}
+	return ex
+}
+
+// Exporter exports Events to the Targets set in its Config. Events are queued and sent in batches, so
+// exporting an Event never blocks. Exporter is safe for concurrent use.
+type Exporter struct {
+	conf    Config
+	types   map[Type]struct{}
+	workers []*worker
+
+	once    sync.Once
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+// Export queues an Event to be sent to the Targets of the Exporter. If the Time of the Event is zero, it is
+// set to the current time. Events with a Type that is not exported are ignored, as are Events exported after
+// the Exporter is closed.
+func (ex *Exporter) Export(e Event) {
+	if _, ok := ex.types[e.Type]; !ok && len(ex.types) != 0 {
+		return
+	}
+	select {
+	case <-ex.closing:
+		return
+	default:
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	for _, w := range ex.workers {
+		select {
+		case w.queue <- e:
+		default:
+			ex.conf.Log.Errorf("export: queue full, dropping %v event", e.Type)
+		}
+	}
+}
+
+// Close sends the Events still queued to the Targets of the Exporter, without retrying them, and stops the
+// Exporter. Close blocks until all Targets have been sent their Events.
+func (ex *Exporter) Close() error {
+	ex.once.Do(func() {
+		close(ex.closing)
+	})
+	ex.wg.Wait()
+	return nil
+}
+
+// worker sends the Events queued for a single Target in batches.
+type worker struct {
+	ex    *Exporter
+	t     Target
+	queue chan Event
+	batch []Event
+}
+
+// run collects Events from the queue of the worker and sends them in batches until the Exporter is closed.
+func (w *worker) run() {
+	defer w.ex.wg.Done()
+	t := time.NewTicker(w.ex.conf.FlushInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case e := <-w.queue:
+			if w.batch = append(w.batch, e); len(w.batch) >= w.ex.conf.BatchSize {
+				w.flush()
+			}
+		case <-t.C:
+			w.flush()
+		case <-w.ex.closing:
+			for len(w.queue) > 0 {
+				if w.batch = append(w.batch, <-w.queue); len(w.batch) >= w.ex.conf.BatchSize {
+					w.flush()
+				}
+			}
+			w.flush()
+			return
+		}
+	}
+}
+
+// flush sends the current batch of the worker to its Target, retrying with an increasing delay if sending
+// fails. Once the Exporter is closing, batches are no longer retried.
+func (w *worker) flush() {
+	if len(w.batch) == 0 {
+		return
+	}
+	batch := w.batch
+	w.batch = nil
+
+	delay := w.ex.conf.RetryDelay
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		err := w.t.Send(ctx, batch)
+		cancel()
+		if err == nil {
+			return
+		}
+		if attempt >= w.ex.conf.MaxRetries {
+			w.ex.conf.Log.Errorf("export: dropping %v events after %v attempts: %v", len(batch), attempt+1, err)
+			return
+		}
+		w.ex.conf.Log.Debugf("export: retrying %v events in %v: %v", len(batch), delay, err)
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-w.ex.closing:
+			w.ex.conf.Log.Errorf("export: dropping %v events while closing: %v", len(batch), err)
+			return
+		}
+	}
+}
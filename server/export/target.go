@@ -0,0 +1,203 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Target is a destination that batches of Events are exported to, such as an
+// HTTP webhook or a message queue. Send is called from a single goroutine per
+// Target.
+type Target interface {
+	// Send sends a batch of Events to the Target. If an error is returned,
+	// the batch is sent again later, up to the amount of retries set in the
+	// Config of the Exporter.
+	Send(ctx context.Context, events []Event) error
+}
+
+// TargetFunc is a function that implements Target. It may be used to export
+// Events to a message queue or any other destination.
+type TargetFunc func(ctx context.Context, events []Event) error
+
+// Send ...
+func (f TargetFunc) Send(ctx context.Context, events []Event) error {
+	return f(ctx, events)
+}
+
+// Format encodes a batch of Events into the body of an HTTP request.
+type Format func(events []Event) ([]byte, error)
+
+// JSON is a Format that encodes a batch of Events as a JSON array of Events.
+func JSON(events []Event) ([]byte, error) {
+	return json.Marshal(events)
+}
+
+// discordMessageLimit is the maximum amount of characters in the content of
+// a Discord message.
+const discordMessageLimit = 2000
+
+// Discord is a Format that encodes a batch of Events as a message for a
+// Discord webhook, with one line per Event. Content exceeding the 2000
+// characters allowed in a Discord message is cut off. DiscordWebhook should be
+// used to send batches of any size in multiple messages instead.
+func Discord(events []Event) ([]byte, error) {
+	lines := make([]string, len(events))
+	for i, e := range events {
+		lines[i] = e.Message
+	}
+	return json.Marshal(map[string]any{
+		"content": truncate(strings.Join(lines, "\n"), discordMessageLimit),
+		// Messages may hold anything sent in the chat, so mentions of users
+		// and roles are disabled.
+		"allowed_mentions": map[string]any{"parse": []string{}},
+	})
+}
+
+// DiscordWebhook is a Target that posts batches of Events to a Discord
+// webhook. Unlike a Webhook with the Discord Format, it splits batches over
+// multiple messages so that no message exceeds the limit of 2000 characters.
+type DiscordWebhook struct {
+	// URL is the URL of the Discord webhook.
+	URL string
+	// Client is the http.Client used to send requests. If nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Send posts a batch of Events to the Discord webhook in as few messages as
+// possible. If one of the messages could not be sent, an error is returned and
+// the messages sent before it are sent again when the batch is retried.
+func (d DiscordWebhook) Send(ctx context.Context, events []Event) error {
+	w := Webhook{URL: d.URL, Format: Discord, Client: d.Client}
+	for len(events) > 0 {
+		n, size := 0, 0
+		for _, e := range events {
+			length := utf8.RuneCountInString(e.Message)
+			if n > 0 {
+				length++
+			}
+			if n > 0 && size+length > discordMessageLimit {
+				break
+			}
+			n, size = n+1, size+length
+		}
+		if err := w.Send(ctx, events[:n]); err != nil {
+			return err
+		}
+		events = events[n:]
+	}
+	return nil
+}
+
+// truncate cuts off the string passed after n characters.
+func truncate(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// Webhook is a Target that posts batches of Events to an HTTP endpoint.
+type Webhook struct {
+	// URL is the URL that Events are posted to.
+	URL string
+	// Format is the Format that batches of Events are encoded with. If nil,
+	// JSON is used.
+	Format Format
+	// Headers holds additional headers sent with every request, such as an
+	// Authorization header.
+	Headers map[string]string
+	// Client is the http.Client used to send requests. If nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+}
+
+// maxRateLimitWait is the maximum duration that a Webhook waits for when it
+// is rate limited before it gives up on sending a batch.
+const maxRateLimitWait = time.Minute
+
+// Send posts a batch of Events to the URL of the Webhook. If the endpoint
+// responds with 429 Too Many Requests, the batch is sent again after the delay
+// in its Retry-After header. An error is returned if the request failed or if
+// the response had a status code other than 2xx.
+func (w Webhook) Send(ctx context.Context, events []Event) error {
+	format, client := w.Format, w.Client
+	if format == nil {
+		format = JSON
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	body, err := format(events)
+	if err != nil {
+		return fmt.Errorf("encode events: %w", err)
+	}
+	var waited time.Duration
+	for {
+		retryAfter, err := w.post(ctx, client, body)
+		if err != nil || retryAfter == 0 {
+			return err
+		}
+		if waited += retryAfter; waited > maxRateLimitWait {
+			return fmt.Errorf("post events: rate limited for longer than %v", maxRateLimitWait)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryAfter):
+		}
+	}
+}
+
+// post posts the body passed to the URL of the Webhook. If the endpoint
+// responded with 429 Too Many Requests, the duration to wait for before
+// trying again is returned.
+func (w Webhook) post(ctx context.Context, client *http.Client, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("post events: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return retryAfter(resp.Header.Get("Retry-After")), nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return 0, fmt.Errorf("post events: unexpected status %v", resp.Status)
+	}
+	return 0, nil
+}
+
+// retryAfter parses the value of a Retry-After header, which is either an
+// amount of seconds or an HTTP date. If it could not be parsed, a delay of one
+// second is returned.
+func retryAfter(v string) time.Duration {
+	if secs, err := strconv.ParseFloat(v, 64); err == nil && secs > 0 {
+		return time.Duration(secs * float64(time.Second))
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return time.Second
+}
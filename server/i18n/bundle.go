@@ -0,0 +1,128 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// Bundle holds server-side messages in multiple languages. Messages are identified by a key and may contain
+// formatting verbs, which are filled out according to the rules of fmt.Sprintf when the message is translated.
+// Methods on Bundle are safe for simultaneous use from multiple goroutines.
+type Bundle struct {
+	fallback language.Tag
+
+	mu       sync.RWMutex
+	tags     []language.Tag
+	messages map[language.Tag]map[string]string
+	matcher  language.Matcher
+}
+
+// NewBundle returns a new, empty Bundle. Messages that are not available in the language of a player are
+// translated using the fallback language passed.
+func NewBundle(fallback language.Tag) *Bundle {
+	b := &Bundle{fallback: fallback, messages: map[language.Tag]map[string]string{}}
+	b.addLanguage(fallback)
+	return b
+}
+
+// Add adds a message with the key passed in the language passed, overwriting the message if it already
+// existed.
+func (b *Bundle) Add(lang language.Tag, key, message string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addLanguage(lang)
+	b.messages[lang][key] = message
+}
+
+// AddMessages adds all messages in the map passed in the language passed, with the keys of the map as the keys
+// of the messages.
+func (b *Bundle) AddMessages(lang language.Tag, messages map[string]string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addLanguage(lang)
+	for key, message := range messages {
+		b.messages[lang][key] = message
+	}
+}
+
+// LoadFile loads the messages of a JSON file with a key-message object into the Bundle in the language passed.
+func (b *Bundle) LoadFile(lang language.Tag, file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("load language file: %w", err)
+	}
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return fmt.Errorf("decode language file %v: %w", file, err)
+	}
+	b.AddMessages(lang, messages)
+	return nil
+}
+
+// LoadDir loads all JSON files in the directory passed into the Bundle. The name of every file, without the
+// .json extension, is parsed as the language of its messages, such as en_US.json or nl-NL.json.
+func (b *Bundle) LoadDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("load language directory: %w", err)
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		lang, err := language.Parse(strings.ReplaceAll(name, "_", "-"))
+		if err != nil {
+			return fmt.Errorf("parse language of file %v: %w", file, err)
+		}
+		if err := b.LoadFile(lang, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Languages returns all languages that the Bundle holds messages in.
+func (b *Bundle) Languages() []language.Tag {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]language.Tag(nil), b.tags...)
+}
+
+// Translate translates the message with the key passed to the language that best matches the language passed.
+// If the message does not exist in that language, the message in the fallback language of the Bundle is used.
+// If it does not exist in the fallback language either, the key itself is returned. The args passed are
+// formatted into the message according to the rules of fmt.Sprintf.
+func (b *Bundle) Translate(lang language.Tag, key string, args ...any) string {
+	b.mu.RLock()
+	_, i, _ := b.matcher.Match(lang)
+	message, ok := b.messages[b.tags[i]][key]
+	if !ok {
+		message, ok = b.messages[b.fallback][key]
+	}
+	b.mu.RUnlock()
+
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// addLanguage adds a language to the Bundle if it did not yet exist. b.mu must be held when addLanguage is
+// called.
+func (b *Bundle) addLanguage(lang language.Tag) {
+	if _, ok := b.messages[lang]; ok {
+		return
+	}
+	b.messages[lang] = map[string]string{}
+	// The fallback language is always the first tag, so that the matcher falls back to it if no other
+	// language matches.
+	b.tags = append(b.tags, lang)
+	b.matcher = language.NewMatcher(b.tags)
+}
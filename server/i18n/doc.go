@@ -0,0 +1,4 @@
+// Package i18n implements the translation of messages sent to players. Messages may either be translated by
+// the client itself, using the translation keys built into the game, or by the server, using a Bundle of
+// messages for every language supported.
+package i18n
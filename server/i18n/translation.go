@@ -0,0 +1,25 @@
+package i18n
+
+// Translation is a message that is translated by the client, using the translation keys built into the game,
+// such as 'death.attack.generic'. The client translates the message to the language it has selected in its
+// settings.
+type Translation struct {
+	key    string
+	params []string
+}
+
+// Translate returns a Translation for the translation key passed. The params passed are filled out in the
+// message, replacing %s or %1$s style placeholders in the translated message.
+func Translate(key string, params ...string) Translation {
+	return Translation{key: key, params: params}
+}
+
+// Key returns the translation key of the Translation.
+func (t Translation) Key() string {
+	return t.key
+}
+
+// Params returns the parameters filled out in the translated message.
+func (t Translation) Params() []string {
+	return t.params
+}
@@ -1,9 +1,40 @@
 package nbtconv
 
 import (
+	"bytes"
+	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"sync"
 )
 
+// itemCopy holds the map and buffer that CopyItem encodes an item stack into. These are reused between calls.
+type itemCopy struct {
+	data map[string]any
+	buf  *bytes.Buffer
+}
+
+// itemCopyPool pools the itemCopy values used by CopyItem.
+var itemCopyPool = sync.Pool{
+	New: func() any {
+		return &itemCopy{data: make(map[string]any, 6), buf: new(bytes.Buffer)}
+	},
+}
+
+// CopyItem returns a deep copy of the item.Stack passed by encoding it to NBT and decoding it again. Unlike
+// passing the result of WriteItem to Item, CopyItem reuses the map and buffer it encodes the stack into.
+func CopyItem(s item.Stack) item.Stack {
+	c := itemCopyPool.Get().(*itemCopy)
+	writeItemStack(c.data, writeItemTag(s, true, c.buf), s)
+	cp := Item(c.data, nil)
+
+	for k := range c.data {
+		delete(c.data, k)
+	}
+	c.buf.Reset()
+	itemCopyPool.Put(c)
+	return cp
+}
+
 // InvFromNBT decodes the data of an NBT slice into the inventory passed.
 func InvFromNBT(inv *inventory.Inventory, items []any) {
 	for _, itemData := range items {
@@ -86,6 +86,20 @@ func Slice(m map[string]any, k string) []any {
 	return v
 }
 
+// Strings reads a []string from a map at a specific key. Lists of strings decoded as []any are converted.
+func Strings(m map[string]any, k string) []string {
+	if v, ok := m[k].([]string); ok {
+		return v
+	}
+	var list []string
+	for _, v := range Slice(m, k) {
+		if str, ok := v.(string); ok {
+			list = append(list, str)
+		}
+	}
+	return list
+}
+
 // Vec3 converts x, y and z values in an NBT map to an mgl64.Vec3.
 func Vec3(x map[string]any, k string) mgl64.Vec3 {
 	if i, ok := x[k].([]any); ok {
@@ -205,7 +219,7 @@ func readItemStack(m, t map[string]any) item.Stack {
 	if n, ok := it.(world.NBTer); ok {
 		it = n.DecodeNBT(t).(world.Item)
 	}
-	return item.NewStack(it, int(Uint8(m, "Count")))
+	return item.NewStack(it, int(Uint8(m, "Count"))).WithCanPlaceOn(Strings(m, "CanPlaceOn")...).WithCanDestroy(Strings(m, "CanDestroy")...)
 }
 
 // readDamage reads the damage value stored in the NBT with the Damage tag and saves it to the item.Stack passed.
@@ -11,6 +11,18 @@ import (
 
 // WriteItem encodes an item stack into a map that can be encoded using NBT.
 func WriteItem(s item.Stack, disk bool) map[string]any {
+	tag := writeItemTag(s, disk, nil)
+	if !disk {
+		return tag
+	}
+	data := make(map[string]any, 4)
+	writeItemStack(data, tag, s)
+	return data
+}
+
+// writeItemTag encodes the tag of an item stack into a new map, either for disk or for network purposes. If not
+// nil, buf is used to encode the values set to the item stack.
+func writeItemTag(s item.Stack, disk bool, buf *bytes.Buffer) map[string]any {
 	tag := make(map[string]any)
 	if nbt, ok := s.Item().(world.NBTer); ok {
 		for k, v := range nbt.EncodeNBT() {
@@ -20,18 +32,9 @@ func WriteItem(s item.Stack, disk bool) map[string]any {
 	writeAnvilCost(tag, s)
 	writeDamage(tag, s, disk)
 	writeDisplay(tag, s)
-	writeDragonflyData(tag, s)
+	writeDragonflyData(tag, s, buf)
 	writeEnchantments(tag, s)
-
-	data := make(map[string]any)
-	if disk {
-		writeItemStack(data, tag, s)
-	} else {
-		for k, v := range tag {
-			data[k] = v
-		}
-	}
-	return data
+	return tag
 }
 
 // WriteBlock encodes a world.Block into a map that can be encoded using NBT.
@@ -53,6 +56,12 @@ func writeItemStack(m, t map[string]any, s item.Stack) {
 		m["Block"] = v
 	}
 	m["Count"] = byte(s.Count())
+	if canPlaceOn := s.CanPlaceOn(); len(canPlaceOn) != 0 {
+		m["CanPlaceOn"] = canPlaceOn
+	}
+	if canDestroy := s.CanDestroy(); len(canDestroy) != 0 {
+		m["CanDestroy"] = canDestroy
+	}
 	if len(t) > 0 {
 		m["tag"] = t
 	}
@@ -64,15 +73,19 @@ func writeBlock(m map[string]any, b world.Block) {
 	m["version"] = chunk.CurrentBlockVersion
 }
 
-// writeDragonflyData writes additional data associated with an item.Stack to a map for NBT encoding.
-func writeDragonflyData(m map[string]any, s item.Stack) {
-	if v := s.Values(); len(v) != 0 {
-		buf := new(bytes.Buffer)
-		if err := gob.NewEncoder(buf).Encode(mapToSlice(v)); err != nil {
-			panic("error encoding item user data: " + err.Error())
-		}
-		m["dragonflyData"] = buf.Bytes()
+// writeDragonflyData writes additional data associated with an item.Stack to a map for NBT encoding. If not nil,
+// buf is used to encode the data.
+func writeDragonflyData(m map[string]any, s item.Stack, buf *bytes.Buffer) {
+	if !s.HasValues() {
+		return
+	}
+	if buf == nil {
+		buf = new(bytes.Buffer)
+	}
+	if err := gob.NewEncoder(buf).Encode(mapToSlice(s.Values())); err != nil {
+		panic("error encoding item user data: " + err.Error())
 	}
+	m["dragonflyData"] = buf.Bytes()
 }
 
 // mapToSlice converts a map to a slice of the type mapValue and orders the slice by the keys in the map to ensure a
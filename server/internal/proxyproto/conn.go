@@ -0,0 +1,177 @@
+// Package proxyproto implements the receiving side of version 2 of the PROXY protocol for datagram connections,
+// used by proxies to pass on the address of the client they are proxying.
+package proxyproto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// signature is the signature that every version 2 PROXY protocol header starts with.
+var signature = []byte{0x0d, 0x0a, 0x0d, 0x0a, 0x00, 0x0d, 0x0a, 0x51, 0x55, 0x49, 0x54, 0x0a}
+
+const (
+	// headerLen is the length of the fixed part of a version 2 header, excluding the addresses.
+	headerLen = 16
+	// cmdProxy is the version and command byte of a header that carries addresses.
+	cmdProxy = 0x21
+	// familyUDP4 and familyUDP6 are the address families of headers carrying IPv4 and IPv6 UDP addresses.
+	familyUDP4, familyUDP6 = 0x12, 0x22
+)
+
+// mappingTimeout is the duration after which the client associated with a proxy address is forgotten if no
+// datagrams were received from the proxy address.
+const mappingTimeout = time.Minute
+
+// Conn wraps around a net.PacketConn to strip PROXY protocol headers from incoming datagrams. Datagrams are
+// reported to originate from the client address found in the header, and datagrams written to a client
+// address are sent to the proxy that the client connected through. Proxies that only send a header with the
+// first datagram of a client are supported too. The client associated with a proxy address is remembered until
+// the proxy sends a header with a different client for that address, or until no datagrams were received from
+// the proxy address for a minute.
+type Conn struct {
+	net.PacketConn
+	trusted func(addr net.Addr) bool
+
+	mu        sync.RWMutex
+	clients   map[string]*mapping
+	proxies   map[string]*mapping
+	lastSweep time.Time
+}
+
+// mapping associates the address of a client with the address of the proxy it is connected through.
+type mapping struct {
+	client, proxy net.Addr
+	// last is the time in Unix nanoseconds at which a datagram was last received for the mapping.
+	last atomic.Int64
+}
+
+// Wrap wraps the net.PacketConn passed. Headers are only accepted from addresses for which trusted returns true.
+// Datagrams from other addresses are passed on unchanged. If trusted is nil, headers from any address are
+// accepted.
+func Wrap(conn net.PacketConn, trusted func(addr net.Addr) bool) *Conn {
+	if trusted == nil {
+		trusted = func(net.Addr) bool { return true }
+	}
+	return &Conn{PacketConn: conn, trusted: trusted, clients: map[string]*mapping{}, proxies: map[string]*mapping{}, lastSweep: time.Now()}
+}
+
+// ReadFrom reads a datagram into b, stripping the PROXY protocol header if present, and returns the address of
+// the client it originates from.
+func (c *Conn) ReadFrom(b []byte) (int, net.Addr, error) {
+	for {
+		n, addr, err := c.PacketConn.ReadFrom(b)
+		if err != nil || !c.trusted(addr) {
+			return n, addr, err
+		}
+		c.sweep()
+		if !bytes.HasPrefix(b[:n], signature) {
+			c.mu.RLock()
+			m, ok := c.clients[addr.String()]
+			c.mu.RUnlock()
+			if ok {
+				m.last.Store(time.Now().UnixNano())
+				return n, m.client, nil
+			}
+			return n, addr, nil
+		}
+		client, l, err := parseHeader(b[:n])
+		if err != nil {
+			// Drop malformed headers rather than passing them on as game data.
+			continue
+		}
+		if client != nil {
+			m := &mapping{client: client, proxy: addr}
+			m.last.Store(time.Now().UnixNano())
+			c.mu.Lock()
+			if old, ok := c.clients[addr.String()]; ok {
+				c.removeProxy(old)
+			}
+			c.clients[addr.String()], c.proxies[client.String()] = m, m
+			c.mu.Unlock()
+		} else {
+			client = addr
+		}
+		if l == n {
+			// Some proxies send the header in a datagram of its own.
+			continue
+		}
+		return copy(b, b[l:n]), client, nil
+	}
+}
+
+// WriteTo writes the datagram b to the client address passed, sending it to the proxy that the client is
+// connected through, if any.
+func (c *Conn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	c.mu.RLock()
+	m, ok := c.proxies[addr.String()]
+	c.mu.RUnlock()
+	if ok {
+		addr = m.proxy
+	}
+	return c.PacketConn.WriteTo(b, addr)
+}
+
+// sweep removes all mappings for which no datagram was received in the last mappingTimeout. sweep only checks
+// the mappings once every half mappingTimeout.
+func (c *Conn) sweep() {
+	now := time.Now()
+	c.mu.RLock()
+	due := now.Sub(c.lastSweep) >= mappingTimeout/2
+	c.mu.RUnlock()
+	if !due {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastSweep = now
+	for k, m := range c.clients {
+		if now.Sub(time.Unix(0, m.last.Load())) >= mappingTimeout {
+			delete(c.clients, k)
+			c.removeProxy(m)
+		}
+	}
+}
+
+// removeProxy removes the proxy of the client of the mapping passed, unless the client has since connected
+// through another proxy. c.mu must be locked when removeProxy is called.
+func (c *Conn) removeProxy(m *mapping) {
+	if c.proxies[m.client.String()] == m {
+		delete(c.proxies, m.client.String())
+	}
+}
+
+// parseHeader parses the version 2 PROXY protocol header at the start of b. It returns the client address found
+// in the header and the total length of the header. A nil address is returned for headers that do not carry a
+// UDP address, such as those sent for health checks.
+func parseHeader(b []byte) (net.Addr, int, error) {
+	if len(b) < headerLen {
+		return nil, 0, errors.New("proxy protocol header too short")
+	}
+	l := headerLen + int(binary.BigEndian.Uint16(b[14:16]))
+	if len(b) < l {
+		return nil, 0, errors.New("proxy protocol header length exceeds datagram")
+	}
+	if b[12] != cmdProxy {
+		return nil, l, nil
+	}
+	addr := b[headerLen:l]
+	switch b[13] {
+	case familyUDP4:
+		if len(addr) < 12 {
+			return nil, 0, errors.New("proxy protocol ipv4 address too short")
+		}
+		return &net.UDPAddr{IP: net.IPv4(addr[0], addr[1], addr[2], addr[3]), Port: int(binary.BigEndian.Uint16(addr[8:10]))}, l, nil
+	case familyUDP6:
+		if len(addr) < 36 {
+			return nil, 0, errors.New("proxy protocol ipv6 address too short")
+		}
+		return &net.UDPAddr{IP: append(net.IP{}, addr[:16]...), Port: int(binary.BigEndian.Uint16(addr[32:34]))}, l, nil
+	}
+	return nil, l, nil
+}
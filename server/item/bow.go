@@ -3,6 +3,7 @@ package item
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"math"
 	"time"
@@ -95,6 +96,7 @@ func (Bow) Release(releaser Releaser, duration time.Duration, ctx *UseContext) {
 
 	releaser.PlaySound(sound.BowShoot{})
 	releaser.World().AddEntity(projectile)
+	releaser.World().EmitGameEvent(releaser.Position(), gameevent.ProjectileShoot{}, releaser)
 }
 
 // EnchantmentValue ...
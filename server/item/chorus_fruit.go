@@ -0,0 +1,96 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ChorusFruit is a food item obtained from chorus plants. Eating it teleports the consumer to a random safe
+// position nearby.
+type ChorusFruit struct {
+	defaultFood
+}
+
+// AlwaysConsumable ...
+func (ChorusFruit) AlwaysConsumable() bool {
+	return true
+}
+
+// Consume ...
+func (ChorusFruit) Consume(w *world.World, c Consumer) Stack {
+	c.Saturate(4, 2.4)
+	if cd, ok := c.(interface {
+		SetCooldown(item world.Item, cooldown time.Duration)
+	}); ok {
+		cd.SetCooldown(ChorusFruit{}, time.Second)
+	}
+
+	t, ok := c.(interface{ Teleport(pos mgl64.Vec3) })
+	if !ok {
+		return Stack{}
+	}
+	origin := c.Position()
+	for i := 0; i < 16; i++ {
+		pos, ok := chorusFruitDestination(w, origin)
+		if !ok {
+			continue
+		}
+		w.PlaySound(origin, sound.Teleport{})
+		t.Teleport(pos)
+		if c.Position() == pos {
+			// The teleport was not cancelled, so play the sound at the destination too.
+			w.PlaySound(pos, sound.Teleport{})
+		}
+		break
+	}
+	return Stack{}
+}
+
+// chorusFruitDestination attempts to find a safe position to teleport to within 8 blocks of the origin passed.
+// The position is moved down until a block is found to stand on. False is returned if no safe position was
+// found.
+func chorusFruitDestination(w *world.World, origin mgl64.Vec3) (mgl64.Vec3, bool) {
+	r := w.Range()
+	pos := cube.PosFromVec3(mgl64.Vec3{
+		origin[0] + (rand.Float64()-0.5)*16,
+		math.Max(float64(r[0]), math.Min(float64(r[1]), math.Floor(origin[1])+float64(rand.Intn(16)-8))),
+		origin[2] + (rand.Float64()-0.5)*16,
+	})
+	for ; pos[1] > r[0]; pos[1]-- {
+		below := pos.Side(cube.FaceDown)
+		if !w.Block(below).Model().FaceSolid(below, cube.FaceUp, w) {
+			continue
+		}
+		// Make sure the entity fits at the position and does not end up in a liquid.
+		for _, p := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+			if len(w.Block(p).Model().BBox(p, w)) != 0 {
+				return mgl64.Vec3{}, false
+			}
+			if _, ok := w.Liquid(p); ok {
+				return mgl64.Vec3{}, false
+			}
+		}
+		return pos.Vec3Middle(), true
+	}
+	return mgl64.Vec3{}, false
+}
+
+// CompostChance ...
+func (ChorusFruit) CompostChance() float64 {
+	return 0.65
+}
+
+// SmeltInfo ...
+func (ChorusFruit) SmeltInfo() SmeltInfo {
+	return newSmeltInfo(NewStack(PoppedChorusFruit{}, 1), 0.1)
+}
+
+// EncodeItem ...
+func (ChorusFruit) EncodeItem() (name string, meta int16) {
+	return "minecraft:chorus_fruit", 0
+}
@@ -1,6 +1,8 @@
 package item
 
-// Compass is an item used to find the spawn position of a world.
+// Compass is an item used to find the spawn position of a world. A Compass points to the spawn of the world that
+// its holder is in, as set using World.SetSpawn. In dimensions without a spawn, such as the nether and the end, a
+// Compass spins randomly.
 type Compass struct{}
 
 // EncodeItem ...
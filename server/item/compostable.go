@@ -0,0 +1,45 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+)
+
+var (
+	compostMu sync.RWMutex
+	// compostables holds the compost chances of items registered using RegisterCompostable, indexed by their
+	// name and metadata value.
+	compostables = map[compostKey]float64{}
+)
+
+// compostKey identifies an item registered using RegisterCompostable.
+type compostKey struct {
+	name string
+	meta int16
+}
+
+// RegisterCompostable registers an item that does not implement Compostable, such as a custom item, so that it
+// may be used to fill up a composter. The chance passed is the chance in the range of 0-1 that the item produces
+// a layer of compost. Registering an item again replaces its chance.
+func RegisterCompostable(it world.Item, chance float64) {
+	name, meta := it.EncodeItem()
+
+	compostMu.Lock()
+	defer compostMu.Unlock()
+	compostables[compostKey{name: name, meta: meta}] = chance
+}
+
+// CompostChance returns the chance that the item passed produces a layer of compost when put into a composter.
+// Items implementing Compostable return their own chance, while other items return the chance they were
+// registered with using RegisterCompostable. False is returned if the item cannot be composted.
+func CompostChance(it world.Item) (float64, bool) {
+	if c, ok := it.(Compostable); ok {
+		return c.CompostChance(), true
+	}
+	name, meta := it.EncodeItem()
+
+	compostMu.RLock()
+	defer compostMu.RUnlock()
+	chance, ok := compostables[compostKey{name: name, meta: meta}]
+	return chance, ok
+}
@@ -0,0 +1,176 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// Crossbow is a ranged weapon similar to a bow that uses arrows or fireworks as ammunition. Unlike a bow, a
+// crossbow is charged by holding it and fired by using it once charged.
+type Crossbow struct {
+	// Item is the item the crossbow is charged with. If Item is empty, the crossbow is not charged.
+	Item Stack
+}
+
+// MaxCount always returns 1.
+func (Crossbow) MaxCount() int {
+	return 1
+}
+
+// DurabilityInfo ...
+func (Crossbow) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 464,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// FuelInfo ...
+func (Crossbow) FuelInfo() FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// EnchantmentValue ...
+func (Crossbow) EnchantmentValue() int {
+	return 1
+}
+
+// Charged checks if the crossbow is charged with an arrow or firework.
+func (c Crossbow) Charged() bool {
+	return !c.Item.Empty()
+}
+
+// chargeDuration returns the duration the crossbow passed must be held to charge it, taking the quick charge
+// enchantment into account.
+func (Crossbow) chargeDuration(held Stack) time.Duration {
+	for _, enchant := range held.Enchantments() {
+		if q, ok := enchant.Type().(interface{ ChargeDuration(level int) time.Duration }); ok {
+			return q.ChargeDuration(enchant.Level())
+		}
+	}
+	return time.Second * 5 / 4
+}
+
+// Release charges the crossbow if it was held for long enough. A firework held in the off hand is used as
+// ammunition if present. Otherwise, the first arrow found in the inventory is used.
+func (c Crossbow) Release(releaser Releaser, duration time.Duration, ctx *UseContext) {
+	held, left := releaser.HeldItems()
+	if c.Charged() || duration < c.chargeDuration(held) {
+		return
+	}
+	creative := releaser.GameMode().CreativeInventory()
+
+	if _, ok := left.Item().(Firework); ok {
+		c.Item = left.Grow(1 - left.Count())
+		if !creative {
+			left = left.Grow(-1)
+		}
+	} else {
+		arrow, ok := ctx.FirstFunc(func(stack Stack) bool {
+			_, ok := stack.Item().(Arrow)
+			return ok
+		})
+		if !ok && !creative {
+			// No arrows in inventory and not in creative mode.
+			return
+		}
+		if arrow.Empty() {
+			arrow = NewStack(Arrow{}, 1)
+		}
+		c.Item = arrow.Grow(1 - arrow.Count())
+		if !creative {
+			ctx.Consume(c.Item)
+		}
+	}
+
+	releaser.SetHeldItems(held.WithItem(c), left)
+	releaser.PlaySound(sound.CrossbowLoad{})
+}
+
+// Use fires the item that the crossbow is charged with, if any.
+func (c Crossbow) Use(w *world.World, user User, ctx *UseContext) bool {
+	if !c.Charged() {
+		return false
+	}
+	held, left := user.HeldItems()
+	creative := false
+	if g, ok := user.(interface{ GameMode() world.GameMode }); ok {
+		creative = g.GameMode().CreativeInventory()
+	}
+
+	rot := user.Rotation()
+	rot = cube.Rotation{-rot[0], -rot[1]}
+	if rot[0] > 180 {
+		rot[0] = 360 - rot[0]
+	}
+	pos, vel := eyePosition(user), user.Rotation().Vec3().Mul(3.15)
+
+	var projectile world.Entity
+	switch it := c.Item.Item().(type) {
+	case Firework:
+		create := w.EntityRegistry().Config().Firework
+		projectile = create(pos, rot, false, it, user)
+		if v, ok := projectile.(interface{ SetVelocity(vel mgl64.Vec3) }); ok {
+			v.SetVelocity(vel.Mul(0.5))
+		}
+	case Arrow:
+		create := w.EntityRegistry().Config().Arrow
+		projectile = create(pos, vel, rot, 2.0, user, false, false, !creative, 0, it.Tip)
+	default:
+		return false
+	}
+
+	ctx.DamageItem(1)
+	c.Item = Stack{}
+	user.SetHeldItems(held.WithItem(c), left)
+	w.PlaySound(user.Position(), sound.CrossbowShoot{})
+	w.AddEntity(projectile)
+	w.EmitGameEvent(user.Position(), gameevent.ProjectileShoot{}, user)
+	return true
+}
+
+// Requirements always returns nil. Because a crossbow may be charged with either arrows or fireworks, its
+// ammunition is looked up when it is released.
+func (Crossbow) Requirements() []Stack {
+	return nil
+}
+
+// EncodeItem ...
+func (Crossbow) EncodeItem() (name string, meta int16) {
+	return "minecraft:crossbow", 0
+}
+
+// DecodeNBT ...
+func (c Crossbow) DecodeNBT(data map[string]any) any {
+	c.Item = Stack{}
+	if m, ok := data["chargedItem"].(map[string]any); ok {
+		name, _ := m["Name"].(string)
+		meta, _ := m["Damage"].(int16)
+		if it, ok := world.ItemByName(name, meta); ok {
+			if nbt, ok := it.(world.NBTer); ok {
+				if tag, ok := m["tag"].(map[string]any); ok {
+					it = nbt.DecodeNBT(tag).(world.Item)
+				}
+			}
+			c.Item = NewStack(it, 1)
+		}
+	}
+	return c
+}
+
+// EncodeNBT ...
+func (c Crossbow) EncodeNBT() map[string]any {
+	if !c.Charged() {
+		return nil
+	}
+	name, meta := c.Item.Item().EncodeItem()
+	m := map[string]any{"Name": name, "Damage": meta, "Count": byte(1)}
+	if nbt, ok := c.Item.Item().(world.NBTer); ok {
+		m["tag"] = nbt.EncodeNBT()
+	}
+	return map[string]any{"chargedItem": m}
+}
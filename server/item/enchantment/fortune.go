@@ -0,0 +1,43 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Fortune is an enchantment that increases the amount of items dropped by some blocks, such as crops, when they are
+// broken.
+type Fortune struct{}
+
+// Name ...
+func (Fortune) Name() string {
+	return "Fortune"
+}
+
+// MaxLevel ...
+func (Fortune) MaxLevel() int {
+	return 3
+}
+
+// Cost ...
+func (Fortune) Cost(level int) (int, int) {
+	min := 15 + (level-1)*9
+	return min, min + 50
+}
+
+// Rarity ...
+func (Fortune) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// CompatibleWithEnchantment ...
+func (Fortune) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	_, silkTouch := t.(SilkTouch)
+	return !silkTouch
+}
+
+// CompatibleWithItem ...
+func (Fortune) CompatibleWithItem(i world.Item) bool {
+	t, ok := i.(item.Tool)
+	return ok && (t.ToolType() != item.TypeSword && t.ToolType() != item.TypeNone)
+}
@@ -0,0 +1,47 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Impaling is a trident enchantment that increases the damage dealt to entities that are in water or rain.
+type Impaling struct{}
+
+// Name ...
+func (Impaling) Name() string {
+	return "Impaling"
+}
+
+// MaxLevel ...
+func (Impaling) MaxLevel() int {
+	return 5
+}
+
+// Cost ...
+func (Impaling) Cost(level int) (int, int) {
+	min := 1 + (level-1)*8
+	return min, min + 20
+}
+
+// Rarity ...
+func (Impaling) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// Damage returns the additional damage dealt by a trident with the impaling level passed to an entity that is in
+// water or rain.
+func (Impaling) Damage(level int) float64 {
+	return float64(level) * 2.5
+}
+
+// CompatibleWithEnchantment ...
+func (Impaling) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	return true
+}
+
+// CompatibleWithItem ...
+func (Impaling) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.Trident)
+	return ok
+}
@@ -0,0 +1,47 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Loyalty is a trident enchantment that makes a thrown trident return to its thrower after hitting an entity or block.
+type Loyalty struct{}
+
+// Name ...
+func (Loyalty) Name() string {
+	return "Loyalty"
+}
+
+// MaxLevel ...
+func (Loyalty) MaxLevel() int {
+	return 3
+}
+
+// Cost ...
+func (Loyalty) Cost(level int) (int, int) {
+	min := 5 + level*7
+	return min, 50
+}
+
+// Rarity ...
+func (Loyalty) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityUncommon
+}
+
+// ReturnSpeed returns the speed with which a thrown trident with the loyalty level passed returns to its thrower.
+func (Loyalty) ReturnSpeed(level int) float64 {
+	return float64(level) * 0.05
+}
+
+// CompatibleWithEnchantment ...
+func (Loyalty) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	_, riptide := t.(Riptide)
+	return !riptide
+}
+
+// CompatibleWithItem ...
+func (Loyalty) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.Trident)
+	return ok
+}
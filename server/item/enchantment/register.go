@@ -21,24 +21,24 @@ func init() {
 	item.RegisterEnchantment(15, Efficiency{})
 	item.RegisterEnchantment(16, SilkTouch{})
 	item.RegisterEnchantment(17, Unbreaking{})
-	// TODO: (18) Fortune.
+	item.RegisterEnchantment(18, Fortune{})
 	item.RegisterEnchantment(19, Power{})
 	item.RegisterEnchantment(20, Punch{})
 	item.RegisterEnchantment(21, Flame{})
 	item.RegisterEnchantment(22, Infinity{})
 	// TODO: (23) Luck of the Sea.
 	// TODO: (24) Lure.
-	// TODO: (25) Frost Walker.
+	item.RegisterEnchantment(25, FrostWalker{})
 	item.RegisterEnchantment(26, Mending{})
 	// TODO: (27) Curse of Binding.
 	item.RegisterEnchantment(28, CurseOfVanishing{})
-	// TODO: (29) Impaling.
-	// TODO: (30) Riptide.
-	// TODO: (31) Loyalty.
-	// TODO: (32) Channeling.
+	item.RegisterEnchantment(29, Impaling{})
+	item.RegisterEnchantment(30, Riptide{})
+	item.RegisterEnchantment(31, Loyalty{})
+	item.RegisterEnchantment(32, Channeling{})
 	// TODO: (33) Multishot.
 	// TODO: (34) Piercing.
-	// TODO: (35) Quick Charge.
+	item.RegisterEnchantment(35, QuickCharge{})
 	item.RegisterEnchantment(36, SoulSpeed{})
 	item.RegisterEnchantment(37, SwiftSneak{})
 }
@@ -0,0 +1,50 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Riptide is a trident enchantment that launches the player when the trident is released while it is in water or rain,
+// instead of throwing the trident.
+type Riptide struct{}
+
+// Name ...
+func (Riptide) Name() string {
+	return "Riptide"
+}
+
+// MaxLevel ...
+func (Riptide) MaxLevel() int {
+	return 3
+}
+
+// Cost ...
+func (Riptide) Cost(level int) (int, int) {
+	min := 10 + level*7
+	return min, 50
+}
+
+// Rarity ...
+func (Riptide) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// RiptideForce returns the velocity with which a player is launched when releasing a trident with the riptide level
+// passed.
+func (Riptide) RiptideForce(level int) float64 {
+	return 3 * (1 + float64(level)) / 4
+}
+
+// CompatibleWithEnchantment ...
+func (Riptide) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	_, loyalty := t.(Loyalty)
+	_, channeling := t.(Channeling)
+	return !loyalty && !channeling
+}
+
+// CompatibleWithItem ...
+func (Riptide) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.Trident)
+	return ok
+}
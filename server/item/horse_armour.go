@@ -0,0 +1,38 @@
+package item
+
+// HorseArmour is an item that can be equipped by horses to protect them from damage. Horse armour exists in the
+// leather, gold, iron and diamond ArmourTier.
+type HorseArmour struct {
+	// Tier is the tier of the horse armour.
+	Tier ArmourTier
+}
+
+// MaxCount ...
+func (HorseArmour) MaxCount() int {
+	return 1
+}
+
+// DefencePoints returns the defence points provided by the horse armour.
+func (h HorseArmour) DefencePoints() float64 {
+	switch h.Tier.(type) {
+	case ArmourTierLeather:
+		return 3
+	case ArmourTierGold:
+		return 7
+	case ArmourTierIron:
+		return 5
+	case ArmourTierDiamond:
+		return 11
+	}
+	panic("invalid horse armour tier")
+}
+
+// EncodeItem ...
+func (h HorseArmour) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + h.Tier.Name() + "_horse_armor", 0
+}
+
+// horseArmourTiers returns the ArmourTiers that horse armour exists in.
+func horseArmourTiers() []ArmourTier {
+	return []ArmourTier{ArmourTierLeather{}, ArmourTierGold{}, ArmourTierIron{}, ArmourTierDiamond{}}
+}
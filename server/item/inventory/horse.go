@@ -0,0 +1,37 @@
+package inventory
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+const (
+	// HorseSaddleSlot is the slot of a horse inventory that holds the saddle of the horse.
+	HorseSaddleSlot = iota
+	// HorseArmourSlot is the slot of a horse inventory that holds the armour of the horse.
+	HorseArmourSlot
+)
+
+// NewHorse returns an inventory for the equipment of a horse. It has two slots: One for a saddle and one for
+// horse armour. Only saddles and horse armour may be placed in these slots respectively.
+// The function passed is called when a slot is changed. It may be nil to not call anything.
+func NewHorse(f func(slot int, before, after item.Stack)) *Inventory {
+	inv := New(2, f)
+	inv.canAdd = canAddHorseEquipment
+	return inv
+}
+
+// canAddHorseEquipment checks if the item passed can be equipped by a horse in the slot passed.
+func canAddHorseEquipment(s item.Stack, slot int) bool {
+	if s.Empty() {
+		return true
+	}
+	switch slot {
+	case HorseSaddleSlot:
+		_, ok := s.Item().(item.Saddle)
+		return ok
+	case HorseArmourSlot:
+		_, ok := s.Item().(item.HorseArmour)
+		return ok
+	}
+	return false
+}
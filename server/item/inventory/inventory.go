@@ -3,6 +3,7 @@ package inventory
 import (
 	"errors"
 	"fmt"
+	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
 	"golang.org/x/exp/slices"
 	"math"
@@ -20,8 +21,18 @@ type Inventory struct {
 	h     Handler
 	slots []item.Stack
 
-	f      func(slot int, before, after item.Stack)
-	canAdd func(s item.Stack, slot int) bool
+	changes *event.Bus[SlotChange]
+	canAdd  func(s item.Stack, slot int) bool
+}
+
+// SlotChange is the event dispatched by the Bus returned by Inventory.Changes every time a slot of the
+// Inventory is changed. The change has already taken place when it is dispatched, so cancelling it has no
+// effect.
+type SlotChange struct {
+	// Slot is the slot that was changed.
+	Slot int
+	// Before and After are the item.Stack in the slot before and after the change.
+	Before, After item.Stack
 }
 
 // ErrSlotOutOfRange is returned by any methods on inventory when a slot is passed which is not within the
@@ -30,16 +41,19 @@ var ErrSlotOutOfRange = errors.New("slot is out of range: must be in range 0 <=
 
 // New creates a new inventory with the size passed. The inventory size cannot be changed after it has been
 // constructed.
-// A function may be passed which is called every time a slot is changed. The function may also be nil, if
-// nothing needs to be done.
+// A function may be passed which is called every time a slot is changed. It is subscribed to the Bus returned
+// by Changes with event.PriorityLowest. The function may also be nil, if nothing needs to be done.
 func New(size int, f func(slot int, before, after item.Stack)) *Inventory {
 	if size <= 0 {
 		panic("inventory size must be at least 1")
 	}
-	if f == nil {
-		f = func(slot int, before, after item.Stack) {}
+	inv := &Inventory{h: NopHandler{}, slots: make([]item.Stack, size), changes: &event.Bus[SlotChange]{}, canAdd: func(s item.Stack, slot int) bool { return true }}
+	if f != nil {
+		inv.changes.Subscribe(event.PriorityLowest, func(_ *event.Context, c SlotChange) {
+			f(c.Slot, c.Before, c.After)
+		})
 	}
-	return &Inventory{h: NopHandler{}, slots: make([]item.Stack, size), f: f, canAdd: func(s item.Stack, slot int) bool { return true }}
+	return inv
 }
 
 // Item attempts to obtain an item from a specific slot in the inventory. If an item was present in that slot,
@@ -305,6 +319,14 @@ func (inv *Inventory) Handle(h Handler) {
 	inv.h = h
 }
 
+// Changes returns the event.Bus that SlotChange events are dispatched to every time a slot of the Inventory is
+// changed. The function passed to New is subscribed to it, and any number of other handlers may be too.
+func (inv *Inventory) Changes() *event.Bus[SlotChange] {
+	inv.mu.RLock()
+	defer inv.mu.RUnlock()
+	return inv.changes
+}
+
 // Handler returns the Handler currently assigned to the Inventory. This is the NopHandler by default.
 func (inv *Inventory) Handler() Handler {
 	inv.mu.RLock()
@@ -323,10 +345,12 @@ func (inv *Inventory) setItem(slot int, it item.Stack) func() {
 	if it.Count() > it.MaxCount() {
 		it = it.Grow(it.MaxCount() - it.Count())
 	}
-	before := inv.slots[slot]
+	before, changes := inv.slots[slot], inv.changes
 	inv.slots[slot] = it
 	return func() {
-		inv.f(slot, before, it)
+		if changes.Len() > 0 {
+			changes.Call(event.C(), SlotChange{Slot: slot, Before: before, After: it})
+		}
 	}
 }
 
@@ -343,7 +367,7 @@ func (inv *Inventory) size() int {
 	return len(inv.slots)
 }
 
-// Close closes the inventory, freeing the function called for every slot change. It also clears any items
+// Close closes the inventory, unsubscribing all handlers of slot changes. It also clears any items
 // that may currently be in the inventory.
 // The returned error is always nil.
 func (inv *Inventory) Close() error {
@@ -351,7 +375,7 @@ func (inv *Inventory) Close() error {
 	defer inv.mu.Unlock()
 
 	inv.check()
-	inv.f = func(int, item.Stack, item.Stack) {}
+	inv.changes = &event.Bus[SlotChange]{}
 	return nil
 }
 
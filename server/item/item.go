@@ -157,6 +157,14 @@ type Releasable interface {
 	Requirements() []Stack
 }
 
+// Chargeable represents a Releasable item that is charged by releasing it, such as a crossbow. A Chargeable
+// item that is charged is not used over a longer duration again. Instead, using it releases its charge.
+type Chargeable interface {
+	Releasable
+	// Charged checks if the item is currently charged.
+	Charged() bool
+}
+
 // User represents an entity that is able to use an item in the world, typically entities such as players,
 // which interact with the world using an item.
 type User interface {
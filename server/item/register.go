@@ -30,6 +30,8 @@ func init() {
 	world.RegisterItem(Bucket{})
 	world.RegisterItem(CarrotOnAStick{})
 	world.RegisterItem(Charcoal{})
+	world.RegisterItem(ChorusFruit{})
+	world.RegisterItem(Crossbow{})
 	world.RegisterItem(Chicken{Cooked: true})
 	world.RegisterItem(Chicken{})
 	world.RegisterItem(ClayBall{})
@@ -67,6 +69,7 @@ func init() {
 	world.RegisterItem(GoldenCarrot{})
 	world.RegisterItem(Gunpowder{})
 	world.RegisterItem(HeartOfTheSea{})
+	world.RegisterItem(Totem{})
 	world.RegisterItem(Honeycomb{})
 	world.RegisterItem(InkSac{Glowing: true})
 	world.RegisterItem(InkSac{})
@@ -105,10 +108,12 @@ func init() {
 	world.RegisterItem(RawIron{})
 	world.RegisterItem(RecoveryCompass{})
 	world.RegisterItem(RottenFlesh{})
+	world.RegisterItem(Saddle{})
 	world.RegisterItem(Salmon{Cooked: true})
 	world.RegisterItem(Salmon{})
 	world.RegisterItem(Scute{})
 	world.RegisterItem(Shears{})
+	world.RegisterItem(Shield{})
 	world.RegisterItem(ShulkerShell{})
 	world.RegisterItem(Slimeball{})
 	world.RegisterItem(Snowball{})
@@ -116,11 +121,15 @@ func init() {
 	world.RegisterItem(Spyglass{})
 	world.RegisterItem(Stick{})
 	world.RegisterItem(Sugar{})
+	world.RegisterItem(Trident{})
 	world.RegisterItem(TropicalFish{})
 	world.RegisterItem(TurtleShell{})
 	world.RegisterItem(WarpedFungusOnAStick{})
 	world.RegisterItem(Wheat{})
 	world.RegisterItem(WrittenBook{})
+	for _, t := range horseArmourTiers() {
+		world.RegisterItem(HorseArmour{Tier: t})
+	}
 	for _, t := range ArmourTiers() {
 		world.RegisterItem(Helmet{Tier: t})
 		world.RegisterItem(Chestplate{Tier: t})
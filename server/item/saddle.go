@@ -0,0 +1,14 @@
+package item
+
+// Saddle is an item that can be put on horses and similar mobs to make them controllable while riding them.
+type Saddle struct{}
+
+// MaxCount ...
+func (Saddle) MaxCount() int {
+	return 1
+}
+
+// EncodeItem ...
+func (Saddle) EncodeItem() (name string, meta int16) {
+	return "minecraft:saddle", 0
+}
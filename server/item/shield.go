@@ -0,0 +1,33 @@
+package item
+
+// Shield is a defensive item that may be held in either hand. A player holding a shield blocks damage coming from
+// the front while sneaking.
+type Shield struct{}
+
+// MaxCount always returns 1.
+func (Shield) MaxCount() int {
+	return 1
+}
+
+// OffHand ...
+func (Shield) OffHand() bool {
+	return true
+}
+
+// DurabilityInfo ...
+func (Shield) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 337,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// RepairableBy ...
+func (Shield) RepairableBy(i Stack) bool {
+	return toolTierRepairable(ToolTierWood)(i)
+}
+
+// EncodeItem ...
+func (Shield) EncodeItem() (name string, meta int16) {
+	return "minecraft:shield", 0
+}
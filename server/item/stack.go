@@ -21,6 +21,8 @@ type Stack struct {
 	customName string
 	lore       []string
 
+	canPlaceOn, canDestroy []string
+
 	damage int
 
 	anvilCost int
@@ -144,6 +146,13 @@ func (s Stack) WithDurability(d int) Stack {
 	return s
 }
 
+// WithItem returns a copy of the stack with its item replaced by the item passed. The count, durability and
+// other data of the stack, such as its enchantments and custom name, are kept.
+func (s Stack) WithItem(t world.Item) Stack {
+	s.item = t
+	return s
+}
+
 // Empty checks if the stack is empty (has a count of 0).
 func (s Stack) Empty() bool {
 	return s.Count() == 0 || s.item == nil
@@ -200,6 +209,48 @@ func (s Stack) Lore() []string {
 	return s.lore
 }
 
+// WithCanPlaceOn returns a copy of the Stack that may be placed on the blocks with the identifiers passed, such
+// as 'minecraft:stone', by players in a game mode that does not allow editing the world, like adventure mode.
+// Identifiers without a namespace are assumed to be in the 'minecraft' namespace. The list may be cleared by
+// passing no identifiers.
+func (s Stack) WithCanPlaceOn(identifiers ...string) Stack {
+	s.canPlaceOn = namespaced(identifiers)
+	return s
+}
+
+// CanPlaceOn returns the identifiers of the blocks that the Stack may be placed on in adventure mode, as set
+// using WithCanPlaceOn.
+func (s Stack) CanPlaceOn() []string {
+	return s.canPlaceOn
+}
+
+// PlaceableOn checks if the Stack may be placed on the world.Block passed in adventure mode.
+func (s Stack) PlaceableOn(b world.Block) bool {
+	name, _ := b.EncodeBlock()
+	return slices.Contains(s.canPlaceOn, name)
+}
+
+// WithCanDestroy returns a copy of the Stack that may be used to break the blocks with the identifiers passed,
+// such as 'minecraft:dirt', by players in a game mode that does not allow editing the world, like adventure
+// mode. Identifiers without a namespace are assumed to be in the 'minecraft' namespace. The list may be
+// cleared by passing no identifiers.
+func (s Stack) WithCanDestroy(identifiers ...string) Stack {
+	s.canDestroy = namespaced(identifiers)
+	return s
+}
+
+// CanDestroy returns the identifiers of the blocks that the Stack may break in adventure mode, as set using
+// WithCanDestroy.
+func (s Stack) CanDestroy() []string {
+	return s.canDestroy
+}
+
+// Destroys checks if the Stack may be used to break the world.Block passed in adventure mode.
+func (s Stack) Destroys(b world.Block) bool {
+	name, _ := b.EncodeBlock()
+	return slices.Contains(s.canDestroy, name)
+}
+
 // WithValue returns the current Stack with a value set at a specific key. This method may be used to
 // associate custom data with the item stack, which will persist through server restarts.
 // The value stored may later be obtained by making a call to Stack.Value().
@@ -225,6 +276,12 @@ func (s Stack) Value(key string) (val any, ok bool) {
 	return val, ok
 }
 
+// HasValues checks if any values were set to the Stack using Stack.WithValue(). Unlike Stack.Values(), HasValues
+// does not copy the values of the Stack.
+func (s Stack) HasValues() bool {
+	return len(s.data) != 0
+}
+
 // WithEnchantments returns the current stack with the passed enchantments. If an enchantment is not compatible
 // with the item stack, it will not be applied.
 func (s Stack) WithEnchantments(enchants ...Enchantment) Stack {
@@ -341,6 +398,9 @@ func (s Stack) Comparable(s2 Stack) bool {
 	for !slices.Equal(s.lore, s2.lore) {
 		return false
 	}
+	if !slices.Equal(s.canPlaceOn, s2.canPlaceOn) || !slices.Equal(s.canDestroy, s2.canDestroy) {
+		return false
+	}
 	if len(s.enchantments) != len(s2.enchantments) {
 		return false
 	}
@@ -398,6 +458,22 @@ func format(a []any) string {
 	return strings.TrimSuffix(fmt.Sprintln(a...), "\n")
 }
 
+// namespaced returns a copy of the block identifiers passed with the 'minecraft' namespace added to identifiers
+// that do not have one.
+func namespaced(identifiers []string) []string {
+	if len(identifiers) == 0 {
+		return nil
+	}
+	m := make([]string, 0, len(identifiers))
+	for _, id := range identifiers {
+		if !strings.Contains(id, ":") {
+			id = "minecraft:" + id
+		}
+		m = append(m, id)
+	}
+	return m
+}
+
 // copyMap makes a copy of the map passed. It does not recursively copy the map.
 func copyMap(m map[string]any) map[string]any {
 	cp := make(map[string]any, len(m))
@@ -0,0 +1,20 @@
+package item
+
+// Totem is a totem of undying. A totem of undying held in either hand prevents the death of its holder once,
+// after which it is consumed.
+type Totem struct{}
+
+// MaxCount always returns 1.
+func (Totem) MaxCount() int {
+	return 1
+}
+
+// EncodeItem ...
+func (Totem) EncodeItem() (name string, meta int16) {
+	return "minecraft:totem_of_undying", 0
+}
+
+// OffHand ...
+func (Totem) OffHand() bool {
+	return true
+}
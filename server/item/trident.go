@@ -0,0 +1,91 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// Trident is a weapon that may be used for melee attacks or thrown at entities. A trident enchanted with riptide
+// launches its user when released in water or rain instead of being thrown.
+type Trident struct{}
+
+// MaxCount always returns 1.
+func (Trident) MaxCount() int {
+	return 1
+}
+
+// AttackDamage ...
+func (Trident) AttackDamage() float64 {
+	return 8
+}
+
+// DurabilityInfo ...
+func (Trident) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability:    251,
+		BrokenItem:       simpleItem(Stack{}),
+		AttackDurability: 1,
+		BreakDurability:  2,
+	}
+}
+
+// EnchantmentValue ...
+func (Trident) EnchantmentValue() int {
+	return 1
+}
+
+// Release ...
+func (Trident) Release(releaser Releaser, duration time.Duration, ctx *UseContext) {
+	if duration < time.Second/2 {
+		// The trident must be charged for at least ten ticks.
+		return
+	}
+	held, _ := releaser.HeldItems()
+	w, creative := releaser.World(), releaser.GameMode().CreativeInventory()
+
+	for _, enchant := range held.Enchantments() {
+		r, ok := enchant.Type().(interface{ RiptideForce(level int) float64 })
+		if !ok {
+			continue
+		}
+		// Riptide tridents may only be used in water or rain and launch the releaser instead of being thrown.
+		pos := cube.PosFromVec3(releaser.Position())
+		liquid, inLiquid := w.Liquid(pos)
+		if !(inLiquid && liquid.LiquidType() == "water") && !w.RainingAt(pos) {
+			return
+		}
+		if riptider, ok := releaser.(interface{ Riptide(vel mgl64.Vec3) }); ok {
+			riptider.Riptide(releaser.Rotation().Vec3().Mul(r.RiptideForce(enchant.Level())))
+			w.PlaySound(releaser.Position(), sound.TridentRiptide{Level: enchant.Level()})
+			ctx.DamageItem(1)
+		}
+		return
+	}
+
+	rot := releaser.Rotation()
+	rot = cube.Rotation{-rot[0], -rot[1]}
+	if rot[0] > 180 {
+		rot[0] = 360 - rot[0]
+	}
+
+	create := w.EntityRegistry().Config().Trident
+	w.AddEntity(create(eyePosition(releaser), releaser.Rotation().Vec3().Mul(2.5), rot, releaser, held.Damage(1), !creative))
+	w.PlaySound(releaser.Position(), sound.TridentThrow{})
+	w.EmitGameEvent(releaser.Position(), gameevent.ProjectileShoot{}, releaser)
+	if !creative {
+		ctx.SubtractFromCount(1)
+	}
+}
+
+// Requirements ...
+func (Trident) Requirements() []Stack {
+	return nil
+}
+
+// EncodeItem ...
+func (Trident) EncodeItem() (name string, meta int16) {
+	return "minecraft:trident", 0
+}
@@ -1,5 +1,7 @@
 package item
 
+import "github.com/go-gl/mathgl/mgl64"
+
 // UseContext is passed to every item Use methods. It may be used to subtract items or to deal damage to them
 // after the action is complete.
 type UseContext struct {
@@ -17,6 +19,9 @@ type UseContext struct {
 	ConsumedItems []Stack
 	// NewItemSurvivalOnly will add any new items only in survival mode.
 	NewItemSurvivalOnly bool
+	// ClickPos is the position relative to the block that was clicked, at which the block was clicked. It is set
+	// when a block is activated and ranges from 0 to 1 on every axis.
+	ClickPos mgl64.Vec3
 
 	// FirstFunc returns the first item in the context holder's inventory if found. The second return value describes
 	// whether the item was found. The comparable function is used to compare the item to the given item.
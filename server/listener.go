@@ -2,8 +2,12 @@ package server
 
 import (
 	"fmt"
+	"github.com/df-mc/atomic"
+	"github.com/df-mc/dragonfly/server/logging"
 	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/version"
 	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 	"github.com/sirupsen/logrus"
 	"io"
 	"log"
@@ -25,28 +29,63 @@ type Listener interface {
 func (uc UserConfig) listenerFunc(conf Config) (Listener, error) {
 	cfg := minecraft.ListenConfig{
 		MaximumPlayers:         conf.MaxPlayers,
-		StatusProvider:         statusProvider{name: conf.Name},
-		AuthenticationDisabled: conf.AuthDisabled,
+		StatusProvider:         conf.StatusProvider,
+		AuthenticationDisabled: conf.AuthDisabled || conf.Forwarding.Secret != "",
 		ResourcePacks:          conf.Resources,
 		Biomes:                 biomes(),
 		TexturePacksRequired:   conf.ResourcesRequired,
+		AcceptedProtocols:      version.Protocols(),
+		Compression:            conf.Compression,
+		FlushRate:              conf.FlushRate,
 	}
-	if l, ok := conf.Log.(*logrus.Logger); ok {
+	if conf.MaxDecompressedSize > 0 {
+		if cfg.Compression == nil {
+			cfg.Compression = packet.DefaultCompression
+		}
+		cfg.Compression = limitedCompression{Compression: cfg.Compression, max: conf.MaxDecompressedSize}
+	}
+	switch l := conf.Log.(type) {
+	case *logrus.Logger:
 		cfg.ErrorLog = log.Default()
 		log.SetOutput(l.WithField("src", "gophertunnel").WriterLevel(logrus.DebugLevel))
+	case *logging.Logger:
+		cfg.ErrorLog = log.Default()
+		log.SetFlags(0)
+		log.SetOutput(l.Subsystem(logging.SubsystemNetwork).WithField("src", "gophertunnel").Writer(logging.LevelDebug))
+	}
+	if conf.Forwarding.ProxyProtocol && len(conf.Forwarding.TrustedProxies) == 0 {
+		return nil, fmt.Errorf("create minecraft listener: proxy protocol requires trusted proxies")
+	}
+	networkName := "raknet"
+	var throttled *throttledListener
+	if conf.Forwarding.ProxyProtocol || conf.Query || conf.ResourceSendRate > 0 {
+		n := network{f: conf.Forwarding}
+		if p, ok := conf.StatusProvider.(statusProvider); ok && conf.Query {
+			n.query = p.queryData
+		}
+		if conf.ResourceSendRate > 0 {
+			throttled = &throttledListener{rate: conf.ResourceSendRate}
+			n.throttled = throttled
+		}
+		networkName = networkID
+		minecraft.RegisterNetwork(networkName, n)
 	}
-	l, err := cfg.Listen("raknet", uc.Network.Address)
+	l, err := cfg.Listen(networkName, uc.Network.Address)
 	if err != nil {
 		return nil, fmt.Errorf("create minecraft listener: %w", err)
 	}
 	conf.Log.Infof("Server running on %v.\n", l.Addr())
-	return listener{l}, nil
+	return listener{l, conf.MaxBatchSize, throttled}, nil
 }
 
 // listener is a Listener implementation that wraps around a minecraft.Listener so that it can be listened on by
 // Server.
 type listener struct {
 	*minecraft.Listener
+	maxBatchSize int
+	// throttled is the throttledListener limiting the rate at which resource packs are sent, or nil if the rate
+	// is not limited.
+	throttled *throttledListener
 }
 
 // Accept blocks until the next connection is established and returns it. An error is returned if the Listener was
@@ -56,10 +95,47 @@ func (l listener) Accept() (session.Conn, error) {
 	if err != nil {
 		return nil, err
 	}
+	if l.throttled != nil {
+		// The connection has finished logging in, so all resource packs have been sent.
+		l.throttled.release(conn.RemoteAddr())
+	}
+	if l.maxBatchSize > 0 {
+		return &batchConn{Conn: conn.(*minecraft.Conn), max: int64(l.maxBatchSize)}, nil
+	}
 	return conn.(session.Conn), err
 }
 
 // Disconnect disconnects a connection from the Listener with a reason.
 func (l listener) Disconnect(conn session.Conn, reason string) error {
+	if c, ok := conn.(*batchConn); ok {
+		return l.Listener.Disconnect(c.Conn, reason)
+	}
 	return l.Listener.Disconnect(conn.(*minecraft.Conn), reason)
 }
+
+// batchConn wraps around a *minecraft.Conn to flush the packets buffered for it once a maximum amount of
+// packets has been written since the last flush.
+type batchConn struct {
+	*minecraft.Conn
+	max     int64
+	pending atomic.Int64
+}
+
+// WritePacket writes a packet to the Conn, flushing the packets buffered if the maximum batch size is
+// reached.
+func (c *batchConn) WritePacket(pk packet.Packet) error {
+	if err := c.Conn.WritePacket(pk); err != nil {
+		return err
+	}
+	if c.pending.Add(1) >= c.max {
+		c.pending.Store(0)
+		return c.Conn.Flush()
+	}
+	return nil
+}
+
+// Flush flushes the packets buffered, resetting the amount of pending packets.
+func (c *batchConn) Flush() error {
+	c.pending.Store(0)
+	return c.Conn.Flush()
+}
@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Level is the severity of a message logged. Loggers only log messages with a
+// Level at least as high as the Level set for them.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+// ParseLevel parses a Level from its name, such as "debug" or "warn". An error
+// is returned if the name is not that of a Level.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	case "fatal":
+		return LevelFatal, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q, expected debug, info, warn, error or fatal", name)
+}
+
+// String returns the name of the Level, such as "info".
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	case LevelFatal:
+		return "fatal"
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
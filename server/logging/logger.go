@@ -0,0 +1,167 @@
+// Package logging implements a logger with levels per subsystem of the server,
+// such as the network or worlds. Entries logged are passed to a Sink, which may
+// write them as text or JSON or pass them on to other logging libraries.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Subsystems that the server logs messages from. A Logger for a subsystem is
+// obtained through Logger.Subsystem, so that its Level may be set separately.
+const (
+	SubsystemServer  = "server"
+	SubsystemNetwork = "network"
+	SubsystemWorld   = "world"
+	SubsystemEntity  = "entity"
+	SubsystemPlugin  = "plugin"
+)
+
+// Logger is a logger that passes the messages logged to it to a Sink as an
+// Entry. Loggers obtained through Subsystem and WithField share the Sink and
+// Levels of the Logger they were derived from, so that the Level of a
+// subsystem may be changed at any time. Logger implements server.Logger and
+// the Logger interfaces of the world and session packages.
+type Logger struct {
+	sink      Sink
+	levels    *levels
+	subsystem string
+	fields    map[string]any
+}
+
+// New creates a Logger that passes messages of at least the Level passed to
+// the Sink passed.
+func New(sink Sink, level Level) *Logger {
+	return &Logger{sink: sink, levels: &levels{def: level, subsystems: map[string]Level{}}}
+}
+
+// Subsystem returns a Logger that logs messages as part of the subsystem
+// passed, such as SubsystemNetwork. Messages logged to it are filtered using
+// the Level set for the subsystem, if any.
+func (l *Logger) Subsystem(name string) *Logger {
+	cp := *l
+	cp.subsystem = name
+	return &cp
+}
+
+// WithField returns a Logger that adds a field with the key and value passed
+// to every Entry logged to it.
+func (l *Logger) WithField(key string, val any) *Logger {
+	cp := *l
+	cp.fields = make(map[string]any, len(l.fields)+1)
+	for k, v := range l.fields {
+		cp.fields[k] = v
+	}
+	cp.fields[key] = val
+	return &cp
+}
+
+// SetLevel sets the minimum Level of messages logged by the subsystem passed.
+// If the subsystem is empty, the default Level used for subsystems without a
+// Level of their own is set.
+func (l *Logger) SetLevel(subsystem string, level Level) {
+	l.levels.mu.Lock()
+	defer l.levels.mu.Unlock()
+	if subsystem == "" {
+		l.levels.def = level
+		return
+	}
+	l.levels.subsystems[subsystem] = level
+}
+
+// ResetLevels removes the Levels set for all subsystems and sets the default
+// Level to the one passed.
+func (l *Logger) ResetLevels(level Level) {
+	l.levels.mu.Lock()
+	defer l.levels.mu.Unlock()
+	l.levels.def = level
+	l.levels.subsystems = map[string]Level{}
+}
+
+// Enabled checks if messages with the Level passed are logged by the Logger.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.levels.of(l.subsystem)
+}
+
+// Debugf logs a message with LevelDebug.
+func (l *Logger) Debugf(format string, a ...any) {
+	l.log(LevelDebug, format, a...)
+}
+
+// Infof logs a message with LevelInfo.
+func (l *Logger) Infof(format string, a ...any) {
+	l.log(LevelInfo, format, a...)
+}
+
+// Warnf logs a message with LevelWarn.
+func (l *Logger) Warnf(format string, a ...any) {
+	l.log(LevelWarn, format, a...)
+}
+
+// Errorf logs a message with LevelError.
+func (l *Logger) Errorf(format string, a ...any) {
+	l.log(LevelError, format, a...)
+}
+
+// Fatalf logs a message with LevelFatal and exits the program with status 1.
+func (l *Logger) Fatalf(format string, a ...any) {
+	l.log(LevelFatal, format, a...)
+	os.Exit(1)
+}
+
+// Writer returns an io.Writer that logs every line written to it with the
+// Level passed. It may be used to redirect the output of loggers that write to
+// an io.Writer, such as those of the log package.
+func (l *Logger) Writer(level Level) io.Writer {
+	return writer{l: l, level: level}
+}
+
+// log logs a message with a specific Level if the Level is enabled.
+func (l *Logger) log(level Level, format string, a ...any) {
+	if !l.Enabled(level) {
+		return
+	}
+	l.sink.Write(Entry{
+		Time:      time.Now(),
+		Level:     level,
+		Subsystem: l.subsystem,
+		Message:   fmt.Sprintf(format, a...),
+		Fields:    l.fields,
+	})
+}
+
+// levels holds the Levels of a Logger and the Loggers derived from it.
+type levels struct {
+	mu         sync.RWMutex
+	def        Level
+	subsystems map[string]Level
+}
+
+// of returns the Level of the subsystem passed.
+func (lv *levels) of(subsystem string) Level {
+	lv.mu.RLock()
+	defer lv.mu.RUnlock()
+	if level, ok := lv.subsystems[subsystem]; ok {
+		return level
+	}
+	return lv.def
+}
+
+// writer implements io.Writer by logging each line written to a Logger.
+type writer struct {
+	l     *Logger
+	level Level
+}
+
+// Write ...
+func (w writer) Write(b []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(b), "\n"), "\n") {
+		w.l.log(w.level, "%s", line)
+	}
+	return len(b), nil
+}
@@ -0,0 +1,173 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a single message logged to a Logger.
+type Entry struct {
+	// Time is the time at which the message was logged.
+	Time time.Time
+	// Level is the Level that the message was logged with.
+	Level Level
+	// Subsystem is the subsystem that logged the message. It is empty if the
+	// message was not logged by a specific subsystem.
+	Subsystem string
+	// Message is the formatted message.
+	Message string
+	// Fields holds additional fields added using Logger.WithField. It must not
+	// be modified.
+	Fields map[string]any
+}
+
+// Sink handles entries logged to a Logger, for example by writing them to a
+// file or passing them on to another logging library. Write may be called by
+// multiple goroutines at the same time.
+type Sink interface {
+	// Write handles an Entry logged to a Logger.
+	Write(e Entry)
+}
+
+// SinkFunc is a function that implements Sink.
+type SinkFunc func(e Entry)
+
+// Write ...
+func (f SinkFunc) Write(e Entry) {
+	f(e)
+}
+
+// TextSink returns a Sink that writes entries to the io.Writer passed in a
+// human-readable format, one entry per line.
+func TextSink(w io.Writer) Sink {
+	return &textSink{w: w}
+}
+
+// textSink is the Sink returned by TextSink.
+type textSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write ...
+func (s *textSink) Write(e Entry) {
+	b := &strings.Builder{}
+	b.WriteString(e.Time.Format("2006-01-02 15:04:05"))
+	b.WriteByte(' ')
+	b.WriteString(strings.ToUpper(e.Level.String()))
+	if e.Subsystem != "" {
+		b.WriteString(" [" + e.Subsystem + "]")
+	}
+	b.WriteByte(' ')
+	b.WriteString(e.Message)
+	for _, k := range sortedKeys(e.Fields) {
+		_, _ = fmt.Fprintf(b, " %v=%v", k, e.Fields[k])
+	}
+	b.WriteByte('\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = io.WriteString(s.w, b.String())
+}
+
+// JSONSink returns a Sink that writes entries to the io.Writer passed as JSON
+// objects, one per line, so that they may be collected by log aggregators.
+// Fields added using Logger.WithField are written alongside the time, level,
+// subsystem and message of the entry.
+func JSONSink(w io.Writer) Sink {
+	return &jsonSink{enc: json.NewEncoder(w)}
+}
+
+// jsonSink is the Sink returned by JSONSink.
+type jsonSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// Write ...
+func (s *jsonSink) Write(e Entry) {
+	m := make(map[string]any, len(e.Fields)+4)
+	for k, v := range e.Fields {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		m[k] = v
+	}
+	m["time"] = e.Time.Format(time.RFC3339Nano)
+	m["level"] = e.Level.String()
+	m["msg"] = e.Message
+	if e.Subsystem != "" {
+		m["subsystem"] = e.Subsystem
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.enc.Encode(m); err != nil {
+		// The entry holds a field that cannot be encoded, so fall back to
+		// encoding it in its textual form.
+		for k, v := range e.Fields {
+			m[k] = fmt.Sprint(v)
+		}
+		_ = s.enc.Encode(m)
+	}
+}
+
+// Logrus returns a Sink that passes entries on to the logrus.Logger passed.
+// The subsystem and fields of an Entry are passed as logrus fields. The Level
+// of the logrus.Logger still applies to the entries passed.
+func Logrus(l *logrus.Logger) Sink {
+	return SinkFunc(func(e Entry) {
+		entry := logrus.NewEntry(l).WithTime(e.Time).WithFields(e.Fields)
+		if e.Subsystem != "" {
+			entry = entry.WithField("subsystem", e.Subsystem)
+		}
+		level := logrus.DebugLevel
+		switch e.Level {
+		case LevelInfo:
+			level = logrus.InfoLevel
+		case LevelWarn:
+			level = logrus.WarnLevel
+		case LevelError, LevelFatal:
+			// Logger.Fatalf already exits the program, so fatal entries are
+			// logged as errors to leave that to the Logger.
+			level = logrus.ErrorLevel
+		}
+		entry.Log(level, e.Message)
+	})
+}
+
+// Std returns a Sink that writes entries to the log.Logger passed, prefixed by
+// their level and subsystem.
+func Std(l *log.Logger) Sink {
+	return SinkFunc(func(e Entry) {
+		b := &strings.Builder{}
+		b.WriteString(strings.ToUpper(e.Level.String()))
+		if e.Subsystem != "" {
+			b.WriteString(" [" + e.Subsystem + "]")
+		}
+		b.WriteByte(' ')
+		b.WriteString(e.Message)
+		for _, k := range sortedKeys(e.Fields) {
+			_, _ = fmt.Fprintf(b, " %v=%v", k, e.Fields[k])
+		}
+		l.Print(b.String())
+	})
+}
+
+// sortedKeys returns the keys of the map passed in sorted order, so that
+// fields are written in the same order every time.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"net"
+)
+
+// Login holds the data of a connection logging in to a Server. It is passed
+// to the handlers subscribed to Server.Logins.
+type Login struct {
+	// Addr is the address of the connection.
+	Addr net.Addr
+	// Identity is the identity data of the connection.
+	Identity login.IdentityData
+	// Device is the Device that the connection joins with. Like the rest of
+	// the client data, it cannot be trusted, as it may be changed freely by
+	// the player connecting.
+	Device player.Device
+	// Message is the disconnect message shown to the connection if the login
+	// is cancelled.
+	Message string
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// serveMetrics serves metrics of the Server in the Prometheus text format on
+// the MetricsAddress in the Config, until the Server is closed.
+func (srv *Server) serveMetrics() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		srv.writeMetrics(w)
+	})
+	s := &http.Server{Addr: srv.conf.MetricsAddress, Handler: mux, ReadHeaderTimeout: time.Second * 5}
+
+	go func() {
+		<-srv.closing
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		_ = s.Shutdown(ctx)
+	}()
+	srv.conf.Log.Infof("Serving metrics on %v/metrics.", srv.conf.MetricsAddress)
+	if err := s.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		srv.conf.Log.Errorf("serve metrics: %v", err)
+	}
+}
+
+// writeMetrics writes all metrics of the Server to the io.Writer passed in
+// the Prometheus text format.
+func (srv *Server) writeMetrics(w io.Writer) {
+	m := &metricWriter{w: w}
+	m.gauge("dragonfly_players_online", "Amount of players currently online.", float64(len(srv.Players())))
+	m.gauge("dragonfly_players_max", "Maximum amount of players allowed online.", float64(srv.MaxPlayerCount()))
+	m.gauge("dragonfly_tps", "Lowest ticks per second of the default worlds.", srv.TPS())
+	m.gauge("dragonfly_mspt", "Highest milliseconds per tick of the default worlds.", srv.MSPT())
+
+	received, sent := session.PacketStats()
+	m.counter("dragonfly_packets_received_total", "Total amount of packets received from players.", float64(received))
+	m.counter("dragonfly_packets_sent_total", "Total amount of packets sent to players.", float64(sent))
+
+	worlds := []*world.World{srv.world, srv.nether, srv.end}
+	m.worldGauge("dragonfly_world_tps", "Ticks per second of a world.", worlds, (*world.World).TPS)
+	m.worldGauge("dragonfly_world_mspt", "Milliseconds per tick of a world.", worlds, (*world.World).MSPT)
+	m.worldGauge("dragonfly_world_chunks_loaded", "Amount of chunks loaded in a world.", worlds, func(w *world.World) float64 {
+		return float64(w.ChunkCount())
+	})
+	m.worldGauge("dragonfly_world_entities", "Amount of entities in a world.", worlds, func(w *world.World) float64 {
+		return float64(w.EntityCount())
+	})
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	m.gauge("dragonfly_memory_heap_alloc_bytes", "Bytes of allocated heap objects.", float64(stats.HeapAlloc))
+	m.gauge("dragonfly_memory_sys_bytes", "Bytes of memory obtained from the OS.", float64(stats.Sys))
+	m.counter("dragonfly_memory_gc_total", "Total amount of completed GC cycles.", float64(stats.NumGC))
+	m.gauge("dragonfly_goroutines", "Amount of goroutines currently running.", float64(runtime.NumGoroutine()))
+}
+
+// metricWriter writes metrics in the Prometheus text format to an io.Writer.
+type metricWriter struct {
+	w io.Writer
+}
+
+// gauge writes a gauge metric with the name, help text and value passed.
+func (m *metricWriter) gauge(name, help string, v float64) {
+	m.header(name, help, "gauge")
+	_, _ = fmt.Fprintf(m.w, "%v %v\n", name, v)
+}
+
+// counter writes a counter metric with the name, help text and value passed.
+func (m *metricWriter) counter(name, help string, v float64) {
+	m.header(name, help, "counter")
+	_, _ = fmt.Fprintf(m.w, "%v %v\n", name, v)
+}
+
+// worldGauge writes a gauge metric with a value for every world passed,
+// labelled with the dimension of the world.
+func (m *metricWriter) worldGauge(name, help string, worlds []*world.World, f func(w *world.World) float64) {
+	m.header(name, help, "gauge")
+	for _, w := range worlds {
+		if w == nil {
+			continue
+		}
+		_, _ = fmt.Fprintf(m.w, "%v{dimension=%q} %v\n", name, strings.ToLower(fmt.Sprint(w.Dimension())), f(w))
+	}
+}
+
+// header writes the HELP and TYPE lines of a metric.
+func (m *metricWriter) header(name, help, typ string) {
+	_, _ = fmt.Fprintf(m.w, "# HELP %v %v\n# TYPE %v %v\n", name, help, name, typ)
+}
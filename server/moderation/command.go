@@ -0,0 +1,474 @@
+package moderation
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/permission"
+	"github.com/df-mc/dragonfly/server/player"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commands returns the commands used to manage the lists of the Manager passed in-game: /ban, /tempban, /unban,
+// /banip, /unbanip, /whitelist, /mute, /tempmute and /unmute. Each of them requires the permission node
+// 'dragonfly.command.<name>' or permission.LevelAdmin. The commands returned may be registered using cmd.Register.
+func Commands(m *Manager) []cmd.Command {
+	return []cmd.Command{
+		cmd.New("ban", "Bans players from the server.", nil, ban{m: m}, banName{m: m}),
+		cmd.New("tempban", "Bans players from the server for a limited time.", nil, tempBan{m: m}, tempBanName{m: m}),
+		cmd.New("unban", "Removes the ban of a player.", []string{"pardon"}, unban{m: m}),
+		cmd.New("banip", "Bans the IP addresses of players from the server.", nil, banIP{m: m}, banAddress{m: m}),
+		cmd.New("unbanip", "Removes the ban of an IP address.", []string{"pardon-ip"}, unbanIP{m: m}),
+		cmd.New("whitelist", "Manages the whitelist of the server.", nil,
+			whitelistAdd{m: m}, whitelistRemove{m: m}, whitelistToggle{m: m}, whitelistList{m: m}),
+		cmd.New("mute", "Prevents players from chatting.", nil, mute{m: m}),
+		cmd.New("tempmute", "Prevents players from chatting for a limited time.", nil, tempMute{m: m}),
+		cmd.New("unmute", "Allows muted players to chat again.", nil, unmute{m: m}),
+	}
+}
+
+// ban implements the /ban command.
+type ban struct {
+	m       *Manager
+	Targets []cmd.Target              `cmd:"victim"`
+	Reason  cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Permission ...
+func (ban) Permission() string { return "dragonfly.command.ban" }
+
+// Level ...
+func (ban) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (b ban) Run(src cmd.Source, o *cmd.Output) {
+	banPlayers(src, o, b.Targets, newEntry(src, b.Reason, time.Time{}), b.m.Ban)
+}
+
+// banName implements the /ban command for players that are offline, which are banned by their name or XUID.
+type banName struct {
+	m      *Manager
+	Name   string                    `cmd:"player"`
+	Reason cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Permission ...
+func (banName) Permission() string { return "dragonfly.command.ban" }
+
+// Level ...
+func (banName) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (b banName) Run(src cmd.Source, o *cmd.Output) {
+	banOffline(o, b.Name, newEntry(src, b.Reason, time.Time{}), b.m.BanName)
+}
+
+// tempBan implements the /tempban command.
+type tempBan struct {
+	m        *Manager
+	Targets  []cmd.Target              `cmd:"victim"`
+	Duration string                    `cmd:"duration"`
+	Reason   cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Permission ...
+func (tempBan) Permission() string { return "dragonfly.command.tempban" }
+
+// Level ...
+func (tempBan) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (b tempBan) Run(src cmd.Source, o *cmd.Output) {
+	d, err := ParseDuration(b.Duration)
+	if err != nil {
+		o.Error(err)
+		return
+	}
+	banPlayers(src, o, b.Targets, newEntry(src, b.Reason, time.Now().Add(d)), b.m.Ban)
+}
+
+// tempBanName implements the /tempban command for players that are offline, which are banned by their name or
+// XUID.
+type tempBanName struct {
+	m        *Manager
+	Name     string                    `cmd:"player"`
+	Duration string                    `cmd:"duration"`
+	Reason   cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Permission ...
+func (tempBanName) Permission() string { return "dragonfly.command.tempban" }
+
+// Level ...
+func (tempBanName) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (b tempBanName) Run(src cmd.Source, o *cmd.Output) {
+	d, err := ParseDuration(b.Duration)
+	if err != nil {
+		o.Error(err)
+		return
+	}
+	banOffline(o, b.Name, newEntry(src, b.Reason, time.Now().Add(d)), b.m.BanName)
+}
+
+// unban implements the /unban command.
+type unban struct {
+	m    *Manager
+	Name string `cmd:"player"`
+}
+
+// Permission ...
+func (unban) Permission() string { return "dragonfly.command.unban" }
+
+// Level ...
+func (unban) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (u unban) Run(_ cmd.Source, o *cmd.Output) {
+	removed, err := u.m.Bans.RemoveName(u.Name)
+	if err != nil {
+		o.Error(err)
+	} else if !removed {
+		o.Errorf("%v is not banned.", u.Name)
+	} else {
+		o.Printf("Unbanned %v.", u.Name)
+	}
+}
+
+// banIP implements the /banip command.
+type banIP struct {
+	m       *Manager
+	Targets []cmd.Target              `cmd:"victim"`
+	Reason  cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Permission ...
+func (banIP) Permission() string { return "dragonfly.command.banip" }
+
+// Level ...
+func (banIP) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (b banIP) Run(src cmd.Source, o *cmd.Output) {
+	banPlayers(src, o, b.Targets, newEntry(src, b.Reason, time.Time{}), b.m.BanIP)
+}
+
+// banAddress implements the /banip command for IP addresses, so that addresses of players that are offline may
+// be banned.
+type banAddress struct {
+	m       *Manager
+	Address string                    `cmd:"address"`
+	Reason  cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Permission ...
+func (banAddress) Permission() string { return "dragonfly.command.banip" }
+
+// Level ...
+func (banAddress) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (b banAddress) Run(src cmd.Source, o *cmd.Output) {
+	banOffline(o, b.Address, newEntry(src, b.Reason, time.Time{}), b.m.BanAddress)
+}
+
+// unbanIP implements the /unbanip command.
+type unbanIP struct {
+	m       *Manager
+	Address string `cmd:"address"`
+}
+
+// Permission ...
+func (unbanIP) Permission() string { return "dragonfly.command.unbanip" }
+
+// Level ...
+func (unbanIP) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (u unbanIP) Run(_ cmd.Source, o *cmd.Output) {
+	if _, ok := u.m.IPBans.Entry(u.Address); !ok {
+		o.Errorf("%v is not banned.", u.Address)
+		return
+	}
+	if err := u.m.IPBans.Remove(u.Address); err != nil {
+		o.Error(err)
+		return
+	}
+	o.Printf("Unbanned IP address %v.", u.Address)
+}
+
+// whitelistAdd implements the /whitelist add command.
+type whitelistAdd struct {
+	m    *Manager
+	Add  cmd.SubCommand `cmd:"add"`
+	Name string         `cmd:"player"`
+}
+
+// Permission ...
+func (whitelistAdd) Permission() string { return "dragonfly.command.whitelist" }
+
+// Level ...
+func (whitelistAdd) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (w whitelistAdd) Run(src cmd.Source, o *cmd.Output) {
+	if err := w.m.Whitelist.Add(w.Name, Entry{Name: w.Name, Source: sourceName(src)}); err != nil {
+		o.Error(err)
+		return
+	}
+	o.Printf("Added %v to the whitelist.", w.Name)
+}
+
+// whitelistRemove implements the /whitelist remove command.
+type whitelistRemove struct {
+	m      *Manager
+	Remove cmd.SubCommand `cmd:"remove"`
+	Name   string         `cmd:"player"`
+}
+
+// Permission ...
+func (whitelistRemove) Permission() string { return "dragonfly.command.whitelist" }
+
+// Level ...
+func (whitelistRemove) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (w whitelistRemove) Run(_ cmd.Source, o *cmd.Output) {
+	removed, err := w.m.Whitelist.RemoveName(w.Name)
+	if err != nil {
+		o.Error(err)
+	} else if !removed {
+		o.Errorf("%v is not whitelisted.", w.Name)
+	} else {
+		o.Printf("Removed %v from the whitelist.", w.Name)
+	}
+}
+
+// whitelistToggle implements the /whitelist <on|off> command.
+type whitelistToggle struct {
+	m     *Manager
+	State toggle `cmd:"state"`
+}
+
+// Permission ...
+func (whitelistToggle) Permission() string { return "dragonfly.command.whitelist" }
+
+// Level ...
+func (whitelistToggle) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (w whitelistToggle) Run(_ cmd.Source, o *cmd.Output) {
+	w.m.SetWhitelistEnabled(w.State == "on")
+	o.Printf("Turned the whitelist %v.", w.State)
+}
+
+// whitelistList implements the /whitelist list command.
+type whitelistList struct {
+	m    *Manager
+	List cmd.SubCommand `cmd:"list"`
+}
+
+// Permission ...
+func (whitelistList) Permission() string { return "dragonfly.command.whitelist" }
+
+// Level ...
+func (whitelistList) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (w whitelistList) Run(_ cmd.Source, o *cmd.Output) {
+	entries := w.m.Whitelist.Entries()
+	names := make([]string, 0, len(entries))
+	for k, e := range entries {
+		if e.Name != "" {
+			k = e.Name
+		}
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	o.Printf("There are %v whitelisted players: %v", len(names), strings.Join(names, ", "))
+}
+
+// mute implements the /mute command.
+type mute struct {
+	m       *Manager
+	Targets []cmd.Target              `cmd:"victim"`
+	Reason  cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Permission ...
+func (mute) Permission() string { return "dragonfly.command.mute" }
+
+// Level ...
+func (mute) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (m mute) Run(src cmd.Source, o *cmd.Output) {
+	mutePlayers(o, m.Targets, newEntry(src, m.Reason, time.Time{}), m.m)
+}
+
+// tempMute implements the /tempmute command.
+type tempMute struct {
+	m        *Manager
+	Targets  []cmd.Target              `cmd:"victim"`
+	Duration string                    `cmd:"duration"`
+	Reason   cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Permission ...
+func (tempMute) Permission() string { return "dragonfly.command.tempmute" }
+
+// Level ...
+func (tempMute) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (m tempMute) Run(src cmd.Source, o *cmd.Output) {
+	d, err := ParseDuration(m.Duration)
+	if err != nil {
+		o.Error(err)
+		return
+	}
+	mutePlayers(o, m.Targets, newEntry(src, m.Reason, time.Now().Add(d)), m.m)
+}
+
+// unmute implements the /unmute command.
+type unmute struct {
+	m       *Manager
+	Targets []cmd.Target `cmd:"victim"`
+}
+
+// Permission ...
+func (unmute) Permission() string { return "dragonfly.command.unmute" }
+
+// Level ...
+func (unmute) Level() permission.Level { return permission.LevelAdmin }
+
+// Run ...
+func (u unmute) Run(_ cmd.Source, o *cmd.Output) {
+	for _, t := range u.Targets {
+		p, ok := t.(*player.Player)
+		if !ok {
+			continue
+		}
+		if err := u.m.Unmute(p); err != nil {
+			o.Error(err)
+			continue
+		}
+		o.Printf("Unmuted %v.", p.Name())
+	}
+}
+
+// toggle is a cmd.Enum used to turn the whitelist on or off.
+type toggle string
+
+// Type ...
+func (toggle) Type() string { return "WhitelistState" }
+
+// Options ...
+func (toggle) Options(cmd.Source) []string { return []string{"on", "off"} }
+
+// banPlayers bans all players among the targets passed using the function passed.
+func banPlayers(src cmd.Source, o *cmd.Output, targets []cmd.Target, e Entry, f func(p *player.Player, e Entry) error) {
+	for _, t := range targets {
+		p, ok := t.(*player.Player)
+		if !ok {
+			continue
+		}
+		if p == src {
+			o.Errorf("You cannot ban yourself.")
+			continue
+		}
+		if err := f(p, e); err != nil {
+			o.Error(err)
+			continue
+		}
+		o.Printf("Banned %v.", p.Name())
+	}
+}
+
+// banOffline bans the name, XUID or IP address passed using the function passed.
+func banOffline(o *cmd.Output, key string, e Entry, f func(key string, e Entry) error) {
+	if err := f(key, e); err != nil {
+		o.Error(err)
+		return
+	}
+	o.Printf("Banned %v.", key)
+}
+
+// mutePlayers mutes all players among the targets passed.
+func mutePlayers(o *cmd.Output, targets []cmd.Target, e Entry, m *Manager) {
+	for _, t := range targets {
+		p, ok := t.(*player.Player)
+		if !ok {
+			continue
+		}
+		if err := m.Mute(p, e); err != nil {
+			o.Error(err)
+			continue
+		}
+		o.Printf("Muted %v.", p.Name())
+	}
+}
+
+// newEntry creates an Entry added by the cmd.Source passed with an optional reason and expiry time.
+func newEntry(src cmd.Source, reason cmd.Optional[cmd.Varargs], expires time.Time) Entry {
+	r, _ := reason.Load()
+	return Entry{Reason: string(r), Source: sourceName(src), Expires: expires}
+}
+
+// sourceName returns the name of the cmd.Source passed, or 'Server' if it has no name.
+func sourceName(src cmd.Source) string {
+	if n, ok := src.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+	return "Server"
+}
+
+// ParseDuration parses a duration such as '30m', '12h', '7d' or '1w2d'. In addition to the units accepted by
+// time.ParseDuration, ParseDuration accepts 'd' for days and 'w' for weeks. Units may be given in any order, such
+// as in '2d1w'. Durations must be positive.
+func ParseDuration(s string) (time.Duration, error) {
+	var total time.Duration
+	rest := strings.ToLower(strings.TrimSpace(s))
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	for rest != "" {
+		// Every token consists of a number followed by a unit, such as '2d' or '1.5h'.
+		i := strings.IndexFunc(rest, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		j := strings.IndexFunc(rest[i:], func(r rune) bool { return (r >= '0' && r <= '9') || r == '.' })
+		if j == -1 {
+			j = len(rest) - i
+		}
+		number, unit := rest[:i], rest[i:i+j]
+		rest = rest[i+j:]
+
+		var d time.Duration
+		switch unit {
+		case "w", "d":
+			n, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			d = time.Duration(n * float64(time.Hour*24))
+			if unit == "w" {
+				d *= 7
+			}
+		default:
+			var err error
+			if d, err = time.ParseDuration(number + unit); err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+		}
+		total += d
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", s)
+	}
+	return total, nil
+}
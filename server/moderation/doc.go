@@ -0,0 +1,7 @@
+// Package moderation implements ban lists, a whitelist and a mute list. Each list holds entries with an optional
+// reason and expiry time and is stored in a JSON file, so that it persists across restarts.
+//
+// A Manager combines these lists. It implements the Allow method of server.Allower to refuse banned players and,
+// if the whitelist is enabled, players not on it. Commands returned by Commands may be registered to manage the
+// lists in-game.
+package moderation
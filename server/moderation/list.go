@@ -0,0 +1,169 @@
+package moderation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is an entry in a List, such as a ban or a mute.
+type Entry struct {
+	// Name is the name of the player that the Entry applies to, if known. It is only used for display
+	// purposes.
+	Name string `json:"name,omitempty"`
+	// Reason is the reason for which the Entry was added.
+	Reason string `json:"reason,omitempty"`
+	// Source is the name of whoever added the Entry, such as the name of a player or 'Server'.
+	Source string `json:"source,omitempty"`
+	// Created is the time at which the Entry was added.
+	Created time.Time `json:"created"`
+	// Expires is the time at which the Entry expires. If zero, the Entry never expires.
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// Permanent checks if the Entry never expires.
+func (e Entry) Permanent() bool {
+	return e.Expires.IsZero()
+}
+
+// Expired checks if the Entry has expired.
+func (e Entry) Expired() bool {
+	return !e.Permanent() && time.Now().After(e.Expires)
+}
+
+// List is a list of entries keyed by a string, such as an XUID, a player name or an IP address. Keys are case
+// insensitive. A List is stored in a JSON file and changes made through its methods are written to the file
+// immediately. Expired entries are treated as if they were not present and are removed when the List is next
+// changed.
+// Methods on List are safe for simultaneous use from multiple goroutines.
+type List struct {
+	path string
+
+	mu      sync.RWMutex
+	entries map[string]Entry
+}
+
+// NewList creates a List that reads from and writes to the JSON file at the path passed. If the file does not
+// exist, it is created when the first change is made. If the path is empty, the List is kept in memory only.
+func NewList(path string) (*List, error) {
+	l := &List{path: path, entries: map[string]Entry{}}
+	if path == "" {
+		return l, nil
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read list: %w", err)
+	}
+	var entries map[string]Entry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("decode list: %w", err)
+	}
+	for k, e := range entries {
+		l.entries[strings.ToLower(k)] = e
+	}
+	return l, nil
+}
+
+// Add adds an Entry to the List under the key passed, replacing any Entry already present under it. If the
+// Created time of the Entry is zero, it is set to the current time.
+func (l *List) Add(key string, e Entry) error {
+	if e.Created.IsZero() {
+		e.Created = time.Now()
+	}
+	return l.update(func() {
+		l.entries[strings.ToLower(key)] = e
+	})
+}
+
+// Remove removes the Entry under the key passed from the List.
+func (l *List) Remove(key string) error {
+	return l.update(func() {
+		delete(l.entries, strings.ToLower(key))
+	})
+}
+
+// RemoveName removes all entries from the List that are either stored under the name passed or hold it as
+// their Name. It may be used to remove entries of players that are offline, of whom only the name is known.
+// RemoveName returns true if any entries were removed.
+func (l *List) RemoveName(name string) (bool, error) {
+	removed := false
+	err := l.update(func() {
+		for k, e := range l.entries {
+			if k == strings.ToLower(name) || strings.EqualFold(e.Name, name) {
+				delete(l.entries, k)
+				removed = true
+			}
+		}
+	})
+	return removed, err
+}
+
+// Entry looks up the Entry under the key passed. If the List has no such Entry, or if it has expired, false is
+// returned.
+func (l *List) Entry(key string) (Entry, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	e, ok := l.entries[strings.ToLower(key)]
+	if !ok || e.Expired() {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Lookup looks up the first Entry present under any of the keys passed. It may be used to look up an Entry
+// that may have been added using either the XUID or the name of a player. Empty keys are ignored.
+func (l *List) Lookup(keys ...string) (Entry, bool) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if e, ok := l.Entry(key); ok {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Entries returns all entries in the List that have not expired, mapped by their keys.
+func (l *List) Entries() map[string]Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	m := make(map[string]Entry, len(l.entries))
+	for k, e := range l.entries {
+		if !e.Expired() {
+			m[k] = e
+		}
+	}
+	return m
+}
+
+// update calls f with the List locked, removes expired entries and writes the resulting entries to the file of
+// the List.
+func (l *List) update(f func()) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f()
+	for k, e := range l.entries {
+		if e.Expired() {
+			delete(l.entries, k)
+		}
+	}
+	if l.path == "" {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(l.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode list: %w", err)
+	}
+	if err := os.WriteFile(l.path, b, 0644); err != nil {
+		return fmt.Errorf("write list: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package moderation
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Manager combines the ban lists, whitelist and mute list of a server. Manager implements the Allow method of
+// server.Allower, so that it may be used to refuse players that are banned or not whitelisted.
+type Manager struct {
+	// Bans holds the players that are banned, by their XUID or, if they are not authenticated, by their name.
+	Bans *List
+	// IPBans holds the IP addresses that are banned.
+	IPBans *List
+	// Whitelist holds the players that may join while the whitelist is enabled, by their XUID or name.
+	Whitelist *List
+	// Mutes holds the players that are muted, by their XUID or, if they are not authenticated, by their name.
+	Mutes *List
+
+	whitelistEnabled atomic.Bool
+	banned           event.Bus[Entry]
+}
+
+// NewManager creates a Manager that stores its lists as JSON files in the directory passed. The directory is
+// created if it does not yet exist. If dir is empty, the lists are kept in memory only.
+func NewManager(dir string) (*Manager, error) {
+	path := func(name string) string {
+		if dir == "" {
+			return ""
+		}
+		return filepath.Join(dir, name)
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			return nil, fmt.Errorf("create moderation dir: %w", err)
+		}
+	}
+	m := &Manager{}
+	for _, l := range []struct {
+		list **List
+		name string
+	}{{&m.Bans, "bans.json"}, {&m.IPBans, "banned-ips.json"}, {&m.Whitelist, "whitelist.json"}, {&m.Mutes, "mutes.json"}} {
+		list, err := NewList(path(l.name))
+		if err != nil {
+			return nil, fmt.Errorf("load %v: %w", l.name, err)
+		}
+		*l.list = list
+	}
+	return m, nil
+}
+
+// Banned returns the event.Bus that the Entry of a player is dispatched to when the player is banned using Ban
+// or BanIP. Entries added to the Bans or IPBans lists directly are not dispatched. Cancelling the event has no
+// effect.
+func (m *Manager) Banned() *event.Bus[Entry] {
+	return &m.banned
+}
+
+// SetWhitelistEnabled enables or disables the whitelist. While enabled, only players on the Whitelist may join.
+// Players already online are not kicked when the whitelist is enabled.
+func (m *Manager) SetWhitelistEnabled(enabled bool) {
+	m.whitelistEnabled.Store(enabled)
+}
+
+// WhitelistEnabled checks if the whitelist is enabled.
+func (m *Manager) WhitelistEnabled() bool {
+	return m.whitelistEnabled.Load()
+}
+
+// Allow refuses players that are banned, either by their XUID, name or IP address, and players that are not on
+// the Whitelist if it is enabled.
+func (m *Manager) Allow(addr net.Addr, d login.IdentityData, _ login.ClientData) (string, bool) {
+	if e, ok := m.Bans.Lookup(d.XUID, d.DisplayName); ok {
+		return BanMessage(e), false
+	}
+	if e, ok := m.IPBans.Entry(host(addr)); ok {
+		return BanMessage(e), false
+	}
+	if _, ok := m.Whitelist.Lookup(d.XUID, d.DisplayName); !ok && m.WhitelistEnabled() {
+		return "You are not whitelisted on this server.", false
+	}
+	return "", true
+}
+
+// Ban bans the player passed with the Entry passed and disconnects it. The IP address of the player is not
+// banned. BanIP may be used to do so.
+func (m *Manager) Ban(p *player.Player, e Entry) error {
+	e.Name = p.Name()
+	if err := m.Bans.Add(Key(p), e); err != nil {
+		return err
+	}
+	m.banned.Call(event.C(), e)
+	p.Disconnect(BanMessage(e))
+	return nil
+}
+
+// BanIP bans the IP address of the player passed with the Entry passed and disconnects it.
+func (m *Manager) BanIP(p *player.Player, e Entry) error {
+	addr := host(p.Addr())
+	if addr == "" {
+		return fmt.Errorf("player %v has no address", p.Name())
+	}
+	e.Name = p.Name()
+	if err := m.IPBans.Add(addr, e); err != nil {
+		return err
+	}
+	m.banned.Call(event.C(), e)
+	p.Disconnect(BanMessage(e))
+	return nil
+}
+
+// BanName bans the player with the name or XUID passed with the Entry passed. It may be used to ban players that
+// are offline. Players that are online are not disconnected: Ban should be used for those instead.
+func (m *Manager) BanName(name string, e Entry) error {
+	if name = strings.TrimSpace(name); name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+	if e.Name == "" {
+		e.Name = name
+	}
+	if err := m.Bans.Add(name, e); err != nil {
+		return err
+	}
+	m.banned.Call(event.C(), e)
+	return nil
+}
+
+// BanAddress bans the IP address passed with the Entry passed. It may be used to ban the addresses of players
+// that are offline. Players that are online are not disconnected: BanIP should be used for those instead.
+func (m *Manager) BanAddress(addr string, e Entry) error {
+	ip := net.ParseIP(strings.TrimSpace(addr))
+	if ip == nil {
+		return fmt.Errorf("invalid IP address %q", addr)
+	}
+	if err := m.IPBans.Add(ip.String(), e); err != nil {
+		return err
+	}
+	m.banned.Call(event.C(), e)
+	return nil
+}
+
+// Mute mutes the player passed with the Entry passed, preventing it from chatting until the Entry expires. The
+// mute persists if the player leaves and joins again, provided ApplyMute is called when it joins.
+func (m *Manager) Mute(p *player.Player, e Entry) error {
+	e.Name = p.Name()
+	if err := m.Mutes.Add(Key(p), e); err != nil {
+		return err
+	}
+	m.ApplyMute(p)
+	return nil
+}
+
+// Unmute removes the mute of the player passed.
+func (m *Manager) Unmute(p *player.Player) error {
+	p.Unmute()
+	if err := m.Mutes.Remove(Key(p)); err != nil {
+		return err
+	}
+	_, err := m.Mutes.RemoveName(p.Name())
+	return err
+}
+
+// ApplyMute mutes the player passed if it has an Entry in the Mutes list. It should be called when a player
+// joins the server.
+func (m *Manager) ApplyMute(p *player.Player) {
+	e, ok := m.Mutes.Lookup(p.XUID(), p.Name())
+	if !ok {
+		return
+	}
+	if e.Permanent() {
+		p.Mute(0)
+		return
+	}
+	if d := time.Until(e.Expires); d > 0 {
+		p.Mute(d)
+	}
+}
+
+// Key returns the key under which entries for the player passed are stored: Its XUID, or its name if it is not
+// authenticated with XBOX Live.
+func Key(p *player.Player) string {
+	if xuid := p.XUID(); xuid != "" {
+		return xuid
+	}
+	return p.Name()
+}
+
+// BanMessage returns the disconnect message shown to a player banned with the Entry passed.
+func BanMessage(e Entry) string {
+	var b strings.Builder
+	b.WriteString("You are banned from this server.")
+	if e.Reason != "" {
+		b.WriteString("\nReason: " + e.Reason)
+	}
+	if !e.Permanent() {
+		b.WriteString("\nExpires: " + e.Expires.Format("2006-01-02 15:04 MST"))
+	}
+	return b.String()
+}
+
+// host returns the IP address of the net.Addr passed without its port. If addr is nil, an empty string is
+// returned.
+func host(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	h, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return h
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/proxyproto"
+	"github.com/sandertv/go-raknet"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"net"
+)
+
+// networkID is the ID of the minecraft.Network registered by the default listener when the PROXY protocol,
+// queries or a resource pack send rate are enabled.
+const networkID = "raknet+dragonfly"
+
+// Compile time check to make sure network implements minecraft.Network.
+var _ minecraft.Network = network{}
+
+// network is a minecraft.Network that listens on RakNet, optionally accepting PROXY protocol headers from
+// proxies trusted by its Forwarding, answering queries on the same port and limiting the rate at which resource
+// packs are sent.
+type network struct {
+	minecraft.RakNet
+	f     Forwarding
+	query func() queryData
+	// throttled, if not nil, is used to limit the rate at which data is sent to connections that are logging
+	// in.
+	throttled *throttledListener
+}
+
+// Listen ...
+func (n network) Listen(address string) (minecraft.NetworkListener, error) {
+	l, err := raknet.ListenConfig{UpstreamPacketListener: n}.Listen(address)
+	if err != nil {
+		return nil, err
+	}
+	if n.throttled != nil {
+		n.throttled.NetworkListener = l
+		return n.throttled, nil
+	}
+	return l, nil
+}
+
+// ListenPacket ...
+func (n network) ListenPacket(network, address string) (net.PacketConn, error) {
+	conn, err := net.ListenPacket(network, address)
+	if err != nil {
+		return nil, err
+	}
+	if n.f.ProxyProtocol {
+		conn = proxyproto.Wrap(conn, n.f.trusted)
+	}
+	if n.query != nil {
+		conn = newQueryConn(conn, n.query)
+	}
+	return conn, nil
+}
@@ -0,0 +1,8 @@
+// Package permission implements node based permissions. Permission nodes are dot separated strings, such as
+// 'dragonfly.command.ban', that may be granted or denied to players directly or through the groups that they
+// are in. Nodes may be granted using wildcards: Granting 'dragonfly.command.*' grants all nodes starting with
+// 'dragonfly.command.'.
+//
+// Permissions are looked up through a Provider. FileProvider is the default implementation, which stores
+// groups and players in a JSON file. Other backends may be plugged in by implementing Provider.
+package permission
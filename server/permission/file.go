@@ -0,0 +1,286 @@
+package permission
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Group is a named set of permission nodes. Players in a Group have all permissions of the Group and the groups
+// it inherits from, unless overridden by permissions set for the player directly.
+type Group struct {
+	// Name is the name of the Group, such as 'admin'.
+	Name string `json:"-"`
+	// Inherits is a list of names of groups that the Group inherits permissions from. Permissions set in the
+	// Group itself take precedence over inherited permissions.
+	Inherits []string `json:"inherits,omitempty"`
+	// Permissions maps permission nodes, which may contain wildcards, to their value. A value of false
+	// explicitly denies the node.
+	Permissions map[string]bool `json:"permissions,omitempty"`
+}
+
+// entry holds the groups and permissions of a single player in a FileProvider.
+type entry struct {
+	Groups      []string        `json:"groups,omitempty"`
+	Permissions map[string]bool `json:"permissions,omitempty"`
+}
+
+// fileData is the structure of the JSON file written by a FileProvider.
+type fileData struct {
+	DefaultGroup string               `json:"default_group,omitempty"`
+	Groups       map[string]*Group    `json:"groups"`
+	Players      map[uuid.UUID]*entry `json:"players"`
+}
+
+// FileProvider is the default Provider implementation. It stores groups and the permissions of players in a
+// JSON file. Changes made through its methods are written to the file immediately.
+// Players that are not in any group are treated as if they are in the default group, if set.
+type FileProvider struct {
+	path string
+
+	mu   sync.RWMutex
+	data fileData
+}
+
+// Compile time check to make sure FileProvider implements Provider.
+var _ Provider = (*FileProvider)(nil)
+
+// DefaultGroup and AdminGroup are the names of the groups that a FileProvider is seeded with if its file does not
+// yet exist. Players are in DefaultGroup unless added to another group. DefaultGroup grants no permissions, so
+// that players may only run commands that require no permission or an operator level they have. AdminGroup
+// inherits from DefaultGroup and grants all permissions of the server.
+const (
+	DefaultGroup = "default"
+	AdminGroup   = "admin"
+)
+
+// NewFileProvider creates a FileProvider that reads from and writes to the JSON file at the path passed. If the
+// file does not exist, it is created with a DefaultGroup, set as the default group, and an AdminGroup.
+func NewFileProvider(path string) (*FileProvider, error) {
+	p := &FileProvider{path: path, data: fileData{Groups: map[string]*Group{}, Players: map[uuid.UUID]*entry{}}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := p.seed(); err != nil {
+			return nil, err
+		}
+		return p, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read permissions: %w", err)
+	}
+	if err := json.Unmarshal(b, &p.data); err != nil {
+		return nil, fmt.Errorf("decode permissions: %w", err)
+	}
+	if p.data.Groups == nil {
+		p.data.Groups = map[string]*Group{}
+	}
+	if p.data.Players == nil {
+		p.data.Players = map[uuid.UUID]*entry{}
+	}
+	for name, g := range p.data.Groups {
+		g.Name, g.Permissions = name, lowerKeys(g.Permissions)
+	}
+	for _, e := range p.data.Players {
+		e.Permissions = lowerKeys(e.Permissions)
+	}
+	return p, nil
+}
+
+// seed adds the DefaultGroup and AdminGroup to the FileProvider and writes them to its file.
+func (p *FileProvider) seed() error {
+	return p.update(func() {
+		p.data.DefaultGroup = DefaultGroup
+		p.data.Groups[DefaultGroup] = &Group{Name: DefaultGroup, Permissions: map[string]bool{}}
+		p.data.Groups[AdminGroup] = &Group{Name: AdminGroup, Inherits: []string{DefaultGroup}, Permissions: map[string]bool{"dragonfly.*": true}}
+	})
+}
+
+// Check checks the value of a permission node for the player with the UUID passed. Permissions set for the
+// player directly are checked first, after which the groups of the player and the groups they inherit from are
+// checked in order.
+func (p *FileProvider) Check(id uuid.UUID, node string) (value, set bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	candidates := Candidates(node)
+	groups := []string{p.data.DefaultGroup}
+	if e, ok := p.data.Players[id]; ok {
+		if v, ok := lookup(e.Permissions, candidates); ok {
+			return v, true
+		}
+		if len(e.Groups) != 0 {
+			groups = e.Groups
+		}
+	}
+	visited := map[string]struct{}{}
+	for _, g := range groups {
+		if v, ok := p.checkGroup(g, candidates, visited); ok {
+			return v, true
+		}
+	}
+	return false, false
+}
+
+// checkGroup checks the candidates passed against the Group with the name passed and the groups it inherits
+// from. Groups already visited are skipped to prevent cyclic inheritance from causing infinite recursion.
+func (p *FileProvider) checkGroup(name string, candidates []string, visited map[string]struct{}) (bool, bool) {
+	if _, ok := visited[name]; ok {
+		return false, false
+	}
+	visited[name] = struct{}{}
+	g, ok := p.data.Groups[name]
+	if !ok {
+		return false, false
+	}
+	if v, ok := lookup(g.Permissions, candidates); ok {
+		return v, true
+	}
+	for _, parent := range g.Inherits {
+		if v, ok := p.checkGroup(parent, candidates, visited); ok {
+			return v, true
+		}
+	}
+	return false, false
+}
+
+// lookup returns the value of the first candidate present in the permissions map passed.
+func lookup(permissions map[string]bool, candidates []string) (bool, bool) {
+	for _, c := range candidates {
+		if v, ok := permissions[c]; ok {
+			return v, true
+		}
+	}
+	return false, false
+}
+
+// Group looks up a Group by its name. If not found, false is returned.
+func (p *FileProvider) Group(name string) (Group, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	g, ok := p.data.Groups[name]
+	if !ok {
+		return Group{}, false
+	}
+	return Group{Name: g.Name, Inherits: slices.Clone(g.Inherits), Permissions: cloneMap(g.Permissions)}, true
+}
+
+// SetGroup adds a Group to the FileProvider, replacing any existing Group with the same name.
+func (p *FileProvider) SetGroup(g Group) error {
+	g.Inherits, g.Permissions = slices.Clone(g.Inherits), lowerKeys(g.Permissions)
+	return p.update(func() {
+		p.data.Groups[g.Name] = &g
+	})
+}
+
+// RemoveGroup removes the Group with the name passed from the FileProvider. Players in the Group remain in it,
+// but no longer receive any permissions from it.
+func (p *FileProvider) RemoveGroup(name string) error {
+	return p.update(func() {
+		delete(p.data.Groups, name)
+	})
+}
+
+// SetDefaultGroup sets the group that players that are not in any group are treated to be in.
+func (p *FileProvider) SetDefaultGroup(name string) error {
+	return p.update(func() {
+		p.data.DefaultGroup = name
+	})
+}
+
+// Groups returns the names of the groups that the player with the UUID passed is in.
+func (p *FileProvider) Groups(id uuid.UUID) []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if e, ok := p.data.Players[id]; ok {
+		return slices.Clone(e.Groups)
+	}
+	return nil
+}
+
+// AddToGroup adds the player with the UUID passed to the group with the name passed.
+func (p *FileProvider) AddToGroup(id uuid.UUID, group string) error {
+	return p.update(func() {
+		if e := p.entry(id); !slices.Contains(e.Groups, group) {
+			e.Groups = append(e.Groups, group)
+		}
+	})
+}
+
+// RemoveFromGroup removes the player with the UUID passed from the group with the name passed.
+func (p *FileProvider) RemoveFromGroup(id uuid.UUID, group string) error {
+	return p.update(func() {
+		e := p.entry(id)
+		if i := slices.Index(e.Groups, group); i != -1 {
+			e.Groups = slices.Delete(e.Groups, i, i+1)
+		}
+	})
+}
+
+// SetPermission sets the value of a permission node for the player with the UUID passed, overriding the value
+// of the node in any of the groups of the player.
+func (p *FileProvider) SetPermission(id uuid.UUID, node string, value bool) error {
+	return p.update(func() {
+		e := p.entry(id)
+		if e.Permissions == nil {
+			e.Permissions = map[string]bool{}
+		}
+		e.Permissions[strings.ToLower(node)] = value
+	})
+}
+
+// UnsetPermission removes a permission node set for the player with the UUID passed, so that the value of the
+// node is once again decided by the groups of the player.
+func (p *FileProvider) UnsetPermission(id uuid.UUID, node string) error {
+	return p.update(func() {
+		delete(p.entry(id).Permissions, strings.ToLower(node))
+	})
+}
+
+// entry returns the entry of the player with the UUID passed, creating it if it does not yet exist. p.mu must be
+// locked when entry is called.
+func (p *FileProvider) entry(id uuid.UUID) *entry {
+	e, ok := p.data.Players[id]
+	if !ok {
+		e = &entry{}
+		p.data.Players[id] = e
+	}
+	return e
+}
+
+// update calls f with the FileProvider locked and writes the resulting data to the file of the FileProvider.
+func (p *FileProvider) update(f func()) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	f()
+
+	b, err := json.MarshalIndent(p.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode permissions: %w", err)
+	}
+	if err := os.WriteFile(p.path, b, 0644); err != nil {
+		return fmt.Errorf("write permissions: %w", err)
+	}
+	return nil
+}
+
+// lowerKeys returns a copy of the map passed with all keys converted to lower case.
+func lowerKeys(m map[string]bool) map[string]bool {
+	cp := make(map[string]bool, len(m))
+	for k, v := range m {
+		cp[strings.ToLower(k)] = v
+	}
+	return cp
+}
+
+// cloneMap returns a copy of the map passed.
+func cloneMap(m map[string]bool) map[string]bool {
+	cp := make(map[string]bool, len(m))
+	for k, v := range m {
+		cp[k] = v
+	}
+	return cp
+}
@@ -0,0 +1,112 @@
+package permission
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"sync"
+)
+
+// Level is the operator level of a player or other command source. Higher levels allow running more commands.
+// The levels match those of vanilla Minecraft.
+type Level int
+
+const (
+	// LevelNone is the level of players that are not operators.
+	LevelNone Level = iota
+	// LevelModerator is the lowest operator level. Moderators may bypass spawn protection.
+	LevelModerator
+	// LevelGameMaster is the operator level that allows running commands that affect the game, such as changing
+	// game modes.
+	LevelGameMaster
+	// LevelAdmin is the operator level that allows running commands that manage players, such as banning and
+	// kicking them.
+	LevelAdmin
+	// LevelOwner is the highest operator level. It allows running any command, including commands that manage
+	// the server itself. The console always has this level.
+	LevelOwner
+)
+
+// Leveled is a value that has an operator Level, such as a player or the console. Leveled is implemented by
+// *player.Player.
+type Leveled interface {
+	// OperatorLevel returns the operator Level of the value.
+	OperatorLevel() Level
+}
+
+// operator is an entry in an OperatorList.
+type operator struct {
+	Name  string `json:"name,omitempty"`
+	Level Level  `json:"level"`
+}
+
+// OperatorList holds the operators of a server together with their Level. It is stored in a JSON file and
+// changes made through its methods are written to the file immediately.
+// Methods on OperatorList are safe for simultaneous use from multiple goroutines.
+type OperatorList struct {
+	path string
+
+	mu  sync.RWMutex
+	ops map[uuid.UUID]operator
+}
+
+// NewOperatorList creates an OperatorList that reads from and writes to the JSON file at the path passed. If the
+// file does not exist, it is created when the first change is made.
+func NewOperatorList(path string) (*OperatorList, error) {
+	l := &OperatorList{path: path, ops: map[uuid.UUID]operator{}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read operators: %w", err)
+	}
+	if err := json.Unmarshal(b, &l.ops); err != nil {
+		return nil, fmt.Errorf("decode operators: %w", err)
+	}
+	return l, nil
+}
+
+// Level returns the operator Level of the player with the UUID passed. LevelNone is returned if the player is
+// not an operator.
+func (l *OperatorList) Level(id uuid.UUID) Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.ops[id].Level
+}
+
+// SetLevel sets the operator Level of the player with the UUID and name passed. Setting the Level to LevelNone
+// or lower removes the player from the OperatorList.
+func (l *OperatorList) SetLevel(id uuid.UUID, name string, lvl Level) error {
+	if lvl > LevelOwner {
+		lvl = LevelOwner
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lvl <= LevelNone {
+		delete(l.ops, id)
+	} else {
+		l.ops[id] = operator{Name: name, Level: lvl}
+	}
+
+	b, err := json.MarshalIndent(l.ops, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode operators: %w", err)
+	}
+	if err := os.WriteFile(l.path, b, 0644); err != nil {
+		return fmt.Errorf("write operators: %w", err)
+	}
+	return nil
+}
+
+// Operators returns the operator levels of all operators in the OperatorList, mapped by their UUIDs.
+func (l *OperatorList) Operators() map[uuid.UUID]Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	m := make(map[uuid.UUID]Level, len(l.ops))
+	for id, op := range l.ops {
+		m[id] = op.Level
+	}
+	return m
+}
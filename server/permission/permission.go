@@ -0,0 +1,52 @@
+package permission
+
+import (
+	"github.com/google/uuid"
+	"strings"
+)
+
+// Provider provides the permissions of players. Implementations may store permissions in any way, for example
+// in a file or in an external database.
+// Methods on Provider must be safe for simultaneous use from multiple goroutines.
+type Provider interface {
+	// Check checks the value of the permission node passed for the player with the UUID passed. If the node, or
+	// a wildcard matching it, is set for the player, either directly or through one of the groups the player is
+	// in, the value is returned along with true. If not, set is false.
+	Check(id uuid.UUID, node string) (value, set bool)
+}
+
+// Holder is a value that may hold permissions, such as a player. Holder is implemented by *player.Player.
+type Holder interface {
+	// HasPermission checks if the Holder has the permission node passed.
+	HasPermission(node string) bool
+}
+
+// NopProvider is a Provider that never has any permission node set for any player.
+type NopProvider struct{}
+
+// Compile time check to make sure NopProvider implements Provider.
+var _ Provider = NopProvider{}
+
+// Check always returns false, false.
+func (NopProvider) Check(uuid.UUID, string) (bool, bool) { return false, false }
+
+// Has checks if the player with the UUID passed has the permission node passed in the Provider passed. If the
+// node is not set for the player at all, def is returned.
+func Has(p Provider, id uuid.UUID, node string, def bool) bool {
+	if v, ok := p.Check(id, node); ok {
+		return v
+	}
+	return def
+}
+
+// Candidates returns all keys that a permission node may be matched by, from most to least specific. For the
+// node 'a.b.c', these are 'a.b.c', 'a.b.*', 'a.*' and '*'. Provider implementations may use Candidates to look
+// up the value of a node, returning the value of the first candidate set.
+func Candidates(node string) []string {
+	node = strings.ToLower(node)
+	candidates := []string{node}
+	for i := strings.LastIndexByte(node, '.'); i != -1; i = strings.LastIndexByte(node[:i], '.') {
+		candidates = append(candidates, node[:i]+".*")
+	}
+	return append(candidates, "*")
+}
@@ -0,0 +1,113 @@
+package ability
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Ability is a bit set of abilities that a player may have, such as the ability to fly or to break blocks.
+// Multiple abilities may be combined using the | operator.
+type Ability uint32
+
+const (
+	// MayFly is the ability to start flying.
+	MayFly Ability = 1 << iota
+	// Build is the ability to place blocks.
+	Build
+	// Mine is the ability to break blocks.
+	Mine
+	// DoorsAndSwitches is the ability to use doors, trapdoors, buttons, levers and other blocks that may be
+	// activated.
+	DoorsAndSwitches
+	// OpenContainers is the ability to open containers, such as chests.
+	OpenContainers
+	// AttackPlayers is the ability to attack other players.
+	AttackPlayers
+	// AttackMobs is the ability to attack entities other than players.
+	AttackMobs
+	// Operator is the ability to use operator commands. Players with this ability are shown as operators to
+	// the client.
+	Operator
+	// Muted prevents a player from sending chat messages. The chat input of the client is disabled if the
+	// player has this ability.
+	Muted
+)
+
+// Has checks if all abilities in a are also present in the Ability passed.
+func (a Ability) Has(other Ability) bool {
+	return a&other == other
+}
+
+// Default returns the abilities that a player in the world.GameMode passed has by default.
+func Default(mode world.GameMode) Ability {
+	var a Ability
+	if mode.AllowsFlying() {
+		a |= MayFly
+	}
+	if mode.AllowsEditing() {
+		a |= Build | Mine
+	}
+	if mode.AllowsInteraction() {
+		a |= DoorsAndSwitches | OpenContainers | AttackPlayers | AttackMobs
+	}
+	return a
+}
+
+const (
+	// DefaultFlySpeed is the speed at which players fly by default.
+	DefaultFlySpeed = 0.05
+	// DefaultWalkSpeed is the speed at which players walk by default.
+	DefaultWalkSpeed = 0.1
+)
+
+// Abilities holds the abilities of a player that are changed from the defaults of its world.GameMode, together
+// with the speeds at which it flies and walks. The zero value of Abilities leaves the defaults of the
+// world.GameMode untouched.
+type Abilities struct {
+	// Granted holds the abilities that the player has, regardless of its world.GameMode.
+	Granted Ability
+	// Denied holds the abilities that the player does not have, regardless of its world.GameMode. Denied
+	// takes precedence over Granted.
+	Denied Ability
+	// FlySpeed is the speed at which the player flies. If 0, DefaultFlySpeed is used.
+	FlySpeed float64
+	// WalkSpeed is the speed at which the player walks according to the client. If 0, DefaultWalkSpeed is
+	// used.
+	WalkSpeed float64
+}
+
+// Grant returns a copy of the Abilities with the abilities passed granted.
+func (a Abilities) Grant(ability Ability) Abilities {
+	a.Granted, a.Denied = a.Granted|ability, a.Denied&^ability
+	return a
+}
+
+// Deny returns a copy of the Abilities with the abilities passed denied.
+func (a Abilities) Deny(ability Ability) Abilities {
+	a.Granted, a.Denied = a.Granted&^ability, a.Denied|ability
+	return a
+}
+
+// Reset returns a copy of the Abilities with the abilities passed neither granted nor denied, so that the
+// defaults of the world.GameMode of the player are used for them.
+func (a Abilities) Reset(ability Ability) Abilities {
+	a.Granted, a.Denied = a.Granted&^ability, a.Denied&^ability
+	return a
+}
+
+// Of returns the abilities that a player in the world.GameMode passed has with the Abilities applied.
+func (a Abilities) Of(mode world.GameMode) Ability {
+	return (Default(mode) | a.Granted) &^ a.Denied
+}
+
+// Speeds returns the fly and walk speeds of the Abilities, using DefaultFlySpeed and DefaultWalkSpeed if
+// they were not set.
+func (a Abilities) Speeds() (fly, walk float64) {
+	fly, walk = a.FlySpeed, a.WalkSpeed
+	if fly <= 0 {
+		fly = DefaultFlySpeed
+	}
+	if walk <= 0 {
+		walk = DefaultWalkSpeed
+	}
+	return fly, walk
+}
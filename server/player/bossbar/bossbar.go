@@ -3,22 +3,53 @@ package bossbar
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 )
 
 // BossBar represents a boss bar that may be sent to a player. It is shown as a purple bar with text above
 // it. The health shown by the bar may be changed.
+// A player views only one boss bar created using New at a time: Sending another one replaces the boss bar shown.
+// Boss bars created using NewSeparate each have a unique ID, which is retained by copies returned by their With
+// methods. They are shown in addition to other boss bars, so that a player may view multiple different boss
+// bars at the same time, and sending a copy updates the boss bar already shown.
 type BossBar struct {
-	text   string
-	health float64
-	c      Colour
+	id      uint64
+	text    string
+	health  float64
+	c       Colour
+	overlay Overlay
 }
 
+// barCount is used to assign a unique ID to every BossBar created using NewSeparate.
+var barCount atomic.Uint64
+
 // New creates a new boss bar with the text passed. The text is formatted according to the rules of
 // fmt.Sprintln.
 // By default, the boss bar will have a full health bar. To change this, use BossBar.WithHealthPercentage().
 // The default colour of the BossBar is Purple. This can be changed using BossBar.WithColour.
 func New(text ...any) BossBar {
-	return BossBar{text: format(text), health: 1, c: Purple()}
+	return BossBar{text: format(text), health: 1, c: Purple(), overlay: Progress()}
+}
+
+// NewSeparate creates a new boss bar with the text passed, like New. Unlike a BossBar created using New, the
+// BossBar returned is shown in addition to any other boss bars already shown to a player.
+func NewSeparate(text ...any) BossBar {
+	bar := New(text...)
+	bar.id = barCount.Add(1)
+	return bar
+}
+
+// ID returns the ID of the boss bar. Copies of a BossBar returned by its With methods share the same ID. All
+// boss bars created using New have an ID of 0, while those created using NewSeparate have a unique ID.
+func (bar BossBar) ID() uint64 {
+	return bar.id
+}
+
+// WithText returns a copy of the BossBar with the text passed. The text is formatted according to the rules
+// of fmt.Sprintln.
+func (bar BossBar) WithText(text ...any) BossBar {
+	bar.text = format(text)
+	return bar
 }
 
 // Text returns the text of the boss bar: The text passed when creating the bar using New().
@@ -43,6 +74,12 @@ func (bar BossBar) WithColour(c Colour) BossBar {
 	return bar
 }
 
+// WithOverlay returns a copy of the BossBar with the Overlay passed.
+func (bar BossBar) WithOverlay(o Overlay) BossBar {
+	bar.overlay = o
+	return bar
+}
+
 // HealthPercentage returns the health percentage of the boss bar. The number returned is a value between 0
 // and 1, with 0 being an empty boss bar and 1 being a full one.
 func (bar BossBar) HealthPercentage() float64 {
@@ -54,6 +91,11 @@ func (bar BossBar) Colour() Colour {
 	return bar.c
 }
 
+// Overlay returns the Overlay of the BossBar.
+func (bar BossBar) Overlay() Overlay {
+	return bar.overlay
+}
+
 // format is a utility function to format a list of values to have spaces between them, but no newline at the
 // end, which is typically used for sending messages, popups and tips.
 func format(a []any) string {
@@ -0,0 +1,35 @@
+package bossbar
+
+// Overlay is the overlay drawn over a BossBar, dividing it in a number of notches.
+type Overlay struct{ overlay }
+
+// Progress is the overlay of a boss bar without any notches.
+func Progress() Overlay {
+	return Overlay{overlay(0)}
+}
+
+// Notched6 is the overlay of a boss bar divided in 6 notches.
+func Notched6() Overlay {
+	return Overlay{overlay(1)}
+}
+
+// Notched10 is the overlay of a boss bar divided in 10 notches.
+func Notched10() Overlay {
+	return Overlay{overlay(2)}
+}
+
+// Notched12 is the overlay of a boss bar divided in 12 notches.
+func Notched12() Overlay {
+	return Overlay{overlay(3)}
+}
+
+// Notched20 is the overlay of a boss bar divided in 20 notches.
+func Notched20() Overlay {
+	return Overlay{overlay(4)}
+}
+
+type overlay uint8
+
+func (o overlay) Uint8() uint8 {
+	return uint8(o)
+}
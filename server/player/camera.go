@@ -0,0 +1,50 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/player/camera"
+	"time"
+)
+
+// Camera controls the camera of a Player. It may be used to move the camera away from the Player, for example to
+// show cutscenes or to let the Player spectate a game. A Camera may be obtained by calling Player.Camera.
+type Camera struct {
+	p *Player
+}
+
+// Camera returns the Camera of the Player, which may be used to send camera instructions to the Player.
+func (p *Player) Camera() Camera {
+	return Camera{p: p}
+}
+
+// Set changes the camera of the Player using the camera.Set instruction passed, such as:
+//
+//	p.Camera().Set(camera.NewSet(camera.Free()).WithPosition(pos).WithEase(camera.EasingInOutSine, time.Second*2))
+func (c Camera) Set(set camera.Set) {
+	c.p.session().SetCamera(set)
+}
+
+// Fade fades the screen of the Player to a colour and back using the camera.Fade instruction passed.
+func (c Camera) Fade(fade camera.Fade) {
+	c.p.session().FadeCamera(fade)
+}
+
+// Clear clears all camera instructions previously sent, returning the camera of the Player to normal.
+func (c Camera) Clear() {
+	c.p.session().ClearCamera()
+}
+
+// Shake shakes the camera of the Player with the intensity passed for the duration passed. The client limits the
+// intensity to 4. If rotational is true, the camera is rotated while shaking rather than moved.
+func (c Camera) Shake(intensity float64, d time.Duration, rotational bool) {
+	c.p.session().ShakeCamera(intensity, d, rotational)
+}
+
+// StopShaking stops any shaking of the camera of the Player.
+func (c Camera) StopShaking() {
+	c.p.session().StopCameraShake()
+}
+
+// Lock prevents the Player from rotating its camera and/or from moving. Passing false for both lifts the locks.
+func (c Camera) Lock(rotation, movement bool) {
+	c.p.session().LockInput(rotation, movement)
+}
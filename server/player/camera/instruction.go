@@ -0,0 +1,156 @@
+package camera
+
+import (
+	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
+	"time"
+)
+
+// Easing is an easing function used to move the camera from its current position and rotation to those of a Set
+// instruction over time.
+type Easing uint8
+
+const (
+	EasingLinear Easing = iota
+	EasingSpring
+	EasingInQuad
+	EasingOutQuad
+	EasingInOutQuad
+	EasingInCubic
+	EasingOutCubic
+	EasingInOutCubic
+	EasingInQuart
+	EasingOutQuart
+	EasingInOutQuart
+	EasingInQuint
+	EasingOutQuint
+	EasingInOutQuint
+	EasingInSine
+	EasingOutSine
+	EasingInOutSine
+	EasingInExpo
+	EasingOutExpo
+	EasingInOutExpo
+	EasingInCirc
+	EasingOutCirc
+	EasingInOutCirc
+	EasingInBounce
+	EasingOutBounce
+	EasingInOutBounce
+	EasingInBack
+	EasingOutBack
+	EasingInOutBack
+	EasingInElastic
+	EasingOutElastic
+	EasingInOutElastic
+)
+
+// Set is a camera instruction that changes the camera of a player to a Preset. The position and rotation of the
+// camera may be changed, optionally moving the camera to them using an Easing function.
+type Set struct {
+	preset Preset
+
+	easing       Easing
+	easeDuration time.Duration
+
+	pos, facing           mgl64.Vec3
+	hasPos, hasFacing     bool
+	yaw, pitch            float64
+	hasRotation, resetDef bool
+}
+
+// NewSet returns a new Set instruction that changes the camera of a player to the Preset passed.
+func NewSet(p Preset) Set {
+	return Set{preset: p}
+}
+
+// Preset returns the Preset that the camera is changed to.
+func (s Set) Preset() Preset {
+	return s.preset
+}
+
+// WithEase makes the camera move to the position and rotation of the Set instruction using the Easing function
+// passed, taking the duration passed. The new Set is returned.
+func (s Set) WithEase(e Easing, d time.Duration) Set {
+	s.easing, s.easeDuration = e, d
+	return s
+}
+
+// Ease returns the Easing function and its duration, as set using WithEase. The duration returned is 0 if no
+// easing is used.
+func (s Set) Ease() (Easing, time.Duration) {
+	return s.easing, s.easeDuration
+}
+
+// WithPosition places the camera at the position passed. The new Set is returned.
+func (s Set) WithPosition(pos mgl64.Vec3) Set {
+	s.pos, s.hasPos = pos, true
+	return s
+}
+
+// Position returns the position of the camera, as set using WithPosition. False is returned if no position
+// was set.
+func (s Set) Position() (mgl64.Vec3, bool) {
+	return s.pos, s.hasPos
+}
+
+// WithRotation rotates the camera to the yaw and pitch passed. The new Set is returned.
+func (s Set) WithRotation(yaw, pitch float64) Set {
+	s.yaw, s.pitch, s.hasRotation = yaw, pitch, true
+	return s
+}
+
+// Rotation returns the yaw and pitch of the camera, as set using WithRotation. False is returned if no rotation
+// was set.
+func (s Set) Rotation() (yaw, pitch float64, ok bool) {
+	return s.yaw, s.pitch, s.hasRotation
+}
+
+// WithFacing makes the camera keep facing the position passed for as long as the instruction is active. The new
+// Set is returned.
+func (s Set) WithFacing(pos mgl64.Vec3) Set {
+	s.facing, s.hasFacing = pos, true
+	return s
+}
+
+// Facing returns the position that the camera faces, as set using WithFacing. False is returned if the camera
+// does not face a specific position.
+func (s Set) Facing() (mgl64.Vec3, bool) {
+	return s.facing, s.hasFacing
+}
+
+// WithDefault makes the Set instruction reset the camera to the default values of its Preset. The new Set is
+// returned.
+func (s Set) WithDefault() Set {
+	s.resetDef = true
+	return s
+}
+
+// Default returns true if the Set instruction resets the camera to the default values of its Preset.
+func (s Set) Default() bool {
+	return s.resetDef
+}
+
+// Fade is a camera instruction that fades the screen of a player to a colour and back.
+type Fade struct {
+	colour                color.RGBA
+	fadeIn, wait, fadeOut time.Duration
+}
+
+// NewFade returns a new Fade instruction that fades the screen to the colour passed in the fadeIn duration,
+// keeps the colour for the wait duration and fades back in the fadeOut duration. The alpha channel of the colour
+// is ignored.
+func NewFade(colour color.RGBA, fadeIn, wait, fadeOut time.Duration) Fade {
+	return Fade{colour: colour, fadeIn: fadeIn, wait: wait, fadeOut: fadeOut}
+}
+
+// Colour returns the colour that the screen is faded to.
+func (f Fade) Colour() color.RGBA {
+	return f.colour
+}
+
+// Durations returns the durations it takes to fade to the colour, that the colour is shown and that it takes to
+// fade back respectively.
+func (f Fade) Durations() (fadeIn, wait, fadeOut time.Duration) {
+	return f.fadeIn, f.wait, f.fadeOut
+}
@@ -0,0 +1,55 @@
+package camera
+
+// Preset is a camera preset that a camera instruction may be based on. Dragonfly sends the presets built into
+// the client to every player, so that they may be used in a Set instruction.
+type Preset struct {
+	preset
+}
+
+type preset uint8
+
+// Free returns the free camera Preset. It is a camera that is detached from the player, which may be positioned
+// and rotated freely. It is generally used for cutscenes and spectating.
+func Free() Preset {
+	return Preset{0}
+}
+
+// FirstPerson returns the first person camera Preset, which is the camera that players normally use.
+func FirstPerson() Preset {
+	return Preset{1}
+}
+
+// ThirdPerson returns the third person camera Preset, which shows the player from behind.
+func ThirdPerson() Preset {
+	return Preset{2}
+}
+
+// ThirdPersonFront returns the front facing third person camera Preset, which shows the player from the front.
+func ThirdPersonFront() Preset {
+	return Preset{3}
+}
+
+// Presets returns all camera presets, in the order that they are sent to the client.
+func Presets() []Preset {
+	return []Preset{Free(), FirstPerson(), ThirdPerson(), ThirdPersonFront()}
+}
+
+// Uint8 returns the index of the Preset in the list returned by Presets.
+func (p preset) Uint8() uint8 {
+	return uint8(p)
+}
+
+// Name returns the name of the Preset as known by the client, such as "minecraft:free".
+func (p preset) Name() string {
+	switch p {
+	case 0:
+		return "minecraft:free"
+	case 1:
+		return "minecraft:first_person"
+	case 2:
+		return "minecraft:third_person"
+	case 3:
+		return "minecraft:third_person_front"
+	}
+	panic("should never happen")
+}
@@ -16,11 +16,50 @@ var Global = New()
 type Chat struct {
 	m           sync.Mutex
 	subscribers map[Subscriber]struct{}
+	format      Format
+	filter      Filter
 }
 
-// New returns a new chat.
+// New returns a new chat. Messages sent to the chat using Chat.Send are formatted using DefaultFormat and are
+// delivered to all subscribers.
 func New() *Chat {
-	return &Chat{subscribers: map[Subscriber]struct{}{}}
+	return &Chat{subscribers: map[Subscriber]struct{}{}, format: DefaultFormat}
+}
+
+// NewFiltered returns a new chat that only delivers messages sent using Chat.Send to the subscribers for which
+// the Filter passed returns true. Messages written directly to the chat, such as using fmt.Fprintln, are still
+// delivered to all subscribers.
+func NewFiltered(f Filter) *Chat {
+	c := New()
+	c.filter = f
+	return c
+}
+
+// SetFormat changes the Format used to format messages sent to the chat using Chat.Send.
+func (chat *Chat) SetFormat(f Format) {
+	chat.m.Lock()
+	defer chat.m.Unlock()
+	chat.format = f
+}
+
+// Format returns the Format used to format messages sent to the chat using Chat.Send.
+func (chat *Chat) Format() Format {
+	chat.m.Lock()
+	defer chat.m.Unlock()
+	return chat.format
+}
+
+// Send sends a message by the Sender passed to the chat. The message is formatted using the Format of the
+// chat and is only delivered to the subscribers allowed by the Filter of the chat, if it has one.
+func (chat *Chat) Send(sender Sender, message string) {
+	chat.m.Lock()
+	defer chat.m.Unlock()
+	msg := chat.format.Apply(sender, message)
+	for subscriber := range chat.subscribers {
+		if chat.filter == nil || chat.filter(sender, subscriber) {
+			subscriber.Message(msg)
+		}
+	}
 }
 
 // Write writes the byte slice p as a string to the chat. It is equivalent to calling
@@ -0,0 +1,43 @@
+package chat
+
+import (
+	"github.com/df-mc/dragonfly/server/permission"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Filter decides if a message sent to a Chat by a Sender is delivered to a Subscriber of the Chat.
+type Filter func(sender Sender, subscriber Subscriber) bool
+
+// positioned is a Sender or Subscriber with a position in a world, such as a player.
+type positioned interface {
+	Position() mgl64.Vec3
+	World() *world.World
+}
+
+// NewLocal returns a new Chat that only delivers messages sent using Chat.Send to subscribers in the same
+// world as the Sender and within the radius passed. Messages by senders without a position are delivered to
+// all subscribers.
+func NewLocal(radius float64) *Chat {
+	return NewFiltered(func(sender Sender, subscriber Subscriber) bool {
+		from, ok := sender.(positioned)
+		if !ok {
+			return true
+		}
+		to, ok := subscriber.(positioned)
+		if !ok || from.World() != to.World() {
+			return false
+		}
+		return from.Position().Sub(to.Position()).Len() <= radius
+	})
+}
+
+// NewPermissioned returns a new Chat that only delivers messages sent using Chat.Send to subscribers that have
+// the permission node passed, such as a staff chat. Subscribers that do not implement permission.Holder never
+// receive these messages.
+func NewPermissioned(node string) *Chat {
+	return NewFiltered(func(_ Sender, subscriber Subscriber) bool {
+		h, ok := subscriber.(permission.Holder)
+		return ok && h.HasPermission(node)
+	})
+}
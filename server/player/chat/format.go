@@ -0,0 +1,55 @@
+package chat
+
+import (
+	"strings"
+	"sync"
+)
+
+// Sender is the sender of a message sent to a Chat using Chat.Send, such as a player.
+type Sender interface {
+	// Name returns the name of the Sender, which is used for the {name} placeholder of a Format.
+	Name() string
+}
+
+// Format is a template used to format messages sent to a Chat. It may contain placeholders in the form of
+// {placeholder}, which are replaced when the Format is applied. The {name} and {message} placeholders are
+// always available and are replaced with the name of the Sender and the message respectively. Additional
+// placeholders may be registered using RegisterPlaceholder.
+type Format string
+
+// DefaultFormat is the Format used by a Chat if no other Format is set.
+const DefaultFormat Format = "<{name}> {message}"
+
+var (
+	placeholderMu sync.RWMutex
+	placeholders  = map[string]func(s Sender) string{}
+)
+
+// RegisterPlaceholder registers a placeholder that may be used in any Format. The name passed should not
+// contain braces: Registering a placeholder with the name "rank" makes {rank} available. When a Format with
+// the placeholder is applied, f is called with the Sender of the message to obtain the value of the
+// placeholder. Registering a placeholder with a name that was already registered overwrites it.
+func RegisterPlaceholder(name string, f func(s Sender) string) {
+	placeholderMu.Lock()
+	defer placeholderMu.Unlock()
+	placeholders[name] = f
+}
+
+// Apply formats a message sent by the Sender passed, replacing all placeholders in the Format.
+func (f Format) Apply(sender Sender, message string) string {
+	s := string(f)
+	if strings.Contains(s, "{name}") {
+		s = strings.ReplaceAll(s, "{name}", sender.Name())
+	}
+
+	placeholderMu.RLock()
+	for name, value := range placeholders {
+		if p := "{" + name + "}"; strings.Contains(s, p) {
+			s = strings.ReplaceAll(s, p, value(sender))
+		}
+	}
+	placeholderMu.RUnlock()
+
+	// The message is replaced last, so that placeholders in the message itself are never replaced.
+	return strings.ReplaceAll(s, "{message}", message)
+}
@@ -0,0 +1,115 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"math"
+	"time"
+)
+
+// Combat holds the settings used when a player attacks other entities in melee combat.
+type Combat struct {
+	// Cooldown is the minimum duration between two attacks of the player. Attacks performed before the cooldown
+	// has passed are ignored. If 0, the player may attack as often as its client allows.
+	Cooldown time.Duration
+	// KnockBackForce is the horizontal force with which entities attacked by the player are knocked back,
+	// before the KnockBack enchantment is applied.
+	KnockBackForce float64
+	// KnockBackHeight is the vertical force with which entities attacked by the player are knocked back, before
+	// the KnockBack enchantment is applied.
+	KnockBackHeight float64
+}
+
+// DefaultCombat returns the Combat settings that match those of vanilla Minecraft.
+func DefaultCombat() Combat {
+	return Combat{KnockBackForce: 0.45, KnockBackHeight: 0.3608}
+}
+
+// SetCombat changes the Combat settings used when the player attacks other entities.
+func (p *Player) SetCombat(c Combat) {
+	p.combat.Store(c)
+}
+
+// Combat returns the Combat settings of the player, as set using SetCombat.
+func (p *Player) Combat() Combat {
+	return p.combat.Load()
+}
+
+// attackCooldown checks if the player is still on cooldown from its previous attack. If not, the time of the
+// attack is stored and false is returned.
+func (p *Player) attackCooldown() bool {
+	now := time.Now()
+	if c := p.combat.Load().Cooldown; c > 0 && now.Sub(p.lastAttack.Load()) < c {
+		return true
+	}
+	p.lastAttack.Store(now)
+	return false
+}
+
+// shieldDisableDuration is the duration for which the shield of a player is disabled after being hit by an axe.
+const shieldDisableDuration = time.Second * 5
+
+// Blocking checks if the player is currently blocking with a shield. A player blocks if it is sneaking while
+// holding a shield in either hand, unless the shield was recently disabled by an axe.
+func (p *Player) Blocking() bool {
+	if !p.Sneaking() || p.HasCooldown(item.Shield{}) {
+		return false
+	}
+	mainHand, offHand := p.HeldItems()
+	_, main := mainHand.Item().(item.Shield)
+	_, off := offHand.Item().(item.Shield)
+	return main || off
+}
+
+// blockedByShield checks if the damage dealt by the source passed is blocked by the shield of the player. Only
+// damage from attacks and projectiles coming from the front of the player may be blocked. If the damage is
+// blocked, the shield is damaged accordingly. Attacks with an axe additionally disable the shield for a while.
+func (p *Player) blockedByShield(dmg float64, src world.DamageSource) bool {
+	var origin world.Entity
+	s, melee := src.(entity.AttackDamageSource)
+	if melee {
+		origin = s.Attacker
+	} else if s, ok := src.(entity.ProjectileDamageSource); ok {
+		origin = s.Projectile
+	}
+	if origin == nil || !p.Blocking() {
+		return false
+	}
+	dir := origin.Position().Sub(p.Position())
+	dir[1] = 0
+	look := p.Rotation().Vec3()
+	look[1] = 0
+	if dir.Len() == 0 || look.Len() == 0 || dir.Normalize().Dot(look.Normalize()) <= 0 {
+		// The damage came from behind the player, so the shield can't block it.
+		return false
+	}
+
+	if dmg >= 3 {
+		mainHand, offHand := p.HeldItems()
+		durability := int(math.Floor(dmg)) + 1
+		if _, ok := offHand.Item().(item.Shield); ok {
+			p.SetHeldItems(mainHand, p.damageItem(offHand, durability))
+		} else {
+			p.SetHeldItems(p.damageItem(mainHand, durability), offHand)
+		}
+	}
+	p.World().PlaySound(p.Position(), sound.ShieldBlock{})
+
+	if holder, ok := origin.(interface {
+		HeldItems() (item.Stack, item.Stack)
+	}); ok && melee {
+		if held, _ := holder.HeldItems(); isAxe(held) {
+			p.SetCooldown(item.Shield{}, shieldDisableDuration)
+			p.updateState()
+		}
+	}
+	return true
+}
+
+// isAxe checks if the item.Stack passed holds an axe.
+func isAxe(s item.Stack) bool {
+	_, ok := s.Item().(item.Axe)
+	return ok
+}
@@ -1,8 +1,12 @@
 package player
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player/ability"
+	"github.com/df-mc/dragonfly/server/player/progression"
+	"github.com/df-mc/dragonfly/server/player/stats"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
@@ -44,6 +48,13 @@ type Data struct {
 	MaxAirSupply int64
 	// GameMode is the last gamemode the user had, like creative or survival.
 	GameMode world.GameMode
+	// Abilities holds the abilities of the player that differ from the defaults of its GameMode, together with
+	// its fly and walk speeds.
+	Abilities ability.Abilities
+	// Progress holds the progress the player has made towards milestones and the milestones it has completed.
+	Progress progression.Progress
+	// Statistics holds the statistics tracked for the player, such as the blocks it mined.
+	Statistics stats.Statistics
 	// Inventory contains all the items in the inventory, including armor, main inventory and offhand.
 	Inventory InventoryData
 	// EnderChestInventory contains the items in the player's ender chest.
@@ -54,6 +65,9 @@ type Data struct {
 	FireTicks int64
 	// FallDistance is the distance the player has currently been falling. This is used to calculate fall damage.
 	FallDistance float64
+	// SpawnPoint is the position that the player respawns at after dying, as set using Player.SetSpawnPoint. If
+	// nil, the player respawns at the spawn of the world.
+	SpawnPoint *cube.Pos
 	// World is the world the player was last in.
 	World *world.World
 }
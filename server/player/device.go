@@ -0,0 +1,33 @@
+package player
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+)
+
+// Device holds information about the device and client that a player joined the server with, as sent in the
+// connection request of the client. The information cannot be trusted, as it may be changed freely by the
+// player connecting.
+type Device struct {
+	// OS is the operating system of the device, such as protocol.DeviceAndroid.
+	OS protocol.DeviceOS
+	// Model is the model of the device. It may be empty on some platforms.
+	Model string
+	// ID is the ID of the device.
+	ID string
+	// GameVersion is the version of the client, such as "1.20.50".
+	GameVersion string
+	// PlayFabID is the PlayFab ID of the player.
+	PlayFabID string
+}
+
+// DeviceFromClientData parses the Device of a player from the login.ClientData sent by its client.
+func DeviceFromClientData(d login.ClientData) Device {
+	return Device{
+		OS:          d.DeviceOS,
+		Model:       d.DeviceModel,
+		ID:          d.DeviceID,
+		GameVersion: d.GameVersion,
+		PlayFabID:   d.PlayFabID,
+	}
+}
@@ -0,0 +1,55 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/i18n"
+)
+
+// DisconnectCause is the cause of a player being disconnected from the server.
+type DisconnectCause int
+
+const (
+	// DisconnectKick is the cause of a player being disconnected using Kick, KickTranslated or Disconnect.
+	DisconnectKick DisconnectCause = iota
+	// DisconnectClose is the cause of a player being closed using Close, without a message.
+	DisconnectClose
+	// DisconnectQuit is the cause of a player closing its connection, for example by leaving the game.
+	DisconnectQuit
+	// DisconnectTimeout is the cause of a player whose client stopped responding.
+	DisconnectTimeout
+)
+
+// String returns the cause as a readable string, such as "kick".
+func (c DisconnectCause) String() string {
+	switch c {
+	case DisconnectKick:
+		return "kick"
+	case DisconnectClose:
+		return "close"
+	case DisconnectQuit:
+		return "quit"
+	case DisconnectTimeout:
+		return "timeout"
+	}
+	panic("should never happen")
+}
+
+// Kick disconnects the player, showing the reason passed on its disconnection screen. The reason is formatted
+// following the rules of fmt.Sprintln without a newline at the end. Formatting codes may be used in the
+// reason, for example by formatting it using text.Colourf.
+func (p *Player) Kick(reason ...any) {
+	p.disconnect(DisconnectKick, format(reason))
+}
+
+// KickTranslated disconnects the player, showing the message with the key passed from the i18n.Bundle passed
+// on its disconnection screen, translated to the locale of the Player. The args passed are formatted into the
+// message according to the rules of fmt.Sprintf.
+func (p *Player) KickTranslated(b *i18n.Bundle, key string, args ...any) {
+	p.disconnect(DisconnectKick, p.Translate(b, key, args...))
+}
+
+// disconnect closes the player with a cause and a message shown to it, if it is connected.
+func (p *Player) disconnect(cause DisconnectCause, msg string) {
+	p.once.Do(func() {
+		p.close(cause, msg)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 	"unicode/utf8"
@@ -21,6 +22,8 @@ type Form interface {
 type Custom struct {
 	title       string
 	submittable Submittable
+	elements    []Element
+	close       func(submitter Submitter)
 }
 
 // MarshalJSON ...
@@ -53,7 +56,24 @@ func (f Custom) Title() string {
 	return f.title
 }
 
-// Elements returns a list of all elements as set in the Submittable passed to form.New().
+// WithElements creates a copy of the Custom form and appends the elements passed to the existing elements,
+// after which the new Custom form is returned. The values submitted for these elements are passed to the
+// function of a form created using NewFunc.
+func (f Custom) WithElements(elements ...Element) Custom {
+	f.elements = append(append([]Element(nil), f.elements...), elements...)
+	return f
+}
+
+// WithCloseFunc creates a copy of the Custom form with a function that is called when the Submitter closes
+// the form without submitting it. The function is called in addition to the Close method of a Submittable
+// implementing Closer.
+func (f Custom) WithCloseFunc(close func(submitter Submitter)) Custom {
+	f.close = close
+	return f
+}
+
+// Elements returns a list of all elements as set in the Submittable passed to form.New(), followed by the
+// elements added using WithElements.
 func (f Custom) Elements() []Element {
 	v := reflect.New(reflect.TypeOf(f.submittable)).Elem()
 	v.Set(reflect.ValueOf(f.submittable))
@@ -68,7 +88,7 @@ func (f Custom) Elements() []Element {
 		// Each exported field is guaranteed to implement the Element interface.
 		elements = append(elements, field.Interface().(Element))
 	}
-	return elements
+	return append(elements, f.elements...)
 }
 
 // SubmitJSON submits a JSON data slice to the form. The form will check all values in the JSON array passed,
@@ -77,9 +97,7 @@ func (f Custom) Elements() []Element {
 // called and the fields of the Submittable will be filled out.
 func (f Custom) SubmitJSON(b []byte, submitter Submitter) error {
 	if b == nil {
-		if closer, ok := f.submittable.(Closer); ok {
-			closer.Close(submitter)
-		}
+		closeForm(f.submittable, f.close, submitter)
 		return nil
 	}
 
@@ -109,7 +127,26 @@ func (f Custom) SubmitJSON(b []byte, submitter Submitter) error {
 		fieldV.Set(elem)
 		data = data[1:]
 	}
+	elements := make([]Element, 0, len(f.elements))
+	for _, e := range f.elements {
+		if len(data) == 0 {
+			return fmt.Errorf("form JSON data array does not have enough values")
+		}
+		elem, err := f.parseValue(e, data[0])
+		if err != nil {
+			return fmt.Errorf("error parsing form response value: %w", err)
+		}
+		elements = append(elements, elem.Interface().(Element))
+		data = data[1:]
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("form JSON data array has %v values too many", len(data))
+	}
 
+	if fn, ok := f.submittable.(customFunc); ok {
+		fn.submit(submitter, elements)
+		return nil
+	}
 	v.Interface().(Submittable).Submit(submitter)
 
 	return nil
@@ -148,6 +185,12 @@ func (f Custom) parseValue(elem Element, s any) (reflect.Value, error) {
 		if f > element.Max || f < element.Min {
 			return value, fmt.Errorf("slider value %v is out of range %v-%v", f, element.Min, element.Max)
 		}
+		if element.StepSize > 0 {
+			// Allow for a small error, as the client may not submit the exact value of a step.
+			if steps := (f - element.Min) / element.StepSize; math.Abs(steps-math.Round(steps)) > 1e-3 {
+				return value, fmt.Errorf("slider value %v is not a multiple of step size %v", f, element.StepSize)
+			}
+		}
 		element.value = f
 		value = reflect.ValueOf(element)
 	case Dropdown:
@@ -165,13 +208,15 @@ func (f Custom) parseValue(elem Element, s any) (reflect.Value, error) {
 		v, ok := s.(json.Number)
 		f, err := v.Int64()
 		if !ok || err != nil {
-			return value, fmt.Errorf("value %v is not allowed for dropdown element", s)
+			return value, fmt.Errorf("value %v is not allowed for step slider element", s)
 		}
 		if f < 0 || int(f) >= len(element.Options) {
-			return value, fmt.Errorf("dropdown value %v is out of range %v-%v", f, 0, len(element.Options)-1)
+			return value, fmt.Errorf("step slider value %v is out of range %v-%v", f, 0, len(element.Options)-1)
 		}
 		element.value = int(f)
 		value = reflect.ValueOf(element)
+	default:
+		return value, fmt.Errorf("unknown form element %T", elem)
 	}
 	return value, nil
 }
@@ -195,6 +240,17 @@ func (f Custom) verify() {
 	}
 }
 
+// closeForm calls the Close method of the submittable passed if it implements Closer, and the close function
+// passed if it is not nil.
+func closeForm(submittable any, close func(submitter Submitter), submitter Submitter) {
+	if closer, ok := submittable.(Closer); ok {
+		closer.Close(submitter)
+	}
+	if close != nil {
+		close(submitter)
+	}
+}
+
 // format is a utility function to format a list of values to have spaces between them, but no newline at the
 // end.
 func format(a []any) string {
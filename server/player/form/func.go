@@ -0,0 +1,99 @@
+package form
+
+// NewFunc creates a new Custom form with the title passed, without the need for a Submittable struct. The
+// elements of the form are added using Custom.WithElements. When the form is submitted, submit is called with
+// a Response holding the elements, filled out with the values submitted. The title is formatted according to
+// the rules of fmt.Sprintln.
+func NewFunc(submit func(submitter Submitter, response Response), title ...any) Custom {
+	return New(customFunc{submit: func(submitter Submitter, elements []Element) {
+		submit(submitter, elements)
+	}}, title...)
+}
+
+// NewMenuFunc creates a new Menu form with the title passed, without the need for a MenuSubmittable struct.
+// The buttons of the form are added using Menu.WithButtons. When a button is pressed, submit is called with
+// the index of the button and the button itself. The title is formatted according to the rules of
+// fmt.Sprintln.
+func NewMenuFunc(submit func(submitter Submitter, index int, pressed Button), title ...any) Menu {
+	return NewMenu(menuFunc{submit: submit}, title...)
+}
+
+// NewModalFunc creates a new Modal form with the title passed and the two buttons passed, without the need
+// for a ModalSubmittable struct. YesButton and NoButton may be passed for the default buttons. When one of
+// the buttons is pressed, submit is called with true if the first button was pressed and false if the second
+// button was pressed. The title is formatted according to the rules of fmt.Sprintln.
+func NewModalFunc(submit func(submitter Submitter, yes bool), yes, no Button, title ...any) Modal {
+	return NewModal(modalFunc{Yes: yes, No: no, submit: submit}, title...)
+}
+
+// Response holds the elements of a Custom form created using NewFunc, filled out with the values submitted.
+// The elements are in the same order as they were added to the form, and labels are included.
+type Response []Element
+
+// Input returns the value submitted for the Input element at the index passed. An empty string is returned if
+// the element at the index is not an Input.
+func (r Response) Input(index int) string {
+	v, _ := r.element(index).(Input)
+	return v.Value()
+}
+
+// Toggle returns the value submitted for the Toggle element at the index passed. False is returned if the
+// element at the index is not a Toggle.
+func (r Response) Toggle(index int) bool {
+	v, _ := r.element(index).(Toggle)
+	return v.Value()
+}
+
+// Slider returns the value submitted for the Slider element at the index passed. 0 is returned if the element
+// at the index is not a Slider.
+func (r Response) Slider(index int) float64 {
+	v, _ := r.element(index).(Slider)
+	return v.Value()
+}
+
+// Dropdown returns the index of the option selected for the Dropdown element at the index passed. 0 is
+// returned if the element at the index is not a Dropdown.
+func (r Response) Dropdown(index int) int {
+	v, _ := r.element(index).(Dropdown)
+	return v.Value()
+}
+
+// StepSlider returns the index of the option selected for the StepSlider element at the index passed. 0 is
+// returned if the element at the index is not a StepSlider.
+func (r Response) StepSlider(index int) int {
+	v, _ := r.element(index).(StepSlider)
+	return v.Value()
+}
+
+// element returns the Element at the index passed, or nil if the index is out of range.
+func (r Response) element(index int) Element {
+	if index < 0 || index >= len(r) {
+		return nil
+	}
+	return r[index]
+}
+
+// customFunc is the Submittable used for forms created using NewFunc.
+type customFunc struct {
+	submit func(submitter Submitter, elements []Element)
+}
+
+// Submit ...
+func (customFunc) Submit(Submitter) {}
+
+// menuFunc is the MenuSubmittable used for forms created using NewMenuFunc.
+type menuFunc struct {
+	submit func(submitter Submitter, index int, pressed Button)
+}
+
+// Submit ...
+func (menuFunc) Submit(Submitter, Button) {}
+
+// modalFunc is the ModalSubmittable used for forms created using NewModalFunc.
+type modalFunc struct {
+	Yes, No Button
+	submit  func(submitter Submitter, yes bool)
+}
+
+// Submit ...
+func (modalFunc) Submit(Submitter, Button) {}
@@ -12,6 +12,7 @@ type Menu struct {
 	title, body string
 	submittable MenuSubmittable
 	buttons     []Button
+	close       func(submitter Submitter)
 }
 
 // NewMenu creates a new Menu form using the MenuSubmittable passed to handle the output of the form. The
@@ -50,6 +51,14 @@ func (m Menu) WithButtons(buttons ...Button) Menu {
 	return m
 }
 
+// WithCloseFunc creates a copy of the Menu form with a function that is called when the Submitter closes the
+// form without pressing a button. The function is called in addition to the Close method of a submittable
+// implementing Closer.
+func (m Menu) WithCloseFunc(close func(submitter Submitter)) Menu {
+	m.close = close
+	return m
+}
+
 // Title returns the formatted title passed to the menu upon construction using NewMenu().
 func (m Menu) Title() string {
 	return m.title
@@ -82,9 +91,7 @@ func (m Menu) Buttons() []Button {
 // SubmitJSON submits a JSON value to the menu, containing the index of the button clicked.
 func (m Menu) SubmitJSON(b []byte, submitter Submitter) error {
 	if b == nil {
-		if closer, ok := m.submittable.(Closer); ok {
-			closer.Close(submitter)
-		}
+		closeForm(m.submittable, m.close, submitter)
 		return nil
 	}
 
@@ -97,6 +104,10 @@ func (m Menu) SubmitJSON(b []byte, submitter Submitter) error {
 	if index >= uint(len(buttons)) {
 		return fmt.Errorf("button index points to inexistent button: %v (only %v buttons present)", index, len(buttons))
 	}
+	if fn, ok := m.submittable.(menuFunc); ok {
+		fn.submit(submitter, int(index), buttons[index])
+		return nil
+	}
 	m.submittable.Submit(submitter, buttons[index])
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 type Modal struct {
 	title, body string
 	submittable ModalSubmittable
+	close       func(submitter Submitter)
 }
 
 // NewModal creates a new Modal form using the ModalSubmittable passed to handle the output of the form. The
@@ -56,6 +57,14 @@ func (m Modal) WithBody(body ...any) Modal {
 	return m
 }
 
+// WithCloseFunc creates a copy of the Modal form with a function that is called when the Submitter closes the
+// form without pressing a button. The function is called in addition to the Close method of a submittable
+// implementing Closer.
+func (m Modal) WithCloseFunc(close func(submitter Submitter)) Modal {
+	m.close = close
+	return m
+}
+
 // Title returns the formatted title passed to the menu upon construction using NewModal().
 func (m Modal) Title() string {
 	return m.title
@@ -70,9 +79,7 @@ func (m Modal) Body() string {
 // which is used to determine which button was clicked.
 func (m Modal) SubmitJSON(b []byte, submitter Submitter) error {
 	if b == nil {
-		if closer, ok := m.submittable.(Closer); ok {
-			closer.Close(submitter)
-		}
+		closeForm(m.submittable, m.close, submitter)
 		return nil
 	}
 
@@ -80,6 +87,10 @@ func (m Modal) SubmitJSON(b []byte, submitter Submitter) error {
 	if err := json.Unmarshal(b, &value); err != nil {
 		return fmt.Errorf("error parsing JSON as bool: %w", err)
 	}
+	if fn, ok := m.submittable.(modalFunc); ok {
+		fn.submit(submitter, value)
+		return nil
+	}
 	if value {
 		m.submittable.Submit(submitter, m.Buttons()[0])
 		return nil
@@ -0,0 +1,27 @@
+package gui
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// Button is a slot in a Menu. A Button is displayed as an item in the Menu and runs a function when clicked by
+// the Viewer of the Menu, rather than allowing the item to be taken out.
+type Button struct {
+	// Item is the item displayed in the slot of the Button.
+	Item item.Stack
+
+	click func(v Viewer)
+}
+
+// NewButton creates a Button displaying the item passed. The function passed is called when a Viewer clicks
+// the Button. It may be nil to create a Button that does nothing when clicked, such as a decorative item.
+func NewButton(it item.Stack, click func(v Viewer)) Button {
+	return Button{Item: it, click: click}
+}
+
+// Click runs the function of the Button for the Viewer passed, as if it clicked the Button.
+func (b Button) Click(v Viewer) {
+	if b.click != nil {
+		b.click(v)
+	}
+}
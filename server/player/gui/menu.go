@@ -0,0 +1,127 @@
+package gui
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"golang.org/x/exp/maps"
+	"strings"
+)
+
+// Viewer is a viewer of a Menu, typically a *player.Player. Functions of a Button are called with the Viewer
+// that clicked it.
+type Viewer interface {
+	// OpenMenu opens the Menu passed for the Viewer, replacing any container or Menu it currently has opened.
+	OpenMenu(m Menu)
+	// CloseMenu closes the Menu that the Viewer currently has opened, if any.
+	CloseMenu()
+}
+
+// Menu is a container window defined by the server, such as a chest, that is filled with Buttons. Unlike a
+// normal container, items cannot be taken out of or put into a Menu: Clicking a slot of the Menu runs the
+// function of the Button in that slot instead.
+// A Menu is immutable: Methods that change it return a new Menu, so that a single Menu may safely be opened
+// for many viewers at once.
+type Menu struct {
+	t       Type
+	name    string
+	buttons map[int]Button
+	close   func(v Viewer)
+}
+
+// New creates an empty Menu of the Type passed. The name passed is displayed at the top of the Menu and is
+// formatted following the rules of fmt.Sprintln.
+func New(t Type, name ...any) Menu {
+	return Menu{t: t, name: format(name), buttons: map[int]Button{}}
+}
+
+// WithButton creates a copy of the Menu with the Button passed placed in a specific slot, after which the new
+// Menu is returned. Any Button already in the slot is replaced. WithButton panics if the slot is not within the
+// Menu.
+func (m Menu) WithButton(slot int, b Button) Menu {
+	if slot < 0 || slot >= m.t.Size() {
+		panic(fmt.Sprintf("slot %v is out of range for menu of size %v", slot, m.t.Size()))
+	}
+	m.buttons = m.cloneButtons()
+	m.buttons[slot] = b
+	return m
+}
+
+// WithButtons creates a copy of the Menu with the Buttons passed placed in the first empty slots, after which
+// the new Menu is returned. WithButtons panics if the Menu does not have enough empty slots left.
+func (m Menu) WithButtons(buttons ...Button) Menu {
+	m.buttons = m.cloneButtons()
+	slot := 0
+	for _, b := range buttons {
+		for ; ; slot++ {
+			if slot >= m.t.Size() {
+				panic("menu has no empty slots left")
+			}
+			if _, ok := m.buttons[slot]; !ok {
+				break
+			}
+		}
+		m.buttons[slot] = b
+	}
+	return m
+}
+
+// WithCloseFunc creates a copy of the Menu with a function that is called when the Viewer closes the Menu,
+// after which the new Menu is returned. The function is also called when the Menu is closed because another
+// container or Menu was opened.
+func (m Menu) WithCloseFunc(close func(v Viewer)) Menu {
+	m.close = close
+	return m
+}
+
+// Type returns the Type of the Menu, as passed to New.
+func (m Menu) Type() Type {
+	return m.t
+}
+
+// Name returns the formatted name of the Menu, as passed to New.
+func (m Menu) Name() string {
+	return m.name
+}
+
+// Button returns the Button in the slot passed. False is returned if the slot does not hold a Button.
+func (m Menu) Button(slot int) (Button, bool) {
+	b, ok := m.buttons[slot]
+	return b, ok
+}
+
+// Items returns the items displayed in all slots of the Menu. Slots without a Button hold an empty item.Stack.
+func (m Menu) Items() []item.Stack {
+	items := make([]item.Stack, m.t.Size())
+	for slot, b := range m.buttons {
+		items[slot] = b.Item
+	}
+	return items
+}
+
+// Click clicks the slot passed for the Viewer, running the function of the Button in that slot, if any.
+func (m Menu) Click(v Viewer, slot int) {
+	if b, ok := m.buttons[slot]; ok {
+		b.Click(v)
+	}
+}
+
+// Close runs the close function of the Menu set using WithCloseFunc for the Viewer passed.
+func (m Menu) Close(v Viewer) {
+	if m.close != nil {
+		m.close(v)
+	}
+}
+
+// cloneButtons returns a copy of the buttons of the Menu that may be modified without changing the Menu.
+func (m Menu) cloneButtons() map[int]Button {
+	if m.buttons == nil {
+		return map[int]Button{}
+	}
+	return maps.Clone(m.buttons)
+}
+
+// format is a utility function to format a list of values to have spaces between them, but no newline at the
+// end.
+func format(a []any) string {
+	return strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+}
@@ -0,0 +1,55 @@
+package gui
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// Paginate spreads the Buttons passed over as many pages as needed and returns a Menu for every page. The Menu
+// m passed is used as a template for all pages: Buttons already in it, such as a border of decorative items,
+// are present on every page, and the remaining empty slots are filled with the Buttons passed.
+// The first and last slot of the bottom row of every page are reserved for buttons displaying the previous and
+// next items passed, which open the previous and next page respectively. These buttons are only added if such a
+// page exists. Paginate always returns at least one page and panics if the template has no empty slots left
+// for the Buttons passed.
+func Paginate(m Menu, buttons []Button, previous, next item.Stack) []Menu {
+	size := m.t.Size()
+	prevSlot, nextSlot := size-m.t.RowSize(), size-1
+
+	free := make([]int, 0, size)
+	for slot := 0; slot < size; slot++ {
+		if _, ok := m.buttons[slot]; !ok && slot != prevSlot && slot != nextSlot {
+			free = append(free, slot)
+		}
+	}
+	if len(free) == 0 {
+		panic("menu has no empty slots left for pagination")
+	}
+	n := (len(buttons) + len(free) - 1) / len(free)
+	if n == 0 {
+		n = 1
+	}
+	pages := make([]Menu, n)
+	for i := range pages {
+		page := m
+		for j, slot := range free {
+			index := i*len(free) + j
+			if index >= len(buttons) {
+				break
+			}
+			page = page.WithButton(slot, buttons[index])
+		}
+		i := i
+		if i > 0 {
+			page = page.WithButton(prevSlot, NewButton(previous, func(v Viewer) {
+				v.OpenMenu(pages[i-1])
+			}))
+		}
+		if i < n-1 {
+			page = page.WithButton(nextSlot, NewButton(next, func(v Viewer) {
+				v.OpenMenu(pages[i+1])
+			}))
+		}
+		pages[i] = page
+	}
+	return pages
+}
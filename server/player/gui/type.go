@@ -0,0 +1,46 @@
+package gui
+
+// Type is the type of container that a Menu is displayed in. The Type of a Menu determines the amount of slots
+// it has and how these slots are laid out.
+type Type struct {
+	t uint8
+}
+
+// Chest returns a Type for a Menu displayed in a single chest, with 3 rows of 9 slots.
+func Chest() Type {
+	return Type{0}
+}
+
+// DoubleChest returns a Type for a Menu displayed in a double chest, with 6 rows of 9 slots.
+func DoubleChest() Type {
+	return Type{1}
+}
+
+// Hopper returns a Type for a Menu displayed in a hopper, with a single row of 5 slots.
+func Hopper() Type {
+	return Type{2}
+}
+
+// Size returns the total amount of slots in a Menu of the Type.
+func (t Type) Size() int {
+	switch t.t {
+	case 1:
+		return 54
+	case 2:
+		return 5
+	}
+	return 27
+}
+
+// RowSize returns the amount of slots in a single row of a Menu of the Type.
+func (t Type) RowSize() int {
+	if t.t == 2 {
+		return 5
+	}
+	return 9
+}
+
+// Uint8 returns the Type as a uint8.
+func (t Type) Uint8() uint8 {
+	return t.t
+}
@@ -5,9 +5,12 @@ import (
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player/progression"
+	"github.com/df-mc/dragonfly/server/player/settings"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
 	"net"
 	"time"
 )
@@ -20,6 +23,10 @@ type Handler interface {
 	HandleMove(ctx *event.Context, newPos mgl64.Vec3, newYaw, newPitch float64)
 	// HandleJump handles the player jumping.
 	HandleJump()
+	// HandleMovementViolation handles movement sent by the client of the player that violates its
+	// MovementValidation. ctx.Cancel() may be called to accept the movement anyway. If not cancelled, the
+	// player is moved back to the position it moved from.
+	HandleMovementViolation(ctx *event.Context, v MovementViolation)
 	// HandleTeleport handles the teleportation of a player. ctx.Cancel() may be called to cancel it.
 	HandleTeleport(ctx *event.Context, pos mgl64.Vec3)
 	// HandleChangeWorld handles when the player is added to a new world. before may be nil.
@@ -45,8 +52,16 @@ type Handler interface {
 	// damage being dealt to the player.
 	// The damage dealt to the player may be changed by assigning to *damage.
 	HandleHurt(ctx *event.Context, damage *float64, attackImmunity *time.Duration, src world.DamageSource)
-	// HandleDeath handles the player dying to a particular damage cause.
+	// HandleDeath handles the player dying to a particular damage cause. *keepInv is initially set to the
+	// KeepInventory setting of the world.World and may be changed to decide if the items and experience of the
+	// player are dropped.
 	HandleDeath(src world.DamageSource, keepInv *bool)
+	// HandleSleep handles the player starting to sleep in the bed at the position passed. ctx.Cancel() may be
+	// called to prevent the player from sleeping.
+	HandleSleep(ctx *event.Context, pos cube.Pos)
+	// HandleTotemUse handles a totem of undying held by the player preventing its death. ctx.Cancel() may be
+	// called to cancel the use of the totem, in which case the player dies.
+	HandleTotemUse(ctx *event.Context, src world.DamageSource)
 	// HandleRespawn handles the respawning of the player in the world. The spawn position passed may be
 	// changed by assigning to *pos. The world.World in which the Player is respawned may be modifying by assigning to
 	// *w. This world may be the world the Player died in, but it might also point to a different world (the overworld)
@@ -55,6 +70,12 @@ type Handler interface {
 	// HandleSkinChange handles the player changing their skin. ctx.Cancel() may be called to cancel the skin
 	// change.
 	HandleSkinChange(ctx *event.Context, skin *skin.Skin)
+	// HandleClientSettingsChange handles the client of the player changing its settings, such as its render
+	// distance or input mode. The settings before and after the change are passed.
+	HandleClientSettingsChange(before, after settings.Settings)
+	// HandleEmote handles the player performing an emote with the UUID passed. ctx.Cancel() may be called to
+	// stop the emote from being shown to viewers.
+	HandleEmote(ctx *event.Context, emote uuid.UUID)
 	// HandleStartBreak handles the player starting to break a block at the position passed. ctx.Cancel() may
 	// be called to stop the player from breaking the block completely.
 	HandleStartBreak(ctx *event.Context, pos cube.Pos)
@@ -65,6 +86,14 @@ type Handler interface {
 	// HandleBlockPlace handles the player placing a specific block at a position in its world. ctx.Cancel()
 	// may be called to cancel the block being placed.
 	HandleBlockPlace(ctx *event.Context, pos cube.Pos, b world.Block)
+	// HandleBlockPlaceModify handles the player placing a block at a position in its world and is called before
+	// HandleBlockPlace. The block pointed to by b may be changed to alter the block that is actually placed.
+	// Setting it to nil or calling ctx.Cancel() cancels the block being placed.
+	HandleBlockPlaceModify(ctx *event.Context, pos cube.Pos, b *world.Block)
+	// HandleBlockInteract handles the player interacting with a block at a position in its world, for example
+	// by opening a door or a container. ctx.Cancel() may be called to cancel the interaction, after which the
+	// item held by the player may still be used on the block.
+	HandleBlockInteract(ctx *event.Context, pos cube.Pos, face cube.Face, b world.Block)
 	// HandleBlockPick handles the player picking a specific block at a position in its world. ctx.Cancel()
 	// may be called to cancel the block being picked.
 	HandleBlockPick(ctx *event.Context, pos cube.Pos, b world.Block)
@@ -83,6 +112,17 @@ type Handler interface {
 	// the item actually does anything when used on an entity. It is also called if the player is holding no
 	// item.
 	HandleItemUseOnEntity(ctx *event.Context, e world.Entity)
+	// HandlePlayerInteract handles another player interacting with the player, for example by right-clicking
+	// it. It is called after HandleItemUseOnEntity of the other player. ctx.Cancel() may be called to prevent
+	// the item held by the other player from being used on the player.
+	HandlePlayerInteract(ctx *event.Context, by *Player)
+	// HandleItemStartUse handles the player starting to use an item over a longer duration, such as when it
+	// starts eating food or drawing a bow. ctx.Cancel() may be called to prevent the player from using the item.
+	HandleItemStartUse(ctx *event.Context, item item.Stack)
+	// HandleItemRelease handles the player releasing an item that it was using over a longer duration, such as
+	// a bow, after using it for the duration passed. ctx.Cancel() may be called to cancel the release, in which
+	// case the item is not used at all.
+	HandleItemRelease(ctx *event.Context, item item.Stack, dur time.Duration)
 	// HandleItemConsume handles the player consuming an item. This is called whenever a consumable such as
 	// food is consumed.
 	HandleItemConsume(ctx *event.Context, item item.Stack)
@@ -93,15 +133,20 @@ type Handler interface {
 	// and the target won't be knocked back.
 	// The entity attacked may also be immune when this method is called, in which case no damage and knock-
 	// back will be dealt.
+	// The damage dealt, taking into account the item held, its Sharpness enchantment and the effects of the
+	// player, may be modified. It does not yet include the critical hit multiplier.
 	// The knock back force and height is also provided which can be modified.
 	// The attack can be a critical attack, which would increase damage by a factor of 1.5 and
 	// spawn critical hit particles around the target entity. These particles will not be displayed
 	// if no damage is dealt.
-	HandleAttackEntity(ctx *event.Context, e world.Entity, force, height *float64, critical *bool)
+	HandleAttackEntity(ctx *event.Context, e world.Entity, dmg, force, height *float64, critical *bool)
 	// HandleExperienceGain handles the player gaining experience. ctx.Cancel() may be called to cancel
 	// the gain.
 	// The amount is also provided which can be modified.
 	HandleExperienceGain(ctx *event.Context, amount *int)
+	// HandleMilestoneComplete handles the player completing a progression.Milestone. ctx.Cancel() may be called
+	// to prevent the milestone from being completed. The progress made towards it is kept.
+	HandleMilestoneComplete(ctx *event.Context, m progression.Milestone)
 	// HandlePunchAir handles the player punching air.
 	HandlePunchAir(ctx *event.Context)
 	// HandleSignEdit handles the player editing a sign. It is called for every keystroke while editing a sign and
@@ -128,6 +173,16 @@ type Handler interface {
 	// HandleCommandExecution handles the command execution of a player, who wrote a command in the chat.
 	// ctx.Cancel() may be called to cancel the command execution.
 	HandleCommandExecution(ctx *event.Context, command cmd.Command, args []string)
+	// HandlePacketLimit handles the player repeatedly sending a packet faster than allowed, such as when
+	// spamming chat messages or inventory transactions. The packet passed is the name of the packet, such
+	// as "Text". The player is disconnected unless ctx.Cancel() is called. Packets exceeding the limit are
+	// dropped regardless.
+	HandlePacketLimit(ctx *event.Context, packet string)
+	// HandleDisconnect handles the player being disconnected, regardless of the cause. The message passed is
+	// the message shown to the player on its disconnection screen, if any. HandleDisconnect is called right
+	// before HandleQuit, while the player is still in its world and its inventories, entities and chunks are
+	// not yet cleaned up.
+	HandleDisconnect(cause DisconnectCause, message string)
 	// HandleQuit handles the closing of a player. It is always called when the player is disconnected,
 	// regardless of the reason.
 	HandleQuit()
@@ -141,35 +196,49 @@ type NopHandler struct{}
 // Compile time check to make sure NopHandler implements Handler.
 var _ Handler = NopHandler{}
 
-func (NopHandler) HandleItemDrop(*event.Context, world.Entity)                                {}
-func (NopHandler) HandleMove(*event.Context, mgl64.Vec3, float64, float64)                    {}
-func (NopHandler) HandleJump()                                                                {}
-func (NopHandler) HandleTeleport(*event.Context, mgl64.Vec3)                                  {}
-func (NopHandler) HandleChangeWorld(*world.World, *world.World)                               {}
-func (NopHandler) HandleToggleSprint(*event.Context, bool)                                    {}
-func (NopHandler) HandleToggleSneak(*event.Context, bool)                                     {}
-func (NopHandler) HandleCommandExecution(*event.Context, cmd.Command, []string)               {}
-func (NopHandler) HandleTransfer(*event.Context, *net.UDPAddr)                                {}
-func (NopHandler) HandleChat(*event.Context, *string)                                         {}
-func (NopHandler) HandleSkinChange(*event.Context, *skin.Skin)                                {}
-func (NopHandler) HandleStartBreak(*event.Context, cube.Pos)                                  {}
-func (NopHandler) HandleBlockBreak(*event.Context, cube.Pos, *[]item.Stack, *int)             {}
-func (NopHandler) HandleBlockPlace(*event.Context, cube.Pos, world.Block)                     {}
-func (NopHandler) HandleBlockPick(*event.Context, cube.Pos, world.Block)                      {}
-func (NopHandler) HandleSignEdit(*event.Context, bool, string, string)                        {}
-func (NopHandler) HandleLecternPageTurn(*event.Context, cube.Pos, int, *int)                  {}
-func (NopHandler) HandleItemPickup(*event.Context, *item.Stack)                               {}
-func (NopHandler) HandleItemUse(*event.Context)                                               {}
-func (NopHandler) HandleItemUseOnBlock(*event.Context, cube.Pos, cube.Face, mgl64.Vec3)       {}
-func (NopHandler) HandleItemUseOnEntity(*event.Context, world.Entity)                         {}
-func (NopHandler) HandleItemConsume(*event.Context, item.Stack)                               {}
-func (NopHandler) HandleItemDamage(*event.Context, item.Stack, int)                           {}
-func (NopHandler) HandleAttackEntity(*event.Context, world.Entity, *float64, *float64, *bool) {}
-func (NopHandler) HandleExperienceGain(*event.Context, *int)                                  {}
-func (NopHandler) HandlePunchAir(*event.Context)                                              {}
-func (NopHandler) HandleHurt(*event.Context, *float64, *time.Duration, world.DamageSource)    {}
-func (NopHandler) HandleHeal(*event.Context, *float64, world.HealingSource)                   {}
-func (NopHandler) HandleFoodLoss(*event.Context, int, *int)                                   {}
-func (NopHandler) HandleDeath(world.DamageSource, *bool)                                      {}
-func (NopHandler) HandleRespawn(*mgl64.Vec3, **world.World)                                   {}
-func (NopHandler) HandleQuit()                                                                {}
+func (NopHandler) HandleItemDrop(*event.Context, world.Entity)                          {}
+func (NopHandler) HandleMove(*event.Context, mgl64.Vec3, float64, float64)              {}
+func (NopHandler) HandleJump()                                                          {}
+func (NopHandler) HandleTeleport(*event.Context, mgl64.Vec3)                            {}
+func (NopHandler) HandleMovementViolation(*event.Context, MovementViolation)            {}
+func (NopHandler) HandleChangeWorld(*world.World, *world.World)                         {}
+func (NopHandler) HandleToggleSprint(*event.Context, bool)                              {}
+func (NopHandler) HandleToggleSneak(*event.Context, bool)                               {}
+func (NopHandler) HandleCommandExecution(*event.Context, cmd.Command, []string)         {}
+func (NopHandler) HandleTransfer(*event.Context, *net.UDPAddr)                          {}
+func (NopHandler) HandleChat(*event.Context, *string)                                   {}
+func (NopHandler) HandleSkinChange(*event.Context, *skin.Skin)                          {}
+func (NopHandler) HandleClientSettingsChange(settings.Settings, settings.Settings)      {}
+func (NopHandler) HandleEmote(*event.Context, uuid.UUID)                                {}
+func (NopHandler) HandleStartBreak(*event.Context, cube.Pos)                            {}
+func (NopHandler) HandleBlockBreak(*event.Context, cube.Pos, *[]item.Stack, *int)       {}
+func (NopHandler) HandleBlockPlace(*event.Context, cube.Pos, world.Block)               {}
+func (NopHandler) HandleBlockPlaceModify(*event.Context, cube.Pos, *world.Block)        {}
+func (NopHandler) HandleBlockInteract(*event.Context, cube.Pos, cube.Face, world.Block) {}
+func (NopHandler) HandleBlockPick(*event.Context, cube.Pos, world.Block)                {}
+func (NopHandler) HandleSignEdit(*event.Context, bool, string, string)                  {}
+func (NopHandler) HandleLecternPageTurn(*event.Context, cube.Pos, int, *int)            {}
+func (NopHandler) HandleItemPickup(*event.Context, *item.Stack)                         {}
+func (NopHandler) HandleItemUse(*event.Context)                                         {}
+func (NopHandler) HandleItemUseOnBlock(*event.Context, cube.Pos, cube.Face, mgl64.Vec3) {}
+func (NopHandler) HandleItemUseOnEntity(*event.Context, world.Entity)                   {}
+func (NopHandler) HandlePlayerInteract(*event.Context, *Player)                         {}
+func (NopHandler) HandleItemStartUse(*event.Context, item.Stack)                        {}
+func (NopHandler) HandleItemRelease(*event.Context, item.Stack, time.Duration)          {}
+func (NopHandler) HandleItemConsume(*event.Context, item.Stack)                         {}
+func (NopHandler) HandleItemDamage(*event.Context, item.Stack, int)                     {}
+func (NopHandler) HandleAttackEntity(*event.Context, world.Entity, *float64, *float64, *float64, *bool) {
+}
+func (NopHandler) HandleExperienceGain(*event.Context, *int)                               {}
+func (NopHandler) HandleMilestoneComplete(*event.Context, progression.Milestone)           {}
+func (NopHandler) HandlePunchAir(*event.Context)                                           {}
+func (NopHandler) HandleHurt(*event.Context, *float64, *time.Duration, world.DamageSource) {}
+func (NopHandler) HandleHeal(*event.Context, *float64, world.HealingSource)                {}
+func (NopHandler) HandleFoodLoss(*event.Context, int, *int)                                {}
+func (NopHandler) HandleDeath(world.DamageSource, *bool)                                   {}
+func (NopHandler) HandleSleep(*event.Context, cube.Pos)                                    {}
+func (NopHandler) HandleTotemUse(*event.Context, world.DamageSource)                       {}
+func (NopHandler) HandleRespawn(*mgl64.Vec3, **world.World)                                {}
+func (NopHandler) HandlePacketLimit(*event.Context, string)                                {}
+func (NopHandler) HandleDisconnect(DisconnectCause, string)                                {}
+func (NopHandler) HandleQuit()                                                             {}
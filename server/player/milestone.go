@@ -0,0 +1,122 @@
+package player
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/player/progression"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"math"
+	"time"
+)
+
+// Progress returns a copy of the progress the player has made towards milestones registered using
+// progression.Register, including the milestones it has completed.
+func (p *Player) Progress() progression.Progress {
+	p.progressMu.Lock()
+	defer p.progressMu.Unlock()
+	return p.progress.Clone()
+}
+
+// MilestoneCompleted checks if the player has completed the progression.Milestone with the ID passed.
+func (p *Player) MilestoneCompleted(id string) bool {
+	p.progressMu.Lock()
+	defer p.progressMu.Unlock()
+	_, ok := p.progress.Done(id)
+	return ok
+}
+
+// AdvanceMilestone adds n to the progress the player has made towards the criterion with the name passed of
+// the registered progression.Milestone with the ID passed. The milestone is completed as soon as all of its
+// criteria are met. AdvanceMilestone does nothing if the milestone or criterion does not exist or if the
+// milestone was already completed.
+func (p *Player) AdvanceMilestone(id, criterion string, n int) {
+	m, ok := progression.ByID(id)
+	if !ok {
+		return
+	}
+	c, ok := m.Criterion(criterion)
+	if !ok {
+		return
+	}
+	p.progressMu.Lock()
+	if _, done := p.progress.Done(id); done {
+		p.progressMu.Unlock()
+		return
+	}
+	criteria, ok := p.progress.Criteria[id]
+	if !ok {
+		criteria = make(map[string]int)
+		p.progress.Criteria[id] = criteria
+	}
+	criteria[criterion] = int(math.Max(0, math.Min(float64(criteria[criterion]+n), float64(c.Target()))))
+	met := p.progress.Met(m)
+	p.progressMu.Unlock()
+
+	if met {
+		p.completeMilestone(m)
+	}
+}
+
+// CompleteMilestone completes the registered progression.Milestone with the ID passed for the player,
+// regardless of the progress it has made towards its criteria.
+func (p *Player) CompleteMilestone(id string) {
+	if m, ok := progression.ByID(id); ok && !p.MilestoneCompleted(id) {
+		p.completeMilestone(m)
+	}
+}
+
+// RevokeMilestone removes all progress the player has made towards the progression.Milestone with the ID
+// passed, so that it may be completed again.
+func (p *Player) RevokeMilestone(id string) {
+	p.progressMu.Lock()
+	defer p.progressMu.Unlock()
+	delete(p.progress.Criteria, id)
+	delete(p.progress.Completed, id)
+}
+
+// completeMilestone completes the progression.Milestone passed for the player and shows the completion
+// according to its progression.Notification.
+func (p *Player) completeMilestone(m progression.Milestone) {
+	ctx := event.C()
+	if p.Handler().HandleMilestoneComplete(ctx, m); ctx.Cancelled() {
+		return
+	}
+	p.progressMu.Lock()
+	if _, done := p.progress.Done(m.ID); done {
+		p.progressMu.Unlock()
+		return
+	}
+	delete(p.progress.Criteria, m.ID)
+	p.progress.Completed[m.ID] = time.Now()
+	p.progressMu.Unlock()
+
+	switch m.Notification {
+	case progression.NotifyToast:
+		p.SendToast(m.Title, m.Description)
+	case progression.NotifyChat:
+		_, _ = fmt.Fprintln(chat.Global, text.Colourf("%v has completed the milestone <green>[%v]</green>", p.Name(), m.Title))
+	}
+}
+
+// tickMilestones calls the progression.Criterion Check functions of all registered milestones that the player
+// has not yet completed, advancing the criteria that are met.
+func (p *Player) tickMilestones() {
+	for _, m := range progression.Milestones() {
+		if p.MilestoneCompleted(m.ID) {
+			continue
+		}
+		for _, c := range m.Criteria {
+			if c.Check == nil {
+				continue
+			}
+			p.progressMu.Lock()
+			progress := p.progress.Of(m.ID, c.Name)
+			p.progressMu.Unlock()
+
+			if progress < c.Target() && c.Check(p) {
+				p.AdvanceMilestone(m.ID, c.Name, c.Target())
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player/ability"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+// MovementValidation configures the validation of movement sent by the client of a player. Movement of the
+// client is authoritative by default, meaning that the server accepts any movement it sends. Movement that
+// violates the MovementValidation of a player is passed to Handler.HandleMovementViolation and rolled back,
+// unless the event is cancelled.
+// The zero value of MovementValidation disables all validation.
+type MovementValidation struct {
+	// SpeedTolerance is the factor by which the horizontal speed of the player may exceed the speed expected
+	// from its movement speed, before a ViolationSpeed is reported. Some tolerance is needed to account for
+	// jumping, ice and latency. If 0, the speed of the player is not validated.
+	SpeedTolerance float64
+	// MaxAirTicks is the amount of consecutive movements in the air without falling after which a
+	// ViolationFlight is reported for players that may not fly. If 0, flight is not validated.
+	MaxAirTicks int
+	// Collision specifies if movement into solid blocks should be reported as a ViolationCollision.
+	Collision bool
+	// ExemptDuration is the duration after teleporting or having its velocity changed by the server during which
+	// the movement of the player is not validated. If 0, a duration of one second is used.
+	ExemptDuration time.Duration
+}
+
+// DefaultMovementValidation returns a MovementValidation with sensible defaults that validates speed, flight
+// and collision.
+func DefaultMovementValidation() MovementValidation {
+	return MovementValidation{SpeedTolerance: 1.6, MaxAirTicks: 30, Collision: true, ExemptDuration: time.Second}
+}
+
+// Violation is the type of MovementViolation.
+type Violation int
+
+const (
+	// ViolationSpeed is reported if the player moves horizontally faster than allowed.
+	ViolationSpeed Violation = iota
+	// ViolationFlight is reported if the player stays in the air without falling while it may not fly.
+	ViolationFlight
+	// ViolationCollision is reported if the player moves into solid blocks.
+	ViolationCollision
+)
+
+// MovementViolation is a violation of the MovementValidation of a player, passed to
+// Handler.HandleMovementViolation.
+type MovementViolation struct {
+	// Type is the type of the violation.
+	Type Violation
+	// From is the position the player moved from. The player is moved back to From if the violation is not
+	// cancelled.
+	From mgl64.Vec3
+	// To is the position the player attempted to move to.
+	To mgl64.Vec3
+	// Count is the amount of violations of the player, including this one, since its last valid movement.
+	Count int
+}
+
+// SetMovementValidation changes the MovementValidation used to validate movement sent by the client of the
+// player. Passing the zero value disables validation.
+func (p *Player) SetMovementValidation(v MovementValidation) {
+	p.movement.Store(v)
+}
+
+// MovementValidation returns the MovementValidation of the player, as set using SetMovementValidation.
+func (p *Player) MovementValidation() MovementValidation {
+	return p.movement.Load()
+}
+
+// exemptMovement exempts the player from movement validation for the ExemptDuration of its
+// MovementValidation.
+func (p *Player) exemptMovement() {
+	d := p.movement.Load().ExemptDuration
+	if d <= 0 {
+		d = time.Second
+	}
+	p.movementExempt.Store(time.Now().Add(d))
+}
+
+// validateMovement validates the movement of the player from one position to another. If the movement
+// violates the MovementValidation of the player and the violation is not cancelled, the player is moved back
+// and false is returned.
+func (p *Player) validateMovement(w *world.World, from, to mgl64.Vec3) bool {
+	v := p.movement.Load()
+	if _, riding := p.Riding(); riding || v == (MovementValidation{}) || time.Now().Before(p.movementExempt.Load()) || !p.GameMode().HasCollision() {
+		return true
+	}
+	delta := to.Sub(from)
+	violation, ok := Violation(0), false
+	switch {
+	case v.SpeedTolerance > 0 && math.Hypot(delta[0], delta[2]) > p.maxHorizontalSpeed()*v.SpeedTolerance:
+		violation, ok = ViolationSpeed, true
+	case v.MaxAirTicks > 0 && p.hovering(w, delta) && p.airTicks.Inc() > int64(v.MaxAirTicks):
+		violation, ok = ViolationFlight, true
+	case v.Collision && p.collidesAt(w, to) && !p.collidesAt(w, from):
+		violation, ok = ViolationCollision, true
+	}
+	if !ok {
+		p.violations.Store(0)
+		return true
+	}
+	mv := MovementViolation{Type: violation, From: from, To: to, Count: int(p.violations.Inc())}
+	ctx := event.C()
+	if p.Handler().HandleMovementViolation(ctx, mv); ctx.Cancelled() {
+		return true
+	}
+	p.airTicks.Store(0)
+	p.teleport(from)
+	return false
+}
+
+// maxGlideSpeed is the maximum horizontal distance in blocks that a player gliding with an elytra is expected
+// to move in a single tick.
+const maxGlideSpeed = 3.5
+
+// maxHorizontalSpeed returns the maximum horizontal distance in blocks that the player is expected to move
+// in a single tick, not taking into account any tolerance.
+func (p *Player) maxHorizontalSpeed() float64 {
+	// A movement speed of 0.1 roughly translates to 0.22 blocks per tick when walking on the ground. Jumping
+	// while sprinting temporarily increases this, which is accounted for by the factor used here.
+	speed := p.Speed() * 4
+	if p.Gliding() {
+		// Players gliding with an elytra may reach much higher speeds, especially when diving or when boosted
+		// using firework rockets.
+		speed = math.Max(speed, maxGlideSpeed)
+	}
+	if p.Flying() {
+		fly, _ := p.Abilities().Speeds()
+		speed = math.Max(speed, fly*20)
+	}
+	return speed
+}
+
+// hovering checks if the player is in the air without falling while it is not allowed to fly.
+func (p *Player) hovering(w *world.World, delta mgl64.Vec3) bool {
+	if delta[1] < 0 || p.Flying() || p.Gliding() || p.HasAbility(ability.MayFly) || p.checkOnGround(w) {
+		p.airTicks.Store(0)
+		return false
+	}
+	if _, ok := p.Effect(effect.Levitation{}); ok {
+		p.airTicks.Store(0)
+		return false
+	}
+	pos := cube.PosFromVec3(p.Position())
+	if _, ok := w.Liquid(pos); ok {
+		p.airTicks.Store(0)
+		return false
+	}
+	switch w.Block(pos).(type) {
+	case block.Ladder, block.Vines:
+		p.airTicks.Store(0)
+		return false
+	}
+	return true
+}
+
+// collidesAt checks if the bounding box of the player would intersect with any solid block if it were at the
+// position passed.
+func (p *Player) collidesAt(w *world.World, pos mgl64.Vec3) bool {
+	// Shrink the box slightly so that standing against or on top of a block is not considered a collision.
+	box := p.Type().BBox(p).Translate(pos).Grow(-0.01)
+	min, max := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for x := min[0]; x <= max[0]; x++ {
+		// Blocks such as fences and walls extend into the block above them, so the blocks below the box are
+		// checked too.
+		for y := min[1] - 1; y <= max[1]; y++ {
+			for z := min[2]; z <= max[2]; z++ {
+				bp := cube.Pos{x, y, z}
+				for _, bb := range w.Block(bp).Model().BBox(bp, w) {
+					if bb.Translate(bp.Vec3()).IntersectsWith(box) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,3 @@
+// Package npc implements non-player characters: Entities that look and behave like players, but that are
+// controlled by the server, such as shop keepers and quest givers. NPCs are created using Config.New.
+package npc
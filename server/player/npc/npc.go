@@ -0,0 +1,156 @@
+package npc
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+// Config holds the settings of an NPC created using Config.New.
+type Config struct {
+	// Name is the name of the NPC, which is shown in its name tag.
+	Name string
+	// Skin is the skin of the NPC. If left empty, a plain 64x64 skin is used.
+	Skin skin.Skin
+	// Yaw and Pitch are the rotation of the NPC when it is spawned.
+	Yaw, Pitch float64
+	// Scale is the scale of the NPC. If 0, a scale of 1 is used.
+	Scale float64
+	// Vulnerable specifies if the NPC can be hurt. By default, NPCs are invulnerable.
+	Vulnerable bool
+	// LookRadius is the radius in blocks within which the NPC looks at the nearest player. If 0, the NPC does
+	// not look at players.
+	LookRadius float64
+	// Emote is the UUID of an emote that the NPC performs every EmoteInterval. If uuid.Nil, the NPC does not
+	// perform any emotes.
+	Emote uuid.UUID
+	// EmoteInterval is the interval at which the NPC performs the Emote. If 0, an interval of 10 seconds is
+	// used.
+	EmoteInterval time.Duration
+	// Interact is called when a player interacts with the NPC, for example by right-clicking it.
+	Interact func(npc, p *player.Player)
+	// Attack is called when a player attacks the NPC, for example by left-clicking it.
+	Attack func(npc, p *player.Player)
+}
+
+// New creates a new NPC at the position passed using the settings in the Config. The NPC is a *player.Player
+// without a network session, so that it is shown to other players like any other player. The NPC is not added
+// to a world: world.World.AddEntity must be called to spawn it. It may be removed again by closing it.
+func (conf Config) New(pos mgl64.Vec3) *player.Player {
+	if conf.Skin.Bounds().Empty() {
+		conf.Skin = skin.New(64, 64)
+	}
+	if conf.Scale == 0 {
+		conf.Scale = 1
+	}
+	if conf.EmoteInterval == 0 {
+		conf.EmoteInterval = time.Second * 10
+	}
+	p := player.New(conf.Name, conf.Skin, pos)
+	p.SetScale(conf.Scale)
+	p.Handle(&handler{conf: conf, npc: p})
+	return p
+}
+
+// handler is the player.Handler of an NPC. It calls the callbacks of the Config of the NPC and runs its
+// behaviour once the NPC is added to a world.
+type handler struct {
+	player.NopHandler
+	conf Config
+	npc  *player.Player
+	once sync.Once
+}
+
+// HandleChangeWorld starts the behaviour of the NPC when it is first ticked in a world.
+func (h *handler) HandleChangeWorld(_, after *world.World) {
+	if after == nil {
+		return
+	}
+	h.once.Do(func() {
+		h.npc.Move(mgl64.Vec3{}, h.conf.Yaw, h.conf.Pitch)
+		if h.conf.LookRadius > 0 || h.conf.Emote != uuid.Nil {
+			go h.run()
+		}
+	})
+}
+
+// HandleHurt calls the Attack function of the NPC if it was attacked by a player and cancels the damage if the
+// NPC is not vulnerable.
+func (h *handler) HandleHurt(ctx *event.Context, _ *float64, _ *time.Duration, src world.DamageSource) {
+	if s, ok := src.(entity.AttackDamageSource); ok && h.conf.Attack != nil {
+		if p, ok := s.Attacker.(*player.Player); ok {
+			h.conf.Attack(h.npc, p)
+		}
+	}
+	if !h.conf.Vulnerable {
+		ctx.Cancel()
+	}
+}
+
+// HandlePlayerInteract calls the Interact function of the NPC.
+func (h *handler) HandlePlayerInteract(ctx *event.Context, p *player.Player) {
+	if h.conf.Interact != nil {
+		h.conf.Interact(h.npc, p)
+		ctx.Cancel()
+	}
+}
+
+// run runs the behaviour of the NPC until it is removed from its world, making it look at the nearest player
+// and perform its emote.
+func (h *handler) run() {
+	t := time.NewTicker(time.Second / 10)
+	defer t.Stop()
+
+	lastEmote := time.Now()
+	for range t.C {
+		w := h.npc.World()
+		if w == nil {
+			// The NPC was closed or removed from its world.
+			return
+		}
+		if h.conf.LookRadius > 0 {
+			h.lookAtNearest(w)
+		}
+		if h.conf.Emote != uuid.Nil && time.Since(lastEmote) >= h.conf.EmoteInterval {
+			lastEmote = time.Now()
+			h.npc.Emote(h.conf.Emote)
+		}
+	}
+}
+
+// lookAtNearest rotates the NPC so that it looks at the nearest player within its LookRadius.
+func (h *handler) lookAtNearest(w *world.World) {
+	pos := h.npc.Position()
+	box := cube.Box(pos[0], pos[1], pos[2], pos[0], pos[1], pos[2]).Grow(h.conf.LookRadius)
+
+	var target *player.Player
+	closest := math.MaxFloat64
+	for _, e := range w.EntitiesWithin(box, nil) {
+		p, ok := e.(*player.Player)
+		if !ok || p == h.npc {
+			continue
+		}
+		if dist := p.Position().Sub(pos).Len(); dist <= h.conf.LookRadius && dist < closest {
+			target, closest = p, dist
+		}
+	}
+	if target == nil {
+		return
+	}
+	eye := pos.Add(mgl64.Vec3{0, h.npc.EyeHeight()})
+	diff := target.Position().Add(mgl64.Vec3{0, target.EyeHeight()}).Sub(eye)
+
+	yaw := -math.Atan2(diff[0], diff[2]) * 180 / math.Pi
+	pitch := -math.Atan2(diff[1], math.Hypot(diff[0], diff[2])) * 180 / math.Pi
+	current := h.npc.Rotation()
+	h.npc.Move(mgl64.Vec3{}, yaw-current.Yaw(), pitch-current.Pitch())
+}
@@ -17,22 +17,32 @@ import (
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/i18n"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/enchantment"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/permission"
+	"github.com/df-mc/dragonfly/server/player/ability"
 	"github.com/df-mc/dragonfly/server/player/bossbar"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/df-mc/dragonfly/server/player/gui"
+	"github.com/df-mc/dragonfly/server/player/progression"
 	"github.com/df-mc/dragonfly/server/player/scoreboard"
+	"github.com/df-mc/dragonfly/server/player/settings"
 	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/player/stats"
 	"github.com/df-mc/dragonfly/server/player/title"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/gameevent"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"golang.org/x/exp/maps"
 	"golang.org/x/text/language"
 )
 
@@ -42,14 +52,14 @@ type Player struct {
 	name                                string
 	uuid                                uuid.UUID
 	xuid                                string
-	locale                              language.Tag
 	pos, vel                            atomic.Value[mgl64.Vec3]
 	nameTag                             atomic.Value[string]
 	scoreTag                            atomic.Value[string]
 	yaw, pitch, absorptionHealth, scale atomic.Float64
 	once                                sync.Once
 
-	gameMode atomic.Value[world.GameMode]
+	gameMode  atomic.Value[world.GameMode]
+	abilities atomic.Value[ability.Abilities]
 
 	skin atomic.Value[skin.Skin]
 	// s holds the session of the player. This field should not be used directly, but instead,
@@ -57,6 +67,12 @@ type Player struct {
 	s atomic.Value[*session.Session]
 	// h holds the current Handler of the player. It may be changed at any time by calling the Handle method.
 	h atomic.Value[Handler]
+	// deaths is the event.Bus that the deaths of the player are dispatched to.
+	deaths event.Bus[world.DamageSource]
+	// perms holds the permission.Provider used to check the permissions of the player.
+	perms atomic.Value[permission.Provider]
+	// opLevel holds the operator level of the player.
+	opLevel atomic.Value[permission.Level]
 
 	inv, offHand, enderChest *inventory.Inventory
 	armour                   *inventory.Armour
@@ -64,11 +80,19 @@ type Player struct {
 
 	sneaking, sprinting, swimming, gliding, flying,
 	invisible, immobile, onGround, usingItem atomic.Bool
+
+	sleeping   atomic.Bool
+	sleepPos   atomic.Value[cube.Pos]
+	spawnPos   atomic.Value[*cube.Pos]
 	usingSince atomic.Int64
 
 	glideTicks   atomic.Int64
+	riptideTicks atomic.Int64
 	fireTicks    atomic.Int64
+	frozenTicks  atomic.Int64
+	freezing     atomic.Bool
 	fallDistance atomic.Float64
+	stepDistance atomic.Float64
 
 	breathing         bool
 	airSupplyTicks    atomic.Int64
@@ -76,6 +100,20 @@ type Player struct {
 
 	cooldownMu sync.Mutex
 	cooldowns  map[string]time.Time
+
+	tagMu sync.RWMutex
+	tags  map[string]struct{}
+
+	progressMu sync.Mutex
+	progress   progression.Progress
+
+	statsMu sync.Mutex
+	stats   stats.Statistics
+
+	locale      atomic.Value[language.Tag]
+	listName    atomic.Value[string]
+	chatChannel atomic.Value[*chat.Chat]
+	mutedUntil  atomic.Value[time.Time]
 	// lastTickedWorld holds the world that the player was in, in the last tick.
 	lastTickedWorld *world.World
 
@@ -97,13 +135,29 @@ type Player struct {
 
 	collidedVertically, collidedHorizontally atomic.Bool
 
+	movement       atomic.Value[MovementValidation]
+	movementExempt atomic.Value[time.Time]
+	airTicks       atomic.Int64
+	violations     atomic.Int64
+
+	combat     atomic.Value[Combat]
+	lastAttack atomic.Value[time.Time]
+
+	carrier atomic.Value[Carrier]
+	// carried is true if the Data of the player was carried over to another server by its Carrier.
+	carried atomic.Bool
+	riding  atomic.Value[entity.Rideable]
+
 	breaking          atomic.Bool
 	breakingPos       atomic.Value[cube.Pos]
 	lastBreakDuration time.Duration
+	lastBreakUpdate   time.Time
+	breakProgress     float64
 
 	breakParticleCounter atomic.Uint32
 
 	hunger *hungerManager
+	hud    *hud
 }
 
 // New returns a new initialised player. A random UUID is generated for the player, so that it may be
@@ -122,24 +176,30 @@ func New(name string, skin skin.Skin, pos mgl64.Vec3) *Player {
 		offHand:           inventory.New(1, p.broadcastItems),
 		armour:            inventory.NewArmour(p.broadcastArmour),
 		hunger:            newHungerManager(),
+		hud:               &hud{},
 		health:            entity.NewHealthManager(20, 20),
 		experience:        entity.NewExperienceManager(),
 		effects:           entity.NewEffectManager(),
 		gameMode:          *atomic.NewValue[world.GameMode](world.GameModeSurvival),
 		h:                 *atomic.NewValue[Handler](NopHandler{}),
+		perms:             *atomic.NewValue[permission.Provider](permission.NopProvider{}),
 		name:              name,
 		skin:              *atomic.NewValue(skin),
 		speed:             *atomic.NewFloat64(0.1),
 		nameTag:           *atomic.NewValue(name),
 		heldSlot:          atomic.NewUint32(0),
-		locale:            language.BritishEnglish,
+		locale:            *atomic.NewValue(language.BritishEnglish),
 		breathing:         true,
 		airSupplyTicks:    *atomic.NewInt64(300),
 		maxAirSupplyTicks: *atomic.NewInt64(300),
 		enchantSeed:       *atomic.NewInt64(rand.Int63()),
 		scale:             *atomic.NewFloat64(1),
 		pos:               *atomic.NewValue(pos),
+		combat:            *atomic.NewValue(DefaultCombat()),
 		cooldowns:         make(map[string]time.Time),
+		tags:              make(map[string]struct{}),
+		progress:          progression.Progress{}.Clone(),
+		stats:             stats.Statistics{}.Clone(),
 		mc:                &entity.MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
 	}
 	return p
@@ -154,7 +214,8 @@ func NewWithSession(name, xuid string, uuid uuid.UUID, skin skin.Skin, s *sessio
 	p := New(name, skin, pos)
 	p.s, p.uuid, p.xuid, p.skin = *atomic.NewValue(s), uuid, xuid, *atomic.NewValue(skin)
 	p.inv, p.offHand, p.enderChest, p.armour, p.heldSlot = s.HandleInventories()
-	p.locale, _ = language.Parse(strings.Replace(s.ClientData().LanguageCode, "_", "-", 1))
+	locale, _ := language.Parse(strings.Replace(s.ClientData().LanguageCode, "_", "-", 1))
+	p.locale.Store(locale)
 	if data != nil {
 		p.load(*data)
 	}
@@ -190,6 +251,15 @@ func (p *Player) XUID() string {
 	return p.xuid
 }
 
+// Device returns the Device that the player joined the server with, such as its operating system and the
+// version of its client. If the Player is not connected to a network session, an empty Device is returned.
+func (p *Player) Device() Device {
+	if p.session() == session.Nop {
+		return Device{}
+	}
+	return DeviceFromClientData(p.session().ClientData())
+}
+
 // DeviceID returns the device ID of the player. If the Player is not connected to a network session, an empty string is
 // returned. Otherwise, the device ID the network session sent in the ClientData is returned.
 func (p *Player) DeviceID() string {
@@ -217,6 +287,40 @@ func (p *Player) SelfSignedID() string {
 	return p.session().ClientData().SelfSignedID
 }
 
+// ClientSettings returns the settings of the client of the player, such as its render distance and input mode.
+// If the Player is not connected to a network session, empty settings are returned.
+func (p *Player) ClientSettings() settings.Settings {
+	if p.session() == session.Nop {
+		return settings.Settings{}
+	}
+	return p.session().ClientSettings()
+}
+
+// InputMode returns the input mode that the client of the player is currently using, such as a touch screen or
+// a controller. If the Player is not connected to a network session, settings.Mouse is returned.
+func (p *Player) InputMode() settings.InputMode {
+	if p.session() == session.Nop {
+		return settings.Mouse()
+	}
+	return p.session().ClientSettings().InputMode
+}
+
+// UpdateClientSettings is called when the client of the player changes its settings. The settings before and
+// after the change are passed to the Handler of the player.
+func (p *Player) UpdateClientSettings(before, after settings.Settings) {
+	p.Handler().HandleClientSettingsChange(before, after)
+}
+
+// SetMaxChunkRadius changes the maximum chunk radius of the player. The chunk radius used is the render
+// distance of the client, limited to this maximum. SetMaxChunkRadius may be used to adapt the view distance
+// of a player to its device.
+func (p *Player) SetMaxChunkRadius(radius int) {
+	if p.session() == session.Nop {
+		return
+	}
+	p.session().SetMaxChunkRadius(radius)
+}
+
 // Addr returns the net.Addr of the Player. If the Player is not connected to a network session, nil is returned.
 func (p *Player) Addr() net.Addr {
 	if p.session() == session.Nop {
@@ -247,11 +351,38 @@ func (p *Player) SetSkin(skin skin.Skin) {
 	for _, v := range p.viewers() {
 		v.ViewSkin(p)
 	}
+	p.session().UpdatePlayerListSkin()
 }
 
-// Locale returns the language and locale of the Player, as selected in the Player's settings.
+// Locale returns the language and locale of the Player. By default, this is the language selected in the
+// Player's settings, but it may be changed using SetLocale.
 func (p *Player) Locale() language.Tag {
-	return p.locale
+	return p.locale.Load()
+}
+
+// SetLocale changes the language and locale of the Player used to translate server-side messages, for example
+// to a language selected by the player in a menu. It does not change the language of the client itself.
+func (p *Player) SetLocale(locale language.Tag) {
+	p.locale.Store(locale)
+}
+
+// Translate translates the message with the key passed from the i18n.Bundle passed to the locale of the
+// Player. The args passed are formatted into the message according to the rules of fmt.Sprintf.
+func (p *Player) Translate(b *i18n.Bundle, key string, args ...any) string {
+	return b.Translate(p.Locale(), key, args...)
+}
+
+// MessageTranslated sends the message with the key passed from the i18n.Bundle passed to the player, translated
+// to the locale of the Player. The args passed are formatted into the message according to the rules of
+// fmt.Sprintf.
+func (p *Player) MessageTranslated(b *i18n.Bundle, key string, args ...any) {
+	p.session().SendMessage(p.Translate(b, key, args...))
+}
+
+// SendTranslation sends an i18n.Translation to the player, which is translated by the client to the language
+// selected in its settings.
+func (p *Player) SendTranslation(t i18n.Translation) {
+	p.session().SendTranslation(t.Key(), t.Params())
 }
 
 // Handle changes the current Handler of the player. As a result, events called by the player will call
@@ -264,6 +395,77 @@ func (p *Player) Handle(h Handler) {
 	p.h.Store(h)
 }
 
+// HandlePackets changes the session.PacketHandler of the player's session, which is called for every packet
+// sent to and received from the client. HandlePackets has no effect if the player has no session.
+func (p *Player) HandlePackets(h session.PacketHandler) {
+	if s := p.session(); s != session.Nop {
+		s.HandlePackets(h)
+	}
+}
+
+// SetPermissionProvider changes the permission.Provider used to check the permissions of the player. If nil is
+// passed, permission.NopProvider is used, so that the player has no permissions at all.
+func (p *Player) SetPermissionProvider(provider permission.Provider) {
+	if provider == nil {
+		provider = permission.NopProvider{}
+	}
+	p.perms.Store(provider)
+}
+
+// HasPermission checks if the player has the permission node passed, such as 'dragonfly.command.ban'. Nodes not
+// set for the player in the permission.Provider of the player are treated as not granted.
+func (p *Player) HasPermission(node string) bool {
+	return permission.Has(p.perms.Load(), p.uuid, node, false)
+}
+
+// SetOperatorLevel sets the operator level of the player. Operators may run commands that require a level
+// up to their own, regardless of their permissions. Players with a level above permission.LevelNone are shown as
+// operators client-side.
+func (p *Player) SetOperatorLevel(lvl permission.Level) {
+	p.opLevel.Store(lvl)
+	if lvl > permission.LevelNone {
+		p.SetAbilities(p.Abilities().Grant(ability.Operator))
+		return
+	}
+	p.SetAbilities(p.Abilities().Reset(ability.Operator))
+}
+
+// OperatorLevel returns the operator level of the player, as set using SetOperatorLevel. By default, players
+// have permission.LevelNone.
+func (p *Player) OperatorLevel() permission.Level {
+	return p.opLevel.Load()
+}
+
+// AddTag adds a tag to the player. Tags may be used to select the player in commands, using target selectors
+// such as '@a[tag=admin]'.
+func (p *Player) AddTag(tag string) {
+	p.tagMu.Lock()
+	defer p.tagMu.Unlock()
+	p.tags[tag] = struct{}{}
+}
+
+// RemoveTag removes a tag previously added using AddTag from the player.
+func (p *Player) RemoveTag(tag string) {
+	p.tagMu.Lock()
+	defer p.tagMu.Unlock()
+	delete(p.tags, tag)
+}
+
+// HasTag checks if the player has a tag added using AddTag.
+func (p *Player) HasTag(tag string) bool {
+	p.tagMu.RLock()
+	defer p.tagMu.RUnlock()
+	_, ok := p.tags[tag]
+	return ok
+}
+
+// Tags returns all tags that the player currently has.
+func (p *Player) Tags() []string {
+	p.tagMu.RLock()
+	defer p.tagMu.RUnlock()
+	return maps.Keys(p.tags)
+}
+
 // Message sends a formatted message to the player. The message is formatted following the rules of
 // fmt.Sprintln, however the newline at the end is not written.
 func (p *Player) Message(a ...any) {
@@ -315,17 +517,54 @@ func (p *Player) FallDistance() float64 {
 // and the text it shows.
 // If non-empty, the subtitle is shown in a smaller font below the title. The same counts for the action text
 // of the title, which is shown in a font similar to that of a tip/popup.
+// SendTitle shows the title immediately, replacing any title currently shown. Use QueueTitle to show a title
+// after the current one has disappeared.
 func (p *Player) SendTitle(t title.Title) {
-	p.session().SetTitleDurations(t.FadeInDuration(), t.Duration(), t.FadeOutDuration())
-	if t.Text() != "" || t.Subtitle() != "" {
-		p.session().SendTitle(t.Text())
-		if t.Subtitle() != "" {
-			p.session().SendSubtitle(t.Subtitle())
-		}
+	p.hud.mu.Lock()
+	defer p.hud.mu.Unlock()
+	p.hud.showTitle(p, t)
+}
+
+// QueueTitle queues a title to be shown to the player after all titles previously sent or queued have
+// disappeared from the screen. If no title is currently shown, the title is shown immediately.
+func (p *Player) QueueTitle(t title.Title) {
+	p.hud.mu.Lock()
+	defer p.hud.mu.Unlock()
+	if len(p.hud.titles) == 0 && time.Now().After(p.hud.titleEnd) {
+		p.hud.showTitle(p, t)
+		return
 	}
-	if t.ActionText() != "" {
-		p.session().SendActionBarMessage(t.ActionText())
+	p.hud.titles = append(p.hud.titles, t)
+}
+
+// ClearTitle removes the title currently shown to the player and clears all titles queued using QueueTitle.
+func (p *Player) ClearTitle() {
+	p.hud.mu.Lock()
+	defer p.hud.mu.Unlock()
+	p.hud.titles, p.hud.titleEnd = nil, time.Time{}
+	p.session().ClearTitle()
+}
+
+// SendActionBar sends an action bar message to the player. The message is shown above the hotbar of the player
+// and disappears after a couple of seconds.
+// The message is formatted following the rules of fmt.Sprintln without a newline at the end.
+func (p *Player) SendActionBar(a ...any) {
+	p.session().SendActionBarMessage(format(a))
+}
+
+// SetActionBarFunc sets a function that is called every interval to obtain the action bar message of the
+// player, which is then sent to it, so that the message stays on the screen of the player. This is useful for
+// HUDs that are updated constantly. The interval is rounded down to whole ticks, with a minimum of one tick.
+// If f returns an empty string, no message is sent. Passing a nil function stops the action bar from being
+// refreshed.
+func (p *Player) SetActionBarFunc(f func() string, interval time.Duration) {
+	ticks := int64(interval / (time.Second / 20))
+	if ticks < 1 {
+		ticks = 1
 	}
+	p.hud.mu.Lock()
+	defer p.hud.mu.Unlock()
+	p.hud.actionBar, p.hud.actionBarInterval = f, ticks
 }
 
 // SendScoreboard sends a scoreboard to the player. The scoreboard will be present indefinitely until removed
@@ -341,28 +580,97 @@ func (p *Player) RemoveScoreboard() {
 	p.session().RemoveScoreboard()
 }
 
+// ShowObjective displays a scoreboard.Objective to the player in its display slot. Any objective previously
+// displayed in the same slot is removed. Changes made to the objective are sent to the player until
+// HideObjective is called or until the player disconnects.
+func (p *Player) ShowObjective(o *scoreboard.Objective) {
+	if p.session() == session.Nop {
+		return
+	}
+	o.AddViewer(p.session())
+}
+
+// HideObjective removes a scoreboard.Objective from the screen of the player. Nothing happens if the objective
+// was not displayed to the player.
+func (p *Player) HideObjective(o *scoreboard.Objective) {
+	p.session().HideObjective(o)
+}
+
 // SendBossBar sends a boss bar to the player, so that it will be shown indefinitely at the top of the
-// player's screen.
-// The boss bar may be removed by calling Player.RemoveBossBar().
+// player's screen. If a boss bar with the same ID, for example one returned by BossBar.WithHealthPercentage, is
+// already shown, it is updated instead. Boss bars created using bossbar.New all share the same ID and replace
+// each other, while those created using bossbar.NewSeparate are shown next to other boss bars.
+// The boss bar may be removed by calling Player.HideBossBar() or Player.RemoveBossBar().
 func (p *Player) SendBossBar(bar bossbar.BossBar) {
-	p.session().SendBossBar(bar.Text(), bar.Colour().Uint8(), bar.HealthPercentage())
+	p.session().SendBossBar(bar)
+}
+
+// HideBossBar hides the boss bar with the same ID as the bossbar.BossBar passed from the player's screen. If
+// the boss bar is not currently shown, nothing happens.
+func (p *Player) HideBossBar(bar bossbar.BossBar) {
+	p.session().HideBossBar(bar)
 }
 
-// RemoveBossBar removes any boss bar currently active on the player's screen. If no boss bar is currently
+// RemoveBossBar removes all boss bars currently active on the player's screen. If no boss bar is currently
 // present, nothing happens.
 func (p *Player) RemoveBossBar() {
-	p.session().RemoveBossBar()
+	p.session().RemoveBossBars()
 }
 
-// Chat writes a message in the global chat (chat.Global). The message is prefixed with the name of the
-// player and is formatted following the rules of fmt.Sprintln.
+// Chat writes a message in the chat channel of the player, which is the global chat (chat.Global) unless
+// changed using SetChatChannel. The message is formatted following the rules of fmt.Sprintln and is then
+// formatted using the chat.Format of the channel. Muted players are unable to chat.
 func (p *Player) Chat(msg ...any) {
+	if p.Muted() {
+		p.Message(text.Colourf("<red>You are muted and cannot chat.</red>"))
+		return
+	}
 	message := format(msg)
 	ctx := event.C()
 	if p.Handler().HandleChat(ctx, &message); ctx.Cancelled() {
 		return
 	}
-	_, _ = fmt.Fprintf(chat.Global, "<%v> %v\n", p.name, message)
+	p.ChatChannel().Send(p, message)
+}
+
+// SetChatChannel changes the chat.Chat that messages sent by the player using Chat are sent to. The player
+// does not automatically subscribe to the channel, so chat.Chat.Subscribe must be called for the player to
+// receive messages sent in it. Passing nil resets the channel to chat.Global.
+func (p *Player) SetChatChannel(c *chat.Chat) {
+	if c == nil {
+		c = chat.Global
+	}
+	p.chatChannel.Store(c)
+}
+
+// ChatChannel returns the chat.Chat that messages sent by the player using Chat are sent to.
+func (p *Player) ChatChannel() *chat.Chat {
+	if c := p.chatChannel.Load(); c != nil {
+		return c
+	}
+	return chat.Global
+}
+
+// Mute mutes the player for the duration passed, preventing it from sending messages in the chat. A duration
+// of 0 or lower mutes the player indefinitely, until Unmute is called.
+func (p *Player) Mute(d time.Duration) {
+	// Indefinite mutes use a time far enough in the future to never be reached.
+	until := time.Unix(1<<62, 0)
+	if d > 0 {
+		until = time.Now().Add(d)
+	}
+	p.mutedUntil.Store(until)
+}
+
+// Unmute unmutes the player, allowing it to chat again.
+func (p *Player) Unmute() {
+	p.mutedUntil.Store(time.Time{})
+}
+
+// Muted checks if the player is currently muted, either by a call to Mute or because it has the ability.Muted
+// ability.
+func (p *Player) Muted() bool {
+	return time.Now().Before(p.mutedUntil.Load()) || p.HasAbility(ability.Muted)
 }
 
 // ExecuteCommand executes a command passed as the player. If the command could not be found, or if the usage
@@ -372,23 +680,23 @@ func (p *Player) ExecuteCommand(commandLine string) {
 	if p.Dead() {
 		return
 	}
-	args := strings.Split(commandLine, " ")
-	command, ok := cmd.ByAlias(args[0][1:])
+	command, args, ok := cmd.Lookup(commandLine)
 	if !ok {
-		o := &cmd.Output{}
-		o.Errorf("Unknown command: %v. Please check that the command exists and that you have permission to use it.", args[0])
-		p.SendCommandOutput(o)
+		p.SendCommandOutput(cmd.UnknownCommand(commandLine))
 		return
 	}
 	ctx := event.C()
-	if p.Handler().HandleCommandExecution(ctx, command, args[1:]); ctx.Cancelled() {
+	if p.Handler().HandleCommandExecution(ctx, command, args); ctx.Cancelled() {
 		return
 	}
-	command.Execute(strings.Join(args[1:], " "), p)
+	command.Execute(strings.Join(args, " "), p)
 }
 
 // Transfer transfers the player to a server at the address passed. If the address could not be resolved, an
 // error is returned. If it is returned, the player is closed and transferred to the server.
+// If a Carrier was set using SetCarrier, the Data of the player is passed to it before the player is
+// transferred. The player is not transferred if the Carrier returns an error. Once carried, the items and
+// experience of the player are no longer part of the Data returned by Player.Data.
 func (p *Player) Transfer(address string) error {
 	addr, err := net.ResolveUDPAddr("udp", address)
 	if err != nil {
@@ -399,6 +707,12 @@ func (p *Player) Transfer(address string) error {
 	if p.Handler().HandleTransfer(ctx, addr); ctx.Cancelled() {
 		return nil
 	}
+	if c := p.carrier.Load(); c != nil {
+		if err := c.Carry(p.UUID(), addr, p.Data()); err != nil {
+			return fmt.Errorf("carry player data: %w", err)
+		}
+		p.carried.Store(true)
+	}
 	p.session().Transfer(addr.IP, addr.Port)
 	return nil
 }
@@ -449,6 +763,46 @@ func (p *Player) NameTag() string {
 	return p.nameTag.Load()
 }
 
+// SetListName changes the name of the player shown in the player list of all players. Passing an empty
+// string resets the name to the name of the player.
+func (p *Player) SetListName(name string) {
+	p.listName.Store(name)
+	p.session().UpdatePlayerListEntry()
+}
+
+// ListName returns the name of the player shown in the player list. By default, this is the name of the
+// player, but it may be changed using SetListName.
+func (p *Player) ListName() string {
+	if name := p.listName.Load(); name != "" {
+		return name
+	}
+	return p.name
+}
+
+// HideFromPlayerList hides the Player passed from the player list of the player. The Player passed remains
+// visible in the world. It may be shown in the player list again using ShowInPlayerList.
+func (p *Player) HideFromPlayerList(other *Player) {
+	p.session().HidePlayerListEntry(other)
+}
+
+// ShowInPlayerList shows a Player previously hidden using HideFromPlayerList in the player list of the player.
+func (p *Player) ShowInPlayerList(other *Player) {
+	p.session().ShowPlayerListEntry(other)
+}
+
+// AddPlayerListEntry adds an entry with the name and skin passed to the player list of the player, which does
+// not belong to any player. Such entries may be used to show additional information in the player list, for
+// example in lobbies. The entry is identified by the UUID passed, which may be used to remove it again using
+// RemovePlayerListEntry.
+func (p *Player) AddPlayerListEntry(id uuid.UUID, name string, skin skin.Skin) {
+	p.session().AddFakePlayerListEntry(id, name, skin)
+}
+
+// RemovePlayerListEntry removes an entry added using AddPlayerListEntry from the player list of the player.
+func (p *Player) RemovePlayerListEntry(id uuid.UUID) {
+	p.session().RemoveFakePlayerListEntry(id)
+}
+
 // SetScoreTag changes the score tag displayed over the player in-game. The score tag is displayed under the player's
 // name tag.
 func (p *Player) SetScoreTag(a ...any) {
@@ -537,6 +891,9 @@ func (p *Player) fall(distance float64) {
 		pos = cube.PosFromVec3(p.Position())
 		b   = w.Block(pos)
 	)
+	if !p.Sneaking() {
+		w.EmitGameEvent(p.Position(), gameevent.HitGround{}, p)
+	}
 	if len(b.Model().BBox(pos, w)) == 0 {
 		pos = pos.Sub(cube.Pos{0, 1})
 		b = w.Block(pos)
@@ -566,6 +923,9 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	if _, ok := p.Effect(effect.FireResistance{}); (ok && src.Fire()) || p.Dead() || !p.GameMode().AllowsTakingDamage() {
 		return 0, false
 	}
+	if diff, ok := p.World().Difficulty().(world.MobDifficulty); ok && p.hurtByMob(src) {
+		dmg = diff.MobDamage(dmg)
+	}
 	immunity := time.Second / 2
 	ctx := event.C()
 	if p.Handler().HandleHurt(ctx, &dmg, &immunity, src); ctx.Cancelled() {
@@ -574,6 +934,9 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	if dmg < 0 {
 		return 0, true
 	}
+	if p.blockedByShield(dmg, src) {
+		return 0, false
+	}
 
 	totalDamage := p.FinalDamageFrom(dmg, src)
 	damageLeft := totalDamage
@@ -599,6 +962,7 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 			origin = s.Owner
 		}
 		if l, ok := origin.(entity.Living); ok {
+			entity.AlertPets(p, origin)
 			thornsDmg := p.Armour().ThornsDamage(p.damageItem)
 			if thornsDmg > 0 {
 				l.Hurt(thornsDmg, enchantment.ThornsDamageSource{Owner: p})
@@ -615,14 +979,67 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	} else if _, ok := src.(entity.DrowningDamageSource); ok {
 		w.PlaySound(pos, sound.Drowning{})
 	}
+	w.EmitGameEvent(pos, gameevent.EntityDamage{}, p)
 
 	p.SetAttackImmunity(immunity)
-	if p.Dead() {
+	p.Wake()
+	if p.Dead() && !p.useTotem(src) {
 		p.kill(src)
 	}
 	return totalDamage, true
 }
 
+// useTotem attempts to prevent the death of the player using a totem of undying held in either hand. True is
+// returned if a totem was used, in which case the player is left with one health point and receives the
+// effects of the totem. Totems never prevent death caused by the void.
+func (p *Player) useTotem(src world.DamageSource) bool {
+	if _, ok := src.(entity.VoidDamageSource); ok {
+		return false
+	}
+	mainHand, offHand := p.HeldItems()
+	if _, ok := mainHand.Item().(item.Totem); ok {
+		mainHand = mainHand.Grow(-1)
+	} else if _, ok := offHand.Item().(item.Totem); ok {
+		offHand = offHand.Grow(-1)
+	} else {
+		return false
+	}
+	ctx := event.C()
+	if p.Handler().HandleTotemUse(ctx, src); ctx.Cancelled() {
+		return false
+	}
+	p.SetHeldItems(mainHand, offHand)
+	p.addHealth(1 - p.Health())
+
+	for _, e := range p.Effects() {
+		p.RemoveEffect(e.Type())
+	}
+	p.AddEffect(effect.New(effect.Regeneration{}, 2, time.Second*40))
+	p.AddEffect(effect.New(effect.FireResistance{}, 1, time.Second*40))
+	p.AddEffect(effect.New(effect.Absorption{}, 2, time.Second*5))
+
+	for _, viewer := range p.viewers() {
+		viewer.ViewEntityAction(p, entity.TotemUseAction{})
+	}
+	p.World().PlaySound(p.Position(), sound.Totem{})
+	return true
+}
+
+// hurtByMob checks if the world.DamageSource passed was caused by an entity other than a player, either directly
+// or through a projectile.
+func (p *Player) hurtByMob(src world.DamageSource) bool {
+	var origin world.Entity
+	if s, ok := src.(entity.AttackDamageSource); ok {
+		origin = s.Attacker
+	} else if s, ok := src.(entity.ProjectileDamageSource); ok {
+		origin = s.Owner
+	}
+	if _, ok := origin.(*Player); ok || origin == nil {
+		return false
+	}
+	return true
+}
+
 // FinalDamageFrom resolves the final damage received by the player if it is attacked by the source passed
 // with the damage passed. FinalDamageFrom takes into account things such as the armour worn and the
 // enchantments on the individual pieces.
@@ -641,7 +1058,7 @@ func (p *Player) FinalDamageFrom(dmg float64, src world.DamageSource) float64 {
 func (p *Player) Explode(explosionPos mgl64.Vec3, impact float64, c block.ExplosionConfig) {
 	diff := p.Position().Sub(explosionPos)
 	p.Hurt(math.Floor((impact*impact+impact)*3.5*c.Size+1), entity.ExplosionDamageSource{})
-	p.knockBack(explosionPos, impact, diff[1]/diff.Len()*impact)
+	p.knockBack(diff, impact, diff[1]/diff.Len()*impact)
 }
 
 // SetAbsorption sets the absorption health of a player. This extra health shows as golden hearts and do not
@@ -665,13 +1082,23 @@ func (p *Player) KnockBack(src mgl64.Vec3, force, height float64) {
 	if p.Dead() || !p.GameMode().AllowsTakingDamage() {
 		return
 	}
-	p.knockBack(src, force, height)
+	p.knockBack(p.Position().Sub(src), force, height)
 }
 
-// knockBack is an unexported function that is used to knock the player back. This function does not check if the player
-// can take damage or not.
-func (p *Player) knockBack(src mgl64.Vec3, force, height float64) {
-	velocity := p.Position().Sub(src)
+// KnockBackDirection knocks the player back in the direction passed with a given force and height. Only the
+// horizontal components of the direction are used. Unlike KnockBack, no source position is required, which
+// makes KnockBackDirection suitable for custom knock back and launch pads.
+func (p *Player) KnockBackDirection(dir mgl64.Vec3, force, height float64) {
+	if p.Dead() || !p.GameMode().AllowsTakingDamage() {
+		return
+	}
+	p.knockBack(dir, force, height)
+}
+
+// knockBack is an unexported function that is used to knock the player back in a direction. This function does
+// not check if the player can take damage or not.
+func (p *Player) knockBack(dir mgl64.Vec3, force, height float64) {
+	velocity := dir
 	velocity[1] = 0
 
 	if velocity.Len() != 0 {
@@ -806,20 +1233,32 @@ func (p *Player) DeathPosition() (mgl64.Vec3, world.Dimension, bool) {
 	return *p.deathPos, p.deathDimension, true
 }
 
+// Deaths returns the event.Bus that the world.DamageSource that killed the player is dispatched to every time
+// the player dies, after its Handler has handled the death. Unlike a Handler, any number of handlers may be
+// subscribed to it. Cancelling the event has no effect.
+func (p *Player) Deaths() *event.Bus[world.DamageSource] {
+	return &p.deaths
+}
+
 // kill kills the player, clearing its inventories and resetting it to its base state.
 func (p *Player) kill(src world.DamageSource) {
+	p.Dismount()
 	for _, viewer := range p.viewers() {
 		viewer.ViewEntityAction(p, entity.DeathAction{})
 	}
 
 	p.addHealth(-p.MaxHealth())
+	p.updateStatistics(func(s *stats.Statistics) {
+		s.Deaths++
+	})
 
-	keepInv := false
+	w, pos := p.World(), p.Position()
+	keepInv := w.KeepInventory()
 	p.Handler().HandleDeath(src, &keepInv)
+	p.deaths.Call(event.C(), src)
 	p.StopSneaking()
 	p.StopSprinting()
 
-	w, pos := p.World(), p.Position()
 	if !keepInv {
 		p.dropContents()
 	}
@@ -868,6 +1307,37 @@ func (p *Player) dropContents() {
 	}
 }
 
+// SpawnPoint returns the position that the player respawns at after dying. If the player has not set a spawn
+// point, for example by using a bed, the spawn of the world is returned.
+func (p *Player) SpawnPoint() cube.Pos {
+	if pos := p.spawnPos.Load(); pos != nil {
+		return *pos
+	}
+	// Spawn points used to be saved in the world, so fall back to the spawn point saved there, if any.
+	return p.spawnWorld().PlayerSpawn(p.UUID())
+}
+
+// SetSpawnPoint changes the position that the player respawns at after dying. The spawn point is saved in the
+// Data of the player, so that it persists when the player leaves.
+func (p *Player) SetSpawnPoint(pos cube.Pos) {
+	w := p.spawnWorld()
+	if w == nil {
+		return
+	}
+	p.spawnPos.Store(&pos)
+	p.session().SendSpawnPoint(pos, w.Dimension())
+}
+
+// spawnWorld returns the world.World that the player respawns in. This is the overworld if the player is in the
+// nether or the end, and the world of the player otherwise.
+func (p *Player) spawnWorld() *world.World {
+	w := p.World()
+	if w == nil {
+		return nil
+	}
+	return w.PortalDestination(w.Dimension())
+}
+
 // Respawn spawns the player after it dies, so that its health is replenished and it is spawned in the world
 // again. Nothing will happen if the player does not have a session connected to it.
 func (p *Player) Respawn() {
@@ -884,7 +1354,7 @@ func (p *Player) Respawn() {
 	// We can use the principle here that returning through a portal of a specific dimension inside that dimension will
 	// always bring us back to the overworld.
 	w = w.PortalDestination(w.Dimension())
-	pos := w.PlayerSpawn(p.UUID()).Vec3Middle()
+	pos := p.respawnPosition(w)
 
 	p.Handler().HandleRespawn(&pos, &w)
 
@@ -895,6 +1365,79 @@ func (p *Player) Respawn() {
 	p.SetVisible()
 }
 
+// Sleep makes the player sleep in the block.Bed at the position passed. Sleep does nothing if there is no bed
+// at the position, if the bed is occupied, if the player is already sleeping or if it cannot sleep. The player is
+// woken up when it is hurt, when the bed is removed or when Wake is called.
+func (p *Player) Sleep(pos cube.Pos) {
+	w := p.World()
+	b, ok := w.Block(pos).(block.Bed)
+	if !ok || b.Occupied || p.sleeping.Load() || !p.CanSleep() {
+		return
+	}
+	ctx := event.C()
+	if p.Handler().HandleSleep(ctx, pos); ctx.Cancelled() {
+		return
+	}
+	b.Occupied = true
+	w.SetBlock(pos, b, nil)
+
+	p.sleepPos.Store(pos)
+	p.sleeping.Store(true)
+	p.teleport(pos.Vec3Middle().Add(mgl64.Vec3{0, 0.5625}))
+	p.updateState()
+}
+
+// Sleeping returns the position of the bed that the player is sleeping in. False is returned if the player is
+// not currently sleeping.
+func (p *Player) Sleeping() (cube.Pos, bool) {
+	if !p.sleeping.Load() {
+		return cube.Pos{}, false
+	}
+	return p.sleepPos.Load(), true
+}
+
+// CanSleep checks if the player is able to sleep. Players that are dead or in a game mode without collision, such
+// as spectator mode, cannot sleep.
+func (p *Player) CanSleep() bool {
+	return !p.Dead() && p.GameMode().HasCollision()
+}
+
+// Wake wakes the player up if it is currently sleeping, freeing the bed it was sleeping in.
+func (p *Player) Wake() {
+	if !p.sleeping.CAS(true, false) {
+		return
+	}
+	w, pos := p.World(), p.sleepPos.Load()
+	if b, ok := w.Block(pos).(block.Bed); ok {
+		b.Occupied = false
+		w.SetBlock(pos, b, nil)
+	}
+	for _, v := range p.viewers() {
+		v.ViewEntityAction(p, entity.WakeUpAction{})
+	}
+	p.updateState()
+}
+
+// respawnPosition returns the position in the world.World passed that the player respawns at. If the spawn
+// point of the player is at a block.RespawnBlock, such as a bed, that is no longer valid or is obstructed, the
+// spawn point is reset and the player respawns at the spawn of the world.World instead.
+func (p *Player) respawnPosition(w *world.World) mgl64.Vec3 {
+	spawn := w.PlayerSpawn(p.UUID())
+	rb, ok := w.Block(spawn).(block.RespawnBlock)
+	if !ok {
+		return spawn.Vec3Middle()
+	}
+	if rb.CanRespawnOn(spawn, w) {
+		if pos, ok := rb.SafeSpawn(spawn, w); ok {
+			rb.RespawnOn(spawn, p, w)
+			return pos
+		}
+	}
+	p.SetSpawnPoint(w.Spawn())
+	p.Message(text.Colourf("<grey>You have no home bed or charged respawn anchor, or it was obstructed</grey>"))
+	return w.Spawn().Vec3Middle()
+}
+
 // StartSprinting makes a player start sprinting, increasing the speed of the player by 30% and making
 // particles show up under the feet. The player will only start sprinting if its food level is high enough.
 // If the player is sneaking when calling StartSprinting, it is stopped from sneaking.
@@ -992,16 +1535,38 @@ func (p *Player) StopSwimming() {
 	p.updateState()
 }
 
-// StartGliding makes the player start gliding if it is not currently doing so.
+// StartGliding makes the player start gliding if it is not currently doing so. The player can only start
+// gliding if it is wearing an elytra that is not broken and if it is in the air, not flying, riding or
+// in a liquid. If the player cannot glide, the client is corrected by resending its state.
 func (p *Player) StartGliding() {
+	if !p.canGlide() {
+		p.updateState()
+		return
+	}
 	if !p.gliding.CAS(false, true) {
 		return
 	}
+	p.updateState()
+}
+
+// canGlide checks if the player is currently able to glide.
+func (p *Player) canGlide() bool {
 	chest := p.Armour().Chestplate()
 	if _, ok := chest.Item().(item.Elytra); !ok || chest.Durability() < 2 {
-		return
+		return false
 	}
-	p.updateState()
+	if _, riding := p.Riding(); riding || p.Flying() || p.OnGround() {
+		return false
+	}
+	if _, ok := p.Effect(effect.Levitation{}); ok {
+		return false
+	}
+	if w := p.World(); w != nil {
+		if _, ok := w.Liquid(cube.PosFromVec3(p.Position())); ok {
+			return false
+		}
+	}
+	return true
 }
 
 // Gliding checks if the player is currently gliding.
@@ -1018,10 +1583,45 @@ func (p *Player) StopGliding() {
 	p.updateState()
 }
 
-// StartFlying makes the player start flying if they aren't already. It requires the player to be in a gamemode which
-// allows flying.
+// Riptide launches the player with the velocity passed, as is done when releasing a trident enchanted with
+// riptide. The player spins for one second, during which it damages the first entity it collides with.
+func (p *Player) Riptide(vel mgl64.Vec3) {
+	p.riptideTicks.Store(20)
+	p.SetVelocity(vel)
+	p.updateState()
+}
+
+// Riptiding checks if the player is currently spinning after being launched by a trident enchanted with
+// riptide.
+func (p *Player) Riptiding() bool {
+	return p.riptideTicks.Load() > 0
+}
+
+// tickRiptide ticks the riptide spin of the player. The first living entity that the player collides with
+// while spinning is attacked using the held trident, which stops the spin.
+func (p *Player) tickRiptide(w *world.World) {
+	box := p.Type().BBox(p).Translate(p.Position()).Grow(0.5)
+	for _, e := range w.EntitiesWithin(box, func(e world.Entity) bool { return e == p }) {
+		l, ok := e.(entity.Living)
+		if !ok || l.AttackImmune() {
+			continue
+		}
+		held, _ := p.HeldItems()
+		l.Hurt(held.AttackDamage(), entity.AttackDamageSource{Attacker: p})
+		p.SetVelocity(p.Velocity().Mul(-0.2))
+		p.riptideTicks.Store(0)
+		p.updateState()
+		return
+	}
+	if p.riptideTicks.Dec() == 0 {
+		p.updateState()
+	}
+}
+
+// StartFlying makes the player start flying if they aren't already. It requires the player to have the
+// ability.MayFly ability, which players in a gamemode that allows flying have by default.
 func (p *Player) StartFlying() {
-	if !p.GameMode().AllowsFlying() || !p.flying.CAS(false, true) {
+	if !p.HasAbility(ability.MayFly) || !p.flying.CAS(false, true) {
 		return
 	}
 	p.session().SendGameMode(p.GameMode())
@@ -1062,6 +1662,63 @@ func (p *Player) Jump() {
 	}
 }
 
+// Ride makes the player start riding the entity passed. If the player was already riding another entity, it
+// dismounts that entity first. False is returned if the entity could not be mounted.
+func (p *Player) Ride(e entity.Rideable) bool {
+	if p.Dead() {
+		return false
+	}
+	if current, ok := p.Riding(); ok {
+		if current == e {
+			return false
+		}
+		p.Dismount()
+	}
+	if !e.Mount(p) {
+		return false
+	}
+	p.riding.Store(e)
+	p.StopSprinting()
+	p.StopGliding()
+	for _, v := range p.viewers() {
+		v.ViewEntityMount(p, e)
+	}
+	p.updateState()
+	return true
+}
+
+// Riding returns the entity that the player is currently riding. False is returned if the player is not
+// riding an entity.
+func (p *Player) Riding() (entity.Rideable, bool) {
+	e := p.riding.Load()
+	return e, e != nil
+}
+
+// Dismount makes the player stop riding the entity it is currently riding. The player is moved to the
+// position of the entity.
+func (p *Player) Dismount() {
+	e, ok := p.Riding()
+	if !ok {
+		return
+	}
+	p.riding.Store(nil)
+	e.Dismount()
+	for _, v := range p.viewers() {
+		v.ViewEntityDismount(p, e)
+	}
+	p.updateState()
+	if w, _ := world.OfEntity(e); w == p.World() {
+		p.Teleport(e.Position())
+	}
+}
+
+// MovementInput returns the movement input of the player, as sent by its client. The first value is the
+// strafing input, positive to the left, and the second value is the forward input. Both values are
+// between -1 and 1. MovementInput is used to control entities ridden by the player.
+func (p *Player) MovementInput() mgl64.Vec2 {
+	return p.session().MovementInput()
+}
+
 // SetInvisible sets the player invisible, so that other players will not be able to see it.
 func (p *Player) SetInvisible() {
 	if !p.invisible.CAS(false, true) {
@@ -1120,6 +1777,98 @@ func (p *Player) FireProof() bool {
 	return !p.GameMode().AllowsTakingDamage()
 }
 
+// maxFrozenTicks is the amount of ticks a player must spend in powder snow to become fully frozen.
+const maxFrozenTicks = 140
+
+// Freeze freezes the player for one tick, as happens every tick that the player is inside powder snow. Once
+// the player has been freezing for seven seconds, it is fully frozen and takes freeze damage every two seconds.
+// Players wearing any leather armour do not freeze.
+func (p *Player) Freeze() {
+	for _, it := range p.Armour().Items() {
+		var tier item.ArmourTier
+		switch a := it.Item().(type) {
+		case item.Helmet:
+			tier = a.Tier
+		case item.Chestplate:
+			tier = a.Tier
+		case item.Leggings:
+			tier = a.Tier
+		case item.Boots:
+			tier = a.Tier
+		}
+		if _, leather := tier.(item.ArmourTierLeather); leather {
+			return
+		}
+	}
+	p.freezing.Store(true)
+}
+
+// FrozenDuration returns the duration that the player has been freezing for. The duration decreases again once
+// the player leaves powder snow.
+func (p *Player) FrozenDuration() time.Duration {
+	return time.Duration(p.frozenTicks.Load()) * time.Second / 20
+}
+
+// tickFreezing updates the frozen duration of the player depending on if it was freezing during the last tick
+// and deals freeze damage if the player is fully frozen.
+func (p *Player) tickFreezing(current int64) {
+	if !p.freezing.CAS(true, false) {
+		if p.frozenTicks.Load() > 0 {
+			if p.frozenTicks.Sub(2) < 0 {
+				p.frozenTicks.Store(0)
+			}
+			p.updateState()
+		}
+		return
+	}
+	if p.frozenTicks.Load() < maxFrozenTicks {
+		p.frozenTicks.Inc()
+		p.updateState()
+	} else if current%40 == 0 && p.GameMode().AllowsTakingDamage() {
+		p.Hurt(1, entity.FreezeDamageSource{})
+	}
+}
+
+// step emits a gameevent.Step for every block that the player walks while on the ground. No game events are
+// emitted while the player is sneaking.
+func (p *Player) step(w *world.World, pos, deltaPos mgl64.Vec3) {
+	if p.Sneaking() {
+		return
+	}
+	if p.stepDistance.Add(math.Hypot(deltaPos[0], deltaPos[2])) < 1 {
+		return
+	}
+	p.stepDistance.Store(0)
+	w.EmitGameEvent(pos, gameevent.Step{}, p)
+}
+
+// frostWalk freezes the water around the position passed into frosted ice if the player is wearing boots
+// enchanted with frost walker.
+func (p *Player) frostWalk(w *world.World, pos mgl64.Vec3) {
+	fw, ok := p.Armour().Boots().Enchantment(enchantment.FrostWalker{})
+	if !ok {
+		return
+	}
+	r := (enchantment.FrostWalker{}).Radius(fw.Level())
+	base := cube.PosFromVec3(pos).Side(cube.FaceDown)
+	for x := -r; x <= r; x++ {
+		for z := -r; z <= r; z++ {
+			if x*x+z*z > r*r {
+				continue
+			}
+			bp := base.Add(cube.Pos{x, 0, z})
+			if water, ok := w.Block(bp).(block.Water); !ok || water.Depth != 8 || water.Falling {
+				continue
+			}
+			if _, ok := w.Block(bp.Side(cube.FaceUp)).(block.Air); !ok {
+				continue
+			}
+			w.SetBlock(bp, block.FrostedIce{}, nil)
+			w.ScheduleBlockUpdate(bp, time.Second+time.Duration(rand.Intn(20))*time.Second/20)
+		}
+	}
+}
+
 // OnFireDuration ...
 func (p *Player) OnFireDuration() time.Duration {
 	return time.Duration(p.fireTicks.Load()) * time.Second / 20
@@ -1184,7 +1933,7 @@ func (p *Player) SetGameMode(mode world.GameMode) {
 		v.ViewEntityGameMode(p)
 	}
 
-	if !mode.AllowsFlying() {
+	if !p.HasAbility(ability.MayFly) {
 		p.StopFlying()
 	}
 	if !mode.Visible() {
@@ -1201,6 +1950,29 @@ func (p *Player) GameMode() world.GameMode {
 	return p.gameMode.Load()
 }
 
+// SetAbilities changes the abilities of the player that differ from the defaults of its GameMode, as well as
+// the speeds at which it flies and walks. The new abilities are sent to the client immediately. If the player
+// is no longer able to fly, it stops flying.
+func (p *Player) SetAbilities(a ability.Abilities) {
+	p.abilities.Store(a)
+	if !p.HasAbility(ability.MayFly) {
+		p.StopFlying()
+	}
+	p.session().SendAbilities()
+}
+
+// Abilities returns the abilities of the player that differ from the defaults of its GameMode, as set using
+// SetAbilities.
+func (p *Player) Abilities() ability.Abilities {
+	return p.abilities.Load()
+}
+
+// HasAbility checks if the player has all abilities passed, taking into account both its GameMode and the
+// abilities set using SetAbilities.
+func (p *Player) HasAbility(a ability.Ability) bool {
+	return p.Abilities().Of(p.GameMode()).Has(a)
+}
+
 // HasCooldown returns true if the item passed has an active cooldown, meaning it currently cannot be used again. If the
 // world.Item passed is nil, HasCooldown always returns false.
 func (p *Player) HasCooldown(item world.Item) bool {
@@ -1257,13 +2029,10 @@ func (p *Player) UseItem() {
 		p.SetCooldown(it, cd.Cooldown())
 	}
 
-	if _, ok := it.(item.Releasable); ok {
-		if !p.canRelease() {
+	if _, ok := it.(item.Releasable); ok && !charged(it) {
+		if !p.canRelease() || !p.startUsingItem(i) {
 			return
 		}
-		p.usingSince.Store(time.Now().UnixNano())
-		p.usingItem.Store(true)
-		p.updateState()
 	}
 
 	switch usable := it.(type) {
@@ -1288,10 +2057,9 @@ func (p *Player) UseItem() {
 			p.ReleaseItem()
 			return
 		}
-		if p.usingItem.CAS(false, true) {
+		if !p.usingItem.Load() {
 			// Consumable starts being consumed: Set the start timestamp and update the using state to viewers.
-			p.usingSince.Store(time.Now().UnixNano())
-			p.updateState()
+			p.startUsingItem(i)
 			return
 		}
 		// The player is currently using the item held. This is a signal the item was consumed, so we
@@ -1326,14 +2094,42 @@ func (p *Player) ReleaseItem() {
 	if !p.usingItem.CAS(true, false) || !p.canRelease() || !p.GameMode().AllowsInteraction() {
 		return
 	}
-	ctx := p.useContext()
 	i, _ := p.HeldItems()
-	i.Item().(item.Releasable).Release(p, p.useDuration(), ctx)
+	dur := p.useDuration()
 
-	p.handleUseContext(ctx)
+	ctx := event.C()
+	if p.Handler().HandleItemRelease(ctx, i, dur); ctx.Cancelled() {
+		p.updateState()
+		return
+	}
+	useCtx := p.useContext()
+	i.Item().(item.Releasable).Release(p, dur, useCtx)
+
+	p.handleUseContext(useCtx)
 	p.updateState()
 }
 
+// charged checks if the item passed is an item.Chargeable that is currently charged.
+func charged(it world.Item) bool {
+	c, ok := it.(item.Chargeable)
+	return ok && c.Charged()
+}
+
+// startUsingItem makes the player start using the item.Stack passed over a longer duration, such as when
+// eating food or drawing a bow. False is returned if Handler.HandleItemStartUse cancelled the use.
+func (p *Player) startUsingItem(i item.Stack) bool {
+	ctx := event.C()
+	if p.Handler().HandleItemStartUse(ctx, i); ctx.Cancelled() {
+		// The client already started using the item, so we need to update its state to stop it from doing so.
+		p.updateState()
+		return false
+	}
+	p.usingSince.Store(time.Now().UnixNano())
+	p.usingItem.Store(true)
+	p.updateState()
+	return true
+}
+
 // canRelease returns whether the player can release the item currently held in the main hand.
 func (p *Player) canRelease() bool {
 	held, _ := p.HeldItems()
@@ -1392,6 +2188,10 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 		p.resendBlocks(pos, w, face)
 		return
 	}
+	if !w.Protector().AllowInteract(p, pos, w) {
+		p.resendBlocks(pos, w, face)
+		return
+	}
 	ctx := event.C()
 	if p.Handler().HandleItemUseOnBlock(ctx, pos, face, clickPos); ctx.Cancelled() {
 		p.resendBlocks(pos, w, face)
@@ -1399,7 +2199,7 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 	}
 	i, left := p.HeldItems()
 	b := w.Block(pos)
-	if act, ok := b.(block.Activatable); ok {
+	if act, ok := b.(block.Activatable); ok && p.canActivate(b) {
 		// If a player is sneaking, it will not activate the block clicked, unless it is not holding any
 		// items, in which case the block will be activated as usual.
 		if !p.Sneaking() || i.Empty() {
@@ -1407,7 +2207,11 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 
 			// The block was activated: Blocks such as doors must always have precedence over the item being
 			// used.
-			if useCtx := p.useContext(); act.Activate(pos, face, p.World(), p, useCtx) {
+			ctx, useCtx := event.C(), p.useContext()
+			useCtx.ClickPos = clickPos
+			if p.Handler().HandleBlockInteract(ctx, pos, face, b); ctx.Cancelled() {
+				p.resendBlocks(pos, w, face)
+			} else if act.Activate(pos, face, p.World(), p, useCtx) {
 				p.SetHeldItems(p.subtractItem(p.damageItem(i, useCtx.Damage), useCtx.CountSub), left)
 				p.addNewItem(useCtx)
 				return
@@ -1455,12 +2259,23 @@ func (p *Player) UseItemOnEntity(e world.Entity) bool {
 	if p.Handler().HandleItemUseOnEntity(ctx, e); ctx.Cancelled() {
 		return false
 	}
+	if target, ok := e.(*Player); ok {
+		ctx = event.C()
+		if target.Handler().HandlePlayerInteract(ctx, p); ctx.Cancelled() {
+			return true
+		}
+	}
 	i, left := p.HeldItems()
+	useCtx := p.useContext()
+	if interactable, ok := e.(entity.Interactable); ok && interactable.Interact(p, useCtx) {
+		p.SetHeldItems(p.subtractItem(p.damageItem(i, useCtx.Damage), useCtx.CountSub), left)
+		p.addNewItem(useCtx)
+		return true
+	}
 	usable, ok := i.Item().(item.UsableOnEntity)
 	if !ok {
 		return true
 	}
-	useCtx := p.useContext()
 	if !usable.UseOnEntity(e, e.World(), p, useCtx) {
 		return true
 	}
@@ -1480,27 +2295,21 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 		return false
 	}
 	var (
-		force, height  = 0.45, 0.3608
+		c              = p.combat.Load()
+		force, height  = c.KnockBackForce, c.KnockBackHeight
 		_, slowFalling = p.Effect(effect.SlowFalling{})
 		_, blind       = p.Effect(effect.Blindness{})
 		critical       = !p.Sprinting() && !p.Flying() && p.FallDistance() > 0 && !slowFalling && !blind
 	)
 
-	ctx := event.C()
-	if p.Handler().HandleAttackEntity(ctx, e, &force, &height, &critical); ctx.Cancelled() {
+	if _, ok := e.(*Player); (ok && !p.HasAbility(ability.AttackPlayers)) || (!ok && !p.HasAbility(ability.AttackMobs)) {
 		return false
 	}
-	p.SwingArm()
-
-	i, _ := p.HeldItems()
-	living, ok := e.(entity.Living)
-	if !ok {
+	if p.attackCooldown() {
 		return false
 	}
-	if living.AttackImmune() {
-		return true
-	}
 
+	i, _ := p.HeldItems()
 	dmg := i.AttackDamage()
 	if strength, ok := p.Effect(effect.Strength{}); ok {
 		dmg += dmg * effect.Strength{}.Multiplier(strength.Level())
@@ -1511,17 +2320,41 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	if s, ok := i.Enchantment(enchantment.Sharpness{}); ok {
 		dmg += (enchantment.Sharpness{}).Addend(s.Level())
 	}
+	if imp, ok := i.Enchantment(enchantment.Impaling{}); ok && entity.Wet(e) {
+		dmg += (enchantment.Impaling{}).Damage(imp.Level())
+	}
+
+	ctx := event.C()
+	if p.Handler().HandleAttackEntity(ctx, e, &dmg, &force, &height, &critical); ctx.Cancelled() {
+		return false
+	}
+	p.SwingArm()
+
+	living, ok := e.(entity.Living)
+	if !ok {
+		return false
+	}
+	if living.AttackImmune() {
+		return true
+	}
 	if critical {
 		dmg *= 1.5
 	}
 
 	n, vulnerable := living.Hurt(dmg, entity.AttackDamageSource{Attacker: p})
 	i, left := p.HeldItems()
+	if vulnerable && living.Dead() {
+		name := living.Type().EncodeEntity()
+		p.updateStatistics(func(s *stats.Statistics) {
+			s.Kills[name]++
+		})
+	}
 
 	p.World().PlaySound(entity.EyePosition(e), sound.Attack{Damage: !mgl64.FloatEqual(n, 0)})
 	if !vulnerable {
 		return true
 	}
+	entity.AlertPets(p, e)
 	if critical {
 		for _, v := range p.World().Viewers(living.Position()) {
 			v.ViewEntityAction(living, entity.CriticalHitAction{})
@@ -1557,8 +2390,9 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 func (p *Player) StartBreaking(pos cube.Pos, face cube.Face) {
 	p.AbortBreaking()
 	w := p.World()
-	if _, air := w.Block(pos).(block.Air); air || !p.canReach(pos.Vec3Centre()) {
-		// The block was either out of range or air, so it can't be broken by the player.
+	if _, air := w.Block(pos).(block.Air); air || !p.canReach(pos.Vec3Centre()) || !p.canBreak(w.Block(pos)) || !w.Protector().AllowBuild(p, pos, w) {
+		// The block was either out of range, air, protected or not breakable in the game mode of the player, so
+		// it can't be broken by the player.
 		return
 	}
 	if _, ok := w.Block(pos.Side(face)).(block.Fire); ok {
@@ -1592,6 +2426,7 @@ func (p *Player) StartBreaking(pos cube.Pos, face cube.Face) {
 		return
 	}
 	p.lastBreakDuration = p.breakTime(pos)
+	p.lastBreakUpdate, p.breakProgress = time.Now(), 0
 	for _, viewer := range p.viewers() {
 		viewer.ViewBlockAction(pos, block.StartCrackAction{BreakTime: p.lastBreakDuration})
 	}
@@ -1626,6 +2461,8 @@ func (p *Player) breakTime(pos cube.Pos) time.Duration {
 // FinishBreaking makes the player finish breaking the block it is currently breaking, or returns immediately
 // if the player isn't breaking anything.
 // FinishBreaking will stop the animation and break the block.
+// If the player finishes breaking the block significantly earlier than the break time of the block allows, the
+// block is not broken and is sent to the player again.
 func (p *Player) FinishBreaking() {
 	pos := p.breakingPos.Load()
 	if !p.breaking.Load() {
@@ -1633,9 +2470,33 @@ func (p *Player) FinishBreaking() {
 		return
 	}
 	p.AbortBreaking()
+	if !p.GameMode().CreativeInventory() {
+		p.updateBreakProgress()
+		if p.breakProgress < minBreakProgress {
+			p.resendBlock(pos, p.World())
+			return
+		}
+	}
 	p.BreakBlock(pos)
 }
 
+// minBreakProgress is the minimum fraction of the break time of a block that must have passed before a player
+// is allowed to finish breaking it. Some leniency is needed to account for latency and for changes in break
+// time, such as when landing on the ground, that the server only notices later than the client.
+const minBreakProgress = 0.7
+
+// updateBreakProgress adds the time passed since the last update to the break progress of the player, relative
+// to the break time of the block being broken at the time.
+func (p *Player) updateBreakProgress() {
+	now := time.Now()
+	if p.lastBreakDuration <= 0 {
+		p.breakProgress = 1
+	} else {
+		p.breakProgress += float64(now.Sub(p.lastBreakUpdate)) / float64(p.lastBreakDuration)
+	}
+	p.lastBreakUpdate = now
+}
+
 // AbortBreaking makes the player stop breaking the block it is currently breaking, or returns immediately
 // if the player isn't breaking anything.
 // Unlike FinishBreaking, AbortBreaking does not stop the animation.
@@ -1670,6 +2531,10 @@ func (p *Player) ContinueBreaking(face cube.Face) {
 		// either. Every 5 ticks seems accurate.
 		w.PlaySound(pos.Vec3(), sound.BlockBreaking{Block: w.Block(pos)})
 	}
+	if p.GameMode().CreativeInventory() {
+		return
+	}
+	p.updateBreakProgress()
 	breakTime := p.breakTime(pos)
 	if breakTime != p.lastBreakDuration {
 		for _, viewer := range p.viewers() {
@@ -1696,7 +2561,7 @@ func (p *Player) PlaceBlock(pos cube.Pos, b world.Block, ctx *item.UseContext) {
 // of the player. A bool is returned indicating if a block was placed successfully.
 func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
 	w := p.World()
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+	if !p.canReach(pos.Vec3Centre()) || !p.canPlace(pos) || !w.Protector().AllowBuild(p, pos, w) {
 		p.resendBlocks(pos, w, cube.Faces()...)
 		return false
 	}
@@ -1706,16 +2571,65 @@ func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
 	}
 
 	ctx := event.C()
+	if p.Handler().HandleBlockPlaceModify(ctx, pos, &b); ctx.Cancelled() || b == nil {
+		p.resendBlocks(pos, w, cube.Faces()...)
+		return false
+	}
+	if !ignoreBBox && p.obstructedPos(pos, b) {
+		// The block may have been changed by the Handler, so we need to check for obstructions again.
+		p.resendBlocks(pos, w, cube.Faces()...)
+		return false
+	}
 	if p.Handler().HandleBlockPlace(ctx, pos, b); ctx.Cancelled() {
 		p.resendBlocks(pos, w, cube.Faces()...)
 		return false
 	}
 	w.SetBlock(pos, b, nil)
 	w.PlaySound(pos.Vec3(), sound.BlockPlace{Block: b})
+	w.EmitGameEvent(pos.Vec3Centre(), gameevent.BlockPlace{}, p)
 	p.SwingArm()
 	return true
 }
 
+// canActivate checks if the player has the ability to activate the block passed. Containers require the
+// ability.OpenContainers ability, while other blocks require ability.DoorsAndSwitches.
+func (p *Player) canActivate(b world.Block) bool {
+	if _, ok := b.(block.Container); ok {
+		return p.HasAbility(ability.OpenContainers)
+	}
+	return p.HasAbility(ability.DoorsAndSwitches)
+}
+
+// canPlace checks if the GameMode of the player allows it to place a block at the position passed. Players in
+// a GameMode that does not allow editing the world, such as adventure mode, may only place a block against one
+// of the blocks listed in the CanPlaceOn list of the item held.
+func (p *Player) canPlace(pos cube.Pos) bool {
+	if p.HasAbility(ability.Build) {
+		return true
+	} else if !p.GameMode().AllowsInteraction() {
+		return false
+	}
+	held, _ := p.HeldItems()
+	w := p.World()
+	for _, face := range cube.Faces() {
+		if held.PlaceableOn(w.Block(pos.Side(face))) {
+			return true
+		}
+	}
+	return false
+}
+
+// canBreak checks if the GameMode of the player allows it to break the block passed. Players in a GameMode that
+// does not allow editing the world, such as adventure mode, may only break blocks listed in the CanDestroy list
+// of the item held.
+func (p *Player) canBreak(b world.Block) bool {
+	if p.HasAbility(ability.Mine) {
+		return true
+	}
+	held, _ := p.HeldItems()
+	return p.GameMode().AllowsInteraction() && held.Destroys(b)
+}
+
 // obstructedPos checks if the position passed is obstructed if the block passed is attempted to be placed.
 // The function returns true if there is an entity in the way that could prevent the block from being placed.
 func (p *Player) obstructedPos(pos cube.Pos, b world.Block) bool {
@@ -1748,7 +2662,7 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 		// Don't do anything if the position broken is already air.
 		return
 	}
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+	if !p.canReach(pos.Vec3Centre()) || !p.canBreak(b) || !w.Protector().AllowBuild(p, pos, w) {
 		p.resendBlocks(pos, w)
 		return
 	}
@@ -1774,6 +2688,11 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 	p.SwingArm()
 	w.SetBlock(pos, nil, nil)
 	w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
+	w.EmitGameEvent(pos.Vec3Centre(), gameevent.BlockDestroy{}, p)
+	name, _ := b.EncodeBlock()
+	p.updateStatistics(func(s *stats.Statistics) {
+		s.BlocksMined[name]++
+	})
 
 	if breakable, ok := b.(block.Breakable); ok {
 		info := breakable.BreakInfo()
@@ -1786,6 +2705,10 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 		}
 	}
 	for _, drop := range drops {
+		if drop.Empty() {
+			// Some drops, such as the seeds of a crop, may have a count of 0.
+			continue
+		}
 		ent := entity.NewItem(drop, pos.Vec3Centre())
 		ent.SetVelocity(mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1})
 		w.AddEntity(ent)
@@ -1887,9 +2810,38 @@ func (p *Player) Teleport(pos mgl64.Vec3) {
 	if p.Handler().HandleTeleport(ctx, pos); ctx.Cancelled() {
 		return
 	}
+	p.exemptMovement()
 	p.teleport(pos)
 }
 
+// TeleportToWorld teleports the player to a position in the world.World passed. If w is the world the player
+// is currently in, TeleportToWorld behaves the same as Teleport. Otherwise, the player is removed from its current
+// world and added to w, and the client is shown a loading screen until the chunks around the position are sent.
+func (p *Player) TeleportToWorld(w *world.World, pos mgl64.Vec3) {
+	if w == nil {
+		return
+	}
+	if w == p.World() {
+		p.Teleport(pos)
+		return
+	}
+	ctx := event.C()
+	if p.Handler().HandleTeleport(ctx, pos); ctx.Cancelled() {
+		return
+	}
+	p.AbortBreaking()
+	p.exemptMovement()
+
+	// The position must be updated before adding the player to the new world, so that it is added to the
+	// chunk that it will actually be in.
+	p.pos.Store(pos)
+	p.vel.Store(mgl64.Vec3{})
+	p.ResetFallDistance()
+
+	w.AddEntity(p)
+	p.session().ChangeWorld(w)
+}
+
 // teleport teleports the player to a target position in the world. It does not call the Handler of the
 // player.
 func (p *Player) teleport(pos mgl64.Vec3) {
@@ -1922,6 +2874,9 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 		yaw, pitch            = p.Rotation().Elem()
 		res, resYaw, resPitch = pos.Add(deltaPos), yaw + deltaYaw, pitch + deltaPitch
 	)
+	if p.session() != session.Nop && !deltaPos.ApproxEqual(mgl64.Vec3{}) && !p.validateMovement(w, pos, res) {
+		return
+	}
 	ctx := event.C()
 	if p.Handler().HandleMove(ctx, res, resYaw, resPitch); ctx.Cancelled() {
 		if p.session() != session.Nop && pos.ApproxEqual(p.Position()) {
@@ -1942,6 +2897,13 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 		// Only update velocity if the player is not moving too fast to prevent potential OOMs.
 		p.vel.Store(deltaPos)
 		p.checkBlockCollisions(deltaPos, w)
+		if p.OnGround() {
+			p.frostWalk(w, res)
+			p.step(w, res, deltaPos)
+		}
+		p.updateStatistics(func(s *stats.Statistics) {
+			s.DistanceTravelled += deltaPos.Len()
+		})
 	}
 
 	horizontalVel := deltaPos
@@ -1993,13 +2955,15 @@ func (p *Player) Velocity() mgl64.Vec3 {
 	return p.vel.Load()
 }
 
-// SetVelocity updates the player's velocity. If there is an attached session, this will just send
-// the velocity to the player session for the player to update.
+// SetVelocity updates the player's velocity. Because the movement of players is controlled by the client, the
+// velocity is also sent to the session of the player, as well as to any viewers of the player, so that the
+// client moves the player accordingly.
 func (p *Player) SetVelocity(velocity mgl64.Vec3) {
+	p.vel.Store(velocity)
 	if p.session() == session.Nop {
-		p.vel.Store(velocity)
 		return
 	}
+	p.exemptMovement()
 	for _, v := range p.viewers() {
 		v.ViewEntityVelocity(p, velocity)
 	}
@@ -2177,6 +3141,53 @@ func (p *Player) OpenBlockContainer(pos cube.Pos) {
 	}
 }
 
+// OpenTrading opens the trading window of the entity passed for the player, showing the offers of the
+// entity.TradeList passed. Any entity may be traded with, so that NPCs may be given a custom list of trades.
+// OpenTrading does nothing if the player has no session connected to it.
+func (p *Player) OpenTrading(e world.Entity, l *entity.TradeList) {
+	if p.session() != session.Nop {
+		p.session().OpenTrading(e, l)
+	}
+}
+
+// OpenMenu opens the gui.Menu passed for the player, replacing any container or menu it currently has opened.
+// Items in the menu cannot be taken out by the player: Clicking a slot runs the function of the gui.Button in
+// it instead. OpenMenu does nothing if the player has no session connected to it.
+func (p *Player) OpenMenu(m gui.Menu) {
+	if p.session() != session.Nop {
+		p.session().OpenMenu(m)
+	}
+}
+
+// CloseMenu closes the gui.Menu that the player currently has opened. CloseMenu does nothing if the player
+// has no menu opened.
+func (p *Player) CloseMenu() {
+	p.session().CloseMenu()
+}
+
+// OpenHorseInventory opens the inventory of the horse passed for the player, holding its saddle and armour.
+// OpenHorseInventory does nothing if the player has no session connected to it.
+func (p *Player) OpenHorseInventory(e world.Entity, inv *inventory.Inventory) {
+	if p.session() != session.Nop {
+		p.session().OpenHorseInventory(e, inv)
+	}
+}
+
+// Emote makes the player perform the emote with the UUID passed, showing it to all viewers of the player.
+// The emote is not shown if the Handler of the player cancels it.
+func (p *Player) Emote(emote uuid.UUID) {
+	if p.Dead() {
+		return
+	}
+	ctx := event.C()
+	if p.Handler().HandleEmote(ctx, emote); ctx.Cancelled() {
+		return
+	}
+	for _, v := range p.viewers() {
+		v.ViewEmote(p, emote)
+	}
+}
+
 // HideEntity hides a world.Entity from the Player so that it can under no circumstance see it. Hidden entities can be
 // made visible again through a call to ShowEntity.
 func (p *Player) HideEntity(e world.Entity) {
@@ -2213,6 +3224,11 @@ func (p *Player) Tick(w *world.World, current int64) {
 		p.Handler().HandleChangeWorld(p.lastTickedWorld, w)
 	}
 	p.lastTickedWorld = w
+	if e, ok := p.Riding(); ok {
+		if ew, _ := world.OfEntity(e); ew != w {
+			p.Dismount()
+		}
+	}
 	if _, ok := w.Liquid(cube.PosFromVec3(p.Position())); !ok {
 		p.StopSwimming()
 		if _, ok := p.Armour().Helmet().Item().(item.TurtleShell); ok {
@@ -2220,6 +3236,14 @@ func (p *Player) Tick(w *world.World, current int64) {
 		}
 	}
 
+	if p.Riptiding() {
+		p.tickRiptide(w)
+	}
+	if p.Gliding() && !p.canGlide() {
+		// The player landed, entered a liquid or took off its elytra, so it can no longer glide. Fall damage
+		// from hard landings is dealt as usual, as the fall distance is only reset while gliding slowly.
+		p.StopGliding()
+	}
 	if _, ok := p.Armour().Chestplate().Item().(item.Elytra); ok && p.Gliding() {
 		if t := p.glideTicks.Inc(); t%20 == 0 {
 			d := p.damageItem(p.Armour().Chestplate(), 1)
@@ -2233,7 +3257,21 @@ func (p *Player) Tick(w *world.World, current int64) {
 	p.checkBlockCollisions(p.vel.Load(), w)
 	p.onGround.Store(p.checkOnGround(w))
 
+	if pos, ok := p.Sleeping(); ok {
+		if _, bed := w.Block(pos).(block.Bed); !bed {
+			// The bed was removed while the player was sleeping in it.
+			p.Wake()
+		}
+	}
+
 	p.effects.Tick(p)
+	p.hud.tick(p, current)
+	if current%20 == 0 {
+		p.tickMilestones()
+	}
+	p.updateStatistics(func(s *stats.Statistics) {
+		s.PlayTime += time.Second / 20
+	})
 
 	p.tickFood(w)
 	p.tickAirSupply(w)
@@ -2247,6 +3285,8 @@ func (p *Player) Tick(w *world.World, current int64) {
 		p.Hurt(1, entity.SuffocationDamageSource{})
 	}
 
+	p.tickFreezing(current)
+
 	if p.OnFireDuration() > 0 {
 		p.fireTicks.Sub(1)
 		if !p.GameMode().AllowsTakingDamage() || p.OnFireDuration() <= 0 || w.RainingAt(cube.PosFromVec3(p.Position())) {
@@ -2445,6 +3485,9 @@ func (p *Player) checkBlockCollisions(vel mgl64.Vec3, w *world.World) {
 	min, max := grown.Min(), grown.Max()
 	minX, minY, minZ := int(math.Floor(min[0])), int(math.Floor(min[1])), int(math.Floor(min[2]))
 	maxX, maxY, maxZ := int(math.Ceil(max[0])), int(math.Ceil(max[1])), int(math.Ceil(max[2]))
+	// Blocks such as fences and walls have a collision box that extends into the block above them, so the
+	// blocks below the BBox are included too.
+	minY--
 
 	// A prediction of one BBox per block, plus an additional 2, in case
 	blocks := make([]cube.BBox, 0, (maxX-minX)*(maxY-minY)*(maxZ-minZ)+2)
@@ -2727,51 +3770,74 @@ func (p *Player) addNewItem(ctx *item.UseContext) {
 }
 
 // canReach checks if a player can reach a position with its current range. The range depends on if the player
-// is either survival or creative mode.
+// is either survival or creative mode, and is slightly larger for players using touch input.
 func (p *Player) canReach(pos mgl64.Vec3) bool {
 	const (
 		creativeRange = 14.0
 		survivalRange = 8.0
+		// touchLeniency is the additional range granted to players using a touch screen or motion controller,
+		// as their input is less precise than that of a mouse or controller.
+		touchLeniency = 1.5
 	)
 	if !p.GameMode().AllowsInteraction() {
 		return false
 	}
 	eyes := entity.EyePosition(p)
 
+	r := survivalRange
 	if p.GameMode().CreativeInventory() {
-		return eyes.Sub(pos).Len() <= creativeRange && !p.Dead()
+		r = creativeRange
+	}
+	if mode := p.InputMode(); mode == settings.Touch() || mode == settings.MotionController() {
+		r += touchLeniency
 	}
-	return eyes.Sub(pos).Len() <= survivalRange && !p.Dead()
+	return eyes.Sub(pos).Len() <= r && !p.Dead()
 }
 
 // Disconnect closes the player and removes it from the world.
 // Disconnect, unlike Close, allows a custom message to be passed to show to the player when it is
 // disconnected. The message is formatted following the rules of fmt.Sprintln without a newline at the end.
 func (p *Player) Disconnect(msg ...any) {
-	p.once.Do(func() {
-		p.close(format(msg))
-	})
+	p.disconnect(DisconnectKick, format(msg))
+}
+
+// ExceedPacketLimit is called when the player keeps sending a packet faster than allowed. The player is
+// disconnected unless the Handler of the player cancels the event.
+func (p *Player) ExceedPacketLimit(packet string) {
+	ctx := event.C()
+	if p.Handler().HandlePacketLimit(ctx, packet); ctx.Cancelled() {
+		return
+	}
+	p.Kick("Sent too many packets.")
 }
 
 // Close closes the player and removes it from the world.
 // Close disconnects the player with a 'Connection closed.' message. Disconnect should be used to disconnect a
 // player with a custom message.
 func (p *Player) Close() error {
-	p.once.Do(func() {
-		p.close("Connection closed.")
-	})
+	cause := DisconnectClose
+	if lost, timedOut := p.session().ConnectionLost(); timedOut {
+		cause = DisconnectTimeout
+	} else if lost {
+		cause = DisconnectQuit
+	}
+	p.disconnect(cause, "Connection closed.")
 	return nil
 }
 
 // close closes the player without disconnecting it. It executes code shared by both the closing and the
-// disconnecting of players.
-func (p *Player) close(msg string) {
+// disconnecting of players. The Handler of the player is called before anything is cleaned up, so the
+// player is still in its world with its inventories intact when it is.
+func (p *Player) close(cause DisconnectCause, msg string) {
 	// If the player is being disconnected while they are dead, we respawn the player
 	// so that the player logic works correctly the next time they join.
 	if p.Dead() && p.session() != nil {
 		p.Respawn()
 	}
-	p.h.Swap(NopHandler{}).HandleQuit()
+	h := p.h.Swap(NopHandler{})
+	h.HandleDisconnect(cause, msg)
+	h.HandleQuit()
+	p.Dismount()
 
 	if s := p.s.Swap(nil); s != nil {
 		s.Disconnect(msg)
@@ -2810,11 +3876,18 @@ func (p *Player) load(data Data) {
 	p.enchantSeed.Store(data.EnchantmentSeed)
 
 	p.gameMode.Store(data.GameMode)
+	p.abilities.Store(data.Abilities)
+	p.progress = data.Progress.Clone()
+	p.stats = data.Statistics.Clone()
 	for _, potion := range data.Effects {
 		p.AddEffect(potion)
 	}
 	p.fireTicks.Store(data.FireTicks)
 	p.fallDistance.Store(data.FallDistance)
+	if data.SpawnPoint != nil {
+		pos := *data.SpawnPoint
+		p.spawnPos.Store(&pos)
+	}
 
 	p.loadInventory(data.Inventory)
 	for slot, stack := range data.EnderChestInventory {
@@ -2833,7 +3906,20 @@ func (p *Player) loadInventory(data InventoryData) {
 
 // Data returns the player data that needs to be saved. This is used when the player
 // gets disconnected and the player provider needs to save the data.
+// If the Data of the player was carried over to another server by its Carrier, the Data returned holds no items
+// or experience, as those now belong to the player on the other server. Saving them here would duplicate them.
 func (p *Player) Data() Data {
+	d := p.data()
+	if p.carried.Load() {
+		d.Inventory = InventoryData{Items: make([]item.Stack, len(d.Inventory.Items))}
+		d.EnderChestInventory = make([]item.Stack, len(d.EnderChestInventory))
+		d.Experience = 0
+	}
+	return d
+}
+
+// data returns the full Data of the player.
+func (p *Player) data() Data {
 	yaw, pitch := p.Rotation().Elem()
 	offHand, _ := p.offHand.Item(0)
 
@@ -2859,6 +3945,9 @@ func (p *Player) Data() Data {
 		SaturationLevel: p.hunger.saturationLevel,
 		AbsorptionLevel: p.Absorption(),
 		GameMode:        p.GameMode(),
+		Abilities:       p.Abilities(),
+		Progress:        p.Progress(),
+		Statistics:      p.Statistics(),
 		Inventory: InventoryData{
 			Items:        p.Inventory().Slots(),
 			Boots:        p.armour.Boots(),
@@ -2872,6 +3961,7 @@ func (p *Player) Data() Data {
 		Effects:             p.Effects(),
 		FireTicks:           p.fireTicks.Load(),
 		FallDistance:        p.fallDistance.Load(),
+		SpawnPoint:          p.spawnPos.Load(),
 		World:               p.World(),
 	}
 }
@@ -0,0 +1,92 @@
+package playerdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// NBTSerializer is a player.Serializer that encodes player.Data in the same NBT format as FileProvider.
+type NBTSerializer struct{}
+
+// Marshal ...
+func (NBTSerializer) Marshal(d player.Data) ([]byte, error) {
+	b, err := nbt.MarshalEncoding(dataToNBT(d), nbt.LittleEndian)
+	if err != nil {
+		return nil, fmt.Errorf("encode player data: %w", err)
+	}
+	return b, nil
+}
+
+// Unmarshal ...
+func (NBTSerializer) Unmarshal(b []byte, world func(world.Dimension) *world.World) (player.Data, error) {
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(bytes.NewReader(b), nbt.LittleEndian).Decode(&m); err != nil {
+		return player.Data{}, fmt.Errorf("decode player data: %w", err)
+	}
+	return nbtToData(m, world), nil
+}
+
+// FileCarrier is a player.Carrier that stores the data of players being transferred in a directory, such as a
+// directory on a file system shared by all servers of a network. The data is encoded using a player.Serializer.
+type FileCarrier struct {
+	dir string
+	s   player.Serializer
+}
+
+// NewFileCarrier creates a FileCarrier that stores the data of players in the directory passed, encoded using
+// the player.Serializer passed. If s is nil, NBTSerializer is used. The directory is created if it does not yet
+// exist.
+func NewFileCarrier(dir string, s player.Serializer) (*FileCarrier, error) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, fmt.Errorf("create carrier directory: %w", err)
+	}
+	if s == nil {
+		s = NBTSerializer{}
+	}
+	return &FileCarrier{dir: dir, s: s}, nil
+}
+
+// Carry ...
+func (c *FileCarrier) Carry(id uuid.UUID, _ *net.UDPAddr, d player.Data) error {
+	b, err := c.s.Marshal(d)
+	if err != nil {
+		return err
+	}
+	path := c.path(id)
+	if err := os.WriteFile(path+".tmp", b, 0644); err != nil {
+		return fmt.Errorf("write carried player data: %w", err)
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// Receive ...
+func (c *FileCarrier) Receive(id uuid.UUID, world func(world.Dimension) *world.World) (player.Data, bool, error) {
+	path := c.path(id)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return player.Data{}, false, nil
+	} else if err != nil {
+		return player.Data{}, false, fmt.Errorf("read carried player data: %w", err)
+	}
+	if err := os.Remove(path); err != nil {
+		return player.Data{}, false, fmt.Errorf("remove carried player data: %w", err)
+	}
+	d, err := c.s.Unmarshal(b, world)
+	if err != nil {
+		return player.Data{}, false, err
+	}
+	return d, true, nil
+}
+
+// path returns the path of the file that the carried data of the player with the UUID passed is stored in.
+func (c *FileCarrier) path(id uuid.UUID) string {
+	return filepath.Join(c.dir, id.String()+".dat")
+}
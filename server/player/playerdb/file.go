@@ -0,0 +1,66 @@
+package playerdb
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"os"
+	"path/filepath"
+)
+
+// FileProvider is a player data provider that stores the data of every player in a separate NBT file, named
+// after the UUID of the player, in a directory. Unlike Provider, the files written by FileProvider may be
+// inspected and edited using regular NBT editors.
+type FileProvider struct {
+	dir string
+}
+
+// NewFileProvider creates a new player data provider that saves and loads data using NBT files in the directory
+// passed. The directory is created if it does not yet exist.
+func NewFileProvider(dir string) (*FileProvider, error) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, fmt.Errorf("create player data directory: %w", err)
+	}
+	return &FileProvider{dir: dir}, nil
+}
+
+// Save ...
+func (p *FileProvider) Save(id uuid.UUID, d player.Data) error {
+	b, err := nbt.MarshalEncoding(dataToNBT(d), nbt.LittleEndian)
+	if err != nil {
+		return fmt.Errorf("encode player data: %w", err)
+	}
+	// Write the data to a temporary file first and rename it after, so that the data of a player is never left
+	// half written if the server crashes while saving.
+	path := p.path(id)
+	if err := os.WriteFile(path+".tmp", b, 0644); err != nil {
+		return fmt.Errorf("write player data: %w", err)
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// Load ...
+func (p *FileProvider) Load(id uuid.UUID, world func(world.Dimension) *world.World) (player.Data, error) {
+	b, err := os.ReadFile(p.path(id))
+	if err != nil {
+		return player.Data{}, err
+	}
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(bytes.NewReader(b), nbt.LittleEndian).Decode(&m); err != nil {
+		return player.Data{}, fmt.Errorf("decode player data: %w", err)
+	}
+	return nbtToData(m, world), nil
+}
+
+// Close ...
+func (p *FileProvider) Close() error {
+	return nil
+}
+
+// path returns the path of the file that the data of the player with the UUID passed is stored in.
+func (p *FileProvider) path(id uuid.UUID) string {
+	return filepath.Join(p.dir, id.String()+".dat")
+}
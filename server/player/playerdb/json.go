@@ -1,8 +1,12 @@
 package playerdb
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/ability"
+	"github.com/df-mc/dragonfly/server/player/progression"
+	"github.com/df-mc/dragonfly/server/player/stats"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
@@ -13,27 +17,45 @@ func (p *Provider) fromJson(d jsonData, lookupWorld func(world.Dimension) *world
 	dim, _ := world.DimensionByID(int(d.Dimension))
 	mode, _ := world.GameModeByID(int(d.GameMode))
 	data := player.Data{
-		UUID:                uuid.MustParse(d.UUID),
-		Username:            d.Username,
-		Position:            d.Position,
-		Velocity:            d.Velocity,
-		Yaw:                 d.Yaw,
-		Pitch:               d.Pitch,
-		Health:              d.Health,
-		MaxHealth:           d.MaxHealth,
-		Hunger:              d.Hunger,
-		FoodTick:            d.FoodTick,
-		ExhaustionLevel:     d.ExhaustionLevel,
-		SaturationLevel:     d.SaturationLevel,
-		AbsorptionLevel:     d.AbsorptionLevel,
-		Experience:          d.Experience,
-		AirSupply:           d.AirSupply,
-		MaxAirSupply:        d.MaxAirSupply,
-		EnchantmentSeed:     d.EnchantmentSeed,
-		GameMode:            mode,
+		UUID:            uuid.MustParse(d.UUID),
+		Username:        d.Username,
+		Position:        d.Position,
+		Velocity:        d.Velocity,
+		Yaw:             d.Yaw,
+		Pitch:           d.Pitch,
+		Health:          d.Health,
+		MaxHealth:       d.MaxHealth,
+		Hunger:          d.Hunger,
+		FoodTick:        d.FoodTick,
+		ExhaustionLevel: d.ExhaustionLevel,
+		SaturationLevel: d.SaturationLevel,
+		AbsorptionLevel: d.AbsorptionLevel,
+		Experience:      d.Experience,
+		AirSupply:       d.AirSupply,
+		MaxAirSupply:    d.MaxAirSupply,
+		EnchantmentSeed: d.EnchantmentSeed,
+		GameMode:        mode,
+		Abilities: ability.Abilities{
+			Granted:   ability.Ability(d.Abilities.Granted),
+			Denied:    ability.Ability(d.Abilities.Denied),
+			FlySpeed:  d.Abilities.FlySpeed,
+			WalkSpeed: d.Abilities.WalkSpeed,
+		},
+		Progress: progression.Progress{
+			Criteria:  d.Progress.Criteria,
+			Completed: d.Progress.Completed,
+		}.Clone(),
+		Statistics: stats.Statistics{
+			BlocksMined:       d.Statistics.BlocksMined,
+			Kills:             d.Statistics.Kills,
+			Deaths:            d.Statistics.Deaths,
+			DistanceTravelled: d.Statistics.DistanceTravelled,
+			PlayTime:          d.Statistics.PlayTime,
+		}.Clone(),
 		Effects:             dataToEffects(d.Effects),
 		FireTicks:           d.FireTicks,
 		FallDistance:        d.FallDistance,
+		SpawnPoint:          (*cube.Pos)(d.SpawnPoint),
 		Inventory:           dataToInv(d.Inventory),
 		EnderChestInventory: make([]item.Stack, 27),
 		World:               lookupWorld(dim),
@@ -46,27 +68,45 @@ func (p *Provider) toJson(d player.Data) jsonData {
 	dim, _ := world.DimensionID(d.World.Dimension())
 	mode, _ := world.GameModeID(d.GameMode)
 	return jsonData{
-		UUID:                d.UUID.String(),
-		Username:            d.Username,
-		Position:            d.Position,
-		Velocity:            d.Velocity,
-		Yaw:                 d.Yaw,
-		Pitch:               d.Pitch,
-		Health:              d.Health,
-		MaxHealth:           d.MaxHealth,
-		Hunger:              d.Hunger,
-		FoodTick:            d.FoodTick,
-		ExhaustionLevel:     d.ExhaustionLevel,
-		SaturationLevel:     d.SaturationLevel,
-		AbsorptionLevel:     d.AbsorptionLevel,
-		Experience:          d.Experience,
-		AirSupply:           d.AirSupply,
-		MaxAirSupply:        d.MaxAirSupply,
-		EnchantmentSeed:     d.EnchantmentSeed,
-		GameMode:            uint8(mode),
+		UUID:            d.UUID.String(),
+		Username:        d.Username,
+		Position:        d.Position,
+		Velocity:        d.Velocity,
+		Yaw:             d.Yaw,
+		Pitch:           d.Pitch,
+		Health:          d.Health,
+		MaxHealth:       d.MaxHealth,
+		Hunger:          d.Hunger,
+		FoodTick:        d.FoodTick,
+		ExhaustionLevel: d.ExhaustionLevel,
+		SaturationLevel: d.SaturationLevel,
+		AbsorptionLevel: d.AbsorptionLevel,
+		Experience:      d.Experience,
+		AirSupply:       d.AirSupply,
+		MaxAirSupply:    d.MaxAirSupply,
+		EnchantmentSeed: d.EnchantmentSeed,
+		GameMode:        uint8(mode),
+		Abilities: jsonAbilities{
+			Granted:   uint32(d.Abilities.Granted),
+			Denied:    uint32(d.Abilities.Denied),
+			FlySpeed:  d.Abilities.FlySpeed,
+			WalkSpeed: d.Abilities.WalkSpeed,
+		},
+		Progress: jsonProgress{
+			Criteria:  d.Progress.Criteria,
+			Completed: d.Progress.Completed,
+		},
+		Statistics: jsonStatistics{
+			BlocksMined:       d.Statistics.BlocksMined,
+			Kills:             d.Statistics.Kills,
+			Deaths:            d.Statistics.Deaths,
+			DistanceTravelled: d.Statistics.DistanceTravelled,
+			PlayTime:          d.Statistics.PlayTime,
+		},
 		Effects:             effectsToData(d.Effects),
 		FireTicks:           d.FireTicks,
 		FallDistance:        d.FallDistance,
+		SpawnPoint:          (*[3]int)(d.SpawnPoint),
 		Inventory:           invToData(d.Inventory),
 		EnderChestInventory: encodeItems(d.EnderChestInventory),
 		Dimension:           uint8(dim),
@@ -87,14 +127,35 @@ type jsonData struct {
 	Experience                       int
 	AirSupply, MaxAirSupply          int64
 	GameMode                         uint8
+	Abilities                        jsonAbilities
+	Progress                         jsonProgress
+	Statistics                       jsonStatistics
 	Inventory                        jsonInventoryData
 	EnderChestInventory              []jsonSlot
 	Effects                          []jsonEffect
 	FireTicks                        int64
 	FallDistance                     float64
+	SpawnPoint                       *[3]int
 	Dimension                        uint8
 }
 
+type jsonAbilities struct {
+	Granted, Denied     uint32
+	FlySpeed, WalkSpeed float64
+}
+
+type jsonProgress struct {
+	Criteria  map[string]map[string]int
+	Completed map[string]time.Time
+}
+
+type jsonStatistics struct {
+	BlocksMined, Kills map[string]int
+	Deaths             int
+	DistanceTravelled  float64
+	PlayTime           time.Duration
+}
+
 type jsonInventoryData struct {
 	Items        []jsonSlot
 	Boots        []byte
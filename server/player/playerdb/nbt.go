@@ -0,0 +1,303 @@
+package playerdb
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/ability"
+	"github.com/df-mc/dragonfly/server/player/progression"
+	"github.com/df-mc/dragonfly/server/player/stats"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"time"
+)
+
+// dataToNBT converts player.Data to a map that may be encoded as NBT.
+func dataToNBT(d player.Data) map[string]any {
+	dim, _ := world.DimensionID(d.World.Dimension())
+	mode, _ := world.GameModeID(d.GameMode)
+	m := map[string]any{
+		"UUID":            d.UUID.String(),
+		"Username":        d.Username,
+		"Pos":             []float64{d.Position[0], d.Position[1], d.Position[2]},
+		"Motion":          []float64{d.Velocity[0], d.Velocity[1], d.Velocity[2]},
+		"Yaw":             d.Yaw,
+		"Pitch":           d.Pitch,
+		"Health":          d.Health,
+		"MaxHealth":       d.MaxHealth,
+		"Hunger":          int32(d.Hunger),
+		"FoodTick":        int32(d.FoodTick),
+		"Exhaustion":      d.ExhaustionLevel,
+		"Saturation":      d.SaturationLevel,
+		"Absorption":      d.AbsorptionLevel,
+		"EnchantmentSeed": d.EnchantmentSeed,
+		"Experience":      int32(d.Experience),
+		"AirSupply":       d.AirSupply,
+		"MaxAirSupply":    d.MaxAirSupply,
+		"GameMode":        uint8(mode),
+		"Abilities": map[string]any{
+			"Granted":   int32(d.Abilities.Granted),
+			"Denied":    int32(d.Abilities.Denied),
+			"FlySpeed":  d.Abilities.FlySpeed,
+			"WalkSpeed": d.Abilities.WalkSpeed,
+		},
+		"Progress":     progressToNBT(d.Progress),
+		"Statistics":   statisticsToNBT(d.Statistics),
+		"Inventory":    itemsToNBT(d.Inventory.Items),
+		"Boots":        itemToNBT(d.Inventory.Boots),
+		"Leggings":     itemToNBT(d.Inventory.Leggings),
+		"Chestplate":   itemToNBT(d.Inventory.Chestplate),
+		"Helmet":       itemToNBT(d.Inventory.Helmet),
+		"OffHand":      itemToNBT(d.Inventory.OffHand),
+		"MainHandSlot": uint8(d.Inventory.MainHandSlot),
+		"EnderChest":   itemsToNBT(d.EnderChestInventory),
+		"Effects":      effectsToNBT(d.Effects),
+		"FireTicks":    d.FireTicks,
+		"FallDistance": d.FallDistance,
+		"Dimension":    uint8(dim),
+	}
+	if d.SpawnPoint != nil {
+		m["SpawnPoint"] = []int32{int32(d.SpawnPoint[0]), int32(d.SpawnPoint[1]), int32(d.SpawnPoint[2])}
+	}
+	return m
+}
+
+// nbtToData converts an NBT map, as produced by dataToNBT, back to player.Data.
+func nbtToData(m map[string]any, lookupWorld func(world.Dimension) *world.World) player.Data {
+	dim, _ := world.DimensionByID(int(nbtconv.Uint8(m, "Dimension")))
+	mode, _ := world.GameModeByID(int(nbtconv.Uint8(m, "GameMode")))
+	id, _ := uuid.Parse(nbtconv.String(m, "UUID"))
+	abilities, _ := m["Abilities"].(map[string]any)
+	data := player.Data{
+		UUID:            id,
+		Username:        nbtconv.String(m, "Username"),
+		Position:        vec64(m, "Pos"),
+		Velocity:        vec64(m, "Motion"),
+		Yaw:             nbtconv.Float64(m, "Yaw"),
+		Pitch:           nbtconv.Float64(m, "Pitch"),
+		Health:          nbtconv.Float64(m, "Health"),
+		MaxHealth:       nbtconv.Float64(m, "MaxHealth"),
+		Hunger:          int(nbtconv.Int32(m, "Hunger")),
+		FoodTick:        int(nbtconv.Int32(m, "FoodTick")),
+		ExhaustionLevel: nbtconv.Float64(m, "Exhaustion"),
+		SaturationLevel: nbtconv.Float64(m, "Saturation"),
+		AbsorptionLevel: nbtconv.Float64(m, "Absorption"),
+		EnchantmentSeed: nbtconv.Int64(m, "EnchantmentSeed"),
+		Experience:      int(nbtconv.Int32(m, "Experience")),
+		AirSupply:       nbtconv.Int64(m, "AirSupply"),
+		MaxAirSupply:    nbtconv.Int64(m, "MaxAirSupply"),
+		GameMode:        mode,
+		Abilities: ability.Abilities{
+			Granted:   ability.Ability(nbtconv.Int32(abilities, "Granted")),
+			Denied:    ability.Ability(nbtconv.Int32(abilities, "Denied")),
+			FlySpeed:  nbtconv.Float64(abilities, "FlySpeed"),
+			WalkSpeed: nbtconv.Float64(abilities, "WalkSpeed"),
+		},
+		Progress:   nbtToProgress(m, "Progress"),
+		Statistics: nbtToStatistics(m, "Statistics"),
+		Inventory: player.InventoryData{
+			Items:        make([]item.Stack, 36),
+			Boots:        nbtToItem(m, "Boots"),
+			Leggings:     nbtToItem(m, "Leggings"),
+			Chestplate:   nbtToItem(m, "Chestplate"),
+			Helmet:       nbtToItem(m, "Helmet"),
+			OffHand:      nbtToItem(m, "OffHand"),
+			MainHandSlot: uint32(nbtconv.Uint8(m, "MainHandSlot")),
+		},
+		EnderChestInventory: make([]item.Stack, 27),
+		Effects:             nbtToEffects(nbtconv.Slice(m, "Effects")),
+		FireTicks:           nbtconv.Int64(m, "FireTicks"),
+		FallDistance:        nbtconv.Float64(m, "FallDistance"),
+		World:               lookupWorld(dim),
+	}
+	nbtToItems(nbtconv.Slice(m, "Inventory"), data.Inventory.Items)
+	nbtToItems(nbtconv.Slice(m, "EnderChest"), data.EnderChestInventory)
+	if l, ok := m["SpawnPoint"].([]int32); ok && len(l) == 3 {
+		data.SpawnPoint = &cube.Pos{int(l[0]), int(l[1]), int(l[2])}
+	}
+	return data
+}
+
+// vec64 reads a list of three float64 values from a map at key k.
+func vec64(m map[string]any, k string) (v [3]float64) {
+	l, _ := m[k].([]any)
+	if len(l) != 3 {
+		return
+	}
+	for i, f := range l {
+		v[i], _ = f.(float64)
+	}
+	return
+}
+
+// itemToNBT encodes an item.Stack to a map that may be encoded as NBT. Empty stacks are encoded as an empty map.
+func itemToNBT(s item.Stack) map[string]any {
+	if s.Empty() {
+		return map[string]any{}
+	}
+	return nbtconv.WriteItem(s, true)
+}
+
+// nbtToItem decodes an item.Stack encoded using itemToNBT from a map at key k.
+func nbtToItem(m map[string]any, k string) item.Stack {
+	data, _ := m[k].(map[string]any)
+	if len(data) == 0 {
+		return item.Stack{}
+	}
+	return nbtconv.Item(data, nil)
+}
+
+// itemsToNBT encodes a slice of item stacks to a slice of maps holding the items and their slots. Empty stacks
+// are not included.
+func itemsToNBT(items []item.Stack) []any {
+	encoded := make([]any, 0, len(items))
+	for slot, i := range items {
+		if i.Empty() {
+			continue
+		}
+		data := nbtconv.WriteItem(i, true)
+		data["Slot"] = uint8(slot)
+		encoded = append(encoded, data)
+	}
+	return encoded
+}
+
+// nbtToItems decodes a slice of maps produced by itemsToNBT into the item stack slice passed.
+func nbtToItems(encoded []any, items []item.Stack) {
+	for _, v := range encoded {
+		data, _ := v.(map[string]any)
+		if slot := int(nbtconv.Uint8(data, "Slot")); slot < len(items) {
+			items[slot] = nbtconv.Item(data, nil)
+		}
+	}
+}
+
+// progressToNBT encodes the progress of a player towards milestones to a map that may be encoded as NBT.
+func progressToNBT(p progression.Progress) map[string]any {
+	criteria, completed := make(map[string]any, len(p.Criteria)), make(map[string]any, len(p.Completed))
+	for id, c := range p.Criteria {
+		m := make(map[string]any, len(c))
+		for name, n := range c {
+			m[name] = int32(n)
+		}
+		criteria[id] = m
+	}
+	for id, t := range p.Completed {
+		completed[id] = t.Unix()
+	}
+	return map[string]any{"Criteria": criteria, "Completed": completed}
+}
+
+// nbtToProgress decodes the progress of a player encoded using progressToNBT from a map at key k.
+func nbtToProgress(m map[string]any, k string) progression.Progress {
+	data, _ := m[k].(map[string]any)
+	criteria, _ := data["Criteria"].(map[string]any)
+	completed, _ := data["Completed"].(map[string]any)
+
+	p := progression.Progress{}.Clone()
+	for id, v := range criteria {
+		c, _ := v.(map[string]any)
+		p.Criteria[id] = make(map[string]int, len(c))
+		for name := range c {
+			p.Criteria[id][name] = int(nbtconv.Int32(c, name))
+		}
+	}
+	for id := range completed {
+		p.Completed[id] = time.Unix(nbtconv.Int64(completed, id), 0)
+	}
+	return p
+}
+
+// statisticsToNBT encodes the statistics of a player to a map that may be encoded as NBT.
+func statisticsToNBT(s stats.Statistics) map[string]any {
+	return map[string]any{
+		"BlocksMined":       countsToNBT(s.BlocksMined),
+		"Kills":             countsToNBT(s.Kills),
+		"Deaths":            int32(s.Deaths),
+		"DistanceTravelled": s.DistanceTravelled,
+		"PlayTime":          int64(s.PlayTime),
+	}
+}
+
+// nbtToStatistics decodes the statistics of a player encoded using statisticsToNBT from a map at key k.
+func nbtToStatistics(m map[string]any, k string) stats.Statistics {
+	data, _ := m[k].(map[string]any)
+	return stats.Statistics{
+		BlocksMined:       nbtToCounts(data, "BlocksMined"),
+		Kills:             nbtToCounts(data, "Kills"),
+		Deaths:            int(nbtconv.Int32(data, "Deaths")),
+		DistanceTravelled: nbtconv.Float64(data, "DistanceTravelled"),
+		PlayTime:          time.Duration(nbtconv.Int64(data, "PlayTime")),
+	}
+}
+
+// countsToNBT encodes a map of counts by name to a map that may be encoded as NBT.
+func countsToNBT(counts map[string]int) map[string]any {
+	m := make(map[string]any, len(counts))
+	for name, n := range counts {
+		m[name] = int32(n)
+	}
+	return m
+}
+
+// nbtToCounts decodes a map of counts encoded using countsToNBT from a map at key k.
+func nbtToCounts(m map[string]any, k string) map[string]int {
+	data, _ := m[k].(map[string]any)
+	counts := make(map[string]int, len(data))
+	for name := range data {
+		counts[name] = int(nbtconv.Int32(data, name))
+	}
+	return counts
+}
+
+// effectsToNBT encodes a slice of effects to a slice of maps that may be encoded as NBT.
+func effectsToNBT(effects []effect.Effect) []any {
+	encoded := make([]any, 0, len(effects))
+	for _, e := range effects {
+		id, ok := effect.ID(e.Type())
+		if !ok {
+			continue
+		}
+		encoded = append(encoded, map[string]any{
+			"Id":        int32(id),
+			"Amplifier": int32(e.Level()),
+			"Duration":  int64(e.Duration()),
+			"Ambient":   boolByte(e.Ambient()),
+		})
+	}
+	return encoded
+}
+
+// nbtToEffects decodes a slice of maps produced by effectsToNBT back to effects.
+func nbtToEffects(encoded []any) []effect.Effect {
+	effects := make([]effect.Effect, 0, len(encoded))
+	for _, v := range encoded {
+		data, _ := v.(map[string]any)
+		e, ok := effect.ByID(int(nbtconv.Int32(data, "Id")))
+		if !ok {
+			continue
+		}
+		lvl, dur := int(nbtconv.Int32(data, "Amplifier")), time.Duration(nbtconv.Int64(data, "Duration"))
+		switch t := e.(type) {
+		case effect.LastingType:
+			if nbtconv.Bool(data, "Ambient") {
+				effects = append(effects, effect.NewAmbient(t, lvl, dur))
+				continue
+			}
+			effects = append(effects, effect.New(t, lvl, dur))
+		default:
+			effects = append(effects, effect.NewInstant(t, lvl))
+		}
+	}
+	return effects
+}
+
+// boolByte returns 1 if the bool passed is true, or 0 if it is false.
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
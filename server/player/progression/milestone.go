@@ -0,0 +1,117 @@
+package progression
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+)
+
+// Milestone is a goal defined by the server that players may complete, similar to the advancements found in
+// Java Edition. A Milestone is completed as soon as all of its criteria are met. Milestones must be registered
+// using Register before players can make progress towards them.
+type Milestone struct {
+	// ID uniquely identifies the Milestone. It is used to save the progress of players, so it should not be
+	// changed once players have started making progress towards the Milestone.
+	ID string
+	// Title is the name of the Milestone shown to players when it is completed.
+	Title string
+	// Description describes what must be done to complete the Milestone.
+	Description string
+	// Criteria holds the criteria that must all be met to complete the Milestone. A Milestone without
+	// criteria can only be completed by completing it directly.
+	Criteria []Criterion
+	// Notification specifies how the completion of the Milestone is shown. By default, a toast is shown to
+	// the player that completed it.
+	Notification Notification
+}
+
+// Criterion returns the Criterion of the Milestone with the name passed. If the Milestone has no such
+// criterion, false is returned.
+func (m Milestone) Criterion(name string) (Criterion, bool) {
+	for _, c := range m.Criteria {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Criterion{}, false
+}
+
+// Criterion is a condition that must be met to complete a Milestone.
+type Criterion struct {
+	// Name is the name of the Criterion. It must be unique within the Milestone that holds it.
+	Name string
+	// Goal is the amount of progress that must be made towards the Criterion for it to be met. If Goal is 0
+	// or lower, the Criterion is met as soon as any progress is made.
+	Goal int
+	// Check is an optional function that is called periodically for achievers that have not yet met the
+	// Criterion. The Criterion is met as soon as Check returns true. The Achiever passed is typically a
+	// *player.Player and may be type asserted to one.
+	Check func(a Achiever) bool
+}
+
+// Target returns the amount of progress needed to meet the Criterion, which is always at least 1.
+func (c Criterion) Target() int {
+	if c.Goal <= 0 {
+		return 1
+	}
+	return c.Goal
+}
+
+// Achiever is an entity that may make progress towards milestones, such as a player.
+type Achiever interface {
+	world.Entity
+	// Name returns the name of the Achiever.
+	Name() string
+	// UUID returns the UUID of the Achiever, by which its progress is saved.
+	UUID() uuid.UUID
+}
+
+// Notification specifies how the completion of a Milestone is shown.
+type Notification uint8
+
+const (
+	// NotifyToast shows a toast with the title and description of the Milestone to the player that completed
+	// it.
+	NotifyToast Notification = iota
+	// NotifyChat announces the completion of the Milestone to all players in the chat.
+	NotifyChat
+	// NotifyNone does not show the completion of the Milestone at all.
+	NotifyNone
+)
+
+var (
+	mu         sync.RWMutex
+	milestones = map[string]Milestone{}
+)
+
+// Register registers a Milestone so that players can make progress towards it. If a Milestone with the same
+// ID was already registered, it is overwritten.
+func Register(m Milestone) {
+	mu.Lock()
+	defer mu.Unlock()
+	milestones[m.ID] = m
+}
+
+// ByID looks up a registered Milestone by its ID. If no Milestone with the ID was registered, false is
+// returned.
+func ByID(id string) (Milestone, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	m, ok := milestones[id]
+	return m, ok
+}
+
+// Milestones returns all registered milestones, sorted by their IDs.
+func Milestones() []Milestone {
+	mu.RLock()
+	defer mu.RUnlock()
+	m := make([]Milestone, 0, len(milestones))
+	for _, milestone := range milestones {
+		m = append(m, milestone)
+	}
+	sort.Slice(m, func(i, j int) bool {
+		return m[i].ID < m[j].ID
+	})
+	return m
+}
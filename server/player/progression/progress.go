@@ -0,0 +1,53 @@
+package progression
+
+import (
+	"time"
+)
+
+// Progress holds the progress that a player has made towards milestones. It is saved together with the
+// other data of the player. The zero value of Progress is ready to use.
+type Progress struct {
+	// Criteria maps the IDs of milestones that have not yet been completed to the progress made towards each of
+	// their criteria, by name.
+	Criteria map[string]map[string]int
+	// Completed maps the IDs of completed milestones to the time at which they were completed.
+	Completed map[string]time.Time
+}
+
+// Clone returns a deep copy of the Progress.
+func (p Progress) Clone() Progress {
+	c := Progress{Criteria: make(map[string]map[string]int, len(p.Criteria)), Completed: make(map[string]time.Time, len(p.Completed))}
+	for id, criteria := range p.Criteria {
+		c.Criteria[id] = make(map[string]int, len(criteria))
+		for name, n := range criteria {
+			c.Criteria[id][name] = n
+		}
+	}
+	for id, t := range p.Completed {
+		c.Completed[id] = t
+	}
+	return c
+}
+
+// Done checks if the Milestone with the ID passed was completed. If so, the time at which it was completed
+// is returned.
+func (p Progress) Done(id string) (time.Time, bool) {
+	t, ok := p.Completed[id]
+	return t, ok
+}
+
+// Of returns the progress made towards the criterion with the name passed of the Milestone with the ID
+// passed.
+func (p Progress) Of(id, criterion string) int {
+	return p.Criteria[id][criterion]
+}
+
+// Met checks if all criteria of the Milestone passed are met according to the Progress.
+func (p Progress) Met(m Milestone) bool {
+	for _, c := range m.Criteria {
+		if p.Of(m.ID, c.Name) < c.Target() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,248 @@
+package scoreboard
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"golang.org/x/exp/maps"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Objective is a named list of scores that may be displayed in a DisplaySlot on the screen of players, such as
+// the sidebar or the player list. Unlike a Scoreboard, an Objective is updated incrementally: Changing a score
+// only sends that score to the players viewing the Objective. An Objective may be shown to many players at once,
+// or a separate Objective may be created for every player for personal displays, such as in lobbies.
+// Methods on Objective are safe for simultaneous use from multiple goroutines.
+type Objective struct {
+	name, displayName string
+	slot              DisplaySlot
+	order             SortOrder
+
+	mu      sync.Mutex
+	scores  map[scoreKey]Score
+	viewers map[Viewer]struct{}
+}
+
+// scoreKey identifies a Score in an Objective. Scores set using SetScore are identified by their name, while
+// scores set using SetEntityScore are identified by their entity.
+type scoreKey struct {
+	name string
+	e    world.Entity
+}
+
+// Score is a single entry in an Objective. Its Name is displayed along with its Value, and scores are ordered
+// by their Value according to the SortOrder of the Objective.
+type Score struct {
+	// ID is a unique ID of the Score among the scores of all objectives.
+	ID int64
+	// Name is the text displayed for the Score.
+	Name string
+	// Value is the value of the Score.
+	Value int
+	// Entity is the entity that the Score belongs to if it was set using SetEntityScore, or nil otherwise. Scores
+	// of entities are displayed with the name of the entity and are the only scores shown in the BelowName slot,
+	// where they are displayed under the name tag of the entity.
+	Entity world.Entity
+}
+
+// Viewer is a viewer of an Objective, such as a player. Viewers are notified of changes made to the Objective
+// that they view.
+type Viewer interface {
+	// ViewObjective displays the Objective passed along with all its scores.
+	ViewObjective(o *Objective)
+	// ViewScores updates the scores passed in the Objective passed, adding them if they were not yet shown.
+	ViewScores(o *Objective, scores []Score)
+	// RemoveScores removes the scores passed from the Objective passed.
+	RemoveScores(o *Objective, scores []Score)
+}
+
+// objectiveCount is used to generate a unique name for every Objective.
+var (
+	objectiveMu    sync.Mutex
+	objectiveCount int
+)
+
+// scoreID is used to generate a unique ID for every Score. IDs are unique across objectives, as the client
+// identifies scores by their ID regardless of the objective they are in.
+var scoreID atomic.Int64
+
+// NewObjective creates a new Objective that is displayed in the DisplaySlot passed, with its scores ordered
+// using the SortOrder passed. The display name is formatted according to the rules of fmt.Sprintln.
+func NewObjective(slot DisplaySlot, order SortOrder, displayName ...any) *Objective {
+	objectiveMu.Lock()
+	objectiveCount++
+	name := fmt.Sprintf("objective:%v", objectiveCount)
+	objectiveMu.Unlock()
+
+	return &Objective{
+		name:        name,
+		displayName: strings.TrimSuffix(fmt.Sprintln(displayName...), "\n"),
+		slot:        slot,
+		order:       order,
+		scores:      make(map[scoreKey]Score),
+		viewers:     make(map[Viewer]struct{}),
+	}
+}
+
+// Name returns a name that uniquely identifies the Objective.
+func (o *Objective) Name() string {
+	return o.name
+}
+
+// DisplayName returns the name displayed above the scores of the Objective.
+func (o *Objective) DisplayName() string {
+	return o.displayName
+}
+
+// Slot returns the DisplaySlot that the Objective is displayed in.
+func (o *Objective) Slot() DisplaySlot {
+	return o.slot
+}
+
+// Order returns the SortOrder that the scores of the Objective are ordered in.
+func (o *Objective) Order() SortOrder {
+	return o.order
+}
+
+// SetScore sets the score with the name passed to a value, adding it if it did not yet exist. The change is
+// sent to all viewers of the Objective.
+func (o *Objective) SetScore(name string, value int) {
+	o.update(scoreKey{name: name}, func(int) int { return value })
+}
+
+// AddScore adds delta to the score with the name passed, adding the score with a value of delta if it did not
+// yet exist. The new value of the score is returned.
+func (o *Objective) AddScore(name string, delta int) int {
+	return o.update(scoreKey{name: name}, func(v int) int { return v + delta })
+}
+
+// Score returns the value of the score with the name passed. If no such score exists, false is returned.
+func (o *Objective) Score(name string) (int, bool) {
+	return o.score(scoreKey{name: name})
+}
+
+// RemoveScore removes the score with the name passed from the Objective and all its viewers.
+func (o *Objective) RemoveScore(name string) {
+	o.remove(scoreKey{name: name})
+}
+
+// SetEntityScore sets the score of the entity passed to a value, adding it if it did not yet exist. Unlike
+// scores set using SetScore, the score is bound to the entity: It is displayed with the name of the entity and,
+// if the Objective is displayed in the BelowName slot, under the name tag of the entity. The change is sent to
+// all viewers of the Objective.
+func (o *Objective) SetEntityScore(e world.Entity, value int) {
+	o.update(scoreKey{e: e}, func(int) int { return value })
+}
+
+// AddEntityScore adds delta to the score of the entity passed, adding the score with a value of delta if it did
+// not yet exist. The new value of the score is returned.
+func (o *Objective) AddEntityScore(e world.Entity, delta int) int {
+	return o.update(scoreKey{e: e}, func(v int) int { return v + delta })
+}
+
+// EntityScore returns the value of the score of the entity passed. If the entity has no score, false is
+// returned.
+func (o *Objective) EntityScore(e world.Entity) (int, bool) {
+	return o.score(scoreKey{e: e})
+}
+
+// RemoveEntityScore removes the score of the entity passed from the Objective and all its viewers.
+func (o *Objective) RemoveEntityScore(e world.Entity) {
+	o.remove(scoreKey{e: e})
+}
+
+// update changes the value of the score with the key passed to the value returned by f, which is passed the
+// current value of the score. The score is added if it did not yet exist. The change is sent to all viewers of
+// the Objective and the new value is returned.
+func (o *Objective) update(k scoreKey, f func(v int) int) int {
+	o.mu.Lock()
+	s, ok := o.scores[k]
+	value := f(s.Value)
+	if ok && s.Value == value {
+		o.mu.Unlock()
+		return value
+	}
+	if !ok {
+		s = Score{ID: scoreID.Add(1), Name: k.name, Entity: k.e}
+	}
+	s.Value = value
+	o.scores[k] = s
+	viewers := o.viewerList()
+	o.mu.Unlock()
+
+	for _, v := range viewers {
+		v.ViewScores(o, []Score{s})
+	}
+	return value
+}
+
+// score returns the value of the score with the key passed. If no such score exists, false is returned.
+func (o *Objective) score(k scoreKey) (int, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	s, ok := o.scores[k]
+	return s.Value, ok
+}
+
+// remove removes the score with the key passed from the Objective and all its viewers.
+func (o *Objective) remove(k scoreKey) {
+	o.mu.Lock()
+	s, ok := o.scores[k]
+	delete(o.scores, k)
+	viewers := o.viewerList()
+	o.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	for _, v := range viewers {
+		v.RemoveScores(o, []Score{s})
+	}
+}
+
+// Scores returns all scores of the Objective, ordered by their values according to the SortOrder of the
+// Objective.
+func (o *Objective) Scores() []Score {
+	o.mu.Lock()
+	scores := maps.Values(o.scores)
+	o.mu.Unlock()
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Value == scores[j].Value {
+			return scores[i].Name < scores[j].Name
+		}
+		if o.order == Descending() {
+			return scores[i].Value > scores[j].Value
+		}
+		return scores[i].Value < scores[j].Value
+	})
+	return scores
+}
+
+// viewerList returns a list of all viewers of the Objective. o.mu must be locked when viewerList is called.
+func (o *Objective) viewerList() []Viewer {
+	viewers := make([]Viewer, 0, len(o.viewers))
+	for v := range o.viewers {
+		viewers = append(viewers, v)
+	}
+	return viewers
+}
+
+// AddViewer adds a Viewer to the Objective and displays the Objective to it. Future changes to the Objective
+// are sent to the Viewer until it is removed using RemoveViewer.
+func (o *Objective) AddViewer(v Viewer) {
+	o.mu.Lock()
+	o.viewers[v] = struct{}{}
+	o.mu.Unlock()
+	v.ViewObjective(o)
+}
+
+// RemoveViewer removes a Viewer from the Objective, so that it no longer receives changes made to it. It does
+// not remove the Objective from the screen of the Viewer.
+func (o *Objective) RemoveViewer(v Viewer) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.viewers, v)
+}
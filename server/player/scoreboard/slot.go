@@ -0,0 +1,46 @@
+package scoreboard
+
+// DisplaySlot is a slot on the screen of a player that an Objective may be displayed in.
+type DisplaySlot struct{ slot }
+
+// Sidebar is the display slot on the right side of the screen of a player.
+func Sidebar() DisplaySlot {
+	return DisplaySlot{"sidebar"}
+}
+
+// List is the display slot in the player list, shown next to the names of players.
+func List() DisplaySlot {
+	return DisplaySlot{"list"}
+}
+
+// BelowName is the display slot below the name tags of players.
+func BelowName() DisplaySlot {
+	return DisplaySlot{"belowname"}
+}
+
+type slot string
+
+// String returns the name of the display slot as used in the protocol.
+func (s slot) String() string {
+	return string(s)
+}
+
+// SortOrder is the order in which the scores of an Objective are displayed.
+type SortOrder struct{ order }
+
+// Ascending sorts the scores of an Objective from the lowest value to the highest value.
+func Ascending() SortOrder {
+	return SortOrder{0}
+}
+
+// Descending sorts the scores of an Objective from the highest value to the lowest value.
+func Descending() SortOrder {
+	return SortOrder{1}
+}
+
+type order uint8
+
+// Uint8 returns the sort order as a uint8, as used in the protocol.
+func (o order) Uint8() uint8 {
+	return uint8(o)
+}
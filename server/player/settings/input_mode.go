@@ -0,0 +1,46 @@
+package settings
+
+// InputMode is the input mode of a client, such as a mouse and keyboard or a touch screen.
+type InputMode struct{ inputMode }
+
+// Mouse is the input mode of a client using a mouse and keyboard.
+func Mouse() InputMode {
+	return InputMode{inputMode(1)}
+}
+
+// Touch is the input mode of a client using a touch screen.
+func Touch() InputMode {
+	return InputMode{inputMode(2)}
+}
+
+// GamePad is the input mode of a client using a controller.
+func GamePad() InputMode {
+	return InputMode{inputMode(3)}
+}
+
+// MotionController is the input mode of a client using motion controllers, such as a VR headset.
+func MotionController() InputMode {
+	return InputMode{inputMode(4)}
+}
+
+type inputMode uint8
+
+// Uint8 returns the input mode as a uint8.
+func (i inputMode) Uint8() uint8 {
+	return uint8(i)
+}
+
+// String ...
+func (i inputMode) String() string {
+	switch i {
+	case 1:
+		return "mouse"
+	case 2:
+		return "touch"
+	case 3:
+		return "gamepad"
+	case 4:
+		return "motion controller"
+	}
+	return "unknown"
+}
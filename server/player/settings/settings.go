@@ -0,0 +1,21 @@
+package settings
+
+import "golang.org/x/text/language"
+
+// Settings holds the settings of the client of a player. These settings are first sent by the client when it
+// joins the server, after which some of them, such as the render distance and the input mode, may change
+// while the client is connected.
+type Settings struct {
+	// RenderDistance is the render distance in chunks requested by the client. The chunk radius actually used
+	// for the player may be lower if the render distance exceeds the maximum chunk radius of the server.
+	RenderDistance int
+	// Language is the language that the client has selected.
+	Language language.Tag
+	// UIProfile is the UI profile that the client is using.
+	UIProfile UIProfile
+	// InputMode is the input mode that the client is currently using. It changes when, for example, a
+	// controller is connected to the device of the client.
+	InputMode InputMode
+	// GUIScale is the GUI scale offset set by the client. It ranges from -2 to 0, where 0 is the default scale.
+	GUIScale int
+}
@@ -0,0 +1,29 @@
+package settings
+
+// UIProfile is the UI profile of a client, which changes the layout of the screens shown to the client.
+type UIProfile struct{ uiProfile }
+
+// ClassicUI is the UI profile with the classic layout, typically used on desktop devices and consoles.
+func ClassicUI() UIProfile {
+	return UIProfile{uiProfile(0)}
+}
+
+// PocketUI is the UI profile with the pocket layout, typically used on mobile devices.
+func PocketUI() UIProfile {
+	return UIProfile{uiProfile(1)}
+}
+
+type uiProfile uint8
+
+// Uint8 returns the UI profile as a uint8.
+func (u uiProfile) Uint8() uint8 {
+	return uint8(u)
+}
+
+// String ...
+func (u uiProfile) String() string {
+	if u == 1 {
+		return "pocket"
+	}
+	return "classic"
+}
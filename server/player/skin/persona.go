@@ -0,0 +1,26 @@
+package skin
+
+// PersonaPiece is a piece of a persona skin, which is a skin created using the in-game character creator.
+// Persona skins are made up of multiple pieces, such as the hair, eyes and clothing of the character.
+type PersonaPiece struct {
+	// ID is a UUID that identifies the piece itself.
+	ID string
+	// Type is the type of the piece, such as 'persona_hair', 'persona_eyes' or 'persona_top'.
+	Type string
+	// PackID is a UUID that identifies the pack that the piece belongs to.
+	PackID string
+	// Default specifies if the piece is one of the default pieces that a Steve or Alex skin have.
+	Default bool
+	// ProductID is a UUID that identifies the piece when it comes to purchases. It is empty for default
+	// pieces.
+	ProductID string
+}
+
+// PieceTint holds the tint colours of a PersonaPiece of a specific type.
+type PieceTint struct {
+	// PieceType is the type of the PersonaPiece that the tint colours apply to, such as 'persona_eyes'.
+	PieceType string
+	// Colours holds up to four ARGB colours in hex notation (including #) that apply to different parts of
+	// the piece. For 'persona_eyes', these are the colours of the iris, eyebrows and sclera respectively.
+	Colours []string
+}
@@ -32,6 +32,16 @@ type Skin struct {
 	// Animations holds a list of all animations that the skin has. These animations must be pointed to in the
 	// ModelConfig, in order to display them on the skin.
 	Animations []Animation
+
+	// ArmSize is the size of the arms of a persona skin. This is either 'wide' or 'slim'.
+	ArmSize string
+	// Colour is a hex representation (including #) of the base colour of a persona skin, such as '#b37b62'.
+	Colour string
+	// PersonaPieces holds the pieces that a persona skin is composed of. It is empty for skins that are not
+	// persona skins.
+	PersonaPieces []PersonaPiece
+	// PieceTints holds the tint colours of (some of) the pieces in PersonaPieces.
+	PieceTints []PieceTint
 }
 
 // New creates a new skin using the width and height passed. The dimensions passed must be either 64x32,
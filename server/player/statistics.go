@@ -0,0 +1,27 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/player/stats"
+)
+
+// Statistics returns a copy of the statistics tracked for the player, such as the blocks it mined and the
+// distance it travelled. stats.Rank may be used to produce leaderboards from the statistics of players.
+func (p *Player) Statistics() stats.Statistics {
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	return p.stats.Clone()
+}
+
+// ResetStatistics resets all statistics tracked for the player.
+func (p *Player) ResetStatistics() {
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	p.stats = stats.Statistics{}.Clone()
+}
+
+// updateStatistics calls the function passed with the statistics of the player so that they may be updated.
+func (p *Player) updateStatistics(f func(s *stats.Statistics)) {
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	f(&p.stats)
+}
@@ -0,0 +1,82 @@
+package stats
+
+import (
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// Statistics holds statistics of a player that are tracked while it plays, such as the blocks it mined and
+// the distance it travelled. The zero value of Statistics is ready to use.
+type Statistics struct {
+	// BlocksMined maps the names of blocks, such as 'minecraft:stone', to the amount of times the player
+	// mined a block of that type.
+	BlocksMined map[string]int
+	// Kills maps the names of entity types, such as 'minecraft:zombie', to the amount of times the player
+	// killed an entity of that type. Players killed are counted under 'minecraft:player'.
+	Kills map[string]int
+	// Deaths is the amount of times the player died.
+	Deaths int
+	// DistanceTravelled is the distance in blocks that the player travelled by moving.
+	DistanceTravelled float64
+	// PlayTime is the total time that the player has spent playing.
+	PlayTime time.Duration
+}
+
+// Clone returns a deep copy of the Statistics.
+func (s Statistics) Clone() Statistics {
+	c := s
+	c.BlocksMined, c.Kills = make(map[string]int, len(s.BlocksMined)), make(map[string]int, len(s.Kills))
+	for name, n := range s.BlocksMined {
+		c.BlocksMined[name] = n
+	}
+	for name, n := range s.Kills {
+		c.Kills[name] = n
+	}
+	return c
+}
+
+// TotalBlocksMined returns the total amount of blocks mined, regardless of their type.
+func (s Statistics) TotalBlocksMined() int {
+	return sum(s.BlocksMined)
+}
+
+// TotalKills returns the total amount of entities killed, regardless of their type.
+func (s Statistics) TotalKills() int {
+	return sum(s.Kills)
+}
+
+// sum returns the sum of all values in the map passed.
+func sum(m map[string]int) (n int) {
+	for _, v := range m {
+		n += v
+	}
+	return n
+}
+
+// Entry is an entry of a leaderboard produced using Rank.
+type Entry struct {
+	// UUID is the UUID of the player that the Entry belongs to.
+	UUID uuid.UUID
+	// Value is the value of the statistic of the player that the leaderboard was ranked by.
+	Value float64
+}
+
+// Rank produces a leaderboard from the statistics of the players passed, ranking them by the value returned
+// by the function passed for each of them. The entries returned are sorted from the highest to the lowest
+// value. Players with the same value are sorted by their UUIDs, so that the order is stable.
+// Rank may be used for statistics obtained from online players through player.Player.Statistics, as well as
+// statistics of offline players loaded from a player.Provider.
+func Rank(s map[uuid.UUID]Statistics, value func(s Statistics) float64) []Entry {
+	entries := make([]Entry, 0, len(s))
+	for id, stats := range s {
+		entries = append(entries, Entry{UUID: id, Value: value(stats)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Value == entries[j].Value {
+			return entries[i].UUID.String() < entries[j].UUID.String()
+		}
+		return entries[i].Value > entries[j].Value
+	})
+	return entries
+}
@@ -0,0 +1,61 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/player/title"
+	"sync"
+	"time"
+)
+
+// hud manages the titles and action bar messages shown to a player. It holds a queue of titles that are shown
+// after each other and an optional function that refreshes the action bar of the player periodically.
+type hud struct {
+	mu sync.Mutex
+	// titles holds the titles queued using Player.QueueTitle that have not yet been shown.
+	titles []title.Title
+	// titleEnd is the time at which the title currently shown disappears from the screen.
+	titleEnd time.Time
+
+	// actionBar is called every actionBarInterval ticks to obtain the action bar message shown to the player.
+	actionBar         func() string
+	actionBarInterval int64
+}
+
+// showTitle sends a title.Title to the player passed, sending the durations, subtitle and title in the order
+// that the client expects them in.
+func (h *hud) showTitle(p *Player, t title.Title) {
+	h.titleEnd = time.Now().Add(t.FadeInDuration() + t.Duration() + t.FadeOutDuration())
+
+	s := p.session()
+	s.SetTitleDurations(t.FadeInDuration(), t.Duration(), t.FadeOutDuration())
+	if t.Text() != "" || t.Subtitle() != "" {
+		// The client only shows a subtitle if it is sent before the title it belongs to.
+		if t.Subtitle() != "" {
+			s.SendSubtitle(t.Subtitle())
+		}
+		s.SendTitle(t.Text())
+	}
+	if t.ActionText() != "" {
+		s.SendActionBarMessage(t.ActionText())
+	}
+}
+
+// tick shows the next queued title if the previous one has disappeared and refreshes the action bar of the
+// player if an action bar function was set.
+func (h *hud) tick(p *Player, current int64) {
+	h.mu.Lock()
+	if len(h.titles) != 0 && time.Now().After(h.titleEnd) {
+		t := h.titles[0]
+		h.titles = h.titles[1:]
+		h.showTitle(p, t)
+	}
+	actionBar, interval := h.actionBar, h.actionBarInterval
+	h.mu.Unlock()
+
+	// The action bar function is called without holding the mutex, so that it may safely call methods of the
+	// player that use it.
+	if actionBar != nil && current%interval == 0 {
+		if msg := actionBar(); msg != "" {
+			p.session().SendActionBarMessage(msg)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"net"
+)
+
+// Carrier carries the state of players over to another server when they are transferred to it using
+// Player.Transfer. It allows servers of a network that do not use a proxy to move players between them without
+// losing their inventory, health and other data, typically by storing the data in a database shared by all
+// servers.
+type Carrier interface {
+	// Carry is called right before the player with the UUID passed is transferred to the server at the address
+	// passed. It should store the Data passed so that the other server may Receive it. If an error is returned,
+	// the player is not transferred.
+	Carry(id uuid.UUID, addr *net.UDPAddr, data Data) error
+	// Receive is called when a player with the UUID passed joins the server. It returns the Data carried over by
+	// the server that the player was transferred from, if any, and should remove it so that it is only used once.
+	// If no data was carried over, false is returned, and the data of the player is loaded from the Provider of
+	// the server instead.
+	Receive(id uuid.UUID, world func(world.Dimension) *world.World) (Data, bool, error)
+}
+
+// Serializer encodes and decodes the Data of a player to and from bytes. It may be used by a Carrier to store
+// the Data of players in a format of choice.
+type Serializer interface {
+	// Marshal encodes the Data passed.
+	Marshal(data Data) ([]byte, error)
+	// Unmarshal decodes Data from the bytes passed. The world function passed is used to look up the world.World
+	// the player was in.
+	Unmarshal(b []byte, world func(world.Dimension) *world.World) (Data, error)
+}
+
+// SetCarrier sets the Carrier used to carry the state of the player over to other servers when it is
+// transferred using Transfer. Passing nil stops the state of the player from being carried over.
+func (p *Player) SetCarrier(c Carrier) {
+	p.carrier.Store(c)
+}
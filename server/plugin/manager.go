@@ -0,0 +1,194 @@
+package plugin
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/logging"
+	"github.com/df-mc/dragonfly/server/scheduler"
+	"os"
+	"path/filepath"
+	goplugin "plugin"
+	"strings"
+	"sync"
+)
+
+// Manager loads, enables and disables the plugins of a server.Server.
+type Manager struct {
+	srv   *server.Server
+	log   server.Logger
+	sched *scheduler.Scheduler
+
+	mu      sync.Mutex
+	plugins []loaded
+}
+
+// loaded is a Plugin enabled by a Manager, together with the Context it was enabled with.
+type loaded struct {
+	p   Plugin
+	ctx *Context
+}
+
+// NewManager creates a Manager that enables plugins on the server.Server passed. Plugins are passed the
+// server.Logger passed to log to. If it is a *logging.Logger, plugins log as part of the plugin subsystem
+// with a field holding their name.
+func NewManager(srv *server.Server, log server.Logger) *Manager {
+	return &Manager{srv: srv, log: log, sched: scheduler.New(srv.World())}
+}
+
+// Load enables the Plugin passed. An error is returned if a Plugin with the same name is already loaded or
+// if enabling the Plugin failed.
+func (m *Manager) Load(p Plugin) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, l := range m.plugins {
+		if l.p.Name() == p.Name() {
+			return fmt.Errorf("plugin %v: already loaded", p.Name())
+		}
+	}
+	log := m.log
+	if l, ok := log.(*logging.Logger); ok {
+		log = l.Subsystem(logging.SubsystemPlugin).WithField("plugin", p.Name())
+	}
+	ctx := &Context{srv: m.srv, log: log, sched: m.sched.Group()}
+	if err := p.Enable(ctx); err != nil {
+		ctx.close()
+		return fmt.Errorf("plugin %v: enable: %w", p.Name(), err)
+	}
+	m.plugins = append(m.plugins, loaded{p: p, ctx: ctx})
+	m.log.Infof("Enabled plugin %v.", p.Name())
+	return nil
+}
+
+// LoadRegistered loads all plugins registered using Register. Plugins that fail to load do not stop others
+// from loading. The errors of all plugins that failed to load are returned.
+func (m *Manager) LoadRegistered() error {
+	registeredMu.Lock()
+	plugins := append([]Plugin(nil), registered...)
+	registeredMu.Unlock()
+
+	var errs []error
+	for _, p := range plugins {
+		if err := m.Load(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// LoadDir loads all Go plugins (files with the .so extension) in the directory passed. Each Go plugin must
+// export a variable named Plugin holding a value implementing the Plugin interface. Go plugins are only
+// supported on Linux, FreeBSD and macOS, and must be built with the same Go version and versions of
+// dependencies as the server.
+func (m *Manager) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("read plugin directory: %w", err)
+	}
+	var errs []error
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".so") {
+			continue
+		}
+		if err := m.loadFile(filepath.Join(dir, e.Name())); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// loadFile loads a Go plugin from the file at the path passed.
+func (m *Manager) loadFile(path string) error {
+	f, err := goplugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("open %v: %w", path, err)
+	}
+	sym, err := f.Lookup("Plugin")
+	if err != nil {
+		return fmt.Errorf("open %v: %w", path, err)
+	}
+	// Lookup returns a pointer to exported variables, so we first check for a pointer to a Plugin.
+	switch p := sym.(type) {
+	case *Plugin:
+		return m.Load(*p)
+	case Plugin:
+		return m.Load(p)
+	}
+	return fmt.Errorf("open %v: exported Plugin symbol of type %T does not implement plugin.Plugin", path, sym)
+}
+
+// Disable disables the Plugin with the name passed. False is returned if no such Plugin was loaded.
+func (m *Manager) Disable(name string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, l := range m.plugins {
+		if l.p.Name() == name {
+			m.plugins = append(m.plugins[:i], m.plugins[i+1:]...)
+			return true, m.disable(l)
+		}
+	}
+	return false, nil
+}
+
+// Plugins returns all plugins currently loaded, in the order they were loaded.
+func (m *Manager) Plugins() []Plugin {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	plugins := make([]Plugin, len(m.plugins))
+	for i, l := range m.plugins {
+		plugins[i] = l.p
+	}
+	return plugins
+}
+
+// Close disables all plugins loaded, in the reverse order they were loaded. It should be called once the
+// server.Server is closed.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var errs []error
+	for i := len(m.plugins) - 1; i >= 0; i-- {
+		if err := m.disable(m.plugins[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	m.plugins = nil
+	_ = m.sched.Close()
+	return joinErrors(errs)
+}
+
+// disable disables a loaded Plugin and removes everything it registered through its Context.
+func (m *Manager) disable(l loaded) error {
+	err := l.p.Disable()
+	l.ctx.close()
+	if err != nil {
+		return fmt.Errorf("plugin %v: disable: %w", l.p.Name(), err)
+	}
+	m.log.Infof("Disabled plugin %v.", l.p.Name())
+	return nil
+}
+
+// multiError is an error made up of multiple errors.
+type multiError []error
+
+// Error ...
+func (e multiError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// joinErrors returns an error made up of the errors passed, or nil if none were passed.
+func joinErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return multiError(errs)
+}
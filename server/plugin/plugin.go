@@ -0,0 +1,120 @@
+// Package plugin implements loading of plugins: gameplay code that lives outside the core of the server and
+// is enabled and disabled as a unit. Plugins are either registered at compile time using Register, or loaded
+// at runtime from Go plugins built with `go build -buildmode=plugin`.
+package plugin
+
+import (
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/scheduler"
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+)
+
+// Plugin is a unit of gameplay code that may be enabled on a server.Server and disabled again.
+type Plugin interface {
+	// Name returns the name of the Plugin. It must be unique among all plugins loaded by a Manager.
+	Name() string
+	// Enable enables the Plugin. The Context passed provides access to the server and should be used to
+	// register commands and event handlers, so that they are removed when the Plugin is disabled. If an
+	// error is returned, the Plugin is disabled straight away.
+	Enable(ctx *Context) error
+	// Disable disables the Plugin. It is called when the Plugin is unloaded or when the server is closed, and
+	// should release any resources held by the Plugin.
+	Disable() error
+}
+
+var (
+	registeredMu sync.Mutex
+	registered   []Plugin
+)
+
+// Register registers a Plugin at compile time, generally from an init function of the package implementing
+// it. Registered plugins are loaded by Manager.LoadRegistered.
+func Register(p Plugin) {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	registered = append(registered, p)
+}
+
+// Context is passed to a Plugin when it is enabled. It provides access to the server, its worlds, commands,
+// events and a scheduler. Commands, event handlers and tasks registered through the Context are removed
+// automatically when the Plugin is disabled.
+type Context struct {
+	srv   *server.Server
+	log   server.Logger
+	sched *scheduler.Scheduler
+
+	mu       sync.Mutex
+	disabled bool
+	commands []cmd.Command
+	unsubs   []func()
+}
+
+// Server returns the server.Server that the Plugin was enabled on.
+func (ctx *Context) Server() *server.Server {
+	return ctx.srv
+}
+
+// Log returns the server.Logger that the Plugin should log to.
+func (ctx *Context) Log() server.Logger {
+	return ctx.log
+}
+
+// Scheduler returns the scheduler.Scheduler that the Plugin should use to schedule tasks. Its tasks run in
+// sync with the ticking of the overworld and are cancelled when the Plugin is disabled.
+func (ctx *Context) Scheduler() *scheduler.Scheduler {
+	return ctx.sched
+}
+
+// Worlds returns the overworld, nether and end of the server.
+func (ctx *Context) Worlds() (overworld, nether, end *world.World) {
+	return ctx.srv.World(), ctx.srv.Nether(), ctx.srv.End()
+}
+
+// RegisterCommand registers a cmd.Command that is unregistered when the Plugin is disabled.
+func (ctx *Context) RegisterCommand(c cmd.Command) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.disabled {
+		return
+	}
+	cmd.Register(c)
+	ctx.commands = append(ctx.commands, c)
+}
+
+// HandleJoin subscribes a function to players joining the server, with the event.Priority passed. It may be
+// used to add a player.Handler to players. The function is unsubscribed when the Plugin is disabled.
+func (ctx *Context) HandleJoin(p event.Priority, f func(ctx *event.Context, p *player.Player)) {
+	ctx.OnDisable(ctx.srv.Joins().Subscribe(p, f))
+}
+
+// OnDisable adds a function that is called when the Plugin is disabled, after its Disable method. It may be
+// used to undo anything the Plugin registered elsewhere, such as subscriptions to an event.Bus.
+func (ctx *Context) OnDisable(f func()) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.disabled {
+		f()
+		return
+	}
+	ctx.unsubs = append(ctx.unsubs, f)
+}
+
+// close unregisters all commands and calls all functions registered by the Plugin using the Context.
+func (ctx *Context) close() {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	ctx.disabled = true
+	_ = ctx.sched.Close()
+	for _, c := range ctx.commands {
+		cmd.Unregister(c)
+	}
+	for i := len(ctx.unsubs) - 1; i >= 0; i-- {
+		ctx.unsubs[i]()
+	}
+	ctx.commands, ctx.unsubs = nil, nil
+}
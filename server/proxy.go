@@ -0,0 +1,88 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/df-mc/dragonfly/server/session"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Forwarding configures a Server to run behind a proxy, such as a proxy that moves players between multiple
+// servers. The zero value disables all forwarding.
+type Forwarding struct {
+	// ProxyProtocol specifies if the default listener should accept version 2 PROXY protocol headers, so that
+	// the real addresses of clients connecting through a proxy are known.
+	ProxyProtocol bool
+	// TrustedProxies holds the IP addresses of proxies from which PROXY protocol headers are accepted. Headers
+	// are never accepted if TrustedProxies is empty, as anyone would otherwise be able to forge their address.
+	TrustedProxies []string
+	// Secret is a secret shared with the proxy. If not empty, Xbox Live authentication is disabled and the
+	// identity forwarded by the proxy is accepted instead, granted the proxy proves that it knows the secret.
+	// To do so, the proxy must set the PlatformOnlineID of the client data of a player to the token returned
+	// by ForwardingToken. Connections without a valid token, or with a token that has expired, are
+	// disconnected.
+	Secret string
+}
+
+// maxForwardingLifetime is the maximum duration that a token returned by ForwardingToken may remain valid for.
+// Tokens expiring further in the future are refused, so that a token that leaks cannot be used for long.
+const maxForwardingLifetime = time.Minute * 5
+
+// trusted checks if the net.Addr passed is the address of a trusted proxy.
+func (f Forwarding) trusted(addr net.Addr) bool {
+	udp, ok := addr.(*net.UDPAddr)
+	if !ok {
+		return false
+	}
+	for _, ip := range f.TrustedProxies {
+		if udp.IP.Equal(net.ParseIP(ip)) {
+			return true
+		}
+	}
+	return false
+}
+
+// verify checks if the identity of the session.Conn passed was forwarded by a proxy that knows the Secret of
+// the Forwarding. verify always returns true if no Secret is set.
+func (f Forwarding) verify(c session.Conn) bool {
+	if f.Secret == "" {
+		return true
+	}
+	expiry, sig, ok := strings.Cut(c.ClientData().PlatformOnlineID, ":")
+	if !ok {
+		return false
+	}
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return false
+	}
+	expires := time.Unix(unix, 0)
+	if now := time.Now(); now.After(expires) || expires.Sub(now) > maxForwardingLifetime {
+		return false
+	}
+	b, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	id := c.IdentityData()
+	return hmac.Equal(b, ForwardingSignature(f.Secret, id.XUID, id.Identity, id.DisplayName, expires))
+}
+
+// ForwardingToken returns the token that a proxy must set as the PlatformOnlineID of the client data of a
+// player with the XUID, UUID and display name passed when a Forwarding.Secret is set. The token is valid until
+// the expiry time passed, which must be at most five minutes in the future.
+func ForwardingToken(secret, xuid, uuid, displayName string, expires time.Time) string {
+	return strconv.FormatInt(expires.Unix(), 10) + ":" + hex.EncodeToString(ForwardingSignature(secret, xuid, uuid, displayName, expires))
+}
+
+// ForwardingSignature computes the HMAC-SHA256 signature, using the secret passed as key, of the XUID, UUID,
+// display name and expiry time passed. It is the signature held by a token returned by ForwardingToken.
+func ForwardingSignature(secret, xuid, uuid, displayName string, expires time.Time) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(xuid + ":" + strings.ToLower(uuid) + ":" + displayName + ":" + strconv.FormatInt(expires.Unix(), 10)))
+	return h.Sum(nil)
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// queryData holds the data of a Server sent in response to queries.
+type queryData struct {
+	status    minecraft.ServerStatus
+	world     string
+	players   []string
+	whitelist bool
+	// port is the port the Server listens on. If 0, the port of the queryConn is used.
+	port int
+}
+
+const (
+	// queryMagic is the prefix of every query packet sent by a client.
+	queryMagic = 0xfefd
+	// queryTypeHandshake and queryTypeStat are the types of query packets.
+	queryTypeHandshake, queryTypeStat = 0x09, 0x00
+	// queryTokenLifetime is the duration after which the challenge token of a queryConn is changed.
+	queryTokenLifetime = time.Second * 30
+)
+
+// queryConn wraps around a net.PacketConn to answer queries following the GameSpy 4 (UT3) query protocol
+// received on it. Other datagrams are passed on unchanged.
+type queryConn struct {
+	net.PacketConn
+	data func() queryData
+
+	mu                sync.Mutex
+	token, prevToken  int32
+	tokenRotationTime time.Time
+}
+
+// newQueryConn wraps the net.PacketConn passed so that it answers queries using the data returned by the
+// function passed.
+func newQueryConn(conn net.PacketConn, data func() queryData) *queryConn {
+	return &queryConn{PacketConn: conn, data: data}
+}
+
+// ReadFrom reads the next datagram that is not a query into b, answering any queries read before it.
+func (c *queryConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	for {
+		n, addr, err := c.PacketConn.ReadFrom(b)
+		if err != nil || n < 7 || binary.BigEndian.Uint16(b) != queryMagic {
+			return n, addr, err
+		}
+		if resp := c.handle(b[2:n]); resp != nil {
+			_, _ = c.PacketConn.WriteTo(resp, addr)
+		}
+	}
+}
+
+// handle handles the query packet b, excluding its magic, and returns the response to it. Nil is returned if
+// the packet is invalid or has an invalid challenge token.
+func (c *queryConn) handle(b []byte) []byte {
+	typ, session := b[0], b[1:5]
+	resp := bytes.NewBuffer([]byte{typ})
+	resp.Write(session)
+
+	switch typ {
+	case queryTypeHandshake:
+		resp.WriteString(strconv.Itoa(int(c.currentToken())))
+		resp.WriteByte(0)
+	case queryTypeStat:
+		if len(b) < 9 || !c.validToken(int32(binary.BigEndian.Uint32(b[5:9]))) {
+			return nil
+		}
+		d := c.data()
+		if addr, ok := c.LocalAddr().(*net.UDPAddr); ok && d.port == 0 {
+			d.port = addr.Port
+		}
+		if len(b) >= 13 {
+			writeFullStat(resp, d)
+		} else {
+			writeBasicStat(resp, d)
+		}
+	default:
+		return nil
+	}
+	return resp.Bytes()
+}
+
+// writeBasicStat writes the response to a basic stat query to the buffer passed.
+func writeBasicStat(buf *bytes.Buffer, d queryData) {
+	for _, s := range []string{d.status.ServerName, "SMP", d.world, strconv.Itoa(d.status.PlayerCount), strconv.Itoa(d.status.MaxPlayers)} {
+		buf.WriteString(s)
+		buf.WriteByte(0)
+	}
+	_ = binary.Write(buf, binary.LittleEndian, uint16(d.port))
+	buf.WriteString("0.0.0.0")
+	buf.WriteByte(0)
+}
+
+// writeFullStat writes the response to a full stat query to the buffer passed.
+func writeFullStat(buf *bytes.Buffer, d queryData) {
+	whitelist := "off"
+	if d.whitelist {
+		whitelist = "on"
+	}
+	buf.WriteString("splitnum\x00\x80\x00")
+	for _, kv := range [][2]string{
+		{"hostname", d.status.ServerName},
+		{"gametype", "SMP"},
+		{"game_id", "MINECRAFTPE"},
+		{"version", protocol.CurrentVersion},
+		{"server_engine", "Dragonfly"},
+		{"plugins", ""},
+		{"map", d.world},
+		{"numplayers", strconv.Itoa(d.status.PlayerCount)},
+		{"maxplayers", strconv.Itoa(d.status.MaxPlayers)},
+		{"whitelist", whitelist},
+		{"hostip", "0.0.0.0"},
+		{"hostport", strconv.Itoa(d.port)},
+	} {
+		buf.WriteString(kv[0])
+		buf.WriteByte(0)
+		buf.WriteString(kv[1])
+		buf.WriteByte(0)
+	}
+	buf.WriteString("\x00\x01player_\x00\x00")
+	for _, name := range d.players {
+		buf.WriteString(name)
+		buf.WriteByte(0)
+	}
+	buf.WriteByte(0)
+}
+
+// currentToken returns the current challenge token, generating a new one if the previous one expired.
+func (c *queryConn) currentToken() int32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.tokenRotationTime) > queryTokenLifetime {
+		c.prevToken, c.token = c.token, rand.Int31()
+		c.tokenRotationTime = time.Now()
+	}
+	return c.token
+}
+
+// validToken checks if the challenge token passed is the current or previous challenge token.
+func (c *queryConn) validToken(token int32) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return token != 0 && (token == c.token || token == c.prevToken)
+}
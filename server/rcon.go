@@ -0,0 +1,295 @@
+package server
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RCON configures the RCON listener of a Server, which allows external tools, such as server panels, to execute
+// commands on the Server using the Source RCON protocol. Commands are executed by a ConsoleSender.
+type RCON struct {
+	// Address is the TCP address on which RCON connections are accepted, such as ':25575'. If empty, RCON is
+	// disabled.
+	Address string
+	// Password is the password that RCON clients must authenticate with. RCON is not enabled if no Password is
+	// set.
+	Password string
+	// Commands holds the names of the commands that may be executed over RCON. If empty, any command may be
+	// executed.
+	Commands []string
+	// RateLimit is the maximum amount of commands that RCON clients with the same IP address may execute per
+	// second. If 0, the amount of commands is not limited.
+	RateLimit int
+}
+
+const (
+	// rconTypeResponse, rconTypeCommand and rconTypeAuth are the types of RCON packets. The auth response packet
+	// shares its type with the command packet.
+	rconTypeResponse, rconTypeCommand, rconTypeAuth = 0, 2, 3
+	// rconMaxPacketSize is the maximum size of an RCON packet sent by a client.
+	rconMaxPacketSize = 4096 + 10
+	// rconMaxResponseSize is the maximum size of the body of a single RCON response packet. Longer responses
+	// are split over multiple packets.
+	rconMaxResponseSize = 4096
+	// rconTimeout is the duration after which an idle RCON connection is closed.
+	rconTimeout = time.Minute * 5
+	// rconMaxFailures is the amount of times an IP address may fail to authenticate before it is locked out.
+	rconMaxFailures = 3
+	// rconLockout is the duration that an IP address is locked out for after failing to authenticate
+	// rconMaxFailures times. The duration doubles with every following failure, up to rconMaxLockout.
+	rconLockout, rconMaxLockout = time.Minute, time.Hour
+)
+
+// rconClient holds the state of all RCON connections from a single IP address.
+type rconClient struct {
+	mu          sync.Mutex
+	failures    int
+	lockedUntil time.Time
+	limit       rateLimiter
+	// last is the time at which the last connection from the IP address was made or closed.
+	last time.Time
+}
+
+// rconPacket is a packet of the Source RCON protocol.
+type rconPacket struct {
+	id, typ int32
+	body    string
+}
+
+// serveRCON accepts RCON connections on the address of the RCON config of the Server until the Server is closed.
+func (srv *Server) serveRCON() {
+	conf := srv.conf.RCON
+	if conf.Password == "" {
+		srv.conf.Log.Errorf("rcon: no password set, not listening on %v", conf.Address)
+		return
+	}
+	l, err := net.Listen("tcp", conf.Address)
+	if err != nil {
+		srv.conf.Log.Errorf("rcon: %v", err)
+		return
+	}
+	go func() {
+		<-srv.closing
+		_ = l.Close()
+	}()
+	srv.conf.Log.Infof("Serving RCON on %v.", l.Addr())
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go srv.handleRCON(c)
+	}
+}
+
+// rconClient returns the rconClient of the IP address of the net.Addr passed, creating it if it does not yet
+// exist. Clients that have not connected for longer than rconMaxLockout are removed.
+func (srv *Server) rconClient(addr net.Addr) *rconClient {
+	ip := addr.String()
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		ip = tcp.IP.String()
+	}
+	srv.rconMu.Lock()
+	defer srv.rconMu.Unlock()
+	if srv.rconClients == nil {
+		srv.rconClients = map[string]*rconClient{}
+	}
+	now := time.Now()
+	for k, client := range srv.rconClients {
+		client.mu.Lock()
+		idle := now.Sub(client.last) > rconMaxLockout && now.After(client.lockedUntil)
+		client.mu.Unlock()
+		if idle && k != ip {
+			delete(srv.rconClients, k)
+		}
+	}
+	client, ok := srv.rconClients[ip]
+	if !ok {
+		client = &rconClient{limit: rateLimiter{limit: srv.conf.RCON.RateLimit}}
+		srv.rconClients[ip] = client
+	}
+	client.mu.Lock()
+	client.last = now
+	client.mu.Unlock()
+	return client
+}
+
+// locked checks if the rconClient is currently locked out after failing to authenticate.
+func (client *rconClient) locked() bool {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return time.Now().Before(client.lockedUntil)
+}
+
+// authenticated records the result of an authentication attempt of the rconClient, locking it out if it failed
+// too many times.
+func (client *rconClient) authenticated(ok bool) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if ok {
+		client.failures = 0
+		return
+	}
+	if client.failures++; client.failures >= rconMaxFailures {
+		lockout := rconLockout << (client.failures - rconMaxFailures)
+		if lockout > rconMaxLockout || lockout <= 0 {
+			lockout = rconMaxLockout
+		}
+		client.lockedUntil = time.Now().Add(lockout)
+	}
+}
+
+// allow checks if the rconClient may execute another command without exceeding the rate limit.
+func (client *rconClient) allow() bool {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.limit.allow()
+}
+
+// handleRCON handles the RCON connection passed until it is closed or fails to authenticate.
+func (srv *Server) handleRCON(c net.Conn) {
+	defer c.Close()
+	var (
+		conf          = srv.conf.RCON
+		authenticated bool
+		client        = srv.rconClient(c.RemoteAddr())
+	)
+	if client.locked() {
+		srv.conf.Log.Debugf("rcon: %v: locked out after failing to authenticate", c.RemoteAddr())
+		return
+	}
+	for {
+		_ = c.SetReadDeadline(time.Now().Add(rconTimeout))
+		pk, err := readRCONPacket(c)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				srv.conf.Log.Debugf("rcon: %v: %v", c.RemoteAddr(), err)
+			}
+			return
+		}
+		switch pk.typ {
+		case rconTypeAuth:
+			if client.locked() {
+				return
+			}
+			if subtle.ConstantTimeCompare([]byte(pk.body), []byte(conf.Password)) != 1 {
+				srv.conf.Log.Infof("RCON connection %v failed to authenticate.", c.RemoteAddr())
+				client.authenticated(false)
+				_ = writeRCONPacket(c, rconPacket{id: -1, typ: rconTypeCommand})
+				return
+			}
+			client.authenticated(true)
+			authenticated = true
+			if err := writeRCONPacket(c, rconPacket{id: pk.id, typ: rconTypeCommand}); err != nil {
+				return
+			}
+		case rconTypeCommand:
+			if !authenticated {
+				return
+			}
+			var resp string
+			if client.allow() {
+				srv.conf.Log.Infof("RCON connection %v executed command: %v", c.RemoteAddr(), pk.body)
+				resp = srv.executeRCON(pk.body)
+			} else {
+				resp = "Too many commands, please slow down.\n"
+			}
+			for first := true; first || resp != ""; first = false {
+				n := len(resp)
+				if n > rconMaxResponseSize {
+					n = rconMaxResponseSize
+				}
+				if err := writeRCONPacket(c, rconPacket{id: pk.id, typ: rconTypeResponse, body: resp[:n]}); err != nil {
+					return
+				}
+				resp = resp[n:]
+			}
+		}
+	}
+}
+
+// executeRCON executes the command line passed on behalf of an RCON connection and returns its output.
+func (srv *Server) executeRCON(line string) string {
+	line = strings.TrimPrefix(strings.TrimSpace(line), "/")
+	if command, _, ok := cmd.Lookup(line); ok && !srv.rconAllowed(command) {
+		return fmt.Sprintf("Command /%v may not be executed over RCON.\n", command.Name())
+	}
+	buf := &bytes.Buffer{}
+	sender := &ConsoleSender{name: "Rcon", w: srv.World(), out: buf, plain: true}
+	sender.ExecuteCommand(line)
+	return buf.String()
+}
+
+// rconAllowed checks if the cmd.Command passed may be executed over RCON.
+func (srv *Server) rconAllowed(command cmd.Command) bool {
+	allowed := srv.conf.RCON.Commands
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, name := range allowed {
+		if strings.EqualFold(name, command.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// readRCONPacket reads a single rconPacket from the io.Reader passed.
+func readRCONPacket(r io.Reader) (rconPacket, error) {
+	var size int32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return rconPacket{}, err
+	}
+	if size < 10 || size > rconMaxPacketSize {
+		return rconPacket{}, fmt.Errorf("invalid packet size %v", size)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return rconPacket{}, err
+	}
+	return rconPacket{
+		id:   int32(binary.LittleEndian.Uint32(b[0:4])),
+		typ:  int32(binary.LittleEndian.Uint32(b[4:8])),
+		body: string(bytes.TrimRight(b[8:], "\x00")),
+	}, nil
+}
+
+// writeRCONPacket writes the rconPacket passed to the io.Writer passed.
+func writeRCONPacket(w io.Writer, pk rconPacket) error {
+	b := make([]byte, 14+len(pk.body))
+	binary.LittleEndian.PutUint32(b[0:4], uint32(10+len(pk.body)))
+	binary.LittleEndian.PutUint32(b[4:8], uint32(pk.id))
+	binary.LittleEndian.PutUint32(b[8:12], uint32(pk.typ))
+	copy(b[12:], pk.body)
+	_, err := w.Write(b)
+	return err
+}
+
+// rateLimiter limits the amount of actions performed per second. A rateLimiter with a limit of 0 allows any
+// amount of actions.
+type rateLimiter struct {
+	limit  int
+	window time.Time
+	n      int
+}
+
+// allow checks if another action may be performed in the current second and counts it if so.
+func (r *rateLimiter) allow() bool {
+	if r.limit <= 0 {
+		return true
+	}
+	if now := time.Now(); now.Sub(r.window) >= time.Second {
+		r.window, r.n = now, 0
+	}
+	r.n++
+	return r.n <= r.limit
+}
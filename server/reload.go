@@ -0,0 +1,72 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/logging"
+	"github.com/df-mc/dragonfly/server/session"
+)
+
+// Reload applies the settings of the UserConfig passed that may be changed
+// while the Server is running. These are the name of the server shown in the
+// server list, the displayed maximum player count, the join, quit and shutdown
+// messages, the maximum chunk radius, the packet rate limits and, if the Log
+// of the Server is a *logging.Logger, the log levels. The chunk
+// radius and rate limits are also applied to players already online. Other
+// settings, such as the address or worlds of the Server, require a restart and
+// are left unchanged. An error is returned if the UserConfig is not valid, in
+// which case no settings are changed.
+func (srv *Server) Reload(uc UserConfig) error {
+	if err := uc.Validate(); err != nil {
+		return err
+	}
+	srv.confMu.Lock()
+	srv.conf.Name = uc.Server.Name
+	srv.conf.DisplayedMaxPlayers = uc.Players.DisplayedMaxCount
+	srv.conf.JoinMessage = uc.Server.JoinMessage
+	srv.conf.QuitMessage = uc.Server.QuitMessage
+	srv.conf.ShutdownMessage = uc.Server.ShutdownMessage
+	srv.conf.MaxChunkRadius = uc.Players.MaximumChunkRadius
+	srv.conf.PacketRateLimits = uc.rateLimits()
+	if l, ok := srv.conf.Log.(*logging.Logger); ok {
+		// The levels were validated above, so this cannot fail.
+		_ = uc.applyLogLevels(l)
+	}
+	limits := srv.conf.PacketRateLimits
+	srv.confMu.Unlock()
+
+	srv.pmu.RLock()
+	for _, s := range srv.sessions {
+		s.SetMaxChunkRadius(uc.Players.MaximumChunkRadius)
+		s.SetRateLimits(limits)
+	}
+	srv.pmu.RUnlock()
+	srv.conf.Log.Infof("Reloaded config.")
+	return nil
+}
+
+// reloadable returns the settings of the Server that may be changed by
+// Server.Reload.
+func (srv *Server) reloadable() reloadableConfig {
+	srv.confMu.RLock()
+	defer srv.confMu.RUnlock()
+	return reloadableConfig{
+		Name:                srv.conf.Name,
+		DisplayedMaxPlayers: srv.conf.DisplayedMaxPlayers,
+		JoinMessage:         srv.conf.JoinMessage,
+		QuitMessage:         srv.conf.QuitMessage,
+		ShutdownMessage:     srv.conf.ShutdownMessage,
+		MaxChunkRadius:      srv.conf.MaxChunkRadius,
+		PacketRateLimits:    srv.conf.PacketRateLimits,
+	}
+}
+
+// reloadableConfig holds a copy of the fields of a Config that may be changed
+// by Server.Reload.
+type reloadableConfig struct {
+	Name                string
+	DisplayedMaxPlayers int
+	JoinMessage         string
+	QuitMessage         string
+	ShutdownMessage     string
+	MaxChunkRadius      int
+	PacketRateLimits    map[uint32]session.RateLimit
+}
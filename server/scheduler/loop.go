@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+)
+
+// loop runs the tasks of one or more Schedulers on the goroutine ticking a world.World. It runs once every
+// tick for as long as it has tasks to run, using world.World.Exec.
+type loop struct {
+	w *world.World
+
+	mu      sync.Mutex
+	tick    int64
+	tasks   []*Task
+	running bool
+}
+
+// schedule schedules a Task to run after a delay in ticks.
+func (l *loop) schedule(t *Task, delay int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t.next = l.tick + delay
+	l.tasks = append(l.tasks, t)
+	if !l.running {
+		l.running = true
+		l.w.Exec(l.run)
+	}
+}
+
+// run advances the loop by one tick and runs all tasks that are due.
+func (l *loop) run() {
+	l.mu.Lock()
+	l.tick++
+	tick, due, remaining := l.tick, make([]*Task, 0, len(l.tasks)), l.tasks[:0]
+	for _, t := range l.tasks {
+		if t.Cancelled() {
+			continue
+		}
+		if t.next <= tick {
+			due = append(due, t)
+			continue
+		}
+		remaining = append(remaining, t)
+	}
+	l.tasks = remaining
+	l.mu.Unlock()
+
+	for _, t := range due {
+		if t.Cancelled() {
+			continue
+		}
+		t.f()
+		if t.interval == 0 {
+			t.done()
+			continue
+		}
+		if !t.Cancelled() {
+			l.mu.Lock()
+			t.next = tick + t.interval
+			l.tasks = append(l.tasks, t)
+			l.mu.Unlock()
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.tasks) == 0 {
+		l.running = false
+		return
+	}
+	l.w.Exec(l.run)
+}
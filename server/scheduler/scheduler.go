@@ -0,0 +1,175 @@
+// Package scheduler implements the scheduling of tasks that run in sync with the ticking of a world.World,
+// either once after a delay or repeatedly, and of tasks that run asynchronously.
+package scheduler
+
+import (
+	"github.com/df-mc/atomic"
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+	"time"
+)
+
+// tickDuration is the duration of a single tick.
+const tickDuration = time.Second / 20
+
+// Scheduler schedules tasks that run on the goroutine ticking a world.World, so that they are aligned to its
+// ticks and may safely interact with it, and tasks that run asynchronously. Groups of tasks, such as those
+// of a plugin, may be created using Group, so that they can be cancelled together using Close.
+type Scheduler struct {
+	l      *loop
+	parent *Scheduler
+
+	mu       sync.Mutex
+	closed   bool
+	tasks    map[*Task]struct{}
+	children map[*Scheduler]struct{}
+}
+
+// New creates a Scheduler that runs tasks on the goroutine ticking the world.World passed.
+func New(w *world.World) *Scheduler {
+	return newScheduler(&loop{w: w}, nil)
+}
+
+// newScheduler creates a Scheduler using a loop and an optional parent.
+func newScheduler(l *loop, parent *Scheduler) *Scheduler {
+	return &Scheduler{l: l, parent: parent, tasks: map[*Task]struct{}{}, children: map[*Scheduler]struct{}{}}
+}
+
+// Group creates a Scheduler that schedules tasks in the same way as s, but whose tasks may be cancelled
+// separately by calling Close on it. Closing s also closes all groups created from it.
+func (s *Scheduler) Group() *Scheduler {
+	g := newScheduler(s.l, s)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		g.closed = true
+		return g
+	}
+	s.children[g] = struct{}{}
+	return g
+}
+
+// RunLater runs a function once after a delay, on the goroutine ticking the world.World of the Scheduler.
+// The delay is rounded to ticks and is at least one tick.
+func (s *Scheduler) RunLater(delay time.Duration, f func()) *Task {
+	return s.schedule(&Task{f: f}, delay)
+}
+
+// RunRepeating runs a function repeatedly with the interval passed, first after the delay passed, on the
+// goroutine ticking the world.World of the Scheduler, until the Task returned is cancelled. The delay and
+// interval are rounded to ticks and are at least one tick.
+func (s *Scheduler) RunRepeating(delay, interval time.Duration, f func()) *Task {
+	return s.schedule(&Task{f: f, interval: ticks(interval)}, delay)
+}
+
+// Sync runs a function on the goroutine ticking the world.World of the Scheduler at the start of the next
+// tick. It may be used by asynchronous tasks to hop back onto the tick goroutine, for example to apply the
+// result of a computation to the world.
+func (s *Scheduler) Sync(f func()) *Task {
+	return s.RunLater(0, f)
+}
+
+// RunAsync runs a function on a new goroutine. The function is not run if the Task returned is cancelled
+// before the function starts.
+func (s *Scheduler) RunAsync(f func()) *Task {
+	t := &Task{f: f}
+	if !s.track(t) {
+		return t
+	}
+	go func() {
+		defer t.done()
+		if !t.Cancelled() {
+			f()
+		}
+	}()
+	return t
+}
+
+// Close cancels all tasks scheduled using the Scheduler and any groups created from it. Tasks scheduled after
+// closing the Scheduler are cancelled immediately. Close always returns nil.
+func (s *Scheduler) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	tasks, children := s.tasks, s.children
+	s.tasks, s.children = map[*Task]struct{}{}, map[*Scheduler]struct{}{}
+	s.mu.Unlock()
+
+	for t := range tasks {
+		t.cancelled.Store(true)
+	}
+	for c := range children {
+		_ = c.Close()
+	}
+	if s.parent != nil {
+		s.parent.mu.Lock()
+		delete(s.parent.children, s)
+		s.parent.mu.Unlock()
+	}
+	return nil
+}
+
+// schedule schedules a Task to run on the tick goroutine after a delay.
+func (s *Scheduler) schedule(t *Task, delay time.Duration) *Task {
+	if s.track(t) {
+		s.l.schedule(t, ticks(delay))
+	}
+	return t
+}
+
+// track adds a Task to the tasks of the Scheduler. If the Scheduler is closed, the Task is cancelled and
+// false is returned.
+func (s *Scheduler) track(t *Task) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t.owner = s
+	if s.closed {
+		t.cancelled.Store(true)
+		return false
+	}
+	s.tasks[t] = struct{}{}
+	return true
+}
+
+// Task is a function scheduled to run using a Scheduler. It may be used to cancel the function.
+type Task struct {
+	f        func()
+	interval int64
+	next     int64
+
+	owner     *Scheduler
+	cancelled atomic.Bool
+}
+
+// Cancel cancels the Task, so that it does not run again. Cancel does not stop a function that is currently
+// running.
+func (t *Task) Cancel() {
+	t.cancelled.Store(true)
+	t.done()
+}
+
+// Cancelled checks if the Task was cancelled, either using Cancel or by closing its Scheduler.
+func (t *Task) Cancelled() bool {
+	return t.cancelled.Load()
+}
+
+// done removes the Task from the tasks of its Scheduler.
+func (t *Task) done() {
+	if t.owner == nil {
+		return
+	}
+	t.owner.mu.Lock()
+	delete(t.owner.tasks, t)
+	t.owner.mu.Unlock()
+}
+
+// ticks converts a time.Duration to ticks, rounding to the nearest tick. At least one tick is returned.
+func ticks(d time.Duration) int64 {
+	if n := int64((d + tickDuration/2) / tickDuration); n > 1 {
+		return n
+	}
+	return 1
+}
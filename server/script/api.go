@@ -0,0 +1,316 @@
+package script
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/scheduler"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	lua "github.com/yuin/gopher-lua"
+	"strings"
+	"time"
+)
+
+const (
+	playerType    = "player"
+	worldType     = "world"
+	inventoryType = "inventory"
+)
+
+// register registers the globals and types of the API in the Lua state of the script.
+func (s *script) register() {
+	l := s.l
+	l.SetGlobal("server", l.SetFuncs(l.NewTable(), map[string]lua.LGFunction{
+		"broadcast": func(l *lua.LState) int {
+			_, _ = fmt.Fprintln(chat.Global, l.CheckString(1))
+			return 0
+		},
+		"players": func(l *lua.LState) int {
+			t := l.NewTable()
+			for _, p := range s.srv.Players() {
+				t.Append(s.value(p))
+			}
+			l.Push(t)
+			return 1
+		},
+		"player": func(l *lua.LState) int {
+			p, ok := s.srv.PlayerByName(l.CheckString(1))
+			if !ok {
+				l.Push(lua.LNil)
+				return 1
+			}
+			l.Push(s.value(p))
+			return 1
+		},
+		"world": func(l *lua.LState) int {
+			l.Push(s.value(s.srv.World()))
+			return 1
+		},
+	}))
+	l.SetGlobal("events", l.SetFuncs(l.NewTable(), map[string]lua.LGFunction{
+		"on": func(l *lua.LState) int {
+			name, f := l.CheckString(1), l.CheckFunction(2)
+			s.handlers[name] = append(s.handlers[name], f)
+			return 0
+		},
+	}))
+	l.SetGlobal("commands", l.SetFuncs(l.NewTable(), map[string]lua.LGFunction{
+		"register": func(l *lua.LState) int {
+			name, description, f := l.CheckString(1), l.CheckString(2), l.CheckFunction(3)
+			s.ctx.RegisterCommand(cmd.New(name, description, nil, command{s: s, f: f}))
+			return 0
+		},
+	}))
+	l.SetGlobal("scheduler", l.SetFuncs(l.NewTable(), map[string]lua.LGFunction{
+		"after": func(l *lua.LState) int {
+			delay, f := seconds(l.CheckNumber(1)), l.CheckFunction(2)
+			return s.pushTask(s.ctx.Scheduler().RunLater(delay, func() { s.callFunction(f) }))
+		},
+		"every": func(l *lua.LState) int {
+			interval, f := seconds(l.CheckNumber(1)), l.CheckFunction(2)
+			return s.pushTask(s.ctx.Scheduler().RunRepeating(interval, interval, func() { s.callFunction(f) }))
+		},
+	}))
+	l.SetGlobal("log", l.NewFunction(func(l *lua.LState) int {
+		s.ctx.Log().Infof("[%v] %v", s.name, l.CheckString(1))
+		return 0
+	}))
+
+	s.registerType(playerType, map[string]lua.LGFunction{
+		"name": func(l *lua.LState) int {
+			l.Push(lua.LString(checkPlayer(l).Name()))
+			return 1
+		},
+		"message": func(l *lua.LState) int {
+			checkPlayer(l).Message(l.CheckString(2))
+			return 0
+		},
+		"kick": func(l *lua.LState) int {
+			checkPlayer(l).Kick(l.OptString(2, ""))
+			return 0
+		},
+		"position": func(l *lua.LState) int {
+			pos := checkPlayer(l).Position()
+			l.Push(lua.LNumber(pos[0]))
+			l.Push(lua.LNumber(pos[1]))
+			l.Push(lua.LNumber(pos[2]))
+			return 3
+		},
+		"teleport": func(l *lua.LState) int {
+			p := checkPlayer(l)
+			p.Teleport(mgl64.Vec3{float64(l.CheckNumber(2)), float64(l.CheckNumber(3)), float64(l.CheckNumber(4))})
+			return 0
+		},
+		"health": func(l *lua.LState) int {
+			l.Push(lua.LNumber(checkPlayer(l).Health()))
+			return 1
+		},
+		"world": func(l *lua.LState) int {
+			l.Push(s.value(checkPlayer(l).World()))
+			return 1
+		},
+		"inventory": func(l *lua.LState) int {
+			l.Push(s.value(checkPlayer(l).Inventory()))
+			return 1
+		},
+	})
+	s.registerType(worldType, map[string]lua.LGFunction{
+		"name": func(l *lua.LState) int {
+			l.Push(lua.LString(checkWorld(l).Name()))
+			return 1
+		},
+		"block": func(l *lua.LState) int {
+			name, _ := checkWorld(l).Block(checkPos(l, 2)).EncodeBlock()
+			l.Push(lua.LString(name))
+			return 1
+		},
+		"set_block": func(l *lua.LState) int {
+			w, pos := checkWorld(l), checkPos(l, 2)
+			b, ok := world.BlockByName(l.CheckString(5), properties(l.OptTable(6, l.NewTable())))
+			if !ok {
+				l.ArgError(5, "unknown block or block properties")
+			}
+			w.SetBlock(pos, b, nil)
+			return 0
+		},
+	})
+	s.registerType(inventoryType, map[string]lua.LGFunction{
+		"size": func(l *lua.LState) int {
+			l.Push(lua.LNumber(checkInventory(l).Size()))
+			return 1
+		},
+		"item": func(l *lua.LState) int {
+			it, err := checkInventory(l).Item(l.CheckInt(2))
+			if err != nil {
+				l.ArgError(2, err.Error())
+			}
+			if it.Empty() {
+				l.Push(lua.LNil)
+				l.Push(lua.LNumber(0))
+				return 2
+			}
+			name, _ := it.Item().EncodeItem()
+			l.Push(lua.LString(name))
+			l.Push(lua.LNumber(it.Count()))
+			return 2
+		},
+		"set_item": func(l *lua.LState) int {
+			inv := checkInventory(l)
+			if err := inv.SetItem(l.CheckInt(2), checkStack(l, 3)); err != nil {
+				l.ArgError(2, err.Error())
+			}
+			return 0
+		},
+		"add_item": func(l *lua.LState) int {
+			n, _ := checkInventory(l).AddItem(checkStack(l, 2))
+			l.Push(lua.LNumber(n))
+			return 1
+		},
+		"clear": func(l *lua.LState) int {
+			checkInventory(l).Clear()
+			return 0
+		},
+	})
+}
+
+// pushTask pushes a function that cancels the scheduler.Task passed to the Lua stack.
+func (s *script) pushTask(t *scheduler.Task) int {
+	s.l.Push(s.l.NewFunction(func(*lua.LState) int {
+		t.Cancel()
+		return 0
+	}))
+	return 1
+}
+
+// seconds converts a Lua number of seconds to a time.Duration.
+func seconds(n lua.LNumber) time.Duration {
+	return time.Duration(float64(n) * float64(time.Second))
+}
+
+// registerType registers a userdata type with the name and methods passed.
+func (s *script) registerType(name string, methods map[string]lua.LGFunction) {
+	mt := s.l.NewTypeMetatable(name)
+	s.l.SetField(mt, "__index", s.l.SetFuncs(s.l.NewTable(), methods))
+}
+
+// value converts a Go value to a Lua value. Players, worlds and inventories are converted to userdata with
+// their respective types.
+func (s *script) value(v any) lua.LValue {
+	var typ string
+	switch v := v.(type) {
+	case lua.LValue:
+		return v
+	case *player.Player:
+		typ = playerType
+	case *world.World:
+		typ = worldType
+	case *inventory.Inventory:
+		typ = inventoryType
+	case string:
+		return lua.LString(v)
+	case int:
+		return lua.LNumber(v)
+	case float64:
+		return lua.LNumber(v)
+	case nil:
+		return lua.LNil
+	default:
+		panic(fmt.Sprintf("cannot convert %T to a Lua value", v))
+	}
+	ud := s.l.NewUserData()
+	ud.Value = v
+	s.l.SetMetatable(ud, s.l.GetTypeMetatable(typ))
+	return ud
+}
+
+// checkPlayer checks if the first argument is a player and returns it.
+func checkPlayer(l *lua.LState) *player.Player {
+	return checkUserData[*player.Player](l, 1, playerType)
+}
+
+// checkWorld checks if the first argument is a world and returns it.
+func checkWorld(l *lua.LState) *world.World {
+	return checkUserData[*world.World](l, 1, worldType)
+}
+
+// checkInventory checks if the first argument is an inventory and returns it.
+func checkInventory(l *lua.LState) *inventory.Inventory {
+	return checkUserData[*inventory.Inventory](l, 1, inventoryType)
+}
+
+// checkUserData checks if the argument n is userdata holding a value of type T and returns it.
+func checkUserData[T any](l *lua.LState, n int, name string) T {
+	if v, ok := l.CheckUserData(n).Value.(T); ok {
+		return v
+	}
+	l.ArgError(n, name+" expected")
+	panic("unreachable")
+}
+
+// checkPos checks if the arguments n, n+1 and n+2 are numbers and returns them as a cube.Pos.
+func checkPos(l *lua.LState, n int) cube.Pos {
+	return cube.Pos{l.CheckInt(n), l.CheckInt(n + 1), l.CheckInt(n + 2)}
+}
+
+// checkStack checks if the argument n is the name of an item, optionally followed by a count, and returns
+// it as an item.Stack.
+func checkStack(l *lua.LState, n int) item.Stack {
+	it, ok := world.ItemByName(l.CheckString(n), 0)
+	if !ok {
+		l.ArgError(n, "unknown item")
+	}
+	return item.NewStack(it, l.OptInt(n+1, 1))
+}
+
+// properties converts a Lua table of block properties to a map as accepted by world.BlockByName. Numbers are
+// converted to int32 and booleans to uint8, as used by block states.
+func properties(t *lua.LTable) map[string]any {
+	m := map[string]any{}
+	t.ForEach(func(k, v lua.LValue) {
+		switch v := v.(type) {
+		case lua.LNumber:
+			m[k.String()] = int32(v)
+		case lua.LBool:
+			m[k.String()] = uint8(0)
+			if v {
+				m[k.String()] = uint8(1)
+			}
+		default:
+			m[k.String()] = v.String()
+		}
+	})
+	return m
+}
+
+// command is a cmd.Runnable that calls a Lua function when run.
+type command struct {
+	s    *script
+	f    *lua.LFunction
+	Args cmd.Optional[cmd.Varargs] `cmd:"args"`
+}
+
+// Run ...
+func (c command) Run(src cmd.Source, _ *cmd.Output) {
+	args, _ := c.Args.Load()
+	p, _ := src.(*player.Player)
+
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	if c.s.l == nil {
+		return
+	}
+	t := c.s.l.NewTable()
+	for _, arg := range strings.Fields(string(args)) {
+		t.Append(lua.LString(arg))
+	}
+	if p == nil {
+		c.s.protectedCall(c.f, nil, t)
+		return
+	}
+	c.s.protectedCall(c.f, p, t)
+}
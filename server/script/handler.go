@@ -0,0 +1,84 @@
+package script
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// handler is a player.Handler that passes the events of a player to the scripts of a Plugin before passing
+// them to the player.Handler that the player had when it joined.
+type handler struct {
+	player.Handler
+	pl *Plugin
+	p  *player.Player
+}
+
+// HandleChat ...
+func (h handler) HandleChat(ctx *event.Context, message *string) {
+	if h.pl.call("chat", h.p, *message) {
+		ctx.Cancel()
+	}
+	h.Handler.HandleChat(ctx, message)
+}
+
+// HandleBlockBreak ...
+func (h handler) HandleBlockBreak(ctx *event.Context, pos cube.Pos, drops *[]item.Stack, xp *int) {
+	name, _ := h.p.World().Block(pos).EncodeBlock()
+	if h.pl.call("block_break", h.p, pos[0], pos[1], pos[2], name) {
+		ctx.Cancel()
+	}
+	h.Handler.HandleBlockBreak(ctx, pos, drops, xp)
+}
+
+// HandleBlockPlace ...
+func (h handler) HandleBlockPlace(ctx *event.Context, pos cube.Pos, b world.Block) {
+	name, _ := b.EncodeBlock()
+	if h.pl.call("block_place", h.p, pos[0], pos[1], pos[2], name) {
+		ctx.Cancel()
+	}
+	h.Handler.HandleBlockPlace(ctx, pos, b)
+}
+
+// HandleItemUse ...
+func (h handler) HandleItemUse(ctx *event.Context) {
+	held, _ := h.p.HeldItems()
+	var name string
+	if !held.Empty() {
+		name, _ = held.Item().EncodeItem()
+	}
+	if h.pl.call("item_use", h.p, name) {
+		ctx.Cancel()
+	}
+	h.Handler.HandleItemUse(ctx)
+}
+
+// HandleHurt ...
+func (h handler) HandleHurt(ctx *event.Context, damage *float64, attackImmunity *time.Duration, src world.DamageSource) {
+	if h.pl.call("hurt", h.p, *damage) {
+		ctx.Cancel()
+	}
+	h.Handler.HandleHurt(ctx, damage, attackImmunity, src)
+}
+
+// HandleDeath ...
+func (h handler) HandleDeath(src world.DamageSource, keepInv *bool) {
+	h.pl.call("death", h.p)
+	h.Handler.HandleDeath(src, keepInv)
+}
+
+// HandleRespawn ...
+func (h handler) HandleRespawn(pos *mgl64.Vec3, w **world.World) {
+	h.pl.call("respawn", h.p)
+	h.Handler.HandleRespawn(pos, w)
+}
+
+// HandleQuit ...
+func (h handler) HandleQuit() {
+	h.pl.call("quit", h.p)
+	h.Handler.HandleQuit()
+}
@@ -0,0 +1,201 @@
+// Package script implements an optional Lua scripting runtime for gameplay logic. Scripts are plain Lua
+// files loaded from a directory by a Plugin, so that server owners can write lightweight gameplay code
+// without recompiling the server. Scripts have access to players, worlds, inventories, commands and events
+// through the globals documented on Plugin.
+package script
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/plugin"
+	lua "github.com/yuin/gopher-lua"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Plugin is a plugin.Plugin that runs all Lua scripts (files with the .lua extension) in a directory. Each
+// script runs in its own Lua state and is only ever run by one goroutine at a time. Scripts may use the
+// following globals:
+//
+//	server.broadcast(message)          -- Sends a chat message to all players.
+//	server.players()                   -- Returns a table of all players online.
+//	server.player(name)                -- Returns the player with the name passed, or nil.
+//	server.world()                     -- Returns the overworld.
+//	events.on(name, function)          -- Calls the function for an event. The events supported are
+//	                                   -- listed below.
+//	commands.register(name, description, function)
+//	                                   -- Registers a command. The function is passed the player running
+//	                                   -- it, or nil if it is not run by a player, and its arguments.
+//	scheduler.after(seconds, function) -- Calls the function once after a delay. Returns a function that
+//	                                   -- cancels the task.
+//	scheduler.every(seconds, function) -- Calls the function repeatedly with an interval. Returns a
+//	                                   -- function that cancels the task.
+//	log(message)                       -- Logs a message to the server log.
+//
+// The following events are supported. Each is passed the player concerned first. Events marked with an
+// asterisk are cancelled if the function returns false.
+//
+//	join                               -- A player joined the server.
+//	chat*(message)                     -- A player sent a chat message.
+//	block_break*(x, y, z, block)       -- A player is breaking a block.
+//	block_place*(x, y, z, block)       -- A player is placing a block.
+//	item_use*(item)                    -- A player used the item held in its main hand.
+//	hurt*(damage)                      -- A player is about to be hurt.
+//	death                              -- A player died.
+//	respawn                            -- A player respawned.
+//	quit                               -- A player left the server.
+//
+// To receive player events other than join, the Plugin wraps the player.Handler of every player joining
+// after all join handlers with a priority lower than event.PriorityHighest were called, so player.Handlers
+// set by other plugins must be set before then.
+//
+// Players have the methods name, message, kick, position, teleport, health, world and inventory. Worlds have
+// the methods name, block and set_block, and inventories the methods size, item, set_item, add_item and
+// clear. Items and blocks are referred to by their names, such as "minecraft:stone".
+type Plugin struct {
+	// Dir is the directory that scripts are loaded from.
+	Dir string
+
+	srv     *server.Server
+	scripts []*script
+}
+
+// Name ...
+func (*Plugin) Name() string {
+	return "scripts"
+}
+
+// Enable loads and runs all scripts in the directory of the Plugin. Scripts that fail to run are logged and
+// skipped.
+func (pl *Plugin) Enable(ctx *plugin.Context) error {
+	pl.srv = ctx.Server()
+	entries, err := os.ReadDir(pl.Dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("read script directory: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".lua") {
+			continue
+		}
+		s := newScript(ctx, e.Name())
+		if err := s.run(filepath.Join(pl.Dir, e.Name())); err != nil {
+			ctx.Log().Errorf("Error running script %v: %v", e.Name(), err)
+			s.close()
+			continue
+		}
+		pl.scripts = append(pl.scripts, s)
+	}
+	ctx.HandleJoin(event.PriorityHighest, func(_ *event.Context, p *player.Player) {
+		p.Handle(handler{Handler: p.Handler(), pl: pl, p: p})
+		pl.call("join", p)
+	})
+	return nil
+}
+
+// Disable closes all scripts run by the Plugin and restores the player.Handlers of players online.
+func (pl *Plugin) Disable() error {
+	for _, p := range pl.srv.Players() {
+		if h, ok := p.Handler().(handler); ok && h.pl == pl {
+			p.Handle(h.Handler)
+		}
+	}
+	for _, s := range pl.scripts {
+		s.close()
+	}
+	pl.scripts = nil
+	return nil
+}
+
+// call calls the handlers of all scripts registered for the event with the name passed. True is returned if
+// any of the handlers cancelled the event by returning false.
+func (pl *Plugin) call(name string, args ...any) (cancel bool) {
+	for _, s := range pl.scripts {
+		if s.call(name, args...) {
+			cancel = true
+		}
+	}
+	return cancel
+}
+
+// script is a single Lua script with its own Lua state.
+type script struct {
+	name string
+	ctx  *plugin.Context
+	srv  *server.Server
+
+	mu       sync.Mutex
+	l        *lua.LState
+	handlers map[string][]*lua.LFunction
+}
+
+// newScript creates a script with a new Lua state that has the API of the Plugin registered.
+func newScript(ctx *plugin.Context, name string) *script {
+	s := &script{name: name, ctx: ctx, srv: ctx.Server(), l: lua.NewState(), handlers: map[string][]*lua.LFunction{}}
+	s.register()
+	return s
+}
+
+// run runs the Lua file at the path passed in the state of the script.
+func (s *script) run(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.l.DoFile(path)
+}
+
+// call calls all handlers of the script registered for the event with the name passed, converting the
+// arguments passed to Lua values. True is returned if any of the handlers returned false.
+func (s *script) call(name string, args ...any) (cancel bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.l == nil {
+		return false
+	}
+	for _, f := range s.handlers[name] {
+		if s.protectedCall(f, args...) == lua.LFalse {
+			cancel = true
+		}
+	}
+	return cancel
+}
+
+// callFunction calls a Lua function of the script without arguments.
+func (s *script) callFunction(f *lua.LFunction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.l != nil {
+		s.protectedCall(f)
+	}
+}
+
+// protectedCall calls a Lua function with the arguments passed, logging any error that occurs. The value
+// returned by the function is returned, or lua.LNil if it failed. It assumes s.mu is locked upon calling.
+func (s *script) protectedCall(f *lua.LFunction, args ...any) lua.LValue {
+	values := make([]lua.LValue, len(args))
+	for i, arg := range args {
+		values[i] = s.value(arg)
+	}
+	if err := s.l.CallByParam(lua.P{Fn: f, NRet: 1, Protect: true}, values...); err != nil {
+		s.ctx.Log().Errorf("Error in script %v: %v", s.name, err)
+		return lua.LNil
+	}
+	ret := s.l.Get(-1)
+	s.l.Pop(1)
+	return ret
+}
+
+// close closes the Lua state of the script.
+func (s *script) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.l != nil {
+		s.l.Close()
+		s.l = nil
+	}
+}
@@ -8,9 +8,13 @@ import (
 	"fmt"
 	"github.com/df-mc/atomic"
 	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/economy"
+	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/internal/iteminternal"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	_ "github.com/df-mc/dragonfly/server/item" // Imported for maintaining correct initialisation order.
+	"github.com/df-mc/dragonfly/server/logging"
+	"github.com/df-mc/dragonfly/server/permission"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/session"
@@ -26,6 +30,7 @@ import (
 	"github.com/sandertv/gophertunnel/minecraft/text"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
+	"math"
 	"math/rand"
 	"os"
 	"os/exec"
@@ -40,7 +45,9 @@ import (
 // Server implements a Dragonfly server. It runs the main server loop and
 // handles the connections of players trying to join the server.
 type Server struct {
-	conf Config
+	// confMu guards the fields of conf that may be changed by Server.Reload.
+	confMu sync.RWMutex
+	conf   Config
 
 	once    sync.Once
 	started atomic.Bool
@@ -51,17 +58,30 @@ type Server struct {
 
 	listeners []Listener
 	incoming  chan *session.Session
+	logins    event.Bus[*Login]
+	joins     event.Bus[*player.Player]
+	quits     event.Bus[*player.Player]
 
 	pmu sync.RWMutex
 	// p holds a map of all players currently connected to the server. When they
 	// leave, they are removed from the map.
 	p map[uuid.UUID]*player.Player
+	// sessions holds the sessions of the players in p, so that settings
+	// reloaded may be applied to them.
+	sessions map[uuid.UUID]*session.Session
 	// pwg is a sync.WaitGroup used to wait for all players to be disconnected
 	// before server shutdown, so that their data is saved properly.
 	pwg sync.WaitGroup
 	// wg is used to wait for all Listeners to be closed and their respective
 	// goroutines to be finished.
 	wg sync.WaitGroup
+	// closing is closed when the Server starts shutting down.
+	closing chan struct{}
+
+	rconMu sync.Mutex
+	// rconClients holds the authentication failures and command rate of RCON
+	// clients, indexed by their IP address.
+	rconClients map[string]*rconClient
 }
 
 // HandleFunc is a function that may be passed to Server.Accept(). It can be
@@ -88,6 +108,18 @@ func (srv *Server) Listen() {
 	srv.conf.Log.Infof("Starting Dragonfly for Minecraft v%v...", protocol.CurrentVersion)
 	srv.startListening()
 	go srv.wait()
+	if srv.conf.SaveInterval > 0 {
+		go srv.autoSave()
+	}
+	if srv.conf.MetricsAddress != "" {
+		go srv.serveMetrics()
+	}
+	if srv.conf.RCON.Address != "" {
+		go srv.serveRCON()
+	}
+	if srv.conf.API.Address != "" {
+		go srv.serveAPI()
+	}
 }
 
 // Accept accepts an incoming player into the server. It blocks until a player
@@ -102,18 +134,49 @@ func (srv *Server) Accept(f HandleFunc) bool {
 		return false
 	}
 	p := s.Controllable().(*player.Player)
+	srv.joins.Call(event.C(), p)
 	if f != nil {
 		f(p)
 	}
 
 	srv.pmu.Lock()
 	srv.p[p.UUID()] = p
+	srv.sessions[p.UUID()] = s
 	srv.pmu.Unlock()
 
 	s.Start()
 	return true
 }
 
+// Logins returns the event.Bus that connections logging in to the Server are
+// dispatched to, after the Allower of the Server allowed them to join.
+// Handlers may cancel the event to refuse the connection, for example to
+// apply platform-specific policies based on the Device of the connection.
+func (srv *Server) Logins() *event.Bus[*Login] {
+	return &srv.logins
+}
+
+// Joins returns the event.Bus that players accepted by the Server are
+// dispatched to, before the HandleFunc passed to Accept is called. Handlers
+// may use it to add a player.Handler to players. Cancelling the event has no
+// effect.
+func (srv *Server) Joins() *event.Bus[*player.Player] {
+	return &srv.joins
+}
+
+// Economy returns the economy.Economy of the Server, which plugins should use
+// for any balances so that they share a single currency.
+func (srv *Server) Economy() economy.Economy {
+	return srv.conf.Economy
+}
+
+// Quits returns the event.Bus that players are dispatched to when they leave
+// the Server, after their player.Handler has handled the quit and before their
+// data is saved. Cancelling the event has no effect.
+func (srv *Server) Quits() *event.Bus[*player.Player] {
+	return &srv.quits
+}
+
 // World returns the overworld of the server. Players will be spawned in this
 // world and this world will be read from and written to when the world is
 // edited.
@@ -133,6 +196,21 @@ func (srv *Server) End() *world.World {
 	return srv.end
 }
 
+// TPS returns the amount of ticks per second that the server is currently
+// running at. Normally, this is 20. The TPS returned is the lowest TPS of the
+// overworld, nether and end, measured over their last 100 ticks.
+func (srv *Server) TPS() float64 {
+	return math.Min(srv.world.TPS(), math.Min(srv.nether.TPS(), srv.end.TPS()))
+}
+
+// MSPT returns the average amount of milliseconds that a tick of the server
+// currently takes. The MSPT returned is the highest MSPT of the overworld,
+// nether and end, measured over their last 100 ticks. If it exceeds 50, the
+// server is unable to run at 20 ticks per second.
+func (srv *Server) MSPT() float64 {
+	return math.Max(srv.world.MSPT(), math.Max(srv.nether.MSPT(), srv.end.MSPT()))
+}
+
 // MaxPlayerCount returns the maximum amount of players that are allowed to
 // play on the server at the same time. Players trying to join when the server
 // is full will be refused to enter. If the config has a maximum player count
@@ -208,10 +286,11 @@ func (srv *Server) Close() error {
 func (srv *Server) close() {
 	srv.conf.Log.Infof("Server shutting down...")
 	defer srv.conf.Log.Infof("Server stopped.")
+	close(srv.closing)
 
 	srv.conf.Log.Debugf("Disconnecting players...")
 	for _, p := range srv.Players() {
-		p.Disconnect(text.Colourf("<yellow>%v</yellow>", srv.conf.ShutdownMessage))
+		p.Disconnect(text.Colourf("<yellow>%v</yellow>", srv.reloadable().ShutdownMessage))
 	}
 	srv.pwg.Wait()
 
@@ -220,6 +299,11 @@ func (srv *Server) close() {
 		srv.conf.Log.Errorf("Error while closing player provider: %v", err)
 	}
 
+	srv.conf.Log.Debugf("Closing economy...")
+	if err := srv.conf.Economy.Close(); err != nil {
+		srv.conf.Log.Errorf("Error while closing economy: %v", err)
+	}
+
 	srv.conf.Log.Debugf("Closing worlds...")
 	for _, w := range []*world.World{srv.end, srv.nether, srv.world} {
 		if err := w.Close(); err != nil {
@@ -235,6 +319,43 @@ func (srv *Server) close() {
 	}
 }
 
+// autoSave saves the data of all online players every time the SaveInterval
+// in the Config passes, until the Server is closed. Worlds are saved at the
+// same interval by the worlds themselves.
+func (srv *Server) autoSave() {
+	t := time.NewTicker(srv.conf.SaveInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			srv.conf.Log.Debugf("Saving player data...")
+			srv.savePlayers()
+		case <-srv.closing:
+			return
+		}
+	}
+}
+
+// Save saves the data of all players online and all chunks of the worlds of
+// the Server that were changed.
+func (srv *Server) Save() {
+	srv.savePlayers()
+	for _, w := range []*world.World{srv.world, srv.nether, srv.end} {
+		w.Save()
+	}
+}
+
+// savePlayers saves the data of all players online using the PlayerProvider of
+// the Server.
+func (srv *Server) savePlayers() {
+	for _, p := range srv.Players() {
+		if err := srv.conf.PlayerProvider.Save(p.UUID(), p.Data()); err != nil {
+			srv.conf.Log.Errorf("Error while saving data: %v", err)
+		}
+	}
+}
+
 // listen makes the Server listen for new connections from the Listener passed.
 // This may be used to listen for players on different interfaces. Note that
 // the maximum player count of additional Listeners added is not enforced
@@ -260,7 +381,7 @@ func (srv *Server) listen(l Listener) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if msg, ok := srv.conf.Allower.Allow(c.RemoteAddr(), c.IdentityData(), c.ClientData()); !ok {
+			if msg, ok := srv.allow(c); !ok {
 				_ = c.WritePacket(&packet.Disconnect{HideDisconnectionScreen: msg == "", Message: msg})
 				_ = c.Close()
 				return
@@ -270,6 +391,31 @@ func (srv *Server) listen(l Listener) {
 	}
 }
 
+// allow checks if the connection passed is allowed to join the Server, first
+// verifying its forwarded identity, then checking the moderation.Manager, if
+// set, then the Allower of the Server and finally the handlers subscribed to
+// the Logins event.Bus.
+func (srv *Server) allow(c session.Conn) (string, bool) {
+	if !srv.conf.Forwarding.verify(c) {
+		srv.conf.Log.Debugf("connection %v has invalid forwarded identity", c.RemoteAddr())
+		return "Invalid forwarded identity.", false
+	}
+	if m := srv.conf.Moderation; m != nil {
+		if msg, ok := m.Allow(c.RemoteAddr(), c.IdentityData(), c.ClientData()); !ok {
+			return msg, false
+		}
+	}
+	if msg, ok := srv.conf.Allower.Allow(c.RemoteAddr(), c.IdentityData(), c.ClientData()); !ok {
+		return msg, false
+	}
+	l := &Login{Addr: c.RemoteAddr(), Identity: c.IdentityData(), Device: player.DeviceFromClientData(c.ClientData())}
+	ctx := event.C()
+	if srv.logins.Call(ctx, l); ctx.Cancelled() {
+		return l.Message, false
+	}
+	return "", true
+}
+
 // startListening starts making the EncodeBlock listener listen, accepting new
 // connections from players.
 func (srv *Server) startListening() {
@@ -309,6 +455,20 @@ func (srv *Server) wait() {
 	close(srv.incoming)
 }
 
+// loadPlayerData loads the data of the player with the UUID passed. Data carried over by the Carrier of the
+// Server takes precedence over data from the PlayerProvider.
+func (srv *Server) loadPlayerData(id uuid.UUID) (player.Data, error) {
+	if srv.conf.Carrier != nil {
+		d, ok, err := srv.conf.Carrier.Receive(id, srv.dimension)
+		if err != nil {
+			srv.conf.Log.Errorf("receive carried data of player %v: %v", id, err)
+		} else if ok {
+			return d, nil
+		}
+	}
+	return srv.conf.PlayerProvider.Load(id, srv.dimension)
+}
+
 // finaliseConn finalises the session.Conn passed and subtracts from the
 // sync.WaitGroup once done.
 func (srv *Server) finaliseConn(ctx context.Context, conn session.Conn, l Listener) {
@@ -316,7 +476,7 @@ func (srv *Server) finaliseConn(ctx context.Context, conn session.Conn, l Listen
 	data := srv.defaultGameData()
 
 	var playerData *player.Data
-	if d, err := srv.conf.PlayerProvider.Load(id, srv.dimension); err == nil {
+	if d, err := srv.loadPlayerData(id); err == nil {
 		if d.World == nil {
 			d.World = srv.world
 		}
@@ -350,7 +510,7 @@ func (srv *Server) defaultGameData() minecraft.GameData {
 		EntityUniqueID:  1,
 		EntityRuntimeID: 1,
 
-		WorldName:       srv.conf.Name,
+		WorldName:       srv.reloadable().Name,
 		BaseGameVersion: protocol.CurrentVersion,
 
 		Time:       int64(srv.world.Time()),
@@ -405,12 +565,14 @@ func (srv *Server) handleSessionClose(c session.Controllable) {
 	srv.pmu.Lock()
 	p, ok := srv.p[c.UUID()]
 	delete(srv.p, c.UUID())
+	delete(srv.sessions, c.UUID())
 	srv.pmu.Unlock()
 	if !ok {
 		// When a player disconnects immediately after a session is started, it might not be added to the players map
 		// yet. This is expected, but we need to be careful not to crash when this happens.
 		return
 	}
+	srv.quits.Call(event.C(), p)
 
 	if err := srv.conf.PlayerProvider.Save(p.UUID(), p.Data()); err != nil {
 		srv.conf.Log.Errorf("Error while saving data: %v", err)
@@ -425,10 +587,27 @@ func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, data *player.Da
 	if data != nil {
 		w, gm, pos = data.World, data.GameMode, data.Position
 	}
-	s := session.New(conn, srv.conf.MaxChunkRadius, srv.conf.Log, srv.conf.JoinMessage, srv.conf.QuitMessage)
+	conf := srv.reloadable()
+	s := session.New(conn, conf.MaxChunkRadius, subsystemLog(srv.conf.Log, logging.SubsystemNetwork), conf.JoinMessage, conf.QuitMessage)
+	s.SetRateLimits(conf.PacketRateLimits)
+	s.SetSubChunkRequests(!srv.conf.DisableSubChunkRequests)
 	p := player.NewWithSession(conn.IdentityData().DisplayName, conn.IdentityData().XUID, id, srv.parseSkin(conn.ClientData()), s, pos, data)
+	p.SetPermissionProvider(srv.conf.PermissionProvider)
+	p.SetMovementValidation(srv.conf.MovementValidation)
+	p.SetCombat(srv.conf.Combat)
+	if srv.conf.Carrier != nil {
+		p.SetCarrier(srv.conf.Carrier)
+	}
+	if srv.conf.Moderation != nil {
+		srv.conf.Moderation.ApplyMute(p)
+	}
 
 	s.Spawn(p, pos, w, gm, srv.handleSessionClose)
+	if srv.conf.Operators != nil {
+		if lvl := srv.conf.Operators.Level(id); lvl > permission.LevelNone {
+			p.SetOperatorLevel(lvl)
+		}
+	}
 	srv.pwg.Add(1)
 	return s
 }
@@ -437,25 +616,34 @@ func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, data *player.Da
 // the program if the world could not be loaded. The layers passed are used to
 // create a generator.Flat that is used as generator for the world.
 func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *world.World {
-	logger := srv.conf.Log
-	if v, ok := logger.(interface {
+	logger := subsystemLog(srv.conf.Log, logging.SubsystemWorld)
+	// Add a dimension field to be able to distinguish between the different
+	// dimensions in the log. Dimensions implement fmt.Stringer so we can just
+	// fmt.Sprint them for a readable name.
+	dimension := strings.ToLower(fmt.Sprint(dim))
+	switch v := logger.(type) {
+	case *logging.Logger:
+		logger = v.WithField("dimension", dimension)
+	case interface {
 		WithField(key string, field any) *logrus.Entry
-	}); ok {
-		// Add a dimension field to be able to distinguish between the different
-		// dimensions in the log. Dimensions implement fmt.Stringer so we can
-		// just fmt.Sprint them for a readable name.
-		logger = v.WithField("dimension", strings.ToLower(fmt.Sprint(dim)))
+	}:
+		logger = v.WithField("dimension", dimension)
 	}
 	logger.Debugf("Loading world...")
 
 	conf := world.Config{
-		Log:             logger,
-		Dim:             dim,
-		Provider:        srv.conf.WorldProvider,
-		Generator:       srv.conf.Generator(dim),
-		RandomTickSpeed: srv.conf.RandomTickSpeed,
-		ReadOnly:        srv.conf.ReadOnlyWorld,
-		Entities:        srv.conf.Entities,
+		Log:              logger,
+		Dim:              dim,
+		Provider:         srv.conf.WorldProvider,
+		Generator:        srv.conf.Generator(dim),
+		RandomTickSpeed:  srv.conf.RandomTickSpeed,
+		ReadOnly:         srv.conf.ReadOnlyWorld,
+		Entities:         srv.conf.Entities,
+		MobSpawns:        srv.conf.MobSpawns,
+		SaveInterval:     srv.conf.SaveInterval,
+		TickRegionSize:   srv.conf.TickRegionSize,
+		ActivationRanges: srv.conf.ActivationRanges,
+		LowTPSThreshold:  srv.conf.LowTPSThreshold,
 		PortalDestination: func(dim world.Dimension) *world.World {
 			if dim == world.Nether {
 				return *nether
@@ -465,6 +653,9 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 			return nil
 		},
 	}
+	if dim == world.Overworld && srv.conf.SpawnProtection > 0 {
+		conf.Protector = world.SpawnProtector{Radius: srv.conf.SpawnProtection}
+	}
 	w := conf.New()
 	logger.Infof(`Opened world "%v".`, w.Name())
 	return w
@@ -486,6 +677,20 @@ func (srv *Server) parseSkin(data login.ClientData) skin.Skin {
 	playerSkin.Cape = skin.NewCape(data.CapeImageWidth, data.CapeImageHeight)
 	playerSkin.Cape.Pix, _ = base64.StdEncoding.DecodeString(data.CapeData)
 
+	playerSkin.ArmSize, playerSkin.Colour = data.ArmSize, data.SkinColour
+	for _, piece := range data.PersonaPieces {
+		playerSkin.PersonaPieces = append(playerSkin.PersonaPieces, skin.PersonaPiece{
+			ID:        piece.PieceID,
+			Type:      piece.PieceType,
+			PackID:    piece.PackID,
+			Default:   piece.Default,
+			ProductID: piece.ProductID,
+		})
+	}
+	for _, tint := range data.PieceTintColours {
+		playerSkin.PieceTints = append(playerSkin.PieceTints, skin.PieceTint{PieceType: tint.PieceType, Colours: append([]string(nil), tint.Colours[:]...)})
+	}
+
 	for _, animation := range data.AnimatedImageData {
 		var t skin.AnimationType
 		switch animation.Type {
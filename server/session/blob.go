@@ -0,0 +1,89 @@
+package session
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+const (
+	// maxPendingBlobs is the maximum amount of blobs that a blobStore holds before evicting the oldest ones.
+	maxPendingBlobs = 4096
+	// maxPendingBlobSize is the maximum total size in bytes of the blobs that a blobStore holds before
+	// evicting the oldest ones.
+	maxPendingBlobSize = 32 << 20
+)
+
+// blobStore holds the blobs of chunks sent to a client with the client blob cache enabled. Blobs are only
+// referenced by their hash when sent, so they are kept until the client reports whether it has them cached.
+// The amount and total size of blobs held are limited: If exceeded, the oldest blobs are evicted and should
+// be sent to the client regardless of whether it has them cached.
+type blobStore struct {
+	blobs map[uint64][]byte
+	// order holds the hashes of blobs in the order they were added. It may hold hashes of blobs that were
+	// already removed from the store, which are skipped when evicting.
+	order []uint64
+	size  int
+}
+
+// newBlobStore creates an empty blobStore.
+func newBlobStore() *blobStore {
+	return &blobStore{blobs: map[uint64][]byte{}}
+}
+
+// add adds a blob with a hash to the blobStore. If the blobStore exceeds its limits as a result, the oldest
+// blobs are evicted from it and returned.
+func (b *blobStore) add(hash uint64, blob []byte) (evicted []protocol.CacheBlob) {
+	if existing, ok := b.blobs[hash]; ok {
+		b.size -= len(existing)
+	} else {
+		b.order = append(b.order, hash)
+	}
+	b.blobs[hash] = blob
+	b.size += len(blob)
+
+	for len(b.blobs) > maxPendingBlobs || b.size > maxPendingBlobSize {
+		h := b.order[0]
+		b.order = b.order[1:]
+		if blob, ok := b.take(h); ok {
+			evicted = append(evicted, protocol.CacheBlob{Hash: h, Payload: blob})
+		}
+	}
+	if len(b.order) > len(b.blobs)*2+64 {
+		b.compact()
+	}
+	return evicted
+}
+
+// take removes a blob from the blobStore and returns it. False is returned if no blob with the hash passed
+// was present.
+func (b *blobStore) take(hash uint64) ([]byte, bool) {
+	blob, ok := b.blobs[hash]
+	if ok {
+		delete(b.blobs, hash)
+		b.size -= len(blob)
+	}
+	return blob, ok
+}
+
+// all removes all blobs from the blobStore and returns them.
+func (b *blobStore) all() []protocol.CacheBlob {
+	blobs := make([]protocol.CacheBlob, 0, len(b.blobs))
+	for h, blob := range b.blobs {
+		blobs = append(blobs, protocol.CacheBlob{Hash: h, Payload: blob})
+	}
+	*b = *newBlobStore()
+	return blobs
+}
+
+// compact removes the hashes of blobs no longer in the blobStore from its order.
+func (b *blobStore) compact() {
+	order, seen := make([]uint64, 0, len(b.blobs)), make(map[uint64]struct{}, len(b.blobs))
+	for _, h := range b.order {
+		if _, ok := seen[h]; ok {
+			continue
+		}
+		if _, ok := b.blobs[h]; ok {
+			order, seen[h] = append(order, h), struct{}{}
+		}
+	}
+	b.order = order
+}
@@ -0,0 +1,173 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/player/bossbar"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// bossBarEntity is an invisible entity spawned to the client to show a boss bar for. The client only shows
+// multiple boss bars if each of them belongs to a different entity. Boss bars with an ID of 0 are shown for the
+// entity of the player itself, so no entity is spawned for them.
+type bossBarEntity struct {
+	runtimeID uint64
+	pos       mgl64.Vec3
+	bar       bossbar.BossBar
+}
+
+// bossBarMoveDistance is the distance that a player must move away from the entities of its boss bars before
+// they are moved to the player again. The client stops showing a boss bar if its entity is too far away.
+const bossBarMoveDistance = 16
+
+// SendBossBar shows a boss bar to the session. If a boss bar with the same ID is already shown, it is updated
+// instead.
+func (s *Session) SendBossBar(bar bossbar.BossBar) {
+	if s == Nop {
+		return
+	}
+	s.bossBarMu.Lock()
+	defer s.bossBarMu.Unlock()
+
+	if e, ok := s.bossBars[bar.ID()]; ok {
+		s.updateBossBar(e, bar)
+		return
+	}
+	e := &bossBarEntity{runtimeID: selfEntityRuntimeID, pos: s.c.Position(), bar: bar}
+	if bar.ID() != 0 {
+		s.entityMutex.Lock()
+		s.currentEntityRuntimeID += 1
+		e.runtimeID = s.currentEntityRuntimeID
+		s.entityMutex.Unlock()
+	}
+	s.bossBars[bar.ID()] = e
+	s.showBossBar(e)
+}
+
+// showBossBar spawns the entity of the bossBarEntity passed, if needed, and shows its boss bar. s.bossBarMu must
+// be held when showBossBar is called.
+func (s *Session) showBossBar(e *bossBarEntity) {
+	if e.runtimeID == selfEntityRuntimeID {
+		s.writeBossBarShow(e)
+		return
+	}
+	m := protocol.NewEntityMetadata()
+	m[protocol.EntityDataKeyScale] = float32(0)
+	m[protocol.EntityDataKeyWidth] = float32(0)
+	m[protocol.EntityDataKeyHeight] = float32(0)
+	m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagInvisible)
+	m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagNoAI)
+	m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSilent)
+	s.writePacket(&packet.AddActor{
+		EntityUniqueID:  int64(e.runtimeID),
+		EntityRuntimeID: e.runtimeID,
+		EntityType:      "minecraft:slime",
+		EntityMetadata:  m,
+		Position:        vec64To32(e.pos),
+	})
+	s.writeBossBarShow(e)
+}
+
+// writeBossBarShow writes the packet that shows the boss bar of the bossBarEntity passed.
+func (s *Session) writeBossBarShow(e *bossBarEntity) {
+	bar := e.bar
+	s.writePacket(&packet.BossEvent{
+		BossEntityUniqueID: int64(e.runtimeID),
+		EventType:          packet.BossEventShow,
+		BossBarTitle:       bar.Text(),
+		HealthPercentage:   float32(bar.HealthPercentage()),
+		Colour:             uint32(bar.Colour().Uint8()),
+		Overlay:            uint32(bar.Overlay().Uint8()),
+	})
+}
+
+// updateBossBar sends the changes between the boss bar shown for the bossBarEntity passed and the BossBar
+// passed. s.bossBarMu must be held when updateBossBar is called.
+func (s *Session) updateBossBar(e *bossBarEntity, bar bossbar.BossBar) {
+	id := int64(e.runtimeID)
+	if bar.Text() != e.bar.Text() {
+		s.writePacket(&packet.BossEvent{BossEntityUniqueID: id, EventType: packet.BossEventTitle, BossBarTitle: bar.Text()})
+	}
+	if bar.HealthPercentage() != e.bar.HealthPercentage() {
+		s.writePacket(&packet.BossEvent{BossEntityUniqueID: id, EventType: packet.BossEventHealthPercentage, HealthPercentage: float32(bar.HealthPercentage())})
+	}
+	if bar.Colour() != e.bar.Colour() || bar.Overlay() != e.bar.Overlay() {
+		s.writePacket(&packet.BossEvent{
+			BossEntityUniqueID: id,
+			EventType:          packet.BossEventAppearanceProperties,
+			Colour:             uint32(bar.Colour().Uint8()),
+			Overlay:            uint32(bar.Overlay().Uint8()),
+		})
+	}
+	e.bar = bar
+}
+
+// HideBossBar hides the boss bar with the same ID as the BossBar passed. Nothing happens if the boss bar was not
+// shown to the session.
+func (s *Session) HideBossBar(bar bossbar.BossBar) {
+	if s == Nop {
+		return
+	}
+	s.bossBarMu.Lock()
+	defer s.bossBarMu.Unlock()
+	if e, ok := s.bossBars[bar.ID()]; ok {
+		s.removeBossBar(e)
+		delete(s.bossBars, bar.ID())
+	}
+}
+
+// RemoveBossBars hides all boss bars currently shown to the session.
+func (s *Session) RemoveBossBars() {
+	if s == Nop {
+		return
+	}
+	s.bossBarMu.Lock()
+	defer s.bossBarMu.Unlock()
+	for id, e := range s.bossBars {
+		s.removeBossBar(e)
+		delete(s.bossBars, id)
+	}
+}
+
+// removeBossBar hides the boss bar of the bossBarEntity passed and despawns the entity.
+func (s *Session) removeBossBar(e *bossBarEntity) {
+	s.writePacket(&packet.BossEvent{BossEntityUniqueID: int64(e.runtimeID), EventType: packet.BossEventHide})
+	if e.runtimeID != selfEntityRuntimeID {
+		s.writePacket(&packet.RemoveActor{EntityUniqueID: int64(e.runtimeID)})
+	}
+}
+
+// respawnBossBars spawns the entities of the boss bars shown to the session again at the position of the
+// Controllable and shows their boss bars again. The client removes all entities when changing dimension, so
+// respawnBossBars must be called after every dimension change.
+func (s *Session) respawnBossBars() {
+	s.bossBarMu.Lock()
+	defer s.bossBarMu.Unlock()
+	pos := s.c.Position()
+	for _, e := range s.bossBars {
+		e.pos = pos
+		s.showBossBar(e)
+	}
+}
+
+// moveBossBars moves the entities of the boss bars shown to the session to the position of the Controllable if
+// it moved too far away from them.
+func (s *Session) moveBossBars() {
+	s.bossBarMu.Lock()
+	defer s.bossBarMu.Unlock()
+	if len(s.bossBars) == 0 {
+		return
+	}
+	pos := s.c.Position()
+	for _, e := range s.bossBars {
+		if e.runtimeID == selfEntityRuntimeID || e.pos.Sub(pos).Len() < bossBarMoveDistance {
+			continue
+		}
+		e.pos = pos
+		s.writePacket(&packet.MoveActorAbsolute{
+			EntityRuntimeID: e.runtimeID,
+			Position:        vec64To32(pos),
+			Flags:           packet.MoveFlagTeleport,
+		})
+	}
+}
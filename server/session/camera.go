@@ -0,0 +1,91 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/player/camera"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"time"
+)
+
+// sendCameraPresets sends all camera presets to the client, so that they may be used in camera instructions.
+func (s *Session) sendCameraPresets() {
+	presets := camera.Presets()
+	pk := &packet.CameraPresets{Presets: make([]protocol.CameraPreset, 0, len(presets))}
+	for _, p := range presets {
+		pk.Presets = append(pk.Presets, protocol.CameraPreset{Name: p.Name()})
+	}
+	s.writePacket(pk)
+}
+
+// SetCamera changes the camera of the client using the camera.Set instruction passed.
+func (s *Session) SetCamera(set camera.Set) {
+	ins := protocol.CameraInstructionSet{Preset: uint32(set.Preset().Uint8())}
+	if e, d := set.Ease(); d > 0 {
+		ins.Ease = protocol.Option(protocol.CameraEase{Type: uint8(e), Duration: float32(d.Seconds())})
+	}
+	if pos, ok := set.Position(); ok {
+		ins.Position = protocol.Option(vec64To32(pos))
+	}
+	if yaw, pitch, ok := set.Rotation(); ok {
+		ins.Rotation = protocol.Option(mgl32.Vec2{float32(pitch), float32(yaw)})
+	}
+	if pos, ok := set.Facing(); ok {
+		ins.Facing = protocol.Option(vec64To32(pos))
+	}
+	if set.Default() {
+		ins.Default = protocol.Option(true)
+	}
+	s.writePacket(&packet.CameraInstruction{Set: protocol.Option(ins)})
+}
+
+// FadeCamera fades the screen of the client using the camera.Fade instruction passed.
+func (s *Session) FadeCamera(fade camera.Fade) {
+	fadeIn, wait, fadeOut := fade.Durations()
+	s.writePacket(&packet.CameraInstruction{Fade: protocol.Option(protocol.CameraInstructionFade{
+		FadeInDuration:  float32(fadeIn.Seconds()),
+		WaitDuration:    float32(wait.Seconds()),
+		FadeOutDuration: float32(fadeOut.Seconds()),
+		Colour:          fade.Colour(),
+	})})
+}
+
+// ClearCamera clears all camera instructions sent to the client, returning the camera to normal.
+func (s *Session) ClearCamera() {
+	s.writePacket(&packet.CameraInstruction{Clear: protocol.Option(true)})
+}
+
+// ShakeCamera shakes the camera of the client with the intensity passed for the duration passed. If rotational
+// is true, the camera is rotated rather than moved while shaking.
+func (s *Session) ShakeCamera(intensity float64, d time.Duration, rotational bool) {
+	typ := packet.CameraShakeTypePositional
+	if rotational {
+		typ = packet.CameraShakeTypeRotational
+	}
+	s.writePacket(&packet.CameraShake{
+		Intensity: float32(intensity),
+		Duration:  float32(d.Seconds()),
+		Type:      typ,
+		Action:    packet.CameraShakeActionAdd,
+	})
+}
+
+// StopCameraShake stops any shaking of the camera of the client.
+func (s *Session) StopCameraShake() {
+	s.writePacket(&packet.CameraShake{Action: packet.CameraShakeActionStop})
+}
+
+// LockInput locks the camera rotation and/or the movement of the client. Passing false for both unlocks them.
+func (s *Session) LockInput(camera, movement bool) {
+	if s == Nop {
+		return
+	}
+	var locks uint32
+	if camera {
+		locks |= packet.ClientInputLockCamera
+	}
+	if movement {
+		locks |= packet.ClientInputLockMovement
+	}
+	s.writePacket(&packet.UpdateClientInputLocks{Locks: locks, Position: vec64To32(s.c.Position())})
+}
@@ -11,9 +11,14 @@ import (
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
 
-// subChunkRequests is set to true to enable the sub-chunk request system. This can (likely) cause unexpected issues,
-// but also solves issues with block entities such as item frames and lecterns as of v1.19.10.
-const subChunkRequests = true
+// SetSubChunkRequests changes whether the Session uses the sub-chunk request system. If enabled, which is the
+// default, chunks are sent without their sub-chunks, which the client then requests only when it needs them.
+// This lowers bandwidth usage and join times, and solves issues with block entities such as item frames and
+// lecterns as of v1.19.10. If disabled, full chunks are sent at once. SetSubChunkRequests must be called
+// before the Session is spawned.
+func (s *Session) SetSubChunkRequests(enabled bool) {
+	s.subChunkRequests = enabled
+}
 
 // ViewChunk ...
 func (s *Session) ViewChunk(pos world.ChunkPos, c *chunk.Chunk, blockEntities map[cube.Pos]world.Block) {
@@ -46,6 +51,7 @@ func (s *Session) ViewSubChunks(center world.SubChunkPos, offsets []protocol.Sub
 			continue
 		}
 		col.Lock()
+		col.Decompress()
 		entries = append(entries, s.subChunkEntry(offset, ind, col, transaction))
 		col.Unlock()
 	}
@@ -116,12 +122,12 @@ func (s *Session) subChunkEntry(offset protocol.SubChunkOffset, ind int16, col *
 		Offset:        offset,
 	}
 	if s.conn.ClientCacheEnabled() {
-		if hash := xxhash.Sum64(serialisedSubChunk); s.trackBlob(hash, serialisedSubChunk) {
-			transaction[hash] = struct{}{}
+		hash := xxhash.Sum64(serialisedSubChunk)
+		s.trackBlob(hash, serialisedSubChunk)
+		transaction[hash] = struct{}{}
 
-			entry.BlobHash = hash
-			entry.RawPayload = blockEntityBuf.Bytes()
-		}
+		entry.BlobHash = hash
+		entry.RawPayload = blockEntityBuf.Bytes()
 	}
 	return entry
 }
@@ -129,19 +135,19 @@ func (s *Session) subChunkEntry(offset protocol.SubChunkOffset, ind int16, col *
 // sendBlobHashes sends chunk blob hashes of the data of the chunk and stores the data in a map of blobs. Only
 // data that the client doesn't yet have will be sent over the network.
 func (s *Session) sendBlobHashes(pos world.ChunkPos, c *chunk.Chunk, blockEntities map[cube.Pos]world.Block) {
-	if subChunkRequests {
+	if s.subChunkRequests {
 		biomes := chunk.EncodeBiomes(c, chunk.NetworkEncoding)
-		if hash := xxhash.Sum64(biomes); s.trackBlob(hash, biomes) {
-			s.writePacket(&packet.LevelChunk{
-				SubChunkCount:   protocol.SubChunkRequestModeLimited,
-				Position:        protocol.ChunkPos(pos),
-				HighestSubChunk: c.HighestFilledSubChunk(),
-				BlobHashes:      []uint64{hash},
-				RawPayload:      []byte{0},
-				CacheEnabled:    true,
-			})
-			return
-		}
+		hash := xxhash.Sum64(biomes)
+		s.trackBlob(hash, biomes)
+		s.writePacket(&packet.LevelChunk{
+			SubChunkCount:   protocol.SubChunkRequestModeLimited,
+			Position:        protocol.ChunkPos(pos),
+			HighestSubChunk: c.HighestFilledSubChunk(),
+			BlobHashes:      []uint64{hash},
+			RawPayload:      []byte{0},
+			CacheEnabled:    true,
+		})
+		return
 	}
 
 	var (
@@ -158,14 +164,8 @@ func (s *Session) sendBlobHashes(pos world.ChunkPos, c *chunk.Chunk, blockEntiti
 
 	s.blobMu.Lock()
 	s.openChunkTransactions = append(s.openChunkTransactions, m)
-	if l := len(s.blobs); l > 4096 {
-		s.blobMu.Unlock()
-		s.log.Errorf("player %v has too many blobs pending %v: disconnecting", s.c.Name(), l)
-		_ = s.c.Close()
-		return
-	}
 	for i := range hashes {
-		s.blobs[hashes[i]] = blobs[i]
+		s.sendEvictedBlobs(s.blobs.add(hashes[i], blobs[i]))
 	}
 	s.blobMu.Unlock()
 
@@ -191,7 +191,7 @@ func (s *Session) sendBlobHashes(pos world.ChunkPos, c *chunk.Chunk, blockEntiti
 
 // sendNetworkChunk sends a network encoded chunk to the client.
 func (s *Session) sendNetworkChunk(pos world.ChunkPos, c *chunk.Chunk, blockEntities map[cube.Pos]world.Block) {
-	if subChunkRequests {
+	if s.subChunkRequests {
 		s.writePacket(&packet.LevelChunk{
 			SubChunkCount:   protocol.SubChunkRequestModeLimited,
 			Position:        protocol.ChunkPos(pos),
@@ -227,17 +227,35 @@ func (s *Session) sendNetworkChunk(pos world.ChunkPos, c *chunk.Chunk, blockEnti
 	})
 }
 
-// trackBlob attempts to track the given blob. If the player has too many pending blobs, it returns false and closes the
-// connection.
-func (s *Session) trackBlob(hash uint64, blob []byte) bool {
+// trackBlob tracks the given blob until the client reports whether it has it cached. If the session holds too
+// many blobs as a result, the oldest ones are sent to the client straight away.
+func (s *Session) trackBlob(hash uint64, blob []byte) {
 	s.blobMu.Lock()
-	if l := len(s.blobs); l > 4096 {
-		s.blobMu.Unlock()
-		s.log.Errorf("player %v has too many blobs pending %v: disconnecting", s.c.Name(), l)
-		_ = s.c.Close()
-		return false
+	defer s.blobMu.Unlock()
+	s.sendEvictedBlobs(s.blobs.add(hash, blob))
+}
+
+// sendEvictedBlobs sends blobs evicted from the blobStore of the session to the client, so that chunks
+// referencing them can still be loaded. It assumes s.blobMu is locked upon calling.
+func (s *Session) sendEvictedBlobs(blobs []protocol.CacheBlob) {
+	if len(blobs) == 0 {
+		return
 	}
-	s.blobs[hash] = blob
-	s.blobMu.Unlock()
-	return true
+	for _, blob := range blobs {
+		s.resolveBlob(blob.Hash)
+	}
+	s.writePacket(&packet.ClientCacheMissResponse{Blobs: blobs})
+}
+
+// resolveBlob resolves a blob hash in the open chunk transactions of the session. It assumes s.blobMu is
+// locked upon calling.
+func (s *Session) resolveBlob(hash uint64) {
+	leftover := make([]map[uint64]struct{}, 0, len(s.openChunkTransactions))
+	for _, m := range s.openChunkTransactions {
+		delete(m, hash)
+		if len(m) != 0 {
+			leftover = append(leftover, m)
+		}
+	}
+	s.openChunkTransactions = leftover
 }
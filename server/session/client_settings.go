@@ -0,0 +1,60 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/player/settings"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"golang.org/x/text/language"
+	"strings"
+)
+
+// ClientSettings returns the settings of the client of the Session. These are first sent by the client when it
+// logs in, after which some of them may be changed by the client while it is connected.
+func (s *Session) ClientSettings() settings.Settings {
+	return s.clientSettings.Load()
+}
+
+// updateClientSettings changes the settings of the client using the function passed. If the settings changed
+// as a result, the Controllable of the Session is notified of the change.
+func (s *Session) updateClientSettings(f func(st *settings.Settings)) {
+	before := s.clientSettings.Load()
+	after := before
+	f(&after)
+	if after == before {
+		return
+	}
+	s.clientSettings.Store(after)
+	if s.c != nil {
+		s.c.UpdateClientSettings(before, after)
+	}
+}
+
+// clientSettingsFrom returns the initial settings.Settings of the client at the other end of the Conn passed,
+// as sent in its login.ClientData.
+func clientSettingsFrom(conn Conn) settings.Settings {
+	data := conn.ClientData()
+	lang, _ := language.Parse(strings.Replace(data.LanguageCode, "_", "-", 1))
+	profile := settings.ClassicUI()
+	if data.UIProfile == 1 {
+		profile = settings.PocketUI()
+	}
+	return settings.Settings{
+		RenderDistance: conn.ChunkRadius(),
+		Language:       lang,
+		UIProfile:      profile,
+		InputMode:      inputMode(uint32(data.CurrentInputMode)),
+		GUIScale:       data.GUIScale,
+	}
+}
+
+// inputMode converts an input mode as sent by the client to a settings.InputMode.
+func inputMode(mode uint32) settings.InputMode {
+	switch mode {
+	case packet.InputModeTouch:
+		return settings.Touch()
+	case packet.InputModeGamePad:
+		return settings.GamePad()
+	case packet.InputModeMotionController:
+		return settings.MotionController()
+	}
+	return settings.Mouse()
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
@@ -178,6 +179,8 @@ func valueToParamType(i cmd.ParamInfo, source cmd.Source) (t uint32, enum comman
 		}
 	case mgl64.Vec3:
 		return protocol.CommandArgTypePosition, enum
+	case cube.Pos:
+		return protocol.CommandArgTypeBlockPosition, enum
 	case cmd.SubCommand:
 		return 0, commandEnum{
 			Type:    "SubCommand" + i.Name,
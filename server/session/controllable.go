@@ -3,11 +3,15 @@ package session
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/ability"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/df-mc/dragonfly/server/player/gui"
+	"github.com/df-mc/dragonfly/server/player/settings"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
@@ -20,9 +24,11 @@ import (
 // Methods in Controllable will be added as Session needs them in order to handle packets.
 type Controllable interface {
 	Name() string
+	ListName() string
 	world.Entity
 	item.User
 	form.Submitter
+	gui.Viewer
 	cmd.Source
 	chat.Subscriber
 
@@ -37,6 +43,8 @@ type Controllable interface {
 	ExecuteCommand(commandLine string)
 	GameMode() world.GameMode
 	SetGameMode(mode world.GameMode)
+	Abilities() ability.Abilities
+	HasAbility(a ability.Ability) bool
 	Effects() []effect.Effect
 
 	UseItem()
@@ -49,6 +57,7 @@ type Controllable interface {
 	Drop(s item.Stack) (n int)
 	SwingArm()
 	PunchAir()
+	Emote(emote uuid.UUID)
 
 	ExperienceLevel() int
 	SetExperienceLevel(level int)
@@ -71,10 +80,16 @@ type Controllable interface {
 	StartFlying()
 	Flying() bool
 	StopFlying()
+	Sleeping() (cube.Pos, bool)
+	Wake()
+
+	SpawnPoint() cube.Pos
 	StartGliding()
 	Gliding() bool
 	StopGliding()
 	Jump()
+	Riding() (entity.Rideable, bool)
+	Dismount()
 
 	StartBreaking(pos cube.Pos, face cube.Face)
 	ContinueBreaking(face cube.Face)
@@ -89,6 +104,14 @@ type Controllable interface {
 
 	EnderChestInventory() *inventory.Inventory
 
+	// UpdateClientSettings is called when the client of the controllable changes any of its settings, such as
+	// its render distance or input mode. The settings before and after the change are passed.
+	UpdateClientSettings(before, after settings.Settings)
+
+	// ExceedPacketLimit is called when the controllable keeps sending a packet faster than its RateLimit
+	// allows. The packet passed is the name of the packet, such as "Text".
+	ExceedPacketLimit(packet string)
+
 	// UUID returns the UUID of the controllable. It must be unique for all controllable entities present in
 	// the server.
 	UUID() uuid.UUID
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
@@ -36,6 +37,8 @@ func (s *Session) parseEntityMetadata(e world.Entity) protocol.EntityMetadata {
 	s.addSpecificMetadata(e, m)
 	if ent, ok := e.(*entity.Ent); ok {
 		s.addSpecificMetadata(ent.Behaviour(), m)
+	} else if mob, ok := e.(*entity.Mob); ok {
+		s.addSpecificMetadata(mob.Behaviour(), m)
 	}
 	return m
 }
@@ -44,6 +47,9 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if sn, ok := e.(sneaker); ok && sn.Sneaking() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSneaking)
 	}
+	if b, ok := e.(blocker); ok && b.Blocking() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagBlocking)
+	}
 	if sp, ok := e.(sprinter); ok && sp.Sprinting() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSprinting)
 	}
@@ -53,6 +59,13 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if gl, ok := e.(glider); ok && gl.Gliding() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagGliding)
 	}
+	if sl, ok := e.(sleeper); ok {
+		if pos, sleeping := sl.Sleeping(); sleeping {
+			// The sleeping flag of players is the second bit of the player flags.
+			m.SetFlag(protocol.EntityDataKeyPlayerFlags, 1)
+			m[protocol.EntityDataKeyBedPosition] = protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+		}
+	}
 	if b, ok := e.(breather); ok {
 		m[protocol.EntityDataKeyAirSupply] = int16(b.AirSupply().Milliseconds() / 50)
 		m[protocol.EntityDataKeyAirSupplyMax] = int16(b.MaxAirSupply().Milliseconds() / 50)
@@ -66,6 +79,9 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if i, ok := e.(immobile); ok && i.Immobile() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagNoAI)
 	}
+	if f, ok := e.(freezer); ok && f.FrozenDuration() > 0 {
+		m[protocol.EntityDataKeyFreezingEffectStrength] = float32(f.FrozenDuration()) / float32(time.Second*7)
+	}
 	if o, ok := e.(onFire); ok && o.OnFireDuration() > 0 {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagOnFire)
 	}
@@ -75,6 +91,12 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if c, ok := e.(arrow); ok && c.Critical() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagCritical)
 	}
+	if t, ok := e.(trident); ok && t.Returning() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagReturnTrident)
+	}
+	if r, ok := e.(riptider); ok && r.Riptiding() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagDamageNearbyMobs)
+	}
 	if g, ok := e.(gameMode); ok {
 		if g.GameMode().HasCollision() {
 			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagHasCollision)
@@ -91,9 +113,33 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 		if o, ok := e.(owned); ok && f.Attached() {
 			m[protocol.EntityDataKeyCustomDisplay] = int64(s.entityRuntimeID(o.Owner()))
 		}
-	} else if o, ok := e.(owned); ok {
+	} else if o, ok := e.(owned); ok && o.Owner() != nil {
 		m[protocol.EntityDataKeyOwner] = int64(s.entityRuntimeID(o.Owner()))
 	}
+	if t, ok := e.(tameable); ok && t.Tamed() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagTamed)
+	}
+	if st, ok := e.(sitter); ok && st.Sitting() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSitting)
+	}
+	if c, ok := e.(collared); ok {
+		if colour, ok := c.Collar(); ok {
+			m[protocol.EntityDataKeyColorIndex] = colour.Uint8()
+		}
+	}
+	if sd, ok := e.(saddled); ok && sd.Saddled() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSaddled)
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagPowerJump)
+	}
+	if r, ok := e.(rider); ok {
+		if vehicle, ok := r.Riding(); ok {
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagRiding)
+			m[protocol.EntityDataKeySeatOffset] = vec64To32(vehicle.SeatOffset())
+		}
+	}
+	if b, ok := e.(baby); ok && b.Baby() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagBaby)
+	}
 	if sc, ok := e.(scaled); ok {
 		m[protocol.EntityDataKeyScale] = float32(sc.Scale())
 	}
@@ -164,12 +210,20 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if mv, ok := e.(markVariable); ok {
 		m[protocol.EntityDataKeyMarkVariant] = mv.MarkVariant()
 	}
+	if t, ok := e.(trader); ok {
+		m[protocol.EntityDataKeyTradeTier] = int32(t.Trades().Tier())
+		m[protocol.EntityDataKeyTradeExperience] = int32(t.Trades().Experience())
+	}
 }
 
 type sneaker interface {
 	Sneaking() bool
 }
 
+type blocker interface {
+	Blocking() bool
+}
+
 type sprinter interface {
 	Sprinting() bool
 }
@@ -182,6 +236,14 @@ type glider interface {
 	Gliding() bool
 }
 
+type freezer interface {
+	FrozenDuration() time.Duration
+}
+
+type sleeper interface {
+	Sleeping() (cube.Pos, bool)
+}
+
 type breather interface {
 	Breathing() bool
 	AirSupply() time.Duration
@@ -196,6 +258,30 @@ type invisible interface {
 	Invisible() bool
 }
 
+type tameable interface {
+	Tamed() bool
+}
+
+type sitter interface {
+	Sitting() bool
+}
+
+type collared interface {
+	Collar() (item.Colour, bool)
+}
+
+type saddled interface {
+	Saddled() bool
+}
+
+type rider interface {
+	Riding() (entity.Rideable, bool)
+}
+
+type baby interface {
+	Baby() bool
+}
+
 type scaled interface {
 	Scale() float64
 }
@@ -241,6 +327,14 @@ type arrow interface {
 	Critical() bool
 }
 
+type trident interface {
+	Returning() bool
+}
+
+type riptider interface {
+	Riptiding() bool
+}
+
 type orb interface {
 	Experience() int
 }
@@ -269,3 +363,7 @@ type variable interface {
 type markVariable interface {
 	MarkVariant() int32
 }
+
+type trader interface {
+	Trades() *entity.TradeList
+}
@@ -20,16 +20,11 @@ const (
 // menu. It also contains information such as the new name of the item and the multi-recipe network ID.
 func (h *ItemStackRequestHandler) handleCraftRecipeOptional(a *protocol.CraftRecipeOptionalStackRequestAction, s *Session, filterStrings []string) (err error) {
 	// First check if there actually is an anvil opened.
-	if !s.containerOpened.Load() {
-		return fmt.Errorf("no anvil container opened")
-	}
-
-	w := s.c.World()
-	pos := s.openedPos.Load()
-	anvil, ok := w.Block(pos).(block.Anvil)
+	anvil, win, ok := openedBlock[block.Anvil](s)
 	if !ok {
 		return fmt.Errorf("no anvil container opened")
 	}
+	w, pos := s.c.World(), win.pos
 	if len(filterStrings) < int(a.FilterStringIndex) {
 		return fmt.Errorf("filter string index %v is out of bounds", a.FilterStringIndex)
 	}
@@ -19,11 +19,7 @@ func (h *ItemStackRequestHandler) handleBeaconPayment(a *protocol.BeaconPaymentS
 		Slot:        beaconInputSlot,
 	}
 	// First check if there actually is a beacon opened.
-	if !s.containerOpened.Load() {
-		return fmt.Errorf("no beacon container opened")
-	}
-	pos := s.openedPos.Load()
-	beacon, ok := s.c.World().Block(pos).(block.Beacon)
+	beacon, w, ok := openedBlock[block.Beacon](s)
 	if !ok {
 		return fmt.Errorf("no beacon container opened")
 	}
@@ -49,7 +45,7 @@ func (h *ItemStackRequestHandler) handleBeaconPayment(a *protocol.BeaconPaymentS
 	if sOk {
 		beacon.Secondary = secondary.(effect.LastingType)
 	}
-	s.c.World().SetBlock(pos, beacon, nil)
+	s.c.World().SetBlock(w.pos, beacon, nil)
 
 	// The client will send a Destroy action after this action, but we can't rely on that because the client
 	// could just not send it.
@@ -17,11 +17,11 @@ func (c *ClientCacheBlobStatusHandler) Handle(p packet.Packet, s *Session) error
 
 	s.blobMu.Lock()
 	for _, hit := range pk.HitHashes {
-		delete(s.blobs, hit)
-		c.resolveBlob(hit, s)
+		s.blobs.take(hit)
+		s.resolveBlob(hit)
 	}
 	for _, miss := range pk.MissHashes {
-		blob, ok := s.blobs[miss]
+		blob, ok := s.blobs.take(miss)
 		if !ok {
 			// This is expected to happen sometimes, for example when we send the same block storage or biomes a lot of
 			// times in a short timeframe. There is no need to log this, it'll just cause unnecessary noise that doesn't
@@ -29,7 +29,7 @@ func (c *ClientCacheBlobStatusHandler) Handle(p packet.Packet, s *Session) error
 			continue
 		}
 		resp.Blobs = append(resp.Blobs, protocol.CacheBlob{Hash: miss, Payload: blob})
-		c.resolveBlob(miss, s)
+		s.resolveBlob(miss)
 	}
 	s.blobMu.Unlock()
 
@@ -38,16 +38,3 @@ func (c *ClientCacheBlobStatusHandler) Handle(p packet.Packet, s *Session) error
 	}
 	return nil
 }
-
-// resolveBlob resolves a blob hash in the session passed. It assumes s.blobMu is locked upon calling.
-func (c *ClientCacheBlobStatusHandler) resolveBlob(hash uint64, s *Session) {
-	leftover := make([]map[uint64]struct{}, 0, len(s.openChunkTransactions))
-	for _, m := range s.openChunkTransactions {
-		delete(m, hash)
-		if len(m) != 0 {
-			leftover = append(leftover, m)
-		}
-	}
-	s.openChunkTransactions = leftover
-	delete(s.blobs, hash)
-}
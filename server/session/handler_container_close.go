@@ -18,7 +18,7 @@ func (h *ContainerCloseHandler) Handle(p packet.Packet, s *Session) error {
 		// Closing of the normal inventory.
 		s.writePacket(&packet.ContainerClose{WindowID: 0})
 		s.invOpened = false
-	case byte(s.openedWindowID.Load()):
+	case byte(s.windowID.Load()):
 		s.closeCurrentContainer()
 	case 0xff:
 		// TODO: Handle closing the crafting grid.
@@ -168,17 +168,17 @@ func (h *ItemStackRequestHandler) handleCreativeCraft(a *protocol.CraftCreativeS
 	return h.createResults(s, it)
 }
 
-// craftingSize gets the crafting size based on the opened container ID.
+// craftingSize gets the crafting size based on the type of the opened window.
 func (s *Session) craftingSize() uint32 {
-	if s.openedContainerID.Load() == 1 {
+	if w, ok := s.currentWindow(); ok && w.containerType == protocol.ContainerTypeWorkbench {
 		return craftingGridSizeLarge
 	}
 	return craftingGridSizeSmall
 }
 
-// craftingOffset gets the crafting offset based on the opened container ID.
+// craftingOffset gets the crafting offset based on the type of the opened window.
 func (s *Session) craftingOffset() uint32 {
-	if s.openedContainerID.Load() == 1 {
+	if w, ok := s.currentWindow(); ok && w.containerType == protocol.ContainerTypeWorkbench {
 		return craftingGridLargeOffset
 	}
 	return craftingGridSmallOffset
@@ -26,8 +26,6 @@ func (h *EmoteHandler) Handle(p packet.Packet, s *Session) error {
 	if err != nil {
 		return err
 	}
-	for _, viewer := range s.c.World().Viewers(s.c.Position()) {
-		viewer.ViewEmote(s.c, emote)
-	}
+	s.c.Emote(emote)
 	return nil
 }
@@ -38,7 +38,11 @@ func (h *ItemStackRequestHandler) handleEnchant(a *protocol.CraftRecipeStackRequ
 	}
 
 	// Determine the available enchantments using the session's enchantment seed.
-	allCosts, allEnchants := s.determineAvailableEnchantments(s.c.World(), s.openedPos.Load(), input)
+	_, w, ok := openedBlock[block.EnchantingTable](s)
+	if !ok {
+		return fmt.Errorf("no enchanting table opened")
+	}
+	allCosts, allEnchants := s.determineAvailableEnchantments(s.c.World(), w.pos, input)
 	if len(allEnchants) == 0 {
 		return fmt.Errorf("can't enchant non-enchantable item")
 	}
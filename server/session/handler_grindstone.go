@@ -22,10 +22,7 @@ const (
 // handleGrindstoneCraft handles a CraftGrindstoneRecipe stack request action made using a grindstone.
 func (h *ItemStackRequestHandler) handleGrindstoneCraft(s *Session) error {
 	// First check if there actually is a grindstone opened.
-	if !s.containerOpened.Load() {
-		return fmt.Errorf("no grindstone container opened")
-	}
-	if _, ok := s.c.World().Block(s.openedPos.Load()).(block.Grindstone); !ok {
+	if _, _, ok := openedBlock[block.Grindstone](s); !ok {
 		return fmt.Errorf("no grindstone container opened")
 	}
 
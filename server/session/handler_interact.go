@@ -2,6 +2,7 @@ package session
 
 import (
 	"fmt"
+	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
@@ -17,7 +18,18 @@ func (h *InteractHandler) Handle(p packet.Packet, s *Session) error {
 	switch pk.ActionType {
 	case packet.InteractActionMouseOverEntity:
 		// We don't need this action.
+	case packet.InteractActionLeaveVehicle:
+		s.c.Dismount()
 	case packet.InteractActionOpenInventory:
+		if e, ok := s.c.Riding(); ok {
+			if m, ok := e.(*entity.Mob); ok {
+				if h, ok := m.Behaviour().(*entity.HorseBehaviour); ok && h.OwnerUUID() == s.c.UUID() {
+					// Opening the inventory while riding a tamed horse opens the inventory of the horse.
+					s.OpenHorseInventory(m, h.Inventory())
+					return nil
+				}
+			}
+		}
 		if s.invOpened {
 			// When there is latency, this might end up being sent multiple times. If we send a ContainerOpen
 			// multiple times, the client crashes.
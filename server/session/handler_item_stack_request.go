@@ -7,6 +7,7 @@ import (
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
@@ -64,6 +65,13 @@ func (h *ItemStackRequestHandler) Handle(p packet.Packet, s *Session) error {
 // handleRequest resolves a single item stack request from the client.
 func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s *Session) (err error) {
 	h.currentRequest = req.RequestID
+	if slot, ok := menuSlot(req, s); ok {
+		// Items in a menu may never be moved. The request is rejected so that the client reverts the change,
+		// and the button in the slot is clicked instead.
+		h.reject(req.RequestID, s)
+		s.clickMenu(slot)
+		return nil
+	}
 	defer func() {
 		if err != nil {
 			h.reject(req.RequestID, s)
@@ -88,9 +96,13 @@ func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s
 		case *protocol.BeaconPaymentStackRequestAction:
 			err = h.handleBeaconPayment(a, s)
 		case *protocol.CraftRecipeStackRequestAction:
-			if s.containerOpened.Load() {
+			if t := s.openedTrade(); t != nil {
+				err = h.handleTrade(a, s, t)
+				break
+			}
+			if b, _, ok := openedBlock[world.Block](s); ok {
 				var special bool
-				switch s.c.World().Block(s.openedPos.Load()).(type) {
+				switch b.(type) {
 				case block.SmithingTable:
 					err, special = h.handleSmithing(a, s), true
 				case block.Stonecutter:
@@ -207,13 +219,11 @@ func (h *ItemStackRequestHandler) handleSwap(a *protocol.SwapStackRequestAction,
 // collectRewards checks if the source inventory has rewards for the player, for example, experience rewards when
 // smelting. If it does, it will drop the rewards at the player's location.
 func (h *ItemStackRequestHandler) collectRewards(s *Session, inv *inventory.Inventory, slot int) {
-	w := s.c.World()
-	if inv == s.openedWindow.Load() && s.containerOpened.Load() && slot == inv.Size()-1 {
-		if f, ok := w.Block(s.openedPos.Load()).(smelter); ok {
-			for _, o := range entity.NewExperienceOrbs(entity.EyePosition(s.c), f.ResetExperience()) {
-				o.SetVelocity(mgl64.Vec3{(rand.Float64()*0.2 - 0.1) * 2, rand.Float64() * 0.4, (rand.Float64()*0.2 - 0.1) * 2})
-				w.AddEntity(o)
-			}
+	if f, win, ok := openedBlock[smelter](s); ok && inv == win.inv && slot == inv.Size()-1 {
+		w := s.c.World()
+		for _, o := range entity.NewExperienceOrbs(entity.EyePosition(s.c), f.ResetExperience()) {
+			o.SetVelocity(mgl64.Vec3{(rand.Float64()*0.2 - 0.1) * 2, rand.Float64() * 0.4, (rand.Float64()*0.2 - 0.1) * 2})
+			w.AddEntity(o)
 		}
 	}
 }
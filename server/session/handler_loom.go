@@ -19,10 +19,7 @@ const (
 // handleLoomCraft handles a CraftLoomRecipe stack request action made using a loom table.
 func (h *ItemStackRequestHandler) handleLoomCraft(a *protocol.CraftLoomRecipeStackRequestAction, s *Session) error {
 	// First check if there actually is a loom opened.
-	if !s.containerOpened.Load() {
-		return fmt.Errorf("no loom container opened")
-	}
-	if _, ok := s.c.World().Block(s.openedPos.Load()).(block.Loom); !ok {
+	if _, _, ok := openedBlock[block.Loom](s); !ok {
 		return fmt.Errorf("no loom container opened")
 	}
 
@@ -0,0 +1,18 @@
+package session
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// PassengerJumpHandler handles the PassengerJump packet.
+type PassengerJumpHandler struct{}
+
+// Handle ...
+func (h PassengerJumpHandler) Handle(p packet.Packet, s *Session) error {
+	pk := p.(*packet.PassengerJump)
+	if e, ok := s.c.Riding(); ok {
+		// The jump strength is sent as a percentage of the maximum strength.
+		e.Jump(float64(pk.JumpStrength) / 100)
+	}
+	return nil
+}
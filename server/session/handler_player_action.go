@@ -2,6 +2,7 @@ package session
 
 import (
 	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
@@ -23,14 +24,17 @@ func handlePlayerAction(action int32, face int32, pos protocol.BlockPos, entityR
 		return errSelfRuntimeID
 	}
 	switch action {
-	case protocol.PlayerActionRespawn, protocol.PlayerActionDimensionChangeDone:
-		// Don't do anything for these actions.
+	case protocol.PlayerActionRespawn:
+		// Don't do anything for this action.
+	case protocol.PlayerActionDimensionChangeDone:
+		s.finishDimensionChange()
 	case protocol.PlayerActionStopSleeping:
 		if mode := s.c.GameMode(); !mode.Visible() && !mode.HasCollision() {
 			// As of v1.19.50, the client sends this packet when switching to spectator mode... even if it wasn't
 			// sleeping in the first place. This accounts for that.
 			return nil
 		}
+		s.c.Wake()
 	case protocol.PlayerActionStartBreak, protocol.PlayerActionContinueDestroyBlock:
 		s.swingingArm.Store(true)
 		defer s.swingingArm.Store(false)
@@ -72,3 +76,26 @@ func handlePlayerAction(action int32, face int32, pos protocol.BlockPos, entityR
 	}
 	return nil
 }
+
+// breakBlock handles a request of the client to break the block at the position passed. Outside of creative
+// mode, the block is only broken if the player has been breaking it for long enough, which is validated by
+// Controllable.FinishBreaking.
+func (s *Session) breakBlock(pos cube.Pos) {
+	if s.c.GameMode().CreativeInventory() {
+		s.c.BreakBlock(pos)
+		return
+	}
+	held, _ := s.c.HeldItems()
+	if block.BreakDuration(s.c.World().Block(pos), held) == 0 {
+		// Blocks that break instantly, such as flowers, may be broken without the client ever starting to
+		// break them.
+		s.c.BreakBlock(pos)
+		return
+	}
+	if pos != s.breakingPos {
+		// The client never started breaking this block, so it can't have finished breaking it either.
+		s.ViewBlockUpdate(pos, s.c.World().Block(pos), 0)
+		return
+	}
+	s.c.FinishBreaking()
+}
@@ -3,6 +3,7 @@ package session
 import (
 	"fmt"
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player/settings"
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
@@ -16,6 +17,12 @@ type PlayerAuthInputHandler struct{}
 // Handle ...
 func (h PlayerAuthInputHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.PlayerAuthInput)
+	s.moveInput.Store(mgl64.Vec2{float64(pk.MoveVector[0]), float64(pk.MoveVector[1])})
+	if mode := inputMode(pk.InputMode); mode != s.ClientSettings().InputMode {
+		s.updateClientSettings(func(st *settings.Settings) {
+			st.InputMode = mode
+		})
+	}
 	if err := h.handleMovement(pk, s); err != nil {
 		return err
 	}
@@ -144,7 +151,7 @@ func (h PlayerAuthInputHandler) handleUseItemData(data protocol.UseItemTransacti
 	// Seems like this is only used for breaking blocks at the moment.
 	switch data.ActionType {
 	case protocol.UseItemActionBreakBlock:
-		s.c.BreakBlock(pos)
+		s.breakBlock(pos)
 	default:
 		return fmt.Errorf("unhandled UseItem ActionType for PlayerAuthInput packet %v", data.ActionType)
 	}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/player/ability"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
 
@@ -11,7 +12,7 @@ type RequestAbilityHandler struct{}
 func (a RequestAbilityHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.RequestAbility)
 	if pk.Ability == packet.AbilityFlying {
-		if !s.c.GameMode().AllowsFlying() {
+		if !s.c.HasAbility(ability.MayFly) {
 			s.log.Debugf("failed processing packet from %v (%v): RequestAbility: flying flag enabled while not being able to fly\n", s.conn.RemoteAddr(), s.c.Name())
 			s.sendAbilities()
 			return nil
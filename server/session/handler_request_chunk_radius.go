@@ -1,6 +1,7 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/player/settings"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
 
@@ -11,13 +12,10 @@ type RequestChunkRadiusHandler struct{}
 func (*RequestChunkRadiusHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.RequestChunkRadius)
 
-	if pk.ChunkRadius > s.maxChunkRadius {
-		pk.ChunkRadius = s.maxChunkRadius
-	}
-	s.chunkRadius = pk.ChunkRadius
-
-	s.chunkLoader.ChangeRadius(int(pk.ChunkRadius))
-
-	s.writePacket(&packet.ChunkRadiusUpdated{ChunkRadius: s.chunkRadius})
+	s.requestedChunkRadius.Store(pk.ChunkRadius)
+	s.changeChunkRadius(pk.ChunkRadius)
+	s.updateClientSettings(func(st *settings.Settings) {
+		st.RenderDistance = int(pk.ChunkRadius)
+	})
 	return nil
 }
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"fmt"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
@@ -12,6 +13,13 @@ type SubChunkRequestHandler struct{}
 // Handle ...
 func (*SubChunkRequestHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.SubChunkRequest)
+	if !s.subChunkRequests {
+		return fmt.Errorf("sub-chunk requests are disabled")
+	}
+	if dim, _ := world.DimensionID(s.c.World().Dimension()); pk.Dimension != int32(dim) {
+		// The client may still request sub-chunks of the dimension it was in before changing dimensions.
+		return nil
+	}
 	s.ViewSubChunks(world.SubChunkPos(pk.Position), pk.Offsets)
 	return nil
 }
@@ -0,0 +1,146 @@
+package session
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"math/rand"
+)
+
+const (
+	// tradeFirstInputSlot is the slot index of the first input item in the trading window.
+	tradeFirstInputSlot = 0x04
+	// tradeSecondInputSlot is the slot index of the second input item in the trading window.
+	tradeSecondInputSlot = 0x05
+)
+
+// trade holds the entity that a Session is trading with and its entity.TradeList.
+type trade struct {
+	e    world.Entity
+	l    *entity.TradeList
+	tier int
+}
+
+// OpenTrading opens the trading window of the entity passed for the Session, showing the offers in the
+// entity.TradeList passed.
+func (s *Session) OpenTrading(e world.Entity, l *entity.TradeList) {
+	nextID := s.openWindow(&window{containerType: protocol.ContainerTypeTrade, entity: e, trade: &trade{e: e, l: l, tier: l.Tier()}})
+	s.sendTrades(nextID, e, l)
+}
+
+// sendTrades sends the offers of an entity.TradeList to the client in the window with the ID passed. Sending
+// the offers opens the trading window if it was not yet opened.
+func (s *Session) sendTrades(windowID byte, e world.Entity, l *entity.TradeList) {
+	offers := l.Offers()
+	recipes := make([]any, 0, len(offers))
+	for i, o := range offers {
+		price := o.Price()
+		recipe := map[string]any{
+			"buyA":             nbtconv.WriteItem(price, false),
+			"buyCountA":        int32(price.Count()),
+			"sell":             nbtconv.WriteItem(o.Sell, false),
+			"uses":             int32(o.Uses),
+			"maxUses":          int32(o.MaxUses),
+			"demand":           int32(0),
+			"priceMultiplierA": float32(o.PriceMultiplier),
+			"priceMultiplierB": float32(0),
+			"tier":             int32(o.Tier),
+			"traderExp":        int32(o.TraderExperience),
+			"rewardExp":        boolByte(o.RewardExperience),
+			"netId":            int32(i + 1),
+		}
+		if o.MaxUses == 0 {
+			// Offers without a maximum amount of uses are never disabled.
+			recipe["maxUses"] = int32(o.Uses + 1)
+		}
+		if !o.BuyB.Empty() {
+			recipe["buyB"] = nbtconv.WriteItem(o.BuyB, false)
+			recipe["buyCountB"] = int32(o.BuyB.Count())
+		}
+		recipes = append(recipes, recipe)
+	}
+	requirements := make([]any, 0, len(entity.TradeTierExperience))
+	for tier, exp := range entity.TradeTierExperience {
+		requirements = append(requirements, map[string]any{fmt.Sprint(tier): int32(exp)})
+	}
+	buf := bytes.NewBuffer(nil)
+	_ = nbt.NewEncoderWithEncoding(buf, nbt.NetworkLittleEndian).Encode(map[string]any{
+		"Recipes":             recipes,
+		"TierExpRequirements": requirements,
+	})
+	s.writePacket(&packet.UpdateTrade{
+		WindowID:         windowID,
+		WindowType:       protocol.ContainerTypeTrade,
+		TradeTier:        int32(l.Tier()),
+		VillagerUniqueID: int64(s.entityRuntimeID(e)),
+		EntityUniqueID:   selfEntityRuntimeID,
+		DisplayName:      l.Name(),
+		NewTradeUI:       true,
+		SerialisedOffers: buf.Bytes(),
+	})
+}
+
+// handleTrade handles a CraftRecipe stack request action made using a trading window. The network ID of the
+// recipe is the index of the offer in the entity.TradeList plus one.
+func (h *ItemStackRequestHandler) handleTrade(a *protocol.CraftRecipeStackRequestAction, s *Session, t *trade) error {
+	first, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
+		ContainerID: protocol.ContainerTradeTwoIngredientOne,
+		Slot:        tradeFirstInputSlot,
+	}, s)
+	secondInput, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
+		ContainerID: protocol.ContainerTradeTwoIngredientTwo,
+		Slot:        tradeSecondInputSlot,
+	}, s)
+
+	// The offer is validated and used under the same lock, so that it cannot be changed in between by a call
+	// to SetOffers.
+	var (
+		err           error
+		price, second item.Stack
+	)
+	o, ok := t.l.TradeIf(int(a.RecipeNetworkID)-1, func(o entity.TradeOffer) bool {
+		price, second = o.Price(), o.BuyB
+		if !matchingStacks(first, price) || first.Count() < price.Count() {
+			err = fmt.Errorf("first input item is not the same as the price of the offer")
+		} else if !second.Empty() && (!matchingStacks(secondInput, second) || secondInput.Count() < second.Count()) {
+			err = fmt.Errorf("second input item is not the same as the second price of the offer")
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("trade offer with network id %v does not exist or is not available", a.RecipeNetworkID)
+	}
+
+	h.setItemInSlot(protocol.StackRequestSlotInfo{
+		ContainerID: protocol.ContainerTradeTwoIngredientOne,
+		Slot:        tradeFirstInputSlot,
+	}, first.Grow(-price.Count()), s)
+	if !second.Empty() {
+		h.setItemInSlot(protocol.StackRequestSlotInfo{
+			ContainerID: protocol.ContainerTradeTwoIngredientTwo,
+			Slot:        tradeSecondInputSlot,
+		}, secondInput.Grow(-second.Count()), s)
+	}
+	if o.RewardExperience {
+		w := s.c.World()
+		for _, orb := range entity.NewExperienceOrbs(t.e.Position(), rand.Intn(4)+3) {
+			w.AddEntity(orb)
+		}
+	}
+	if tier := t.l.Tier(); tier != t.tier {
+		// The trader reached a new tier, so the offers unlocked must be shown to the client.
+		t.tier = tier
+		if w, ok := s.currentWindow(); ok {
+			s.sendTrades(w.id, t.e, t.l)
+		}
+	}
+	return h.createResults(s, o.Sell)
+}
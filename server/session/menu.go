@@ -0,0 +1,146 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/gui"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// menu holds a gui.Menu opened by a Session, together with the positions of the fake blocks sent to the client
+// to display it in.
+type menu struct {
+	m   gui.Menu
+	pos []cube.Pos
+}
+
+// OpenMenu opens the gui.Menu passed for the Session. The menu is displayed in a fake container block that is
+// sent to the client just above the head of the player and removed again once the menu is closed. If a menu
+// with the same type and name is already opened, only its contents are replaced, so that switching between
+// pages of a menu does not close and reopen the window.
+func (s *Session) OpenMenu(m gui.Menu) {
+	if s == Nop {
+		return
+	}
+	if w, ok := s.currentWindow(); ok && w.menu != nil && w.menu.m.Type() == m.Type() && w.menu.m.Name() == m.Name() {
+		s.openedWindow.Store(&window{id: w.id, containerType: w.containerType, inv: w.inv, pos: w.pos, menu: &menu{m: m, pos: w.menu.pos}})
+		s.sendMenuItems(m)
+		return
+	}
+	s.closeCurrentContainer()
+
+	w := s.c.World()
+	pos := cube.PosFromVec3(s.c.Position()).Add(cube.Pos{0, 2})
+	if pos[1] > w.Range().Max() {
+		pos[1] -= 4
+	}
+	positions := []cube.Pos{pos}
+	if m.Type() == gui.DoubleChest() {
+		positions = append(positions, pos.Side(cube.FaceEast))
+	}
+
+	var (
+		b             world.Block = block.NewChest()
+		id                        = "Chest"
+		containerType byte        = protocol.ContainerTypeContainer
+	)
+	if m.Type() == gui.Hopper() {
+		b, _ = world.DefaultBlock("minecraft:hopper")
+		id, containerType = "Hopper", protocol.ContainerTypeHopper
+	}
+	for i, p := range positions {
+		blockPos := protocol.BlockPos{int32(p[0]), int32(p[1]), int32(p[2])}
+		s.writePacket(&packet.UpdateBlock{
+			Position:          blockPos,
+			NewBlockRuntimeID: world.BlockRuntimeID(b),
+			Flags:             packet.BlockUpdateNetwork,
+		})
+		data := map[string]any{"id": id, "x": blockPos[0], "y": blockPos[1], "z": blockPos[2]}
+		if m.Name() != "" {
+			data["CustomName"] = m.Name()
+		}
+		if len(positions) == 2 {
+			// Both halves of a double chest point to each other so that the client shows them as one chest.
+			pair := positions[1-i]
+			data["pairx"], data["pairz"], data["pairlead"] = int32(pair[0]), int32(pair[2]), boolByte(i == 0)
+		}
+		s.writePacket(&packet.BlockActorData{Position: blockPos, NBTData: data})
+	}
+
+	s.openWindow(&window{
+		containerType: containerType,
+		inv:           inventory.New(m.Type().Size(), nil),
+		pos:           pos,
+		menu:          &menu{m: m, pos: positions},
+	})
+	s.sendMenuItems(m)
+}
+
+// CloseMenu closes the gui.Menu currently opened by the Session. CloseMenu does nothing if the Session has no
+// menu opened.
+func (s *Session) CloseMenu() {
+	if s.openedMenu() != nil {
+		s.closeCurrentContainer()
+	}
+}
+
+// sendMenuItems sends the items of the gui.Menu passed to the client in the window currently opened.
+func (s *Session) sendMenuItems(m gui.Menu) {
+	w, ok := s.currentWindow()
+	if !ok {
+		return
+	}
+	for slot, it := range m.Items() {
+		_ = w.inv.SetItem(slot, it)
+	}
+	s.sendInv(w.inv, uint32(w.id))
+}
+
+// closeMenu restores the blocks that were replaced client-side to display the menu passed and calls the close
+// function of the menu. closeMenu is called after the window of the menu has been closed.
+func (s *Session) closeMenu(m *menu) {
+	w := s.c.World()
+	for _, pos := range m.pos {
+		s.ViewBlockUpdate(pos, w.Block(pos), 0)
+	}
+	m.m.Close(s.c)
+}
+
+// clickMenu clicks the slot passed in the gui.Menu currently opened by the Session.
+func (s *Session) clickMenu(slot int) {
+	if m := s.openedMenu(); m != nil {
+		m.m.Click(s.c, slot)
+	}
+}
+
+// menuSlot returns the slot of the gui.Menu opened by the Session that the actions of the item stack request
+// passed attempt to change. False is returned if no menu is opened or if the request does not touch it.
+func menuSlot(req protocol.ItemStackRequest, s *Session) (int, bool) {
+	if s.openedMenu() == nil {
+		return 0, false
+	}
+	for _, action := range req.Actions {
+		var slots []protocol.StackRequestSlotInfo
+		switch a := action.(type) {
+		case *protocol.TakeStackRequestAction:
+			slots = []protocol.StackRequestSlotInfo{a.Source, a.Destination}
+		case *protocol.PlaceStackRequestAction:
+			slots = []protocol.StackRequestSlotInfo{a.Source, a.Destination}
+		case *protocol.SwapStackRequestAction:
+			slots = []protocol.StackRequestSlotInfo{a.Source, a.Destination}
+		case *protocol.DropStackRequestAction:
+			slots = []protocol.StackRequestSlotInfo{a.Source}
+		case *protocol.DestroyStackRequestAction:
+			slots = []protocol.StackRequestSlotInfo{a.Source}
+		}
+		for _, slot := range slots {
+			if slot.ContainerID == protocol.ContainerLevelEntity {
+				return int(slot.Slot), true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,43 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// PacketHandler handles the packets sent and received by a Session. It may be used to observe packets, or to
+// modify or cancel them before they are handled by the Session or sent to the client. Packets are passed
+// after they are decoded and before they are encoded, so they may be type asserted to their concrete type.
+type PacketHandler interface {
+	// HandleClientPacket handles a packet received from the client. ctx.Cancel() may be called to prevent the
+	// Session from handling the packet. HandleClientPacket is called on the goroutine reading packets of the
+	// Session, so packets are handled in the order they arrive.
+	HandleClientPacket(ctx *event.Context, pk packet.Packet)
+	// HandleServerPacket handles a packet about to be sent to the client. ctx.Cancel() may be called to
+	// prevent the packet from being sent. HandleServerPacket may be called from any goroutine.
+	HandleServerPacket(ctx *event.Context, pk packet.Packet)
+}
+
+// NopPacketHandler implements the PacketHandler interface but does not execute any code when a packet is
+// sent or received. User handlers may embed NopPacketHandler to avoid having to implement each method.
+type NopPacketHandler struct{}
+
+// Compile time check to make sure NopPacketHandler implements PacketHandler.
+var _ PacketHandler = NopPacketHandler{}
+
+func (NopPacketHandler) HandleClientPacket(*event.Context, packet.Packet) {}
+func (NopPacketHandler) HandleServerPacket(*event.Context, packet.Packet) {}
+
+// HandlePackets sets the PacketHandler of the Session, which is called for every packet sent and received.
+// Passing nil resets the PacketHandler to a NopPacketHandler.
+func (s *Session) HandlePackets(h PacketHandler) {
+	if h == nil {
+		h = NopPacketHandler{}
+	}
+	s.packetHandler.Store(h)
+}
+
+// packetHandlerOf returns the PacketHandler of the Session.
+func (s *Session) packetHandlerOf() PacketHandler {
+	return s.packetHandler.Load()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/df-mc/atomic"
 	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
@@ -12,6 +13,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item/creative"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/player/ability"
 	"github.com/df-mc/dragonfly/server/player/form"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/world"
@@ -47,18 +49,26 @@ func (s *Session) StartShowingEntity(e world.Entity) {
 
 // closeCurrentContainer closes the container the player might currently have open.
 func (s *Session) closeCurrentContainer() {
-	if !s.containerOpened.Load() {
+	win, ok := s.closeWindow()
+	if !ok {
+		return
+	}
+	if win.menu != nil {
+		s.closeMenu(win.menu)
+		return
+	}
+	if win.entity != nil {
+		// Trading windows and horse inventories are not opened at a position, so there is no block to remove the
+		// viewer from.
 		return
 	}
-	s.closeWindow()
 
-	pos := s.openedPos.Load()
 	w := s.c.World()
-	b := w.Block(pos)
+	b := w.Block(win.pos)
 	if container, ok := b.(block.Container); ok {
-		container.RemoveViewer(s, w, pos)
+		container.RemoveViewer(s, w, win.pos)
 	} else if enderChest, ok := b.(block.EnderChest); ok {
-		enderChest.RemoveViewer(w, pos)
+		enderChest.RemoveViewer(w, win.pos)
 	}
 }
 
@@ -91,6 +101,19 @@ func (s *Session) SendRespawn(pos mgl64.Vec3) {
 	})
 }
 
+// SendSpawnPoint sends the spawn point of the Controllable entity of the session to the client. Unlike the world
+// spawn, sent in ViewWorldSpawn, the spawn point of the player does not change the position that compasses point to.
+func (s *Session) SendSpawnPoint(pos cube.Pos, dim world.Dimension) {
+	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+	id, _ := world.DimensionID(dim)
+	s.writePacket(&packet.SetSpawnPosition{
+		SpawnType:     packet.SpawnTypePlayer,
+		Position:      blockPos,
+		Dimension:     int32(id),
+		SpawnPosition: blockPos,
+	})
+}
+
 // sendRecipes sends the current crafting recipes to the session.
 func (s *Session) sendRecipes() {
 	recipes := make([]protocol.Recipe, 0, len(recipe.Recipes()))
@@ -182,72 +205,59 @@ func (s *Session) invByID(id int32) (*inventory.Inventory, bool) {
 		return s.inv, true
 	case protocol.ContainerOffhand:
 		return s.offHand, true
+	case protocol.ContainerHorseEquip:
+		if w, ok := s.currentWindow(); ok && w.containerType == protocol.ContainerTypeHorse {
+			return w.inv, true
+		}
 	case protocol.ContainerArmor:
 		// Armour inventory.
 		return s.armour.Inventory(), true
 	case protocol.ContainerLevelEntity:
-		if s.containerOpened.Load() {
-			b := s.c.World().Block(s.openedPos.Load())
-			if _, chest := b.(block.Chest); chest {
-				return s.openedWindow.Load(), true
-			} else if _, enderChest := b.(block.EnderChest); enderChest {
-				return s.openedWindow.Load(), true
+		if w, ok := s.currentWindow(); ok && w.entity == nil && w.menu == nil {
+			if w.containerType == protocol.ContainerTypeContainer || w.containerType == protocol.ContainerTypeHopper {
+				return w.inv, true
 			}
 		}
 	case protocol.ContainerBarrel:
-		if s.containerOpened.Load() {
-			if _, barrel := s.c.World().Block(s.openedPos.Load()).(block.Barrel); barrel {
-				return s.openedWindow.Load(), true
-			}
+		if _, w, barrel := openedBlock[block.Barrel](s); barrel {
+			return w.inv, true
 		}
 	case protocol.ContainerBeaconPayment:
-		if s.containerOpened.Load() {
-			if _, beacon := s.c.World().Block(s.openedPos.Load()).(block.Beacon); beacon {
-				return s.ui, true
-			}
+		if _, _, beacon := openedBlock[block.Beacon](s); beacon {
+			return s.ui, true
 		}
 	case protocol.ContainerAnvilInput, protocol.ContainerAnvilMaterial:
-		if s.containerOpened.Load() {
-			if _, anvil := s.c.World().Block(s.openedPos.Load()).(block.Anvil); anvil {
-				return s.ui, true
-			}
+		if _, _, anvil := openedBlock[block.Anvil](s); anvil {
+			return s.ui, true
 		}
 	case protocol.ContainerSmithingTableInput, protocol.ContainerSmithingTableMaterial:
-		if s.containerOpened.Load() {
-			if _, smithing := s.c.World().Block(s.openedPos.Load()).(block.SmithingTable); smithing {
-				return s.ui, true
-			}
+		if _, _, smithing := openedBlock[block.SmithingTable](s); smithing {
+			return s.ui, true
 		}
 	case protocol.ContainerLoomInput, protocol.ContainerLoomDye, protocol.ContainerLoomMaterial:
-		if s.containerOpened.Load() {
-			if _, loom := s.c.World().Block(s.openedPos.Load()).(block.Loom); loom {
-				return s.ui, true
-			}
+		if _, _, loom := openedBlock[block.Loom](s); loom {
+			return s.ui, true
 		}
 	case protocol.ContainerStonecutterInput:
-		if s.containerOpened.Load() {
-			if _, ok := s.c.World().Block(s.openedPos.Load()).(block.Stonecutter); ok {
-				return s.ui, true
-			}
+		if _, _, ok := openedBlock[block.Stonecutter](s); ok {
+			return s.ui, true
 		}
 	case protocol.ContainerGrindstoneInput, protocol.ContainerGrindstoneAdditional:
-		if s.containerOpened.Load() {
-			if _, ok := s.c.World().Block(s.openedPos.Load()).(block.Grindstone); ok {
-				return s.ui, true
-			}
+		if _, _, ok := openedBlock[block.Grindstone](s); ok {
+			return s.ui, true
 		}
 	case protocol.ContainerEnchantingInput, protocol.ContainerEnchantingMaterial:
-		if s.containerOpened.Load() {
-			if _, enchanting := s.c.World().Block(s.openedPos.Load()).(block.EnchantingTable); enchanting {
-				return s.ui, true
-			}
+		if _, _, enchanting := openedBlock[block.EnchantingTable](s); enchanting {
+			return s.ui, true
+		}
+	case protocol.ContainerTradeTwoIngredientOne, protocol.ContainerTradeTwoIngredientTwo:
+		if s.openedTrade() != nil {
+			return s.ui, true
 		}
 	case protocol.ContainerFurnaceIngredient, protocol.ContainerFurnaceFuel, protocol.ContainerFurnaceResult,
 		protocol.ContainerBlastFurnaceIngredient, protocol.ContainerSmokerIngredient:
-		if s.containerOpened.Load() {
-			if _, ok := s.c.World().Block(s.openedPos.Load()).(smelter); ok {
-				return s.openedWindow.Load(), true
-			}
+		if _, w, ok := openedBlock[smelter](s); ok {
+			return w.inv, true
 		}
 	}
 	return nil, false
@@ -353,12 +363,22 @@ func (s *Session) SendGameMode(mode world.GameMode) {
 	}
 	s.writePacket(&packet.SetPlayerGameType{GameType: gameTypeFromMode(mode)})
 	s.sendAbilities()
+	s.updateSpectators()
+}
+
+// SendAbilities sends the abilities of the Controllable entity of the session to the client.
+func (s *Session) SendAbilities() {
+	if s == Nop {
+		return
+	}
+	s.sendAbilities()
 }
 
 // sendAbilities sends the abilities of the Controllable entity of the session to the client.
 func (s *Session) sendAbilities() {
-	mode, abilities := s.c.GameMode(), uint32(0)
-	if mode.AllowsFlying() {
+	mode, a, abilities := s.c.GameMode(), s.c.Abilities(), uint32(0)
+	has := a.Of(mode)
+	if has.Has(ability.MayFly) {
 		abilities |= protocol.AbilityMayFly
 		if s.c.Flying() {
 			abilities |= protocol.AbilityFlying
@@ -377,23 +397,27 @@ func (s *Session) sendAbilities() {
 	if mode.CreativeInventory() {
 		abilities |= protocol.AbilityInstantBuild
 	}
-	if mode.AllowsEditing() {
-		abilities |= protocol.AbilityBuild | protocol.AbilityMine
+	for flag, v := range abilityFlags {
+		if has.Has(flag) {
+			abilities |= v
+		}
 	}
-	if mode.AllowsInteraction() {
-		abilities |= protocol.AbilityDoorsAndSwitches | protocol.AbilityOpenContainers | protocol.AbilityAttackPlayers | protocol.AbilityAttackMobs
+	permissions, commandPermissions := uint8(packet.PermissionLevelMember), uint8(packet.CommandPermissionLevelNormal)
+	if has.Has(ability.Operator) {
+		permissions, commandPermissions = packet.PermissionLevelOperator, packet.CommandPermissionLevelAdmin
 	}
+	flySpeed, walkSpeed := a.Speeds()
 	s.writePacket(&packet.UpdateAbilities{AbilityData: protocol.AbilityData{
 		EntityUniqueID:     selfEntityRuntimeID,
-		PlayerPermissions:  packet.PermissionLevelMember,
-		CommandPermissions: packet.CommandPermissionLevelNormal,
-		Layers: []protocol.AbilityLayer{ // TODO: Support customization of fly and walk speeds.
+		PlayerPermissions:  permissions,
+		CommandPermissions: commandPermissions,
+		Layers: []protocol.AbilityLayer{
 			{
 				Type:      protocol.AbilityLayerTypeBase,
 				Abilities: protocol.AbilityCount - 1,
 				Values:    abilities,
-				FlySpeed:  protocol.AbilityBaseFlySpeed,
-				WalkSpeed: protocol.AbilityBaseWalkSpeed,
+				FlySpeed:  float32(flySpeed),
+				WalkSpeed: float32(walkSpeed),
 			},
 		},
 	}})
@@ -488,12 +512,21 @@ func (s *Session) addToPlayerList(session *Session) {
 	s.entities[runtimeID] = c
 	s.entityMutex.Unlock()
 
+	if s.playerListEntryHidden(c.UUID()) {
+		return
+	}
+	s.writePlayerListEntry(c, runtimeID)
+}
+
+// writePlayerListEntry writes the player list entry of a Controllable with the runtime ID passed to the
+// session.
+func (s *Session) writePlayerListEntry(c Controllable, runtimeID uint64) {
 	s.writePacket(&packet.PlayerList{
 		ActionType: packet.PlayerListActionAdd,
 		Entries: []protocol.PlayerListEntry{{
 			UUID:           c.UUID(),
 			EntityUniqueID: int64(runtimeID),
-			Username:       c.Name(),
+			Username:       c.ListName(),
 			XUID:           c.XUID(),
 			Skin:           skinToProtocol(c.Skin()),
 		}},
@@ -521,6 +554,20 @@ func skinToProtocol(s skin.Skin) protocol.Skin {
 		protocolAnim.ExpressionType = uint32(animation.AnimationExpression)
 		animations = append(animations, protocolAnim)
 	}
+	pieces := make([]protocol.PersonaPiece, 0, len(s.PersonaPieces))
+	for _, piece := range s.PersonaPieces {
+		pieces = append(pieces, protocol.PersonaPiece{
+			PieceID:   piece.ID,
+			PieceType: piece.Type,
+			PackID:    piece.PackID,
+			Default:   piece.Default,
+			ProductID: piece.ProductID,
+		})
+	}
+	tints := make([]protocol.PersonaPieceTintColour, 0, len(s.PieceTints))
+	for _, tint := range s.PieceTints {
+		tints = append(tints, protocol.PersonaPieceTintColour{PieceType: tint.PieceType, Colours: tint.Colours})
+	}
 
 	return protocol.Skin{
 		PlayFabID:          s.PlayFabID,
@@ -539,6 +586,10 @@ func skinToProtocol(s skin.Skin) protocol.Skin {
 		Animations:         animations,
 		Trusted:            true,
 		OverrideAppearance: true,
+		ArmSize:            s.ArmSize,
+		SkinColour:         s.Colour,
+		PersonaPieces:      pieces,
+		PieceTintColours:   tints,
 	}
 }
 
@@ -598,7 +649,7 @@ func (s *Session) HandleInventories() (inv, offHand, enderChest *inventory.Inven
 			return
 		}
 		if !s.inTransaction.Load() {
-			if _, ok := s.c.World().Block(s.openedPos.Load()).(block.EnderChest); ok {
+			if _, _, ok := openedBlock[block.EnderChest](s); ok {
 				s.ViewSlotChange(slot, item)
 			}
 		}
@@ -721,6 +772,8 @@ func stackFromItem(it item.Stack) protocol.ItemStack {
 		Count:          uint16(it.Count()),
 		BlockRuntimeID: int32(blockRuntimeID),
 		NBTData:        nbtconv.WriteItem(it, false),
+		CanBePlacedOn:  it.CanPlaceOn(),
+		CanBreak:       it.CanDestroy(),
 	}
 }
 
@@ -743,7 +796,7 @@ func stackToItem(it protocol.ItemStack) item.Stack {
 	if nbter, ok := t.(world.NBTer); ok && len(it.NBTData) != 0 {
 		t = nbter.DecodeNBT(it.NBTData).(world.Item)
 	}
-	s := item.NewStack(t, int(it.Count))
+	s := item.NewStack(t, int(it.Count)).WithCanPlaceOn(it.CanBePlacedOn...).WithCanDestroy(it.CanBreak...)
 	return nbtconv.Item(it.NBTData, &s)
 }
 
@@ -822,6 +875,19 @@ func protocolToSkin(sk protocol.Skin) (s skin.Skin, err error) {
 
 	s.Cape = skin.NewCape(int(sk.CapeImageWidth), int(sk.CapeImageHeight))
 	s.Cape.Pix = sk.CapeData
+	s.ArmSize, s.Colour = sk.ArmSize, sk.SkinColour
+	for _, piece := range sk.PersonaPieces {
+		s.PersonaPieces = append(s.PersonaPieces, skin.PersonaPiece{
+			ID:        piece.PieceID,
+			Type:      piece.PieceType,
+			PackID:    piece.PackID,
+			Default:   piece.Default,
+			ProductID: piece.ProductID,
+		})
+	}
+	for _, tint := range sk.PieceTintColours {
+		s.PieceTints = append(s.PieceTints, skin.PieceTint{PieceType: tint.PieceType, Colours: tint.Colours})
+	}
 
 	m := make(map[string]any)
 	if err = json.Unmarshal(sk.SkinGeometry, &m); err != nil {
@@ -854,6 +920,18 @@ func protocolToSkin(sk protocol.Skin) (s skin.Skin, err error) {
 	return
 }
 
+// abilityFlags maps abilities of a player to the ability flags sent over the network.
+var abilityFlags = map[ability.Ability]uint32{
+	ability.Build:            protocol.AbilityBuild,
+	ability.Mine:             protocol.AbilityMine,
+	ability.DoorsAndSwitches: protocol.AbilityDoorsAndSwitches,
+	ability.OpenContainers:   protocol.AbilityOpenContainers,
+	ability.AttackPlayers:    protocol.AbilityAttackPlayers,
+	ability.AttackMobs:       protocol.AbilityAttackMobs,
+	ability.Operator:         protocol.AbilityOperatorCommands,
+	ability.Muted:            protocol.AbilityMuted,
+}
+
 // gameTypeFromMode returns the game type ID from the game mode passed.
 func gameTypeFromMode(mode world.GameMode) int32 {
 	if mode.AllowsFlying() && mode.CreativeInventory() {
@@ -862,6 +940,10 @@ func gameTypeFromMode(mode world.GameMode) int32 {
 	if !mode.Visible() && !mode.HasCollision() {
 		return packet.GameTypeSpectator
 	}
+	if !mode.AllowsEditing() && mode.AllowsInteraction() {
+		// The client only respects the CanPlaceOn and CanDestroy lists of items in adventure mode.
+		return packet.GameTypeAdventure
+	}
 	return packet.GameTypeSurvival
 }
 
@@ -877,3 +959,9 @@ func item_id(s item.Stack) int32
 //
 //go:linkname world_add github.com/df-mc/dragonfly/server/world.add
 func world_add(e world.Entity, w *world.World)
+
+// MovementInput returns the movement input last sent by the client. The first value is the strafing input,
+// positive to the left, and the second value is the forward input.
+func (s *Session) MovementInput() mgl64.Vec2 {
+	return s.moveInput.Load()
+}
@@ -0,0 +1,117 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// HidePlayerListEntry hides the Controllable passed from the player list of the session. The entity of the
+// Controllable remains visible.
+func (s *Session) HidePlayerListEntry(c Controllable) {
+	if s == Nop {
+		return
+	}
+	s.listMu.Lock()
+	s.hiddenListEntries[c.UUID()] = struct{}{}
+	s.listMu.Unlock()
+	s.writePacket(&packet.PlayerList{
+		ActionType: packet.PlayerListActionRemove,
+		Entries:    []protocol.PlayerListEntry{{UUID: c.UUID()}},
+	})
+}
+
+// ShowPlayerListEntry shows the Controllable passed in the player list of the session again after it was hidden
+// using HidePlayerListEntry.
+func (s *Session) ShowPlayerListEntry(c Controllable) {
+	if s == Nop {
+		return
+	}
+	s.listMu.Lock()
+	_, hidden := s.hiddenListEntries[c.UUID()]
+	delete(s.hiddenListEntries, c.UUID())
+	s.listMu.Unlock()
+
+	if runtimeID := s.entityRuntimeID(c); hidden && runtimeID != 0 {
+		s.writePlayerListEntry(c, runtimeID)
+	}
+}
+
+// playerListEntryHidden checks if the player with the UUID passed was hidden from the player list of the
+// session.
+func (s *Session) playerListEntryHidden(id uuid.UUID) bool {
+	s.listMu.Lock()
+	defer s.listMu.Unlock()
+	_, ok := s.hiddenListEntries[id]
+	return ok
+}
+
+// UpdatePlayerListEntry resends the player list entry of the Controllable of the session to all sessions, so
+// that changes to its list name are shown.
+func (s *Session) UpdatePlayerListEntry() {
+	if s == Nop {
+		return
+	}
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	for _, other := range sessions {
+		runtimeID := other.entityRuntimeID(s.c)
+		if runtimeID == 0 || other.playerListEntryHidden(s.c.UUID()) {
+			continue
+		}
+		other.writePacket(&packet.PlayerList{
+			ActionType: packet.PlayerListActionRemove,
+			Entries:    []protocol.PlayerListEntry{{UUID: s.c.UUID()}},
+		})
+		other.writePlayerListEntry(s.c, runtimeID)
+	}
+}
+
+// UpdatePlayerListSkin sends the skin of the Controllable of the session to all sessions that are not viewing
+// it through its world, so that its skin in their player list is updated without the need to rejoin.
+func (s *Session) UpdatePlayerListSkin() {
+	if s == Nop {
+		return
+	}
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	pk := &packet.PlayerSkin{UUID: s.c.UUID(), Skin: skinToProtocol(s.c.Skin())}
+	for _, other := range sessions {
+		if other.c.World() != s.c.World() {
+			other.writePacket(pk)
+		}
+	}
+}
+
+// AddFakePlayerListEntry adds an entry to the player list of the session that does not belong to any player.
+// Fake entries may be used to show additional information in the player list, such as in lobbies. The entry
+// is identified by the UUID passed and may be removed again using RemoveFakePlayerListEntry.
+func (s *Session) AddFakePlayerListEntry(id uuid.UUID, name string, sk skin.Skin) {
+	if s == Nop {
+		return
+	}
+	s.entityMutex.Lock()
+	s.currentEntityRuntimeID += 1
+	runtimeID := s.currentEntityRuntimeID
+	s.entityMutex.Unlock()
+
+	s.writePacket(&packet.PlayerList{
+		ActionType: packet.PlayerListActionAdd,
+		Entries: []protocol.PlayerListEntry{{
+			UUID:           id,
+			EntityUniqueID: int64(runtimeID),
+			Username:       name,
+			Skin:           skinToProtocol(sk),
+		}},
+	})
+}
+
+// RemoveFakePlayerListEntry removes an entry added using AddFakePlayerListEntry from the player list of the
+// session.
+func (s *Session) RemoveFakePlayerListEntry(id uuid.UUID) {
+	s.writePacket(&packet.PlayerList{
+		ActionType: packet.PlayerListActionRemove,
+		Entries:    []protocol.PlayerListEntry{{UUID: id}},
+	})
+}
@@ -0,0 +1,105 @@
+package session
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"math"
+	"time"
+)
+
+// RateLimit limits the rate at which a client may send a specific packet. Packets sent faster than the limit
+// allows are dropped. A client that keeps exceeding the limit after its packets are dropped is reported to
+// its Controllable through ExceedPacketLimit, which generally disconnects it.
+type RateLimit struct {
+	// Rate is the amount of packets a client may send per second on average.
+	Rate float64
+	// Burst is the amount of packets a client may send at once before the Rate applies. The same amount of
+	// packets may be dropped before the client is reported.
+	Burst int
+}
+
+// DefaultRateLimits returns the RateLimits applied to sessions by default, indexed by packet ID. They cover
+// packets that are expensive to handle for the server.
+func DefaultRateLimits() map[uint32]RateLimit {
+	return map[uint32]RateLimit{
+		packet.IDInventoryTransaction: {Rate: 40, Burst: 80},
+		packet.IDItemStackRequest:     {Rate: 40, Burst: 80},
+		packet.IDText:                 {Rate: 4, Burst: 10},
+		packet.IDCommandRequest:       {Rate: 4, Burst: 10},
+		packet.IDPlayerSkin:           {Rate: 0.2, Burst: 3},
+	}
+}
+
+// SetRateLimits changes the RateLimits applied to packets received by the Session, indexed by packet ID.
+// Packets without a RateLimit are not limited. SetRateLimits may be called while the Session is running, in
+// which case the packets the client sent previously no longer count towards the new limits.
+func (s *Session) SetRateLimits(limits map[uint32]RateLimit) {
+	s.limiter.Store(newRateLimiter(limits))
+}
+
+// revertDropped reverts the client-side changes of a packet dropped because it exceeded its RateLimit, so
+// that the inventory of the client does not go out of sync with that of the server.
+func (s *Session) revertDropped(pk packet.Packet) {
+	switch pk := pk.(type) {
+	case *packet.ItemStackRequest:
+		responses := make([]protocol.ItemStackResponse, len(pk.Requests))
+		for i, req := range pk.Requests {
+			responses[i] = protocol.ItemStackResponse{Status: protocol.ItemStackResponseStatusError, RequestID: req.RequestID}
+		}
+		s.writePacket(&packet.ItemStackResponse{Responses: responses})
+	case *packet.InventoryTransaction:
+		s.handlers[packet.IDInventoryTransaction].(*InventoryTransactionHandler).resendInventories(s)
+	}
+}
+
+// rateLimiter applies RateLimits to packets using token buckets. Each packet ID has one bucket for packets
+// allowed and one for packets dropped, so that clients are only reported if they keep exceeding a limit.
+type rateLimiter struct {
+	limits  map[uint32]RateLimit
+	buckets map[uint32]*[2]bucket
+}
+
+// newRateLimiter creates a rateLimiter applying the RateLimits passed.
+func newRateLimiter(limits map[uint32]RateLimit) *rateLimiter {
+	return &rateLimiter{limits: limits, buckets: make(map[uint32]*[2]bucket, len(limits))}
+}
+
+// allow checks if a packet with the ID passed is allowed. If not, report specifies if the client exceeded
+// the limit for long enough to be reported.
+func (r *rateLimiter) allow(id uint32) (ok, report bool) {
+	if r == nil {
+		return true, false
+	}
+	limit, limited := r.limits[id]
+	if !limited {
+		return true, false
+	}
+	b, found := r.buckets[id]
+	if !found {
+		now := time.Now()
+		b = &[2]bucket{{tokens: float64(limit.Burst), last: now}, {tokens: float64(limit.Burst), last: now}}
+		r.buckets[id] = b
+	}
+	if b[0].take(limit) {
+		return true, false
+	}
+	return false, !b[1].take(limit)
+}
+
+// bucket is a token bucket that refills at the Rate of a RateLimit, up to its Burst.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket and takes a token from it. False is returned if the bucket was empty.
+func (b *bucket) take(limit RateLimit) bool {
+	now := time.Now()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
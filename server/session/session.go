@@ -7,14 +7,19 @@ import (
 	"github.com/df-mc/atomic"
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/item/recipe"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/df-mc/dragonfly/server/player/scoreboard"
+	"github.com/df-mc/dragonfly/server/player/settings"
+	"github.com/df-mc/dragonfly/server/timings"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft"
 	"github.com/sandertv/gophertunnel/minecraft/nbt"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
@@ -23,6 +28,7 @@ import (
 	"github.com/sandertv/gophertunnel/minecraft/text"
 	"io"
 	"net"
+	"strings"
 	"sync"
 	"time"
 )
@@ -36,6 +42,10 @@ type Session struct {
 	c        Controllable
 	conn     Conn
 	handlers map[uint32]packetHandler
+	// packetHandler is the PacketHandler called for every packet sent and received by the session.
+	packetHandler atomic.Value[PacketHandler]
+	// limiter applies the RateLimits set using SetRateLimits to packets received by the session.
+	limiter atomic.Value[*rateLimiter]
 
 	// onStop is called when the session is stopped. The controllable passed is the controllable that the
 	// session controls.
@@ -44,11 +54,42 @@ type Session struct {
 	currentScoreboard atomic.Value[string]
 	currentLines      atomic.Value[[]string]
 
-	chunkLoader                 *world.Loader
-	chunkRadius, maxChunkRadius int32
+	objectiveMu sync.Mutex
+	// objectives holds the scoreboard.Objective displayed in every scoreboard.DisplaySlot of the session.
+	objectives map[scoreboard.DisplaySlot]*scoreboard.Objective
+
+	bossBarMu sync.Mutex
+	// bossBars holds the boss bars currently shown to the session, indexed by the ID of the boss bar.
+	bossBars map[uint64]*bossBarEntity
+
+	chunkLoader *world.Loader
+	// subChunkRequests specifies if sub-chunks are sent when requested by the client rather than as part
+	// of full chunks.
+	subChunkRequests bool
+	// lastChunkPos is the position of the controllable the last time chunks were sent.
+	lastChunkPos mgl64.Vec3
+	// requestedChunkRadius is the chunk radius last requested by the client, which chunkRadius is set to if
+	// it does not exceed maxChunkRadius.
+	chunkRadius, maxChunkRadius, requestedChunkRadius atomic.Int32
+	// clientSettings holds the settings of the client, such as its render distance and input mode.
+	clientSettings atomic.Value[settings.Settings]
+	// worldMu is held while the Session is switching worlds, so that a world switch requested directly through
+	// ChangeWorld never races with one detected while sending chunks.
+	worldMu sync.Mutex
+	// pendingDimension holds the dimension that the client is moved to once it acknowledges the intermediate
+	// dimension change sent when switching to a world of the same dimension. It is nil if no change is pending.
+	pendingDimension atomic.Value[*int32]
 
 	teleportPos atomic.Value[*mgl64.Vec3]
 
+	blockUpdateMu sync.Mutex
+	// blockUpdates holds the block changes viewed since the last tick, grouped by the sub chunk they are in. They
+	// are sent to the client at the end of every tick by sendBlockUpdates.
+	blockUpdates map[protocol.SubChunkPos]*packet.UpdateSubChunkBlocks
+	// blockEntityUpdates holds the block entity data of blocks viewed since the last tick. These are sent after
+	// the block changes in blockUpdates.
+	blockEntityUpdates []*packet.BlockActorData
+
 	entityMutex sync.RWMutex
 	// currentEntityRuntimeID holds the runtime ID assigned to the last entity. It is incremented for every
 	// entity spawned to the session.
@@ -57,6 +98,13 @@ type Session struct {
 	entityRuntimeIDs map[world.Entity]uint64
 	entities         map[uint64]world.Entity
 	hiddenEntities   map[world.Entity]struct{}
+	// hiddenSpectators holds the players that are currently not shown to the session because they are in a
+	// GameMode that is not visible.
+	hiddenSpectators map[world.Entity]struct{}
+
+	listMu sync.Mutex
+	// hiddenListEntries holds the UUIDs of players hidden from the player list of the session.
+	hiddenListEntries map[uuid.UUID]struct{}
 
 	// heldSlot is the slot in the inventory that the controllable is holding.
 	heldSlot                     *atomic.Uint32
@@ -65,17 +113,22 @@ type Session struct {
 
 	breakingPos cube.Pos
 
-	inTransaction, containerOpened atomic.Bool
-	openedWindowID                 atomic.Uint32
-	openedContainerID              atomic.Uint32
-	openedWindow                   atomic.Value[*inventory.Inventory]
-	openedPos                      atomic.Value[cube.Pos]
-	swingingArm                    atomic.Bool
+	inTransaction atomic.Bool
+	// windowID is the ID of the last window opened by the session. openedWindow holds the window currently
+	// opened, or nil if no window is opened.
+	windowID     atomic.Uint32
+	openedWindow atomic.Value[*window]
+	moveInput    atomic.Value[mgl64.Vec2]
+	swingingArm  atomic.Bool
+
+	// connClosed is set when the connection is closed by the server. connLost and connTimedOut are set if
+	// the connection is lost otherwise.
+	connClosed, connLost, connTimedOut atomic.Bool
 
 	recipes map[uint32]recipe.Recipe
 
 	blobMu                sync.Mutex
-	blobs                 map[uint64][]byte
+	blobs                 *blobStore
 	openChunkTransactions []map[uint64]struct{}
 	invOpened             bool
 
@@ -155,22 +208,49 @@ func New(conn Conn, maxChunkRadius int, log Logger, joinMessage, quitMessage str
 		entityRuntimeIDs:       map[world.Entity]uint64{},
 		entities:               map[uint64]world.Entity{},
 		hiddenEntities:         map[world.Entity]struct{}{},
-		blobs:                  map[uint64][]byte{},
-		chunkRadius:            int32(r),
-		maxChunkRadius:         int32(maxChunkRadius),
+		hiddenSpectators:       map[world.Entity]struct{}{},
+		hiddenListEntries:      map[uuid.UUID]struct{}{},
+		objectives:             map[scoreboard.DisplaySlot]*scoreboard.Objective{},
+		bossBars:               map[uint64]*bossBarEntity{},
+		blockUpdates:           map[protocol.SubChunkPos]*packet.UpdateSubChunkBlocks{},
+		blobs:                  newBlobStore(),
 		conn:                   conn,
 		log:                    log,
 		currentEntityRuntimeID: 1,
 		heldSlot:               atomic.NewUint32(0),
 		joinMessage:            joinMessage,
 		quitMessage:            quitMessage,
-		openedWindow:           *atomic.NewValue(inventory.New(1, nil)),
+		packetHandler:          *atomic.NewValue[PacketHandler](NopPacketHandler{}),
+		subChunkRequests:       true,
 	}
 
+	s.chunkRadius.Store(int32(r))
+	s.maxChunkRadius.Store(int32(maxChunkRadius))
+	s.requestedChunkRadius.Store(int32(conn.ChunkRadius()))
+	s.clientSettings.Store(clientSettingsFrom(conn))
+
 	s.registerHandlers()
 	return s
 }
 
+// SetMaxChunkRadius changes the maximum chunk radius of the Session. The chunk radius of the Session is
+// changed immediately to the radius last requested by the client, limited to the new maximum.
+func (s *Session) SetMaxChunkRadius(maxChunkRadius int) {
+	s.maxChunkRadius.Store(int32(maxChunkRadius))
+	s.changeChunkRadius(s.requestedChunkRadius.Load())
+}
+
+// changeChunkRadius changes the chunk radius of the Session to the radius passed, limited to the maximum
+// chunk radius of the Session, and sends the new radius to the client.
+func (s *Session) changeChunkRadius(r int32) {
+	if limit := s.maxChunkRadius.Load(); r > limit {
+		r = limit
+	}
+	s.chunkRadius.Store(r)
+	s.chunkLoader.ChangeRadius(int(r))
+	s.writePacket(&packet.ChunkRadiusUpdated{ChunkRadius: r})
+}
+
 // Spawn makes the Controllable passed spawn in the world.World.
 // The function passed will be called when the session stops running.
 func (s *Session) Spawn(c Controllable, pos mgl64.Vec3, w *world.World, gm world.GameMode, onStop func(controllable Controllable)) {
@@ -180,20 +260,22 @@ func (s *Session) Spawn(c Controllable, pos mgl64.Vec3, w *world.World, gm world
 	s.entityRuntimeIDs[c] = selfEntityRuntimeID
 	s.entities[selfEntityRuntimeID] = c
 
-	s.chunkLoader = world.NewLoader(int(s.chunkRadius), w, s)
+	s.chunkLoader = world.NewLoader(int(s.chunkRadius.Load()), w, s)
 	s.chunkLoader.Move(pos)
 	s.writePacket(&packet.NetworkChunkPublisherUpdate{
 		Position: protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
-		Radius:   uint32(s.chunkRadius) << 4,
+		Radius:   uint32(s.chunkRadius.Load()) << 4,
 	})
 
 	s.sendAvailableEntities(w)
+	s.sendCameraPresets()
 
 	s.initPlayerList()
 
 	world_add(c, w)
 	s.c.SetGameMode(gm)
 	s.SendSpeed(0.1)
+	s.SendSpawnPoint(c.SpawnPoint(), w.PortalDestination(w.Dimension()).Dimension())
 	for _, e := range s.c.Effects() {
 		s.SendEffect(e)
 	}
@@ -230,7 +312,9 @@ func (s *Session) Close() error {
 }
 
 // close closes the session, which in turn closes the controllable and the connection that the session
-// manages.
+// manages. Cleanup happens in a fixed order: The controllable is closed first, so that its handlers see it
+// in its original state. Its items are then returned to its inventory before onStop is called, so that
+// saved data is complete, after which inventories, chunks and entities are released.
 func (s *Session) close() {
 	_ = s.c.Close()
 
@@ -250,6 +334,7 @@ func (s *Session) close() {
 	_ = s.armour.Close()
 
 	s.closeCurrentContainer()
+	s.closeObjectives()
 	_ = s.chunkLoader.Close()
 	s.c.World().RemoveEntity(s.c)
 
@@ -269,6 +354,7 @@ func (s *Session) close() {
 // eventually.
 func (s *Session) CloseConnection() {
 	s.connOnce.Do(func() {
+		s.connClosed.Store(true)
 		_ = s.conn.Close()
 		s.closeBackground <- struct{}{}
 	})
@@ -304,11 +390,26 @@ func (s *Session) handlePackets() {
 		}
 		_ = s.Close()
 	}()
+	lastRead := time.Now()
 	for {
 		pk, err := s.conn.ReadPacket()
 		if err != nil {
+			s.lostConnection(lastRead)
 			return
 		}
+		lastRead = time.Now()
+		packetsReceived.Add(1)
+		if ok, report := s.limiter.Load().allow(pk.ID()); !ok {
+			if report {
+				s.c.ExceedPacketLimit(strings.TrimPrefix(fmt.Sprintf("%T", pk), "*packet."))
+			}
+			s.revertDropped(pk)
+			continue
+		}
+		ctx := event.C()
+		if s.packetHandlerOf().HandleClientPacket(ctx, pk); ctx.Cancelled() {
+			continue
+		}
 		if err := s.handlePacket(pk); err != nil {
 			// An error occurred during the handling of a packet. Print the error and stop handling any more
 			// packets.
@@ -318,6 +419,29 @@ func (s *Session) handlePackets() {
 	}
 }
 
+// connectionTimeout is the duration without packets from the client after which a lost connection is
+// considered to have timed out. Clients send packets every tick, so a client that quits normally never
+// goes this long without sending packets.
+const connectionTimeout = time.Second * 5
+
+// lostConnection records that the connection of the Session was lost, either because the client closed it or
+// because it timed out, given the time the last packet was read.
+func (s *Session) lostConnection(lastRead time.Time) {
+	if s.connClosed.Load() {
+		// The connection was closed by the server.
+		return
+	}
+	s.connLost.Store(true)
+	s.connTimedOut.Store(time.Since(lastRead) > connectionTimeout)
+}
+
+// ConnectionLost checks if the connection of the Session was lost, rather than closed by the server. If so,
+// timedOut specifies if the connection was lost because the client stopped responding, as opposed to the
+// client closing the connection itself.
+func (s *Session) ConnectionLost() (lost, timedOut bool) {
+	return s.connLost.Load(), s.connTimedOut.Load()
+}
+
 // background performs background tasks of the Session. This includes chunk sending and automatic command updating.
 // background returns when the Session's connection is closed using CloseConnection.
 func (s *Session) background() {
@@ -333,12 +457,14 @@ func (s *Session) background() {
 	for {
 		select {
 		case <-t.C:
+			s.sendBlockUpdates()
 			s.sendChunks()
 
 			if i++; i%20 == 0 {
 				// Enum resending happens relatively often and frequent updates are more important than with full
 				// command changes. Those are generally only related to permission changes, which doesn't happen often.
 				s.resendEnums(enums, enumValues)
+				s.moveBossBars()
 			}
 			if i%100 == 0 {
 				// Try to resend commands only every 5 seconds.
@@ -352,54 +478,103 @@ func (s *Session) background() {
 	}
 }
 
-// sendChunks sends the next up to 4 chunks to the connection. What chunks are loaded depends on the connection of
-// the chunk loader and the chunks that were previously loaded.
+// fastMovementDistance is the distance in blocks that a controllable must move in between two ticks for the
+// session to send chunks at a higher rate.
+const fastMovementDistance = 4
+
+// sendChunks sends the next up to 4 chunks to the connection, or up to 8 if the controllable is moving fast.
+// What chunks are loaded depends on the connection of the chunk loader and the chunks that were previously
+// loaded.
 func (s *Session) sendChunks() {
 	pos := s.c.Position()
 	s.chunkLoader.Move(pos)
+	s.chunkLoader.Look(s.c.Rotation().Yaw())
 	s.writePacket(&packet.NetworkChunkPublisherUpdate{
 		Position: protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
-		Radius:   uint32(s.chunkRadius) << 4,
+		Radius:   uint32(s.chunkRadius.Load()) << 4,
 	})
 
 	const maxChunkTransactions = 8
 
-	if w := s.c.World(); s.chunkLoader.World() != w && w != nil {
-		s.handleWorldSwitch(w)
+	if w := s.c.World(); w != nil {
+		s.ChangeWorld(w)
+	}
+	if s.pendingDimension.Load() != nil {
+		// The client is still in the intermediate dimension: Chunks sent now would be discarded by the client.
+		return
 	}
 
 	s.blobMu.Lock()
 	toLoad := maxChunkTransactions - len(s.openChunkTransactions)
 	s.blobMu.Unlock()
-	if toLoad > 4 {
+	// Players moving fast or teleporting need chunks around them quickly, so we send chunks at a higher
+	// rate until they slow down again.
+	if moved := pos.Sub(s.lastChunkPos).Len(); moved < fastMovementDistance && toLoad > 4 {
 		toLoad = 4
 	}
+	s.lastChunkPos = pos
 	s.chunkLoader.Load(toLoad)
 }
 
+// ChangeWorld moves the client of the Session to the world.World passed. Chunks of the old world are discarded
+// and the client is shown a loading screen while the chunks of the new world are sent. ChangeWorld does nothing
+// if the Session is already viewing w.
+func (s *Session) ChangeWorld(w *world.World) {
+	if s == Nop {
+		return
+	}
+	s.worldMu.Lock()
+	defer s.worldMu.Unlock()
+	if s.chunkLoader.World() != w {
+		s.handleWorldSwitch(w)
+	}
+}
+
 // handleWorldSwitch handles the player of the Session switching worlds.
 func (s *Session) handleWorldSwitch(w *world.World) {
+	// Block changes viewed in the old world must not be applied to the chunks of the new world.
+	s.blockUpdateMu.Lock()
+	s.blockUpdates, s.blockEntityUpdates = map[protocol.SubChunkPos]*packet.UpdateSubChunkBlocks{}, nil
+	s.blockUpdateMu.Unlock()
+
 	if s.conn.ClientCacheEnabled() {
 		s.blobMu.Lock()
 		// Force out all blobs before changing worlds. This ensures no outdated chunk loading in the new world.
-		resp := &packet.ClientCacheMissResponse{Blobs: make([]protocol.CacheBlob, 0, len(s.blobs))}
-		for h, blob := range s.blobs {
-			resp.Blobs = append(resp.Blobs, protocol.CacheBlob{Hash: h, Payload: blob})
-		}
-		s.writePacket(resp)
-
-		s.blobs = map[uint64][]byte{}
+		s.writePacket(&packet.ClientCacheMissResponse{Blobs: s.blobs.all()})
 		s.openChunkTransactions = nil
 		s.blobMu.Unlock()
 	}
 
 	dim, _ := world.DimensionID(w.Dimension())
-	same := w.Dimension() == s.chunkLoader.World().Dimension()
-	if !same {
+	if w.Dimension() == s.chunkLoader.World().Dimension() {
+		// The client does not clear its chunks or show a loading screen when changing to the dimension it is
+		// already in. We first send it to a different dimension so that both of these happen anyway. The change
+		// back to the actual dimension is only sent once the client acknowledges the first one, as the client
+		// ignores a dimension change sent while it is still changing dimensions.
+		other, _ := world.DimensionID(world.Nether)
+		if w.Dimension() == world.Nether {
+			other, _ = world.DimensionID(world.Overworld)
+		}
+		s.changeDimension(int32(other), true)
+		target := int32(dim)
+		s.pendingDimension.Store(&target)
+	} else {
 		s.changeDimension(int32(dim), false)
 	}
 	s.ViewEntityTeleport(s.c, s.c.Position())
 	s.chunkLoader.ChangeWorld(w)
+	s.writePacket(&packet.ChunkRadiusUpdated{ChunkRadius: s.chunkRadius.Load()})
+}
+
+// finishDimensionChange is called when the client acknowledges a dimension change. If a world switch to the same
+// dimension is pending, the client is moved to its actual dimension.
+func (s *Session) finishDimensionChange() {
+	s.worldMu.Lock()
+	defer s.worldMu.Unlock()
+	if dim := s.pendingDimension.Swap(nil); dim != nil {
+		s.changeDimension(*dim, false)
+		s.ViewEntityTeleport(s.c, s.c.Position())
+	}
 }
 
 // changeDimension changes the dimension of the client. If silent is set to true, the portal noise will be stopped
@@ -415,6 +590,7 @@ func (s *Session) changeDimension(dim int32, silent bool) {
 		EntityRuntimeID: selfEntityRuntimeID,
 		ActionType:      protocol.PlayerActionDimensionChangeDone,
 	})
+	s.respawnBossBars()
 }
 
 // handlePacket handles an incoming packet, processing it accordingly. If the packet had invalid data or was
@@ -429,6 +605,7 @@ func (s *Session) handlePacket(pk packet.Packet) error {
 		// A nil handler means it was explicitly unhandled.
 		return nil
 	}
+	defer timings.Global.Start("session.packets")()
 	if err := handler.Handle(pk, s); err != nil {
 		return fmt.Errorf("%T: %w", pk, err)
 	}
@@ -461,6 +638,7 @@ func (s *Session) registerHandlers() {
 		packet.IDMobEquipment:          &MobEquipmentHandler{},
 		packet.IDModalFormResponse:     &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
 		packet.IDMovePlayer:            nil,
+		packet.IDPassengerJump:         PassengerJumpHandler{},
 		packet.IDPlayerAction:          &PlayerActionHandler{},
 		packet.IDPlayerAuthInput:       &PlayerAuthInputHandler{},
 		packet.IDPlayerSkin:            &PlayerSkinHandler{},
@@ -476,11 +654,9 @@ func (s *Session) registerHandlers() {
 // handleInterfaceUpdate handles an update to the UI inventory, used for updating enchantment options and possibly more
 // in the future.
 func (s *Session) handleInterfaceUpdate(slot int, _, item item.Stack) {
-	if slot == enchantingInputSlot && s.containerOpened.Load() {
-		pos := s.openedPos.Load()
-		b := s.c.World().Block(pos)
-		if _, enchanting := b.(block.EnchantingTable); enchanting {
-			s.sendEnchantmentOptions(s.c.World(), pos, item)
+	if slot == enchantingInputSlot {
+		if _, w, enchanting := openedBlock[block.EnchantingTable](s); enchanting {
+			s.sendEnchantmentOptions(s.c.World(), w.pos, item)
 		}
 	}
 }
@@ -490,7 +666,12 @@ func (s *Session) writePacket(pk packet.Packet) {
 	if s == Nop {
 		return
 	}
+	ctx := event.C()
+	if s.packetHandlerOf().HandleServerPacket(ctx, pk); ctx.Cancelled() {
+		return
+	}
 	_ = s.conn.WritePacket(pk)
+	packetsSent.Add(1)
 }
 
 // initPlayerList initialises the player list of the session and sends the session itself to all other
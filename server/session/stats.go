@@ -0,0 +1,15 @@
+package session
+
+import "sync/atomic"
+
+var (
+	// packetsReceived and packetsSent hold the total amount of packets received from and sent to all sessions.
+	packetsReceived, packetsSent atomic.Uint64
+)
+
+// PacketStats returns the total amount of packets received from and sent to all sessions since the program was
+// started. The rate at which packets are sent and received may be calculated by comparing the values returned
+// over time.
+func PacketStats() (received, sent uint64) {
+	return packetsReceived.Load(), packetsSent.Load()
+}
@@ -2,8 +2,10 @@ package session
 
 import (
 	"github.com/df-mc/dragonfly/server/player/scoreboard"
+	"github.com/df-mc/dragonfly/server/world"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"golang.org/x/exp/maps"
 	"time"
 )
 
@@ -15,6 +17,16 @@ func (s *Session) SendMessage(message string) {
 	})
 }
 
+// SendTranslation ...
+func (s *Session) SendTranslation(key string, params []string) {
+	s.writePacket(&packet.Text{
+		TextType:         packet.TextTypeTranslation,
+		NeedsTranslation: true,
+		Message:          key,
+		Parameters:       params,
+	})
+}
+
 // SendTip ...
 func (s *Session) SendTip(message string) {
 	s.writePacket(&packet.Text{
@@ -114,27 +126,6 @@ func (s *Session) RemoveScoreboard() {
 	s.currentLines.Store([]string{})
 }
 
-// SendBossBar sends a boss bar to the player with the text passed and the health percentage of the bar.
-// SendBossBar removes any boss bar that might be active before sending the new one.
-func (s *Session) SendBossBar(text string, colour uint8, healthPercentage float64) {
-	s.RemoveBossBar()
-	s.writePacket(&packet.BossEvent{
-		BossEntityUniqueID: selfEntityRuntimeID,
-		EventType:          packet.BossEventShow,
-		BossBarTitle:       text,
-		HealthPercentage:   float32(healthPercentage),
-		Colour:             uint32(colour),
-	})
-}
-
-// RemoveBossBar removes any boss bar currently active on the player's screen.
-func (s *Session) RemoveBossBar() {
-	s.writePacket(&packet.BossEvent{
-		BossEntityUniqueID: selfEntityRuntimeID,
-		EventType:          packet.BossEventHide,
-	})
-}
-
 const tickLength = time.Second / 20
 
 // SetTitleDurations ...
@@ -157,7 +148,125 @@ func (s *Session) SendSubtitle(text string) {
 	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionSetSubtitle, Text: text})
 }
 
+// ClearTitle ...
+func (s *Session) ClearTitle() {
+	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionClear})
+}
+
 // SendActionBarMessage ...
 func (s *Session) SendActionBarMessage(text string) {
 	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionSetActionBar, Text: text})
 }
+
+// ViewObjective displays a scoreboard.Objective with all its scores in its display slot. If another objective
+// was displayed in the same slot, it is removed first.
+func (s *Session) ViewObjective(o *scoreboard.Objective) {
+	if s == Nop {
+		return
+	}
+	s.objectiveMu.Lock()
+	current, ok := s.objectives[o.Slot()]
+	s.objectives[o.Slot()] = o
+	s.objectiveMu.Unlock()
+
+	if ok && current != o {
+		current.RemoveViewer(s)
+		s.writePacket(&packet.RemoveObjective{ObjectiveName: current.Name()})
+	}
+	s.writePacket(&packet.SetDisplayObjective{
+		DisplaySlot:   o.Slot().String(),
+		ObjectiveName: o.Name(),
+		DisplayName:   o.DisplayName(),
+		CriteriaName:  "dummy",
+		SortOrder:     int32(o.Order().Uint8()),
+	})
+	s.ViewScores(o, o.Scores())
+}
+
+// HideObjective removes a scoreboard.Objective from the screen of the session and stops it from receiving
+// updates of the objective.
+func (s *Session) HideObjective(o *scoreboard.Objective) {
+	if s == Nop {
+		return
+	}
+	o.RemoveViewer(s)
+
+	s.objectiveMu.Lock()
+	current, ok := s.objectives[o.Slot()]
+	if ok && current == o {
+		delete(s.objectives, o.Slot())
+	}
+	s.objectiveMu.Unlock()
+	if ok && current == o {
+		s.writePacket(&packet.RemoveObjective{ObjectiveName: o.Name()})
+	}
+}
+
+// ViewScores sends the scores passed of a scoreboard.Objective, adding or updating them.
+func (s *Session) ViewScores(o *scoreboard.Objective, scores []scoreboard.Score) {
+	s.writeScores(o, scores, packet.ScoreboardActionModify)
+}
+
+// RemoveScores removes the scores passed from a scoreboard.Objective displayed to the session.
+func (s *Session) RemoveScores(o *scoreboard.Objective, scores []scoreboard.Score) {
+	s.writeScores(o, scores, packet.ScoreboardActionRemove)
+}
+
+// writeScores writes a packet.SetScore with the action passed for all scores passed.
+func (s *Session) writeScores(o *scoreboard.Objective, scores []scoreboard.Score, action byte) {
+	if s == Nop || len(scores) == 0 {
+		return
+	}
+	pk := &packet.SetScore{ActionType: action, Entries: make([]protocol.ScoreboardEntry, 0, len(scores))}
+	for _, score := range scores {
+		entry := protocol.ScoreboardEntry{
+			EntryID:       score.ID,
+			ObjectiveName: o.Name(),
+			Score:         int32(score.Value),
+			IdentityType:  protocol.ScoreboardIdentityFakePlayer,
+			DisplayName:   score.Name,
+		}
+		if score.Entity != nil {
+			id := s.entityRuntimeID(score.Entity)
+			if id == 0 {
+				// The entity is not visible to the session. Its score is sent once it is spawned.
+				continue
+			}
+			entry.IdentityType, entry.EntityUniqueID = protocol.ScoreboardIdentityEntity, int64(id)
+			if _, ok := score.Entity.(Controllable); ok {
+				entry.IdentityType = protocol.ScoreboardIdentityPlayer
+			}
+		}
+		pk.Entries = append(pk.Entries, entry)
+	}
+	if len(pk.Entries) == 0 {
+		return
+	}
+	s.writePacket(pk)
+}
+
+// viewEntityScores sends the scores of the entity passed in all objectives viewed by the session. Scores of an
+// entity can only be sent while the entity is visible to the session, so they are sent again when it is spawned.
+func (s *Session) viewEntityScores(e world.Entity) {
+	s.objectiveMu.Lock()
+	objectives := maps.Values(s.objectives)
+	s.objectiveMu.Unlock()
+
+	for _, o := range objectives {
+		for _, score := range o.Scores() {
+			if score.Entity == e {
+				s.ViewScores(o, []scoreboard.Score{score})
+			}
+		}
+	}
+}
+
+// closeObjectives stops the session from viewing any scoreboard.Objective it currently views.
+func (s *Session) closeObjectives() {
+	s.objectiveMu.Lock()
+	defer s.objectiveMu.Unlock()
+	for slot, o := range s.objectives {
+		o.RemoveViewer(s)
+		delete(s.objectives, slot)
+	}
+}
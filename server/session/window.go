@@ -0,0 +1,149 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"reflect"
+)
+
+// containerTypes holds the container types of the windows opened for blocks, indexed by the type of the block.
+// Blocks not present in the map are opened as a generic container, such as a chest.
+var containerTypes = map[reflect.Type]byte{
+	reflect.TypeOf(block.CraftingTable{}):   protocol.ContainerTypeWorkbench,
+	reflect.TypeOf(block.EnchantingTable{}): protocol.ContainerTypeEnchantment,
+	reflect.TypeOf(block.Anvil{}):           protocol.ContainerTypeAnvil,
+	reflect.TypeOf(block.Beacon{}):          protocol.ContainerTypeBeacon,
+	reflect.TypeOf(block.Loom{}):            protocol.ContainerTypeLoom,
+	reflect.TypeOf(block.Grindstone{}):      protocol.ContainerTypeGrindstone,
+	reflect.TypeOf(block.Stonecutter{}):     protocol.ContainerTypeStonecutter,
+	reflect.TypeOf(block.SmithingTable{}):   protocol.ContainerTypeSmithingTable,
+	reflect.TypeOf(block.Furnace{}):         protocol.ContainerTypeFurnace,
+	reflect.TypeOf(block.BlastFurnace{}):    protocol.ContainerTypeBlastFurnace,
+	reflect.TypeOf(block.Smoker{}):          protocol.ContainerTypeSmoker,
+}
+
+// RegisterContainerType registers the container type of the window opened for blocks of the same type as the
+// block passed, so that custom blocks may open windows such as a furnace or a hopper window. The container type
+// passed is one of the protocol.ContainerType constants. Blocks without a registered container type are opened
+// as a generic container, such as a chest.
+// RegisterContainerType is not safe for concurrent use and should be called before any blocks are opened.
+func RegisterContainerType(b world.Block, containerType byte) {
+	containerTypes[reflect.TypeOf(b)] = containerType
+}
+
+// containerType returns the container type of the window opened for the block passed.
+func containerType(b any) byte {
+	if t, ok := containerTypes[reflect.TypeOf(b)]; ok {
+		return t
+	}
+	return protocol.ContainerTypeContainer
+}
+
+// window is a container window opened by a Session. A Session has at most one window opened at a time, and the
+// window it holds is the single source of truth for the ID, type and contents of the window opened client-side.
+type window struct {
+	// id is the ID of the window, used by the client to refer to it. It is assigned when the window is opened.
+	id byte
+	// containerType is the type of the window, which is one of the protocol.ContainerType constants.
+	containerType byte
+	// inv is the inventory displayed in the window. Windows that do not hold any items server-side, such as a
+	// crafting table, have an empty inventory with a size of 1.
+	inv *inventory.Inventory
+
+	// pos is the position of the block that the window was opened for. It is only valid if entity and menu are
+	// both nil.
+	pos cube.Pos
+	// entity is the entity that the window was opened for, such as a horse or a villager, if any.
+	entity world.Entity
+	// trade is the trade opened in the window, if the window is a trading window.
+	trade *trade
+	// menu is the menu displayed in the window, if the window was opened for a gui.Menu.
+	menu *menu
+}
+
+// openWindow opens the window passed for the Session, after closing the window that is currently opened, if any.
+// The window is assigned the next window ID, which is returned. A ContainerOpen packet is sent to the client for
+// all windows except for trading windows, which are opened by sending the offers of the trade instead.
+func (s *Session) openWindow(w *window) byte {
+	s.closeCurrentContainer()
+
+	w.id = s.nextWindowID()
+	if w.inv == nil {
+		w.inv = inventory.New(1, nil)
+	}
+	s.openedWindow.Store(w)
+	if w.containerType == protocol.ContainerTypeTrade {
+		return w.id
+	}
+
+	pk := &packet.ContainerOpen{
+		WindowID:                w.id,
+		ContainerType:           w.containerType,
+		ContainerEntityUniqueID: -1,
+	}
+	if w.entity != nil {
+		pk.ContainerEntityUniqueID = int64(s.entityRuntimeID(w.entity))
+	} else {
+		pk.ContainerPosition = protocol.BlockPos{int32(w.pos[0]), int32(w.pos[1]), int32(w.pos[2])}
+	}
+	s.writePacket(pk)
+	return w.id
+}
+
+// currentWindow returns the window currently opened by the Session. False is returned if no window is opened.
+func (s *Session) currentWindow() (*window, bool) {
+	w := s.openedWindow.Load()
+	return w, w != nil
+}
+
+// openedTrade returns the trade opened in the window of the Session, or nil if no trading window is opened.
+func (s *Session) openedTrade() *trade {
+	if w, ok := s.currentWindow(); ok {
+		return w.trade
+	}
+	return nil
+}
+
+// openedMenu returns the menu opened in the window of the Session, or nil if no menu is opened.
+func (s *Session) openedMenu() *menu {
+	if w, ok := s.currentWindow(); ok {
+		return w.menu
+	}
+	return nil
+}
+
+// openedBlock returns the block that the window currently opened by the Session was opened for, together with
+// the window itself, if the block is of the type T. False is returned if no window is opened, if the window was
+// not opened for a block or if the block is not of the type T.
+func openedBlock[T any](s *Session) (T, *window, bool) {
+	var zero T
+	w, ok := s.currentWindow()
+	if !ok || w.entity != nil || w.menu != nil {
+		return zero, nil, false
+	}
+	b, ok := s.c.World().Block(w.pos).(T)
+	return b, w, ok
+}
+
+// nextWindowID produces the next window ID for a new window. It is an int of 1-99.
+func (s *Session) nextWindowID() byte {
+	if s.windowID.CAS(99, 1) {
+		return 1
+	}
+	return byte(s.windowID.Add(1))
+}
+
+// closeWindow closes the container window currently opened and returns it. If no window is open, closeWindow
+// does nothing and returns false.
+func (s *Session) closeWindow() (*window, bool) {
+	w := s.openedWindow.Swap(nil)
+	if w == nil {
+		return nil, false
+	}
+	s.writePacket(&packet.ContainerClose{WindowID: w.id})
+	return w, true
+}
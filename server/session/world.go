@@ -1,8 +1,10 @@
 package session
 
 import (
+	"bytes"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"image/color"
+	"math"
 	"math/rand"
 	"strings"
 	"time"
@@ -13,12 +15,14 @@ import (
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
@@ -38,12 +42,35 @@ type OffsetEntity interface {
 	NetworkOffset() float64
 }
 
-// entityHidden checks if a world.Entity is being explicitly hidden from the Session.
+// entityHidden checks if a world.Entity is being explicitly hidden from the Session, or if it is a player in a
+// GameMode that makes it invisible to the Session.
 func (s *Session) entityHidden(e world.Entity) bool {
 	s.entityMutex.RLock()
 	_, ok := s.hiddenEntities[e]
 	s.entityMutex.RUnlock()
-	return ok
+	return ok || s.spectatorHidden(e)
+}
+
+// spectatorHidden checks if a world.Entity is a player in a GameMode that is not visible, such as spectator
+// mode, while the Controllable of the Session is in a GameMode that is visible. Only players that are
+// spectating themselves are able to see other spectators.
+func (s *Session) spectatorHidden(e world.Entity) bool {
+	c, ok := e.(Controllable)
+	if !ok || e == world.Entity(s.c) {
+		return false
+	}
+	return !c.GameMode().Visible() && s.c.GameMode().Visible()
+}
+
+// updateSpectators shows or hides the players around the Controllable of the Session after its GameMode was
+// changed, so that spectators are only shown if the Controllable is spectating too.
+func (s *Session) updateSpectators() {
+	r := float64(s.chunkRadius.Load() << 4)
+	for _, e := range s.c.World().EntitiesWithin(cube.Box(-r, -r, -r, r, r, r).Translate(s.c.Position()), nil) {
+		if e != world.Entity(s.c) {
+			s.ViewEntityGameMode(e)
+		}
+	}
 }
 
 // ViewEntity ...
@@ -53,6 +80,11 @@ func (s *Session) ViewEntity(e world.Entity) {
 		return
 	}
 	if s.entityHidden(e) {
+		if s.spectatorHidden(e) {
+			s.entityMutex.Lock()
+			s.hiddenSpectators[e] = struct{}{}
+			s.entityMutex.Unlock()
+		}
 		return
 	}
 	var runtimeID uint64
@@ -69,6 +101,8 @@ func (s *Session) ViewEntity(e world.Entity) {
 		s.entities[runtimeID] = e
 	}
 	s.entityMutex.Unlock()
+	// Scores bound to the entity may only be sent after the entity was spawned.
+	defer s.viewEntityScores(e)
 
 	yaw, pitch := e.Rotation().Elem()
 	metadata := s.parseEntityMetadata(e)
@@ -105,6 +139,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 			UUID:            v.UUID(),
 			Username:        v.Name(),
 			Yaw:             float32(yaw),
+			EntityLinks:     s.entityLinks(e),
 			AbilityData: protocol.AbilityData{
 				EntityUniqueID: int64(runtimeID),
 				Layers: []protocol.AbilityLayer{{
@@ -156,18 +191,99 @@ func (s *Session) ViewEntity(e world.Entity) {
 		Pitch:           float32(pitch),
 		Yaw:             float32(yaw),
 		HeadYaw:         float32(yaw),
+		Attributes:      s.entityAttributes(e),
+		EntityLinks:     s.entityLinks(e),
 	})
 }
 
+// entityLinks returns the links of an entity with the entities it is riding or that are riding it. Links with
+// entities that the Session is not viewing are not included.
+func (s *Session) entityLinks(e world.Entity) []protocol.EntityLink {
+	var links []protocol.EntityLink
+	if r, ok := e.(entity.Rider); ok {
+		if vehicle, ok := r.Riding(); ok && s.entityRuntimeID(vehicle) != 0 {
+			links = append(links, s.entityLink(r, vehicle, protocol.EntityLinkRider))
+		}
+	}
+	if v, ok := e.(entity.Rideable); ok {
+		if r, ok := v.Rider(); ok && s.entityRuntimeID(r) != 0 {
+			links = append(links, s.entityLink(r, v, protocol.EntityLinkRider))
+		}
+	}
+	return links
+}
+
+// entityLink returns a protocol.EntityLink of the type passed between a rider and the vehicle it is riding.
+func (s *Session) entityLink(rider, vehicle world.Entity, t byte) protocol.EntityLink {
+	return protocol.EntityLink{
+		RiddenEntityUniqueID: int64(s.entityRuntimeID(vehicle)),
+		RiderEntityUniqueID:  int64(s.entityRuntimeID(rider)),
+		Type:                 t,
+		RiderInitiated:       true,
+	}
+}
+
+// entityAttributes returns the attributes of a mob, such as its health and movement speed, to be sent when the
+// mob is spawned.
+func (s *Session) entityAttributes(e world.Entity) []protocol.AttributeValue {
+	m, ok := e.(*entity.Mob)
+	if !ok {
+		return nil
+	}
+	attributes := []protocol.AttributeValue{
+		{Name: "minecraft:health", Value: float32(m.Health()), Max: float32(m.MaxHealth())},
+		{Name: "minecraft:movement", Value: float32(m.Speed()), Max: math.MaxFloat32},
+	}
+	if j, ok := m.Behaviour().(interface{ JumpStrength() float64 }); ok {
+		attributes = append(attributes, protocol.AttributeValue{Name: "minecraft:horse.jump_strength", Value: float32(j.JumpStrength()), Max: 2})
+	}
+	return attributes
+}
+
+// ViewEntityMount ...
+func (s *Session) ViewEntityMount(rider, vehicle world.Entity) {
+	s.writePacket(&packet.SetActorLink{EntityLink: s.entityLink(rider, vehicle, protocol.EntityLinkRider)})
+}
+
+// ViewEntityDismount ...
+func (s *Session) ViewEntityDismount(rider, vehicle world.Entity) {
+	s.writePacket(&packet.SetActorLink{EntityLink: s.entityLink(rider, vehicle, protocol.EntityLinkRemove)})
+}
+
 // ViewEntityGameMode ...
 func (s *Session) ViewEntityGameMode(e world.Entity) {
-	if s.entityHidden(e) {
-		return
-	}
 	c, ok := e.(Controllable)
 	if !ok {
 		return
 	}
+	hide := s.spectatorHidden(e)
+
+	s.entityMutex.Lock()
+	_, explicit := s.hiddenEntities[e]
+	_, spectating := s.hiddenSpectators[e]
+	if hide {
+		s.hiddenSpectators[e] = struct{}{}
+	} else {
+		delete(s.hiddenSpectators, e)
+	}
+	s.entityMutex.Unlock()
+
+	switch {
+	case explicit:
+		return
+	case hide:
+		if !spectating {
+			// The player switched to a GameMode that is not visible to the Session, so it is despawned.
+			s.removeEntity(e)
+		}
+		return
+	case spectating:
+		// The player was previously hidden because of its GameMode, but is now visible again.
+		s.ViewEntity(e)
+		s.ViewEntityState(e)
+		s.ViewEntityItems(e)
+		s.ViewEntityArmour(e)
+	}
 	s.writePacket(&packet.UpdatePlayerGameType{
 		GameType:       gameTypeFromMode(c.GameMode()),
 		PlayerUniqueID: int64(s.entityRuntimeID(c)),
@@ -176,6 +292,15 @@ func (s *Session) ViewEntityGameMode(e world.Entity) {
 
 // HideEntity ...
 func (s *Session) HideEntity(e world.Entity) {
+	s.entityMutex.Lock()
+	delete(s.hiddenSpectators, e)
+	s.entityMutex.Unlock()
+	s.removeEntity(e)
+}
+
+// removeEntity despawns a world.Entity for the Session, keeping the runtime ID of players so that they may be
+// shown again later.
+func (s *Session) removeEntity(e world.Entity) {
 	if s.entityRuntimeID(e) == selfEntityRuntimeID {
 		return
 	}
@@ -385,6 +510,11 @@ func (s *Session) ViewParticle(pos mgl64.Vec3, p world.Particle) {
 			Position:  vec64To32(pos),
 			EventData: int32(world.BlockRuntimeID(pa.Block)) | (int32(pa.Face) << 24),
 		})
+	case particle.MobSpawn:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventParticlesMobBlockSpawn,
+			Position:  vec64To32(pos),
+		})
 	case particle.EndermanTeleport:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventParticlesTeleport,
@@ -461,6 +591,14 @@ func (s *Session) ViewParticle(pos mgl64.Vec3, p world.Particle) {
 			EventType: packet.LevelEventParticleLegacyEvent | 10,
 			Position:  vec64To32(pos),
 		})
+	case particle.Custom:
+		dim, _ := world.DimensionID(s.c.World().Dimension())
+		s.writePacket(&packet.SpawnParticleEffect{
+			Dimension:      byte(dim),
+			EntityUniqueID: -1,
+			Position:       vec64To32(pos),
+			ParticleName:   pa.Identifier,
+		})
 	}
 }
 
@@ -534,6 +672,38 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		return
 	case sound.Teleport:
 		pk.SoundType = packet.SoundEventTeleport
+	case sound.Custom:
+		volume, pitch := so.Volume, so.Pitch
+		if volume == 0 {
+			volume = 1
+		}
+		if pitch == 0 {
+			pitch = 1
+		}
+		s.writePacket(&packet.PlaySound{
+			SoundName: so.Name,
+			Position:  vec64To32(pos),
+			Volume:    float32(volume),
+			Pitch:     float32(pitch),
+		})
+		return
+	case sound.BookPageTurn:
+		s.writePacket(&packet.PlaySound{SoundName: "item.book.page_turn", Position: vec64To32(pos), Volume: 1, Pitch: 1})
+		return
+	case sound.ChiseledBookshelfInsert:
+		name := "insert.chiseled_bookshelf"
+		if so.Enchanted {
+			name = "insert_enchanted.chiseled_bookshelf"
+		}
+		s.writePacket(&packet.PlaySound{SoundName: name, Position: vec64To32(pos), Volume: 1, Pitch: 1})
+		return
+	case sound.ChiseledBookshelfRemove:
+		name := "pickup.chiseled_bookshelf"
+		if so.Enchanted {
+			name = "pickup_enchanted.chiseled_bookshelf"
+		}
+		s.writePacket(&packet.PlaySound{SoundName: name, Position: vec64To32(pos), Volume: 1, Pitch: 1})
+		return
 	case sound.ItemFrameAdd:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventSoundAddItem,
@@ -552,6 +722,12 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 			Position:  vec64To32(pos),
 		})
 		return
+	case sound.Totem:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventSoundTotemUsed,
+			Position:  vec64To32(pos),
+		})
+		return
 	case sound.GhastWarning:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventSoundGhastWarning,
@@ -670,6 +846,8 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType, pk.ExtraData = packet.SoundEventHit, int32(world.BlockRuntimeID(so.Block))
 	case sound.ItemBreak:
 		pk.SoundType = packet.SoundEventBreak
+	case sound.ShieldBlock:
+		pk.SoundType = packet.SoundEventShieldBlock
 	case sound.ItemUseOn:
 		pk.SoundType, pk.ExtraData = packet.SoundEventItemUseOn, int32(world.BlockRuntimeID(so.Block))
 	case sound.Fizz:
@@ -697,6 +875,29 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventBow
 	case sound.ArrowHit:
 		pk.SoundType = packet.SoundEventBowHit
+	case sound.CrossbowLoad:
+		pk.SoundType = packet.SoundEventCrossbowLoadingEnd
+	case sound.CrossbowShoot:
+		pk.SoundType = packet.SoundEventCrossbowShoot
+	case sound.TridentThrow:
+		pk.SoundType = packet.SoundEventTridentThrow
+	case sound.TridentHit:
+		pk.SoundType = packet.SoundEventTridentHit
+	case sound.TridentHitGround:
+		pk.SoundType = packet.SoundEventTridentHitGround
+	case sound.TridentReturn:
+		pk.SoundType = packet.SoundEventTridentReturn
+	case sound.TridentThunder:
+		pk.SoundType = packet.SoundEventTridentThunder
+	case sound.TridentRiptide:
+		switch {
+		case so.Level >= 3:
+			pk.SoundType = packet.SoundEventTridentRiptide3
+		case so.Level == 2:
+			pk.SoundType = packet.SoundEventTridentRiptide2
+		default:
+			pk.SoundType = packet.SoundEventTridentRiptide1
+		}
 	case sound.ItemThrow:
 		pk.SoundType, pk.EntityType = packet.SoundEventThrow, "minecraft:player"
 	case sound.LevelUp:
@@ -760,6 +961,12 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventComposterReady
 	case sound.LecternBookPlace:
 		pk.SoundType = packet.SoundEventLecternBookPlace
+	case sound.CampfireCrackle:
+		pk.SoundType = packet.SoundEventCampfireCrackle
+	case sound.SculkSensorPowerOn:
+		pk.SoundType = packet.SoundEventSculkSensorPowerOn
+	case sound.SculkSensorPowerOff:
+		pk.SoundType = packet.SoundEventSculkSensorPowerOff
 	}
 	s.writePacket(pk)
 }
@@ -789,9 +996,13 @@ func (s *Session) OpenSign(pos cube.Pos, frontSide bool) {
 
 // ViewFurnaceUpdate updates a furnace for the associated session based on previous times.
 func (s *Session) ViewFurnaceUpdate(prevCookTime, cookTime, prevRemainingFuelTime, remainingFuelTime, prevMaxFuelTime, maxFuelTime time.Duration) {
+	w, ok := s.currentWindow()
+	if !ok {
+		return
+	}
 	if prevCookTime != cookTime {
 		s.writePacket(&packet.ContainerSetData{
-			WindowID: byte(s.openedWindowID.Load()),
+			WindowID: w.id,
 			Key:      packet.ContainerDataFurnaceTickCount,
 			Value:    int32(cookTime.Milliseconds() / 50),
 		})
@@ -799,7 +1010,7 @@ func (s *Session) ViewFurnaceUpdate(prevCookTime, cookTime, prevRemainingFuelTim
 
 	if prevRemainingFuelTime != remainingFuelTime {
 		s.writePacket(&packet.ContainerSetData{
-			WindowID: byte(s.openedWindowID.Load()),
+			WindowID: w.id,
 			Key:      packet.ContainerDataFurnaceLitTime,
 			Value:    int32(remainingFuelTime.Milliseconds() / 50),
 		})
@@ -807,7 +1018,7 @@ func (s *Session) ViewFurnaceUpdate(prevCookTime, cookTime, prevRemainingFuelTim
 
 	if prevMaxFuelTime != maxFuelTime {
 		s.writePacket(&packet.ContainerSetData{
-			WindowID: byte(s.openedWindowID.Load()),
+			WindowID: w.id,
 			Key:      packet.ContainerDataFurnaceLitDuration,
 			Value:    int32(maxFuelTime.Milliseconds() / 50),
 		})
@@ -817,22 +1028,69 @@ func (s *Session) ViewFurnaceUpdate(prevCookTime, cookTime, prevRemainingFuelTim
 // ViewBlockUpdate ...
 func (s *Session) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
 	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
-	s.writePacket(&packet.UpdateBlock{
-		Position:          blockPos,
-		NewBlockRuntimeID: world.BlockRuntimeID(b),
-		Flags:             packet.BlockUpdateNetwork,
-		Layer:             uint32(layer),
-	})
+	entry := protocol.BlockChangeEntry{
+		BlockPos:       blockPos,
+		BlockRuntimeID: world.BlockRuntimeID(b),
+		Flags:          packet.BlockUpdateNetwork,
+	}
+	subPos := protocol.SubChunkPos{blockPos[0] >> 4, blockPos[1] >> 4, blockPos[2] >> 4}
+
+	s.blockUpdateMu.Lock()
+	defer s.blockUpdateMu.Unlock()
+	pk, ok := s.blockUpdates[subPos]
+	if !ok {
+		pk = &packet.UpdateSubChunkBlocks{Position: subPos}
+		s.blockUpdates[subPos] = pk
+	}
+	if layer == 0 {
+		pk.Blocks = append(pk.Blocks, entry)
+	} else {
+		pk.Extra = append(pk.Extra, entry)
+	}
 	if v, ok := b.(world.NBTer); ok {
 		NBTData := v.EncodeNBT()
 		NBTData["x"], NBTData["y"], NBTData["z"] = int32(pos.X()), int32(pos.Y()), int32(pos.Z())
-		s.writePacket(&packet.BlockActorData{
+		s.blockEntityUpdates = append(s.blockEntityUpdates, &packet.BlockActorData{
 			Position: blockPos,
 			NBTData:  NBTData,
 		})
 	}
 }
 
+// sendBlockUpdates sends all block changes viewed since the last call to sendBlockUpdates to the client. Changes
+// within the same sub chunk are sent in a single packet, while a sub chunk with only a single change is sent
+// using an UpdateBlock packet.
+func (s *Session) sendBlockUpdates() {
+	s.blockUpdateMu.Lock()
+	updates, blockEntities := s.blockUpdates, s.blockEntityUpdates
+	if len(updates) == 0 {
+		s.blockUpdateMu.Unlock()
+		return
+	}
+	s.blockUpdates, s.blockEntityUpdates = map[protocol.SubChunkPos]*packet.UpdateSubChunkBlocks{}, nil
+	s.blockUpdateMu.Unlock()
+
+	for _, pk := range updates {
+		if len(pk.Blocks)+len(pk.Extra) != 1 {
+			s.writePacket(pk)
+			continue
+		}
+		entry, layer := pk.Extra, uint32(1)
+		if len(pk.Blocks) == 1 {
+			entry, layer = pk.Blocks, 0
+		}
+		s.writePacket(&packet.UpdateBlock{
+			Position:          entry[0].BlockPos,
+			NewBlockRuntimeID: entry[0].BlockRuntimeID,
+			Flags:             entry[0].Flags,
+			Layer:             layer,
+		})
+	}
+	for _, pk := range blockEntities {
+		s.writePacket(pk)
+	}
+}
+
 // ViewEntityAction ...
 func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 	switch act := a.(type) {
@@ -866,6 +1124,16 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			EntityRuntimeID: s.entityRuntimeID(e),
 			EventType:       packet.ActorEventDeath,
 		})
+	case entity.WakeUpAction:
+		s.writePacket(&packet.Animate{
+			ActionType:      packet.AnimateActionStopSleep,
+			EntityRuntimeID: s.entityRuntimeID(e),
+		})
+	case entity.TotemUseAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventTalismanActivate,
+		})
 	case entity.PickedUpAction:
 		s.writePacket(&packet.TakeItemActor{
 			ItemEntityRuntimeID:  s.entityRuntimeID(e),
@@ -877,6 +1145,11 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			EventType:       packet.ActorEventShake,
 			EventData:       int32(act.Duration.Milliseconds() / 50),
 		})
+	case entity.LoveAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventInLoveHearts,
+		})
 	case entity.FireworkExplosionAction:
 		s.writePacket(&packet.ActorEvent{
 			EntityRuntimeID: s.entityRuntimeID(e),
@@ -926,7 +1199,7 @@ func (s *Session) ViewEntityAnimation(e world.Entity, animationName string) {
 
 // OpenBlockContainer ...
 func (s *Session) OpenBlockContainer(pos cube.Pos) {
-	if s.containerOpened.Load() && s.openedPos.Load() == pos {
+	if w, ok := s.currentWindow(); ok && w.entity == nil && w.menu == nil && w.pos == pos {
 		return
 	}
 	s.closeCurrentContainer()
@@ -938,78 +1211,64 @@ func (s *Session) OpenBlockContainer(pos cube.Pos) {
 		return
 	}
 	// We hit a special kind of window like beacons, which are not actually opened server-side.
-	nextID := s.nextWindowID()
-	s.containerOpened.Store(true)
-	s.openedWindow.Store(inventory.New(1, nil))
-	s.openedPos.Store(pos)
-
-	var containerType byte
-	switch b := b.(type) {
-	case block.CraftingTable:
-		containerType = protocol.ContainerTypeWorkbench
-	case block.EnchantingTable:
-		containerType = protocol.ContainerTypeEnchantment
-	case block.Anvil:
-		containerType = protocol.ContainerTypeAnvil
-	case block.Beacon:
-		containerType = protocol.ContainerTypeBeacon
-	case block.Loom:
-		containerType = protocol.ContainerTypeLoom
-	case block.Grindstone:
-		containerType = protocol.ContainerTypeGrindstone
-	case block.Stonecutter:
-		containerType = protocol.ContainerTypeStonecutter
-	case block.SmithingTable:
-		containerType = protocol.ContainerTypeSmithingTable
-	case block.EnderChest:
-		b.AddViewer(w, pos)
-
-		inv := s.c.EnderChestInventory()
-		s.openedWindow.Store(inv)
-
-		defer s.sendInv(inv, uint32(nextID))
+	win := &window{containerType: containerType(b), pos: pos}
+	if enderChest, ok := b.(block.EnderChest); ok {
+		enderChest.AddViewer(w, pos)
+		win.inv = s.c.EnderChestInventory()
+	}
+	nextID := s.openWindow(win)
+	if _, ok := b.(block.EnderChest); ok {
+		s.sendInv(win.inv, uint32(nextID))
 	}
-
-	s.openedContainerID.Store(uint32(containerType))
-	s.writePacket(&packet.ContainerOpen{
-		WindowID:                nextID,
-		ContainerType:           containerType,
-		ContainerPosition:       protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
-		ContainerEntityUniqueID: -1,
-	})
 }
 
 // openNormalContainer opens a normal container that can hold items in it server-side.
 func (s *Session) openNormalContainer(b block.Container, pos cube.Pos) {
 	b.AddViewer(s, s.c.World(), pos)
 
-	nextID := s.nextWindowID()
-	s.containerOpened.Store(true)
-	s.openedWindow.Store(b.Inventory())
-	s.openedPos.Store(pos)
-
-	var containerType byte
-	switch b.(type) {
-	case block.Furnace:
-		containerType = protocol.ContainerTypeFurnace
-	case block.BlastFurnace:
-		containerType = protocol.ContainerTypeBlastFurnace
-	case block.Smoker:
-		containerType = protocol.ContainerTypeSmoker
+	nextID := s.openWindow(&window{containerType: containerType(b), inv: b.Inventory(), pos: pos})
+	s.sendInv(b.Inventory(), uint32(nextID))
+}
+
+// OpenHorseInventory opens the inventory of the horse passed for the Session. The inventory holds the saddle
+// and the armour of the horse.
+func (s *Session) OpenHorseInventory(e world.Entity, inv *inventory.Inventory) {
+	nextID := s.openWindow(&window{containerType: protocol.ContainerTypeHorse, inv: inv, entity: e})
+
+	id := int64(s.entityRuntimeID(e))
+	slots := make([]any, 0, inv.Size())
+	for slot, accepted := range [][]world.Item{
+		inventory.HorseSaddleSlot: {item.Saddle{}},
+		inventory.HorseArmourSlot: {item.HorseArmour{Tier: item.ArmourTierLeather{}}, item.HorseArmour{Tier: item.ArmourTierGold{}}, item.HorseArmour{Tier: item.ArmourTierIron{}}, item.HorseArmour{Tier: item.ArmourTierDiamond{}}},
+	} {
+		acceptedItems := make([]any, 0, len(accepted))
+		for _, it := range accepted {
+			acceptedItems = append(acceptedItems, map[string]any{"slotItem": nbtconv.WriteItem(item.NewStack(it, 1), false)})
+		}
+		current, _ := inv.Item(slot)
+		slots = append(slots, map[string]any{
+			"slotNumber":    int32(slot),
+			"item":          nbtconv.WriteItem(current, false),
+			"acceptedItems": acceptedItems,
+		})
 	}
+	buf := bytes.NewBuffer(nil)
+	_ = nbt.NewEncoderWithEncoding(buf, nbt.NetworkLittleEndian).Encode(map[string]any{"slots": slots})
 
-	s.writePacket(&packet.ContainerOpen{
+	s.writePacket(&packet.UpdateEquip{
 		WindowID:                nextID,
-		ContainerType:           containerType,
-		ContainerPosition:       protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
-		ContainerEntityUniqueID: -1,
+		WindowType:              protocol.ContainerTypeHorse,
+		Size:                    int32(inv.Size()),
+		EntityUniqueID:          id,
+		SerialisedInventoryData: buf.Bytes(),
 	})
-	s.sendInv(b.Inventory(), uint32(nextID))
+	s.sendInv(inv, uint32(nextID))
 }
 
 // ViewSlotChange ...
 func (s *Session) ViewSlotChange(slot int, newItem item.Stack) {
-	if !s.containerOpened.Load() {
+	w, ok := s.currentWindow()
+	if !ok {
 		return
 	}
 	if s.inTransaction.Load() {
@@ -1017,7 +1276,7 @@ func (s *Session) ViewSlotChange(slot int, newItem item.Stack) {
 		return
 	}
 	s.writePacket(&packet.InventorySlot{
-		WindowID: s.openedWindowID.Load(),
+		WindowID: uint32(w.id),
 		Slot:     uint32(slot),
 		NewItem:  instanceFromItem(newItem),
 	})
@@ -1070,8 +1329,7 @@ func (s *Session) ViewEmote(player world.Entity, emote uuid.UUID) {
 
 // ViewSkin ...
 func (s *Session) ViewSkin(e world.Entity) {
-	switch v := e.(type) {
-	case Controllable:
+	if v, ok := e.(skinned); ok {
 		s.writePacket(&packet.PlayerSkin{
 			UUID: v.UUID(),
 			Skin: skinToProtocol(v.Skin()),
@@ -1079,13 +1337,20 @@ func (s *Session) ViewSkin(e world.Entity) {
 	}
 }
 
+// skinned is an entity with a skin, such as a player or a human-like NPC.
+type skinned interface {
+	UUID() uuid.UUID
+	Skin() skin.Skin
+}
+
 // ViewWorldSpawn ...
-func (s *Session) ViewWorldSpawn(pos cube.Pos) {
+func (s *Session) ViewWorldSpawn(pos cube.Pos, dim world.Dimension) {
 	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+	id, _ := world.DimensionID(dim)
 	s.writePacket(&packet.SetSpawnPosition{
 		SpawnType:     packet.SpawnTypeWorld,
 		Position:      blockPos,
-		Dimension:     packet.DimensionOverworld,
+		Dimension:     int32(id),
 		SpawnPosition: blockPos,
 	})
 }
@@ -1109,25 +1374,6 @@ func (s *Session) ViewWeather(raining, thunder bool) {
 	s.writePacket(pk)
 }
 
-// nextWindowID produces the next window ID for a new window. It is an int of 1-99.
-func (s *Session) nextWindowID() byte {
-	if s.openedWindowID.CAS(99, 1) {
-		return 1
-	}
-	return byte(s.openedWindowID.Add(1))
-}
-
-// closeWindow closes the container window currently opened. If no window is open, closeWindow will do
-// nothing.
-func (s *Session) closeWindow() {
-	if !s.containerOpened.CAS(true, false) {
-		return
-	}
-	s.openedContainerID.Store(0)
-	s.openedWindow.Store(inventory.New(1, nil))
-	s.writePacket(&packet.ContainerClose{WindowID: byte(s.openedWindowID.Load())})
-}
-
 // entityRuntimeID returns the runtime ID of the entity passed.
 // noinspection GoCommentLeadingSpace
 func (s *Session) entityRuntimeID(e world.Entity) uint64 {
@@ -4,19 +4,48 @@ import (
 	"github.com/sandertv/gophertunnel/minecraft"
 )
 
-// statusProvider handles the way the server shows up in the server list. The
-// online players and maximum players are not changeable from outside the
-// server, but the server name may be changed at any time.
+// statusProvider handles the way the server shows up in the server list. By
+// default, it shows the name of the server and the amount of players online on
+// all of its listeners. If the Config of the Server has a StatusProvider set,
+// the status is obtained from it instead. The DisplayedMaxPlayers of the
+// Config, if set, are always shown instead of the actual maximum.
 type statusProvider struct {
-	name string
+	srv    *Server
+	custom minecraft.ServerStatusProvider
 }
 
 // ServerStatus returns the player count, max players and the server's name as
 // a minecraft.ServerStatus.
-func (s statusProvider) ServerStatus(playerCount, maxPlayers int) minecraft.ServerStatus {
-	return minecraft.ServerStatus{
-		ServerName:  s.name,
-		PlayerCount: playerCount,
+func (s statusProvider) ServerStatus(_, maxPlayers int) minecraft.ServerStatus {
+	conf := s.srv.reloadable()
+	status := minecraft.ServerStatus{
+		ServerName:  conf.Name,
+		PlayerCount: len(s.srv.Players()),
 		MaxPlayers:  maxPlayers,
 	}
+	if s.custom != nil {
+		status = s.custom.ServerStatus(status.PlayerCount, maxPlayers)
+	}
+	if n := conf.DisplayedMaxPlayers; n > 0 {
+		status.MaxPlayers = n
+	}
+	return status
+}
+
+// queryData returns the queryData of the Server.
+func (s statusProvider) queryData() queryData {
+	players := s.srv.Players()
+	names := make([]string, len(players))
+	for i, p := range players {
+		names[i] = p.Name()
+	}
+	d := queryData{
+		status:  s.ServerStatus(len(players), s.srv.MaxPlayerCount()),
+		world:   s.srv.World().Name(),
+		players: names,
+	}
+	if m := s.srv.conf.Moderation; m != nil {
+		d.whitelist = m.WhitelistEnabled()
+	}
+	return d
 }
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft"
+	"net"
+	"sync"
+	"time"
+)
+
+// throttledListener is a minecraft.NetworkListener that limits the rate at which data is sent to connections
+// until they have logged in. Resource packs are sent to clients while they log in, so throttledListener limits
+// the bandwidth used for sending resource packs.
+type throttledListener struct {
+	minecraft.NetworkListener
+	rate int
+
+	conns sync.Map
+}
+
+// Accept accepts the next connection of the listener and throttles the data sent to it.
+func (l *throttledListener) Accept() (net.Conn, error) {
+	conn, err := l.NetworkListener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	c := &throttledConn{Conn: conn, l: l, rate: l.rate, last: time.Now()}
+	l.conns.Store(conn.RemoteAddr().String(), c)
+	return c, nil
+}
+
+// release stops throttling the data sent to the connection with the address passed. It is called once the
+// connection has logged in.
+func (l *throttledListener) release(addr net.Addr) {
+	if c, ok := l.conns.LoadAndDelete(addr.String()); ok {
+		c.(*throttledConn).release()
+	}
+}
+
+// throttledConn is a net.Conn of which writes are limited to a rate in bytes per second until it is released.
+type throttledConn struct {
+	net.Conn
+	l    *throttledListener
+	rate int
+
+	mu       sync.Mutex
+	released bool
+	// allowance is the amount of bytes that may still be written without waiting, refilled over time at the
+	// rate of the throttledConn.
+	allowance float64
+	last      time.Time
+}
+
+// Write writes b to the connection, first waiting until enough time has passed to stay within the rate of the
+// throttledConn.
+func (c *throttledConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	if !c.released {
+		now := time.Now()
+		c.allowance += now.Sub(c.last).Seconds() * float64(c.rate)
+		if limit := float64(c.rate); c.allowance > limit {
+			// Allow bursts of at most a second worth of data.
+			c.allowance = limit
+		}
+		c.last = now
+		if c.allowance -= float64(len(b)); c.allowance < 0 {
+			time.Sleep(time.Duration(-c.allowance / float64(c.rate) * float64(time.Second)))
+		}
+	}
+	c.mu.Unlock()
+	return c.Conn.Write(b)
+}
+
+// Close closes the connection and stops throttling it.
+func (c *throttledConn) Close() error {
+	c.l.release(c.RemoteAddr())
+	return c.Conn.Close()
+}
+
+// release stops throttling writes to the connection.
+func (c *throttledConn) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.released = true
+}
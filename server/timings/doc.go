@@ -0,0 +1,4 @@
+// Package timings implements a profiler that records how long specific systems of the server, such as ticking
+// worlds, entities and blocks and handling packets, take to run. A Report of the timings recorded may be
+// obtained using `timings.Global.Report()`, so that the systems causing the server to lag can be found.
+package timings
@@ -0,0 +1,137 @@
+package timings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Global is the Timings that the server records the durations of its systems to, such as ticking worlds,
+// ticking entities and blocks and handling packets. A report of it may be obtained using Global.Report() to
+// find out what is causing the server to lag.
+var Global = New()
+
+// Timings records how long specific systems take to run. Every system is identified by a name, such as
+// 'world.tick.entities'. The zero value of Timings is not ready for use: New must be used to create one.
+// Methods on Timings may be called from multiple goroutines concurrently.
+type Timings struct {
+	mu      sync.Mutex
+	since   time.Time
+	records map[string]*record
+}
+
+// record holds the timings recorded for a single system.
+type record struct {
+	count    int64
+	total    time.Duration
+	max      time.Duration
+	lastTick time.Duration
+}
+
+// New returns a new, empty Timings.
+func New() *Timings {
+	return &Timings{since: time.Now(), records: make(map[string]*record)}
+}
+
+// Start starts timing the system with the name passed. The function returned must be called when the system
+// finished running, after which the time elapsed is recorded. Start is typically used as such:
+//
+//	defer timings.Global.Start("world.tick")()
+func (t *Timings) Start(name string) func() {
+	start := time.Now()
+	return func() {
+		t.Record(name, time.Since(start))
+	}
+}
+
+// Record records that the system with the name passed took d to run.
+func (t *Timings) Record(name string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.records[name]
+	if !ok {
+		r = &record{}
+		t.records[name] = r
+	}
+	r.count++
+	r.total += d
+	r.lastTick = d
+	if d > r.max {
+		r.max = d
+	}
+}
+
+// Reset clears all timings recorded so far. Reports obtained after calling Reset only hold timings recorded
+// after the call.
+func (t *Timings) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.since = time.Now()
+	t.records = make(map[string]*record)
+}
+
+// Report returns a Report of all timings recorded since the Timings was created or last Reset.
+func (t *Timings) Report() Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r := Report{Duration: time.Since(t.since), Entries: make([]Entry, 0, len(t.records))}
+	for name, rec := range t.records {
+		r.Entries = append(r.Entries, Entry{Name: name, Count: rec.count, Total: rec.total, Max: rec.max, Last: rec.lastTick})
+	}
+	sort.Slice(r.Entries, func(i, j int) bool {
+		return r.Entries[i].Total > r.Entries[j].Total
+	})
+	return r
+}
+
+// Report is a report of the timings recorded by a Timings. It may be printed to get a human-readable overview.
+type Report struct {
+	// Duration is the duration over which the timings in the Report were recorded.
+	Duration time.Duration
+	// Entries holds the timings of every system recorded, sorted from the largest total duration to the
+	// smallest.
+	Entries []Entry
+}
+
+// Entry holds the timings of a single system in a Report.
+type Entry struct {
+	// Name is the name of the system, such as 'world.tick.entities'.
+	Name string
+	// Count is the amount of times the system was run.
+	Count int64
+	// Total is the total duration that the system took to run.
+	Total time.Duration
+	// Max is the longest duration that a single run of the system took.
+	Max time.Duration
+	// Last is the duration of the most recent run of the system.
+	Last time.Duration
+}
+
+// Average returns the average duration of a single run of the system.
+func (e Entry) Average() time.Duration {
+	if e.Count == 0 {
+		return 0
+	}
+	return e.Total / time.Duration(e.Count)
+}
+
+// Share returns the percentage of the duration of the Report passed that the system took to run.
+func (e Entry) Share(r Report) float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(e.Total) / float64(r.Duration) * 100
+}
+
+// String formats the Report as a table, listing every system with its total, average and maximum durations.
+func (r Report) String() string {
+	sb := &strings.Builder{}
+	_, _ = fmt.Fprintf(sb, "Timings report over %v:\n", r.Duration.Round(time.Millisecond))
+	for _, e := range r.Entries {
+		_, _ = fmt.Fprintf(sb, "  %v: %.2f%% total=%v count=%v avg=%v max=%v\n", e.Name, e.Share(r), e.Total.Round(time.Microsecond), e.Count, e.Average().Round(time.Microsecond), e.Max.Round(time.Microsecond))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,302 @@
+package version
+
+import (
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"golang.org/x/exp/slices"
+)
+
+// proto implements minecraft.Protocol for a Version.
+type proto struct {
+	v Version
+}
+
+// ID ...
+func (p proto) ID() int32 {
+	return p.v.Protocol
+}
+
+// Ver ...
+func (p proto) Ver() string {
+	return p.v.Name
+}
+
+// Packets ...
+func (p proto) Packets(listener bool) packet.Pool {
+	if p.v.Pool != nil {
+		return p.v.Pool(listener)
+	}
+	return minecraft.DefaultProtocol.Packets(listener)
+}
+
+// NewReader ...
+func (p proto) NewReader(r minecraft.ByteReader, shieldID int32, enableLimits bool) protocol.IO {
+	return protocol.NewReader(r, shieldID, enableLimits)
+}
+
+// NewWriter ...
+func (p proto) NewWriter(w minecraft.ByteWriter, shieldID int32) protocol.IO {
+	return protocol.NewWriter(w, shieldID)
+}
+
+// ConvertToLatest converts a packet read from a client on the Version to packets of the latest protocol.
+func (p proto) ConvertToLatest(pk packet.Packet, _ *minecraft.Conn) []packet.Packet {
+	if status, ok := pk.(*packet.ClientCacheStatus); ok && len(p.v.Blocks.toLatest) != 0 {
+		// Cached chunk blobs cannot be told apart from each other, so their block runtime IDs cannot be
+		// converted. The client is forced to receive chunks without the blob cache instead.
+		status.Enabled = false
+	}
+	pks := []packet.Packet{pk}
+	if f, ok := p.v.Upgrade[pk.ID()]; ok {
+		pks = f(pk)
+	}
+	for i, pk := range pks {
+		pks[i] = p.translate(pk, p.v.Blocks.ToLatest, p.v.Items.ToLatest)
+	}
+	return pks
+}
+
+// ConvertFromLatest converts a packet of the latest protocol to packets to be sent to a client on the
+// Version.
+func (p proto) ConvertFromLatest(pk packet.Packet, _ *minecraft.Conn) []packet.Packet {
+	pks := []packet.Packet{pk}
+	if f, ok := p.v.Downgrade[pk.ID()]; ok {
+		pks = f(pk)
+	}
+	for i, pk := range pks {
+		pks[i] = p.translate(pk, p.v.Blocks.FromLatest, p.v.Items.FromLatest)
+		if len(p.v.Blocks.fromLatest) != 0 {
+			pks[i] = p.translateChunk(pks[i], p.v.Blocks.FromLatest)
+		}
+	}
+	return pks
+}
+
+// translate converts the block runtime IDs and item network IDs found in a packet using the functions
+// passed. The packet passed may be shared with other connections, so it is never changed: A converted copy
+// of the packet is returned instead.
+func (p proto) translate(pk packet.Packet, block func(uint32) uint32, item func(int32) int32) packet.Packet {
+	stack := func(s protocol.ItemStack) protocol.ItemStack {
+		if s.NetworkID != 0 {
+			s.NetworkID = item(s.NetworkID)
+		}
+		if s.BlockRuntimeID != 0 {
+			s.BlockRuntimeID = int32(block(uint32(s.BlockRuntimeID)))
+		}
+		return s
+	}
+	stacks := func(s []protocol.ItemStack) []protocol.ItemStack {
+		s = slices.Clone(s)
+		for i := range s {
+			s[i] = stack(s[i])
+		}
+		return s
+	}
+	instance := func(i protocol.ItemInstance) protocol.ItemInstance {
+		i.Stack = stack(i.Stack)
+		return i
+	}
+	descriptor := func(d protocol.ItemDescriptorCount) protocol.ItemDescriptorCount {
+		if def, ok := d.Descriptor.(*protocol.DefaultItemDescriptor); ok && def.NetworkID != 0 {
+			d.Descriptor = &protocol.DefaultItemDescriptor{NetworkID: int16(item(int32(def.NetworkID))), MetadataValue: def.MetadataValue}
+		}
+		return d
+	}
+	descriptors := func(d []protocol.ItemDescriptorCount) []protocol.ItemDescriptorCount {
+		d = slices.Clone(d)
+		for i := range d {
+			d[i] = descriptor(d[i])
+		}
+		return d
+	}
+	entries := func(e []protocol.BlockChangeEntry) []protocol.BlockChangeEntry {
+		e = slices.Clone(e)
+		for i := range e {
+			e[i].BlockRuntimeID = block(e[i].BlockRuntimeID)
+		}
+		return e
+	}
+	useItem := func(data protocol.UseItemTransactionData) protocol.UseItemTransactionData {
+		data.HeldItem = instance(data.HeldItem)
+		data.BlockRuntimeID = block(data.BlockRuntimeID)
+		return data
+	}
+	request := func(r protocol.ItemStackRequest) protocol.ItemStackRequest {
+		r.Actions = slices.Clone(r.Actions)
+		for i, action := range r.Actions {
+			if results, ok := action.(*protocol.CraftResultsDeprecatedStackRequestAction); ok {
+				r.Actions[i] = &protocol.CraftResultsDeprecatedStackRequestAction{ResultItems: stacks(results.ResultItems), TimesCrafted: results.TimesCrafted}
+			}
+		}
+		return r
+	}
+
+	switch pk := pk.(type) {
+	case *packet.UpdateBlock:
+		c := *pk
+		c.NewBlockRuntimeID = block(pk.NewBlockRuntimeID)
+		return &c
+	case *packet.UpdateBlockSynced:
+		c := *pk
+		c.NewBlockRuntimeID = block(pk.NewBlockRuntimeID)
+		return &c
+	case *packet.UpdateSubChunkBlocks:
+		c := *pk
+		c.Blocks, c.Extra = entries(pk.Blocks), entries(pk.Extra)
+		return &c
+	case *packet.InventoryContent:
+		c := *pk
+		c.Content = slices.Clone(pk.Content)
+		for i := range c.Content {
+			c.Content[i] = instance(c.Content[i])
+		}
+		return &c
+	case *packet.InventorySlot:
+		c := *pk
+		c.NewItem = instance(pk.NewItem)
+		return &c
+	case *packet.MobEquipment:
+		c := *pk
+		c.NewItem = instance(pk.NewItem)
+		return &c
+	case *packet.MobArmourEquipment:
+		c := *pk
+		c.Helmet, c.Chestplate = instance(pk.Helmet), instance(pk.Chestplate)
+		c.Leggings, c.Boots = instance(pk.Leggings), instance(pk.Boots)
+		return &c
+	case *packet.AddItemActor:
+		c := *pk
+		c.Item = instance(pk.Item)
+		return &c
+	case *packet.AddPlayer:
+		c := *pk
+		c.HeldItem = instance(pk.HeldItem)
+		return &c
+	case *packet.CreativeContent:
+		c := *pk
+		c.Items = slices.Clone(pk.Items)
+		for i := range c.Items {
+			c.Items[i].Item = stack(c.Items[i].Item)
+		}
+		return &c
+	case *packet.StartGame:
+		c := *pk
+		c.Items = slices.Clone(pk.Items)
+		for i := range c.Items {
+			c.Items[i].RuntimeID = int16(item(int32(c.Items[i].RuntimeID)))
+		}
+		return &c
+	case *packet.CraftingData:
+		c := *pk
+		c.Recipes = slices.Clone(pk.Recipes)
+		for i, recipe := range c.Recipes {
+			switch r := recipe.(type) {
+			case *protocol.ShapelessRecipe:
+				cr := *r
+				cr.Input, cr.Output = descriptors(r.Input), stacks(r.Output)
+				c.Recipes[i] = &cr
+			case *protocol.ShapedRecipe:
+				cr := *r
+				cr.Input, cr.Output = descriptors(r.Input), stacks(r.Output)
+				c.Recipes[i] = &cr
+			case *protocol.FurnaceRecipe:
+				cr := *r
+				cr.InputType.NetworkID, cr.Output = item(r.InputType.NetworkID), stack(r.Output)
+				c.Recipes[i] = &cr
+			case *protocol.FurnaceDataRecipe:
+				cr := *r
+				cr.InputType.NetworkID, cr.Output = item(r.InputType.NetworkID), stack(r.Output)
+				c.Recipes[i] = &cr
+			case *protocol.SmithingTransformRecipe:
+				cr := *r
+				cr.Template, cr.Base, cr.Addition = descriptor(r.Template), descriptor(r.Base), descriptor(r.Addition)
+				cr.Result = stack(r.Result)
+				c.Recipes[i] = &cr
+			case *protocol.SmithingTrimRecipe:
+				cr := *r
+				cr.Template, cr.Base, cr.Addition = descriptor(r.Template), descriptor(r.Base), descriptor(r.Addition)
+				c.Recipes[i] = &cr
+			}
+		}
+		c.PotionRecipes = slices.Clone(pk.PotionRecipes)
+		for i, r := range c.PotionRecipes {
+			c.PotionRecipes[i].InputPotionID, c.PotionRecipes[i].ReagentItemID = item(r.InputPotionID), item(r.ReagentItemID)
+			c.PotionRecipes[i].OutputPotionID = item(r.OutputPotionID)
+		}
+		c.PotionContainerChangeRecipes = slices.Clone(pk.PotionContainerChangeRecipes)
+		for i, r := range c.PotionContainerChangeRecipes {
+			c.PotionContainerChangeRecipes[i].InputItemID, c.PotionContainerChangeRecipes[i].ReagentItemID = item(r.InputItemID), item(r.ReagentItemID)
+			c.PotionContainerChangeRecipes[i].OutputItemID = item(r.OutputItemID)
+		}
+		return &c
+	case *packet.ItemStackRequest:
+		c := *pk
+		c.Requests = slices.Clone(pk.Requests)
+		for i := range c.Requests {
+			c.Requests[i] = request(c.Requests[i])
+		}
+		return &c
+	case *packet.PlayerAuthInput:
+		c := *pk
+		c.ItemInteractionData = useItem(pk.ItemInteractionData)
+		c.ItemStackRequest = request(pk.ItemStackRequest)
+		return &c
+	case *packet.InventoryTransaction:
+		c := *pk
+		c.Actions = slices.Clone(pk.Actions)
+		for i := range c.Actions {
+			c.Actions[i].OldItem, c.Actions[i].NewItem = instance(c.Actions[i].OldItem), instance(c.Actions[i].NewItem)
+		}
+		switch data := pk.TransactionData.(type) {
+		case *protocol.UseItemTransactionData:
+			d := useItem(*data)
+			c.TransactionData = &d
+		case *protocol.UseItemOnEntityTransactionData:
+			d := *data
+			d.HeldItem = instance(data.HeldItem)
+			c.TransactionData = &d
+		case *protocol.ReleaseItemTransactionData:
+			d := *data
+			d.HeldItem = instance(data.HeldItem)
+			c.TransactionData = &d
+		}
+		return &c
+	}
+	return pk
+}
+
+// translateChunk converts the block runtime IDs in the palettes of the chunk data sent in a packet using the
+// function passed. Only chunk data sent without the client blob cache is converted, which is disabled for
+// Versions with a block table by ConvertToLatest.
+func (p proto) translateChunk(pk packet.Packet, block func(uint32) uint32) packet.Packet {
+	switch pk := pk.(type) {
+	case *packet.LevelChunk:
+		if pk.CacheEnabled || pk.SubChunkCount >= protocol.SubChunkRequestModeLimited {
+			// Either the sub chunks are sent using blobs, or they are sent separately in SubChunk packets.
+			return pk
+		}
+		c := *pk
+		if payload, err := chunk.NetworkConvert(pk.RawPayload, int(pk.SubChunkCount), block); err == nil {
+			c.RawPayload = payload
+		}
+		return &c
+	case *packet.SubChunk:
+		if pk.CacheEnabled {
+			return pk
+		}
+		c := *pk
+		c.SubChunkEntries = slices.Clone(pk.SubChunkEntries)
+		for i, entry := range c.SubChunkEntries {
+			if entry.Result != protocol.SubChunkResultSuccess {
+				continue
+			}
+			if payload, err := chunk.NetworkConvert(entry.RawPayload, 1, block); err == nil {
+				c.SubChunkEntries[i].RawPayload = payload
+			}
+		}
+		return &c
+	}
+	return pk
+}
@@ -0,0 +1,128 @@
+// Package version implements a translation layer that allows clients on protocol versions other than the
+// latest to join a server. Each supported version is described by a Version, which holds the conversion
+// tables for block runtime IDs and item network IDs and the packets that changed compared to the latest
+// protocol. Versions are added using Register, after which they are accepted by the default listener.
+//
+// Chunk payloads sent to a Version with a block table have the palettes of their sub chunks converted. The
+// client blob cache is disabled for clients on such a Version, as cached blobs cannot be converted.
+package version
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"sync"
+)
+
+// Version describes a protocol version other than the latest one and how packets are converted between that
+// version and the latest one.
+type Version struct {
+	// Protocol is the protocol ID of the version, such as 560.
+	Protocol int32
+	// Name is the Minecraft version associated with the protocol, such as "1.19.50".
+	Name string
+	// Pool returns the packet.Pool holding the packets of the version. If listener is true, the pool should
+	// only hold packets that may be sent by a client. If nil, the pools of the latest protocol are used.
+	Pool func(listener bool) packet.Pool
+	// Blocks holds the conversion of block runtime IDs between the version and the latest protocol. Block
+	// runtime IDs absent from the Table are sent unchanged.
+	Blocks Table[uint32]
+	// Items holds the conversion of item network IDs between the version and the latest protocol. Network IDs
+	// absent from the Table are sent unchanged.
+	Items Table[int32]
+	// Upgrade holds functions, indexed by packet ID, that convert a packet read from a client on the version
+	// to packets of the latest protocol. Packets without a function are only converted using the Blocks and
+	// Items tables.
+	Upgrade map[uint32]Converter
+	// Downgrade holds functions, indexed by packet ID, that convert a packet of the latest protocol to packets
+	// to be sent to a client on the version. They are called before block runtime IDs and item network IDs in
+	// the packets returned are converted. The packet passed may be shared with other connections, so it must
+	// not be changed in place.
+	Downgrade map[uint32]Converter
+}
+
+// Converter converts a packet between a Version and the latest protocol. It may return any number of packets,
+// including none if the packet has no equivalent in the other protocol.
+type Converter func(pk packet.Packet) []packet.Packet
+
+// Table is a two-way conversion table of IDs, such as block runtime IDs or item network IDs, between a
+// Version and the latest protocol.
+type Table[T comparable] struct {
+	toLatest, fromLatest map[T]T
+}
+
+// NewTable creates a Table from a map of IDs of a Version to the corresponding IDs of the latest protocol.
+func NewTable[T comparable](m map[T]T) Table[T] {
+	t := Table[T]{toLatest: maps.Clone(m), fromLatest: make(map[T]T, len(m))}
+	for old, latest := range m {
+		t.fromLatest[latest] = old
+	}
+	return t
+}
+
+// ToLatest converts an ID of the Version to the corresponding ID of the latest protocol.
+func (t Table[T]) ToLatest(id T) T {
+	if latest, ok := t.toLatest[id]; ok {
+		return latest
+	}
+	return id
+}
+
+// FromLatest converts an ID of the latest protocol to the corresponding ID of the Version.
+func (t Table[T]) FromLatest(id T) T {
+	if old, ok := t.fromLatest[id]; ok {
+		return old
+	}
+	return id
+}
+
+var (
+	mu       sync.RWMutex
+	versions = map[int32]Version{}
+)
+
+// Register registers a Version so that clients on it are able to join. Registering a Version with the
+// protocol ID of one registered previously replaces it. Register panics if the protocol ID of the Version is
+// that of the latest protocol, as it is always supported.
+func Register(v Version) {
+	if v.Protocol == protocol.CurrentProtocol {
+		panic("cannot register version with the latest protocol ID")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	versions[v.Protocol] = v
+}
+
+// ByProtocol looks up a registered Version by its protocol ID. False is returned if no Version with the
+// protocol ID was registered.
+func ByProtocol(id int32) (Version, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	v, ok := versions[id]
+	return v, ok
+}
+
+// Versions returns all registered Versions, ordered by their protocol IDs from newest to oldest.
+func Versions() []Version {
+	mu.RLock()
+	v := maps.Values(versions)
+	mu.RUnlock()
+
+	slices.SortFunc(v, func(a, b Version) bool {
+		return a.Protocol > b.Protocol
+	})
+	return v
+}
+
+// Protocols returns the minecraft.Protocols of all registered Versions, so that they may be accepted by a
+// minecraft.Listener.
+func Protocols() []minecraft.Protocol {
+	v := Versions()
+	protocols := make([]minecraft.Protocol, len(v))
+	for i, ver := range v {
+		protocols[i] = proto{v: ver}
+	}
+	return protocols
+}
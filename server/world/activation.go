@@ -0,0 +1,36 @@
+package world
+
+import (
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ActivationRange specifies the distance from viewers within which entities of a specific type are fully ticked.
+// Entities further away are ticked at a reduced rate, or not at all, so that entities far away from players,
+// such as those in item or mob farms, do not take up time during every tick.
+type ActivationRange struct {
+	// Range is the horizontal distance in blocks from a viewer within which entities are ticked every tick. The
+	// distance is measured to the centre of the chunk that the viewer is in.
+	Range float64
+	// InactiveTickRate is the interval in ticks at which entities outside of Range are ticked. If 0 or lower,
+	// entities outside of Range are not ticked at all until a viewer comes close again.
+	InactiveTickRate int64
+}
+
+// shouldTick checks if an entity at the position passed should be ticked during the current tick, considering
+// the chunk positions of all loaders of the World.
+func (r ActivationRange) shouldTick(pos mgl64.Vec3, loaders []ChunkPos, current int64) bool {
+	for _, chunkPos := range loaders {
+		dx, dz := pos[0]-float64(chunkPos[0]<<4+8), pos[2]-float64(chunkPos[1]<<4+8)
+		if dx*dx+dz*dz <= r.Range*r.Range {
+			return true
+		}
+	}
+	if r.InactiveTickRate <= 0 {
+		return false
+	}
+	// Offset the tick by the chunk of the entity so that inactive entities in different chunks are not all
+	// ticked during the same tick.
+	chunkPos := chunkPosFromVec3(pos)
+	offset := int64(chunkPos[0]*31 + chunkPos[1])
+	return (current+offset)%r.InactiveTickRate == 0
+}
@@ -155,6 +155,12 @@ type RandomTicker interface {
 	RandomTick(pos cube.Pos, w *World, r *rand.Rand)
 }
 
+// SnowAccumulator represents a block that snow accumulates on while it is snowing, such as snow layers.
+type SnowAccumulator interface {
+	// AccumulateSnow is called when snow falls on the block at the position passed.
+	AccumulateSnow(pos cube.Pos, w *World)
+}
+
 // ScheduledTicker represents a block that executes an action when it has a block update scheduled, such as
 // when a block adjacent to it is broken.
 type ScheduledTicker interface {
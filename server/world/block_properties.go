@@ -0,0 +1,120 @@
+package world
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// BlockProperties returns the name and a copy of the state properties of the Block passed, as they are found in
+// the runtime block palette. The map returned may be freely modified and passed to BlockWithProperties.
+func BlockProperties(b Block) (string, map[string]any) {
+	name, properties := b.EncodeBlock()
+	m := make(map[string]any, len(properties))
+	for k, v := range properties {
+		m[k] = v
+	}
+	return name, m
+}
+
+// BlockWithProperty returns the Block passed with the state property key set to value, such as
+// BlockWithProperty(b, "facing_direction", "north"). The value may either be of the same type as the property or a
+// string that is parsed into that type. An error is returned if the block has no such property or if no block
+// state exists with the resulting properties.
+func BlockWithProperty(b Block, key string, value any) (Block, error) {
+	return BlockWithProperties(b, map[string]any{key: value})
+}
+
+// BlockWithProperties returns the Block passed with all state properties in the map set to their respective values.
+// Properties not present in the map keep the value they have in the Block passed. Values are handled the same way
+// as in BlockWithProperty.
+func BlockWithProperties(b Block, properties map[string]any) (Block, error) {
+	name, current := BlockProperties(b)
+	for k, v := range properties {
+		old, ok := current[k]
+		if !ok {
+			return b, fmt.Errorf("block %v has no property %v", name, k)
+		}
+		val, err := propertyValue(old, v)
+		if err != nil {
+			return b, fmt.Errorf("block %v: property %v: %w", name, k, err)
+		}
+		current[k] = val
+	}
+	nb, ok := BlockByName(name, current)
+	if !ok {
+		return b, fmt.Errorf("block %v has no state with properties %v", name, current)
+	}
+	return nb, nil
+}
+
+// DefaultBlock returns the Block with the name passed in its default state, which is the first state of the block
+// found in the runtime block palette. False is returned if no block with the name exists.
+func DefaultBlock(name string) (Block, bool) {
+	properties, ok := blockProperties[name]
+	if !ok {
+		return nil, false
+	}
+	return BlockByName(name, properties)
+}
+
+// BlockPropertyValues returns all values that each state property of the block with the name passed may have,
+// in the order that they are found in the runtime block palette. Nil is returned if no block with the name
+// exists.
+func BlockPropertyValues(name string) map[string][]any {
+	if _, ok := blockProperties[name]; !ok {
+		return nil
+	}
+	values := map[string][]any{}
+	for _, b := range blocks {
+		n, properties := b.EncodeBlock()
+		if n != name {
+			continue
+		}
+	props:
+		for k, v := range properties {
+			for _, existing := range values[k] {
+				if existing == v {
+					continue props
+				}
+			}
+			values[k] = append(values[k], v)
+		}
+	}
+	return values
+}
+
+// propertyValue converts the value v so that it has the same type as the property value old. An error is returned
+// if v cannot be converted.
+func propertyValue(old, v any) (any, error) {
+	s, isString := v.(string)
+	if i, ok := v.(int); ok {
+		s, isString = strconv.Itoa(i), true
+	}
+	switch old.(type) {
+	case bool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		} else if isString {
+			return strconv.ParseBool(s)
+		}
+	case uint8:
+		if b, ok := v.(uint8); ok {
+			return b, nil
+		} else if isString {
+			n, err := strconv.ParseUint(s, 10, 8)
+			return uint8(n), err
+		}
+	case int32:
+		if n, ok := v.(int32); ok {
+			return n, nil
+		} else if isString {
+			n, err := strconv.ParseInt(s, 10, 32)
+			return int32(n), err
+		}
+	case string:
+		if isString {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot use %v (%T) as %T", v, v, old)
+}
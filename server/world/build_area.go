@@ -0,0 +1,140 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"golang.org/x/exp/slices"
+)
+
+// chunkResendThreshold is the amount of blocks that must change in a single chunk applied by a BuildArea for the
+// whole chunk to be sent to viewers, rather than every block separately.
+const chunkResendThreshold = 512
+
+// BuildArea is a batch of block changes that are written to a World at once. Unlike separate calls to
+// World.SetBlock, a BuildArea locks every chunk affected only once, updates the light of a chunk only after all of
+// its blocks are set and updates neighbouring blocks only once all blocks have been placed. Chunks in which a lot
+// of blocks change are sent to viewers as a whole rather than block by block.
+// A BuildArea may be created using NewBuildArea. It is not safe for concurrent use.
+type BuildArea struct {
+	w       *World
+	changes map[ChunkPos]map[cube.Pos]Block
+}
+
+// NewBuildArea creates an empty BuildArea that writes blocks to the World passed.
+func NewBuildArea(w *World) *BuildArea {
+	return &BuildArea{w: w, changes: map[ChunkPos]map[cube.Pos]Block{}}
+}
+
+// SetBlock queues the block passed to be set at a position in the World once Apply is called. Setting a block at
+// a position multiple times results in only the last block being set. Nil may be passed to set air.
+// SetBlock panics if the block passed has not yet been registered using RegisterBlock().
+func (a *BuildArea) SetBlock(pos cube.Pos, b Block) {
+	if a.w == nil || pos.OutOfBounds(a.w.Range()) {
+		return
+	}
+	if b == nil {
+		b = air()
+	}
+	// Make sure the block is registered so that Apply does not panic halfway through.
+	BlockRuntimeID(b)
+
+	chunkPos := chunkPosFromBlockPos(pos)
+	m, ok := a.changes[chunkPos]
+	if !ok {
+		m = map[cube.Pos]Block{}
+		a.changes[chunkPos] = m
+	}
+	m[pos] = b
+}
+
+// Len returns the amount of block changes currently queued in the BuildArea.
+func (a *BuildArea) Len() int {
+	n := 0
+	for _, m := range a.changes {
+		n += len(m)
+	}
+	return n
+}
+
+// Apply writes all blocks queued in the BuildArea to the World and clears the BuildArea so that it may be reused.
+// The SetOpts passed are applied to all blocks. Apply returns the amount of blocks that changed in the World:
+// Blocks that were the same as the block already in the World are not counted.
+func (a *BuildArea) Apply(opts *SetOpts) int {
+	if a.w == nil {
+		return 0
+	}
+	if opts == nil {
+		opts = &SetOpts{}
+	}
+	var changed []cube.Pos
+	for chunkPos, blocks := range a.changes {
+		changed = append(changed, a.applyChunk(chunkPos, blocks, opts)...)
+	}
+	a.changes = map[ChunkPos]map[cube.Pos]Block{}
+
+	if !opts.DisableBlockUpdates {
+		for _, pos := range changed {
+			a.w.doBlockUpdatesAround(pos)
+		}
+	}
+	return len(changed)
+}
+
+// applyChunk sets all blocks passed in the chunk at the position passed, sends the changes to the viewers of the
+// chunk and updates the light in it. The positions at which the block changed are returned.
+func (a *BuildArea) applyChunk(chunkPos ChunkPos, blocks map[cube.Pos]Block, opts *SetOpts) []cube.Pos {
+	type update struct {
+		pos   cube.Pos
+		b     Block
+		layer int
+	}
+	var (
+		changed, lightUpdates []cube.Pos
+		updates               []update
+	)
+	c := a.w.chunk(chunkPos)
+	for pos, b := range blocks {
+		x, y, z := uint8(pos[0]), int16(pos[1]), uint8(pos[2])
+		rid, before := BlockRuntimeID(b), c.Block(x, y, z, 0)
+		if rid == before && !nbtBlocks[rid] {
+			continue
+		}
+		c.SetBlock(x, y, z, 0, rid)
+		if nbtBlocks[rid] {
+			c.BlockEntities[pos] = b
+		} else {
+			delete(c.BlockEntities, pos)
+		}
+		if !opts.DisableLiquidDisplacement {
+			var secondLayer Block
+			if b, secondLayer = displaceLiquid(c.Chunk, x, y, z, before, rid, b); secondLayer != nil {
+				updates = append(updates, update{pos: pos, b: secondLayer, layer: 1})
+			}
+		}
+		updates = append(updates, update{pos: pos, b: b})
+		if lightChanged(before, c.Block(x, y, z, 0)) {
+			lightUpdates = append(lightUpdates, pos)
+		}
+		changed = append(changed, pos)
+	}
+	if len(changed) != 0 {
+		c.modified = true
+	}
+	viewers := slices.Clone(c.viewers)
+	if len(changed) >= chunkResendThreshold {
+		for _, viewer := range viewers {
+			viewer.ViewChunk(chunkPos, c.Chunk, c.BlockEntities)
+		}
+		updates = nil
+	}
+	c.Unlock()
+
+	for _, u := range updates {
+		for _, viewer := range viewers {
+			viewer.ViewBlockUpdate(u.pos, u.b, u.layer)
+		}
+	}
+	if len(lightUpdates) != 0 {
+		a.w.updateLightIn(chunkPos, lightUpdates...)
+	}
+	return changed
+}
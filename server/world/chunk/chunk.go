@@ -22,6 +22,9 @@ type Chunk struct {
 	sub []*SubChunk
 	// biomes is an array of biome IDs. There is one biome ID for every column in the chunk.
 	biomes []*PalettedStorage
+	// compressed holds the compressed block storages and light of all sub chunks if the chunk was compressed
+	// using Compress. It is nil if the chunk is not compressed.
+	compressed []byte
 }
 
 // New initialises a new chunk and returns it, so that it may be used.
@@ -171,6 +174,26 @@ func (chunk *Chunk) Compact() {
 	}
 }
 
+// Clone returns a deep copy of the Chunk. Changes made to the Chunk returned do not affect the original Chunk and
+// vice versa. Block storages are copied lazily, once either of the chunks is changed.
+func (chunk *Chunk) Clone() *Chunk {
+	c := &Chunk{
+		r:                    chunk.r,
+		air:                  chunk.air,
+		recalculateHeightMap: chunk.recalculateHeightMap,
+		heightMap:            append(HeightMap(nil), chunk.heightMap...),
+		sub:                  make([]*SubChunk, len(chunk.sub)),
+		biomes:               make([]*PalettedStorage, len(chunk.biomes)),
+	}
+	for i, sub := range chunk.sub {
+		c.sub[i] = sub.clone()
+	}
+	for i, biome := range chunk.biomes {
+		c.biomes[i] = biome.clone()
+	}
+	return c
+}
+
 // SubChunk finds the correct SubChunk in the Chunk by a Y value.
 func (chunk *Chunk) SubChunk(y int16) *SubChunk {
 	return chunk.sub[chunk.SubIndex(y)]
@@ -0,0 +1,119 @@
+package chunk
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/klauspost/compress/flate"
+	"io"
+)
+
+const (
+	// compressedBlockLight and compressedSkyLight are flags set for a sub chunk in the data of a compressed Chunk
+	// if the block light or skylight of the sub chunk is held in the data, rather than in one of the shared light
+	// arrays.
+	compressedBlockLight = 1 << iota
+	compressedSkyLight
+)
+
+// Compressed checks if the Chunk is currently compressed using Compress.
+func (chunk *Chunk) Compressed() bool {
+	return chunk.compressed != nil
+}
+
+// Compress compresses the block storages and light of all sub chunks of the Chunk to reduce the memory that the
+// Chunk occupies, which is useful for chunks that remain loaded without being used. A compressed Chunk must be
+// decompressed using Decompress before any of its other methods are called. Compress does nothing if the Chunk
+// is already compressed.
+func (chunk *Chunk) Compress() {
+	if chunk.compressed != nil {
+		return
+	}
+	raw := pool.Get().(*bytes.Buffer)
+	defer func() {
+		raw.Reset()
+		pool.Put(raw)
+	}()
+
+	for _, sub := range chunk.sub {
+		_ = raw.WriteByte(byte(len(sub.storages)))
+		for _, storage := range sub.storages {
+			encodePalettedStorage(raw, storage, nil, NetworkEncoding, BlockPaletteEncoding)
+		}
+		var flags byte
+		if !sharedLight(sub.blockLight) {
+			flags |= compressedBlockLight
+		}
+		if !sharedLight(sub.skyLight) {
+			flags |= compressedSkyLight
+		}
+		_ = raw.WriteByte(flags)
+		if flags&compressedBlockLight != 0 {
+			_, _ = raw.Write(sub.blockLight)
+		}
+		if flags&compressedSkyLight != 0 {
+			_, _ = raw.Write(sub.skyLight)
+		}
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, raw.Len()/4))
+	w, _ := flate.NewWriter(buf, flate.BestSpeed)
+	_, _ = w.Write(raw.Bytes())
+	_ = w.Close()
+	chunk.compressed = buf.Bytes()
+
+	for _, sub := range chunk.sub {
+		sub.storages = nil
+		if !sharedLight(sub.blockLight) {
+			sub.blockLight = nil
+		}
+		if !sharedLight(sub.skyLight) {
+			sub.skyLight = nil
+		}
+	}
+}
+
+// Decompress restores the block storages and light of a Chunk compressed using Compress. Decompress does nothing if
+// the Chunk is not compressed.
+func (chunk *Chunk) Decompress() {
+	if chunk.compressed == nil {
+		return
+	}
+	if err := chunk.decompress(); err != nil {
+		// The data was produced by Compress, so this should never happen.
+		panic(fmt.Errorf("decompress chunk: %w", err))
+	}
+	chunk.compressed = nil
+}
+
+// decompress decodes the compressed data of the Chunk into its sub chunks.
+func (chunk *Chunk) decompress() error {
+	r := flate.NewReader(bytes.NewReader(chunk.compressed))
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	buf := bytes.NewBuffer(data)
+	for _, sub := range chunk.sub {
+		count, err := buf.ReadByte()
+		if err != nil {
+			return err
+		}
+		sub.storages, sub.shared = make([]*PalettedStorage, count), false
+		for i := range sub.storages {
+			if sub.storages[i], err = decodePalettedStorage(buf, NetworkEncoding, BlockPaletteEncoding); err != nil {
+				return err
+			}
+		}
+		flags, err := buf.ReadByte()
+		if err != nil {
+			return err
+		}
+		if flags&compressedBlockLight != 0 {
+			sub.blockLight = append([]uint8(nil), buf.Next(len(noLight))...)
+		}
+		if flags&compressedSkyLight != 0 {
+			sub.skyLight = append([]uint8(nil), buf.Next(len(noLight))...)
+		}
+	}
+	return nil
+}
@@ -48,6 +48,48 @@ func NetworkDecode(air uint32, data []byte, count int, r cube.Range) (*Chunk, er
 	return c, nil
 }
 
+// NetworkConvert converts the block runtime IDs of the first count network encoded sub chunks in data using the
+// function passed, such as to send them to a client with a different block palette. Any data following these
+// sub chunks, such as biomes and block entities, is returned unchanged.
+func NetworkConvert(data []byte, count int, convert func(uint32) uint32) ([]byte, error) {
+	var (
+		buf = bytes.NewBuffer(data)
+		out = bytes.NewBuffer(make([]byte, 0, len(data)))
+	)
+	for i := 0; i < count; i++ {
+		ver, err := buf.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("error reading version: %w", err)
+		}
+		_ = out.WriteByte(ver)
+		storageCount := byte(1)
+		switch ver {
+		default:
+			return nil, fmt.Errorf("unknown sub chunk version %v: can't convert", ver)
+		case 1:
+		case 8, 9:
+			header := buf.Next(int(ver - 7))
+			if len(header) != int(ver-7) {
+				return nil, fmt.Errorf("error reading sub chunk header: unexpected EOF")
+			}
+			_, _ = out.Write(header)
+			storageCount = header[0]
+		}
+		for j := byte(0); j < storageCount; j++ {
+			storage, err := decodePalettedStorage(buf, NetworkEncoding, BlockPaletteEncoding)
+			if err != nil {
+				return nil, err
+			} else if storage == nil {
+				return nil, fmt.Errorf("block storage pointed to previous one")
+			}
+			storage.palette.Replace(convert)
+			encodePalettedStorage(out, storage, nil, NetworkEncoding, BlockPaletteEncoding)
+		}
+	}
+	_, _ = out.Write(buf.Bytes())
+	return out.Bytes(), nil
+}
+
 // DiskDecode decodes the data from a SerialisedData object into a chunk and returns it. If the data was
 // invalid, an error is returned.
 func DiskDecode(data SerialisedData, r cube.Range) (*Chunk, error) {
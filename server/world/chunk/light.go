@@ -104,6 +104,56 @@ func (a *lightArea) propagate(queue *list.List) {
 	}
 }
 
+// removeLight sets the light of the nodes passed to 0 and removes all light that spread from these nodes in the
+// lightArea. Nodes that have light which did not come from the removed nodes are appended to sources, so that
+// their light may be spread into the area that was cleared. Block light sources found within the cleared area
+// are added to the queue add.
+func (a *lightArea) removeLight(nodes []lightNode, add *list.List, sources []lightNode) []lightNode {
+	queue := list.New()
+	for _, n := range nodes {
+		a.setLight(n.pos, n.lt, 0)
+		queue.PushBack(n)
+	}
+	for queue.Len() != 0 {
+		n := queue.Remove(queue.Front()).(lightNode)
+		for _, neighbour := range a.neighbours(n) {
+			level := a.light(neighbour.pos, n.lt)
+			if level == 0 {
+				continue
+			}
+			if level >= n.level {
+				// The light of this neighbour did not come from the removed node, so it should spread back
+				// into the area that was cleared.
+				sources = append(sources, neighbour)
+				continue
+			}
+			a.setLight(neighbour.pos, n.lt, 0)
+			neighbour.level = level
+			queue.PushBack(neighbour)
+
+			if n.lt == BlockLight {
+				if emitted := a.highest(neighbour.pos, LightBlocks); emitted > 0 {
+					add.PushBack(node(neighbour.pos, emitted, BlockLight))
+				}
+			}
+		}
+	}
+	return sources
+}
+
+// spreadFrom adds nodes to the queue passed for all neighbours of the lightNode passed that its current light
+// level is able to spread to.
+func (a *lightArea) spreadFrom(n lightNode, queue *list.List) {
+	level := a.light(n.pos, n.lt)
+	for _, neighbour := range a.neighbours(n) {
+		filter := a.highest(neighbour.pos, FilteringBlocks) + 1
+		if level > filter && a.light(neighbour.pos, n.lt) < level-filter {
+			neighbour.level = level - filter
+			queue.PushBack(neighbour)
+		}
+	}
+}
+
 // lightNode is a node pushed to the queue which is used to propagate light.
 type lightNode struct {
 	pos   cube.Pos
@@ -51,6 +51,42 @@ func (a *lightArea) Spread() {
 	}
 }
 
+// Update updates the light in the lightArea after the block at the cube.Pos passed was changed. Light that can no
+// longer reach the blocks around it is removed, after which light is spread again from the remaining light sources
+// and the block itself. The lightArea must consist of 3x3 chunks with the cube.Pos passed in the centre chunk, and
+// all of these chunks must have passed both the light 'filling' and 'spreading' stages.
+func (a *lightArea) Update(pos cube.Pos) {
+	var sources []lightNode
+	add := list.New()
+
+	// Remove block light spreading from the position, and re-add any light that the new block emits.
+	sources = a.removeLight([]lightNode{node(pos, a.light(pos, BlockLight), BlockLight)}, add, sources)
+	if level := a.highest(pos, LightBlocks); level > 0 {
+		add.PushBack(node(pos, level, BlockLight))
+	}
+
+	// Remove skylight from the position and from the column of full skylight below it, which may now be
+	// obstructed. The column is filled up again until the new height map value below.
+	removed := []lightNode{node(pos, a.light(pos, SkyLight), SkyLight)}
+	for below := pos.Side(cube.FaceDown); below[1] >= a.r.Min() && a.light(below, SkyLight) == 15; below = below.Side(cube.FaceDown) {
+		removed = append(removed, node(below, 15, SkyLight))
+	}
+	sources = a.removeLight(removed, add, sources)
+
+	height := int(a.chunk(pos).highestLightBlocker(uint8(pos[0]), uint8(pos[2]), true))
+	for y := a.r.Max(); y >= height; y-- {
+		if above := (cube.Pos{pos[0], y, pos[2]}); a.light(above, SkyLight) < 15 {
+			add.PushBack(node(above, 15, SkyLight))
+		}
+	}
+	for _, n := range sources {
+		a.spreadFrom(n, add)
+	}
+	for add.Len() != 0 {
+		a.propagate(add)
+	}
+}
+
 // light returns the light at a cube.Pos with the light type l.
 func (a *lightArea) light(pos cube.Pos, l light) uint8 {
 	return l.light(a.sub(pos), uint8(pos[0]&0xf), uint8(pos[1]&0xf), uint8(pos[2]&0xf))
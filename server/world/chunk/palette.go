@@ -23,6 +23,11 @@ func newPalette(size paletteSize, values []uint32) *Palette {
 	return &Palette{size: size, values: values, last: math.MaxUint32}
 }
 
+// clone returns a copy of the Palette.
+func (palette *Palette) clone() *Palette {
+	return newPalette(palette.size, append([]uint32(nil), palette.values...))
+}
+
 // Len returns the amount of unique values in the Palette.
 func (palette *Palette) Len() int {
 	return len(palette.values)
@@ -60,6 +60,11 @@ func emptyStorage(v uint32) *PalettedStorage {
 	return newPalettedStorage([]uint32{}, newPalette(0, []uint32{v}))
 }
 
+// clone returns a deep copy of the PalettedStorage, including its Palette.
+func (storage *PalettedStorage) clone() *PalettedStorage {
+	return newPalettedStorage(append([]uint32{}, storage.indices...), storage.palette.clone())
+}
+
 // Palette returns the Palette of the PalettedStorage.
 func (storage *PalettedStorage) Palette() *Palette {
 	return storage.palette
@@ -1,5 +1,7 @@
 package chunk
 
+import "bytes"
+
 // SubChunk is a cube of blocks located in a chunk. It has a size of 16x16x16 blocks and forms part of a stack
 // that forms a Chunk.
 type SubChunk struct {
@@ -7,6 +9,9 @@ type SubChunk struct {
 	storages   []*PalettedStorage
 	blockLight []uint8
 	skyLight   []uint8
+	// shared is true if the storages of the SubChunk may also be held by a clone of the SubChunk. Shared storages
+	// are copied as soon as the SubChunk is changed.
+	shared bool
 }
 
 // NewSubChunk creates a new sub chunk. All sub chunks should be created through this function
@@ -23,6 +28,7 @@ func (sub *SubChunk) Empty() bool {
 // Layer returns a certain block storage/layer from a sub chunk. If no storage at the layer exists, the layer
 // is created, as well as all layers between the current highest layer and the new highest layer.
 func (sub *SubChunk) Layer(layer uint8) *PalettedStorage {
+	sub.unshare()
 	for uint8(len(sub.storages)) <= layer {
 		// Keep appending to storages until the requested layer is achieved. Makes working with new layers
 		// much easier.
@@ -31,7 +37,8 @@ func (sub *SubChunk) Layer(layer uint8) *PalettedStorage {
 	return sub.storages[layer]
 }
 
-// Layers returns all layers in the sub chunk. This method may also return an empty slice.
+// Layers returns all layers in the sub chunk. This method may also return an empty slice. The layers returned must
+// not be changed: Layer should be used to obtain a layer that may be changed.
 func (sub *SubChunk) Layers() []*PalettedStorage {
 	return sub.storages
 }
@@ -52,7 +59,7 @@ func (sub *SubChunk) SetBlock(x, y, z byte, layer uint8, block uint32) {
 
 // SetBlockLight sets the block light value at a specific position in the sub chunk.
 func (sub *SubChunk) SetBlockLight(x, y, z byte, level uint8) {
-	if ptr := &sub.blockLight[0]; ptr == noLightPtr {
+	if ptr := &sub.blockLight[0]; ptr == fullLightPtr || ptr == noLightPtr {
 		// Copy the block light as soon as it is changed to create a COW system.
 		sub.blockLight = append([]byte(nil), sub.blockLight...)
 	}
@@ -88,12 +95,51 @@ func (sub *SubChunk) SkyLight(x, y, z byte) uint8 {
 	return (sub.skyLight[index>>1] >> ((index & 1) << 2)) & 0xf
 }
 
+// clone returns a deep copy of the SubChunk. The storages of the SubChunk are shared with the clone until either
+// of them is changed, after which the one changed copies them. Light arrays that are shared between sub chunks
+// are not copied, as these are copied on write anyway.
+func (sub *SubChunk) clone() *SubChunk {
+	sub.shared = true
+	c := &SubChunk{air: sub.air, storages: append([]*PalettedStorage(nil), sub.storages...), shared: true}
+	c.blockLight, c.skyLight = cloneLight(sub.blockLight), cloneLight(sub.skyLight)
+	return c
+}
+
+// unshare copies the storages of the SubChunk if they may be shared with a clone of the SubChunk, so that they
+// may safely be changed.
+func (sub *SubChunk) unshare() {
+	if !sub.shared {
+		return
+	}
+	for i, storage := range sub.storages {
+		sub.storages[i] = storage.clone()
+	}
+	sub.shared = false
+}
+
+// cloneLight returns a copy of the light array passed, unless it is one of the shared light arrays.
+func cloneLight(l []uint8) []uint8 {
+	if sharedLight(l) {
+		return l
+	}
+	return append([]uint8(nil), l...)
+}
+
+// sharedLight checks if the light array passed is one of the light arrays shared between sub chunks, or if it
+// is not yet set at all.
+func sharedLight(l []uint8) bool {
+	return len(l) == 0 || &l[0] == noLightPtr || &l[0] == fullLightPtr
+}
+
 // Compact cleans the garbage from all block storages that sub chunk contains, so that they may be
 // cleanly written to a database.
 func (sub *SubChunk) compact() {
 	newStorages := make([]*PalettedStorage, 0, len(sub.storages))
 	for _, storage := range sub.storages {
-		storage.compact()
+		if !sub.shared {
+			// Storages shared with a clone may be read at the same time, so these are left as they are.
+			storage.compact()
+		}
 		if len(storage.palette.values) == 1 && storage.palette.values[0] == sub.air {
 			// If the palette has only air in it, it means the storage is empty, so we can ignore it.
 			continue
@@ -101,4 +147,19 @@ func (sub *SubChunk) compact() {
 		newStorages = append(newStorages, storage)
 	}
 	sub.storages = newStorages
+	sub.blockLight, sub.skyLight = compactLight(sub.blockLight), compactLight(sub.skyLight)
+}
+
+// compactLight returns one of the shared light arrays if the light array passed is completely dark or completely
+// lit, so that the memory of the array passed may be freed. If neither is the case, l is returned.
+func compactLight(l []uint8) []uint8 {
+	if sharedLight(l) {
+		return l
+	}
+	if bytes.Equal(l, noLight) {
+		return noLight
+	} else if bytes.Equal(l, fullLight) {
+		return fullLight
+	}
+	return l
 }
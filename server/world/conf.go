@@ -39,6 +39,31 @@ type Config struct {
 	// Entities is an EntityRegistry with all entity types registered that may
 	// be added to the World.
 	Entities EntityRegistry
+	// Protector is the Protector consulted before entities break, place or interact with blocks in the World. If set
+	// to nil, NopProtector is used, which allows everything.
+	Protector Protector
+	// MobSpawns is the spawn list used to naturally spawn mobs in the World. Mobs are spawned around viewers of
+	// the World, respecting the mob cap of each MobCategory, light levels and the Biomes of each MobSpawnEntry.
+	// If empty, no mobs are spawned naturally.
+	MobSpawns []MobSpawnEntry
+	// SaveInterval is the interval at which modified chunks and the settings of the World are saved to the
+	// Provider while the World is running. If 0 or lower, chunks are only saved when they are unloaded or when
+	// the World is closed.
+	SaveInterval time.Duration
+	// TickRegionSize is the size in chunks of the square regions that the World is divided in when ticking
+	// entities and blocks. Entities and blocks in regions that are not adjacent to each other are ticked on
+	// separate goroutines in parallel. Those within a chunk of the edge of their region may affect neighbouring
+	// regions and are ticked after all regions on a single goroutine. Sizes between 1 and 3 are raised to 4, the
+	// smallest size that leaves room for such an interior. If set to 0 or lower, the World is ticked on a single
+	// goroutine.
+	TickRegionSize int
+	// ActivationRanges maps encoded entity types, such as 'minecraft:item', to the ActivationRange used for
+	// entities of that type. Entities in chunks that have no viewers are never ticked. Entities of a type not
+	// present in the map are ticked every tick as long as their chunk has viewers.
+	ActivationRanges map[string]ActivationRange
+	// LowTPSThreshold is the TPS (ticks per second) below which Handler.HandleLowTPS is called. If set to 0 or
+	// lower, HandleLowTPS is never called.
+	LowTPSThreshold float64
 }
 
 // Logger is a logger implementation that may be passed to the Log field of Config. World will send errors and debug
@@ -66,6 +91,9 @@ func (conf Config) New() *World {
 	if conf.RandomTickSpeed == 0 {
 		conf.RandomTickSpeed = 3
 	}
+	if conf.Protector == nil {
+		conf.Protector = NopProtector{}
+	}
 	if conf.RandSource == nil {
 		conf.RandSource = rand.NewSource(time.Now().Unix())
 	}
@@ -73,19 +101,25 @@ func (conf Config) New() *World {
 	w := &World{
 		scheduledUpdates: make(map[cube.Pos]int64),
 		entities:         make(map[Entity]ChunkPos),
+		entityGrid:       newEntityGrid(),
 		viewers:          make(map[*Loader]Viewer),
 		chunks:           make(map[ChunkPos]*Column),
 		closing:          make(chan struct{}),
 		handler:          *atomic.NewValue[Handler](NopHandler{}),
+		protector:        *atomic.NewValue[Protector](conf.Protector),
 		r:                rand.New(conf.RandSource),
 		advance:          s.ref.Inc() == 1,
 		conf:             conf,
 		ra:               conf.Dim.Range(),
 		set:              s,
 	}
-	w.weather, w.ticker = weather{w: w}, ticker{w: w}
+	w.weather, w.ticker = weather{w: w}, newTicker(w)
+	w.mobSpawner = &mobSpawner{w: w, mobs: make(map[Entity]MobCategory)}
 
 	go w.tickLoop()
 	go w.chunkCacheJanitor()
+	if conf.SaveInterval > 0 {
+		go w.autoSave()
+	}
 	return w
 }
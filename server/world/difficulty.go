@@ -1,5 +1,7 @@
 package world
 
+import "math"
+
 // Difficulty represents the difficulty of a Minecraft world. The difficulty of
 // a world influences all kinds of aspects of the world, such as the damage
 // enemies deal to players, the way hunger depletes, whether hostile monsters
@@ -16,6 +18,19 @@ type Difficulty interface {
 	FireSpreadIncrease() int
 }
 
+// MobDifficulty is a Difficulty that also influences the behaviour of mobs.
+// Difficulties that do not implement MobDifficulty spawn hostile mobs and
+// leave the damage dealt by mobs unchanged.
+type MobDifficulty interface {
+	Difficulty
+	// HostileMobsSpawn specifies if hostile mobs spawn naturally with this
+	// difficulty.
+	HostileMobsSpawn() bool
+	// MobDamage scales damage dealt to players by mobs according to the
+	// difficulty, returning the new damage.
+	MobDamage(dmg float64) float64
+}
+
 var (
 	// DifficultyPeaceful prevents most hostile mobs from spawning and makes
 	// players rapidly regenerate health and food.
@@ -93,6 +108,8 @@ type difficultyPeaceful struct{}
 func (difficultyPeaceful) FoodRegenerates() bool          { return true }
 func (difficultyPeaceful) StarvationHealthLimit() float64 { return 20 }
 func (difficultyPeaceful) FireSpreadIncrease() int        { return 0 }
+func (difficultyPeaceful) HostileMobsSpawn() bool         { return false }
+func (difficultyPeaceful) MobDamage(dmg float64) float64  { return 0 }
 
 // difficultyEasy difficulty has mobs deal less damage to players than normal
 // and starvation won't occur if a player has less than 5 hearts of health.
@@ -101,6 +118,8 @@ type difficultyEasy struct{}
 func (difficultyEasy) FoodRegenerates() bool          { return false }
 func (difficultyEasy) StarvationHealthLimit() float64 { return 10 }
 func (difficultyEasy) FireSpreadIncrease() int        { return 7 }
+func (difficultyEasy) HostileMobsSpawn() bool         { return true }
+func (difficultyEasy) MobDamage(dmg float64) float64  { return math.Min(dmg/2+1, dmg) }
 
 // difficultyNormal difficulty has mobs that deal normal damage to players.
 // Starvation will occur until the player is down to a single heart.
@@ -109,6 +128,8 @@ type difficultyNormal struct{}
 func (difficultyNormal) FoodRegenerates() bool          { return false }
 func (difficultyNormal) StarvationHealthLimit() float64 { return 2 }
 func (difficultyNormal) FireSpreadIncrease() int        { return 14 }
+func (difficultyNormal) HostileMobsSpawn() bool         { return true }
+func (difficultyNormal) MobDamage(dmg float64) float64  { return dmg }
 
 // difficultyHard difficulty has mobs that deal above average damage to
 // players. Starvation will kill players with too little food and monsters will
@@ -118,3 +139,5 @@ type difficultyHard struct{}
 func (difficultyHard) FoodRegenerates() bool          { return false }
 func (difficultyHard) StarvationHealthLimit() float64 { return -1 }
 func (difficultyHard) FireSpreadIncrease() int        { return 21 }
+func (difficultyHard) HostileMobsSpawn() bool         { return true }
+func (difficultyHard) MobDamage(dmg float64) float64  { return dmg * 1.5 }
@@ -0,0 +1,135 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"sync"
+)
+
+// entityCellShift is the amount of bits that block coordinates are shifted to the right to obtain the entityCell
+// that they are in. A shift of 2 results in cells of 4x4x4 blocks.
+const entityCellShift = 2
+
+// entityCell is the position of a cell in an entityGrid.
+type entityCell [3]int32
+
+// cellFromVec3 returns the entityCell that the position passed is in.
+func cellFromVec3(vec mgl64.Vec3) entityCell {
+	return entityCell{
+		int32(math.Floor(vec[0])) >> entityCellShift,
+		int32(math.Floor(vec[1])) >> entityCellShift,
+		int32(math.Floor(vec[2])) >> entityCellShift,
+	}
+}
+
+// entityGrid is a spatial hash of the entities in a World. It divides the World in small cells, so that entities
+// near a specific position may be found without having to go through all entities of the chunks around it. The
+// cell of an entity is updated every tick, so the cell an entity is stored in may lag behind its position by up
+// to a tick, similarly to the chunk that it is stored in.
+// Methods on entityGrid are safe for simultaneous use from multiple goroutines.
+type entityGrid struct {
+	mu    sync.RWMutex
+	cells map[entityCell][]Entity
+	pos   map[Entity]entityCell
+}
+
+// newEntityGrid returns a new, empty entityGrid.
+func newEntityGrid() *entityGrid {
+	return &entityGrid{cells: make(map[entityCell][]Entity), pos: make(map[Entity]entityCell)}
+}
+
+// add adds an Entity to the entityGrid at the position passed. If the Entity was already in the entityGrid, it
+// is moved to the cell of the new position.
+func (g *entityGrid) add(e Entity, pos mgl64.Vec3) {
+	cell := cellFromVec3(pos)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if old, ok := g.pos[e]; ok {
+		if old == cell {
+			return
+		}
+		g.removeFromCell(e, old)
+	}
+	g.pos[e] = cell
+	g.cells[cell] = append(g.cells[cell], e)
+}
+
+// move updates the cell of an Entity already in the entityGrid. move does nothing if the Entity is not in the
+// entityGrid or if its cell did not change.
+func (g *entityGrid) move(e Entity, pos mgl64.Vec3) {
+	cell := cellFromVec3(pos)
+
+	g.mu.RLock()
+	old, ok := g.pos[e]
+	g.mu.RUnlock()
+	if !ok || old == cell {
+		return
+	}
+	g.add(e, pos)
+}
+
+// remove removes an Entity from the entityGrid.
+func (g *entityGrid) remove(e Entity) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if cell, ok := g.pos[e]; ok {
+		g.removeFromCell(e, cell)
+		delete(g.pos, e)
+	}
+}
+
+// removeFromCell removes an Entity from the cell passed, deleting the cell if it is left empty. g.mu must be
+// locked when removeFromCell is called.
+func (g *entityGrid) removeFromCell(e Entity, cell entityCell) {
+	if entities := sliceutil.DeleteVal(g.cells[cell], e); len(entities) > 0 {
+		g.cells[cell] = entities
+		return
+	}
+	delete(g.cells, cell)
+}
+
+// within returns all entities in the entityGrid whose position is within the cube.BBox passed. Entities for which
+// ignored returns true are not returned. ignored may be nil.
+func (g *entityGrid) within(box cube.BBox, ignored func(Entity) bool) []Entity {
+	minCell, maxCell := cellFromVec3(box.Min()), cellFromVec3(box.Max())
+	cellCount := int64(maxCell[0]-minCell[0]+1) * int64(maxCell[1]-minCell[1]+1) * int64(maxCell[2]-minCell[2]+1)
+
+	// Make an estimate of 16 entities on average.
+	m := make([]Entity, 0, 16)
+	add := func(e Entity) {
+		if (ignored == nil || !ignored(e)) && box.Vec3Within(e.Position()) {
+			m = append(m, e)
+		}
+	}
+
+	g.mu.RLock()
+	var entities []Entity
+	if cellCount > int64(len(g.cells)) {
+		// The box covers more cells than there are occupied cells, so it is cheaper to go through all cells that
+		// are occupied instead.
+		for cell, cellEntities := range g.cells {
+			if cell[0] >= minCell[0] && cell[0] <= maxCell[0] && cell[1] >= minCell[1] && cell[1] <= maxCell[1] && cell[2] >= minCell[2] && cell[2] <= maxCell[2] {
+				entities = append(entities, cellEntities...)
+			}
+		}
+	} else {
+		for x := minCell[0]; x <= maxCell[0]; x++ {
+			for y := minCell[1]; y <= maxCell[1]; y++ {
+				for z := minCell[2]; z <= maxCell[2]; z++ {
+					entities = append(entities, g.cells[entityCell{x, y, z}]...)
+				}
+			}
+		}
+	}
+	g.mu.RUnlock()
+
+	// The entities are only checked after unlocking the mutex, so that calls to Position() and ignored can never
+	// result in a deadlock.
+	for _, e := range entities {
+		add(e)
+	}
+	return m
+}
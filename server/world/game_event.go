@@ -0,0 +1,73 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// GameEvent represents an event in the world that causes a vibration, such as an entity stepping on a block, a
+// block being placed or an explosion. GameEvents are emitted using World.EmitGameEvent, after which any
+// VibrationListener in range of the event is able to detect it.
+type GameEvent interface {
+	// Frequency returns the frequency of the vibration caused by the GameEvent. The frequency ranges from 1 to
+	// 15 and is, for example, used by sculk sensors to determine the strength of their comparator signal.
+	Frequency() int
+}
+
+// VibrationListener represents a block that listens for vibrations caused by GameEvents emitted nearby, such
+// as a sculk sensor. VibrationListeners are looked up through the block entities of a chunk, so they must
+// also implement NBTer.
+type VibrationListener interface {
+	NBTer
+	// VibrationRange returns the maximum distance in blocks between the listener and a GameEvent for the
+	// listener to be able to detect it. The range may not exceed 16 blocks.
+	VibrationRange() float64
+	// ListenVibration is called when a GameEvent is emitted within the range of the listener at the position
+	// passed. The position of the GameEvent is passed as src, and the Entity that caused it, if any, as e.
+	ListenVibration(pos cube.Pos, src mgl64.Vec3, ev GameEvent, e Entity, w *World)
+}
+
+// maxVibrationRange is the maximum range of a VibrationListener. It limits the chunks that are searched for
+// listeners when a GameEvent is emitted.
+const maxVibrationRange = 16
+
+// EmitGameEvent emits a GameEvent at the position passed. The Entity that caused the event may be passed as e,
+// or nil if the event was not caused by an entity. Every VibrationListener in a loaded chunk within range of
+// the position is notified of the event.
+func (w *World) EmitGameEvent(pos mgl64.Vec3, ev GameEvent, e Entity) {
+	if w == nil {
+		return
+	}
+	ctx := event.C()
+	if w.Handler().HandleGameEvent(ctx, ev, pos, e); ctx.Cancelled() {
+		return
+	}
+	type listener struct {
+		pos cube.Pos
+		l   VibrationListener
+	}
+	var listeners []listener
+
+	minPos := chunkPosFromVec3(pos.Sub(mgl64.Vec3{maxVibrationRange, 0, maxVibrationRange}))
+	maxPos := chunkPosFromVec3(pos.Add(mgl64.Vec3{maxVibrationRange, 0, maxVibrationRange}))
+	for x := minPos[0]; x <= maxPos[0]; x++ {
+		for z := minPos[1]; z <= maxPos[1]; z++ {
+			c, ok := w.chunkFromCache(ChunkPos{x, z})
+			if !ok {
+				// Vibrations are not able to reach listeners in chunks that aren't loaded.
+				continue
+			}
+			for p, b := range c.BlockEntities {
+				if l, ok := b.(VibrationListener); ok && p.Vec3Centre().Sub(pos).Len() <= l.VibrationRange() {
+					listeners = append(listeners, listener{pos: p, l: l})
+				}
+			}
+			c.Unlock()
+		}
+	}
+	// Listeners are called only after all chunks were unlocked, so that they are free to modify the world.
+	for _, l := range listeners {
+		l.l.ListenVibration(l.pos, pos, ev, e, w)
+	}
+}
@@ -30,7 +30,8 @@ var (
 	// items and can break blocks instantly. Players with creative mode can also fly.
 	GameModeCreative creative
 	// GameModeAdventure represents the adventure game mode: Players with this game mode cannot edit the world
-	// (placing or breaking blocks).
+	// (placing or breaking blocks), unless the item they hold lists the block in its CanPlaceOn or CanDestroy
+	// lists.
 	GameModeAdventure adventure
 	// GameModeSpectator represents the spectator game mode: Players with this game mode cannot interact with the
 	// world and cannot be seen by other players. spectator players can fly, like creative mode, and can
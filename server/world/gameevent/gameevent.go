@@ -0,0 +1,49 @@
+package gameevent
+
+// Step is emitted when an entity that is not sneaking walks over a block.
+type Step struct{}
+
+// HitGround is emitted when an entity that is not sneaking lands on the ground after falling.
+type HitGround struct{}
+
+// ProjectileLand is emitted when a projectile hits a block.
+type ProjectileLand struct{}
+
+// ProjectileShoot is emitted when an entity shoots or throws a projectile.
+type ProjectileShoot struct{}
+
+// EntityDamage is emitted when an entity takes damage.
+type EntityDamage struct{}
+
+// BlockDestroy is emitted when a block is broken.
+type BlockDestroy struct{}
+
+// BlockPlace is emitted when a block is placed.
+type BlockPlace struct{}
+
+// Explode is emitted when an explosion takes place.
+type Explode struct{}
+
+// Frequency ...
+func (Step) Frequency() int { return 1 }
+
+// Frequency ...
+func (HitGround) Frequency() int { return 2 }
+
+// Frequency ...
+func (ProjectileLand) Frequency() int { return 2 }
+
+// Frequency ...
+func (ProjectileShoot) Frequency() int { return 3 }
+
+// Frequency ...
+func (EntityDamage) Frequency() int { return 7 }
+
+// Frequency ...
+func (BlockDestroy) Frequency() int { return 12 }
+
+// Frequency ...
+func (BlockPlace) Frequency() int { return 13 }
+
+// Frequency ...
+func (Explode) Frequency() int { return 15 }
@@ -26,6 +26,10 @@ type Handler interface {
 	// HandleSound handles a Sound being played in the World at a specific position. ctx.Cancel() may be called
 	// to stop the Sound from playing to viewers of the position.
 	HandleSound(ctx *event.Context, s Sound, pos mgl64.Vec3)
+	// HandleGameEvent handles a GameEvent being emitted in the World at a specific position, such as a block
+	// being placed. The Entity that caused the GameEvent is passed, or nil if it was not caused by an entity.
+	// ctx.Cancel() may be called to prevent VibrationListeners from detecting the GameEvent.
+	HandleGameEvent(ctx *event.Context, ev GameEvent, pos mgl64.Vec3, e Entity)
 	// HandleFireSpread handles when a fire block spreads from one block to another block. When this event handler gets
 	// called, both the position of the original fire will be passed, and the position where it will spread to after the
 	// event. The age of the fire may also be altered by changing the underlying value of the newFireAge pointer, which
@@ -35,10 +39,30 @@ type Handler interface {
 	// wood, that can be broken by fire. HandleBlockBurn is often succeeded by HandleFireSpread, when fire spreads to
 	// the position of the original block and the event.Context is not cancelled in HandleBlockBurn.
 	HandleBlockBurn(ctx *event.Context, pos cube.Pos)
+	// HandleBlockGrow handles a plant at a position growing naturally or by the use of bone meal. The block at pos
+	// is replaced with newBlock if the event is not cancelled. For plants that grow upwards, such as sugar cane,
+	// pos is the position of the new block rather than of the existing plant.
+	HandleBlockGrow(ctx *event.Context, pos cube.Pos, newBlock Block)
+	// HandleTreeGrow handles a sapling at a position growing into a tree. The Structure passed is the tree that
+	// will be built at structurePos, which is the lowest corner of the tree. ctx.Cancel() may be called to
+	// prevent the sapling from growing.
+	HandleTreeGrow(ctx *event.Context, pos, structurePos cube.Pos, s Structure)
+	// HandleCropTrample handles an entity trampling the farmland block at the position passed by falling on it,
+	// turning it back into dirt and breaking any crop on top of it. ctx.Cancel() may be called to prevent the
+	// farmland from being trampled.
+	HandleCropTrample(ctx *event.Context, pos cube.Pos, e Entity)
 	// HandleEntitySpawn handles an entity being spawned into a World through a call to World.AddEntity.
 	HandleEntitySpawn(e Entity)
 	// HandleEntityDespawn handles an entity being despawned from a World through a call to World.RemoveEntity.
 	HandleEntityDespawn(e Entity)
+	// HandleEntityBreed handles two entities, parentA and parentB, breeding to create a new entity, child.
+	// ctx.Cancel() may be called to prevent the child from being spawned. If cancelled, the parents leave love
+	// mode without receiving a breeding cooldown.
+	HandleEntityBreed(ctx *event.Context, parentA, parentB, child Entity)
+	// HandleLowTPS handles the TPS (ticks per second) of the World dropping below the LowTPSThreshold set in the
+	// Config of the World. The TPS and the MSPT (average milliseconds per tick) at that moment are passed.
+	// HandleLowTPS is called only once until the TPS rises above the threshold again.
+	HandleLowTPS(tps, mspt float64)
 	// HandleClose handles the World being closed. HandleClose may be used as a moment to finish code running on other
 	// goroutines that operates on the World specifically. HandleClose is called directly before the World stops
 	// ticking and before any chunks are saved to disk.
@@ -57,8 +81,14 @@ func (NopHandler) HandleLiquidFlow(*event.Context, cube.Pos, cube.Pos, Liquid, B
 func (NopHandler) HandleLiquidDecay(*event.Context, cube.Pos, Liquid, Liquid)         {}
 func (NopHandler) HandleLiquidHarden(*event.Context, cube.Pos, Block, Block, Block)   {}
 func (NopHandler) HandleSound(*event.Context, Sound, mgl64.Vec3)                      {}
+func (NopHandler) HandleGameEvent(*event.Context, GameEvent, mgl64.Vec3, Entity)      {}
 func (NopHandler) HandleFireSpread(*event.Context, cube.Pos, cube.Pos)                {}
 func (NopHandler) HandleBlockBurn(*event.Context, cube.Pos)                           {}
+func (NopHandler) HandleBlockGrow(*event.Context, cube.Pos, Block)                    {}
+func (NopHandler) HandleTreeGrow(*event.Context, cube.Pos, cube.Pos, Structure)       {}
+func (NopHandler) HandleCropTrample(*event.Context, cube.Pos, Entity)                 {}
 func (NopHandler) HandleEntitySpawn(Entity)                                           {}
 func (NopHandler) HandleEntityDespawn(Entity)                                         {}
+func (NopHandler) HandleEntityBreed(*event.Context, Entity, Entity, Entity)           {}
+func (NopHandler) HandleLowTPS(float64, float64)                                      {}
 func (NopHandler) HandleClose()                                                       {}
@@ -3,6 +3,7 @@ package world
 import (
 	"github.com/go-gl/mathgl/mgl64"
 	"math"
+	"sort"
 	"sync"
 )
 
@@ -16,6 +17,7 @@ type Loader struct {
 
 	mu        sync.RWMutex
 	pos       ChunkPos
+	dir       mgl64.Vec2
 	loadQueue []ChunkPos
 	loaded    map[ChunkPos]*Column
 
@@ -73,6 +75,37 @@ func (l *Loader) Move(pos mgl64.Vec3) {
 	l.populateLoadQueue()
 }
 
+// position returns the ChunkPos that the Loader is currently in.
+func (l *Loader) position() ChunkPos {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.pos
+}
+
+// Look changes the direction, in degrees of yaw, that the Loader faces. Chunks in front of the Loader are
+// loaded before chunks behind it at a similar distance, so that the chunks a viewer is looking at are
+// loaded first. The load queue is only re-prioritised if the direction changed significantly.
+func (l *Loader) Look(yaw float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rad := mgl64.DegToRad(yaw)
+	dir := mgl64.Vec2{-math.Sin(rad), math.Cos(rad)}
+	if l.dir.Dot(dir) > math.Cos(math.Pi/4) {
+		// The direction changed by less than 45 degrees: Not worth re-prioritising the queue.
+		return
+	}
+	l.dir = dir
+	l.prioritiseLoadQueue()
+}
+
+// Queued returns the amount of chunks that are waiting to be loaded by the Loader.
+func (l *Loader) Queued() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.loadQueue)
+}
+
 // Load loads n chunks around the centre of the chunk, starting with the middle and working outwards. For
 // every chunk loaded, the Viewer passed through construction in New has its ViewChunk method called.
 // Load does nothing for n <= 0.
@@ -165,18 +198,15 @@ func (l *Loader) evictUnused() {
 
 // populateLoadQueue populates the load queue of the loader. This method is called once to create the order in
 // which chunks around the position the loader is now in should be loaded. Chunks are ordered to be loaded
-// from the middle outwards.
+// from the middle outwards in a spiral, prioritising chunks in the direction the loader faces.
 func (l *Loader) populateLoadQueue() {
-	// We'll first load the chunk positions to load in a map indexed by the distance to the center (basically,
-	// what precedence it should have), and put them in the loadQueue in that order.
-	queue := map[int32][]ChunkPos{}
+	l.loadQueue = l.loadQueue[:0]
 
 	r := int32(l.r)
 	for x := -r; x <= r; x++ {
 		for z := -r; z <= r; z++ {
 			distance := math.Sqrt(float64(x*x) + float64(z*z))
-			chunkDistance := int32(math.Round(distance))
-			if chunkDistance > r {
+			if int32(math.Round(distance)) >= r {
 				// The chunk was outside the chunk radius.
 				continue
 			}
@@ -185,16 +215,38 @@ func (l *Loader) populateLoadQueue() {
 				// The chunk was already loaded, so we don't need to do anything.
 				continue
 			}
-			if m, ok := queue[chunkDistance]; ok {
-				queue[chunkDistance] = append(m, pos)
-				continue
-			}
-			queue[chunkDistance] = []ChunkPos{pos}
+			l.loadQueue = append(l.loadQueue, pos)
 		}
 	}
+	l.prioritiseLoadQueue()
+}
 
-	l.loadQueue = l.loadQueue[:0]
-	for i := int32(0); i < r; i++ {
-		l.loadQueue = append(l.loadQueue, queue[i]...)
+// prioritiseLoadQueue sorts the load queue so that chunks closest to the loader are loaded first. Chunks in
+// the direction the loader faces are treated as if they were closer than chunks behind it. Chunks with the
+// same priority are ordered by their angle to the loader, so that they are loaded in a spiral.
+func (l *Loader) prioritiseLoadQueue() {
+	type entry struct {
+		pos             ChunkPos
+		priority, angle float64
+	}
+	entries := make([]entry, len(l.loadQueue))
+	for i, pos := range l.loadQueue {
+		offset := mgl64.Vec2{float64(pos[0] - l.pos[0]), float64(pos[1] - l.pos[1])}
+		e := entry{pos: pos, angle: math.Atan2(offset[1], offset[0])}
+		if distance := offset.Len(); distance > 0 {
+			// Chunks straight ahead are prioritised as if they were 30% closer, chunks straight behind as
+			// if they were 30% further away.
+			e.priority = distance * (1 - 0.3*l.dir.Dot(offset.Mul(1/distance)))
+		}
+		entries[i] = e
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].priority != entries[j].priority {
+			return entries[i].priority < entries[j].priority
+		}
+		return entries[i].angle < entries[j].angle
+	})
+	for i, e := range entries {
+		l.loadQueue[i] = e.pos
 	}
 }
@@ -24,6 +24,9 @@ type Config struct {
 	// Log is the Logger that will be used to log errors and debug messages to.
 	// If set to nil, a Logrus logger will be used.
 	Log Logger
+	// EntityLog is the Logger that errors about reading and writing entities
+	// are logged to. If set to nil, Log will be used.
+	EntityLog Logger
 	// Compression specifies the compression to use for compressing new data in
 	// the database. Decompression of the database will happen based on IDs
 	// found in the compressed blocks and is therefore uninfluenced by this
@@ -51,6 +54,9 @@ func (conf Config) Open(dir string) (*DB, error) {
 	if conf.Log == nil {
 		conf.Log = logrus.New()
 	}
+	if conf.EntityLog == nil {
+		conf.EntityLog = conf.Log
+	}
 	if conf.BlockSize == 0 {
 		conf.BlockSize = 16 * opt.KiB
 	}
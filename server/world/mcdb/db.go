@@ -45,6 +45,9 @@ func (db *DB) Settings() *world.Settings {
 // SaveSettings saves the world.Settings passed to the level.dat.
 func (db *DB) SaveSettings(s *world.Settings) {
 	db.ldat.PutSettings(s)
+	if err := db.writeLevelDat(); err != nil {
+		db.conf.Log.Errorf("save settings: %v", err)
+	}
 }
 
 // playerData holds the fields that indicate where player data is stored for a player with a specific UUID.
@@ -252,13 +255,13 @@ func (db *DB) entities(k dbKey) ([]world.Entity, error) {
 		}
 		id, ok := m["identifier"]
 		if !ok {
-			db.conf.Log.Errorf("missing identifier field in %v", m)
+			db.conf.EntityLog.Errorf("missing identifier field in %v", m)
 			continue
 		}
 		name, _ := id.(string)
 		t, ok := db.conf.Entities.Lookup(name)
 		if !ok {
-			db.conf.Log.Errorf("entity %v was not registered (%v)", name, m)
+			db.conf.EntityLog.Errorf("entity %v was not registered (%v)", name, m)
 			continue
 		}
 		if s, ok := t.(world.SaveableEntityType); ok {
@@ -368,7 +371,7 @@ func (db *DB) storeEntities(batch *leveldb.Batch, k dbKey, entities []world.Enti
 		x := t.EncodeNBT(e)
 		x["identifier"] = t.EncodeEntity()
 		if err := enc.Encode(x); err != nil {
-			db.conf.Log.Errorf("store entities: error encoding NBT: %w", err)
+			db.conf.EntityLog.Errorf("store entities: error encoding NBT: %w", err)
 		}
 	}
 	batch.Put(k.Sum(keyEntities), buf.Bytes())
@@ -409,19 +412,27 @@ func (db *DB) NewColumnIterator(r *IteratorRange) *ColumnIterator {
 
 // Close closes the provider, saving any file that might need to be saved, such as the level.dat.
 func (db *DB) Close() error {
+	if err := db.writeLevelDat(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	return db.ldb.Close()
+}
+
+// writeLevelDat writes the level.dat and levelname.txt files of the DB to disk.
+func (db *DB) writeLevelDat() error {
 	db.ldat.LastPlayed = time.Now().Unix()
 
 	var ldat leveldat.LevelDat
 	if err := ldat.Marshal(*db.ldat); err != nil {
-		return fmt.Errorf("close: %w", err)
+		return err
 	}
 	if err := ldat.WriteFile(filepath.Join(db.dir, "level.dat")); err != nil {
-		return fmt.Errorf("close: %w", err)
+		return err
 	}
 	if err := os.WriteFile(filepath.Join(db.dir, "levelname.txt"), []byte(db.ldat.LevelName), 0644); err != nil {
-		return fmt.Errorf("close: write levelname.txt: %w", err)
+		return fmt.Errorf("write levelname.txt: %w", err)
 	}
-	return db.ldb.Close()
+	return nil
 }
 
 // dbKey holds a position and dimension.
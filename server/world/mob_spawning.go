@@ -0,0 +1,232 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"golang.org/x/exp/slices"
+	"sync"
+)
+
+// MobCategory is a category of mobs that are spawned naturally. Every MobCategory has its own mob cap and its
+// own rules on where mobs may spawn.
+type MobCategory int
+
+const (
+	// MobCategoryHostile is the category of hostile mobs, such as zombies. Hostile mobs only spawn in the dark
+	// and never spawn if the Difficulty of the World is DifficultyPeaceful.
+	MobCategoryHostile MobCategory = iota
+	// MobCategoryPassive is the category of passive mobs, such as cows. Passive mobs only spawn in light.
+	MobCategoryPassive
+	// MobCategoryAmbient is the category of ambient mobs, such as bats. Ambient mobs only spawn in the dark.
+	MobCategoryAmbient
+)
+
+// Cap returns the maximum amount of mobs of the MobCategory that may be spawned naturally for every viewer of
+// a World.
+func (c MobCategory) Cap() int {
+	switch c {
+	case MobCategoryHostile:
+		return 70
+	case MobCategoryPassive:
+		return 10
+	}
+	return 15
+}
+
+// interval returns the interval in ticks at which spawning mobs of the MobCategory is attempted.
+func (c MobCategory) interval() int64 {
+	if c == MobCategoryPassive {
+		return 400
+	}
+	return 20
+}
+
+// lightAllowed checks if a mob of the MobCategory may spawn at the light level passed.
+func (c MobCategory) lightAllowed(l uint8) bool {
+	if c == MobCategoryPassive {
+		return l >= 9
+	}
+	return l <= 7
+}
+
+// MobSpawnEntry is an entry in the spawn list of a World. It describes a type of mob that may spawn naturally,
+// how common it is and where it may spawn.
+type MobSpawnEntry struct {
+	// Category is the MobCategory of the mob. It decides the mob cap that the mob counts towards and the light
+	// levels that the mob may spawn at.
+	Category MobCategory
+	// Weight is the weight of the entry relative to other entries with the same Category. Entries with a
+	// higher weight are selected more often. Entries with a Weight of 0 or lower are never selected.
+	Weight int
+	// MinGroupSize and MaxGroupSize are the minimum and maximum amount of mobs spawned at once.
+	MinGroupSize, MaxGroupSize int
+	// Biomes is a list of biomes that the mob may spawn in. If empty, the mob may spawn in any biome.
+	Biomes []Biome
+	// New creates a new mob at the position passed. The Entity returned is added to the World.
+	New func(pos mgl64.Vec3) Entity
+}
+
+// mobSpawner implements the natural spawning of mobs in a World, using the MobSpawnEntry list passed in the
+// Config of the World.
+type mobSpawner struct {
+	w *World
+
+	mu sync.Mutex
+	// mobs holds all entities spawned by the mobSpawner, together with the MobCategory they were spawned with.
+	mobs map[Entity]MobCategory
+}
+
+// tickMobSpawning attempts to spawn mobs of every MobCategory around the loaders passed if the mob cap of the
+// category has not yet been reached.
+func (s *mobSpawner) tickMobSpawning(loaders []*Loader, tick int64) {
+	if len(s.w.conf.MobSpawns) == 0 || len(loaders) == 0 {
+		return
+	}
+	counts := s.counts()
+	for _, c := range []MobCategory{MobCategoryHostile, MobCategoryPassive, MobCategoryAmbient} {
+		if tick%c.interval() != 0 || counts[c] >= c.Cap()*len(loaders) {
+			continue
+		}
+		if diff, ok := s.w.Difficulty().(MobDifficulty); ok && c == MobCategoryHostile && !diff.HostileMobsSpawn() {
+			continue
+		}
+		for _, l := range loaders {
+			l.mu.RLock()
+			pos := l.pos
+			l.mu.RUnlock()
+			s.attempt(c, pos, loaders)
+		}
+	}
+}
+
+// counts counts the amount of mobs spawned for every MobCategory that are still in the World, removing those
+// that no longer are.
+func (s *mobSpawner) counts() map[MobCategory]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[MobCategory]int, 3)
+	s.w.entityMu.RLock()
+	for e, c := range s.mobs {
+		if _, ok := s.w.entities[e]; !ok {
+			delete(s.mobs, e)
+			continue
+		}
+		counts[c]++
+	}
+	s.w.entityMu.RUnlock()
+	return counts
+}
+
+// attempt attempts to spawn a group of mobs of the MobCategory passed in a random chunk around the ChunkPos
+// passed.
+func (s *mobSpawner) attempt(c MobCategory, centre ChunkPos, loaders []*Loader) {
+	const spawnRadius = 8
+	r := s.w.r
+
+	x := int(centre[0]<<4) + r.Intn(spawnRadius*32+16) - spawnRadius*16
+	z := int(centre[1]<<4) + r.Intn(spawnRadius*32+16) - spawnRadius*16
+	col, ok := s.w.chunkFromCache(chunkPosFromBlockPos(cube.Pos{x, 0, z}))
+	if !ok {
+		// Never spawn mobs in chunks that aren't loaded: Doing so would load the chunk.
+		return
+	}
+	col.Unlock()
+
+	y := s.w.HighestBlock(x, z) + 1
+	if c != MobCategoryPassive {
+		if low := s.w.Range().Min(); y > low {
+			y = low + r.Intn(y-low+1)
+		}
+	}
+	pos := cube.Pos{x, y, z}
+	if !s.farFromLoaders(pos, loaders) || !s.canSpawnAt(c, pos) {
+		return
+	}
+	entry, ok := s.entry(c, s.w.Biome(pos))
+	if !ok {
+		return
+	}
+	n := entry.MinGroupSize
+	if entry.MaxGroupSize > n {
+		n += r.Intn(entry.MaxGroupSize - n + 1)
+	}
+	for i := 0; i < n; i++ {
+		mobPos := pos
+		if i != 0 {
+			mobPos = pos.Add(cube.Pos{r.Intn(5) - 2, 0, r.Intn(5) - 2})
+			if !s.canSpawnAt(c, mobPos) {
+				continue
+			}
+		}
+		e := entry.New(mobPos.Vec3Middle())
+		s.mu.Lock()
+		s.mobs[e] = c
+		s.mu.Unlock()
+		s.w.AddEntity(e)
+	}
+}
+
+// entry selects a random MobSpawnEntry of the MobCategory passed that may spawn in the Biome passed. False is
+// returned if no such entry exists.
+func (s *mobSpawner) entry(c MobCategory, b Biome) (MobSpawnEntry, bool) {
+	entries, total := make([]MobSpawnEntry, 0, len(s.w.conf.MobSpawns)), 0
+	for _, entry := range s.w.conf.MobSpawns {
+		if entry.Category != c || entry.Weight <= 0 || entry.New == nil {
+			continue
+		}
+		if len(entry.Biomes) != 0 && !slices.ContainsFunc(entry.Biomes, func(other Biome) bool {
+			return other.EncodeBiome() == b.EncodeBiome()
+		}) {
+			continue
+		}
+		entries, total = append(entries, entry), total+entry.Weight
+	}
+	if total == 0 {
+		return MobSpawnEntry{}, false
+	}
+	n := s.w.r.Intn(total)
+	for _, entry := range entries {
+		if n -= entry.Weight; n < 0 {
+			return entry, true
+		}
+	}
+	return MobSpawnEntry{}, false
+}
+
+// canSpawnAt checks if a mob of the MobCategory passed can spawn at the position passed. This is the case if
+// the position and the one above it are free, the block below has a solid top face and the light level is
+// allowed for the MobCategory.
+func (s *mobSpawner) canSpawnAt(c MobCategory, pos cube.Pos) bool {
+	if pos.OutOfBounds(s.w.Range()) || pos.Side(cube.FaceUp).OutOfBounds(s.w.Range()) {
+		return false
+	}
+	above := pos.Side(cube.FaceUp)
+	if len(s.w.Block(pos).Model().BBox(pos, s.w)) != 0 || len(s.w.Block(above).Model().BBox(above, s.w)) != 0 {
+		return false
+	}
+	if _, ok := s.w.Liquid(pos); ok {
+		return false
+	}
+	below := pos.Side(cube.FaceDown)
+	if !s.w.Block(below).Model().FaceSolid(below, cube.FaceUp, s.w) {
+		return false
+	}
+	return c.lightAllowed(s.w.Light(pos))
+}
+
+// farFromLoaders checks if the position passed is at least 24 blocks away from the centre of the chunks that
+// all loaders passed are in.
+func (s *mobSpawner) farFromLoaders(pos cube.Pos, loaders []*Loader) bool {
+	for _, l := range loaders {
+		l.mu.RLock()
+		chunkPos := l.pos
+		l.mu.RUnlock()
+
+		dx, dz := float64(pos[0]-int(chunkPos[0]<<4+8)), float64(pos[2]-int(chunkPos[1]<<4+8))
+		if dx*dx+dz*dz < 24*24 {
+			return false
+		}
+	}
+	return true
+}
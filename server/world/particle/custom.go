@@ -0,0 +1,10 @@
+package particle
+
+// Custom is a particle effect that is spawned by its identifier, such as "minecraft:heart_particle" or a particle
+// added by a resource pack. It may be used to show particles that have no type in this package.
+type Custom struct {
+	particle
+
+	// Identifier is the identifier of the particle effect.
+	Identifier string
+}
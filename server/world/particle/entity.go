@@ -32,3 +32,6 @@ type Effect struct {
 
 // EntityFlame is a particle shown when an entity is set on fire.
 type EntityFlame struct{ particle }
+
+// MobSpawn is a particle shown when a mob spawner spawns a mob.
+type MobSpawn struct{ particle }
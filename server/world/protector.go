@@ -0,0 +1,87 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/permission"
+)
+
+// Protector decides which entities may change or interact with blocks at specific positions in a World. A
+// Protector is consulted before a block is broken or placed and before a block, such as a container, is
+// interacted with. It may be implemented to protect specific regions of a World, such as land claims.
+type Protector interface {
+	// AllowBuild returns true if the Entity passed may break or place a block at the cube.Pos passed.
+	AllowBuild(e Entity, pos cube.Pos, w *World) bool
+	// AllowInteract returns true if the Entity passed may interact with the block at the cube.Pos passed, for
+	// example by opening a container or by using an item on it.
+	AllowInteract(e Entity, pos cube.Pos, w *World) bool
+}
+
+// NopProtector is a Protector that allows entities to do anything anywhere.
+type NopProtector struct{}
+
+// Compile time check to make sure NopProtector implements Protector.
+var _ Protector = NopProtector{}
+
+func (NopProtector) AllowBuild(Entity, cube.Pos, *World) bool    { return true }
+func (NopProtector) AllowInteract(Entity, cube.Pos, *World) bool { return true }
+
+// SpawnProtectionBypass is the permission node that allows entities implementing permission.Holder to build
+// and interact with blocks in the area protected by a SpawnProtector.
+const SpawnProtectionBypass = "dragonfly.spawnprotection.bypass"
+
+// SpawnProtector is a Protector that prevents entities from building and interacting with blocks within a
+// horizontal radius around the spawn of a World. Entities with the SpawnProtectionBypass permission are not
+// affected.
+type SpawnProtector struct {
+	// Radius is the radius in blocks around the spawn of the World that is protected. If Radius is 0 or
+	// lower, no blocks are protected.
+	Radius int
+}
+
+// AllowBuild returns false if pos is within the Radius of the spawn of w.
+func (s SpawnProtector) AllowBuild(e Entity, pos cube.Pos, w *World) bool {
+	return !s.protected(e, pos, w)
+}
+
+// AllowInteract returns false if pos is within the Radius of the spawn of w.
+func (s SpawnProtector) AllowInteract(e Entity, pos cube.Pos, w *World) bool {
+	return !s.protected(e, pos, w)
+}
+
+// protected checks if a cube.Pos is within the protected area around the spawn of w and if the Entity passed
+// is unable to bypass the protection.
+func (s SpawnProtector) protected(e Entity, pos cube.Pos, w *World) bool {
+	if s.Radius <= 0 {
+		return false
+	}
+	if holder, ok := e.(permission.Holder); ok && holder.HasPermission(SpawnProtectionBypass) {
+		return false
+	}
+	spawn := w.Spawn()
+	dx, dz := pos[0]-spawn[0], pos[2]-spawn[2]
+	return dx >= -s.Radius && dx <= s.Radius && dz >= -s.Radius && dz <= s.Radius
+}
+
+// Protectors combines multiple Protector implementations into one. An action is only allowed if every
+// Protector in the slice allows it.
+type Protectors []Protector
+
+// AllowBuild returns true if every Protector allows building at pos.
+func (p Protectors) AllowBuild(e Entity, pos cube.Pos, w *World) bool {
+	for _, protector := range p {
+		if !protector.AllowBuild(e, pos, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowInteract returns true if every Protector allows interacting with the block at pos.
+func (p Protectors) AllowInteract(e Entity, pos cube.Pos, w *World) bool {
+	for _, protector := range p {
+		if !protector.AllowInteract(e, pos, w) {
+			return false
+		}
+	}
+	return true
+}
@@ -41,6 +41,9 @@ type Settings struct {
 	// TickRange is the radius in chunks around a Viewer that has its blocks and entities ticked when the world is
 	// ticked. If set to 0, blocks and entities will never be ticked.
 	TickRange int32
+	// KeepInventory specifies if players keep their items and experience when they die. If set to false, the
+	// inventory and experience of players is dropped on death.
+	KeepInventory bool
 }
 
 // defaultSettings returns the default Settings for a new World.
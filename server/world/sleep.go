@@ -0,0 +1,56 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// Sleeper represents an entity that is able to sleep, such as a player sleeping in a bed. The night in a World
+// is skipped once all Sleepers in it have been sleeping for a while.
+type Sleeper interface {
+	Entity
+	// Sleeping returns the position of the bed that the Sleeper is sleeping in. False is returned if the
+	// Sleeper is not currently sleeping.
+	Sleeping() (cube.Pos, bool)
+	// Wake wakes the Sleeper up if it is currently sleeping.
+	Wake()
+	// CanSleep checks if the Sleeper is able to sleep at all. Sleepers that cannot sleep, such as players in
+	// spectator mode or dead players, do not need to be sleeping for the night to be skipped.
+	CanSleep() bool
+}
+
+// sleepTicks is the amount of ticks that all Sleepers in a World must have been sleeping for before the night
+// is skipped.
+const sleepTicks = 100
+
+// tickSleeping checks if all Sleepers in the World are sleeping every second. If they have been sleeping for at
+// least sleepTicks, the time of the World is advanced to the next morning, the weather is cleared and all
+// Sleepers are woken up.
+func (t ticker) tickSleeping(tick int64) {
+	if tick%20 != 0 || !t.w.conf.Dim.TimeCycle() {
+		return
+	}
+	sleepers, sleeping := make([]Sleeper, 0, 8), 0
+	for _, e := range t.w.Entities() {
+		if s, ok := e.(Sleeper); ok && s.CanSleep() {
+			sleepers = append(sleepers, s)
+			if _, ok := s.Sleeping(); ok {
+				sleeping++
+			}
+		}
+	}
+	if len(sleepers) == 0 || sleeping != len(sleepers) {
+		t.w.sleepTicks = 0
+		return
+	}
+	if t.w.sleepTicks += 20; t.w.sleepTicks < sleepTicks {
+		return
+	}
+	t.w.sleepTicks = 0
+
+	current := t.w.Time()
+	t.w.SetTime(current - current%24000 + 24000)
+	t.w.StopRaining()
+	for _, s := range sleepers {
+		s.Wake()
+	}
+}
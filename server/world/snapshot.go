@@ -0,0 +1,116 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// ChunkSnapshot is an immutable copy of a chunk at the time it was taken. Unlike the World, a ChunkSnapshot
+// holds no locks and is never changed by the tick loop, so it may be read freely from other goroutines, for
+// example for map rendering, analytics or path finding.
+// Methods on ChunkSnapshot are safe for simultaneous use from multiple goroutines.
+type ChunkSnapshot struct {
+	pos           ChunkPos
+	c             *chunk.Chunk
+	blockEntities map[cube.Pos]Block
+}
+
+// ChunkSnapshot takes a ChunkSnapshot of the chunk at the ChunkPos passed. If the chunk is not yet loaded, it is
+// loaded, or generated if it could not be found in the world save. Changes made to the World after the call do
+// not show up in the ChunkSnapshot returned.
+func (w *World) ChunkSnapshot(pos ChunkPos) *ChunkSnapshot {
+	if w == nil {
+		return &ChunkSnapshot{pos: pos, c: chunk.New(airRID, w.Range())}
+	}
+	c := w.chunk(pos)
+	defer c.Unlock()
+	blockEntities := make(map[cube.Pos]Block, len(c.BlockEntities))
+	for p, b := range c.BlockEntities {
+		blockEntities[p] = copyBlockEntity(b)
+	}
+	return &ChunkSnapshot{pos: pos, c: c.Chunk.Clone(), blockEntities: blockEntities}
+}
+
+// copyBlockEntity returns a deep copy of the block entity passed by encoding it to NBT and decoding it into a new
+// block. Block entities often hold pointers, such as to an inventory, that would otherwise be shared with the
+// World.
+func copyBlockEntity(b Block) Block {
+	nbter, ok := b.(NBTer)
+	if !ok {
+		return b
+	}
+	if cp, ok := nbter.DecodeNBT(nbter.EncodeNBT()).(Block); ok {
+		return cp
+	}
+	return b
+}
+
+// Pos returns the ChunkPos of the chunk that the ChunkSnapshot was taken of.
+func (s *ChunkSnapshot) Pos() ChunkPos {
+	return s.pos
+}
+
+// Range returns the vertical range of the ChunkSnapshot.
+func (s *ChunkSnapshot) Range() cube.Range {
+	return s.c.Range()
+}
+
+// Block returns the block at the position passed. Only the X and Z values of the position within the chunk are
+// used, so a position outside the chunk results in the block at the same relative position within it.
+func (s *ChunkSnapshot) Block(pos cube.Pos) Block {
+	if pos.OutOfBounds(s.c.Range()) {
+		return air()
+	}
+	rid := s.c.Block(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0)
+	if nbtBlocks[rid] {
+		// Block entities are stored by their absolute position, so the relative position passed is first moved
+		// into the chunk.
+		abs := cube.Pos{int(s.pos[0])<<4 | pos[0]&0xf, pos[1], int(s.pos[1])<<4 | pos[2]&0xf}
+		if b, ok := s.blockEntities[abs]; ok {
+			return b
+		}
+	}
+	b, _ := BlockByRuntimeID(rid)
+	return b
+}
+
+// Biome returns the biome at the position passed. Similarly to Block, only the relative X and Z values of the
+// position are used.
+func (s *ChunkSnapshot) Biome(pos cube.Pos) Biome {
+	if pos.OutOfBounds(s.c.Range()) {
+		return ocean()
+	}
+	b, _ := BiomeByID(int(s.c.Biome(uint8(pos[0]), int16(pos[1]), uint8(pos[2]))))
+	return b
+}
+
+// Light returns the light level at the position passed, in the range 0-15.
+func (s *ChunkSnapshot) Light(pos cube.Pos) uint8 {
+	if pos[1] < s.c.Range()[0] {
+		return 0
+	} else if pos[1] > s.c.Range()[1] {
+		return 15
+	}
+	return s.c.Light(uint8(pos[0]), int16(pos[1]), uint8(pos[2]))
+}
+
+// SkyLight returns the skylight level at the position passed, in the range 0-15.
+func (s *ChunkSnapshot) SkyLight(pos cube.Pos) uint8 {
+	if pos[1] < s.c.Range()[0] {
+		return 0
+	} else if pos[1] > s.c.Range()[1] {
+		return 15
+	}
+	return s.c.SkyLight(uint8(pos[0]), int16(pos[1]), uint8(pos[2]))
+}
+
+// HighestBlock returns the Y value of the highest non-air block at the X and Z passed. If no blocks are present
+// in the column, the minimum height of the ChunkSnapshot is returned.
+func (s *ChunkSnapshot) HighestBlock(x, z int) int {
+	return int(s.c.HighestBlock(uint8(x), uint8(z)))
+}
+
+// HighestLightBlocker returns the Y value of the highest fully light blocking block at the X and Z passed.
+func (s *ChunkSnapshot) HighestLightBlocker(x, z int) int {
+	return int(s.c.HighestLightBlocker(uint8(x), uint8(z)))
+}
@@ -123,6 +123,15 @@ type TNT struct{ sound }
 // FireExtinguish is a sound played when a fire is extinguished.
 type FireExtinguish struct{ sound }
 
+// CampfireCrackle is a sound played randomly by a lit campfire.
+type CampfireCrackle struct{ sound }
+
+// SculkSensorPowerOn is a sound played when a sculk sensor detects a vibration and becomes active.
+type SculkSensorPowerOn struct{ sound }
+
+// SculkSensorPowerOff is a sound played when a sculk sensor stops being active.
+type SculkSensorPowerOff struct{ sound }
+
 // Note is a sound played by note blocks.
 type Note struct {
 	sound
@@ -176,6 +185,25 @@ type ComposterReady struct{ sound }
 // LecternBookPlace is a sound played when a book is placed in a lectern.
 type LecternBookPlace struct{ sound }
 
+// BookPageTurn is a sound played when a page is turned in a book held by a lectern.
+type BookPageTurn struct{ sound }
+
+// ChiseledBookshelfInsert is a sound played when a book is put into a chiseled bookshelf.
+type ChiseledBookshelfInsert struct {
+	sound
+
+	// Enchanted is true if the book inserted was an enchanted book.
+	Enchanted bool
+}
+
+// ChiseledBookshelfRemove is a sound played when a book is taken out of a chiseled bookshelf.
+type ChiseledBookshelfRemove struct {
+	sound
+
+	// Enchanted is true if the book removed was an enchanted book.
+	Enchanted bool
+}
+
 // sound implements the world.Sound interface.
 type sound struct{}
 
@@ -0,0 +1,14 @@
+package sound
+
+// Custom is a sound that is played by its name, such as "mob.cat.meow" or a sound added by a resource pack. It
+// may be used to play sounds that have no type in this package.
+type Custom struct {
+	sound
+
+	// Name is the name of the sound, as found in the sound definitions of the client.
+	Name string
+	// Volume is the volume of the sound. A Volume of 0 is treated as 1.
+	Volume float64
+	// Pitch is the pitch of the sound. A Pitch of 0 is treated as 1.
+	Pitch float64
+}
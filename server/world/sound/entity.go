@@ -41,6 +41,12 @@ type LevelUp struct{ sound }
 // Experience is a sound played whenever a player picks up an XP orb.
 type Experience struct{ sound }
 
+// Totem is a sound played when a totem of undying prevents the death of an entity.
+type Totem struct{ sound }
+
+// ShieldBlock is a sound played when a shield blocks damage dealt to an entity.
+type ShieldBlock struct{ sound }
+
 // GhastWarning is a sound played when a ghast is ready to attack.
 type GhastWarning struct{ sound }
 
@@ -50,6 +50,35 @@ type BowShoot struct{ sound }
 // ArrowHit is a sound played when an arrow hits ground.
 type ArrowHit struct{ sound }
 
+// CrossbowLoad is a sound played when a crossbow is charged.
+type CrossbowLoad struct{ sound }
+
+// CrossbowShoot is a sound played when a crossbow is shot.
+type CrossbowShoot struct{ sound }
+
+// TridentThrow is a sound played when a trident is thrown.
+type TridentThrow struct{ sound }
+
+// TridentHit is a sound played when a thrown trident hits an entity.
+type TridentHit struct{ sound }
+
+// TridentHitGround is a sound played when a thrown trident hits ground.
+type TridentHitGround struct{ sound }
+
+// TridentReturn is a sound played when a trident enchanted with loyalty starts returning to its thrower.
+type TridentReturn struct{ sound }
+
+// TridentThunder is a sound played when a trident enchanted with channeling summons lightning.
+type TridentThunder struct{ sound }
+
+// TridentRiptide is a sound played when a player launches itself using a trident enchanted with riptide.
+type TridentRiptide struct {
+	// Level is the level of the riptide enchantment, ranging from 1 to 3.
+	Level int
+
+	sound
+}
+
 // Teleport is a sound played upon teleportation of an enderman, or teleportation of a player by an ender pearl or a chorus fruit.
 type Teleport struct{ sound }
 
@@ -3,27 +3,65 @@ package world
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/df-mc/dragonfly/server/timings"
+	"github.com/go-gl/mathgl/mgl64"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	"math/rand"
+	"runtime"
+	"sync"
 	"time"
 )
 
 // ticker implements World ticking methods. World embeds this struct, so any exported methods on ticker are exported
 // methods on World.
-type ticker struct{ w *World }
+type ticker struct {
+	w *World
+	// regionRands holds a *rand.Rand for every goroutine that ticks regions in parallel. The same *rand.Rand is
+	// reused by a goroutine for all regions it ticks.
+	regionRands []*rand.Rand
+}
+
+// newTicker returns a ticker for the World passed.
+func newTicker(w *World) ticker {
+	rands := make([]*rand.Rand, runtime.GOMAXPROCS(0))
+	for i := range rands {
+		rands[i] = rand.New(rand.NewSource(w.r.Int63()))
+	}
+	return ticker{w: w, regionRands: rands}
+}
+
+// maxCatchUpTicks is the maximum amount of ticks that a World runs directly after each other to catch up after
+// falling behind. If a World falls further behind than that, the ticks missed are skipped instead.
+const maxCatchUpTicks = 20
 
 // tickLoop starts ticking the World 20 times every second, updating all entities, blocks and other features such as
-// the time and weather of the world, as required.
+// the time and weather of the world, as required. If a tick takes longer than a 20th of a second, the ticks that
+// follow are run directly after each other until the World has caught up.
 func (t ticker) tickLoop() {
-	tc := time.NewTicker(time.Second / 20)
+	const interval = time.Second / 20
+	tc := time.NewTimer(interval)
 	defer tc.Stop()
+	next := time.Now().Add(interval)
 
 	t.w.running.Add(1)
 	for {
 		select {
 		case <-tc.C:
+			start := time.Now()
 			t.tick()
+			d := time.Since(start)
+			timings.Global.Record("world.tick", d)
+			t.w.tickStats.record(start, d)
+			t.checkLag()
+
+			next = next.Add(interval)
+			if time.Since(next) > interval*maxCatchUpTicks {
+				// The World is too far behind to catch up: Skip the ticks missed and continue ticking normally
+				// from now on.
+				next = time.Now()
+			}
+			tc.Reset(time.Until(next))
 		case <-t.w.closing:
 			// World is being closed: Stop ticking and get rid of a task.
 			t.w.running.Done()
@@ -32,8 +70,20 @@ func (t ticker) tickLoop() {
 	}
 }
 
+// checkLag calls Handler.HandleLowTPS if the TPS of the World dropped below the LowTPSThreshold in the Config of
+// the World.
+func (t ticker) checkLag() {
+	if t.w.conf.LowTPSThreshold <= 0 {
+		return
+	}
+	if tps := t.w.tickStats.tps(); t.w.tickStats.checkLagging(tps, t.w.conf.LowTPSThreshold) {
+		t.w.Handler().HandleLowTPS(tps, t.w.tickStats.mspt())
+	}
+}
+
 // tick performs a tick on the World and updates the time, weather, blocks and entities that require updates.
 func (t ticker) tick() {
+	t.execQueued()
 	viewers, loaders := t.w.allViewers()
 
 	t.w.set.Lock()
@@ -68,10 +118,40 @@ func (t ticker) tick() {
 		t.w.tickLightning()
 	}
 
-	t.tickEntities(tick)
+	stop := timings.Global.Start("world.tick.entities")
+	t.tickEntities(loaders, tick)
+	stop()
+
+	t.tickSleeping(tick)
+
+	stop = timings.Global.Start("world.tick.mob_spawning")
+	t.w.mobSpawner.tickMobSpawning(loaders, tick)
+	stop()
+
+	stop = timings.Global.Start("world.tick.blocks.random")
 	t.tickBlocksRandomly(loaders, tick)
+	stop()
+
+	stop = timings.Global.Start("world.tick.blocks.scheduled")
 	t.tickScheduledBlocks(tick)
+	stop()
+
+	stop = timings.Global.Start("world.tick.blocks.neighbour_updates")
 	t.performNeighbourUpdates()
+	stop()
+}
+
+// execQueued runs the functions passed to World.Exec since the last tick. Functions passed to Exec while
+// running them are run during the next tick.
+func (t ticker) execQueued() {
+	t.w.execMu.Lock()
+	queue := t.w.queue
+	t.w.queue = nil
+	t.w.execMu.Unlock()
+
+	for _, f := range queue {
+		f()
+	}
 }
 
 // tickScheduledBlocks executes scheduled block updates in chunks that are currently loaded.
@@ -126,6 +206,7 @@ func (t ticker) tickBlocksRandomly(loaders []*Loader, tick int64) {
 		g             randUint4
 		blockEntities []cube.Pos
 		randomBlocks  []cube.Pos
+		precipitation []ChunkPos
 	)
 	if r == 0 {
 		// NOP if the simulation distance is 0.
@@ -148,7 +229,18 @@ func (t ticker) tickBlocksRandomly(loaders []*Loader, tick int64) {
 			continue
 		}
 		c.Lock()
+		c.Decompress()
 		blockEntities = append(blockEntities, maps.Keys(c.BlockEntities)...)
+		if len(c.BlockEntities) != 0 {
+			// Block entities within the simulation distance are ticked and may be changed by players, for
+			// example by changing the contents of a chest, so the chunk must be saved again.
+			c.modified = true
+		}
+
+		if t.w.r.Intn(16) == 0 {
+			// Every tick, there is a 1/16 chance of a precipitation tick in a chunk.
+			precipitation = append(precipitation, pos)
+		}
 
 		cx, cz := int(pos[0]<<4), int(pos[1]<<4)
 
@@ -178,11 +270,14 @@ func (t ticker) tickBlocksRandomly(loaders []*Loader, tick int64) {
 	}
 	t.w.chunkMu.Unlock()
 
-	for _, pos := range randomBlocks {
+	for _, pos := range precipitation {
+		t.w.tickPrecipitation(pos)
+	}
+	tickInRegions(t.w, randomBlocks, chunkPosFromBlockPos, func(pos cube.Pos, r *rand.Rand) {
 		if rb, ok := t.w.Block(pos).(RandomTicker); ok {
-			rb.RandomTick(pos, t.w, t.w.r)
+			rb.RandomTick(pos, t.w, r)
 		}
-	}
+	})
 	for _, pos := range blockEntities {
 		if tb, ok := t.w.Block(pos).(TickerBlock); ok {
 			tb.Tick(tick, pos, t.w)
@@ -205,7 +300,7 @@ func (t ticker) anyWithinDistance(pos ChunkPos, loaded []ChunkPos, r int32) bool
 
 // tickEntities ticks all entities in the world, making sure they are still located in the correct chunks and
 // updating where necessary.
-func (t ticker) tickEntities(tick int64) {
+func (t ticker) tickEntities(loaders []*Loader, tick int64) {
 	type entityToMove struct {
 		e             Entity
 		after         *Column
@@ -214,12 +309,23 @@ func (t ticker) tickEntities(tick int64) {
 	var (
 		entitiesToMove []entityToMove
 		entitiesToTick []TickerEntity
+		loaded         []ChunkPos
 	)
+	if len(t.w.conf.ActivationRanges) != 0 {
+		loaded = make([]ChunkPos, 0, len(loaders))
+		for _, loader := range loaders {
+			loader.mu.RLock()
+			loaded = append(loaded, loader.pos)
+			loader.mu.RUnlock()
+		}
+	}
 
 	t.w.chunkMu.Lock()
 	t.w.entityMu.Lock()
 	for e, lastPos := range t.w.entities {
-		chunkPos := chunkPosFromVec3(e.Position())
+		pos := e.Position()
+		chunkPos := chunkPosFromVec3(pos)
+		t.w.entityGrid.move(e, pos)
 
 		c, ok := t.w.chunks[chunkPos]
 		if !ok {
@@ -228,10 +334,14 @@ func (t ticker) tickEntities(tick int64) {
 
 		c.Lock()
 		v := len(c.viewers)
+		if v > 0 {
+			// Entities in viewed chunks may move or otherwise change, so the chunk must be saved again.
+			c.modified = true
+		}
 		c.Unlock()
 
 		if v > 0 {
-			if ticker, ok := e.(TickerEntity); ok {
+			if ticker, ok := e.(TickerEntity); ok && t.activated(e, pos, loaded, tick) {
 				entitiesToTick = append(entitiesToTick, ticker)
 			}
 		}
@@ -248,6 +358,7 @@ func (t ticker) tickEntities(tick int64) {
 			if old, ok := t.w.chunks[lastPos]; ok {
 				old.Lock()
 				old.Entities = sliceutil.DeleteVal(old.Entities, e)
+				old.modified = true
 				viewers = slices.Clone(old.viewers)
 				old.Unlock()
 			}
@@ -260,6 +371,7 @@ func (t ticker) tickEntities(tick int64) {
 	for _, move := range entitiesToMove {
 		move.after.Lock()
 		move.after.Entities = append(move.after.Entities, move.e)
+		move.after.modified = true
 		viewersAfter := move.after.viewers
 		move.after.Unlock()
 
@@ -278,14 +390,125 @@ func (t ticker) tickEntities(tick int64) {
 			}
 		}
 	}
-	for _, ticker := range entitiesToTick {
+	entityPos := func(e TickerEntity) ChunkPos {
+		return chunkPosFromVec3(e.Position())
+	}
+	tickInRegions(t.w, entitiesToTick, entityPos, func(ticker TickerEntity, _ *rand.Rand) {
 		// Make sure the entity is still in world and has not been closed.
 		if ticker.World() == t.w {
 			// We gather entities to ticker and ticker them later, so that the lock on the entity mutex is no longer
 			// active.
 			ticker.Tick(t.w, tick)
 		}
+	})
+}
+
+// activated checks if the Entity passed at the position passed should be ticked during the current tick, using
+// the ActivationRange configured for its type.
+func (t ticker) activated(e Entity, pos mgl64.Vec3, loaded []ChunkPos, tick int64) bool {
+	r, ok := t.w.conf.ActivationRanges[e.Type().EncodeEntity()]
+	if !ok {
+		return true
+	}
+	return r.shouldTick(pos, loaded, tick)
+}
+
+const (
+	// regionInteractionRadius is the largest distance in chunks over which a block or entity ticked is assumed to
+	// change the World around it, for example by spreading fire, flowing or moving. Values ticked that are within
+	// this distance of the edge of their region are ticked after all regions, on a single goroutine.
+	regionInteractionRadius = 1
+	// minTickRegionSize is the minimum size in chunks of the regions that tickInRegions divides values in. A
+	// smaller TickRegionSize is raised to this size, so that every region has an interior of at least two by two
+	// chunks that does not interact with any other region.
+	minTickRegionSize = regionInteractionRadius*2 + 2
+)
+
+// tickInRegions calls f for every value in values. If the TickRegionSize in the Config of the World is positive,
+// the values are grouped in regions using the ChunkPos returned by pos, and values in regions that are not
+// adjacent to each other are passed to f on parallel goroutines. The regions are ticked in four phases, so that
+// two regions ticked at the same time are always at least one region apart, and values in a region are always
+// passed to f in the same goroutine. Each goroutine is passed its own *rand.Rand.
+// Values within regionInteractionRadius chunks of the edge of their region may change blocks or entities in a
+// neighbouring region. These are merged back in after all regions were ticked, by passing them to f on the
+// calling goroutine, so that no two goroutines ever change the same region.
+func tickInRegions[T any](w *World, values []T, pos func(T) ChunkPos, f func(v T, r *rand.Rand)) {
+	size := int32(w.conf.TickRegionSize)
+	if size <= 0 || len(values) < 2 {
+		for _, v := range values {
+			f(v, w.r)
+		}
+		return
+	}
+	if size < minTickRegionSize {
+		size = minTickRegionSize
+	}
+	var (
+		phases [4]map[[2]int32][]T
+		edge   []T
+	)
+	for _, v := range values {
+		p := pos(v)
+		region := [2]int32{floorDiv(p[0], size), floorDiv(p[1], size)}
+		if nearRegionEdge(p, region, size) {
+			edge = append(edge, v)
+			continue
+		}
+		phase := &phases[(region[0]&1)<<1|region[1]&1]
+		if *phase == nil {
+			*phase = make(map[[2]int32][]T)
+		}
+		(*phase)[region] = append((*phase)[region], v)
+	}
+
+	rands := w.ticker.regionRands
+	for _, regions := range phases {
+		if len(regions) == 0 {
+			continue
+		}
+		queue := make(chan []T, len(regions))
+		for _, regionValues := range regions {
+			queue <- regionValues
+		}
+		close(queue)
+
+		var wg sync.WaitGroup
+		for i := 0; i < len(rands) && i < len(regions); i++ {
+			wg.Add(1)
+			go func(r *rand.Rand) {
+				defer wg.Done()
+				for regionValues := range queue {
+					for _, v := range regionValues {
+						f(v, r)
+					}
+				}
+			}(rands[i])
+		}
+		wg.Wait()
+	}
+	for _, v := range edge {
+		f(v, w.r)
+	}
+}
+
+// nearRegionEdge checks if the ChunkPos passed is within regionInteractionRadius chunks of the edge of the region
+// passed with the size passed.
+func nearRegionEdge(pos ChunkPos, region [2]int32, size int32) bool {
+	for i := 0; i < 2; i++ {
+		rel := pos[i] - region[i]*size
+		if rel < regionInteractionRadius || rel >= size-regionInteractionRadius {
+			return true
+		}
+	}
+	return false
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+func floorDiv(a, b int32) int32 {
+	if a < 0 {
+		return (a - b + 1) / b
 	}
+	return a / b
 }
 
 // randUint4 is a structure used to generate random uint4s.
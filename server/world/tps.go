@@ -0,0 +1,100 @@
+package world
+
+import (
+	"sync"
+	"time"
+)
+
+// tickSampleCount is the amount of ticks over which the TPS and MSPT of a World are measured.
+const tickSampleCount = 100
+
+// tickStats keeps track of the start times and durations of the most recent ticks of a World, so that the TPS
+// (ticks per second) and MSPT (milliseconds per tick) of the World may be calculated.
+type tickStats struct {
+	mu        sync.Mutex
+	n, i      int
+	starts    [tickSampleCount]time.Time
+	durations [tickSampleCount]time.Duration
+
+	lagging bool
+}
+
+// record records a tick that started at the time passed and took d to complete.
+func (s *tickStats) record(start time.Time, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.starts[s.i], s.durations[s.i] = start, d
+	s.i = (s.i + 1) % tickSampleCount
+	if s.n < tickSampleCount {
+		s.n++
+	}
+}
+
+// tps returns the amount of ticks per second measured over the most recent ticks. The value returned is never
+// higher than 20.
+func (s *tickStats) tps() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.n < 2 {
+		return 20
+	}
+	newest := s.starts[(s.i+tickSampleCount-1)%tickSampleCount]
+	oldest := s.starts[(s.i+tickSampleCount-s.n)%tickSampleCount]
+	elapsed := newest.Sub(oldest)
+	if elapsed <= 0 {
+		return 20
+	}
+	tps := float64(s.n-1) / elapsed.Seconds()
+	if tps > 20 {
+		return 20
+	}
+	return tps
+}
+
+// mspt returns the average amount of milliseconds that the most recent ticks took to complete.
+func (s *tickStats) mspt() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.n == 0 {
+		return 0
+	}
+	var total time.Duration
+	for i := 0; i < s.n; i++ {
+		total += s.durations[i]
+	}
+	return float64(total) / float64(s.n) / float64(time.Millisecond)
+}
+
+// checkLagging checks if the tps passed is below the threshold passed. True is returned only if the tps
+// dropped below the threshold since the last call, so that lag is reported only once until the tps recovers.
+func (s *tickStats) checkLagging(tps, threshold float64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tps >= threshold {
+		s.lagging = false
+		return false
+	}
+	if s.lagging {
+		return false
+	}
+	s.lagging = true
+	return true
+}
+
+// TPS returns the amount of ticks per second that the World is currently ticking at, measured over the last
+// 100 ticks. Normally, a World ticks 20 times per second. A lower value means the World is lagging behind.
+func (w *World) TPS() float64 {
+	if w == nil {
+		return 20
+	}
+	return w.tickStats.tps()
+}
+
+// MSPT returns the average amount of milliseconds that ticking the World took over the last 100 ticks. If the
+// MSPT exceeds 50, the World is no longer able to tick 20 times per second.
+func (w *World) MSPT() float64 {
+	if w == nil {
+		return 0
+	}
+	return w.tickStats.mspt()
+}
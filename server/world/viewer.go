@@ -41,6 +41,10 @@ type Viewer interface {
 	ViewEntityItems(e Entity)
 	// ViewEntityArmour views the items currently equipped as armour by the entity.
 	ViewEntityArmour(e Entity)
+	// ViewEntityMount views an entity, rider, starting to ride another entity, vehicle.
+	ViewEntityMount(rider, vehicle Entity)
+	// ViewEntityDismount views an entity, rider, stopping to ride another entity, vehicle.
+	ViewEntityDismount(rider, vehicle Entity)
 	// ViewEntityAction views an action performed by an entity. Available actions may be found in the `action`
 	// package, and include things such as swinging an arm.
 	ViewEntityAction(e Entity, a EntityAction)
@@ -64,8 +68,9 @@ type Viewer interface {
 	ViewEmote(e Entity, emote uuid.UUID)
 	// ViewSkin views the current skin of a player.
 	ViewSkin(e Entity)
-	// ViewWorldSpawn views the current spawn location of the world.
-	ViewWorldSpawn(pos cube.Pos)
+	// ViewWorldSpawn views the current spawn location of the world, which is in the Dimension passed. Compasses
+	// held by the viewer point to this location.
+	ViewWorldSpawn(pos cube.Pos, dim Dimension)
 	// ViewWeather views the weather of the world, including rain and thunder.
 	ViewWeather(raining, thunder bool)
 }
@@ -87,6 +92,8 @@ func (NopViewer) ViewChunk(ChunkPos, *chunk.Chunk, map[cube.Pos]Block)       {}
 func (NopViewer) ViewTime(int)                                               {}
 func (NopViewer) ViewEntityItems(Entity)                                     {}
 func (NopViewer) ViewEntityArmour(Entity)                                    {}
+func (NopViewer) ViewEntityMount(Entity, Entity)                             {}
+func (NopViewer) ViewEntityDismount(Entity, Entity)                          {}
 func (NopViewer) ViewEntityAction(Entity, EntityAction)                      {}
 func (NopViewer) ViewEntityState(Entity)                                     {}
 func (NopViewer) ViewEntityAnimation(Entity, string)                         {}
@@ -96,7 +103,7 @@ func (NopViewer) ViewBlockUpdate(cube.Pos, Block, int)                       {}
 func (NopViewer) ViewBlockAction(cube.Pos, BlockAction)                      {}
 func (NopViewer) ViewEmote(Entity, uuid.UUID)                                {}
 func (NopViewer) ViewSkin(Entity)                                            {}
-func (NopViewer) ViewWorldSpawn(cube.Pos)                                    {}
+func (NopViewer) ViewWorldSpawn(cube.Pos, Dimension)                         {}
 func (NopViewer) ViewWeather(bool, bool)                                     {}
 func (NopViewer) ViewFurnaceUpdate(time.Duration, time.Duration, time.Duration, time.Duration, time.Duration, time.Duration) {
 }
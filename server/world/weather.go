@@ -154,6 +154,40 @@ func (w weather) enableWeatherCycle(v bool) {
 	w.w.set.WeatherCycle = v
 }
 
+// tickPrecipitation performs a precipitation tick in a random column of the chunk at the ChunkPos passed. In cold
+// biomes, still water at the surface of the column freezes to ice at night, and snow layers form on the surface
+// while it is snowing.
+func (w weather) tickPrecipitation(c ChunkPos) {
+	v := w.w.r.Int31()
+	x, z := int(c[0]<<4)+int(v&0xf), int(c[1]<<4)+int((v>>8)&0xf)
+	pos := cube.Pos{x, w.w.HighestBlock(x, z), z}
+	if w.w.Temperature(pos) > 0.15 {
+		return
+	}
+	if t := w.w.Time() % 24000; t >= 13000 && t < 23000 {
+		if l, ok := w.w.Block(pos).(Liquid); ok && l.LiquidType() == "water" && l.LiquidDepth() == 8 && !l.LiquidFalling() {
+			if ice, ok := BlockByName("minecraft:ice", nil); ok {
+				w.w.SetBlock(pos, ice, nil)
+			}
+			return
+		}
+	}
+	above := pos.Side(cube.FaceUp)
+	if !w.SnowingAt(above) {
+		return
+	}
+	if s, ok := w.w.Block(pos).(SnowAccumulator); ok {
+		s.AccumulateSnow(pos, w.w)
+		return
+	}
+	if BlockRuntimeID(w.w.Block(above)) != airRID || !w.w.Block(pos).Model().FaceSolid(pos, cube.FaceUp, w.w) {
+		return
+	}
+	if snow, ok := BlockByName("minecraft:snow_layer", map[string]any{"height": int32(0), "covered_bit": false}); ok {
+		w.w.SetBlock(above, snow, nil)
+	}
+}
+
 // tickLightning iterates over all loaded chunks in the World, striking lightning in each one with a 1/100,000 chance.
 func (w weather) tickLightning() {
 	w.w.chunkMu.Lock()
@@ -3,6 +3,7 @@ package world
 import (
 	"errors"
 	"github.com/df-mc/goleveldb/leveldb"
+	"math"
 	"math/rand"
 	"sync"
 	"time"
@@ -32,11 +33,16 @@ type World struct {
 
 	o sync.Once
 
-	set     *Settings
-	handler atomic.Value[Handler]
+	set       *Settings
+	handler   atomic.Value[Handler]
+	protector atomic.Value[Protector]
 
 	weather
 	ticker
+	mobSpawner *mobSpawner
+	// sleepTicks is the amount of ticks that all Sleepers in the World have been sleeping for. It is only
+	// accessed from the tick loop.
+	sleepTicks int64
 
 	lastPos   ChunkPos
 	lastChunk *Column
@@ -53,9 +59,15 @@ type World struct {
 	// entities holds a map of entities currently loaded and the last ChunkPos that the Entity was in.
 	// These are tracked so that a call to RemoveEntity can find the correct entity.
 	entities map[Entity]ChunkPos
+	// entityGrid is a spatial hash of all entities in the World, used to quickly find entities within a BBox.
+	entityGrid *entityGrid
 
 	r *rand.Rand
 
+	execMu sync.Mutex
+	// queue holds functions passed to Exec that are run at the start of the next tick.
+	queue []func()
+
 	updateMu sync.Mutex
 	// scheduledUpdates is a map of tick time values indexed by the block position at which an update is
 	// scheduled. If the current tick exceeds the tick value passed, the block update will be performed
@@ -65,6 +77,8 @@ type World struct {
 
 	viewersMu sync.Mutex
 	viewers   map[*Loader]Viewer
+
+	tickStats tickStats
 }
 
 // New creates a new initialised world. The world may be used right away, but it will not be saved or loaded
@@ -241,10 +255,7 @@ func (w *World) SetBlock(pos cube.Pos, b Block, opts *SetOpts) {
 
 	rid := BlockRuntimeID(b)
 
-	var before uint32
-	if rid != airRID && !opts.DisableLiquidDisplacement {
-		before = c.Block(x, y, z, 0)
-	}
+	before := c.Block(x, y, z, 0)
 
 	c.modified = true
 	c.SetBlock(x, y, z, 0, rid)
@@ -256,43 +267,65 @@ func (w *World) SetBlock(pos cube.Pos, b Block, opts *SetOpts) {
 
 	viewers := slices.Clone(c.viewers)
 
+	var secondLayer Block
 	if !opts.DisableLiquidDisplacement {
-		var secondLayer Block
-
-		if rid == airRID {
-			if li := c.Block(x, y, z, 1); li != airRID {
-				c.SetBlock(x, y, z, 0, li)
-				c.SetBlock(x, y, z, 1, airRID)
-				secondLayer = air()
-				b, _ = BlockByRuntimeID(li)
-			}
-		} else if liquidDisplacingBlocks[rid] && liquidBlocks[before] {
-			l, _ := BlockByRuntimeID(before)
-			if b.(LiquidDisplacer).CanDisplace(l.(Liquid)) {
-				c.SetBlock(x, y, z, 1, before)
-				secondLayer = l
-			}
-		}
-		c.Unlock()
+		b, secondLayer = displaceLiquid(c.Chunk, x, y, z, before, rid, b)
+	}
+	// The light is updated based on the block that ends up in the first layer, which may be a liquid that was
+	// moved there from the second layer.
+	after := c.Block(x, y, z, 0)
+	updateLight := lightChanged(before, after)
+	c.Unlock()
 
-		if secondLayer != nil {
-			for _, viewer := range viewers {
-				viewer.ViewBlockUpdate(pos, secondLayer, 1)
-			}
+	if secondLayer != nil {
+		for _, viewer := range viewers {
+			viewer.ViewBlockUpdate(pos, secondLayer, 1)
 		}
-	} else {
-		c.Unlock()
 	}
 
 	for _, viewer := range viewers {
 		viewer.ViewBlockUpdate(pos, b, 0)
 	}
+	if updateLight {
+		w.updateLight(pos)
+	}
 
 	if !opts.DisableBlockUpdates {
 		w.doBlockUpdatesAround(pos)
 	}
 }
 
+// displaceLiquid moves liquid between the layers of the chunk passed after the block at x, y and z was changed from
+// the runtime ID before to the block b with runtime ID rid. If b is air, a liquid on the second layer is moved to
+// the first. If b is able to displace the liquid it replaced, that liquid is moved to the second layer. The block
+// that ends up on the first layer is returned, together with the block now on the second layer, or nil if the
+// second layer did not change.
+func displaceLiquid(c *chunk.Chunk, x uint8, y int16, z uint8, before, rid uint32, b Block) (Block, Block) {
+	if rid == airRID {
+		if li := c.Block(x, y, z, 1); li != airRID {
+			c.SetBlock(x, y, z, 0, li)
+			c.SetBlock(x, y, z, 1, airRID)
+			l, _ := BlockByRuntimeID(li)
+			return l, air()
+		}
+	} else if liquidDisplacingBlocks[rid] && liquidBlocks[before] {
+		l, _ := BlockByRuntimeID(before)
+		if b.(LiquidDisplacer).CanDisplace(l.(Liquid)) {
+			c.SetBlock(x, y, z, 1, before)
+			return b, l
+		}
+	} else if li := c.Block(x, y, z, 1); liquidBlocks[li] && !liquidBlocks[rid] {
+		// A liquid was displaced by the block previously here. If the new block cannot hold it, such as when a
+		// slab is turned into a double slab, the liquid is removed.
+		l, _ := BlockByRuntimeID(li)
+		if displacer, ok := b.(LiquidDisplacer); !ok || !displacer.CanDisplace(l.(Liquid)) {
+			c.SetBlock(x, y, z, 1, airRID)
+			return b, air()
+		}
+	}
+	return b, nil
+}
+
 // SetBiome sets the biome at the position passed. If a chunk is not yet loaded at that position, the chunk is
 // first loaded or generated if it could not be found in the world save.
 func (w *World) SetBiome(pos cube.Pos, b Biome) {
@@ -336,6 +369,9 @@ func (w *World) BuildStructure(pos cube.Pos, s Structure) {
 				return w.Block(actual)
 			}
 			baseX, baseZ := chunkX<<4, chunkZ<<4
+			// lightUpdates holds all positions in the chunk at which a block was placed that changes the light
+			// in the chunk.
+			var lightUpdates []cube.Pos
 			subs := c.Sub()
 			for i, sub := range subs {
 				baseY := (i + (w.Range()[0] >> 4)) << 4
@@ -368,9 +404,12 @@ func (w *World) BuildStructure(pos cube.Pos, s Structure) {
 							b, liq := s.At(xOffset-pos[0], yOffset-pos[1], zOffset-pos[2], f)
 							if b != nil {
 								rid := BlockRuntimeID(b)
+								nbtPos := cube.Pos{xOffset, yOffset, zOffset}
+								if lightChanged(sub.Block(uint8(xOffset), uint8(yOffset), uint8(zOffset), 0), rid) {
+									lightUpdates = append(lightUpdates, nbtPos)
+								}
 								sub.SetBlock(uint8(xOffset), uint8(yOffset), uint8(zOffset), 0, rid)
 
-								nbtPos := cube.Pos{xOffset, yOffset, zOffset}
 								if nbtBlocks[rid] {
 									c.BlockEntities[nbtPos] = b
 								} else {
@@ -395,6 +434,10 @@ func (w *World) BuildStructure(pos cube.Pos, s Structure) {
 				viewer.ViewChunk(chunkPos, c.Chunk, c.BlockEntities)
 			}
 			c.Unlock()
+
+			if len(lightUpdates) != 0 {
+				w.updateLightIn(chunkPos, lightUpdates...)
+			}
 		}
 	}
 }
@@ -655,6 +698,67 @@ func (w *World) PlaySound(pos mgl64.Vec3, s Sound) {
 	}
 }
 
+// PlayEntityAction shows the EntityAction passed, such as an animation of the entity swinging its arm, being hurt
+// or dying, to all viewers of the Entity.
+func (w *World) PlayEntityAction(e Entity, a EntityAction) {
+	for _, viewer := range w.Viewers(e.Position()) {
+		viewer.ViewEntityAction(e, a)
+	}
+}
+
+// AddParticleWithin spawns a particle at a given position in the world like AddParticle, but only shows it to
+// viewers that are within the radius in blocks passed of the position.
+func (w *World) AddParticleWithin(pos mgl64.Vec3, p Particle, radius float64) {
+	if w == nil {
+		return
+	}
+	p.Spawn(w, pos)
+	for _, viewer := range w.viewersWithin(pos, radius) {
+		viewer.ViewParticle(pos, p)
+	}
+}
+
+// PlaySoundWithin plays a sound at a specific position in the world like PlaySound, but only to viewers that are
+// within the radius in blocks passed of the position.
+func (w *World) PlaySoundWithin(pos mgl64.Vec3, s Sound, radius float64) {
+	ctx := event.C()
+	if w.Handler().HandleSound(ctx, s, pos); ctx.Cancelled() {
+		return
+	}
+	for _, viewer := range w.viewersWithin(pos, radius) {
+		viewer.ViewSound(pos, s)
+	}
+}
+
+// loaderPrecision is the maximum horizontal distance in blocks between a Loader and the centre of the chunk that
+// it is in. It is used as a margin when checking the distance of a Loader to a position.
+var loaderPrecision = math.Sqrt(8*8 + 8*8)
+
+// viewersWithin returns the viewers of the position passed of which the Loader is within the radius passed of
+// the position. Loaders only track the chunk they are in, so the horizontal distance to the centre of that chunk
+// is used, allowing a margin of loaderPrecision blocks.
+func (w *World) viewersWithin(pos mgl64.Vec3, radius float64) []Viewer {
+	if w == nil {
+		return nil
+	}
+	c, ok := w.chunkFromCache(chunkPosFromVec3(pos))
+	if !ok {
+		return nil
+	}
+	viewers, loaders := slices.Clone(c.viewers), slices.Clone(c.loaders)
+	c.Unlock()
+
+	filtered := viewers[:0]
+	for i, l := range loaders {
+		chunkPos := l.position()
+		centre := mgl64.Vec2{float64(chunkPos[0])*16 + 8, float64(chunkPos[1])*16 + 8}
+		if centre.Sub(mgl64.Vec2{pos[0], pos[2]}).Len() <= radius+loaderPrecision {
+			filtered = append(filtered, viewers[i])
+		}
+	}
+	return filtered
+}
+
 var (
 	worldsMu sync.RWMutex
 	// entityWorlds holds a list of all entities added to a world. It may be used to look up the world that an
@@ -676,13 +780,16 @@ func (w *World) AddEntity(e Entity) {
 
 	add(e, w)
 
-	chunkPos := chunkPosFromVec3(e.Position())
+	pos := e.Position()
+	chunkPos := chunkPosFromVec3(pos)
 	w.entityMu.Lock()
 	w.entities[e] = chunkPos
 	w.entityMu.Unlock()
+	w.entityGrid.add(e, pos)
 
 	c := w.chunk(chunkPos)
 	c.Entities = append(c.Entities, e)
+	c.modified = true
 	viewers := slices.Clone(c.viewers)
 	c.Unlock()
 
@@ -718,6 +825,7 @@ func (w *World) RemoveEntity(e Entity) {
 		// The entity currently isn't in this world.
 		return
 	}
+	w.entityGrid.remove(e)
 
 	w.Handler().HandleEntityDespawn(e)
 
@@ -731,6 +839,7 @@ func (w *World) RemoveEntity(e Entity) {
 		return
 	}
 	c.Entities = sliceutil.DeleteVal(c.Entities, e)
+	c.modified = true
 	viewers := slices.Clone(c.viewers)
 	c.Unlock()
 
@@ -743,39 +852,14 @@ func (w *World) RemoveEntity(e Entity) {
 	}
 }
 
-// EntitiesWithin does a lookup through the entities in the chunks touched by the BBox passed, returning all
-// those which are contained within the BBox when it comes to their position.
+// EntitiesWithin does a lookup through the entities near the BBox passed, returning all those which are
+// contained within the BBox when it comes to their position. Entities for which ignored returns true are not
+// returned. ignored may be nil.
 func (w *World) EntitiesWithin(box cube.BBox, ignored func(Entity) bool) []Entity {
 	if w == nil {
 		return nil
 	}
-	// Make an estimate of 16 entities on average.
-	m := make([]Entity, 0, 16)
-
-	minPos, maxPos := chunkPosFromVec3(box.Min()), chunkPosFromVec3(box.Max())
-
-	for x := minPos[0]; x <= maxPos[0]; x++ {
-		for z := minPos[1]; z <= maxPos[1]; z++ {
-			c, ok := w.chunkFromCache(ChunkPos{x, z})
-			if !ok {
-				// The chunk wasn't loaded, so there are no entities here.
-				continue
-			}
-			entities := slices.Clone(c.Entities)
-			c.Unlock()
-
-			for _, entity := range entities {
-				if ignored != nil && ignored(entity) {
-					continue
-				}
-				if box.Vec3Within(entity.Position()) {
-					// The entity position was within the BBox, so we add it to the slice to return.
-					m = append(m, entity)
-				}
-			}
-		}
-	}
-	return m
+	return w.entityGrid.within(box, ignored)
 }
 
 // Entities returns a list of all entities currently added to the World.
@@ -792,6 +876,26 @@ func (w *World) Entities() []Entity {
 	return m
 }
 
+// EntityCount returns the amount of entities currently added to the World.
+func (w *World) EntityCount() int {
+	if w == nil {
+		return 0
+	}
+	w.entityMu.RLock()
+	defer w.entityMu.RUnlock()
+	return len(w.entities)
+}
+
+// ChunkCount returns the amount of chunks currently loaded in the World.
+func (w *World) ChunkCount() int {
+	if w == nil {
+		return 0
+	}
+	w.chunkMu.Lock()
+	defer w.chunkMu.Unlock()
+	return len(w.chunks)
+}
+
 // OfEntity attempts to return a world that an entity is currently in. If the entity was not currently added
 // to a world, the world returned is nil and the bool returned is false.
 func OfEntity(e Entity) (*World, bool) {
@@ -828,7 +932,7 @@ func (w *World) SetSpawn(pos cube.Pos) {
 
 	viewers, _ := w.allViewers()
 	for _, viewer := range viewers {
-		viewer.ViewWorldSpawn(pos)
+		viewer.ViewWorldSpawn(pos, w.Dimension())
 	}
 }
 
@@ -921,6 +1025,38 @@ func (w *World) SetDifficulty(d Difficulty) {
 	w.set.Difficulty = d
 }
 
+// KeepInventory checks if players in the world keep their inventory and experience when they die.
+func (w *World) KeepInventory() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.KeepInventory
+}
+
+// SetKeepInventory changes if players in the world keep their inventory and experience when they die.
+func (w *World) SetKeepInventory(keep bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.KeepInventory = keep
+}
+
+// Exec schedules a function to be run on the goroutine that ticks the World, at the start of its next tick.
+// Functions are run in the order they were passed to Exec. Exec may be used to run code in sync with the
+// ticking of the World, for example from another goroutine.
+func (w *World) Exec(f func()) {
+	if w == nil {
+		return
+	}
+	w.execMu.Lock()
+	w.queue = append(w.queue, f)
+	w.execMu.Unlock()
+}
+
 // ScheduleBlockUpdate schedules a block update at the position passed after a specific delay. If the block at
 // that position does not handle block updates, nothing will happen.
 func (w *World) ScheduleBlockUpdate(pos cube.Pos, delay time.Duration) {
@@ -978,6 +1114,26 @@ func (w *World) Handle(h Handler) {
 	w.handler.Store(h)
 }
 
+// SetProtector changes the Protector of the World. The Protector is consulted before entities break, place or
+// interact with blocks. Passing nil resets the Protector to NopProtector.
+func (w *World) SetProtector(p Protector) {
+	if w == nil {
+		return
+	}
+	if p == nil {
+		p = NopProtector{}
+	}
+	w.protector.Store(p)
+}
+
+// Protector returns the Protector of the World, as set in the Config or through a call to SetProtector.
+func (w *World) Protector() Protector {
+	if w == nil {
+		return NopProtector{}
+	}
+	return w.protector.Load()
+}
+
 // Viewers returns a list of all viewers viewing the position passed. A viewer will be assumed to be watching
 // if the position is within one of the chunks that the viewer is watching.
 func (w *World) Viewers(pos mgl64.Vec3) (viewers []Viewer) {
@@ -1074,7 +1230,7 @@ func (w *World) addWorldViewer(l *Loader) {
 	raining, thundering := w.set.Raining, w.set.Raining && w.set.Thundering
 	w.set.Unlock()
 	l.viewer.ViewWeather(raining, thundering)
-	l.viewer.ViewWorldSpawn(w.Spawn())
+	l.viewer.ViewWorldSpawn(w.Spawn(), w.Dimension())
 }
 
 // removeWorldViewer removes a viewer from the world. Should only be used while the viewer isn't viewing any chunks.
@@ -1147,6 +1303,7 @@ func (w *World) chunkFromCache(pos ChunkPos) (*Column, bool) {
 	w.chunkMu.Unlock()
 	if ok {
 		c.Lock()
+		c.Decompress()
 	}
 	return c, ok
 }
@@ -1171,6 +1328,7 @@ func (w *World) chunk(pos ChunkPos) *Column {
 		c := w.lastChunk
 		w.chunkMu.Unlock()
 		c.Lock()
+		c.Decompress()
 		return c
 	}
 	c, ok := w.chunks[pos]
@@ -1192,6 +1350,7 @@ func (w *World) chunk(pos ChunkPos) *Column {
 	w.chunkMu.Unlock()
 
 	c.Lock()
+	c.Decompress()
 	return c
 }
 
@@ -1234,6 +1393,10 @@ func (w *World) loadChunk(pos ChunkPos) (*Column, error) {
 		}
 		w.entityMu.Unlock()
 
+		for _, e := range col.Entities {
+			w.entityGrid.add(e, e.Position())
+		}
+
 		col.Lock()
 		w.chunkMu.Unlock()
 		return col, nil
@@ -1286,6 +1449,7 @@ func (w *World) spreadLight(pos ChunkPos) {
 	}
 	for _, neighbour := range chunks {
 		neighbour.Lock()
+		neighbour.Decompress()
 	}
 	// All chunks of the current one are present, so we can spread the light from this chunk
 	// to all chunks.
@@ -1299,16 +1463,60 @@ func (w *World) spreadLight(pos ChunkPos) {
 	}
 }
 
+// lightChanged checks if replacing the block with the runtime ID before with the block with the runtime ID after
+// changes the light around it, either because the blocks emit different light levels or because they filter
+// light differently.
+func lightChanged(before, after uint32) bool {
+	return chunk.LightBlocks[before] != chunk.LightBlocks[after] || chunk.FilteringBlocks[before] != chunk.FilteringBlocks[after]
+}
+
+// updateLight updates the light around the position passed after the block at that position was changed. If not
+// all chunks surrounding the position are loaded, the light is not updated, as it will be calculated once these
+// chunks are loaded.
+func (w *World) updateLight(pos cube.Pos) {
+	w.updateLightIn(chunkPosFromBlockPos(pos), pos)
+}
+
+// updateLightIn updates the light at all positions passed, which must all be within the chunk at the ChunkPos
+// centre. The chunk and all its neighbours are locked only once for all positions.
+func (w *World) updateLightIn(centre ChunkPos, positions ...cube.Pos) {
+	chunks := make([]*Column, 0, 9)
+
+	w.chunkMu.Lock()
+	for z := int32(-1); z <= 1; z++ {
+		for x := int32(-1); x <= 1; x++ {
+			neighbour, ok := w.chunks[ChunkPos{centre[0] + x, centre[1] + z}]
+			if !ok {
+				w.chunkMu.Unlock()
+				return
+			}
+			chunks = append(chunks, neighbour)
+		}
+	}
+	for _, neighbour := range chunks {
+		neighbour.Lock()
+		neighbour.Decompress()
+	}
+	w.chunkMu.Unlock()
+
+	c := make([]*chunk.Chunk, 9)
+	for i := range chunks {
+		c[i] = chunks[i].Chunk
+	}
+	area := chunk.LightArea(c, int(centre[0])-1, int(centre[1])-1)
+	for _, pos := range positions {
+		area.Update(pos)
+	}
+	for _, neighbour := range chunks {
+		neighbour.Unlock()
+	}
+}
+
 // saveChunk is called when a chunk is removed from the cache. We first compact the chunk, then we write it to
 // the provider.
 func (w *World) saveChunk(pos ChunkPos, c *Column) {
 	c.Lock()
-	if !w.conf.ReadOnly && (len(c.BlockEntities) > 0 || len(c.Entities) > 0 || c.modified) {
-		c.Compact()
-		if err := w.provider().StoreColumn(pos, w.conf.Dim, c); err != nil {
-			w.conf.Log.Errorf("save chunk: %v", err)
-		}
-	}
+	w.storeChunk(pos, c)
 	ent := c.Entities
 	c.Entities = nil
 	c.Unlock()
@@ -1318,16 +1526,77 @@ func (w *World) saveChunk(pos ChunkPos, c *Column) {
 	}
 }
 
+// storeChunk stores the Column passed to the Provider if it was modified since it was last stored. The Column
+// must be locked when calling storeChunk.
+func (w *World) storeChunk(pos ChunkPos, c *Column) {
+	if w.conf.ReadOnly || !c.modified {
+		return
+	}
+	c.Decompress()
+	c.Compact()
+	if err := w.provider().StoreColumn(pos, w.conf.Dim, c); err != nil {
+		w.conf.Log.Errorf("save chunk: %v", err)
+		return
+	}
+	c.modified = false
+}
+
+// Save saves all chunks currently loaded that hold data that must be saved to the Provider, along with the
+// settings of the World. Unlike Close, Save does not unload any chunks and the World may continue to be used
+// normally. Save does nothing if the World is read-only.
+func (w *World) Save() {
+	if w == nil || w.conf.ReadOnly {
+		return
+	}
+	w.chunkMu.Lock()
+	toSave := maps.Clone(w.chunks)
+	w.chunkMu.Unlock()
+
+	for pos, c := range toSave {
+		c.Lock()
+		w.storeChunk(pos, c)
+		c.Unlock()
+	}
+	if w.advance {
+		w.set.Lock()
+		w.provider().SaveSettings(w.set)
+		w.set.Unlock()
+	}
+}
+
+// autoSave runs until the World is closed, saving the World every time the SaveInterval in the Config passes.
+func (w *World) autoSave() {
+	t := time.NewTicker(w.conf.SaveInterval)
+	defer t.Stop()
+
+	w.running.Add(1)
+	for {
+		select {
+		case <-t.C:
+			w.conf.Log.Debugf("Saving world...")
+			w.Save()
+		case <-w.closing:
+			w.running.Done()
+			return
+		}
+	}
+}
+
 // chunkCacheJanitor runs until the world is running, cleaning chunks that are no longer in use from the cache.
+// Chunks without viewers are compressed every minute and are removed from the cache every five minutes.
 func (w *World) chunkCacheJanitor() {
-	t := time.NewTicker(time.Minute * 5)
+	t := time.NewTicker(time.Minute)
 	defer t.Stop()
 
 	w.running.Add(1)
 	chunksToRemove := map[ChunkPos]*Column{}
-	for {
+	for i := 1; ; i++ {
 		select {
 		case <-t.C:
+			if i%5 != 0 {
+				w.compressColdChunks()
+				continue
+			}
 			w.chunkMu.Lock()
 			for pos, c := range w.chunks {
 				c.Lock()
@@ -1354,10 +1623,29 @@ func (w *World) chunkCacheJanitor() {
 	}
 }
 
+// compressColdChunks compacts and compresses all chunks in the cache that have no viewers, to reduce their memory
+// usage. A compressed chunk is decompressed as soon as it is accessed again.
+func (w *World) compressColdChunks() {
+	w.chunkMu.Lock()
+	cols := maps.Values(w.chunks)
+	w.chunkMu.Unlock()
+
+	for _, c := range cols {
+		c.Lock()
+		if len(c.viewers) == 0 && !c.Compressed() {
+			c.Compact()
+			c.Compress()
+		}
+		c.Unlock()
+	}
+}
+
 // Column represents the data of a chunk including the block entities and loaders. This data is protected
 // by the mutex present in the chunk.Chunk held.
 type Column struct {
 	sync.Mutex
+	// modified is true if the blocks, entities or block entities of the Column were changed since it was last
+	// stored to the Provider. Only modified columns are stored.
 	modified bool
 
 	*chunk.Chunk